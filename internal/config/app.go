@@ -16,12 +16,188 @@ type DatabaseConfig struct {
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
 
+// MQTTConfig holds the settings of a single broker with one subscription.
+// If Brokers is set, the single broker settings are ignored.
 type MQTTConfig struct {
-	Broker   string `mapstructure:"broker"`
-	ClientID string `mapstructure:"client_id"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	Topic    string `mapstructure:"topic"`
+	Broker         string             `mapstructure:"broker"`
+	ClientID       string             `mapstructure:"client_id"`
+	Username       string             `mapstructure:"username"`
+	Password       string             `mapstructure:"password"`
+	Topic          string             `mapstructure:"topic"`
+	Decoder        string             `mapstructure:"decoder"`
+	TLS            MQTTTLSConfig      `mapstructure:"tls"`
+	Downlink       MQTTDownlinkConfig `mapstructure:"downlink"`
+	Brokers        []MQTTBrokerConfig `mapstructure:"brokers"`
+	DownlinkBroker string             `mapstructure:"downlink_broker"`
+	DownlinkExpiry time.Duration      `mapstructure:"downlink_expiry"`
+}
+
+type MQTTBrokerConfig struct {
+	Name          string                   `mapstructure:"name"`
+	Broker        string                   `mapstructure:"broker"`
+	ClientID      string                   `mapstructure:"client_id"`
+	Username      string                   `mapstructure:"username"`
+	Password      string                   `mapstructure:"password"`
+	TLS           MQTTTLSConfig            `mapstructure:"tls"`
+	Subscriptions []MQTTSubscriptionConfig `mapstructure:"subscriptions"`
+	Downlink      MQTTDownlinkConfig       `mapstructure:"downlink"`
+}
+
+type MQTTSubscriptionConfig struct {
+	Topic   string `mapstructure:"topic"`
+	Decoder string `mapstructure:"decoder"`
+	QoS     *byte  `mapstructure:"qos"`
+}
+
+type MQTTTLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CAFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+type MQTTDownlinkConfig struct {
+	Topic    string        `mapstructure:"topic"`
+	AckTopic string        `mapstructure:"ack_topic"`
+	FPort    uint8         `mapstructure:"f_port"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type SensorConfig struct {
+	OfflineThreshold time.Duration             `mapstructure:"offline_threshold"`
+	Battery          SensorBatteryConfig       `mapstructure:"battery"`
+	Assignment       SensorAssignmentConfig    `mapstructure:"assignment"`
+	Retention        SensorRetentionConfig     `mapstructure:"retention"`
+	LocationDrift    SensorLocationDriftConfig `mapstructure:"location_drift"`
+	Anomaly          SensorAnomalyConfig       `mapstructure:"anomaly"`
+	Ingest           SensorIngestConfig        `mapstructure:"ingest"`
+}
+
+type SensorBatteryConfig struct {
+	Threshold          float64       `mapstructure:"threshold"`
+	EmptyLevel         float64       `mapstructure:"empty_level"`
+	TrendWindow        time.Duration `mapstructure:"trend_window"`
+	MaintenanceHorizon time.Duration `mapstructure:"maintenance_horizon"`
+}
+
+type SensorAssignmentConfig struct {
+	Radius          float64 `mapstructure:"radius"`
+	AmbiguityMargin float64 `mapstructure:"ambiguity_margin"`
+}
+
+type SensorRetentionConfig struct {
+	RawData    time.Duration `mapstructure:"raw_data"`
+	HourlyData time.Duration `mapstructure:"hourly_data"`
+}
+
+type SensorLocationDriftConfig struct {
+	MaxDistance float64 `mapstructure:"max_distance"`
+}
+
+type SensorAnomalyConfig struct {
+	MaxCentibar    int           `mapstructure:"max_centibar"`
+	SpikeThreshold int           `mapstructure:"spike_threshold"`
+	FlatlineCount  int           `mapstructure:"flatline_count"`
+	HistoryWindow  time.Duration `mapstructure:"history_window"`
+}
+
+type SensorIngestConfig struct {
+	Secret             string        `mapstructure:"secret"`
+	Decoder            string        `mapstructure:"decoder"`
+	SignatureTolerance time.Duration `mapstructure:"signature_tolerance"`
+}
+
+// SimulatorConfig configures the sensor simulator that is started with the simulate command
+type SimulatorConfig struct {
+	Target   string                  `mapstructure:"target"`
+	Broker   string                  `mapstructure:"broker"`
+	ClientID string                  `mapstructure:"client_id"`
+	Username string                  `mapstructure:"username"`
+	Password string                  `mapstructure:"password"`
+	Topic    string                  `mapstructure:"topic"`
+	Interval time.Duration           `mapstructure:"interval"`
+	Speed    float64                 `mapstructure:"speed"`
+	Duration time.Duration           `mapstructure:"duration"`
+	Seed     uint64                  `mapstructure:"seed"`
+	Sensors  []SimulatorSensorConfig `mapstructure:"sensors"`
+}
+
+// SimulatorSensorConfig describes a simulated sensor. Values that are not set are taken from the scenario.
+type SimulatorSensorConfig struct {
+	ID           string        `mapstructure:"id"`
+	Latitude     float64       `mapstructure:"latitude"`
+	Longitude    float64       `mapstructure:"longitude"`
+	Scenario     string        `mapstructure:"scenario"`
+	Centibar     float64       `mapstructure:"centibar"`
+	DryingRate   float64       `mapstructure:"drying_rate"`
+	Battery      float64       `mapstructure:"battery"`
+	BatteryDrain float64       `mapstructure:"battery_drain"`
+	RainInterval time.Duration `mapstructure:"rain_interval"`
+	RainAmount   float64       `mapstructure:"rain_amount"`
+}
+
+// WateringStatusConfig holds the centibar thresholds used to calculate the watering status from the
+// watermark probes. Without thresholds the defaults for probes at 30, 60 and 90 cm are used. The
+// thresholds of an activated watering rule set take precedence over the configured ones.
+type WateringStatusConfig struct {
+	Thresholds []WateringThresholdConfig `mapstructure:"thresholds"`
+	// SoilFactors scale the thresholds per soil condition of the tree cluster, unset soil conditions use the defaults
+	SoilFactors map[string]float64 `mapstructure:"soil_factors"`
+	// TrendWindow is the time span of sensor data the drying trend of a tree cluster is fitted to
+	TrendWindow time.Duration   `mapstructure:"trend_window"`
+	Inference   InferenceConfig `mapstructure:"inference"`
+}
+
+// WateringThresholdConfig applies to trees whose age in years is between MinAge and MaxAge. A probe is
+// evaluated with the threshold of the nearest configured depth.
+type WateringThresholdConfig struct {
+	MinAge   int32 `mapstructure:"min_age"`
+	MaxAge   int32 `mapstructure:"max_age"`
+	Depth    int   `mapstructure:"depth"`
+	Moderate int   `mapstructure:"moderate"`
+	Bad      int   `mapstructure:"bad"`
+}
+
+// WeatherConfig configures the weather provider the precipitation, temperature and ET0 of the regions are
+// fetched from. The weather feeds a water balance that adjusts the watering status of tree clusters without
+// current sensor data and flags tree clusters that turn bad within the forecast horizon.
+type WeatherConfig struct {
+	Enable       bool                   `mapstructure:"enable"`
+	Provider     string                 `mapstructure:"provider"`
+	Interval     time.Duration          `mapstructure:"interval"`
+	PastDays     int                    `mapstructure:"past_days"`
+	ForecastDays int                    `mapstructure:"forecast_days"`
+	OpenMeteo    WeatherOpenMeteoConfig `mapstructure:"open_meteo"`
+	File         WeatherFileConfig      `mapstructure:"file"`
+	WaterBalance WaterBalanceConfig     `mapstructure:"water_balance"`
+}
+
+type WeatherOpenMeteoConfig struct {
+	Host string `mapstructure:"host"`
+}
+
+// WeatherFileConfig reads the weather from a local file or an http url, e.g. as stand-in for a weather service
+type WeatherFileConfig struct {
+	Source string `mapstructure:"source"`
+}
+
+type WaterBalanceConfig struct {
+	CropCoefficient float64       `mapstructure:"crop_coefficient"`
+	RainEfficiency  float64       `mapstructure:"rain_efficiency"`
+	StaleAfter      time.Duration `mapstructure:"stale_after"`
+}
+
+// InferenceConfig estimates the watering status of tree clusters without sensor data every Interval from similar
+// tree clusters with sensor data not older than MaxDataAge. Similar tree clusters lie within Radius in meters, have
+// the same soil condition and trees planted at most MaxAgeDifference years apart on average.
+type InferenceConfig struct {
+	Enable           bool          `mapstructure:"enable"`
+	Interval         time.Duration `mapstructure:"interval"`
+	MaxDataAge       time.Duration `mapstructure:"max_data_age"`
+	Radius           float64       `mapstructure:"radius"`
+	MaxAgeDifference float64       `mapstructure:"max_age_difference"`
+	MinNeighbors     int           `mapstructure:"min_neighbors"`
 }
 
 type LogConfig struct {
@@ -89,12 +265,16 @@ type IdentityAuthConfig struct {
 }
 
 type Config struct {
-	Server       ServerConfig       `mapstructure:"server"`
-	Dashboard    DashboardConfig    `mapstructure:"dashboard"`
-	Routing      RoutingConfig      `mapstructure:"routing"`
-	S3           S3Config           `mapstructure:"s3"`
-	MQTT         MQTTConfig         `mapstructure:"mqtt"`
-	IdentityAuth IdentityAuthConfig `mapstructure:"auth"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Dashboard      DashboardConfig      `mapstructure:"dashboard"`
+	Routing        RoutingConfig        `mapstructure:"routing"`
+	S3             S3Config             `mapstructure:"s3"`
+	MQTT           MQTTConfig           `mapstructure:"mqtt"`
+	Sensor         SensorConfig         `mapstructure:"sensor"`
+	WateringStatus WateringStatusConfig `mapstructure:"watering_status"`
+	Weather        WeatherConfig        `mapstructure:"weather"`
+	IdentityAuth   IdentityAuthConfig   `mapstructure:"auth"`
+	Simulator      SimulatorConfig      `mapstructure:"simulator"`
 }
 
 func InitConfig() (*Config, error) {
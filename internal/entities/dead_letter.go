@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+type DeadLetterReason string
+
+const (
+	DeadLetterReasonDecode  DeadLetterReason = "decode"
+	DeadLetterReasonProcess DeadLetterReason = "process"
+)
+
+type DeadLetterStatus string
+
+const (
+	DeadLetterStatusPending   DeadLetterStatus = "pending"
+	DeadLetterStatusReplaying DeadLetterStatus = "replaying"
+	DeadLetterStatusReplayed  DeadLetterStatus = "replayed"
+)
+
+// DeadLetter is a sensor message that could not be decoded or processed.
+// The raw payload is kept to replay the message later on.
+type DeadLetter struct {
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Topic          string
+	Decoder        string
+	Payload        []byte
+	Reason         DeadLetterReason
+	Error          string
+	Status         DeadLetterStatus
+	ReplayCount    int32
+	LastReplayedAt *time.Time
+}
+
+type DeadLetterCreate struct {
+	Topic   string
+	Decoder string `validate:"required"`
+	Payload []byte
+	Reason  DeadLetterReason `validate:"oneof=decode process"`
+	Error   string           `validate:"required"`
+}
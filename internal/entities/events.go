@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type EventType string
 
 type Event interface {
@@ -7,12 +9,16 @@ type Event interface {
 }
 
 const (
-	EventTypeUpdateTree         EventType = "update tree"
-	EventTypeCreateTree         EventType = "create tree"
-	EventTypeDeleteTree         EventType = "delete tree"
-	EventTypeUpdateTreeCluster  EventType = "update tree cluster"
-	EventTypeNewSensorData      EventType = "receive sensor data"
-	EventTypeUpdateWateringPlan EventType = "update watering plan"
+	EventTypeUpdateTree          EventType = "update tree"
+	EventTypeCreateTree          EventType = "create tree"
+	EventTypeDeleteTree          EventType = "delete tree"
+	EventTypeUpdateTreeCluster   EventType = "update tree cluster"
+	EventTypeNewSensorData       EventType = "receive sensor data"
+	EventTypeUpdateWateringPlan  EventType = "update watering plan"
+	EventTypeSensorBatteryLow    EventType = "sensor battery low"
+	EventTypeUpdateSensorStatus  EventType = "update sensor status"
+	EventTypeCreateSensor        EventType = "create sensor"
+	EventTypeSensorLocationDrift EventType = "sensor location drift"
 )
 
 type BasicEvent struct {
@@ -100,3 +106,67 @@ func NewEventUpdateWateringPlan(prev, newWp *WateringPlan) EventUpdateWateringPl
 		New:        newWp,
 	}
 }
+
+type EventSensorBatteryLow struct {
+	BasicEvent
+	Sensor    *Sensor
+	Prev      *float64
+	Level     float64
+	Threshold float64
+}
+
+func NewEventSensorBatteryLow(sensor *Sensor, prev *float64, level, threshold float64) EventSensorBatteryLow {
+	return EventSensorBatteryLow{
+		BasicEvent: BasicEvent{eventType: EventTypeSensorBatteryLow},
+		Sensor:     sensor,
+		Prev:       prev,
+		Level:      level,
+		Threshold:  threshold,
+	}
+}
+
+type EventUpdateSensorStatus struct {
+	BasicEvent
+	SensorID   string
+	Prev       SensorStatus
+	New        SensorStatus
+	LastSeenAt *time.Time
+}
+
+func NewEventUpdateSensorStatus(sensorID string, prev, newStatus SensorStatus, lastSeenAt *time.Time) EventUpdateSensorStatus {
+	return EventUpdateSensorStatus{
+		BasicEvent: BasicEvent{eventType: EventTypeUpdateSensorStatus},
+		SensorID:   sensorID,
+		Prev:       prev,
+		New:        newStatus,
+		LastSeenAt: lastSeenAt,
+	}
+}
+
+type EventCreateSensor struct {
+	BasicEvent
+	New *Sensor
+}
+
+func NewEventCreateSensor(newSensor *Sensor) EventCreateSensor {
+	return EventCreateSensor{
+		BasicEvent: BasicEvent{eventType: EventTypeCreateSensor},
+		New:        newSensor,
+	}
+}
+
+type EventSensorLocationDrift struct {
+	BasicEvent
+	Sensor *Sensor
+	Drift  *SensorLocationDrift
+	TreeID *int32
+}
+
+func NewEventSensorLocationDrift(sensor *Sensor, drift *SensorLocationDrift, treeID *int32) EventSensorLocationDrift {
+	return EventSensorLocationDrift{
+		BasicEvent: BasicEvent{eventType: EventTypeSensorLocationDrift},
+		Sensor:     sensor,
+		Drift:      drift,
+		TreeID:     treeID,
+	}
+}
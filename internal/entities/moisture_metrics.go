@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// MoistureMetrics summarize the latest sensor data of the sensors in a tree cluster. Coverage is the share of
+// the trees with sensor data, LatestMeasuredAt and OldestMeasuredAt are the newest and the oldest of the latest
+// sensor data of the sensors and tell how fresh the metrics are.
+type MoistureMetrics struct {
+	UpdatedAt        time.Time
+	TreeCount        int32
+	SensorCount      int32
+	Coverage         float64
+	LatestMeasuredAt time.Time
+	OldestMeasuredAt time.Time
+	Depths           []*DepthMoisture
+}
+
+// DepthMoisture are the centibar statistics of the probes of all sensors in a tree cluster at one depth
+type DepthMoisture struct {
+	Depth        int
+	SensorCount  int32
+	MinCentibar  float64
+	MeanCentibar float64
+	MaxCentibar  float64
+}
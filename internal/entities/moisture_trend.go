@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// MoistureTrend is the drying trend of a tree cluster at one probe depth, fitted to the sensor data of all
+// sensors in the tree cluster. A positive trend means the soil dries out. ModerateAt and BadAt are the
+// estimated times the depth crosses the moderate and bad threshold, they are nil if the soil is not drying out.
+type MoistureTrend struct {
+	Depth          int
+	Centibar       float64
+	CentibarPerDay float64
+	SampleCount    int32
+	MeasuredAt     time.Time
+	ModerateAt     *time.Time
+	BadAt          *time.Time
+}
@@ -14,4 +14,6 @@ type MqttPayload struct {
 	Latitude    float64 `validate:"omitempty,min=-90,max=90"`
 	Longitude   float64 `validate:"omitempty,min=-180,max=180"`
 	Watermarks  []Watermark
+	// RawWatermarks holds the values as sent by the sensor if a calibration profile was applied to Watermarks
+	RawWatermarks []Watermark
 }
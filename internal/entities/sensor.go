@@ -11,13 +11,42 @@ const (
 )
 
 type Sensor struct {
-	ID         string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Status     SensorStatus
-	LatestData *SensorData
+	ID               string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Status           SensorStatus
+	LatestData       *SensorData
+	Latitude         float64
+	Longitude        float64
+	OfflineThreshold *time.Duration
+	Calibration      *SensorCalibration
+	LocationDrift    *SensorLocationDrift
+	// DecommissionedAt is set when the sensor is retired. Its data is kept, but it does not accept new data.
+	DecommissionedAt   *time.Time
+	DecommissionReason *string
+	// ReplacedBy is the sensor that took over the trees and flowerbeds of the retired sensor
+	ReplacedBy *string
+}
+
+// SensorDecommission retires a sensor and unlinks it from its trees and flowerbeds
+type SensorDecommission struct {
+	Reason string `validate:"max=500"`
+}
+
+// SensorReplace retires a sensor and links its trees and flowerbeds to the replacement.
+// A replacement that does not exist yet is created at the location of the retired sensor.
+type SensorReplace struct {
+	ReplacementID string `validate:"required"`
+	Reason        string `validate:"max=500"`
+}
+
+// SensorLocationDrift describes a reported sensor position that is too far away from the registered location or the linked tree.
+// Distance is given in meters.
+type SensorLocationDrift struct {
 	Latitude   float64
 	Longitude  float64
+	Distance   float64
+	DetectedAt time.Time
 }
 
 type SensorData struct {
@@ -26,19 +55,124 @@ type SensorData struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Data      *MqttPayload
+	// TreeID is the tree the sensor was linked to when the data was measured
+	TreeID *int32
+	// Flagged readings are kept but excluded from the watering status computation
+	Flagged      bool
+	AnomalyScore float64
+	Anomalies    []SensorDataAnomaly
+}
+
+type SensorDataAnomalyType string
+
+const (
+	SensorDataAnomalySpike      SensorDataAnomalyType = "spike"
+	SensorDataAnomalyFlatline   SensorDataAnomalyType = "flatline"
+	SensorDataAnomalyOutOfRange SensorDataAnomalyType = "out_of_range"
+)
+
+// SensorDataAnomaly describes a suspicious watermark reading at the given depth.
+// The score ranges from 0 to 1, higher values are more suspicious.
+type SensorDataAnomaly struct {
+	Type     SensorDataAnomalyType
+	Depth    int
+	Centibar int
+	Score    float64
 }
 
 type SensorCreate struct {
-	ID         string       `validate:"required"`
-	Status     SensorStatus `validate:"oneof=online offline unknown"`
-	LatestData *SensorData
-	Latitude   float64 `validate:"required,max=90,min=-90"`
-	Longitude  float64 `validate:"required,max=180,min=-180"`
+	ID               string       `validate:"required"`
+	Status           SensorStatus `validate:"oneof=online offline unknown"`
+	LatestData       *SensorData
+	Latitude         float64        `validate:"required,max=90,min=-90"`
+	Longitude        float64        `validate:"required,max=180,min=-180"`
+	OfflineThreshold *time.Duration `validate:"omitempty,min=1s"`
 }
 
 type SensorUpdate struct {
-	Status     SensorStatus `validate:"oneof=online offline unknown"`
-	LatestData *SensorData
-	Latitude   float64 `validate:"required,max=90,min=-90"`
-	Longitude  float64 `validate:"required,max=180,min=-180"`
+	Status           SensorStatus `validate:"oneof=online offline unknown"`
+	LatestData       *SensorData
+	Latitude         float64        `validate:"required,max=90,min=-90"`
+	Longitude        float64        `validate:"required,max=180,min=-180"`
+	OfflineThreshold *time.Duration `validate:"omitempty,min=1s"`
+}
+
+type SensorActivity struct {
+	SensorID         string
+	Status           SensorStatus
+	OfflineThreshold *time.Duration
+	LastSeenAt       *time.Time
+}
+
+type SensorImport struct {
+	ID        string  `validate:"required"`
+	Latitude  float64 `validate:"required,max=90,min=-90"`
+	Longitude float64 `validate:"required,max=180,min=-180"`
+	TreeID    *int32
+}
+
+type SensorBattery struct {
+	SensorID         string
+	Level            float64
+	MeasuredAt       time.Time
+	DischargePerDay  float64
+	SampleCount      int32
+	EstimatedEmptyAt *time.Time
+	BelowThreshold   bool
+}
+
+type SensorDataResolution string
+
+const (
+	SensorDataResolutionRaw    SensorDataResolution = "raw"
+	SensorDataResolutionHourly SensorDataResolution = "hourly"
+	SensorDataResolutionDaily  SensorDataResolution = "daily"
+)
+
+type SensorDataHistoryQuery struct {
+	From       time.Time            `validate:"required"`
+	To         time.Time            `validate:"required,gtfield=From"`
+	Resolution SensorDataResolution `validate:"oneof=raw hourly daily"`
+}
+
+type SensorDataExportFormat string
+
+const (
+	SensorDataExportFormatCSV     SensorDataExportFormat = "csv"
+	SensorDataExportFormatParquet SensorDataExportFormat = "parquet"
+)
+
+// SensorDataExportQuery filters the exported sensor data, empty filters match all sensor data in the time range
+type SensorDataExportQuery struct {
+	SensorIDs     []string
+	TreeClusterID *int32
+	RegionID      *int32
+	From          time.Time              `validate:"required"`
+	To            time.Time              `validate:"required,gtfield=From"`
+	Format        SensorDataExportFormat `validate:"oneof=csv parquet"`
+}
+
+// SensorDataExportRow is a sensor reading together with the tree, tree cluster and region the sensor is linked to
+type SensorDataExportRow struct {
+	Data          *SensorData
+	TreeID        *int32
+	TreeClusterID *int32
+	RegionID      *int32
+}
+
+type SensorDataAggregate struct {
+	Timestamp   time.Time
+	SampleCount int32
+	Battery     float64
+	Humidity    float64
+	Temperature float64
+	Watermarks  []WatermarkAggregate
+}
+
+type WatermarkAggregate struct {
+	Depth      int
+	Centibar   float64
+	Resistance float64
+	// SampleCount is the number of readings with a watermark at this depth
+	SampleCount int32
 }
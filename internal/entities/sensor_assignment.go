@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+type SensorAssignmentStatus string
+
+const (
+	SensorAssignmentStatusPending   SensorAssignmentStatus = "pending"
+	SensorAssignmentStatusResolved  SensorAssignmentStatus = "resolved"
+	SensorAssignmentStatusDismissed SensorAssignmentStatus = "dismissed"
+)
+
+// TreeCandidate is an unassigned tree near a sensor together with its distance to the sensor in meters.
+type TreeCandidate struct {
+	TreeID   int32
+	Distance float64
+}
+
+// SensorAssignmentReview is created when a new sensor could not be linked to a tree automatically
+// because several trees are almost equally close. The review is resolved by choosing one of the candidates.
+type SensorAssignmentReview struct {
+	ID         int32
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	SensorID   string
+	Status     SensorAssignmentStatus
+	TreeID     *int32
+	Candidates []*TreeCandidate
+}
+
+type SensorAssignmentResolve struct {
+	TreeID int32 `validate:"required"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// CalibrationPoint maps a measured watermark resistance in ohm to a soil water tension in centibar.
+type CalibrationPoint struct {
+	Resistance int     `validate:"min=0"`
+	Centibar   float64 `validate:"min=0"`
+}
+
+// SensorCalibration is the calibration profile of a sensor. It is applied to every watermark
+// value the sensor sends. If the curve has at least two points, the centibar value is interpolated
+// from the measured resistance. Afterwards the value is compensated by the deviation of the measured
+// temperature from the reference temperature and shifted by the offset.
+type SensorCalibration struct {
+	SensorID               string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	CentibarOffset         float64
+	TemperatureCoefficient float64
+	ReferenceTemperature   float64
+	Curve                  []CalibrationPoint
+}
+
+type SensorCalibrationUpdate struct {
+	CentibarOffset         float64
+	TemperatureCoefficient float64            `validate:"min=-1,max=1"`
+	ReferenceTemperature   float64            `validate:"min=-50,max=100"`
+	Curve                  []CalibrationPoint `validate:"omitempty,min=2,dive"`
+}
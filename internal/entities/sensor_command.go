@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+type SensorCommandType string
+
+const (
+	SensorCommandTypeSetInterval    SensorCommandType = "set_interval"
+	SensorCommandTypeRequestReading SensorCommandType = "request_reading"
+	SensorCommandTypeReboot         SensorCommandType = "reboot"
+)
+
+type SensorCommandStatus string
+
+const (
+	SensorCommandStatusQueued       SensorCommandStatus = "queued"
+	SensorCommandStatusSent         SensorCommandStatus = "sent"
+	SensorCommandStatusAcknowledged SensorCommandStatus = "acknowledged"
+	SensorCommandStatusExpired      SensorCommandStatus = "expired"
+)
+
+// SensorCommand is a downlink command for a sensor. Queued commands are published
+// to the downlink topic of the network server and acknowledged by the device afterwards.
+// Commands that can not be routed to a network server expire.
+type SensorCommand struct {
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SensorID       string
+	Type           SensorCommandType
+	Status         SensorCommandStatus
+	ReportInterval *time.Duration
+	SentAt         *time.Time
+	AcknowledgedAt *time.Time
+}
+
+type SensorCommandCreate struct {
+	Type           SensorCommandType `validate:"oneof=set_interval request_reading reboot"`
+	ReportInterval *time.Duration    `validate:"required_if=Type set_interval,omitempty,min=1m,max=24h"`
+}
@@ -13,21 +13,61 @@ const (
 )
 
 type TreeCluster struct {
-	ID             int32
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	WateringStatus WateringStatus
-	LastWatered    *time.Time
-	MoistureLevel  float64
-	Region         *Region
-	Address        string
-	Description    string
-	Archived       bool
-	Latitude       *float64
-	Longitude      *float64
-	Trees          []*Tree
-	SoilCondition  TreeSoilCondition
-	Name           string
+	ID                      int32
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	WateringStatus          WateringStatus
+	WateringStatusEstimated bool // the watering status is modeled without sensor data of the tree cluster
+	// WateringStatusInferredAt is the time of the sensor data of similar tree clusters the estimated watering
+	// status is inferred from, nil if the watering status is not inferred
+	WateringStatusInferredAt *time.Time
+	LastWatered              *time.Time
+	MoistureLevel            float64
+	Region                   *Region
+	Address                  string
+	Description              string
+	Archived                 bool
+	Latitude                 *float64
+	Longitude                *float64
+	Trees                    []*Tree
+	SoilCondition            TreeSoilCondition
+	Name                     string
+	MoistureTrends           []*MoistureTrend
+	MoistureMetrics          *MoistureMetrics
+}
+
+type TreeClusterSort string
+
+const (
+	TreeClusterSortName              TreeClusterSort = "name"
+	TreeClusterSortDaysUntilModerate TreeClusterSort = "days_until_moderate"
+	TreeClusterSortDaysUntilBad      TreeClusterSort = "days_until_bad"
+)
+
+// TreeClusterQuery holds the options of a tree cluster list. Without a sort order the tree clusters are sorted by name.
+// Filters that are not set are ignored. The filters only match tree clusters with moisture metrics, except
+// MaxCoverage that also matches tree clusters without sensor data.
+type TreeClusterQuery struct {
+	SortBy           TreeClusterSort
+	MinCoverage      *float64
+	MaxCoverage      *float64
+	MeasuredAfter    *time.Time
+	MeasuredBefore   *time.Time
+	MinMoistureLevel *float64
+	MaxMoistureLevel *float64
+}
+
+func ParseTreeClusterSort(sortStr string) (TreeClusterSort, bool) {
+	switch sortStr {
+	case string(TreeClusterSortName):
+		return TreeClusterSortName, true
+	case string(TreeClusterSortDaysUntilModerate):
+		return TreeClusterSortDaysUntilModerate, true
+	case string(TreeClusterSortDaysUntilBad):
+		return TreeClusterSortDaysUntilBad, true
+	default:
+		return "", false
+	}
 }
 
 type TreeClusterCreate struct {
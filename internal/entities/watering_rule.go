@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// WateringRuleSet is a version of the thresholds used to calculate the watering status. Rule sets are
+// not changed after they are created, a change creates a new version. Only one version is active at a time.
+type WateringRuleSet struct {
+	ID          int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Version     int32
+	Description string
+	Active      bool
+	ActivatedAt *time.Time
+	Thresholds  []WateringThreshold
+}
+
+type WateringRuleSetCreate struct {
+	Description string
+	Thresholds  []WateringThreshold `validate:"required,min=1,dive"`
+}
+
+// TreeWateringStatusDryRun compares the watering status of a tree with the active and the proposed thresholds
+type TreeWateringStatusDryRun struct {
+	TreeID   int32
+	Number   string
+	SensorID string
+	Active   WateringStatus
+	Proposed WateringStatus
+}
+
+// TreeClusterWateringStatusDryRun compares the watering status of a tree cluster with the active and the proposed thresholds
+type TreeClusterWateringStatusDryRun struct {
+	TreeClusterID int32
+	Name          string
+	Active        WateringStatus
+	Proposed      WateringStatus
+}
+
+// WateringRuleDryRun shows how the latest sensor data would be classified under a proposed rule set
+type WateringRuleDryRun struct {
+	Trees        []*TreeWateringStatusDryRun
+	TreeClusters []*TreeClusterWateringStatusDryRun
+}
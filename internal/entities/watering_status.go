@@ -8,3 +8,19 @@ const (
 	WateringStatusBad      WateringStatus = "bad"
 	WateringStatusUnknown  WateringStatus = "unknown"
 )
+
+// WateringThreshold holds the centibar values from which the watering status of a probe at the
+// given depth is moderate or bad, for trees whose age in years is between MinAge and MaxAge.
+type WateringThreshold struct {
+	// SpeciesGroup restricts the threshold to trees of the species or genus, nil applies to all species
+	SpeciesGroup *string `validate:"omitempty,min=1"`
+	// SoilCondition restricts the threshold to tree clusters with the soil condition, nil applies to all soil conditions
+	SoilCondition *TreeSoilCondition `validate:"omitempty,oneof=schluffig sandig lehmig tonig unknown"`
+	MinAge        int32              `validate:"gte=0"`
+	MaxAge        int32              `validate:"gtefield=MinAge"`
+	// Depth of the watermark probe in cm
+	Depth    int `validate:"gt=0"`
+	Moderate int `validate:"gte=0"`
+	// Bad equals Moderate if there is no moderate range
+	Bad int `validate:"gtefield=Moderate"`
+}
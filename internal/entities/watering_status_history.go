@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// WateringStatusCause describes what changed the watering status of a tree or tree cluster
+type WateringStatusCause string
+
+const (
+	WateringStatusCauseSensorData   WateringStatusCause = "sensor_data"
+	WateringStatusCauseWateringPlan WateringStatusCause = "watering_plan"
+	WateringStatusCauseManual       WateringStatusCause = "manual"
+	WateringStatusCauseWeather      WateringStatusCause = "weather"
+	WateringStatusCauseInference    WateringStatusCause = "inference"
+)
+
+// WateringStatusTransition is a change of the watering status of either a tree or a tree cluster
+type WateringStatusTransition struct {
+	ID            int32
+	CreatedAt     time.Time
+	TreeID        *int32
+	TreeClusterID *int32
+	OldStatus     WateringStatus
+	NewStatus     WateringStatus
+	Cause         WateringStatusCause
+}
+
+// WateringStatusPeriod is a time span in which the watering status did not change. The cause of the first
+// period is nil, as it started before the timeline or with the creation of the tree or tree cluster.
+type WateringStatusPeriod struct {
+	Status WateringStatus
+	Cause  *WateringStatusCause
+	From   time.Time
+	To     time.Time
+}
+
+// WateringStatusDays are the days spent in each watering status, fractions of a day included
+type WateringStatusDays struct {
+	Good     float64
+	Moderate float64
+	Bad      float64
+	Unknown  float64
+}
+
+// WateringStatusTimeline is the watering status history of a tree or tree cluster between From and To
+type WateringStatusTimeline struct {
+	From        time.Time
+	To          time.Time
+	Transitions []*WateringStatusTransition
+	Periods     []*WateringStatusPeriod
+	Days        WateringStatusDays
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+// WeatherDay is the daily weather of a region. Days before today are observations, today and the
+// following days are forecasts that are overwritten on every update.
+type WeatherDay struct {
+	RegionID       int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Date           time.Time // midnight UTC of the day
+	Precipitation  float64   // in mm
+	TemperatureMin float64   // in °C
+	TemperatureMax float64   // in °C
+	ET0            float64   // FAO-56 reference evapotranspiration in mm
+	Provider       string
+}
+
+// WeatherLocation is the point the weather of a region is fetched for
+type WeatherLocation struct {
+	RegionID  int32
+	Latitude  float64
+	Longitude float64
+}
+
+// WaterBalanceDay is the modeled water deficit of the root zone of a tree cluster at the end of a day
+type WaterBalanceDay struct {
+	Date           time.Time
+	Precipitation  float64 // in mm
+	ET0            float64 // in mm
+	Deficit        float64 // in mm
+	WateringStatus WateringStatus
+	Forecast       bool
+}
+
+// WaterBalance models the water of the root zone of a tree cluster starting with the last known watering
+// status at BaseDate and the weather of its region since. AdjustedStatus is the modeled status of today,
+// ForecastStatus the most severe status within the forecast horizon. A cluster is flagged if it turns bad
+// within the forecast horizon, BadAt is the first day it is bad.
+type WaterBalance struct {
+	TreeClusterID  int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	RegionID       int32
+	BaseStatus     WateringStatus
+	BaseDate       time.Time
+	WateringStatus WateringStatus
+	AdjustedStatus WateringStatus
+	ForecastStatus WateringStatus
+	Deficit        float64 // in mm at the end of today
+	BadAt          *time.Time
+	Flagged        bool
+	Days           []WaterBalanceDay
+}
@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+type DeadLetterReason string // @Name DeadLetterReason
+
+const (
+	DeadLetterReasonDecode  DeadLetterReason = "decode"
+	DeadLetterReasonProcess DeadLetterReason = "process"
+)
+
+type DeadLetterStatus string // @Name DeadLetterStatus
+
+const (
+	DeadLetterStatusPending   DeadLetterStatus = "pending"
+	DeadLetterStatusReplaying DeadLetterStatus = "replaying"
+	DeadLetterStatusReplayed  DeadLetterStatus = "replayed"
+)
+
+type DeadLetterResponse struct {
+	ID             int32            `json:"id"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Topic          string           `json:"topic"`
+	Decoder        string           `json:"decoder"`
+	Payload        []byte           `json:"payload"`
+	Reason         DeadLetterReason `json:"reason"`
+	Error          string           `json:"error"`
+	Status         DeadLetterStatus `json:"status"`
+	ReplayCount    int32            `json:"replay_count"`
+	LastReplayedAt *time.Time       `json:"last_replayed_at,omitempty" validate:"optional"`
+} // @Name DeadLetter
+
+type DeadLetterListResponse struct {
+	Data       []*DeadLetterResponse `json:"data"`
+	Pagination *Pagination           `json:"pagination"`
+} // @Name DeadLetterList
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapDeadLetterReason MapDeadLetterStatus
+type DeadLetterHTTPMapper interface {
+	FromResponse(*domain.DeadLetter) *entities.DeadLetterResponse
+	FromResponseList([]*domain.DeadLetter) []*entities.DeadLetterResponse
+}
+
+func MapDeadLetterReason(reason domain.DeadLetterReason) entities.DeadLetterReason {
+	return entities.DeadLetterReason(reason)
+}
+
+func MapDeadLetterStatus(status domain.DeadLetterStatus) entities.DeadLetterStatus {
+	return entities.DeadLetterStatus(status)
+}
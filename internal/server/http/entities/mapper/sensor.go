@@ -7,10 +7,25 @@ import (
 
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
-// goverter:extend MapSensorStatus MapLatestDataToResponse
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:SecondsToDurationPtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:DurationPtrToSeconds
+// goverter:extend MapSensorStatus MapSensorStatusReq MapLatestDataToResponse
 type SensorHTTPMapper interface {
 	FromResponse(src *domain.Sensor) *entities.SensorResponse
+	FromResponseList(src []*domain.Sensor) []*entities.SensorResponse
+	// goverter:ignore LatestData
+	FromCreateRequest(src *entities.SensorCreateRequest) *domain.SensorCreate
+	// goverter:ignore LatestData
+	FromUpdateRequest(src *entities.SensorUpdateRequest) *domain.SensorUpdate
+	FromImportRequestList(src []*entities.SensorImportRequest) []*domain.SensorImport
+	FromDecommissionRequest(src *entities.SensorDecommissionRequest) *domain.SensorDecommission
+	FromReplaceRequest(src *entities.SensorReplaceRequest) *domain.SensorReplace
 	FromWatermarkResponse(src *domain.Watermark) *entities.WatermarkResponse
+	FromSensorDataAggregateResponse(src []*domain.SensorDataAggregate) []*entities.SensorDataAggregateResponse
+	FromBatteryResponseList(src []*domain.SensorBattery) []*entities.SensorBatteryResponse
+	FromCalibrationResponse(src *domain.SensorCalibration) *entities.SensorCalibrationResponse
+	FromCalibrationUpdateRequest(src *entities.SensorCalibrationUpdateRequest) *domain.SensorCalibrationUpdate
 }
 
 func MapLatestDataToResponse(sensorData *domain.SensorData) *entities.SensorDataResponse {
@@ -18,7 +33,7 @@ func MapLatestDataToResponse(sensorData *domain.SensorData) *entities.SensorData
 		return nil
 	}
 
-	return &entities.SensorDataResponse{
+	response := &entities.SensorDataResponse{
 		CreatedAt:   sensorData.CreatedAt,
 		UpdatedAt:   sensorData.UpdatedAt,
 		Battery:     sensorData.Data.Battery,
@@ -26,6 +41,31 @@ func MapLatestDataToResponse(sensorData *domain.SensorData) *entities.SensorData
 		Temperature: sensorData.Data.Temperature,
 		Watermarks:  mapWatermarkData(sensorData.Data.Watermarks),
 	}
+
+	if sensorData.Data.RawWatermarks != nil {
+		response.RawWatermarks = mapWatermarkData(sensorData.Data.RawWatermarks)
+	}
+
+	if sensorData.Flagged {
+		response.Flagged = true
+		response.AnomalyScore = sensorData.AnomalyScore
+		response.Anomalies = mapSensorDataAnomalies(sensorData.Anomalies)
+	}
+
+	return response
+}
+
+func mapSensorDataAnomalies(anomalies []domain.SensorDataAnomaly) []*entities.SensorDataAnomalyResponse {
+	responses := make([]*entities.SensorDataAnomalyResponse, len(anomalies))
+	for i, a := range anomalies {
+		responses[i] = &entities.SensorDataAnomalyResponse{
+			Type:     entities.SensorDataAnomalyType(a.Type),
+			Depth:    a.Depth,
+			Centibar: a.Centibar,
+			Score:    a.Score,
+		}
+	}
+	return responses
 }
 
 func mapWatermarkData(watermarks []domain.Watermark) []*entities.WatermarkResponse {
@@ -43,3 +83,7 @@ func mapWatermarkData(watermarks []domain.Watermark) []*entities.WatermarkRespon
 func MapSensorStatus(src domain.SensorStatus) entities.SensorStatus {
 	return entities.SensorStatus(src)
 }
+
+func MapSensorStatusReq(src entities.SensorStatus) domain.SensorStatus {
+	return domain.SensorStatus(src)
+}
@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapSensorAssignmentStatus
+type SensorAssignmentHTTPMapper interface {
+	FromResponse(*domain.SensorAssignmentReview) *entities.SensorAssignmentReviewResponse
+	FromResponseList([]*domain.SensorAssignmentReview) []*entities.SensorAssignmentReviewResponse
+	FromResolveRequest(*entities.SensorAssignmentResolveRequest) *domain.SensorAssignmentResolve
+}
+
+func MapSensorAssignmentStatus(status domain.SensorAssignmentStatus) entities.SensorAssignmentStatus {
+	return entities.SensorAssignmentStatus(status)
+}
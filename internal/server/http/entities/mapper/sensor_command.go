@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:SecondsToDurationPtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:DurationPtrToSeconds
+// goverter:extend MapSensorCommandType MapSensorCommandStatus MapSensorCommandTypeReq
+type SensorCommandHTTPMapper interface {
+	FromResponse(*domain.SensorCommand) *entities.SensorCommandResponse
+	FromResponseList([]*domain.SensorCommand) []*entities.SensorCommandResponse
+	FromCreateRequest(*entities.SensorCommandCreateRequest) *domain.SensorCommandCreate
+}
+
+func MapSensorCommandType(commandType domain.SensorCommandType) entities.SensorCommandType {
+	return entities.SensorCommandType(commandType)
+}
+
+func MapSensorCommandStatus(status domain.SensorCommandStatus) entities.SensorCommandStatus {
+	return entities.SensorCommandStatus(status)
+}
+
+func MapSensorCommandTypeReq(commandType entities.SensorCommandType) domain.SensorCommandType {
+	return domain.SensorCommandType(commandType)
+}
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"math"
+	"time"
+
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 )
@@ -8,15 +11,17 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
-// goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq MapTreesToIDs MapSensorStatus
+// goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq MapTreesToIDs MapSensorStatus MapMoistureForecast MapMoistureMetrics
 // goverter:ignoreMissing
 type TreeClusterHTTPMapper interface {
+	// goverter:map MoistureTrends MoistureForecast
 	FromResponse(*domain.TreeCluster) *entities.TreeClusterResponse
 	FromResponseList([]*domain.TreeCluster) []*entities.TreeClusterInListResponse
 	FromCreateRequest(*entities.TreeClusterCreateRequest) *domain.TreeClusterCreate
 	FromUpdateRequest(*entities.TreeClusterUpdateRequest) *domain.TreeClusterUpdate
 
 	// goverter:map Trees TreeIDs
+	// goverter:map MoistureTrends MoistureForecast
 	FromInListResponse(*domain.TreeCluster) *entities.TreeClusterInListResponse
 }
 
@@ -41,3 +46,92 @@ func MapTreesToIDs(trees []*domain.Tree) []*int32 {
 	}
 	return ids
 }
+
+// MapMoistureForecast summarizes the moisture trends of a tree cluster. The forecast of the cluster is the
+// earliest threshold crossing of all depths, the days until then are relative to the time of the response.
+func MapMoistureForecast(trends []*domain.MoistureTrend) *entities.MoistureForecastResponse {
+	if len(trends) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	forecast := &entities.MoistureForecastResponse{
+		Trends: make([]*entities.MoistureTrendResponse, 0, len(trends)),
+	}
+	for _, trend := range trends {
+		if trend == nil {
+			continue
+		}
+
+		forecast.Trends = append(forecast.Trends, &entities.MoistureTrendResponse{
+			Depth:             trend.Depth,
+			Centibar:          trend.Centibar,
+			CentibarPerDay:    trend.CentibarPerDay,
+			SampleCount:       trend.SampleCount,
+			MeasuredAt:        trend.MeasuredAt,
+			ModerateAt:        trend.ModerateAt,
+			BadAt:             trend.BadAt,
+			DaysUntilModerate: daysUntil(now, trend.ModerateAt),
+			DaysUntilBad:      daysUntil(now, trend.BadAt),
+		})
+		forecast.ModerateAt = earliest(forecast.ModerateAt, trend.ModerateAt)
+		forecast.BadAt = earliest(forecast.BadAt, trend.BadAt)
+	}
+
+	forecast.DaysUntilModerate = daysUntil(now, forecast.ModerateAt)
+	forecast.DaysUntilBad = daysUntil(now, forecast.BadAt)
+	return forecast
+}
+
+// MapMoistureMetrics maps the moisture metrics of a tree cluster. The data age is the hours since the oldest of the
+// latest sensor data of the sensors at the time of the response.
+func MapMoistureMetrics(metrics *domain.MoistureMetrics) *entities.MoistureMetricsResponse {
+	if metrics == nil {
+		return nil
+	}
+
+	depths := make([]*entities.DepthMoistureResponse, 0, len(metrics.Depths))
+	for _, depth := range metrics.Depths {
+		if depth == nil {
+			continue
+		}
+
+		depths = append(depths, &entities.DepthMoistureResponse{
+			Depth:        depth.Depth,
+			SensorCount:  depth.SensorCount,
+			MinCentibar:  depth.MinCentibar,
+			MeanCentibar: depth.MeanCentibar,
+			MaxCentibar:  depth.MaxCentibar,
+		})
+	}
+
+	dataAge := math.Max(time.Since(metrics.OldestMeasuredAt).Hours(), 0)
+	return &entities.MoistureMetricsResponse{
+		TreeCount:        metrics.TreeCount,
+		SensorCount:      metrics.SensorCount,
+		Coverage:         metrics.Coverage,
+		LatestMeasuredAt: metrics.LatestMeasuredAt,
+		OldestMeasuredAt: metrics.OldestMeasuredAt,
+		DataAgeHours:     math.Round(dataAge*10) / 10,
+		UpdatedAt:        metrics.UpdatedAt,
+		Depths:           depths,
+	}
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+// daysUntil returns the days from now until t rounded to one decimal, a crossing in the past is zero days away
+func daysUntil(now time.Time, t *time.Time) *float64 {
+	if t == nil {
+		return nil
+	}
+
+	days := math.Max(t.Sub(now).Hours()/24, 0)
+	days = math.Round(days*10) / 10
+	return &days
+}
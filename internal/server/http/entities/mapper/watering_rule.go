@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq
+type WateringRuleHTTPMapper interface {
+	FromResponse(*domain.WateringRuleSet) *entities.WateringRuleSetResponse
+	FromResponseList([]*domain.WateringRuleSet) []*entities.WateringRuleSetResponse
+	FromCreateRequest(*entities.WateringRuleSetCreateRequest) *domain.WateringRuleSetCreate
+	FromDryRunResponse(*domain.WateringRuleDryRun) *entities.WateringRuleDryRunResponse
+}
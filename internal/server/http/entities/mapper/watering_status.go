@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapWateringStatus MapWateringStatusCause
+type WateringStatusHTTPMapper interface {
+	FromTimelineResponse(*domain.WateringStatusTimeline) *entities.WateringStatusTimelineResponse
+}
+
+func MapWateringStatusCause(cause domain.WateringStatusCause) entities.WateringStatusCause {
+	return entities.WateringStatusCause(cause)
+}
@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapWateringStatus
+type WeatherHTTPMapper interface {
+	FromResponse(*domain.WeatherDay) *entities.WeatherDayResponse
+	FromResponseList([]*domain.WeatherDay) []*entities.WeatherDayResponse
+	FromWaterBalanceResponse(*domain.WaterBalance) *entities.WaterBalanceResponse
+	FromWaterBalanceResponseList([]*domain.WaterBalance) []*entities.WaterBalanceResponse
+}
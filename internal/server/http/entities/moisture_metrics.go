@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+type DepthMoistureResponse struct {
+	Depth        int     `json:"depth"`
+	SensorCount  int32   `json:"sensor_count"`
+	MinCentibar  float64 `json:"min_centibar"`
+	MeanCentibar float64 `json:"mean_centibar"`
+	MaxCentibar  float64 `json:"max_centibar"`
+} // @Name DepthMoisture
+
+type MoistureMetricsResponse struct {
+	TreeCount        int32                    `json:"tree_count"`
+	SensorCount      int32                    `json:"sensor_count"`
+	Coverage         float64                  `json:"coverage"`
+	LatestMeasuredAt time.Time                `json:"latest_measured_at"`
+	OldestMeasuredAt time.Time                `json:"oldest_measured_at"`
+	DataAgeHours     float64                  `json:"data_age_hours"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Depths           []*DepthMoistureResponse `json:"depths"`
+} // @Name MoistureMetrics
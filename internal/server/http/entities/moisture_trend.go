@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type MoistureTrendResponse struct {
+	Depth             int        `json:"depth"`
+	Centibar          float64    `json:"centibar"`
+	CentibarPerDay    float64    `json:"centibar_per_day"`
+	SampleCount       int32      `json:"sample_count"`
+	MeasuredAt        time.Time  `json:"measured_at"`
+	ModerateAt        *time.Time `json:"moderate_at,omitempty" validate:"optional"`
+	BadAt             *time.Time `json:"bad_at,omitempty" validate:"optional"`
+	DaysUntilModerate *float64   `json:"days_until_moderate,omitempty" validate:"optional"`
+	DaysUntilBad      *float64   `json:"days_until_bad,omitempty" validate:"optional"`
+} // @Name MoistureTrend
+
+type MoistureForecastResponse struct {
+	ModerateAt        *time.Time               `json:"moderate_at,omitempty" validate:"optional"`
+	BadAt             *time.Time               `json:"bad_at,omitempty" validate:"optional"`
+	DaysUntilModerate *float64                 `json:"days_until_moderate,omitempty" validate:"optional"`
+	DaysUntilBad      *float64                 `json:"days_until_bad,omitempty" validate:"optional"`
+	Trends            []*MoistureTrendResponse `json:"trends"`
+} // @Name MoistureForecast
@@ -13,29 +13,93 @@ const (
 )
 
 type SensorResponse struct {
-	ID         string              `json:"id"`
-	CreatedAt  time.Time           `json:"created_at"`
-	UpdatedAt  time.Time           `json:"updated_at"`
-	Status     SensorStatus        `json:"status"`
-	LatestData *SensorDataResponse `json:"latest_data"`
-	Latitude   float64             `json:"latitude"`
-	Longitude  float64             `json:"longitude"`
+	ID               string                       `json:"id"`
+	CreatedAt        time.Time                    `json:"created_at"`
+	UpdatedAt        time.Time                    `json:"updated_at"`
+	Status           SensorStatus                 `json:"status"`
+	LatestData       *SensorDataResponse          `json:"latest_data"`
+	Latitude         float64                      `json:"latitude"`
+	Longitude        float64                      `json:"longitude"`
+	OfflineThreshold *int32                       `json:"offline_threshold,omitempty" validate:"optional"` // in seconds
+	Calibration      *SensorCalibrationResponse   `json:"calibration,omitempty" validate:"optional"`
+	LocationDrift    *SensorLocationDriftResponse `json:"location_drift,omitempty" validate:"optional"`
+	// decommissioned sensors keep their data but don't accept new data
+	DecommissionedAt   *time.Time `json:"decommissioned_at,omitempty" validate:"optional"`
+	DecommissionReason *string    `json:"decommission_reason,omitempty" validate:"optional"`
+	ReplacedBy         *string    `json:"replaced_by,omitempty" validate:"optional"`
 } // @Name Sensor
 
+type SensorLocationDriftResponse struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Distance   float64   `json:"distance"` // in meters
+	DetectedAt time.Time `json:"detected_at"`
+} // @Name SensorLocationDrift
+
 type SensorListResponse struct {
 	Data       []*SensorResponse `json:"data"`
 	Pagination Pagination        `json:"pagination"`
 } // @Name SensorList
 
+type SensorCreateRequest struct {
+	ID               string       `json:"id"`
+	Status           SensorStatus `json:"status"`
+	Latitude         float64      `json:"latitude"`
+	Longitude        float64      `json:"longitude"`
+	OfflineThreshold *int32       `json:"offline_threshold,omitempty" validate:"optional"` // in seconds
+} // @Name SensorCreate
+
+type SensorUpdateRequest struct {
+	Status           SensorStatus `json:"status"`
+	Latitude         float64      `json:"latitude"`
+	Longitude        float64      `json:"longitude"`
+	OfflineThreshold *int32       `json:"offline_threshold,omitempty" validate:"optional"` // in seconds
+} // @Name SensorUpdate
+
+type SensorImportRequest struct {
+	ID        string  `json:"id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	TreeID    *int32  `json:"tree_id" validate:"optional"`
+} // @Name SensorImport
+
+type SensorDecommissionRequest struct {
+	Reason string `json:"reason" validate:"optional"`
+} // @Name SensorDecommission
+
+type SensorReplaceRequest struct {
+	ReplacementID string `json:"replacement_id"`
+	Reason        string `json:"reason" validate:"optional"`
+} // @Name SensorReplace
+
 type SensorDataResponse struct {
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
-	Battery     float64              `json:"battery"`
-	Humidity    float64              `json:"humidity"`
-	Temperature float64              `json:"temperature"`
-	Watermarks  []*WatermarkResponse `json:"watermarks"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	UpdatedAt     time.Time                    `json:"updated_at"`
+	Battery       float64                      `json:"battery"`
+	Humidity      float64                      `json:"humidity"`
+	Temperature   float64                      `json:"temperature"`
+	Watermarks    []*WatermarkResponse         `json:"watermarks"`
+	RawWatermarks []*WatermarkResponse         `json:"raw_watermarks,omitempty" validate:"optional"` // values as sent by the sensor if a calibration was applied
+	Flagged       bool                         `json:"flagged"`                                      // flagged data is excluded from the watering status
+	AnomalyScore  float64                      `json:"anomaly_score"`
+	Anomalies     []*SensorDataAnomalyResponse `json:"anomalies,omitempty" validate:"optional"`
 } // @Name SensorData
 
+type SensorDataAnomalyType string // @Name SensorDataAnomalyType
+
+const (
+	SensorDataAnomalySpike      SensorDataAnomalyType = "spike"
+	SensorDataAnomalyFlatline   SensorDataAnomalyType = "flatline"
+	SensorDataAnomalyOutOfRange SensorDataAnomalyType = "out_of_range"
+)
+
+type SensorDataAnomalyResponse struct {
+	Type     SensorDataAnomalyType `json:"type"`
+	Depth    int                   `json:"depth"`
+	Centibar int                   `json:"centibar"`
+	Score    float64               `json:"score"`
+} // @Name SensorDataAnomaly
+
 type SensorDataListResponse struct {
 	Data       []*SensorDataResponse `json:"data"`
 	Pagination Pagination            `json:"pagination"`
@@ -46,3 +110,70 @@ type WatermarkResponse struct {
 	Resistance int `json:"resistance"`
 	Depth      int `json:"depth"`
 } // @Name WatermarkResponse
+
+type SensorBatteryResponse struct {
+	SensorID         string     `json:"sensor_id"`
+	Level            float64    `json:"level"`
+	MeasuredAt       time.Time  `json:"measured_at"`
+	DischargePerDay  float64    `json:"discharge_per_day"`
+	SampleCount      int32      `json:"sample_count"`
+	EstimatedEmptyAt *time.Time `json:"estimated_empty_at,omitempty" validate:"optional"`
+	BelowThreshold   bool       `json:"below_threshold"`
+} // @Name SensorBattery
+
+type SensorMaintenanceListResponse struct {
+	Data []*SensorBatteryResponse `json:"data"`
+} // @Name SensorMaintenanceList
+
+type SensorDataResolution string // @Name SensorDataResolution
+
+const (
+	SensorDataResolutionRaw    SensorDataResolution = "raw"
+	SensorDataResolutionHourly SensorDataResolution = "hourly"
+	SensorDataResolutionDaily  SensorDataResolution = "daily"
+)
+
+type SensorDataHistoryResponse struct {
+	SensorID   string                         `json:"sensor_id"`
+	Resolution SensorDataResolution           `json:"resolution"`
+	From       time.Time                      `json:"from"`
+	To         time.Time                      `json:"to"`
+	Data       []*SensorDataAggregateResponse `json:"data"`
+} // @Name SensorDataHistory
+
+type SensorDataAggregateResponse struct {
+	Timestamp   time.Time                     `json:"timestamp"`
+	SampleCount int32                         `json:"sample_count"`
+	Battery     float64                       `json:"battery"`
+	Humidity    float64                       `json:"humidity"`
+	Temperature float64                       `json:"temperature"`
+	Watermarks  []*WatermarkAggregateResponse `json:"watermarks"`
+} // @Name SensorDataAggregate
+
+type WatermarkAggregateResponse struct {
+	Depth      int     `json:"depth"`
+	Centibar   float64 `json:"centibar"`
+	Resistance float64 `json:"resistance"`
+} // @Name WatermarkAggregate
+
+type CalibrationPoint struct {
+	Resistance int     `json:"resistance"`
+	Centibar   float64 `json:"centibar"`
+} // @Name CalibrationPoint
+
+type SensorCalibrationResponse struct {
+	SensorID               string             `json:"sensor_id"`
+	CreatedAt              time.Time          `json:"created_at"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+	CentibarOffset         float64            `json:"centibar_offset"`
+	TemperatureCoefficient float64            `json:"temperature_coefficient"` // relative change per degree celsius
+	ReferenceTemperature   float64            `json:"reference_temperature"`
+	Curve                  []CalibrationPoint `json:"curve"`
+} // @Name SensorCalibration
+
+type SensorCalibrationUpdateRequest struct {
+	CentibarOffset         float64            `json:"centibar_offset"`
+	TemperatureCoefficient float64            `json:"temperature_coefficient"` // relative change per degree celsius
+	ReferenceTemperature   float64            `json:"reference_temperature"`
+	Curve                  []CalibrationPoint `json:"curve" validate:"optional"`
+} // @Name SensorCalibrationUpdate
@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+type SensorAssignmentStatus string // @Name SensorAssignmentStatus
+
+const (
+	SensorAssignmentStatusPending   SensorAssignmentStatus = "pending"
+	SensorAssignmentStatusResolved  SensorAssignmentStatus = "resolved"
+	SensorAssignmentStatusDismissed SensorAssignmentStatus = "dismissed"
+)
+
+type TreeCandidateResponse struct {
+	TreeID   int32   `json:"tree_id"`
+	Distance float64 `json:"distance"`
+} // @Name TreeCandidate
+
+type SensorAssignmentReviewResponse struct {
+	ID         int32                    `json:"id"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	SensorID   string                   `json:"sensor_id"`
+	Status     SensorAssignmentStatus   `json:"status"`
+	TreeID     *int32                   `json:"tree_id,omitempty" validate:"optional"`
+	Candidates []*TreeCandidateResponse `json:"candidates"`
+} // @Name SensorAssignmentReview
+
+type SensorAssignmentReviewListResponse struct {
+	Data       []*SensorAssignmentReviewResponse `json:"data"`
+	Pagination *Pagination                       `json:"pagination"`
+} // @Name SensorAssignmentReviewList
+
+type SensorAssignmentResolveRequest struct {
+	TreeID int32 `json:"tree_id"`
+} // @Name SensorAssignmentResolve
@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+type SensorCommandType string // @Name SensorCommandType
+
+const (
+	SensorCommandTypeSetInterval    SensorCommandType = "set_interval"
+	SensorCommandTypeRequestReading SensorCommandType = "request_reading"
+	SensorCommandTypeReboot         SensorCommandType = "reboot"
+)
+
+type SensorCommandStatus string // @Name SensorCommandStatus
+
+const (
+	SensorCommandStatusQueued       SensorCommandStatus = "queued"
+	SensorCommandStatusSent         SensorCommandStatus = "sent"
+	SensorCommandStatusAcknowledged SensorCommandStatus = "acknowledged"
+	SensorCommandStatusExpired      SensorCommandStatus = "expired"
+)
+
+type SensorCommandResponse struct {
+	ID             int32               `json:"id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	SensorID       string              `json:"sensor_id"`
+	Type           SensorCommandType   `json:"type"`
+	Status         SensorCommandStatus `json:"status"`
+	ReportInterval *int32              `json:"report_interval,omitempty" validate:"optional"` // in seconds
+	SentAt         *time.Time          `json:"sent_at,omitempty" validate:"optional"`
+	AcknowledgedAt *time.Time          `json:"acknowledged_at,omitempty" validate:"optional"`
+} // @Name SensorCommand
+
+type SensorCommandListResponse struct {
+	Data       []*SensorCommandResponse `json:"data"`
+	Pagination *Pagination              `json:"pagination"`
+} // @Name SensorCommandList
+
+type SensorCommandCreateRequest struct {
+	Type           SensorCommandType `json:"type"`
+	ReportInterval *int32            `json:"report_interval,omitempty" validate:"optional"` // in seconds, required for set_interval
+} // @Name SensorCommandCreate
@@ -0,0 +1,64 @@
+package entities
+
+import "time"
+
+type WateringThresholdResponse struct {
+	SpeciesGroup  *string            `json:"species_group,omitempty" validate:"optional"`  // e.g. "Acer", applies to all species if not set
+	SoilCondition *TreeSoilCondition `json:"soil_condition,omitempty" validate:"optional"` // applies to all soil conditions if not set
+	MinAge        int32              `json:"min_age"`                                      // in years
+	MaxAge        int32              `json:"max_age"`                                      // in years
+	Depth         int                `json:"depth"`                                        // in cm
+	Moderate      int                `json:"moderate"`                                     // in centibar
+	Bad           int                `json:"bad"`                                          // in centibar
+} // @Name WateringThreshold
+
+type WateringRuleSetResponse struct {
+	ID          int32                       `json:"id"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	UpdatedAt   time.Time                   `json:"updated_at"`
+	Version     int32                       `json:"version"`
+	Description string                      `json:"description"`
+	Active      bool                        `json:"active"`
+	ActivatedAt *time.Time                  `json:"activated_at,omitempty" validate:"optional"`
+	Thresholds  []WateringThresholdResponse `json:"thresholds"`
+} // @Name WateringRuleSet
+
+type WateringRuleSetListResponse struct {
+	Data       []*WateringRuleSetResponse `json:"data"`
+	Pagination *Pagination                `json:"pagination"`
+} // @Name WateringRuleSetList
+
+type WateringThresholdRequest struct {
+	SpeciesGroup  *string            `json:"species_group,omitempty" validate:"optional"`
+	SoilCondition *TreeSoilCondition `json:"soil_condition,omitempty" validate:"optional"`
+	MinAge        int32              `json:"min_age"`
+	MaxAge        int32              `json:"max_age"`
+	Depth         int                `json:"depth"`
+	Moderate      int                `json:"moderate"`
+	Bad           int                `json:"bad"`
+} // @Name WateringThresholdRequest
+
+type WateringRuleSetCreateRequest struct {
+	Description string                     `json:"description"`
+	Thresholds  []WateringThresholdRequest `json:"thresholds"`
+} // @Name WateringRuleSetCreate
+
+type TreeWateringStatusDryRunResponse struct {
+	TreeID   int32          `json:"tree_id"`
+	Number   string         `json:"number"`
+	SensorID string         `json:"sensor_id"`
+	Active   WateringStatus `json:"active"`
+	Proposed WateringStatus `json:"proposed"`
+} // @Name TreeWateringStatusDryRun
+
+type TreeClusterWateringStatusDryRunResponse struct {
+	TreeClusterID int32          `json:"tree_cluster_id"`
+	Name          string         `json:"name"`
+	Active        WateringStatus `json:"active"`
+	Proposed      WateringStatus `json:"proposed"`
+} // @Name TreeClusterWateringStatusDryRun
+
+type WateringRuleDryRunResponse struct {
+	Trees        []*TreeWateringStatusDryRunResponse        `json:"trees"`
+	TreeClusters []*TreeClusterWateringStatusDryRunResponse `json:"tree_clusters"`
+} // @Name WateringRuleDryRun
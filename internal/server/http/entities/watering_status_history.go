@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+type WateringStatusCause string // @Name WateringStatusCause
+
+const (
+	WateringStatusCauseSensorData   WateringStatusCause = "sensor_data"
+	WateringStatusCauseWateringPlan WateringStatusCause = "watering_plan"
+	WateringStatusCauseManual       WateringStatusCause = "manual"
+	WateringStatusCauseWeather      WateringStatusCause = "weather"
+	WateringStatusCauseInference    WateringStatusCause = "inference"
+)
+
+type WateringStatusTransitionResponse struct {
+	ID        int32               `json:"id"`
+	CreatedAt time.Time           `json:"created_at"`
+	OldStatus WateringStatus      `json:"old_status"`
+	NewStatus WateringStatus      `json:"new_status"`
+	Cause     WateringStatusCause `json:"cause"`
+} // @Name WateringStatusTransition
+
+type WateringStatusPeriodResponse struct {
+	Status WateringStatus       `json:"status"`
+	Cause  *WateringStatusCause `json:"cause,omitempty" validate:"optional"`
+	From   time.Time            `json:"from"`
+	To     time.Time            `json:"to"`
+} // @Name WateringStatusPeriod
+
+type WateringStatusDaysResponse struct {
+	Good     float64 `json:"good"`
+	Moderate float64 `json:"moderate"`
+	Bad      float64 `json:"bad"`
+	Unknown  float64 `json:"unknown"`
+} // @Name WateringStatusDays
+
+type WateringStatusTimelineResponse struct {
+	From        time.Time                           `json:"from"`
+	To          time.Time                           `json:"to"`
+	Transitions []*WateringStatusTransitionResponse `json:"transitions"`
+	Periods     []*WateringStatusPeriodResponse     `json:"periods"`
+	Days        WateringStatusDaysResponse          `json:"days"`
+} // @Name WateringStatusTimeline
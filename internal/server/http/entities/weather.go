@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+type WeatherDayResponse struct {
+	RegionID       int32     `json:"region_id"`
+	Date           time.Time `json:"date"`
+	Precipitation  float64   `json:"precipitation"`   // in mm
+	TemperatureMin float64   `json:"temperature_min"` // in °C
+	TemperatureMax float64   `json:"temperature_max"` // in °C
+	ET0            float64   `json:"et0"`             // in mm
+	Provider       string    `json:"provider"`
+} // @Name WeatherDay
+
+type WeatherDayListResponse struct {
+	Data []*WeatherDayResponse `json:"data"`
+} // @Name WeatherDayList
+
+type WaterBalanceDayResponse struct {
+	Date           time.Time      `json:"date"`
+	Precipitation  float64        `json:"precipitation"` // in mm
+	ET0            float64        `json:"et0"`           // in mm
+	Deficit        float64        `json:"deficit"`       // in mm
+	WateringStatus WateringStatus `json:"watering_status"`
+	Forecast       bool           `json:"forecast"`
+} // @Name WaterBalanceDay
+
+type WaterBalanceResponse struct {
+	TreeClusterID  int32                     `json:"tree_cluster_id"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+	RegionID       int32                     `json:"region_id"`
+	BaseStatus     WateringStatus            `json:"base_status"`
+	BaseDate       time.Time                 `json:"base_date"`
+	WateringStatus WateringStatus            `json:"watering_status"`
+	AdjustedStatus WateringStatus            `json:"adjusted_status"`
+	ForecastStatus WateringStatus            `json:"forecast_status"`
+	Deficit        float64                   `json:"deficit"` // in mm
+	BadAt          *time.Time                `json:"bad_at,omitempty" validate:"optional"`
+	Flagged        bool                      `json:"flagged"`
+	Days           []WaterBalanceDayResponse `json:"days"`
+} // @Name WaterBalance
+
+type WaterBalanceListResponse struct {
+	Data       []*WaterBalanceResponse `json:"data"`
+	Pagination *Pagination             `json:"pagination"`
+} // @Name WaterBalanceList
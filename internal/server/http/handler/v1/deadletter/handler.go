@@ -0,0 +1,152 @@
+package deadletter
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	deadLetterMapper = generated.DeadLetterHTTPMapperImpl{}
+)
+
+// @Summary		Get all dead letters
+// @Description	Get all sensor messages that could not be decoded or processed
+// @Id				get-all-dead-letters
+// @Tags			Dead Letter
+// @Produce		json
+// @Success		200	{object}	entities.DeadLetterListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/dead-letter [get]
+// @Param			status	query	string	false	"Dead letter status (pending, replaying, replayed)"
+// @Security		Keycloak
+func GetAllDeadLetters(svc service.DeadLetterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		var domainData []*domain.DeadLetter
+		var err error
+
+		statusStr := c.Query("status")
+		switch domain.DeadLetterStatus(statusStr) {
+		case "":
+			domainData, err = svc.GetAll(ctx)
+		case domain.DeadLetterStatusPending, domain.DeadLetterStatusReplaying, domain.DeadLetterStatusReplayed:
+			domainData, err = svc.GetAllByStatus(ctx, domain.DeadLetterStatus(statusStr))
+		default:
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "invalid dead letter status"))
+		}
+
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.DeadLetterListResponse{
+			Data:       deadLetterMapper.FromResponseList(domainData),
+			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+		})
+	}
+}
+
+// @Summary		Get dead letter by ID
+// @Description	Get dead letter by ID including the raw payload
+// @Id				get-dead-letter-by-id
+// @Tags			Dead Letter
+// @Produce		json
+// @Success		200	{object}	entities.DeadLetterResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/dead-letter/{id} [get]
+// @Param			id	path	integer	true	"Dead letter ID"
+// @Security		Keycloak
+func GetDeadLetterByID(svc service.DeadLetterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(deadLetterMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Replay dead letter
+// @Description	Decode the stored payload again and pass it to the sensor message handling. On success the dead letter is marked as replayed, otherwise it stays pending and the error is updated.
+// @Id				replay-dead-letter
+// @Tags			Dead Letter
+// @Produce		json
+// @Success		200	{object}	entities.DeadLetterResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/dead-letter/{id}/replay [post]
+// @Param			id	path	integer	true	"Dead letter ID"
+// @Security		Keycloak
+func ReplayDeadLetter(svc service.DeadLetterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Replay(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(deadLetterMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete dead letter
+// @Description	Delete dead letter
+// @Id				delete-dead-letter
+// @Tags			Dead Letter
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/dead-letter/{id} [delete]
+// @Param			id	path	integer	true	"Dead letter ID"
+// @Security		Keycloak
+func DeleteDeadLetter(svc service.DeadLetterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		err = svc.Delete(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
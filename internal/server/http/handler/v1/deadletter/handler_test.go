@@ -0,0 +1,272 @@
+package deadletter_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/deadletter"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllDeadLetters(t *testing.T) {
+	t.Run("should return all dead letters", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter", deadletter.GetAllDeadLetters(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(TestDeadLetters, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.DeadLetterListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestDeadLetters))
+		assert.Equal(t, TestDeadLetters[0].ID, response.Data[0].ID)
+		assert.Equal(t, TestDeadLetters[0].Payload, response.Data[0].Payload)
+		assert.Equal(t, serverEntities.DeadLetterReasonDecode, response.Data[0].Reason)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return dead letters filtered by status", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter", deadletter.GetAllDeadLetters(mockSvc))
+
+		mockSvc.EXPECT().GetAllByStatus(mock.Anything, entities.DeadLetterStatusPending).Return(TestDeadLetters[:1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter?status=pending", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.DeadLetterListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid status", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter", deadletter.GetAllDeadLetters(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter?status=failed", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter", deadletter.GetAllDeadLetters(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestGetDeadLetterByID(t *testing.T) {
+	t.Run("should return dead letter by id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter/:id", deadletter.GetDeadLetterByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(2)).Return(TestDeadLetters[1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter/2", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.DeadLetterResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestDeadLetters[1].ID, response.ID)
+		assert.Equal(t, TestDeadLetters[1].Topic, response.Topic)
+		assert.Equal(t, serverEntities.DeadLetterStatusReplayed, response.Status)
+		assert.NotNil(t, response.LastReplayedAt)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter/:id", deadletter.GetDeadLetterByID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when dead letter not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Get("/v1/dead-letter/:id", deadletter.GetDeadLetterByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/dead-letter/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	t.Run("should replay dead letter", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Post("/v1/dead-letter/:id/replay", deadletter.ReplayDeadLetter(mockSvc))
+
+		replayed := *TestDeadLetters[0]
+		replayed.Status = entities.DeadLetterStatusReplayed
+		replayed.ReplayCount = 1
+		mockSvc.EXPECT().Replay(mock.Anything, int32(1)).Return(&replayed, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/dead-letter/1/replay", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.DeadLetterResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.DeadLetterStatusReplayed, response.Status)
+		assert.Equal(t, int32(1), response.ReplayCount)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 when dead letter was already replayed", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Post("/v1/dead-letter/:id/replay", deadletter.ReplayDeadLetter(mockSvc))
+
+		mockSvc.EXPECT().Replay(mock.Anything, int32(2)).Return(nil, service.ErrDeadLetterReplayed)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/dead-letter/2/replay", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Post("/v1/dead-letter/:id/replay", deadletter.ReplayDeadLetter(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/dead-letter/abc/replay", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDeleteDeadLetter(t *testing.T) {
+	t.Run("should delete dead letter", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Delete("/v1/dead-letter/:id", deadletter.DeleteDeadLetter(mockSvc))
+
+		mockSvc.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/dead-letter/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 when dead letter not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockDeadLetterService(t)
+		app := fiber.New()
+		app.Delete("/v1/dead-letter/:id", deadletter.DeleteDeadLetter(mockSvc))
+
+		mockSvc.EXPECT().Delete(mock.Anything, int32(99)).Return(service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/dead-letter/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		mockSvc.AssertExpectations(t)
+	})
+}
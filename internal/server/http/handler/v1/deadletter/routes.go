@@ -0,0 +1,13 @@
+package deadletter
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.DeadLetterService) {
+	r.Get("/", GetAllDeadLetters(svc))
+	r.Get("/:id", GetDeadLetterByID(svc))
+	r.Post("/:id/replay", ReplayDeadLetter(svc))
+	r.Delete("/:id", DeleteDeadLetter(svc))
+}
@@ -0,0 +1,89 @@
+package deadletter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/deadletter"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/dead-letter", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockDeadLetterService(t)
+			app := fiber.New()
+			deadletter.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetAll(mock.Anything).Return(TestDeadLetters, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/dead-letter/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockDeadLetterService(t)
+			app := fiber.New()
+			deadletter.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestDeadLetters[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockDeadLetterService(t)
+			app := fiber.New()
+			deadletter.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/dead-letter/:id/replay", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockDeadLetterService(t)
+			app := fiber.New()
+			deadletter.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Replay(mock.Anything, int32(1)).Return(TestDeadLetters[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/replay", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
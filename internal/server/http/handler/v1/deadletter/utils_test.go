@@ -0,0 +1,37 @@
+package deadletter_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	currentTime     = time.Now()
+	TestDeadLetters = []*entities.DeadLetter{
+		{
+			ID:        1,
+			CreatedAt: currentTime,
+			UpdatedAt: currentTime,
+			Topic:     "v3/green-ecolution/devices/sensor-1/up",
+			Decoder:   "ttn",
+			Payload:   []byte(`{"end_device_ids": {"device_id": "sensor-1"}}`),
+			Reason:    entities.DeadLetterReasonDecode,
+			Error:     "invalid mqtt payload",
+			Status:    entities.DeadLetterStatusPending,
+		},
+		{
+			ID:             2,
+			CreatedAt:      currentTime,
+			UpdatedAt:      currentTime,
+			Topic:          "v3/green-ecolution/devices/sensor-2/up",
+			Decoder:        "json",
+			Payload:        []byte(`{"device": "sensor-2"}`),
+			Reason:         entities.DeadLetterReasonProcess,
+			Error:          "sensor not found",
+			Status:         entities.DeadLetterStatusReplayed,
+			ReplayCount:    1,
+			LastReplayedAt: &currentTime,
+		},
+	}
+)
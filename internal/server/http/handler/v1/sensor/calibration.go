@@ -0,0 +1,112 @@
+package sensor
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// @Summary		Get sensor calibration
+// @Description	Get the calibration profile of a sensor
+// @Id				get-sensor-calibration
+// @Tags			Sensor
+// @Produce		json
+// @Success		200	{object}	entities.SensorCalibrationResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/calibration [get]
+// @Param			sensor_id	path	string	true	"Sensor ID"
+// @Security		Keycloak
+func GetSensorCalibration(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetCalibration(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorMapper.FromCalibrationResponse(domainData))
+	}
+}
+
+// @Summary		Update sensor calibration
+// @Description	Create or replace the calibration profile of a sensor. All stored sensor data of the sensor is recomputed from the raw values with the new profile.
+// @Id				update-sensor-calibration
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	entities.SensorCalibrationResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/calibration [put]
+// @Param			sensor_id	path	string									true	"Sensor ID"
+// @Param			body		body	entities.SensorCalibrationUpdateRequest	true	"Sensor Calibration Update Request"
+// @Security		Keycloak
+func UpdateSensorCalibration(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.SensorCalibrationUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.UpdateCalibration(ctx, id, sensorMapper.FromCalibrationUpdateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorMapper.FromCalibrationResponse(domainData))
+	}
+}
+
+// @Summary		Delete sensor calibration
+// @Description	Delete the calibration profile of a sensor. All stored sensor data of the sensor is restored to the raw values.
+// @Id				delete-sensor-calibration
+// @Tags			Sensor
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/calibration [delete]
+// @Param			sensor_id	path	string	true	"Sensor ID"
+// @Security		Keycloak
+func DeleteSensorCalibration(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.DeleteCalibration(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,183 @@
+package sensor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSensorCalibration(t *testing.T) {
+	t.Run("should return calibration successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Get("/v1/sensor/:id/calibration", sensor.GetSensorCalibration(mockSensorService))
+
+		mockSensorService.EXPECT().GetCalibration(mock.Anything, "sensor-1").Return(TestSensorCalibration, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/calibration", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorCalibrationResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensorCalibration.SensorID, response.SensorID)
+		assert.Equal(t, TestSensorCalibration.CentibarOffset, response.CentibarOffset)
+		assert.Len(t, response.Curve, len(TestSensorCalibration.Curve))
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 when sensor has no calibration", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Get("/v1/sensor/:id/calibration", sensor.GetSensorCalibration(mockSensorService))
+
+		mockSensorService.EXPECT().GetCalibration(mock.Anything, "sensor-1").Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/calibration", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestUpdateSensorCalibration(t *testing.T) {
+	t.Run("should update calibration successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id/calibration", sensor.UpdateSensorCalibration(mockSensorService))
+
+		reqBody := serverEntities.SensorCalibrationUpdateRequest{
+			CentibarOffset:         -2,
+			TemperatureCoefficient: 0.02,
+			ReferenceTemperature:   21,
+			Curve: []serverEntities.CalibrationPoint{
+				{Resistance: 500, Centibar: 0},
+				{Resistance: 10000, Centibar: 100},
+			},
+		}
+
+		mockSensorService.EXPECT().UpdateCalibration(
+			mock.Anything,
+			"sensor-1",
+			&entities.SensorCalibrationUpdate{
+				CentibarOffset:         -2,
+				TemperatureCoefficient: 0.02,
+				ReferenceTemperature:   21,
+				Curve:                  TestSensorCalibration.Curve,
+			},
+		).Return(TestSensorCalibration, nil)
+
+		// when
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-1/calibration", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorCalibrationResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensorCalibration.SensorID, response.SensorID)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id/calibration", sensor.UpdateSensorCalibration(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-1/calibration", bytes.NewBufferString(`{"curve": 1`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when service returns validation error", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id/calibration", sensor.UpdateSensorCalibration(mockSensorService))
+
+		mockSensorService.EXPECT().UpdateCalibration(mock.Anything, "sensor-1", mock.Anything).Return(nil, service.NewError(service.BadRequest, "validation error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-1/calibration", bytes.NewBufferString(`{"curve": [{"resistance": 500, "centibar": 0}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDeleteSensorCalibration(t *testing.T) {
+	t.Run("should delete calibration successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Delete("/v1/sensor/:id/calibration", sensor.DeleteSensorCalibration(mockSensorService))
+
+		mockSensorService.EXPECT().DeleteCalibration(mock.Anything, "sensor-1").Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/sensor/sensor-1/calibration", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 when sensor has no calibration", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Delete("/v1/sensor/:id/calibration", sensor.DeleteSensorCalibration(mockSensorService))
+
+		mockSensorService.EXPECT().DeleteCalibration(mock.Anything, "sensor-1").Return(service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/sensor/sensor-1/calibration", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
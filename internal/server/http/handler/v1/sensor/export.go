@@ -0,0 +1,120 @@
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var exportContentTypes = map[domain.SensorDataExportFormat]string{
+	domain.SensorDataExportFormatCSV:     "text/csv;charset=UTF-8",
+	domain.SensorDataExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// @Summary		Export sensor data
+// @Description	Export the raw sensor data as CSV or Parquet file. The watermarks are flattened into a centibar and resistance column per depth of the active watering thresholds (30, 60 and 90 cm by default), readings at other depths are not exported. The data is streamed, so large time ranges can be exported.
+// @Id				export-sensor-data
+// @Tags			Sensor
+// @Produce		text/csv
+// @Produce		application/vnd.apache.parquet
+// @Success		200	{file}		file
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/export [get]
+// @Param			sensor_ids	query	string	false	"Comma separated list of sensor IDs"
+// @Param			cluster_id	query	integer	false	"Tree cluster ID"
+// @Param			region_id	query	integer	false	"Region ID"
+// @Param			from		query	string	false	"Start of the time range (RFC3339), defaults to seven days before 'to'"
+// @Param			to			query	string	false	"End of the time range (RFC3339), defaults to now"
+// @Param			format		query	string	false	"Format of the file (csv, parquet), defaults to csv"
+// @Security		Keycloak
+func ExportSensorData(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := parseExportQuery(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		stream, err := svc.ExportSensorData(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		filename := fmt.Sprintf("sensor-data-%s-%s.%s", query.From.UTC().Format("20060102T150405Z"), query.To.UTC().Format("20060102T150405Z"), query.Format)
+		c.Set(fiber.HeaderContentType, exportContentTypes[query.Format])
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filename))
+		return c.SendStream(stream)
+	}
+}
+
+func parseExportQuery(c *fiber.Ctx) (*domain.SensorDataExportQuery, error) {
+	query := &domain.SensorDataExportQuery{
+		To:     time.Now(),
+		Format: domain.SensorDataExportFormatCSV,
+	}
+
+	if sensorIDs := c.Query("sensor_ids"); sensorIDs != "" {
+		for _, id := range strings.Split(sensorIDs, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				query.SensorIDs = append(query.SensorIDs, strings.Clone(id))
+			}
+		}
+	}
+
+	if clusterIDStr := c.Query("cluster_id"); clusterIDStr != "" {
+		clusterID, err := strconv.Atoi(clusterIDStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'cluster_id' format")
+		}
+		query.TreeClusterID = utils.P(int32(clusterID))
+	}
+
+	if regionIDStr := c.Query("region_id"); regionIDStr != "" {
+		regionID, err := strconv.Atoi(regionIDStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'region_id' format")
+		}
+		query.RegionID = utils.P(int32(regionID))
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'to' format, expected RFC3339")
+		}
+		query.To = to
+	}
+
+	query.From = query.To.Add(-7 * 24 * time.Hour)
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'from' format, expected RFC3339")
+		}
+		query.From = from
+	}
+
+	if format := c.Query("format"); format != "" {
+		if _, ok := exportContentTypes[domain.SensorDataExportFormat(format)]; !ok {
+			return nil, service.NewError(service.BadRequest, "invalid format, expected one of csv, parquet")
+		}
+		query.Format = domain.SensorDataExportFormat(format)
+	}
+
+	if !query.From.Before(query.To) {
+		return nil, service.NewError(service.BadRequest, "'from' must be before 'to'")
+	}
+
+	return query, nil
+}
@@ -0,0 +1,154 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportSensorData(t *testing.T) {
+	t.Run("should stream csv export with filters", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/export", sensor.ExportSensorData(mockSensorService))
+
+		from := time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC)
+		csvData := "sensor_id,created_at\nsensor-1,2024-11-10T01:00:00Z\n"
+		mockSensorService.EXPECT().ExportSensorData(
+			mock.Anything,
+			&entities.SensorDataExportQuery{
+				SensorIDs:     []string{"sensor-1", "sensor-2"},
+				TreeClusterID: utils.P(int32(2)),
+				RegionID:      utils.P(int32(3)),
+				From:          from,
+				To:            to,
+				Format:        entities.SensorDataExportFormatCSV,
+			},
+		).Return(io.NopCloser(strings.NewReader(csvData)), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/export?sensor_ids=sensor-1,%20sensor-2&cluster_id=2&region_id=3&from=2024-11-10T00:00:00Z&to=2024-11-11T00:00:00Z", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv;charset=UTF-8", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "attachment; filename=sensor-data-20241110T000000Z-20241111T000000Z.csv", resp.Header.Get(fiber.HeaderContentDisposition))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, csvData, string(body))
+	})
+
+	t.Run("should stream parquet export with last seven days by default", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/export", sensor.ExportSensorData(mockSensorService))
+
+		mockSensorService.EXPECT().ExportSensorData(
+			mock.Anything,
+			mock.MatchedBy(func(q *entities.SensorDataExportQuery) bool {
+				return q.Format == entities.SensorDataExportFormatParquet && q.To.Sub(q.From) == 7*24*time.Hour &&
+					q.SensorIDs == nil && q.TreeClusterID == nil && q.RegionID == nil
+			}),
+		).Return(io.NopCloser(strings.NewReader("PAR1")), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/export?format=parquet", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/vnd.apache.parquet", resp.Header.Get(fiber.HeaderContentType))
+	})
+
+	t.Run("should return 400 for invalid query", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+		}{
+			{name: "invalid format", query: "format=xlsx"},
+			{name: "invalid cluster id", query: "cluster_id=abc"},
+			{name: "invalid region id", query: "region_id=abc"},
+			{name: "invalid from", query: "from=yesterday"},
+			{name: "invalid to", query: "to=today"},
+			{name: "from after to", query: "from=2024-11-11T00:00:00Z&to=2024-11-10T00:00:00Z"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockSensorService := serviceMock.NewMockSensorService(t)
+				app := fiber.New()
+				app.Get("/v1/sensor/export", sensor.ExportSensorData(mockSensorService))
+
+				// when
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/export?"+tt.query, nil)
+				resp, err := app.Test(req, -1)
+				defer resp.Body.Close()
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				mockSensorService.AssertNotCalled(t, "ExportSensorData")
+			})
+		}
+	})
+
+	t.Run("should return 404 when sensor is not found", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/export", sensor.ExportSensorData(mockSensorService))
+
+		mockSensorService.EXPECT().ExportSensorData(
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, service.NewError(service.NotFound, "sensor not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/export?sensor_ids=sensor-99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/export", sensor.ExportSensorData(mockSensorService))
+
+		mockSensorService.EXPECT().ExportSensorData(
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, errors.New("internal error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/export", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
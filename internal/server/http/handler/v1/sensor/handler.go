@@ -2,6 +2,7 @@ package sensor
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -9,6 +10,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 )
 
 var (
@@ -86,6 +88,86 @@ func GetSensorByID(svc service.SensorService) fiber.Handler {
 	}
 }
 
+// @Summary		Create sensor
+// @Description	Create sensor
+// @Id				create-sensor
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		201	{object}	entities.SensorResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor [post]
+// @Param			body	body	entities.SensorCreateRequest	true	"Sensor Create Request"
+// @Security		Keycloak
+func CreateSensor(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.SensorCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		if req.Status == "" {
+			req.Status = entities.SensorStatusUnknown
+		}
+
+		domainReq := sensorMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := mapToDto(domainData)
+		return c.Status(fiber.StatusCreated).JSON(data)
+	}
+}
+
+// @Summary		Update sensor
+// @Description	Update sensor
+// @Id				update-sensor
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	entities.SensorResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id} [put]
+// @Param			sensor_id	path	string						true	"Sensor ID"
+// @Param			body		body	entities.SensorUpdateRequest	true	"Sensor Update Request"
+// @Security		Keycloak
+func UpdateSensor(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.SensorUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := sensorMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, id, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := mapToDto(domainData)
+		return c.JSON(data)
+	}
+}
+
 // @Summary		Delete sensor
 // @Description	Delete sensor
 // @Id				delete-sensor
@@ -102,7 +184,7 @@ func GetSensorByID(svc service.SensorService) fiber.Handler {
 // @Security		Keycloak
 func DeleteSensor(svc service.SensorService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
 		id := strings.Clone(c.Params("id"))
 		if id == "" {
 			err := service.NewError(service.BadRequest, "invalid ID format")
@@ -119,6 +201,117 @@ func DeleteSensor(svc service.SensorService) fiber.Handler {
 	}
 }
 
+// @Summary		Get sensors that need battery maintenance
+// @Description	Get all sensors whose battery level is below the configured threshold or whose battery is estimated to be empty soon. The sensors that run empty first are listed first.
+// @Id				get-sensor-maintenance
+// @Tags			Sensor
+// @Produce		json
+// @Success		200	{object}	entities.SensorMaintenanceListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/maintenance [get]
+// @Security		Keycloak
+func GetSensorMaintenance(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetMaintenanceList(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.SensorMaintenanceListResponse{
+			Data: sensorMapper.FromBatteryResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get sensor data history
+// @Description	Get the sensor data of a sensor in a time range. The data can be downsampled to hourly or daily averages.
+// @Id				get-sensor-data-history
+// @Tags			Sensor
+// @Produce		json
+// @Success		200	{object}	entities.SensorDataHistoryResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/data [get]
+// @Param			sensor_id	path	string	true	"Sensor ID"
+// @Param			from		query	string	false	"Start of the time range (RFC3339), defaults to seven days before 'to'"
+// @Param			to			query	string	false	"End of the time range (RFC3339), defaults to now"
+// @Param			resolution	query	string	false	"Resolution of the data (raw, hourly, daily), defaults to raw"
+// @Security		Keycloak
+func GetSensorDataHistory(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		query, err := parseHistoryQuery(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetSensorDataHistory(ctx, id, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.SensorDataHistoryResponse{
+			SensorID:   id,
+			Resolution: entities.SensorDataResolution(query.Resolution),
+			From:       query.From,
+			To:         query.To,
+			Data:       sensorMapper.FromSensorDataAggregateResponse(domainData),
+		})
+	}
+}
+
+func parseHistoryQuery(c *fiber.Ctx) (*domain.SensorDataHistoryQuery, error) {
+	query := &domain.SensorDataHistoryQuery{
+		To:         time.Now(),
+		Resolution: domain.SensorDataResolutionRaw,
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'to' format, expected RFC3339")
+		}
+		query.To = to
+	}
+
+	query.From = query.To.Add(-7 * 24 * time.Hour)
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "invalid 'from' format, expected RFC3339")
+		}
+		query.From = from
+	}
+
+	if resolution := c.Query("resolution"); resolution != "" {
+		switch domain.SensorDataResolution(resolution) {
+		case domain.SensorDataResolutionRaw, domain.SensorDataResolutionHourly, domain.SensorDataResolutionDaily:
+			query.Resolution = domain.SensorDataResolution(resolution)
+		default:
+			return nil, service.NewError(service.BadRequest, "invalid resolution, expected one of raw, hourly, daily")
+		}
+	}
+
+	if !query.From.Before(query.To) {
+		return nil, service.NewError(service.BadRequest, "'from' must be before 'to'")
+	}
+
+	return query, nil
+}
+
 func mapToDto(t *domain.Sensor) *entities.SensorResponse {
 	dto := sensorMapper.FromResponse(t)
 	return dto
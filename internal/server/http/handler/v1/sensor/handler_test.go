@@ -1,7 +1,9 @@
 package sensor_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
@@ -187,6 +189,178 @@ func TestGetSensorById(t *testing.T) {
 	})
 }
 
+func TestCreateSensor(t *testing.T) {
+	t.Run("should create sensor successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor", sensor.CreateSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Create(
+			mock.Anything,
+			&entities.SensorCreate{
+				ID:        TestSensorCreateRequest.ID,
+				Status:    entities.SensorStatusOnline,
+				Latitude:  TestSensorCreateRequest.Latitude,
+				Longitude: TestSensorCreateRequest.Longitude,
+			},
+		).Return(TestSensor, nil)
+
+		// when
+		body, _ := json.Marshal(TestSensorCreateRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.SensorResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensor.ID, response.ID)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should create sensor with unknown status when status is missing", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor", sensor.CreateSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Create(
+			mock.Anything,
+			mock.MatchedBy(func(sc *entities.SensorCreate) bool {
+				return sc.Status == entities.SensorStatusUnknown
+			}),
+		).Return(TestSensor, nil)
+
+		// when
+		body := []byte(`{"id": "sensor-5", "latitude": 54.8, "longitude": 9.4}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor", sensor.CreateSensor(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor", bytes.NewBufferString(`{"id": 1`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor", sensor.CreateSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		body, _ := json.Marshal(TestSensorCreateRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+}
+
+func TestUpdateSensor(t *testing.T) {
+	t.Run("should update sensor successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id", sensor.UpdateSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Update(
+			mock.Anything,
+			"sensor-1",
+			&entities.SensorUpdate{
+				Status:    entities.SensorStatusOffline,
+				Latitude:  TestSensorUpdateRequest.Latitude,
+				Longitude: TestSensorUpdateRequest.Longitude,
+			},
+		).Return(TestSensor, nil)
+
+		// when
+		body, _ := json.Marshal(TestSensorUpdateRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensor.ID, response.ID)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id", sensor.UpdateSensor(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-1", bytes.NewBufferString(`{"status": 1`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 for non-existing sensor", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Put("/v1/sensor/:id", sensor.UpdateSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Update(mock.Anything, "sensor-99", mock.Anything).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		body, _ := json.Marshal(TestSensorUpdateRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/sensor/sensor-99", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+}
+
 func TestDeleteSensor(t *testing.T) {
 	t.Run("should delete sensor successfully", func(t *testing.T) {
 		mockSensorService := serviceMock.NewMockSensorService(t)
@@ -233,3 +407,218 @@ func TestDeleteSensor(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 }
+
+func TestGetSensorMaintenance(t *testing.T) {
+	t.Run("should return sensors that need battery maintenance", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Get("/v1/sensor/maintenance", sensor.GetSensorMaintenance(mockSensorService))
+
+		emptyAt := currentTime.Add(48 * time.Hour)
+		mockSensorService.EXPECT().GetMaintenanceList(mock.Anything).Return([]*entities.SensorBattery{
+			{SensorID: "sensor-1", Level: 3.1, MeasuredAt: currentTime, DischargePerDay: 0.05, SampleCount: 12, EstimatedEmptyAt: &emptyAt, BelowThreshold: true},
+			{SensorID: "sensor-2", Level: 3.2, MeasuredAt: currentTime, SampleCount: 3, BelowThreshold: true},
+		}, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/maintenance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorMaintenanceListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, "sensor-1", response.Data[0].SensorID)
+		assert.Equal(t, 3.1, response.Data[0].Level)
+		assert.True(t, response.Data[0].BelowThreshold)
+		assert.NotNil(t, response.Data[0].EstimatedEmptyAt)
+		assert.Nil(t, response.Data[1].EstimatedEmptyAt)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Get("/v1/sensor/maintenance", sensor.GetSensorMaintenance(mockSensorService))
+
+		mockSensorService.EXPECT().GetMaintenanceList(mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/maintenance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+}
+
+func TestGetSensorDataHistory(t *testing.T) {
+	t.Run("should return sensor data history successfully", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		handler := sensor.GetSensorDataHistory(mockSensorService)
+
+		from := time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC)
+		mockSensorService.EXPECT().GetSensorDataHistory(
+			mock.Anything,
+			"sensor-1",
+			&entities.SensorDataHistoryQuery{From: from, To: to, Resolution: entities.SensorDataResolutionHourly},
+		).Return(TestSensorDataHistory, nil)
+
+		app.Get("/v1/sensor/:id/data", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/data?from=2024-11-10T00:00:00Z&to=2024-11-11T00:00:00Z&resolution=hourly", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorDataHistoryResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "sensor-1", response.SensorID)
+		assert.Equal(t, serverEntities.SensorDataResolutionHourly, response.Resolution)
+		assert.True(t, from.Equal(response.From))
+		assert.True(t, to.Equal(response.To))
+		assert.Len(t, response.Data, len(TestSensorDataHistory))
+		for i, data := range response.Data {
+			assert.True(t, TestSensorDataHistory[i].Timestamp.Equal(data.Timestamp))
+			assert.Equal(t, TestSensorDataHistory[i].SampleCount, data.SampleCount)
+			assert.Equal(t, TestSensorDataHistory[i].Battery, data.Battery)
+			assert.Len(t, data.Watermarks, len(TestSensorDataHistory[i].Watermarks))
+		}
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should use raw resolution and last seven days by default", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		handler := sensor.GetSensorDataHistory(mockSensorService)
+
+		mockSensorService.EXPECT().GetSensorDataHistory(
+			mock.Anything,
+			"sensor-1",
+			mock.MatchedBy(func(q *entities.SensorDataHistoryQuery) bool {
+				return q.Resolution == entities.SensorDataResolutionRaw && q.To.Sub(q.From) == 7*24*time.Hour
+			}),
+		).Return([]*entities.SensorDataAggregate{}, nil)
+
+		app.Get("/v1/sensor/:id/data", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/data", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorDataHistoryResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.SensorDataResolutionRaw, response.Resolution)
+		assert.Len(t, response.Data, 0)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid query parameters", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+		}{
+			{name: "invalid from", query: "from=yesterday"},
+			{name: "invalid to", query: "to=2024-13-01"},
+			{name: "invalid resolution", query: "resolution=weekly"},
+			{name: "from after to", query: "from=2024-11-12T00:00:00Z&to=2024-11-11T00:00:00Z"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockSensorService := serviceMock.NewMockSensorService(t)
+				app := fiber.New()
+				handler := sensor.GetSensorDataHistory(mockSensorService)
+
+				app.Get("/v1/sensor/:id/data", handler)
+
+				// when
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/data?"+tt.query, nil)
+				resp, err := app.Test(req, -1)
+				defer resp.Body.Close()
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+				mockSensorService.AssertNotCalled(t, "GetSensorDataHistory")
+			})
+		}
+	})
+
+	t.Run("should return 404 when sensor not found", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		handler := sensor.GetSensorDataHistory(mockSensorService)
+
+		mockSensorService.EXPECT().GetSensorDataHistory(
+			mock.Anything,
+			"sensor-1",
+			mock.Anything,
+		).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		app.Get("/v1/sensor/:id/data", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/data", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app := fiber.New()
+		handler := sensor.GetSensorDataHistory(mockSensorService)
+
+		mockSensorService.EXPECT().GetSensorDataHistory(
+			mock.Anything,
+			"sensor-1",
+			mock.Anything,
+		).Return(nil, errors.New("service error"))
+
+		app.Get("/v1/sensor/:id/data", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/data", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,179 @@
+package sensor
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	csvHeaderID        = "id"
+	csvHeaderLatitude  = "latitude"
+	csvHeaderLongitude = "longitude"
+	csvHeaderTreeID    = "tree_id"
+)
+
+var requiredCSVHeaders = []string{csvHeaderID, csvHeaderLatitude, csvHeaderLongitude}
+
+// @Summary		Import sensors
+// @Description	Create multiple sensors with their coordinates and an optional tree assignment in a single transaction. The sensors can be sent as a JSON array or as a CSV file with the columns id, latitude, longitude and optional tree_id. If one sensor can't be created or its tree is already linked to a sensor, no sensor will be created.
+// @Id				import-sensors
+// @Tags			Sensor
+// @Accept			json
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		201	{object}	entities.SensorListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/import [post]
+// @Param			body	body		[]entities.SensorImportRequest	false	"Sensors to import"
+// @Param			file	formData	file							false	"CSV file to import"
+// @Security		Keycloak
+func ImportSensors(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req []*entities.SensorImportRequest
+		if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+			fileHeader, err := c.FormFile("file")
+			if err != nil {
+				return errorhandler.HandleError(service.NewError(service.BadRequest, "failed to retrieve file"))
+			}
+
+			if !isCSVFile(fileHeader) {
+				return errorhandler.HandleError(service.NewError(service.BadRequest, "uploaded file is not a valid CSV file"))
+			}
+
+			req, err = parseCSVFile(fileHeader)
+			if err != nil {
+				return errorhandler.HandleError(err)
+			}
+		} else if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Import(ctx, sensorMapper.FromImportRequestList(req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.SensorListResponse{
+			Data:       sensorMapper.FromResponseList(domainData),
+			Pagination: entities.Pagination{},
+		})
+	}
+}
+
+func isCSVFile(fileHeader *multipart.FileHeader) bool {
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	return ext == ".csv" || fileHeader.Header.Get(fiber.HeaderContentType) == "text/csv"
+}
+
+func parseCSVFile(fileHeader *multipart.FileHeader) ([]*entities.SensorImportRequest, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, service.NewError(service.InternalError, "failed to open uploaded file")
+	}
+
+	defer func(file multipart.File) {
+		if err := file.Close(); err != nil {
+			slog.Error("error closing file", "error", err)
+		}
+	}(file)
+
+	return parseCSV(file)
+}
+
+func parseCSV(r io.Reader) ([]*entities.SensorImportRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, "failed to read csv header")
+	}
+
+	headerIndexMap := make(map[string]int, len(header))
+	for i, h := range header {
+		headerIndexMap[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for _, h := range requiredCSVHeaders {
+		if _, ok := headerIndexMap[h]; !ok {
+			return nil, service.NewError(service.BadRequest, "missing expected header: "+h)
+		}
+	}
+
+	var sensors []*entities.SensorImportRequest
+	for row := range utils.NumberSequence(1) {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid csv format at row %d: %s", row, err.Error()))
+		}
+
+		sensor, err := parseRowToSensor(row, record, headerIndexMap)
+		if err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, sensor)
+	}
+
+	return sensors, nil
+}
+
+func parseRowToSensor(row int, record []string, headerIndexMap map[string]int) (*entities.SensorImportRequest, error) {
+	id := strings.TrimSpace(record[headerIndexMap[csvHeaderID]])
+	if id == "" {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' value at row %d", csvHeaderID, row))
+	}
+
+	latitude, err := parseCoordinate(record[headerIndexMap[csvHeaderLatitude]])
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' value at row %d", csvHeaderLatitude, row))
+	}
+
+	longitude, err := parseCoordinate(record[headerIndexMap[csvHeaderLongitude]])
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' value at row %d", csvHeaderLongitude, row))
+	}
+
+	sensor := &entities.SensorImportRequest{
+		ID:        id,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+
+	if idx, ok := headerIndexMap[csvHeaderTreeID]; ok {
+		if treeIDStr := strings.TrimSpace(record[idx]); treeIDStr != "" {
+			treeID, err := strconv.ParseInt(treeIDStr, 10, 32)
+			if err != nil {
+				return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' value at row %d", csvHeaderTreeID, row))
+			}
+			sensor.TreeID = utils.P(int32(treeID))
+		}
+	}
+
+	return sensor, nil
+}
+
+func parseCoordinate(value string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", "."), 64)
+}
@@ -0,0 +1,218 @@
+package sensor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCSVRequest(t *testing.T, filename, content string) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportSensors(t *testing.T) {
+	t.Run("should import sensors from json", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		mockSensorService.EXPECT().Import(
+			mock.Anything,
+			[]*entities.SensorImport{
+				{ID: "sensor-1", Latitude: 54.82124518093376, Longitude: 9.485702120628517, TreeID: utils.P(int32(1))},
+				{ID: "sensor-2", Latitude: 54.78780993841013, Longitude: 9.444052105200551},
+			},
+		).Return(TestSensorList[:2], nil)
+
+		// when
+		body, _ := json.Marshal(TestSensorImportRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/import", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.SensorListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, TestSensorList[0].ID, response.Data[0].ID)
+		assert.Equal(t, TestSensorList[1].ID, response.Data[1].ID)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should import sensors from csv file", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		mockSensorService.EXPECT().Import(
+			mock.Anything,
+			[]*entities.SensorImport{
+				{ID: "sensor-1", Latitude: 54.82124518093376, Longitude: 9.485702120628517, TreeID: utils.P(int32(1))},
+				{ID: "sensor-2", Latitude: 54.78780993841013, Longitude: 9.444052105200551},
+			},
+		).Return(TestSensorList[:2], nil)
+
+		csvContent := "id,latitude,longitude,tree_id\n" +
+			"sensor-1,54.82124518093376,9.485702120628517,1\n" +
+			"sensor-2,54.78780993841013,9.444052105200551,\n"
+
+		// when
+		resp, err := app.Test(newCSVRequest(t, "sensors.csv", csvContent), -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.SensorListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should import sensors from csv file without tree column", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		mockSensorService.EXPECT().Import(
+			mock.Anything,
+			[]*entities.SensorImport{
+				{ID: "sensor-1", Latitude: 54.82124518093376, Longitude: 9.485702120628517},
+			},
+		).Return(TestSensorList[:1], nil)
+
+		csvContent := "id,latitude,longitude\nsensor-1,54.82124518093376,9.485702120628517\n"
+
+		// when
+		resp, err := app.Test(newCSVRequest(t, "sensors.csv", csvContent), -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid csv files", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filename string
+			content  string
+		}{
+			{name: "not a csv file", filename: "sensors.txt", content: "id,latitude,longitude\n"},
+			{name: "missing header", filename: "sensors.csv", content: "id,latitude\nsensor-1,54.8\n"},
+			{name: "missing id", filename: "sensors.csv", content: "id,latitude,longitude\n,54.8,9.4\n"},
+			{name: "invalid latitude", filename: "sensors.csv", content: "id,latitude,longitude\nsensor-1,abc,9.4\n"},
+			{name: "invalid tree id", filename: "sensors.csv", content: "id,latitude,longitude,tree_id\nsensor-1,54.8,9.4,abc\n"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				app := fiber.New()
+				mockSensorService := serviceMock.NewMockSensorService(t)
+				app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+				// when
+				resp, err := app.Test(newCSVRequest(t, tt.filename, tt.content), -1)
+				defer resp.Body.Close()
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/import", bytes.NewBufferString(`{"id": "sensor-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when sensor id is already taken", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		mockSensorService.EXPECT().Import(mock.Anything, mock.Anything).Return(nil, service.ErrSensorIDTaken)
+
+		// when
+		body, _ := json.Marshal(TestSensorImportRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/import", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/import", sensor.ImportSensors(mockSensorService))
+
+		mockSensorService.EXPECT().Import(mock.Anything, mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		body, _ := json.Marshal(TestSensorImportRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/import", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,90 @@
+package sensor
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// @Summary		Decommission sensor
+// @Description	Retire a sensor and unlink it from its trees and flowerbeds. The sensor and its data are kept, but new data of the sensor is rejected.
+// @Id				decommission-sensor
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	entities.SensorResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/decommission [post]
+// @Param			sensor_id	path	string								true	"Sensor ID"
+// @Param			body		body	entities.SensorDecommissionRequest	true	"Sensor Decommission Request"
+// @Security		Keycloak
+func DecommissionSensor(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.SensorDecommissionRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Decommission(ctx, id, sensorMapper.FromDecommissionRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Replace sensor
+// @Description	Retire a sensor and link its trees and flowerbeds to the replacement sensor. A replacement that does not exist yet is created at the location of the retired sensor, an existing replacement that is already linked to a tree is rejected. The data of the retired sensor stays assigned to the trees it was measured at.
+// @Id				replace-sensor
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	entities.SensorResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/replace [post]
+// @Param			sensor_id	path	string							true	"Sensor ID"
+// @Param			body		body	entities.SensorReplaceRequest	true	"Sensor Replace Request"
+// @Security		Keycloak
+func ReplaceSensor(svc service.SensorService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
+		id := strings.Clone(c.Params("id"))
+		if id == "" {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.SensorReplaceRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Replace(ctx, id, sensorMapper.FromReplaceRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorMapper.FromResponse(domainData))
+	}
+}
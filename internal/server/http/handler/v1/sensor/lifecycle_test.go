@@ -0,0 +1,189 @@
+package sensor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDecommissionSensor(t *testing.T) {
+	t.Run("should decommission sensor successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/decommission", sensor.DecommissionSensor(mockSensorService))
+
+		decommissioned := &entities.Sensor{
+			ID:                 "sensor-1",
+			Status:             entities.SensorStatusOffline,
+			DecommissionedAt:   utils.P(time.Date(2025, 1, 28, 9, 0, 0, 0, time.UTC)),
+			DecommissionReason: utils.P("water damage"),
+		}
+
+		mockSensorService.EXPECT().Decommission(
+			mock.Anything,
+			"sensor-1",
+			&entities.SensorDecommission{Reason: "water damage"},
+		).Return(decommissioned, nil)
+
+		// when
+		body, _ := json.Marshal(serverEntities.SensorDecommissionRequest{Reason: "water damage"})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/decommission", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", response.ID)
+		assert.Equal(t, decommissioned.DecommissionedAt.Unix(), response.DecommissionedAt.Unix())
+		assert.Equal(t, decommissioned.DecommissionReason, response.DecommissionReason)
+		assert.Nil(t, response.ReplacedBy)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/decommission", sensor.DecommissionSensor(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/decommission", bytes.NewBufferString(`{"reason": 1`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when sensor is already decommissioned", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/decommission", sensor.DecommissionSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Decommission(mock.Anything, "sensor-1", mock.Anything).Return(nil, service.ErrSensorDecommissioned)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/decommission", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when sensor not found", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/decommission", sensor.DecommissionSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Decommission(mock.Anything, "sensor-1", mock.Anything).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/decommission", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestReplaceSensor(t *testing.T) {
+	t.Run("should replace sensor successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/replace", sensor.ReplaceSensor(mockSensorService))
+
+		replacement := &entities.Sensor{
+			ID:        "sensor-2",
+			Status:    entities.SensorStatusUnknown,
+			Latitude:  54.82124518093376,
+			Longitude: 9.485702120628517,
+		}
+
+		mockSensorService.EXPECT().Replace(
+			mock.Anything,
+			"sensor-1",
+			&entities.SensorReplace{ReplacementID: "sensor-2", Reason: "battery swap"},
+		).Return(replacement, nil)
+
+		// when
+		body, _ := json.Marshal(serverEntities.SensorReplaceRequest{ReplacementID: "sensor-2", Reason: "battery swap"})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/replace", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-2", response.ID)
+		assert.Equal(t, replacement.Latitude, response.Latitude)
+		assert.Nil(t, response.DecommissionedAt)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/replace", sensor.ReplaceSensor(mockSensorService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/replace", bytes.NewBufferString(`{"replacement_id": `))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when service returns validation error", func(t *testing.T) {
+		app := fiber.New()
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		app.Post("/v1/sensor/:id/replace", sensor.ReplaceSensor(mockSensorService))
+
+		mockSensorService.EXPECT().Replace(mock.Anything, "sensor-1", mock.Anything).Return(nil, service.NewError(service.BadRequest, "validation error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/replace", bytes.NewBufferString(`{"replacement_id": "sensor-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -7,6 +7,17 @@ import (
 
 func RegisterRoutes(r fiber.Router, svc service.SensorService) {
 	r.Get("/", GetAllSensors(svc))
+	r.Post("/", CreateSensor(svc))
+	r.Post("/import", ImportSensors(svc))
+	r.Get("/maintenance", GetSensorMaintenance(svc))
+	r.Get("/export", ExportSensorData(svc))
 	r.Get("/:id", GetSensorByID(svc))
+	r.Put("/:id", UpdateSensor(svc))
+	r.Get("/:id/data", GetSensorDataHistory(svc))
+	r.Get("/:id/calibration", GetSensorCalibration(svc))
+	r.Put("/:id/calibration", UpdateSensorCalibration(svc))
+	r.Delete("/:id/calibration", DeleteSensorCalibration(svc))
+	r.Post("/:id/decommission", DecommissionSensor(svc))
+	r.Post("/:id/replace", ReplaceSensor(svc))
 	r.Delete("/:id", DeleteSensor(svc))
 }
@@ -1,11 +1,16 @@
 package sensor_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +37,95 @@ func TestRegisterRoutes(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 		})
+
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().Create(
+				mock.Anything,
+				mock.Anything,
+			).Return(TestSensor, nil)
+
+			// when
+			body, _ := json.Marshal(TestSensorCreateRequest)
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor/import", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().Import(
+				mock.Anything,
+				mock.Anything,
+			).Return(TestSensorList[:2], nil)
+
+			// when
+			body, _ := json.Marshal(TestSensorImportRequest)
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/import", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor/maintenance", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().GetMaintenanceList(
+				mock.Anything,
+			).Return([]*entities.SensorBattery{}, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/maintenance", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor/export", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().ExportSensorData(
+				mock.Anything,
+				mock.Anything,
+			).Return(io.NopCloser(strings.NewReader("sensor_id\n")), nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/export", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
 	})
 
 	t.Run("/v1/sensor/:id", func(t *testing.T) {
@@ -55,6 +149,29 @@ func TestRegisterRoutes(t *testing.T) {
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 		})
 
+		t.Run("should call PUT handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().Update(
+				mock.Anything,
+				"sensor-1",
+				mock.Anything,
+			).Return(TestSensor, nil)
+
+			// when
+			body, _ := json.Marshal(TestSensorUpdateRequest)
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/sensor-1", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
 		t.Run("should call DELETE handler", func(t *testing.T) {
 			mockSensorService := serviceMock.NewMockSensorService(t)
 			app := fiber.New()
@@ -75,4 +192,115 @@ func TestRegisterRoutes(t *testing.T) {
 			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 		})
 	})
+
+	t.Run("/v1/sensor/:id/data", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().GetSensorDataHistory(
+				mock.Anything,
+				"sensor-1",
+				mock.Anything,
+			).Return(TestSensorDataHistory, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/sensor-1/data", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+	t.Run("/v1/sensor/:id/calibration", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().GetCalibration(
+				mock.Anything,
+				"sensor-1",
+			).Return(TestSensorCalibration, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/sensor-1/calibration", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().DeleteCalibration(
+				mock.Anything,
+				"sensor-1",
+			).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/sensor-1/calibration", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+	t.Run("/v1/sensor/:id/decommission", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().Decommission(
+				mock.Anything,
+				"sensor-1",
+				mock.Anything,
+			).Return(TestSensor, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/sensor-1/decommission", strings.NewReader(`{"reason": "broken"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor/:id/replace", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSensorService := serviceMock.NewMockSensorService(t)
+			app := fiber.New()
+			sensor.RegisterRoutes(app, mockSensorService)
+
+			mockSensorService.EXPECT().Replace(
+				mock.Anything,
+				"sensor-1",
+				mock.Anything,
+			).Return(TestSensor, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/sensor-1/replace", strings.NewReader(`{"replacement_id": "sensor-2"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
 }
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 var (
@@ -78,4 +80,64 @@ var (
 			LatestData: &entities.SensorData{},
 		},
 	}
+
+	TestSensorDataHistory = []*entities.SensorDataAggregate{
+		{
+			Timestamp:   time.Date(2024, 11, 10, 10, 0, 0, 0, time.UTC),
+			SampleCount: 4,
+			Battery:     34.5,
+			Humidity:    50.25,
+			Temperature: 20.5,
+			Watermarks: []entities.WatermarkAggregate{
+				{Depth: 30, Centibar: 38.5, Resistance: 23.25},
+				{Depth: 60, Centibar: 40, Resistance: 24},
+				{Depth: 90, Centibar: 42.75, Resistance: 25.5},
+			},
+		},
+		{
+			Timestamp:   time.Date(2024, 11, 10, 11, 0, 0, 0, time.UTC),
+			SampleCount: 3,
+			Battery:     34.2,
+			Humidity:    49.5,
+			Temperature: 21,
+			Watermarks: []entities.WatermarkAggregate{
+				{Depth: 30, Centibar: 39, Resistance: 23.5},
+				{Depth: 60, Centibar: 41, Resistance: 24.5},
+				{Depth: 90, Centibar: 43, Resistance: 26},
+			},
+		},
+	}
 )
+
+var (
+	TestSensorCreateRequest = &serverEntities.SensorCreateRequest{
+		ID:        "sensor-5",
+		Status:    serverEntities.SensorStatusOnline,
+		Latitude:  54.82124518093376,
+		Longitude: 9.485702120628517,
+	}
+
+	TestSensorUpdateRequest = &serverEntities.SensorUpdateRequest{
+		Status:    serverEntities.SensorStatusOffline,
+		Latitude:  54.82124518093376,
+		Longitude: 9.485702120628517,
+	}
+
+	TestSensorImportRequest = []*serverEntities.SensorImportRequest{
+		{ID: "sensor-1", Latitude: 54.82124518093376, Longitude: 9.485702120628517, TreeID: utils.P(int32(1))},
+		{ID: "sensor-2", Latitude: 54.78780993841013, Longitude: 9.444052105200551},
+	}
+)
+
+var TestSensorCalibration = &entities.SensorCalibration{
+	SensorID:               "sensor-1",
+	CreatedAt:              time.Now(),
+	UpdatedAt:              time.Now(),
+	CentibarOffset:         -2,
+	TemperatureCoefficient: 0.02,
+	ReferenceTemperature:   21,
+	Curve: []entities.CalibrationPoint{
+		{Resistance: 500, Centibar: 0},
+		{Resistance: 10000, Centibar: 100},
+	},
+}
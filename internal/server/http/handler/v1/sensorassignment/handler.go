@@ -0,0 +1,159 @@
+package sensorassignment
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+var (
+	sensorAssignmentMapper = generated.SensorAssignmentHTTPMapperImpl{}
+)
+
+// @Summary		Get all sensor assignment reviews
+// @Description	Get all sensors that could not be linked to a tree automatically because several trees are almost equally close
+// @Id				get-all-sensor-assignment-reviews
+// @Tags			Sensor Assignment
+// @Produce		json
+// @Success		200	{object}	entities.SensorAssignmentReviewListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor-assignment [get]
+// @Param			status	query	string	false	"Review status (pending, resolved, dismissed)"
+// @Security		Keycloak
+func GetAllSensorAssignmentReviews(svc service.SensorAssignmentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		var domainData []*domain.SensorAssignmentReview
+		var err error
+
+		statusStr := c.Query("status")
+		switch domain.SensorAssignmentStatus(statusStr) {
+		case "":
+			domainData, err = svc.GetAll(ctx)
+		case domain.SensorAssignmentStatusPending, domain.SensorAssignmentStatusResolved, domain.SensorAssignmentStatusDismissed:
+			domainData, err = svc.GetAllByStatus(ctx, domain.SensorAssignmentStatus(statusStr))
+		default:
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "invalid sensor assignment status"))
+		}
+
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.SensorAssignmentReviewListResponse{
+			Data:       sensorAssignmentMapper.FromResponseList(domainData),
+			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+		})
+	}
+}
+
+// @Summary		Get sensor assignment review by ID
+// @Description	Get sensor assignment review by ID including the candidate trees
+// @Id				get-sensor-assignment-review-by-id
+// @Tags			Sensor Assignment
+// @Produce		json
+// @Success		200	{object}	entities.SensorAssignmentReviewResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor-assignment/{id} [get]
+// @Param			id	path	integer	true	"Sensor assignment review ID"
+// @Security		Keycloak
+func GetSensorAssignmentReviewByID(svc service.SensorAssignmentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorAssignmentMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Resolve sensor assignment review
+// @Description	Link the sensor of a pending review to the chosen tree and mark the review as resolved
+// @Id				resolve-sensor-assignment-review
+// @Tags			Sensor Assignment
+// @Produce		json
+// @Success		200	{object}	entities.SensorAssignmentReviewResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor-assignment/{id}/resolve [post]
+// @Param			id		path	integer									true	"Sensor assignment review ID"
+// @Param			body	body	entities.SensorAssignmentResolveRequest	true	"Chosen tree"
+// @Security		Keycloak
+func ResolveSensorAssignmentReview(svc service.SensorAssignmentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.SensorAssignmentResolveRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Resolve(ctx, int32(id), sensorAssignmentMapper.FromResolveRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorAssignmentMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Dismiss sensor assignment review
+// @Description	Close a pending review without linking the sensor to a tree
+// @Id				dismiss-sensor-assignment-review
+// @Tags			Sensor Assignment
+// @Produce		json
+// @Success		200	{object}	entities.SensorAssignmentReviewResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor-assignment/{id}/dismiss [post]
+// @Param			id	path	integer	true	"Sensor assignment review ID"
+// @Security		Keycloak
+func DismissSensorAssignmentReview(svc service.SensorAssignmentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Dismiss(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorAssignmentMapper.FromResponse(domainData))
+	}
+}
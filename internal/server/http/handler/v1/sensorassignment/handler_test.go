@@ -0,0 +1,267 @@
+package sensorassignment_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorassignment"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllSensorAssignmentReviews(t *testing.T) {
+	t.Run("should return all reviews", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment", sensorassignment.GetAllSensorAssignmentReviews(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(TestSensorAssignmentReviews, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorAssignmentReviewListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestSensorAssignmentReviews))
+		assert.Equal(t, "sensor-1", response.Data[0].SensorID)
+		assert.Equal(t, serverEntities.SensorAssignmentStatusPending, response.Data[0].Status)
+		assert.Len(t, response.Data[0].Candidates, 2)
+		assert.Equal(t, int32(1), response.Data[0].Candidates[0].TreeID)
+		assert.Nil(t, response.Data[0].TreeID)
+		assert.Equal(t, int32(3), *response.Data[1].TreeID)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return reviews filtered by status", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment", sensorassignment.GetAllSensorAssignmentReviews(mockSvc))
+
+		mockSvc.EXPECT().GetAllByStatus(mock.Anything, entities.SensorAssignmentStatusPending).Return(TestSensorAssignmentReviews[:1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment?status=pending", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorAssignmentReviewListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid status", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment", sensorassignment.GetAllSensorAssignmentReviews(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment?status=open", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment", sensorassignment.GetAllSensorAssignmentReviews(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetSensorAssignmentReviewByID(t *testing.T) {
+	t.Run("should return review by id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment/:id", sensorassignment.GetSensorAssignmentReviewByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestSensorAssignmentReviews[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorAssignmentReviewResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.ID)
+		assert.Len(t, response.Candidates, 2)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment/:id", sensorassignment.GetSensorAssignmentReviewByID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when review not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor-assignment/:id", sensorassignment.GetSensorAssignmentReviewByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor-assignment/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestResolveSensorAssignmentReview(t *testing.T) {
+	t.Run("should resolve review with chosen tree", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor-assignment/:id/resolve", sensorassignment.ResolveSensorAssignmentReview(mockSvc))
+
+		mockSvc.EXPECT().Resolve(mock.Anything, int32(1), &entities.SensorAssignmentResolve{TreeID: 2}).Return(TestSensorAssignmentReviews[1], nil)
+
+		// when
+		body, _ := json.Marshal(serverEntities.SensorAssignmentResolveRequest{TreeID: 2})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor-assignment/1/resolve", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorAssignmentReviewResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.SensorAssignmentStatusResolved, response.Status)
+	})
+
+	t.Run("should return 400 for invalid body", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor-assignment/:id/resolve", sensorassignment.ResolveSensorAssignmentReview(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor-assignment/1/resolve", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when review is already closed", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor-assignment/:id/resolve", sensorassignment.ResolveSensorAssignmentReview(mockSvc))
+
+		mockSvc.EXPECT().Resolve(mock.Anything, int32(2), mock.Anything).Return(nil, service.ErrSensorAssignmentClosed)
+
+		// when
+		body, _ := json.Marshal(serverEntities.SensorAssignmentResolveRequest{TreeID: 3})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor-assignment/2/resolve", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDismissSensorAssignmentReview(t *testing.T) {
+	t.Run("should dismiss review", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor-assignment/:id/dismiss", sensorassignment.DismissSensorAssignmentReview(mockSvc))
+
+		dismissed := *TestSensorAssignmentReviews[0]
+		dismissed.Status = entities.SensorAssignmentStatusDismissed
+		mockSvc.EXPECT().Dismiss(mock.Anything, int32(1)).Return(&dismissed, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor-assignment/1/dismiss", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorAssignmentReviewResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.SensorAssignmentStatusDismissed, response.Status)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor-assignment/:id/dismiss", sensorassignment.DismissSensorAssignmentReview(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor-assignment/abc/dismiss", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
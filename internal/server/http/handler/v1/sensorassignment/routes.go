@@ -0,0 +1,13 @@
+package sensorassignment
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.SensorAssignmentService) {
+	r.Get("/", GetAllSensorAssignmentReviews(svc))
+	r.Get("/:id", GetSensorAssignmentReviewByID(svc))
+	r.Post("/:id/resolve", ResolveSensorAssignmentReview(svc))
+	r.Post("/:id/dismiss", DismissSensorAssignmentReview(svc))
+}
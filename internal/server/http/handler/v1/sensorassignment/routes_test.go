@@ -0,0 +1,93 @@
+package sensorassignment_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorassignment"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/sensor-assignment", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+			app := fiber.New()
+			sensorassignment.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetAll(mock.Anything).Return(TestSensorAssignmentReviews, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor-assignment/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+			app := fiber.New()
+			sensorassignment.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestSensorAssignmentReviews[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor-assignment/:id/resolve", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+			app := fiber.New()
+			sensorassignment.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Resolve(mock.Anything, int32(1), mock.Anything).Return(TestSensorAssignmentReviews[1], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/resolve", bytes.NewBufferString(`{"tree_id": 3}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor-assignment/:id/dismiss", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorAssignmentService(t)
+			app := fiber.New()
+			sensorassignment.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Dismiss(mock.Anything, int32(1)).Return(TestSensorAssignmentReviews[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/dismiss", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
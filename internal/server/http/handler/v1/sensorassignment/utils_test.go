@@ -0,0 +1,37 @@
+package sensorassignment_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	currentTime                 = time.Now()
+	TestSensorAssignmentReviews = []*entities.SensorAssignmentReview{
+		{
+			ID:        1,
+			CreatedAt: currentTime,
+			UpdatedAt: currentTime,
+			SensorID:  "sensor-1",
+			Status:    entities.SensorAssignmentStatusPending,
+			Candidates: []*entities.TreeCandidate{
+				{TreeID: 1, Distance: 0.5},
+				{TreeID: 2, Distance: 1.2},
+			},
+		},
+		{
+			ID:        2,
+			CreatedAt: currentTime,
+			UpdatedAt: currentTime,
+			SensorID:  "sensor-2",
+			Status:    entities.SensorAssignmentStatusResolved,
+			TreeID:    utils.P(int32(3)),
+			Candidates: []*entities.TreeCandidate{
+				{TreeID: 3, Distance: 0.1},
+				{TreeID: 4, Distance: 0.2},
+			},
+		},
+	}
+)
@@ -0,0 +1,117 @@
+package sensorcommand
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	sensorCommandMapper = generated.SensorCommandHTTPMapperImpl{}
+)
+
+// @Summary		Get all commands of a sensor
+// @Description	Get all downlink commands of a sensor with their current state, newest first
+// @Id				get-all-sensor-commands
+// @Tags			Sensor Command
+// @Produce		json
+// @Success		200	{object}	entities.SensorCommandListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/command [get]
+// @Param			sensor_id	path	string	true	"Sensor ID"
+// @Security		Keycloak
+func GetAllSensorCommands(svc service.SensorCommandService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		sensorID := strings.Clone(c.Params("id"))
+		domainData, err := svc.GetAllBySensorID(ctx, sensorID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.SensorCommandListResponse{
+			Data:       sensorCommandMapper.FromResponseList(domainData),
+			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+		})
+	}
+}
+
+// @Summary		Get sensor command by ID
+// @Description	Get a downlink command of a sensor by ID
+// @Id				get-sensor-command-by-id
+// @Tags			Sensor Command
+// @Produce		json
+// @Success		200	{object}	entities.SensorCommandResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/command/{command_id} [get]
+// @Param			sensor_id	path	string	true	"Sensor ID"
+// @Param			command_id	path	integer	true	"Sensor command ID"
+// @Security		Keycloak
+func GetSensorCommandByID(svc service.SensorCommandService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		sensorID := strings.Clone(c.Params("id"))
+		id, err := strconv.Atoi(c.Params("command_id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if domainData.SensorID != sensorID {
+			return errorhandler.HandleError(service.NewError(service.NotFound, "sensor command not found"))
+		}
+
+		return c.JSON(sensorCommandMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Queue sensor command
+// @Description	Queue a downlink command for a sensor. The command is published to the downlink topic of the network server and acknowledged by the device afterwards.
+// @Id				create-sensor-command
+// @Tags			Sensor Command
+// @Produce		json
+// @Success		201	{object}	entities.SensorCommandResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/{sensor_id}/command [post]
+// @Param			sensor_id	path	string								true	"Sensor ID"
+// @Param			body		body	entities.SensorCommandCreateRequest	true	"Sensor command"
+// @Security		Keycloak
+func CreateSensorCommand(svc service.SensorCommandService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		sensorID := strings.Clone(c.Params("id"))
+		var req entities.SensorCommandCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Create(ctx, sensorID, sensorCommandMapper.FromCreateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(sensorCommandMapper.FromResponse(domainData))
+	}
+}
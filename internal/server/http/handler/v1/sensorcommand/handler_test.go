@@ -0,0 +1,207 @@
+package sensorcommand_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorcommand"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllSensorCommands(t *testing.T) {
+	t.Run("should return all commands of sensor", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command", sensorcommand.GetAllSensorCommands(mockSvc))
+
+		mockSvc.EXPECT().GetAllBySensorID(mock.Anything, "sensor-1").Return(TestSensorCommands, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/command", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorCommandListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestSensorCommands))
+		assert.Equal(t, serverEntities.SensorCommandTypeSetInterval, response.Data[0].Type)
+		assert.Equal(t, serverEntities.SensorCommandStatusAcknowledged, response.Data[0].Status)
+		assert.Equal(t, int32(900), *response.Data[0].ReportInterval)
+		assert.NotNil(t, response.Data[0].AcknowledgedAt)
+		assert.Nil(t, response.Data[1].ReportInterval)
+		assert.Nil(t, response.Data[1].SentAt)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 when sensor does not exist", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command", sensorcommand.GetAllSensorCommands(mockSvc))
+
+		mockSvc.EXPECT().GetAllBySensorID(mock.Anything, "sensor-99").Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-99/command", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetSensorCommandByID(t *testing.T) {
+	t.Run("should return command by id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command/:command_id", sensorcommand.GetSensorCommandByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestSensorCommands[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/command/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.SensorCommandResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.ID)
+		assert.Equal(t, "sensor-1", response.SensorID)
+	})
+
+	t.Run("should return 404 when command belongs to another sensor", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command/:command_id", sensorcommand.GetSensorCommandByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestSensorCommands[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-2/command/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command/:command_id", sensorcommand.GetSensorCommandByID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/command/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when command does not exist", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Get("/v1/sensor/:id/command/:command_id", sensorcommand.GetSensorCommandByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor/sensor-1/command/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateSensorCommand(t *testing.T) {
+	t.Run("should queue command", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/:id/command", sensorcommand.CreateSensorCommand(mockSvc))
+
+		expectedCreate := &entities.SensorCommandCreate{
+			Type:           entities.SensorCommandTypeSetInterval,
+			ReportInterval: utils.P(15 * time.Minute),
+		}
+		mockSvc.EXPECT().Create(mock.Anything, "sensor-1", expectedCreate).Return(TestSensorCommands[0], nil)
+
+		// when
+		body := bytes.NewBufferString(`{"type": "set_interval", "report_interval": 900}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/command", body)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.SensorCommandResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.ID)
+		assert.Equal(t, serverEntities.SensorCommandTypeSetInterval, response.Type)
+	})
+
+	t.Run("should return 400 for invalid body", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/:id/command", sensorcommand.CreateSensorCommand(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/command", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when service rejects command", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorCommandService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/:id/command", sensorcommand.CreateSensorCommand(mockSvc))
+
+		mockSvc.EXPECT().Create(mock.Anything, "sensor-1", mock.Anything).Return(nil, service.NewError(service.BadRequest, "validation error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/sensor-1/command", bytes.NewBufferString(`{"type": "set_interval"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
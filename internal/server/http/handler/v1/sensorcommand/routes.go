@@ -0,0 +1,13 @@
+package sensorcommand
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// RegisterRoutes registers the command routes below a sensor, it is mounted on the sensor router
+func RegisterRoutes(r fiber.Router, svc service.SensorCommandService) {
+	r.Get("/:id/command", GetAllSensorCommands(svc))
+	r.Post("/:id/command", CreateSensorCommand(svc))
+	r.Get("/:id/command/:command_id", GetSensorCommandByID(svc))
+}
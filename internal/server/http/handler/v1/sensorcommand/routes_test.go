@@ -0,0 +1,72 @@
+package sensorcommand_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorcommand"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/sensor/:id/command", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorCommandService(t)
+			app := fiber.New()
+			sensorcommand.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetAllBySensorID(mock.Anything, "sensor-1").Return(TestSensorCommands, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/sensor-1/command", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorCommandService(t)
+			app := fiber.New()
+			sensorcommand.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Create(mock.Anything, "sensor-1", mock.Anything).Return(TestSensorCommands[1], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/sensor-1/command", bytes.NewBufferString(`{"type": "reboot"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/sensor/:id/command/:command_id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorCommandService(t)
+			app := fiber.New()
+			sensorcommand.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestSensorCommands[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/sensor-1/command/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
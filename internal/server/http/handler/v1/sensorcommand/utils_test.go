@@ -0,0 +1,33 @@
+package sensorcommand_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	currentTime        = time.Now()
+	TestSensorCommands = []*entities.SensorCommand{
+		{
+			ID:             1,
+			CreatedAt:      currentTime,
+			UpdatedAt:      currentTime,
+			SensorID:       "sensor-1",
+			Type:           entities.SensorCommandTypeSetInterval,
+			Status:         entities.SensorCommandStatusAcknowledged,
+			ReportInterval: utils.P(15 * time.Minute),
+			SentAt:         &currentTime,
+			AcknowledgedAt: &currentTime,
+		},
+		{
+			ID:        2,
+			CreatedAt: currentTime,
+			UpdatedAt: currentTime,
+			SensorID:  "sensor-1",
+			Type:      entities.SensorCommandTypeReboot,
+			Status:    entities.SensorCommandStatusQueued,
+		},
+	}
+)
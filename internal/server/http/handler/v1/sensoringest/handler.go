@@ -0,0 +1,46 @@
+package sensoringest
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// @Summary		Ingest sensor uplink
+// @Description	Ingest a raw sensor uplink by http, e.g. as webhook of the network server where mqtt is not reachable. The payload is decoded and processed the same way as mqtt messages, rejected payloads are stored as dead letters. The request has to send the configured secret in the X-Ingest-Secret header or the hex encoded HMAC-SHA256 of the timestamp and the body joined by a dot in the X-Signature header together with the unix timestamp in the X-Signature-Timestamp header.
+// @Id				ingest-sensor-data
+// @Tags			Sensor
+// @Accept			json
+// @Produce		json
+// @Success		201	{object}	entities.SensorDataResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/sensor/ingest [post]
+// @Param			decoder			query	string	false	"Payload decoder, e.g. ttn, chirpstack or json. Defaults to the configured decoder"
+// @Param			X-Ingest-Secret	header	string	false	"Shared secret of the webhook"
+// @Param			X-Signature		header	string	false	"HMAC-SHA256 of the timestamp and body, optionally prefixed with sha256="
+// @Param			X-Signature-Timestamp	header	string	false	"Unix timestamp in seconds the signature is created at"
+// @Param			body			body	object	true	"Raw uplink payload"
+func IngestSensorData(svc service.SensorIngestService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		decoder := strings.Clone(c.Query("decoder"))
+		payload := bytes.Clone(c.Body())
+		if len(payload) == 0 {
+			err := service.NewError(service.BadRequest, "payload must not be empty")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Ingest(ctx, decoder, payload)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(mapper.MapLatestDataToResponse(domainData))
+	}
+}
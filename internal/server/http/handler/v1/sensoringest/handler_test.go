@@ -0,0 +1,112 @@
+package sensoringest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensoringest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIngestSensorData(t *testing.T) {
+	t.Run("should ingest payload and return created sensor data", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorIngestService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/ingest", sensoringest.IngestSensorData(mockSvc))
+
+		mockSvc.EXPECT().Ingest(mock.Anything, "", TestRawPayload).Return(TestSensorData, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/ingest", bytes.NewReader(TestRawPayload))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.SensorDataResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensorData.Data.Battery, response.Battery)
+		assert.Len(t, response.Watermarks, 1)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should pass decoder of query to service", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorIngestService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/ingest", sensoringest.IngestSensorData(mockSvc))
+
+		mockSvc.EXPECT().Ingest(mock.Anything, "chirpstack", TestRawPayload).Return(TestSensorData, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/ingest?decoder=chirpstack", bytes.NewReader(TestRawPayload))
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when payload is empty", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorIngestService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/ingest", sensoringest.IngestSensorData(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/ingest", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockSvc.AssertNotCalled(t, "Ingest")
+	})
+
+	t.Run("should return 400 when decoder is unknown", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorIngestService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/ingest", sensoringest.IngestSensorData(mockSvc))
+
+		mockSvc.EXPECT().Ingest(mock.Anything, "unknown", TestRawPayload).Return(nil, service.ErrUnknownSensorDecoder)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/ingest?decoder=unknown", bytes.NewReader(TestRawPayload))
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockSensorIngestService(t)
+		app := fiber.New()
+		app.Post("/v1/sensor/ingest", sensoringest.IngestSensorData(mockSvc))
+
+		mockSvc.EXPECT().Ingest(mock.Anything, "", TestRawPayload).Return(nil, service.NewError(service.InternalError, "database down"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/sensor/ingest", bytes.NewReader(TestRawPayload))
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
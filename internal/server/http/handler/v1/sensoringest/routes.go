@@ -0,0 +1,11 @@
+package sensoringest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// RegisterRoutes registers the webhook for sensor uplinks, it is authenticated by the given middleware instead of a user token
+func RegisterRoutes(r fiber.Router, svc service.SensorIngestService, authMiddleware fiber.Handler) {
+	r.Post("/ingest", authMiddleware, IngestSensorData(svc))
+}
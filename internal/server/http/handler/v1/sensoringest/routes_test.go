@@ -0,0 +1,58 @@
+package sensoringest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensoringest"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/sensor/ingest", func(t *testing.T) {
+		t.Run("should call POST handler after auth middleware", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorIngestService(t)
+			app := fiber.New()
+			authCalled := false
+			sensoringest.RegisterRoutes(app, mockSvc, func(c *fiber.Ctx) error {
+				authCalled = true
+				return c.Next()
+			})
+
+			mockSvc.EXPECT().Ingest(mock.Anything, "", TestRawPayload).Return(TestSensorData, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/ingest", bytes.NewReader(TestRawPayload))
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			assert.True(t, authCalled)
+		})
+
+		t.Run("should not call POST handler when auth middleware rejects the request", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockSensorIngestService(t)
+			app := fiber.New()
+			sensoringest.RegisterRoutes(app, mockSvc, func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusUnauthorized)
+			})
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/ingest", bytes.NewReader(TestRawPayload))
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			mockSvc.AssertNotCalled(t, "Ingest")
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package sensoringest_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	currentTime    = time.Now()
+	TestRawPayload = []byte(`{"end_device_ids":{"device_id":"sensor-1"},"uplink_message":{"decoded_payload":{"battery":3.4}}}`)
+	TestSensorData = &entities.SensorData{
+		ID:        1,
+		SensorID:  "sensor-1",
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+		Data: &entities.MqttPayload{
+			Device:      "sensor-1",
+			Battery:     3.4,
+			Humidity:    50,
+			Temperature: 20,
+			Watermarks: []entities.Watermark{
+				{Centibar: 30, Resistance: 1200, Depth: 30},
+			},
+		},
+	}
+)
@@ -10,6 +10,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 )
 
 var (
@@ -134,7 +135,7 @@ func GetTreeBySensorID(svc service.TreeService) fiber.Handler {
 // @Param			body	body	entities.TreeCreateRequest	true	"Tree to create"
 func CreateTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
 		var req entities.TreeCreateRequest
 		if err := c.BodyParser(&req); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
@@ -168,7 +169,7 @@ func CreateTree(svc service.TreeService) fiber.Handler {
 // @Param			body	body	entities.TreeUpdateRequest	true	"Tree to update"
 func UpdateTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
 		id, err := strconv.Atoi(c.Params("id"))
 		if err != nil {
 			err = service.NewError(service.BadRequest, "invalid ID format")
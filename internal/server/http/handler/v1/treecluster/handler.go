@@ -1,13 +1,18 @@
 package treecluster
 
 import (
+	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
@@ -29,19 +34,31 @@ var (
 // @Router			/v1/cluster [get]
 // @Param			page	query	string	false	"Page"
 // @Param			limit	query	string	false	"Limit"
+// @Param			sort_by	query	string	false	"Sort order: name, days_until_moderate, days_until_bad"
+// @Param			min_coverage	query	number	false	"Minimum share of trees with sensor data, between 0 and 1"
+// @Param			max_coverage	query	number	false	"Maximum share of trees with sensor data, between 0 and 1"
+// @Param			measured_after	query	string	false	"Latest sensor data at or after this time (RFC3339)"
+// @Param			measured_before	query	string	false	"Latest sensor data before this time (RFC3339)"
+// @Param			min_moisture_level	query	number	false	"Minimum moisture level in centibar"
+// @Param			max_moisture_level	query	number	false	"Maximum moisture level in centibar"
 // @Security		Keycloak
 func GetAllTreeClusters(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 
-		domainData, totalCount, err := svc.GetAll(ctx)
+		query, err := parseTreeClusterQuery(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, totalCount, err := svc.GetAll(ctx, query)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
 		data := make([]*entities.TreeClusterInListResponse, len(domainData))
-		for i, domain := range domainData {
-			data[i] = treeClusterMapper.FromInListResponse(domain)
+		for i, cluster := range domainData {
+			data[i] = treeClusterMapper.FromInListResponse(cluster)
 		}
 
 		return c.JSON(entities.TreeClusterListResponse{
@@ -100,7 +117,7 @@ func GetTreeClusterByID(svc service.TreeClusterService) fiber.Handler {
 // @Security		Keycloak
 func CreateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
 
 		var req entities.TreeClusterCreateRequest
 		if err := c.BodyParser(&req); err != nil {
@@ -135,7 +152,7 @@ func CreateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 // @Security		Keycloak
 func UpdateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := storage.WithWateringStatusCause(c.Context(), domain.WateringStatusCauseManual)
 		id, err := strconv.Atoi(c.Params("treecluster_id"))
 		if err != nil {
 			err := service.NewError(service.BadRequest, "invalid ID format")
@@ -188,3 +205,77 @@ func DeleteTreeCluster(svc service.TreeClusterService) fiber.Handler {
 		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
+
+func parseTreeClusterQuery(c *fiber.Ctx) (domain.TreeClusterQuery, error) {
+	var query domain.TreeClusterQuery
+	if sortStr := c.Query("sort_by"); sortStr != "" {
+		sortBy, ok := domain.ParseTreeClusterSort(sortStr)
+		if !ok {
+			return query, service.NewError(service.BadRequest, "invalid sort order, expected one of name, days_until_moderate, days_until_bad")
+		}
+		query.SortBy = sortBy
+	}
+
+	var err error
+	if query.MinCoverage, err = parseCoverage(c, "min_coverage"); err != nil {
+		return query, err
+	}
+	if query.MaxCoverage, err = parseCoverage(c, "max_coverage"); err != nil {
+		return query, err
+	}
+	if query.MeasuredAfter, err = parseTime(c, "measured_after"); err != nil {
+		return query, err
+	}
+	if query.MeasuredBefore, err = parseTime(c, "measured_before"); err != nil {
+		return query, err
+	}
+	if query.MinMoistureLevel, err = parseFloat(c, "min_moisture_level"); err != nil {
+		return query, err
+	}
+	if query.MaxMoistureLevel, err = parseFloat(c, "max_moisture_level"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func parseFloat(c *fiber.Ctx, key string) (*float64, error) {
+	str := c.Query(key)
+	if str == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' format, expected a number", key))
+	}
+
+	return &value, nil
+}
+
+func parseCoverage(c *fiber.Ctx, key string) (*float64, error) {
+	value, err := parseFloat(c, key)
+	if err != nil || value == nil {
+		return value, err
+	}
+
+	if *value < 0 || *value > 1 {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s', expected a number between 0 and 1", key))
+	}
+
+	return value, nil
+}
+
+func parseTime(c *fiber.Ctx, key string) (*time.Time, error) {
+	str := c.Query(key)
+	if str == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("invalid '%s' format, expected RFC3339", key))
+	}
+
+	return &value, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -30,6 +31,7 @@ func TestGetAllTreeCluster(t *testing.T) {
 
 		mockClusterService.EXPECT().GetAll(
 			mock.Anything,
+			entities.TreeClusterQuery{},
 		).Return(TestClusterList, int64(len(TestClusterList)), nil)
 
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster", nil)
@@ -61,6 +63,7 @@ func TestGetAllTreeCluster(t *testing.T) {
 
 		mockClusterService.EXPECT().GetAll(
 			mock.Anything,
+			entities.TreeClusterQuery{},
 		).Return(TestClusterList, int64(len(TestClusterList)), nil)
 
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster?page=1&limit=1", nil)
@@ -131,6 +134,7 @@ func TestGetAllTreeCluster(t *testing.T) {
 
 		mockClusterService.EXPECT().GetAll(
 			mock.Anything,
+			entities.TreeClusterQuery{},
 		).Return([]*entities.TreeCluster{}, int64(0), nil)
 
 		// when
@@ -155,6 +159,118 @@ func TestGetAllTreeCluster(t *testing.T) {
 		mockClusterService.AssertExpectations(t)
 	})
 
+	t.Run("should pass sort order to service", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetAllTreeClusters(mockClusterService)
+		app.Get("/v1/cluster", handler)
+
+		mockClusterService.EXPECT().GetAll(
+			mock.Anything,
+			entities.TreeClusterQuery{SortBy: entities.TreeClusterSortDaysUntilBad},
+		).Return(TestClusterList, int64(len(TestClusterList)), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster?sort_by=days_until_bad", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 Bad Request when sort order is invalid", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetAllTreeClusters(mockClusterService)
+		app.Get("/v1/cluster", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster?sort_by=watering_status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	t.Run("should pass moisture metrics filters to service", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetAllTreeClusters(mockClusterService)
+		app.Get("/v1/cluster", handler)
+
+		measuredAfter := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		measuredBefore := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+		mockClusterService.EXPECT().GetAll(
+			mock.Anything,
+			entities.TreeClusterQuery{
+				MinCoverage:      utils.P(0.25),
+				MaxCoverage:      utils.P(1.0),
+				MeasuredAfter:    &measuredAfter,
+				MeasuredBefore:   &measuredBefore,
+				MinMoistureLevel: utils.P(10.0),
+				MaxMoistureLevel: utils.P(42.5),
+			},
+		).Return(TestClusterList, int64(len(TestClusterList)), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster?min_coverage=0.25&max_coverage=1&measured_after=2025-06-01T00:00:00Z&measured_before=2025-06-03T12:00:00Z&min_moisture_level=10&max_moisture_level=42.5", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.TreeClusterListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.NotNil(t, response.Data[0].MoistureMetrics)
+		assert.Equal(t, 1.0, response.Data[0].MoistureMetrics.Coverage)
+		assert.Nil(t, response.Data[1].MoistureMetrics)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	for _, query := range []string{
+		"min_coverage=abc",
+		"max_coverage=1.5",
+		"min_coverage=-0.1",
+		"measured_after=2025-06-01",
+		"measured_before=yesterday",
+		"min_moisture_level=NaN",
+		"max_moisture_level=abc",
+	} {
+		t.Run("should return 400 Bad Request when filter is invalid: "+query, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(middleware.PaginationMiddleware())
+			mockClusterService := serviceMock.NewMockTreeClusterService(t)
+			handler := treecluster.GetAllTreeClusters(mockClusterService)
+			app.Get("/v1/cluster", handler)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster?"+query, nil)
+			resp, err := app.Test(req, -1)
+			defer resp.Body.Close()
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			mockClusterService.AssertExpectations(t)
+		})
+	}
+
 	t.Run("should return 500 Internal Server Error when service fails", func(t *testing.T) {
 		app := fiber.New()
 		app.Use(middleware.PaginationMiddleware())
@@ -164,6 +280,7 @@ func TestGetAllTreeCluster(t *testing.T) {
 
 		mockClusterService.EXPECT().GetAll(
 			mock.Anything,
+			entities.TreeClusterQuery{},
 		).Return(nil, int64(0), fiber.NewError(fiber.StatusInternalServerError, "service error"))
 
 		// when
@@ -204,6 +321,12 @@ func TestGetTreeClusterByID(t *testing.T) {
 		err = utils.ParseJSONResponse(resp, &response)
 		assert.NoError(t, err)
 		assert.Equal(t, TestCluster.Name, response.Name)
+		assert.Equal(t, TestCluster.MoistureLevel, response.MoistureLevel)
+		assert.NotNil(t, response.MoistureMetrics)
+		assert.Equal(t, int32(1), response.MoistureMetrics.SensorCount)
+		assert.InDelta(t, 2.0, response.MoistureMetrics.DataAgeHours, 0.1)
+		assert.Len(t, response.MoistureMetrics.Depths, 2)
+		assert.Equal(t, 30.0, response.MoistureMetrics.Depths[1].MeanCentibar)
 
 		mockClusterService.AssertExpectations(t)
 	})
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
@@ -28,6 +29,7 @@ func TestRegisterRoutes(t *testing.T) {
 
 			mockClusterService.EXPECT().GetAll(
 				mock.Anything,
+				entities.TreeClusterQuery{},
 			).Return(TestClusterList, int64(len(TestClusterList)), nil)
 
 			// when
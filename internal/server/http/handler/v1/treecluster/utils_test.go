@@ -23,6 +23,18 @@ var (
 		Latitude:       utils.P(testLatitude),
 		Longitude:      utils.P(testLongitude),
 		SoilCondition:  entities.TreeSoilConditionSandig,
+		MoistureLevel:  25,
+		MoistureMetrics: &entities.MoistureMetrics{
+			TreeCount:        1,
+			SensorCount:      1,
+			Coverage:         1,
+			LatestMeasuredAt: time.Now().Add(-2 * time.Hour),
+			OldestMeasuredAt: time.Now().Add(-2 * time.Hour),
+			Depths: []*entities.DepthMoisture{
+				{Depth: 30, SensorCount: 1, MinCentibar: 20, MeanCentibar: 20, MaxCentibar: 20},
+				{Depth: 60, SensorCount: 1, MinCentibar: 30, MeanCentibar: 30, MaxCentibar: 30},
+			},
+		},
 		Trees: []*entities.Tree{
 			{
 				ID:           1,
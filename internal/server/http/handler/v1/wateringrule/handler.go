@@ -0,0 +1,191 @@
+package wateringrule
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	wateringRuleMapper = generated.WateringRuleHTTPMapperImpl{}
+)
+
+// @Summary		Get all watering rule sets
+// @Description	Get all versions of the watering rule sets with their thresholds, newest version first
+// @Id				get-all-watering-rule-sets
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		200	{object}	entities.WateringRuleSetListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set [get]
+// @Security		Keycloak
+func GetAllWateringRuleSets(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringRuleSetListResponse{
+			Data:       wateringRuleMapper.FromResponseList(domainData),
+			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+		})
+	}
+}
+
+// @Summary		Get active watering rule set
+// @Description	Get the watering rule set used to calculate the watering status. If no rule set is active, the configured thresholds are used.
+// @Id				get-active-watering-rule-set
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		200	{object}	entities.WateringRuleSetResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set/active [get]
+// @Security		Keycloak
+func GetActiveWateringRuleSet(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetActive(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringRuleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get watering rule set by ID
+// @Description	Get watering rule set by ID with its thresholds
+// @Id				get-watering-rule-set-by-id
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		200	{object}	entities.WateringRuleSetResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set/{id} [get]
+// @Param			id	path	integer	true	"Watering rule set ID"
+// @Security		Keycloak
+func GetWateringRuleSetByID(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringRuleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create watering rule set
+// @Description	Create a new version of the watering rule sets. The version is not used until it is activated.
+// @Id				create-watering-rule-set
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		201	{object}	entities.WateringRuleSetResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set [post]
+// @Param			body	body	entities.WateringRuleSetCreateRequest	true	"Watering rule set"
+// @Security		Keycloak
+func CreateWateringRuleSet(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		var req entities.WateringRuleSetCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Create(ctx, wateringRuleMapper.FromCreateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(wateringRuleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Activate watering rule set
+// @Description	Activate a version of the watering rule sets. The previously active version is deactivated and the thresholds apply to all further sensor data.
+// @Id				activate-watering-rule-set
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		200	{object}	entities.WateringRuleSetResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set/{id}/activate [post]
+// @Param			id	path	integer	true	"Watering rule set ID"
+// @Security		Keycloak
+func ActivateWateringRuleSet(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Activate(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringRuleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Dry run watering rule set
+// @Description	Classify the latest sensor data of all trees and tree clusters with sensors with the active and the proposed thresholds without storing anything
+// @Id				dry-run-watering-rule-set
+// @Tags			Watering Rule Set
+// @Produce		json
+// @Success		200	{object}	entities.WateringRuleDryRunResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-rule-set/dry-run [post]
+// @Param			body	body	entities.WateringRuleSetCreateRequest	true	"Proposed watering rule set"
+// @Security		Keycloak
+func DryRunWateringRuleSet(svc service.WateringRuleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		var req entities.WateringRuleSetCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.DryRun(ctx, wateringRuleMapper.FromCreateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringRuleMapper.FromDryRunResponse(domainData))
+	}
+}
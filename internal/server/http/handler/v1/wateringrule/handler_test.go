@@ -0,0 +1,363 @@
+package wateringrule_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringrule"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllWateringRuleSets(t *testing.T) {
+	t.Run("should return all watering rule sets", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set", wateringrule.GetAllWateringRuleSets(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(TestWateringRuleSets, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringRuleSetListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestWateringRuleSets))
+		assert.Equal(t, TestWateringRuleSets[0].Version, response.Data[0].Version)
+		assert.True(t, response.Data[0].Active)
+		assert.Len(t, response.Data[0].Thresholds, 2)
+		assert.Equal(t, utils.P("Acer"), response.Data[0].Thresholds[1].SpeciesGroup)
+		assert.Equal(t, utils.P(serverEntities.TreeSoilConditionSandig), response.Data[0].Thresholds[1].SoilCondition)
+		assert.Nil(t, response.Data[0].Thresholds[0].SpeciesGroup)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set", wateringrule.GetAllWateringRuleSets(mockSvc))
+
+		mockSvc.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetActiveWateringRuleSet(t *testing.T) {
+	t.Run("should return active watering rule set", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set/active", wateringrule.GetActiveWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().GetActive(mock.Anything).Return(TestWateringRuleSets[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set/active", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringRuleSetResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestWateringRuleSets[0].ID, response.ID)
+		assert.NotNil(t, response.ActivatedAt)
+	})
+
+	t.Run("should return 404 when no rule set is active", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set/active", wateringrule.GetActiveWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().GetActive(mock.Anything).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set/active", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetWateringRuleSetByID(t *testing.T) {
+	t.Run("should return watering rule set by id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set/:id", wateringrule.GetWateringRuleSetByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestWateringRuleSets[1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringRuleSetResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestWateringRuleSets[1].Description, response.Description)
+		assert.False(t, response.Active)
+		assert.Nil(t, response.ActivatedAt)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set/:id", wateringrule.GetWateringRuleSetByID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set/invalid", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when rule set not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Get("/v1/watering-rule-set/:id", wateringrule.GetWateringRuleSetByID(mockSvc))
+
+		mockSvc.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-rule-set/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateWateringRuleSet(t *testing.T) {
+	t.Run("should create watering rule set", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set", wateringrule.CreateWateringRuleSet(mockSvc))
+
+		expected := &entities.WateringRuleSetCreate{
+			Description: "stricter thresholds",
+			Thresholds: []entities.WateringThreshold{
+				{SoilCondition: utils.P(entities.TreeSoilConditionSandig), MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 10, Bad: 30},
+			},
+		}
+		created := &entities.WateringRuleSet{ID: 3, Version: 3, Description: expected.Description, Thresholds: expected.Thresholds}
+		mockSvc.EXPECT().Create(mock.Anything, expected).Return(created, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set", bytes.NewBufferString(TestWateringRuleSetCreateBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.WateringRuleSetResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), response.Version)
+		assert.Len(t, response.Thresholds, 1)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set", wateringrule.CreateWateringRuleSet(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set", bytes.NewBufferString(`{"thresholds": `))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when service returns validation error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set", wateringrule.CreateWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, service.NewError(service.BadRequest, "validation error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set", bytes.NewBufferString(`{"thresholds": []}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestActivateWateringRuleSet(t *testing.T) {
+	t.Run("should activate watering rule set", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/:id/activate", wateringrule.ActivateWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().Activate(mock.Anything, int32(2)).Return(TestWateringRuleSets[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/2/activate", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringRuleSetResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.True(t, response.Active)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/:id/activate", wateringrule.ActivateWateringRuleSet(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/invalid/activate", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when rule set not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/:id/activate", wateringrule.ActivateWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().Activate(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/99/activate", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestDryRunWateringRuleSet(t *testing.T) {
+	t.Run("should return active and proposed watering status", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/dry-run", wateringrule.DryRunWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().DryRun(mock.Anything, mock.Anything).Return(TestWateringRuleDryRun, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/dry-run", bytes.NewBufferString(TestWateringRuleSetCreateBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringRuleDryRunResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Trees, 1)
+		assert.Equal(t, "sensor-1", response.Trees[0].SensorID)
+		assert.Equal(t, serverEntities.WateringStatusGood, response.Trees[0].Active)
+		assert.Equal(t, serverEntities.WateringStatusModerate, response.Trees[0].Proposed)
+		assert.Len(t, response.TreeClusters, 1)
+		assert.Equal(t, "Cluster 1", response.TreeClusters[0].Name)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/dry-run", wateringrule.DryRunWateringRuleSet(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/dry-run", bytes.NewBufferString(`{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringRuleService(t)
+		app := fiber.New()
+		app.Post("/v1/watering-rule-set/dry-run", wateringrule.DryRunWateringRuleSet(mockSvc))
+
+		mockSvc.EXPECT().DryRun(mock.Anything, mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-rule-set/dry-run", bytes.NewBufferString(TestWateringRuleSetCreateBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
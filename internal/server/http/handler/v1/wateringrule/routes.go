@@ -0,0 +1,15 @@
+package wateringrule
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.WateringRuleService) {
+	r.Get("/", GetAllWateringRuleSets(svc))
+	r.Get("/active", GetActiveWateringRuleSet(svc))
+	r.Post("/", CreateWateringRuleSet(svc))
+	r.Post("/dry-run", DryRunWateringRuleSet(svc))
+	r.Get("/:id", GetWateringRuleSetByID(svc))
+	r.Post("/:id/activate", ActivateWateringRuleSet(svc))
+}
@@ -0,0 +1,130 @@
+package wateringrule_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringrule"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/watering-rule-set", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetAll(mock.Anything).Return(TestWateringRuleSets, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Create(mock.Anything, mock.Anything).Return(TestWateringRuleSets[1], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", bytes.NewBufferString(TestWateringRuleSetCreateBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-rule-set/active", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetActive(mock.Anything).Return(TestWateringRuleSets[0], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/active", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-rule-set/dry-run", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().DryRun(mock.Anything, mock.Anything).Return(TestWateringRuleDryRun, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/dry-run", bytes.NewBufferString(TestWateringRuleSetCreateBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-rule-set/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestWateringRuleSets[1], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-rule-set/:id/activate", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringRuleService(t)
+			app := fiber.New()
+			wateringrule.RegisterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().Activate(mock.Anything, int32(1)).Return(TestWateringRuleSets[1], nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/activate", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
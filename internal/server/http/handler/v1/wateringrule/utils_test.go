@@ -0,0 +1,48 @@
+package wateringrule_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	currentTime          = time.Now()
+	TestWateringRuleSets = []*entities.WateringRuleSet{
+		{
+			ID:          2,
+			CreatedAt:   currentTime,
+			UpdatedAt:   currentTime,
+			Version:     2,
+			Description: "thresholds for maples on sandy soil",
+			Active:      true,
+			ActivatedAt: &currentTime,
+			Thresholds: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33},
+				{SpeciesGroup: utils.P("Acer"), SoilCondition: utils.P(entities.TreeSoilConditionSandig), MinAge: 4, MaxAge: 99, Depth: 30, Moderate: 80, Bad: 120},
+			},
+		},
+		{
+			ID:          1,
+			CreatedAt:   currentTime,
+			UpdatedAt:   currentTime,
+			Version:     1,
+			Description: "initial thresholds",
+			Thresholds: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+			},
+		},
+	}
+
+	TestWateringRuleDryRun = &entities.WateringRuleDryRun{
+		Trees: []*entities.TreeWateringStatusDryRun{
+			{TreeID: 1, Number: "T1", SensorID: "sensor-1", Active: entities.WateringStatusGood, Proposed: entities.WateringStatusModerate},
+		},
+		TreeClusters: []*entities.TreeClusterWateringStatusDryRun{
+			{TreeClusterID: 1, Name: "Cluster 1", Active: entities.WateringStatusGood, Proposed: entities.WateringStatusModerate},
+		},
+	}
+
+	TestWateringRuleSetCreateBody = `{"description": "stricter thresholds", "thresholds": [{"soil_condition": "sandig", "min_age": 0, "max_age": 1, "depth": 30, "moderate": 10, "bad": 30}]}`
+)
@@ -0,0 +1,117 @@
+package wateringstatus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	wateringStatusMapper = generated.WateringStatusHTTPMapperImpl{}
+)
+
+// @Summary		Get watering status timeline of a tree
+// @Description	Get the watering status transitions of a tree with their cause, the periods in between and the days spent in each watering status. Without a time range the last 30 days are returned.
+// @Id				get-tree-watering-status-timeline
+// @Tags			Tree
+// @Produce		json
+// @Success		200	{object}	entities.WateringStatusTimelineResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree/{id}/watering-status [get]
+// @Param			id		path	integer	true	"Tree ID"
+// @Param			from	query	string	false	"Start of the timeline (RFC3339), defaults to 30 days before 'to'"
+// @Param			to		query	string	false	"End of the timeline (RFC3339), defaults to now"
+// @Security		Keycloak
+func GetTreeWateringStatusTimeline(svc service.WateringStatusService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		from, to, err := parseTimeRange(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetTreeTimeline(ctx, int32(id), from, to)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringStatusMapper.FromTimelineResponse(domainData))
+	}
+}
+
+// @Summary		Get watering status timeline of a tree cluster
+// @Description	Get the watering status transitions of a tree cluster with their cause, the periods in between and the days spent in each watering status. Without a time range the last 30 days are returned.
+// @Id				get-tree-cluster-watering-status-timeline
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		200	{object}	entities.WateringStatusTimelineResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/{treecluster_id}/watering-status [get]
+// @Param			treecluster_id	path	integer	true	"Tree Cluster ID"
+// @Param			from			query	string	false	"Start of the timeline (RFC3339), defaults to 30 days before 'to'"
+// @Param			to				query	string	false	"End of the timeline (RFC3339), defaults to now"
+// @Security		Keycloak
+func GetTreeClusterWateringStatusTimeline(svc service.WateringStatusService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("treecluster_id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		from, to, err := parseTimeRange(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetTreeClusterTimeline(ctx, int32(id), from, to)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringStatusMapper.FromTimelineResponse(domainData))
+	}
+}
+
+func parseTimeRange(c *fiber.Ctx) (from, to time.Time, err error) {
+	to = time.Now()
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, service.NewError(service.BadRequest, "invalid 'to' format, expected RFC3339")
+		}
+	}
+
+	from = to.AddDate(0, 0, -30)
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, service.NewError(service.BadRequest, "invalid 'from' format, expected RFC3339")
+		}
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, service.NewError(service.BadRequest, "'from' must be before 'to'")
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,181 @@
+package wateringstatus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringstatus"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTreeClusterWateringStatusTimeline(t *testing.T) {
+	t.Run("should return timeline of tree cluster", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/cluster/:treecluster_id/watering-status", wateringstatus.GetTreeClusterWateringStatusTimeline(mockSvc))
+
+		mockSvc.EXPECT().GetTreeClusterTimeline(mock.Anything, int32(1), from, to).Return(TestTimeline, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/watering-status?from=2025-01-01T00:00:00Z&to=2025-01-31T00:00:00Z", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringStatusTimelineResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, from, response.From)
+		assert.Equal(t, to, response.To)
+		assert.Len(t, response.Transitions, 1)
+		assert.Equal(t, serverEntities.WateringStatusGood, response.Transitions[0].OldStatus)
+		assert.Equal(t, serverEntities.WateringStatusBad, response.Transitions[0].NewStatus)
+		assert.Equal(t, serverEntities.WateringStatusCauseSensorData, response.Transitions[0].Cause)
+		assert.Len(t, response.Periods, 2)
+		assert.Nil(t, response.Periods[0].Cause)
+		assert.Equal(t, serverEntities.WateringStatusCauseSensorData, *response.Periods[1].Cause)
+		assert.Equal(t, 10.0, response.Days.Good)
+		assert.Equal(t, 20.0, response.Days.Bad)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should default to the last 30 days", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/cluster/:treecluster_id/watering-status", wateringstatus.GetTreeClusterWateringStatusTimeline(mockSvc))
+
+		mockSvc.EXPECT().GetTreeClusterTimeline(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(TestTimeline, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/watering-status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		gotFrom := mockSvc.Calls[0].Arguments.Get(2).(time.Time)
+		gotTo := mockSvc.Calls[0].Arguments.Get(3).(time.Time)
+		assert.Equal(t, 30*24*time.Hour, gotTo.Sub(gotFrom))
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/cluster/:treecluster_id/watering-status", wateringstatus.GetTreeClusterWateringStatusTimeline(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/invalid/watering-status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid time range", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/cluster/:treecluster_id/watering-status", wateringstatus.GetTreeClusterWateringStatusTimeline(mockSvc))
+
+		for _, query := range []string{"?from=yesterday", "?to=today", "?from=2025-01-31T00:00:00Z&to=2025-01-01T00:00:00Z"} {
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/watering-status"+query, nil)
+			resp, err := app.Test(req, -1)
+			defer resp.Body.Close()
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+
+	t.Run("should return 404 when tree cluster is not found", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/cluster/:treecluster_id/watering-status", wateringstatus.GetTreeClusterWateringStatusTimeline(mockSvc))
+
+		mockSvc.EXPECT().GetTreeClusterTimeline(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/watering-status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetTreeWateringStatusTimeline(t *testing.T) {
+	t.Run("should return timeline of tree", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/tree/:id/watering-status", wateringstatus.GetTreeWateringStatusTimeline(mockSvc))
+
+		mockSvc.EXPECT().GetTreeTimeline(mock.Anything, int32(1), from, to).Return(TestTimeline, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree/1/watering-status?from=2025-01-01T00:00:00Z&to=2025-01-31T00:00:00Z", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringStatusTimelineResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Periods, 2)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/tree/:id/watering-status", wateringstatus.GetTreeWateringStatusTimeline(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree/invalid/watering-status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWateringStatusService(t)
+		app := fiber.New()
+		app.Get("/v1/tree/:id/watering-status", wateringstatus.GetTreeWateringStatusTimeline(mockSvc))
+
+		mockSvc.EXPECT().GetTreeTimeline(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree/1/watering-status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,16 @@
+package wateringstatus
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// RegisterTreeRoutes registers the watering status routes below a tree, it is mounted on the tree router
+func RegisterTreeRoutes(r fiber.Router, svc service.WateringStatusService) {
+	r.Get("/:id/watering-status", GetTreeWateringStatusTimeline(svc))
+}
+
+// RegisterTreeClusterRoutes registers the watering status routes below a tree cluster, it is mounted on the tree cluster router
+func RegisterTreeClusterRoutes(r fiber.Router, svc service.WateringStatusService) {
+	r.Get("/:treecluster_id/watering-status", GetTreeClusterWateringStatusTimeline(svc))
+}
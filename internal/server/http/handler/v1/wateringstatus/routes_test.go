@@ -0,0 +1,53 @@
+package wateringstatus_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringstatus"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/tree/:id/watering-status", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringStatusService(t)
+			app := fiber.New()
+			wateringstatus.RegisterTreeRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetTreeTimeline(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(TestTimeline, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1/watering-status", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/cluster/:treecluster_id/watering-status", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockSvc := serviceMock.NewMockWateringStatusService(t)
+			app := fiber.New()
+			wateringstatus.RegisterTreeClusterRoutes(app, mockSvc)
+
+			mockSvc.EXPECT().GetTreeClusterTimeline(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(TestTimeline, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1/watering-status", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
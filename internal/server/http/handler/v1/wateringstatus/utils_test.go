@@ -0,0 +1,34 @@
+package wateringstatus_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	from         = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to           = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	transitionAt = time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+
+	TestTimeline = &entities.WateringStatusTimeline{
+		From: from,
+		To:   to,
+		Transitions: []*entities.WateringStatusTransition{
+			{
+				ID:            1,
+				CreatedAt:     transitionAt,
+				TreeClusterID: utils.P(int32(1)),
+				OldStatus:     entities.WateringStatusGood,
+				NewStatus:     entities.WateringStatusBad,
+				Cause:         entities.WateringStatusCauseSensorData,
+			},
+		},
+		Periods: []*entities.WateringStatusPeriod{
+			{Status: entities.WateringStatusGood, From: from, To: transitionAt},
+			{Status: entities.WateringStatusBad, Cause: utils.P(entities.WateringStatusCauseSensorData), From: transitionAt, To: to},
+		},
+		Days: entities.WateringStatusDays{Good: 10, Bad: 20},
+	}
+)
@@ -0,0 +1,118 @@
+package weather
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	weatherMapper = generated.WeatherHTTPMapperImpl{}
+)
+
+// @Summary		Get weather of a region
+// @Description	Get the daily precipitation, temperature and ET0 of a region from the past days until the end of the forecast horizon
+// @Id				get-weather-by-region-id
+// @Tags			Weather
+// @Produce		json
+// @Success		200	{object}	entities.WeatherDayListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/weather/region/{id} [get]
+// @Param			id	path	integer	true	"Region ID"
+// @Security		Keycloak
+func GetWeatherByRegionID(svc service.WeatherService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByRegionID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WeatherDayListResponse{
+			Data: weatherMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get water balances of all tree clusters
+// @Description	Get the water balances of all tree clusters modeled from the last known watering status and the weather. Flagged tree clusters turn bad within the forecast horizon.
+// @Id				get-all-water-balances
+// @Tags			Weather
+// @Produce		json
+// @Success		200	{object}	entities.WaterBalanceListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/weather/water-balance [get]
+// @Param			flagged	query	boolean	false	"Only flagged tree clusters"
+// @Security		Keycloak
+func GetAllWaterBalances(svc service.WeatherService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		flagged := false
+		if flaggedStr := c.Query("flagged"); flaggedStr != "" {
+			var err error
+			flagged, err = strconv.ParseBool(flaggedStr)
+			if err != nil {
+				err := service.NewError(service.BadRequest, "invalid 'flagged' format, expected true or false")
+				return errorhandler.HandleError(err)
+			}
+		}
+
+		domainData, err := svc.GetAllWaterBalances(ctx, flagged)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WaterBalanceListResponse{
+			Data:       weatherMapper.FromWaterBalanceResponseList(domainData),
+			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+		})
+	}
+}
+
+// @Summary		Get water balance of a tree cluster
+// @Description	Get the water balance of a tree cluster with the modeled deficit of every day
+// @Id				get-water-balance-by-tree-cluster-id
+// @Tags			Weather
+// @Produce		json
+// @Success		200	{object}	entities.WaterBalanceResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/weather/water-balance/{cluster_id} [get]
+// @Param			cluster_id	path	integer	true	"Tree cluster ID"
+// @Security		Keycloak
+func GetWaterBalanceByTreeClusterID(svc service.WeatherService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("cluster_id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetWaterBalanceByTreeClusterID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(weatherMapper.FromWaterBalanceResponse(domainData))
+	}
+}
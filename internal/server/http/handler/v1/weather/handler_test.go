@@ -0,0 +1,232 @@
+package weather_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWeatherByRegionID(t *testing.T) {
+	t.Run("should return weather of region", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/region/:id", weather.GetWeatherByRegionID(mockSvc))
+
+		mockSvc.EXPECT().GetByRegionID(mock.Anything, int32(1)).Return(TestWeatherDays, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/region/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WeatherDayListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestWeatherDays))
+		assert.Equal(t, TestWeatherDays[0].Date.Unix(), response.Data[0].Date.Unix())
+		assert.Equal(t, TestWeatherDays[0].Precipitation, response.Data[0].Precipitation)
+		assert.Equal(t, TestWeatherDays[0].TemperatureMin, response.Data[0].TemperatureMin)
+		assert.Equal(t, TestWeatherDays[0].TemperatureMax, response.Data[0].TemperatureMax)
+		assert.Equal(t, TestWeatherDays[0].ET0, response.Data[0].ET0)
+		assert.Equal(t, TestWeatherDays[0].Provider, response.Data[0].Provider)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/region/:id", weather.GetWeatherByRegionID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/region/invalid", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/region/:id", weather.GetWeatherByRegionID(mockSvc))
+
+		mockSvc.EXPECT().GetByRegionID(mock.Anything, int32(1)).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/region/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetAllWaterBalances(t *testing.T) {
+	t.Run("should return all water balances", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance", weather.GetAllWaterBalances(mockSvc))
+
+		mockSvc.EXPECT().GetAllWaterBalances(mock.Anything, false).Return(TestWaterBalances, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WaterBalanceListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, len(TestWaterBalances))
+		assert.Equal(t, int32(1), response.Data[0].TreeClusterID)
+		assert.Equal(t, serverEntities.WateringStatusModerate, response.Data[0].AdjustedStatus)
+		assert.Equal(t, serverEntities.WateringStatusBad, response.Data[0].ForecastStatus)
+		assert.Equal(t, badAt.Unix(), response.Data[0].BadAt.Unix())
+		assert.True(t, response.Data[0].Flagged)
+		assert.Len(t, response.Data[0].Days, 1)
+		assert.Nil(t, response.Data[1].BadAt)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return only flagged water balances", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance", weather.GetAllWaterBalances(mockSvc))
+
+		mockSvc.EXPECT().GetAllWaterBalances(mock.Anything, true).Return(TestWaterBalances[:1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance?flagged=true", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WaterBalanceListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid flagged query", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance", weather.GetAllWaterBalances(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance?flagged=maybe", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance", weather.GetAllWaterBalances(mockSvc))
+
+		mockSvc.EXPECT().GetAllWaterBalances(mock.Anything, false).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetWaterBalanceByTreeClusterID(t *testing.T) {
+	t.Run("should return water balance of tree cluster", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance/:cluster_id", weather.GetWaterBalanceByTreeClusterID(mockSvc))
+
+		mockSvc.EXPECT().GetWaterBalanceByTreeClusterID(mock.Anything, int32(1)).Return(TestWaterBalances[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WaterBalanceResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.TreeClusterID)
+		assert.Equal(t, serverEntities.WateringStatusGood, response.BaseStatus)
+		assert.Equal(t, TestWaterBalances[0].Deficit, response.Deficit)
+		assert.Equal(t, TestWaterBalances[0].Days[0].Deficit, response.Days[0].Deficit)
+		assert.Equal(t, serverEntities.WateringStatusModerate, response.Days[0].WateringStatus)
+
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance/:cluster_id", weather.GetWaterBalanceByTreeClusterID(mockSvc))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance/invalid", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when tree cluster has no water balance", func(t *testing.T) {
+		mockSvc := serviceMock.NewMockWeatherService(t)
+		app := fiber.New()
+		app.Get("/v1/weather/water-balance/:cluster_id", weather.GetWaterBalanceByTreeClusterID(mockSvc))
+
+		mockSvc.EXPECT().GetWaterBalanceByTreeClusterID(mock.Anything, int32(1)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/weather/water-balance/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,12 @@
+package weather
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.WeatherService) {
+	r.Get("/region/:id", GetWeatherByRegionID(svc))
+	r.Get("/water-balance", GetAllWaterBalances(svc))
+	r.Get("/water-balance/:cluster_id", GetWaterBalanceByTreeClusterID(svc))
+}
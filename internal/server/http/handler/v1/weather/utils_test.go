@@ -0,0 +1,50 @@
+package weather_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	currentTime     = time.Now()
+	today           = currentTime.UTC().Truncate(24 * time.Hour)
+	TestWeatherDays = []*entities.WeatherDay{
+		{RegionID: 1, Date: today.AddDate(0, 0, -1), Precipitation: 4.2, TemperatureMin: 2, TemperatureMax: 7.5, ET0: 0.3, Provider: "open-meteo"},
+		{RegionID: 1, Date: today, Precipitation: 0.1, TemperatureMin: 0.5, TemperatureMax: 5, ET0: 0.5, Provider: "open-meteo"},
+	}
+
+	badAt             = today.AddDate(0, 0, 3)
+	TestWaterBalances = []*entities.WaterBalance{
+		{
+			TreeClusterID:  1,
+			CreatedAt:      currentTime,
+			UpdatedAt:      currentTime,
+			RegionID:       1,
+			BaseStatus:     entities.WateringStatusGood,
+			BaseDate:       today.AddDate(0, 0, -2),
+			WateringStatus: entities.WateringStatusGood,
+			AdjustedStatus: entities.WateringStatusModerate,
+			ForecastStatus: entities.WateringStatusBad,
+			Deficit:        25.5,
+			BadAt:          &badAt,
+			Flagged:        true,
+			Days: []entities.WaterBalanceDay{
+				{Date: today, Precipitation: 0.1, ET0: 0.5, Deficit: 25.5, WateringStatus: entities.WateringStatusModerate},
+			},
+		},
+		{
+			TreeClusterID:  2,
+			CreatedAt:      currentTime,
+			UpdatedAt:      currentTime,
+			RegionID:       1,
+			BaseStatus:     entities.WateringStatusModerate,
+			BaseDate:       today,
+			WateringStatus: entities.WateringStatusModerate,
+			AdjustedStatus: entities.WateringStatusModerate,
+			ForecastStatus: entities.WateringStatusModerate,
+			Deficit:        50,
+			Days:           []entities.WaterBalanceDay{},
+		},
+	}
+)
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	IngestSecretHeader             = "X-Ingest-Secret"
+	IngestSignatureHeader          = "X-Signature"
+	IngestSignatureTimestampHeader = "X-Signature-Timestamp"
+	ingestSignaturePrefix          = "sha256="
+
+	defaultIngestSignatureTolerance = 5 * time.Minute
+)
+
+// NewIngestAuthMiddleware authenticates webhook requests of sensor uplinks. A request is accepted if it either sends
+// the configured secret in the X-Ingest-Secret header or signs "<timestamp>.<raw body>" with it as HMAC-SHA256 in the
+// X-Signature header, hex encoded and optionally prefixed with "sha256=". The timestamp is sent in unix seconds in
+// the X-Signature-Timestamp header and has to be within the configured tolerance, so a captured request can not be
+// replayed later on.
+func NewIngestAuthMiddleware(cfg *config.SensorIngestConfig) fiber.Handler {
+	tolerance := cfg.SignatureTolerance
+	if tolerance <= 0 {
+		tolerance = defaultIngestSignatureTolerance
+	}
+
+	return func(c *fiber.Ctx) error {
+		if cfg.Secret == "" {
+			return errorhandler.HandleError(service.NewError(service.Unauthorized, "sensor ingest is disabled"))
+		}
+
+		if signature := c.Get(IngestSignatureHeader); signature != "" {
+			timestamp := c.Get(IngestSignatureTimestampHeader)
+			if !validIngestTimestamp(timestamp, time.Now(), tolerance) {
+				return errorhandler.HandleError(service.NewError(service.Unauthorized, "invalid or expired signature timestamp"))
+			}
+
+			if !validIngestSignature(cfg.Secret, timestamp, c.Body(), signature) {
+				return errorhandler.HandleError(service.NewError(service.Unauthorized, "invalid request signature"))
+			}
+			return c.Next()
+		}
+
+		secret := c.Get(IngestSecretHeader)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(cfg.Secret)) != 1 {
+			return errorhandler.HandleError(service.NewError(service.Unauthorized, "invalid or missing ingest secret"))
+		}
+
+		return c.Next()
+	}
+}
+
+func validIngestTimestamp(timestamp string, now time.Time, tolerance time.Duration) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+	return age <= tolerance && age >= -tolerance
+}
+
+func validIngestSignature(secret, timestamp string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, ingestSignaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewIngestAuthMiddleware(t *testing.T) {
+	body := []byte(`{"end_device_ids":{"device_id":"sensor-1"}}`)
+	cfg := &config.SensorIngestConfig{Secret: "super-secret"}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(10*time.Minute).Unix(), 10)
+
+	newApp := func(cfg *config.SensorIngestConfig) *fiber.App {
+		app := fiber.New()
+		app.Post("/ingest", NewIngestAuthMiddleware(cfg), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusCreated)
+		})
+		return app
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config.SensorIngestConfig
+		headers  map[string]string
+		expected int
+	}{
+		{
+			name:     "should accept request with valid shared secret",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSecretHeader: "super-secret"},
+			expected: fiber.StatusCreated,
+		},
+		{
+			name:     "should accept request with valid signature",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", now, body), IngestSignatureTimestampHeader: now},
+			expected: fiber.StatusCreated,
+		},
+		{
+			name:     "should accept request with prefixed signature",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: "sha256=" + sign("super-secret", now, body), IngestSignatureTimestampHeader: now},
+			expected: fiber.StatusCreated,
+		},
+		{
+			name:     "should accept signed request within configured tolerance",
+			cfg:      &config.SensorIngestConfig{Secret: "super-secret", SignatureTolerance: time.Hour},
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", stale, body), IngestSignatureTimestampHeader: stale},
+			expected: fiber.StatusCreated,
+		},
+		{
+			name:     "should reject signed request without timestamp",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", "", body)},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject signed request with malformed timestamp",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", "yesterday", body), IngestSignatureTimestampHeader: "yesterday"},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject signed request older than the tolerance",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", stale, body), IngestSignatureTimestampHeader: stale},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject signed request from the future beyond the tolerance",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", future, body), IngestSignatureTimestampHeader: future},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject request whose timestamp is not signed",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("super-secret", stale, body), IngestSignatureTimestampHeader: now},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject request with wrong shared secret",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSecretHeader: "wrong"},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject request signed with another secret",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: sign("wrong", now, body), IngestSignatureTimestampHeader: now},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject request with malformed signature",
+			cfg:      cfg,
+			headers:  map[string]string{IngestSignatureHeader: "not-hex", IngestSignatureTimestampHeader: now},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name: "should reject request with invalid signature even when shared secret is valid",
+			cfg:  cfg,
+			headers: map[string]string{
+				IngestSignatureHeader:          sign("wrong", now, body),
+				IngestSignatureTimestampHeader: now,
+				IngestSecretHeader:             "super-secret",
+			},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject request without credentials",
+			cfg:      cfg,
+			headers:  map[string]string{},
+			expected: fiber.StatusUnauthorized,
+		},
+		{
+			name:     "should reject every request when no secret is configured",
+			cfg:      &config.SensorIngestConfig{},
+			headers:  map[string]string{IngestSecretHeader: ""},
+			expected: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			app := newApp(tt.cfg)
+			req := httptest.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			// when
+			resp, err := app.Test(req, -1)
+
+			// then
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// RoleAdmin is the realm role of users that may manage the internals of the application, like dead letters.
+const RoleAdmin = "admin"
+
+// NewRoleMiddleware only passes requests of users with the given realm role. It has to run after the jwt middleware,
+// which stores the claims of the token in the user context.
+func NewRoleMiddleware(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+		if !ok || !hasRealmRole(claims, role) {
+			return errorhandler.HandleError(service.NewError(service.Forbidden, "user has not the required role"))
+		}
+
+		return c.Next()
+	}
+}
+
+func hasRealmRole(claims golangJwt.MapClaims, role string) bool {
+	realmAccess, ok := claims["realm_access"].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	roles, ok := realmAccess["roles"].([]any)
+	if !ok {
+		return false
+	}
+
+	return slices.ContainsFunc(roles, func(r any) bool {
+		name, ok := r.(string)
+		return ok && name == role
+	})
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoleMiddleware(t *testing.T) {
+	newApp := func(claims golangJwt.MapClaims) *fiber.App {
+		app := fiber.New()
+		app.Get("/admin", func(c *fiber.Ctx) error {
+			if claims != nil {
+				c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+			}
+			return c.Next()
+		}, NewRoleMiddleware(RoleAdmin), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+		return app
+	}
+
+	tests := []struct {
+		name     string
+		claims   golangJwt.MapClaims
+		expected int
+	}{
+		{
+			name:     "should accept user with admin role",
+			claims:   golangJwt.MapClaims{"realm_access": map[string]any{"roles": []any{"offline_access", "admin"}}},
+			expected: fiber.StatusOK,
+		},
+		{
+			name:     "should reject user without admin role",
+			claims:   golangJwt.MapClaims{"realm_access": map[string]any{"roles": []any{"offline_access"}}},
+			expected: fiber.StatusForbidden,
+		},
+		{
+			name:     "should reject user without realm roles",
+			claims:   golangJwt.MapClaims{"sub": "user-1"},
+			expected: fiber.StatusForbidden,
+		},
+		{
+			name:     "should reject request without claims",
+			claims:   nil,
+			expected: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			app := newApp(tt.claims)
+			req := httptest.NewRequest(fiber.MethodGet, "/admin", nil)
+
+			// when
+			resp, err := app.Test(req, -1)
+
+			// then
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
@@ -3,16 +3,24 @@ package http
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/deadletter"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/fileimport"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/info"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/plugin"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/region"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorassignment"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensorcommand"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensoringest"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/user"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/watering_plan"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringrule"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringstatus"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
@@ -42,16 +50,35 @@ func (s *Server) v1(router fiber.Router, authMiddlewares ...fiber.Handler) {
 	app.Route("/cluster", func(router fiber.Router) {
 		router.Use(authMiddleware...)
 		treecluster.RegisterRoutes(router, s.services.TreeClusterService)
+		wateringstatus.RegisterTreeClusterRoutes(router, s.services.WateringStatusService)
 	})
 
 	app.Route("/tree", func(router fiber.Router) {
 		router.Use(authMiddleware...)
 		tree.RegisterRoutes(router, s.services.TreeService)
+		wateringstatus.RegisterTreeRoutes(router, s.services.WateringStatusService)
 	})
 
 	app.Route("/sensor", func(router fiber.Router) {
+		// the webhook is only available if a secret is configured
+		if s.cfg.Sensor.Ingest.Secret != "" {
+			sensoringest.RegisterRoutes(router, s.services.SensorIngestService, middleware.NewIngestAuthMiddleware(&s.cfg.Sensor.Ingest))
+		}
 		router.Use(authMiddleware...)
 		sensor.RegisterRoutes(router, s.services.SensorService)
+		sensorcommand.RegisterRoutes(router, s.services.SensorCommandService)
+	})
+
+	app.Route("/dead-letter", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// dead letters contain raw payloads and replays write sensor data, so only admins may access them
+		router.Use(middleware.NewRoleMiddleware(middleware.RoleAdmin))
+		deadletter.RegisterRoutes(router, s.services.DeadLetterService)
+	})
+
+	app.Route("/sensor-assignment", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		sensorassignment.RegisterRoutes(router, s.services.SensorAssignmentService)
 	})
 
 	app.Route("/user", func(router fiber.Router) {
@@ -80,6 +107,16 @@ func (s *Server) v1(router fiber.Router, authMiddlewares ...fiber.Handler) {
 		wateringplan.RegisterRoutes(router, s.services.WateringPlanService)
 	})
 
+	app.Route("/watering-rule-set", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		wateringrule.RegisterRoutes(router, s.services.WateringRuleService)
+	})
+
+	app.Route("/weather", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		weather.RegisterRoutes(router, s.services.WeatherService)
+	})
+
 	app.Route("/import", func(router fiber.Router) {
 		router.Use(authMiddleware...)
 		fileimport.RegisterRoutes(router, s.services.TreeService)
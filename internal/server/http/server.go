@@ -52,6 +52,18 @@ func (s *Server) Run(ctx context.Context) error {
 		s.services.SensorService.RunStatusUpdater(ctx, 1*time.Hour)
 	}()
 
+	go func() {
+		s.services.SensorService.RunDataRetention(ctx, 1*time.Hour)
+	}()
+
+	go func() {
+		s.services.WeatherService.RunWeatherUpdater(ctx, s.cfg.Weather.Interval)
+	}()
+
+	go func() {
+		s.services.TreeClusterService.RunWateringStatusInference(ctx, s.cfg.WateringStatus.Inference.Interval)
+	}()
+
 	go func() {
 		<-ctx.Done()
 		slog.Info("shutting down http server")
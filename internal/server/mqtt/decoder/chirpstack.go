@@ -0,0 +1,127 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+)
+
+const ChirpStackDecoderName = "chirpstack"
+
+type chirpStackUplink struct {
+	DeviceInfo struct {
+		DeviceName string `json:"deviceName"`
+		DevEUI     string `json:"devEui"`
+	} `json:"deviceInfo"`
+	Object *lorawanDecodedPayload `json:"object"`
+}
+
+type chirpStackDownlink struct {
+	ID        string `json:"id"`
+	DevEUI    string `json:"devEui"`
+	Confirmed bool   `json:"confirmed"`
+	FPort     uint8  `json:"fPort"`
+	Data      []byte `json:"data"`
+}
+
+type chirpStackAck struct {
+	QueueItemID  string `json:"queueItemId"`
+	Acknowledged bool   `json:"acknowledged"`
+}
+
+// chirpStackQueueItemPrefix is the fixed part of the queue item id, ChirpStack expects a uuid.
+// The command id is stored in the last group of the uuid.
+const chirpStackQueueItemPrefix = "67650000-0000-4000-8000-"
+
+// ChirpStackDecoder decodes uplink events of ChirpStack v4 (json marshaler).
+// The device name is used as sensor id and falls back to the DevEUI. Downlinks are addressed by the DevEUI.
+type ChirpStackDecoder struct{}
+
+func NewChirpStackDecoder() *ChirpStackDecoder {
+	return &ChirpStackDecoder{}
+}
+
+func (d *ChirpStackDecoder) Name() string {
+	return ChirpStackDecoderName
+}
+
+func (d *ChirpStackDecoder) Decode(payload []byte) (*sensor.MqttPayloadResponse, error) {
+	var uplink chirpStackUplink
+	if err := unmarshal(payload, &uplink); err != nil {
+		return nil, err
+	}
+
+	device := uplink.DeviceInfo.DeviceName
+	if device == "" {
+		device = uplink.DeviceInfo.DevEUI
+	}
+
+	if device == "" {
+		return nil, fmt.Errorf("%w: missing deviceInfo.deviceName and deviceInfo.devEui", ErrInvalidPayload)
+	}
+
+	if uplink.Object == nil {
+		return nil, fmt.Errorf("%w: missing decoded object", ErrInvalidPayload)
+	}
+
+	return uplink.Object.toResponse(device)
+}
+
+// DownlinkAddress returns the DevEUI of an uplink event. ChirpStack queues downlinks by DevEUI
+// and not by the device name used as sensor id.
+func (d *ChirpStackDecoder) DownlinkAddress(payload []byte) (string, error) {
+	var uplink chirpStackUplink
+	if err := unmarshal(payload, &uplink); err != nil {
+		return "", err
+	}
+
+	if uplink.DeviceInfo.DevEUI == "" {
+		return "", fmt.Errorf("%w: missing deviceInfo.devEui", ErrInvalidPayload)
+	}
+
+	return uplink.DeviceInfo.DevEUI, nil
+}
+
+// EncodeDownlink builds a message for the command/down topic. The device has to be the DevEUI, see
+// DownlinkAddress. The command id is encoded into the queue item id, which is returned in the ack event.
+func (d *ChirpStackDecoder) EncodeDownlink(cmd *domain.SensorCommand, device string, fPort uint8) ([]byte, error) {
+	frame, err := commandFrame(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(chirpStackDownlink{
+		ID:        fmt.Sprintf("%s%012x", chirpStackQueueItemPrefix, cmd.ID),
+		DevEUI:    device,
+		Confirmed: true,
+		FPort:     fPort,
+		Data:      frame,
+	})
+}
+
+func (d *ChirpStackDecoder) DecodeDownlinkAck(payload []byte) (int32, error) {
+	var ack chirpStackAck
+	if err := unmarshal(payload, &ack); err != nil {
+		return 0, err
+	}
+
+	raw, ok := strings.CutPrefix(ack.QueueItemID, chirpStackQueueItemPrefix)
+	if !ok {
+		return 0, fmt.Errorf("%w: queue item %q was not queued by the backend", ErrInvalidPayload, ack.QueueItemID)
+	}
+
+	id, err := strconv.ParseInt(raw, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid queue item id %q", ErrInvalidPayload, ack.QueueItemID)
+	}
+
+	if !ack.Acknowledged {
+		return 0, fmt.Errorf("%w: command %d", ErrCommandNotAcked, id)
+	}
+
+	return int32(id), nil
+}
@@ -0,0 +1,76 @@
+package decoder_test
+
+import (
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChirpStackDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *sensor.MqttPayloadResponse
+		wantErr bool
+	}{
+		{
+			name: "should decode uplink event with device name",
+			payload: `{
+				"deduplicationId": "3ac7e3c4-4401-4b8d-9386-a5c902f9202d",
+				"time": "2024-11-10T12:00:00Z",
+				"deviceInfo": {"tenantName": "green-ecolution", "deviceName": "sensor-1", "devEui": "0101010101010101"},
+				"fPort": 1,
+				"object": ` + testDecodedPayload(`"20.5"`) + `
+			}`,
+			want: testPayloadResponse("sensor-1", 20.5),
+		},
+		{
+			name:    "should fall back to dev eui when device name is empty",
+			payload: `{"deviceInfo": {"devEui": "0101010101010101"}, "object": ` + testDecodedPayload(`20.5`) + `}`,
+			want:    testPayloadResponse("0101010101010101", 20.5),
+		},
+		{
+			name:    "should return error on invalid json",
+			payload: `{"deviceInfo":`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when device is unknown",
+			payload: `{"deviceInfo": {}, "object": ` + testDecodedPayload(`20.5`) + `}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when no codec decoded the payload",
+			payload: `{"deviceInfo": {"deviceName": "sensor-1"}, "data": "AQID"}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when battery is missing",
+			payload: `{"deviceInfo": {"deviceName": "sensor-1"}, "object": {"humidity": 50, "temperature": 20}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			d := decoder.NewChirpStackDecoder()
+
+			// when
+			got, err := d.Decode([]byte(tt.payload))
+
+			// then
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
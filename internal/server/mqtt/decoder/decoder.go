@@ -0,0 +1,113 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor/generated"
+)
+
+var (
+	ErrDecoderNotFound   = errors.New("mqtt decoder not found")
+	ErrDecoderRegistered = errors.New("mqtt decoder already registered")
+	ErrInvalidPayload    = errors.New("invalid mqtt payload")
+)
+
+// Decoder converts the raw payload of a mqtt message into the sensor payload
+// that is passed to the sensor service.
+type Decoder interface {
+	Name() string
+	Decode(payload []byte) (*sensor.MqttPayloadResponse, error)
+}
+
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[string]Decoder
+	mapper   sensor.MqttMqttMapper
+}
+
+func NewRegistry(decoders ...Decoder) (*Registry, error) {
+	r := &Registry{
+		decoders: make(map[string]Decoder, len(decoders)),
+		mapper:   &generated.MqttMqttMapperImpl{},
+	}
+
+	for _, d := range decoders {
+		if err := r.Register(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// NewDefaultRegistry returns a registry containing all decoders shipped with the backend.
+func NewDefaultRegistry() *Registry {
+	r, err := NewRegistry(
+		NewTTNDecoder(),
+		NewChirpStackDecoder(),
+		NewJSONDecoder(),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+func (r *Registry) Register(d Decoder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.decoders[d.Name()]; ok {
+		return fmt.Errorf("%w: %s", ErrDecoderRegistered, d.Name())
+	}
+
+	r.decoders[d.Name()] = d
+	return nil
+}
+
+func (r *Registry) Get(name string) (Decoder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDecoderNotFound, name)
+	}
+
+	return d, nil
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.decoders))
+	for name := range r.decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// DecodeSensorPayload decodes the payload with the named decoder and maps it to the domain payload.
+// It is used to replay stored sensor messages outside of the mqtt subscriber.
+func (r *Registry) DecodeSensorPayload(name string, payload []byte) (*domain.MqttPayload, error) {
+	d, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := d.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.FromResponse(decoded), nil
+}
@@ -0,0 +1,59 @@
+package decoder_test
+
+import (
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("should contain all builtin decoders in default registry", func(t *testing.T) {
+		// given
+		r := decoder.NewDefaultRegistry()
+
+		// when
+		names := r.Names()
+
+		// then
+		assert.Equal(t, []string{decoder.ChirpStackDecoderName, decoder.JSONDecoderName, decoder.TTNDecoderName}, names)
+		for _, name := range names {
+			d, err := r.Get(name)
+			assert.NoError(t, err)
+			assert.Equal(t, name, d.Name())
+		}
+	})
+
+	t.Run("should return error when decoder is not registered", func(t *testing.T) {
+		// given
+		r, err := decoder.NewRegistry(decoder.NewJSONDecoder())
+		assert.NoError(t, err)
+
+		// when
+		d, err := r.Get(decoder.TTNDecoderName)
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrDecoderNotFound)
+		assert.Nil(t, d)
+	})
+
+	t.Run("should return error when decoder is registered twice", func(t *testing.T) {
+		// given
+		r := decoder.NewDefaultRegistry()
+
+		// when
+		err := r.Register(decoder.NewTTNDecoder())
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrDecoderRegistered)
+	})
+
+	t.Run("should return error when creating registry with duplicate decoders", func(t *testing.T) {
+		// when
+		r, err := decoder.NewRegistry(decoder.NewJSONDecoder(), decoder.NewJSONDecoder())
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrDecoderRegistered)
+		assert.Nil(t, r)
+	})
+}
@@ -0,0 +1,85 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	ErrUnsupportedCommand = errors.New("unsupported sensor command")
+	ErrCommandNotAcked    = errors.New("downlink was not acknowledged by the device")
+)
+
+// DownlinkEncoder is implemented by decoders whose network server accepts downlink commands.
+// It builds the message for the downlink topic and extracts the command id of a downlink acknowledgement.
+// The device is the id the network server addresses the sensor with, see DownlinkAddresser.
+type DownlinkEncoder interface {
+	EncodeDownlink(cmd *domain.SensorCommand, device string, fPort uint8) ([]byte, error)
+	DecodeDownlinkAck(payload []byte) (int32, error)
+}
+
+// DownlinkAddresser is implemented by decoders whose network server addresses downlinks with another id than
+// the sensor id of the uplinks. It returns this id from an uplink payload. Without it, the sensor id is used.
+type DownlinkAddresser interface {
+	DownlinkAddress(payload []byte) (string, error)
+}
+
+// Command frame opcodes understood by the firmware of our watermark sensors
+const (
+	frameSetInterval    byte = 0x01
+	frameRequestReading byte = 0x02
+	frameReboot         byte = 0x03
+)
+
+const correlationIDPrefix = "green-ecolution:command:"
+
+// commandFrame encodes the command into the binary frame sent to the device.
+// The report interval is sent in minutes as big endian uint16.
+func commandFrame(cmd *domain.SensorCommand) ([]byte, error) {
+	switch cmd.Type {
+	case domain.SensorCommandTypeSetInterval:
+		if cmd.ReportInterval == nil {
+			return nil, fmt.Errorf("%w: missing report interval", ErrUnsupportedCommand)
+		}
+		minutes := *cmd.ReportInterval / time.Minute
+		if minutes < 1 || minutes > 0xffff {
+			return nil, fmt.Errorf("%w: report interval %s out of range", ErrUnsupportedCommand, cmd.ReportInterval)
+		}
+		return binary.BigEndian.AppendUint16([]byte{frameSetInterval}, uint16(minutes)), nil
+	case domain.SensorCommandTypeRequestReading:
+		return []byte{frameRequestReading}, nil
+	case domain.SensorCommandTypeReboot:
+		return []byte{frameReboot}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCommand, cmd.Type)
+	}
+}
+
+func correlationID(id int32) string {
+	return correlationIDPrefix + strconv.Itoa(int(id))
+}
+
+// commandIDFromCorrelationIDs returns the command id of the first correlation id set by the backend
+func commandIDFromCorrelationIDs(ids []string) (int32, bool) {
+	for _, id := range ids {
+		raw, ok := strings.CutPrefix(id, correlationIDPrefix)
+		if !ok {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			continue
+		}
+
+		return int32(parsed), true
+	}
+
+	return 0, false
+}
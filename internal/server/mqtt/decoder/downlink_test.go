@@ -0,0 +1,276 @@
+package decoder_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ decoder.DownlinkEncoder = (*decoder.TTNDecoder)(nil)
+	_ decoder.DownlinkEncoder = (*decoder.ChirpStackDecoder)(nil)
+	_ decoder.DownlinkEncoder = (*decoder.JSONDecoder)(nil)
+
+	_ decoder.DownlinkAddresser = (*decoder.ChirpStackDecoder)(nil)
+)
+
+var testSetIntervalCommand = &domain.SensorCommand{
+	ID:             42,
+	SensorID:       "eui-9876b6fffe1c2b1f",
+	Type:           domain.SensorCommandTypeSetInterval,
+	Status:         domain.SensorCommandStatusQueued,
+	ReportInterval: utils.P(90 * time.Minute),
+}
+
+func TestTTNDecoder_EncodeDownlink(t *testing.T) {
+	t.Run("should encode set interval command as confirmed downlink", func(t *testing.T) {
+		// given
+		d := decoder.NewTTNDecoder()
+
+		// when
+		got, err := d.EncodeDownlink(testSetIntervalCommand, testSetIntervalCommand.SensorID, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"downlinks": [{
+			"f_port": 10,
+			"frm_payload": "AQBa",
+			"priority": "NORMAL",
+			"confirmed": true,
+			"correlation_ids": ["green-ecolution:command:42"]
+		}]}`, string(got))
+	})
+
+	t.Run("should encode command without parameter", func(t *testing.T) {
+		// given
+		d := decoder.NewTTNDecoder()
+		cmd := &domain.SensorCommand{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeReboot}
+
+		// when
+		got, err := d.EncodeDownlink(cmd, cmd.SensorID, 10)
+
+		// then
+		assert.NoError(t, err)
+		var push struct {
+			Downlinks []struct {
+				FrmPayload []byte `json:"frm_payload"`
+			} `json:"downlinks"`
+		}
+		assert.NoError(t, json.Unmarshal(got, &push))
+		assert.Equal(t, []byte{0x03}, push.Downlinks[0].FrmPayload)
+	})
+
+	t.Run("should return error when set interval command has no interval", func(t *testing.T) {
+		// given
+		d := decoder.NewTTNDecoder()
+		cmd := &domain.SensorCommand{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeSetInterval}
+
+		// when
+		got, err := d.EncodeDownlink(cmd, cmd.SensorID, 10)
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrUnsupportedCommand)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error on unknown command type", func(t *testing.T) {
+		// given
+		d := decoder.NewTTNDecoder()
+		cmd := &domain.SensorCommand{ID: 1, SensorID: "sensor-1", Type: "self_destruct"}
+
+		// when
+		got, err := d.EncodeDownlink(cmd, cmd.SensorID, 10)
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrUnsupportedCommand)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTTNDecoder_DecodeDownlinkAck(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    int32
+		wantErr bool
+	}{
+		{
+			name:    "should read command id from downlink ack",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "correlation_ids": ["as:downlink:01J"], "downlink_ack": {"f_port": 10, "confirmed": true, "correlation_ids": ["as:downlink:01J", "green-ecolution:command:42"]}}`,
+			want:    42,
+		},
+		{
+			name:    "should read command id from top level correlation ids",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "correlation_ids": ["green-ecolution:command:7"]}`,
+			want:    7,
+		},
+		{
+			name:    "should return error when downlink was not queued by the backend",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "downlink_ack": {"correlation_ids": ["as:downlink:01J", "green-ecolution:command:abc"]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error on invalid json",
+			payload: `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			d := decoder.NewTTNDecoder()
+
+			// when
+			got, err := d.DecodeDownlinkAck([]byte(tt.payload))
+
+			// then
+			if tt.wantErr {
+				assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChirpStackDecoder_DownlinkAddress(t *testing.T) {
+	t.Run("should return DevEUI of uplink", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+		payload := `{"deviceInfo": {"deviceName": "sensor-1", "devEui": "0101010101010101"}, "object": ` + testDecodedPayload(`20.5`) + `}`
+
+		// when
+		got, err := d.DownlinkAddress([]byte(payload))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "0101010101010101", got)
+	})
+
+	t.Run("should return error when uplink has no DevEUI", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+
+		// when
+		got, err := d.DownlinkAddress([]byte(`{"deviceInfo": {"deviceName": "sensor-1"}}`))
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+		assert.Empty(t, got)
+	})
+}
+
+func TestChirpStackDecoder_EncodeDownlink(t *testing.T) {
+	t.Run("should encode command as queue item of the DevEUI", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+
+		// when
+		got, err := d.EncodeDownlink(testSetIntervalCommand, "0101010101010101", 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": "67650000-0000-4000-8000-00000000002a",
+			"devEui": "0101010101010101",
+			"confirmed": true,
+			"fPort": 10,
+			"data": "AQBa"
+		}`, string(got))
+	})
+}
+
+func TestChirpStackDecoder_DecodeDownlinkAck(t *testing.T) {
+	t.Run("should read command id from queue item id", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+		payload := `{"deviceInfo": {"devEui": "0101010101010101"}, "queueItemId": "67650000-0000-4000-8000-00000000002a", "acknowledged": true, "fCntDown": 3}`
+
+		// when
+		got, err := d.DecodeDownlinkAck([]byte(payload))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(42), got)
+	})
+
+	t.Run("should return error when device did not acknowledge", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+		payload := `{"queueItemId": "67650000-0000-4000-8000-00000000002a", "acknowledged": false}`
+
+		// when
+		got, err := d.DecodeDownlinkAck([]byte(payload))
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrCommandNotAcked)
+		assert.Zero(t, got)
+	})
+
+	t.Run("should return error when queue item was not queued by the backend", func(t *testing.T) {
+		// given
+		d := decoder.NewChirpStackDecoder()
+		payload := `{"queueItemId": "3c3a4d2e-9f2b-4a57-8d52-7a3c6f0e1b2c", "acknowledged": true}`
+
+		// when
+		got, err := d.DecodeDownlinkAck([]byte(payload))
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+		assert.Zero(t, got)
+	})
+}
+
+func TestJSONDecoder_EncodeDownlink(t *testing.T) {
+	t.Run("should encode command with readable fields", func(t *testing.T) {
+		// given
+		d := decoder.NewJSONDecoder()
+
+		// when
+		got, err := d.EncodeDownlink(testSetIntervalCommand, testSetIntervalCommand.SensorID, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"command_id": 42,
+			"device": "eui-9876b6fffe1c2b1f",
+			"type": "set_interval",
+			"report_interval": 5400,
+			"f_port": 10,
+			"data": "AQBa"
+		}`, string(got))
+	})
+}
+
+func TestJSONDecoder_DecodeDownlinkAck(t *testing.T) {
+	t.Run("should read command id", func(t *testing.T) {
+		// given
+		d := decoder.NewJSONDecoder()
+
+		// when
+		got, err := d.DecodeDownlinkAck([]byte(`{"command_id": 42}`))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(42), got)
+	})
+
+	t.Run("should return error when command id is missing", func(t *testing.T) {
+		// given
+		d := decoder.NewJSONDecoder()
+
+		// when
+		got, err := d.DecodeDownlinkAck([]byte(`{"device": "sensor-1"}`))
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+		assert.Zero(t, got)
+	})
+}
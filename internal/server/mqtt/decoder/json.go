@@ -0,0 +1,81 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const JSONDecoderName = "json"
+
+type jsonDownlink struct {
+	CommandID      int32                    `json:"command_id"`
+	Device         string                   `json:"device"`
+	Type           domain.SensorCommandType `json:"type"`
+	ReportInterval *int32                   `json:"report_interval,omitempty"`
+	FPort          uint8                    `json:"f_port"`
+	Data           []byte                   `json:"data"`
+}
+
+type jsonDownlinkAck struct {
+	CommandID int32 `json:"command_id"`
+}
+
+// JSONDecoder decodes messages that are already in the MqttPayload format,
+// e.g. published by a custom gateway.
+type JSONDecoder struct{}
+
+func NewJSONDecoder() *JSONDecoder {
+	return &JSONDecoder{}
+}
+
+func (d *JSONDecoder) Name() string {
+	return JSONDecoderName
+}
+
+func (d *JSONDecoder) Decode(payload []byte) (*sensor.MqttPayloadResponse, error) {
+	var p sensor.MqttPayloadResponse
+	if err := unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Device == "" {
+		return nil, fmt.Errorf("%w: missing device", ErrInvalidPayload)
+	}
+
+	return &p, nil
+}
+
+// EncodeDownlink builds a plain json command for custom gateways. Besides the binary frame it
+// contains the command in readable form, the report interval is given in seconds.
+func (d *JSONDecoder) EncodeDownlink(cmd *domain.SensorCommand, device string, fPort uint8) ([]byte, error) {
+	frame, err := commandFrame(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonDownlink{
+		CommandID:      cmd.ID,
+		Device:         device,
+		Type:           cmd.Type,
+		ReportInterval: utils.DurationPtrToSeconds(cmd.ReportInterval),
+		FPort:          fPort,
+		Data:           frame,
+	})
+}
+
+func (d *JSONDecoder) DecodeDownlinkAck(payload []byte) (int32, error) {
+	var ack jsonDownlinkAck
+	if err := unmarshal(payload, &ack); err != nil {
+		return 0, err
+	}
+
+	if ack.CommandID == 0 {
+		return 0, fmt.Errorf("%w: missing command_id", ErrInvalidPayload)
+	}
+
+	return ack.CommandID, nil
+}
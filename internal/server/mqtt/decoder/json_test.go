@@ -0,0 +1,76 @@
+package decoder_test
+
+import (
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *sensor.MqttPayloadResponse
+		wantErr bool
+	}{
+		{
+			name: "should decode plain mqtt payload",
+			payload: `{
+				"device": "sensor-1",
+				"battery": 3.4,
+				"humidity": 50.5,
+				"temperature": 20.5,
+				"latitude": 54.82124518093376,
+				"longitude": 9.485702120628517,
+				"watermarks": [
+					{"resistance": 23, "centibar": 38, "depth": 30},
+					{"resistance": 24, "centibar": 40, "depth": 60},
+					{"resistance": 25, "centibar": 42, "depth": 90}
+				]
+			}`,
+			want: testPayloadResponse("sensor-1", 20.5),
+		},
+		{
+			name:    "should decode payload with a custom number of watermarks",
+			payload: `{"device": "sensor-2", "battery": 3.1, "watermarks": [{"resistance": 10, "centibar": 12, "depth": 45}]}`,
+			want: &sensor.MqttPayloadResponse{
+				Device:     "sensor-2",
+				Battery:    3.1,
+				Watermarks: []sensor.WatermarkResponse{{Resistance: 10, Centibar: 12, Depth: 45}},
+			},
+		},
+		{
+			name:    "should return error on invalid json",
+			payload: `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when device is missing",
+			payload: `{"battery": 3.4}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			d := decoder.NewJSONDecoder()
+
+			// when
+			got, err := d.Decode([]byte(tt.payload))
+
+			// then
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
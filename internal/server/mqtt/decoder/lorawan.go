@@ -0,0 +1,128 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+)
+
+// lorawanDecodedPayload is the payload produced by the device codec of our watermark
+// sensors. The network server (TTN, ChirpStack, ...) only changes the envelope around it.
+// Sensors with the original codec send up to three probes at 30, 60 and 90 cm, codecs of
+// sensors with other probe counts or depths send the probes in the watermarks list.
+type lorawanDecodedPayload struct {
+	Battery                  *float64    `json:"battery"`
+	Humidity                 *float64    `json:"humidity"`
+	Temperature              *numberLike `json:"temperature"`
+	Latitude                 float64     `json:"latitude"`
+	Longitude                float64     `json:"longitude"`
+	WatermarkOneResistance   *float64    `json:"watermarkOneResistanceValue"`
+	WatermarkOneCentibar     *float64    `json:"watermarkOneCentibarValue"`
+	WatermarkTwoResistance   *float64    `json:"watermarkTwoResistanceValue"`
+	WatermarkTwoCentibar     *float64    `json:"watermarkTwoCentibarValue"`
+	WatermarkThreeResistance *float64    `json:"watermarkThreeResistanceValue"`
+	WatermarkThreeCentibar   *float64    `json:"watermarkThreeCentibarValue"`
+	Watermarks               []struct {
+		Depth      *int     `json:"depth"`
+		Resistance *float64 `json:"resistance"`
+		Centibar   *float64 `json:"centibar"`
+	} `json:"watermarks"`
+}
+
+type lorawanWatermark struct {
+	resistance, centibar *float64
+	depth                int
+}
+
+func (p *lorawanDecodedPayload) watermarks() ([]lorawanWatermark, error) {
+	if len(p.Watermarks) > 0 {
+		watermarks := make([]lorawanWatermark, 0, len(p.Watermarks))
+		for i, w := range p.Watermarks {
+			if w.Depth == nil || *w.Depth <= 0 {
+				return nil, fmt.Errorf("%w: decoded payload is missing the depth of watermark %d", ErrInvalidPayload, i)
+			}
+			watermarks = append(watermarks, lorawanWatermark{w.Resistance, w.Centibar, *w.Depth})
+		}
+		return watermarks, nil
+	}
+
+	watermarks := make([]lorawanWatermark, 0, 3)
+	for _, w := range []lorawanWatermark{
+		{p.WatermarkOneResistance, p.WatermarkOneCentibar, 30},
+		{p.WatermarkTwoResistance, p.WatermarkTwoCentibar, 60},
+		{p.WatermarkThreeResistance, p.WatermarkThreeCentibar, 90},
+	} {
+		// the probe is not connected
+		if w.resistance == nil && w.centibar == nil {
+			continue
+		}
+		watermarks = append(watermarks, w)
+	}
+
+	if len(watermarks) == 0 {
+		return nil, fmt.Errorf("%w: decoded payload has no watermark values", ErrInvalidPayload)
+	}
+
+	return watermarks, nil
+}
+
+func (p *lorawanDecodedPayload) toResponse(device string) (*sensor.MqttPayloadResponse, error) {
+	if p.Battery == nil || p.Humidity == nil || p.Temperature == nil {
+		return nil, fmt.Errorf("%w: decoded payload is missing battery, humidity or temperature", ErrInvalidPayload)
+	}
+
+	watermarks, err := p.watermarks()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &sensor.MqttPayloadResponse{
+		Device:      device,
+		Battery:     *p.Battery,
+		Humidity:    *p.Humidity,
+		Temperature: float64(*p.Temperature),
+		Latitude:    p.Latitude,
+		Longitude:   p.Longitude,
+		Watermarks:  make([]sensor.WatermarkResponse, 0, len(watermarks)),
+	}
+
+	for _, w := range watermarks {
+		if w.resistance == nil || w.centibar == nil {
+			return nil, fmt.Errorf("%w: decoded payload is missing watermark values for depth %d", ErrInvalidPayload, w.depth)
+		}
+
+		payload.Watermarks = append(payload.Watermarks, sensor.WatermarkResponse{
+			Resistance: int(*w.resistance),
+			Centibar:   int(*w.centibar),
+			Depth:      w.depth,
+		})
+	}
+
+	return payload, nil
+}
+
+// numberLike accepts a json number as well as a number encoded as string,
+// because some device codecs send the temperature as string.
+type numberLike float64
+
+func (n *numberLike) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("error parsing number: %w", err)
+	}
+
+	*n = numberLike(v)
+	return nil
+}
+
+func unmarshal(payload []byte, v any) error {
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("%w: error unmarshalling json: %w", ErrInvalidPayload, err)
+	}
+
+	return nil
+}
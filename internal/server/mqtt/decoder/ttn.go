@@ -0,0 +1,106 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+)
+
+const TTNDecoderName = "ttn"
+
+type ttnUplink struct {
+	EndDeviceIDs struct {
+		DeviceID string `json:"device_id"`
+	} `json:"end_device_ids"`
+	UplinkMessage *struct {
+		DecodedPayload *lorawanDecodedPayload `json:"decoded_payload"`
+	} `json:"uplink_message"`
+}
+
+type ttnDownlinkPush struct {
+	Downlinks []ttnDownlink `json:"downlinks"`
+}
+
+type ttnDownlink struct {
+	FPort          uint8    `json:"f_port"`
+	FrmPayload     []byte   `json:"frm_payload"`
+	Priority       string   `json:"priority"`
+	Confirmed      bool     `json:"confirmed"`
+	CorrelationIDs []string `json:"correlation_ids"`
+}
+
+type ttnDownlinkAck struct {
+	CorrelationIDs []string `json:"correlation_ids"`
+	DownlinkAck    *struct {
+		CorrelationIDs []string `json:"correlation_ids"`
+	} `json:"downlink_ack"`
+}
+
+// TTNDecoder decodes uplink messages of The Things Network (TTS v3).
+type TTNDecoder struct{}
+
+func NewTTNDecoder() *TTNDecoder {
+	return &TTNDecoder{}
+}
+
+func (d *TTNDecoder) Name() string {
+	return TTNDecoderName
+}
+
+func (d *TTNDecoder) Decode(payload []byte) (*sensor.MqttPayloadResponse, error) {
+	var uplink ttnUplink
+	if err := unmarshal(payload, &uplink); err != nil {
+		return nil, err
+	}
+
+	if uplink.EndDeviceIDs.DeviceID == "" {
+		return nil, fmt.Errorf("%w: missing end_device_ids.device_id", ErrInvalidPayload)
+	}
+
+	if uplink.UplinkMessage == nil || uplink.UplinkMessage.DecodedPayload == nil {
+		return nil, fmt.Errorf("%w: missing uplink_message.decoded_payload", ErrInvalidPayload)
+	}
+
+	return uplink.UplinkMessage.DecodedPayload.toResponse(uplink.EndDeviceIDs.DeviceID)
+}
+
+// EncodeDownlink builds a message for the down/push topic. The command id is passed as
+// correlation id, TTS returns it in the down/ack message of the confirmed downlink. The device is
+// only part of the topic.
+func (d *TTNDecoder) EncodeDownlink(cmd *domain.SensorCommand, _ string, fPort uint8) ([]byte, error) {
+	frame, err := commandFrame(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(ttnDownlinkPush{
+		Downlinks: []ttnDownlink{{
+			FPort:          fPort,
+			FrmPayload:     frame,
+			Priority:       "NORMAL",
+			Confirmed:      true,
+			CorrelationIDs: []string{correlationID(cmd.ID)},
+		}},
+	})
+}
+
+func (d *TTNDecoder) DecodeDownlinkAck(payload []byte) (int32, error) {
+	var ack ttnDownlinkAck
+	if err := unmarshal(payload, &ack); err != nil {
+		return 0, err
+	}
+
+	ids := ack.CorrelationIDs
+	if ack.DownlinkAck != nil {
+		ids = append(ids, ack.DownlinkAck.CorrelationIDs...)
+	}
+
+	id, ok := commandIDFromCorrelationIDs(ids)
+	if !ok {
+		return 0, fmt.Errorf("%w: missing command correlation id", ErrInvalidPayload)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,138 @@
+package decoder_test
+
+import (
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTNDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *sensor.MqttPayloadResponse
+		wantErr bool
+	}{
+		{
+			name: "should decode uplink with temperature as string",
+			payload: `{
+				"end_device_ids": {"device_id": "eui-9876b6fffe1c2b1f", "application_ids": {"application_id": "green-ecolution"}},
+				"received_at": "2024-11-10T12:00:00Z",
+				"uplink_message": {"f_port": 1, "decoded_payload": ` + testDecodedPayload(`"20.5"`) + `}
+			}`,
+			want: testPayloadResponse("eui-9876b6fffe1c2b1f", 20.5),
+		},
+		{
+			name:    "should decode uplink with temperature as number",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": ` + testDecodedPayload(`21.25`) + `}}`,
+			want:    testPayloadResponse("sensor-1", 21.25),
+		},
+		{
+			name:    "should return error on invalid json",
+			payload: `not json`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when device id is missing",
+			payload: `{"end_device_ids": {}, "uplink_message": {"decoded_payload": ` + testDecodedPayload(`20.5`) + `}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when decoded payload is missing",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"frm_payload": "AQID"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when uplink message is missing",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "join_accept": {}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when watermark values are missing",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": {"battery": 3.4, "humidity": 50, "temperature": 20}}}`,
+			wantErr: true,
+		},
+		{
+			name: "should decode uplink with a single connected probe",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": {
+				"battery": 3.4, "humidity": 50, "temperature": 20,
+				"watermarkTwoResistanceValue": 24, "watermarkTwoCentibarValue": 40
+			}}}`,
+			want: &sensor.MqttPayloadResponse{
+				Device:      "sensor-1",
+				Battery:     3.4,
+				Humidity:    50,
+				Temperature: 20,
+				Watermarks:  []sensor.WatermarkResponse{{Resistance: 24, Centibar: 40, Depth: 60}},
+			},
+		},
+		{
+			name: "should decode uplink with watermark list of arbitrary depths",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": {
+				"battery": 3.4, "humidity": 50, "temperature": 20,
+				"watermarks": [
+					{"depth": 20, "resistance": 23, "centibar": 38},
+					{"depth": 45, "resistance": 24, "centibar": 40},
+					{"depth": 70, "resistance": 25, "centibar": 42},
+					{"depth": 120, "resistance": 26, "centibar": 44}
+				]
+			}}}`,
+			want: &sensor.MqttPayloadResponse{
+				Device:      "sensor-1",
+				Battery:     3.4,
+				Humidity:    50,
+				Temperature: 20,
+				Watermarks: []sensor.WatermarkResponse{
+					{Resistance: 23, Centibar: 38, Depth: 20},
+					{Resistance: 24, Centibar: 40, Depth: 45},
+					{Resistance: 25, Centibar: 42, Depth: 70},
+					{Resistance: 26, Centibar: 44, Depth: 120},
+				},
+			},
+		},
+		{
+			name: "should return error when depth of watermark in list is missing",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": {
+				"battery": 3.4, "humidity": 50, "temperature": 20,
+				"watermarks": [{"resistance": 23, "centibar": 38}]
+			}}}`,
+			wantErr: true,
+		},
+		{
+			name: "should return error when only one value of a probe is present",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": {
+				"battery": 3.4, "humidity": 50, "temperature": 20,
+				"watermarkOneResistanceValue": 23
+			}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "should return error when temperature is not a number",
+			payload: `{"end_device_ids": {"device_id": "sensor-1"}, "uplink_message": {"decoded_payload": ` + testDecodedPayload(`"warm"`) + `}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			d := decoder.NewTTNDecoder()
+
+			// when
+			got, err := d.Decode([]byte(tt.payload))
+
+			// then
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package decoder_test
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+)
+
+// testDecodedPayload returns the output of the watermark sensor codec with the given raw temperature value
+func testDecodedPayload(temperature string) string {
+	return `{
+		"battery": 3.4,
+		"humidity": 50.5,
+		"temperature": ` + temperature + `,
+		"latitude": 54.82124518093376,
+		"longitude": 9.485702120628517,
+		"watermarkOneResistanceValue": 23,
+		"watermarkOneCentibarValue": 38,
+		"watermarkTwoResistanceValue": 24,
+		"watermarkTwoCentibarValue": 40,
+		"watermarkThreeResistanceValue": 25,
+		"watermarkThreeCentibarValue": 42
+	}`
+}
+
+func testPayloadResponse(device string, temperature float64) *sensor.MqttPayloadResponse {
+	return &sensor.MqttPayloadResponse{
+		Device:      device,
+		Battery:     3.4,
+		Humidity:    50.5,
+		Temperature: temperature,
+		Latitude:    54.82124518093376,
+		Longitude:   9.485702120628517,
+		Watermarks: []sensor.WatermarkResponse{
+			{Resistance: 23, Centibar: 38, Depth: 30},
+			{Resistance: 24, Centibar: 40, Depth: 60},
+			{Resistance: 25, Centibar: 42, Depth: 90},
+		},
+	}
+}
@@ -0,0 +1,258 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	defaultDownlinkFPort    uint8 = 10
+	defaultDownlinkInterval       = 30 * time.Second
+	defaultDownlinkExpiry         = 24 * time.Hour
+	deviceTopicPlaceholder        = "{device}"
+)
+
+// downlinkEncoder returns the encoder for downlink commands of a subscription. It returns nil if downlinks are
+// disabled or the decoder of the subscription can not encode downlinks.
+func downlinkEncoder(cfg config.MQTTDownlinkConfig, dec decoder.Decoder, log *slog.Logger) decoder.DownlinkEncoder {
+	if cfg.Topic == "" {
+		return nil
+	}
+
+	enc, ok := dec.(decoder.DownlinkEncoder)
+	if !ok {
+		log.Error("mqtt payload decoder does not support downlink commands", "decoder", dec.Name())
+		return nil
+	}
+
+	return enc
+}
+
+// downlinkRoute is the broker and the encoder of the subscription the latest uplink of a sensor was received on.
+// The device is the id the network server addresses the sensor with, it is used in the topic and the downlink.
+type downlinkRoute struct {
+	broker string
+	enc    decoder.DownlinkEncoder
+	device string
+}
+
+// downlinkDevice returns the id the network server addresses the sensor with. This is the sensor id, unless the
+// encoder resolves another id from the uplink, like the DevEUI of ChirpStack.
+func downlinkDevice(enc decoder.DownlinkEncoder, payload []byte, sensorID string) string {
+	addresser, ok := enc.(decoder.DownlinkAddresser)
+	if !ok {
+		return sensorID
+	}
+
+	device, err := addresser.DownlinkAddress(payload)
+	if err != nil {
+		slog.Warn("could not resolve downlink address of sensor, using the sensor id", "error", err, "sensor_id", sensorID)
+		return sensorID
+	}
+
+	return device
+}
+
+// downlinkRoutes tracks where the latest uplink of every sensor was received. A device only receives downlinks
+// from the network server it is joined to, so its commands are published by the broker of this network server
+// with the encoder of the subscription its uplinks arrive on. The routes are kept in memory, commands of sensors
+// without uplink since the start are published by the downlink broker, see downlinkBroker.
+type downlinkRoutes struct {
+	mu     sync.RWMutex
+	routes map[string]downlinkRoute
+}
+
+func newDownlinkRoutes() *downlinkRoutes {
+	return &downlinkRoutes{routes: make(map[string]downlinkRoute)}
+}
+
+func (r *downlinkRoutes) set(sensorID string, route downlinkRoute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[sensorID] = route
+}
+
+func (r *downlinkRoutes) get(sensorID string) (downlinkRoute, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	route, ok := r.routes[sensorID]
+	return route, ok
+}
+
+// downlinkBroker returns the name of the broker that publishes the commands of sensors without a known route. This
+// is the configured downlink broker or, if none is configured, the only broker with downlinks. Returns an empty
+// string if there is no such broker.
+func (m *Mqtt) downlinkBroker() string {
+	names := make([]string, 0)
+	for _, broker := range m.brokers() {
+		if broker.Downlink.Topic != "" {
+			names = append(names, brokerName(broker))
+		}
+	}
+
+	if name := m.cfg.MQTT.DownlinkBroker; name != "" {
+		if !slices.Contains(names, name) {
+			return ""
+		}
+		return name
+	}
+
+	if len(names) == 1 {
+		return names[0]
+	}
+
+	return ""
+}
+
+func (m *Mqtt) downlinkExpiry() time.Duration {
+	if m.cfg.MQTT.DownlinkExpiry > 0 {
+		return m.cfg.MQTT.DownlinkExpiry
+	}
+	return defaultDownlinkExpiry
+}
+
+// publishFunc publishes a payload to the given topic and waits until the broker received it
+type publishFunc func(topic string, payload []byte) error
+
+// runDownlink publishes queued sensor commands in the configured interval. The fallback encoder is used for sensors
+// without a known route, it is nil if the broker is not the downlink broker. It blocks until the context is canceled.
+func (m *Mqtt) runDownlink(ctx context.Context, client MQTT.Client, broker string, cfg config.MQTTDownlinkConfig, fallback decoder.DownlinkEncoder, log *slog.Logger) {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultDownlinkInterval
+	}
+	log.Info("publishing sensor commands to downlink topic", "topic", cfg.Topic, "ack_topic", cfg.AckTopic, "interval", interval)
+
+	publish := func(topic string, payload []byte) error {
+		token := client.Publish(topic, 1, false, payload)
+		_ = token.Wait()
+		return token.Error()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.publishQueuedCommands(ctx, broker, cfg, fallback, publish)
+		}
+	}
+}
+
+// publishQueuedCommands publishes the queued commands of the sensors whose latest uplink was received by the
+// broker, oldest first, and marks them as sent. Commands of other sensors are left to their broker. Commands of
+// sensors without uplink since the start are published with the fallback encoder if the broker is the downlink
+// broker. Without a downlink broker, or if the fallback encoder addresses the sensor by an id of its uplinks, they
+// wait for an uplink of the sensor and expire if the sensor is not heard from within the downlink expiry. Commands that could not be published are retried in the next run.
+func (m *Mqtt) publishQueuedCommands(ctx context.Context, broker string, cfg config.MQTTDownlinkConfig, fallback decoder.DownlinkEncoder, publish publishFunc) {
+	fPort := cfg.FPort
+	if fPort == 0 {
+		fPort = defaultDownlinkFPort
+	}
+
+	commands, err := m.svc.SensorCommandService.GetAllQueued(ctx)
+	if err != nil {
+		slog.Error("error while fetching queued sensor commands", "error", err)
+		return
+	}
+
+	routable := m.downlinkBroker() != ""
+	// the fallback encoder can not address sensors whose downlink address is only known from an uplink
+	_, needsUplink := fallback.(decoder.DownlinkAddresser)
+	for _, cmd := range commands {
+		route, ok := m.routes.get(cmd.SensorID)
+		switch {
+		case ok && route.broker == broker:
+		case !ok && fallback != nil && !needsUplink:
+			route = downlinkRoute{broker: broker, enc: fallback, device: cmd.SensorID}
+		case !ok && (!routable || needsUplink):
+			m.expireUnroutableCommand(ctx, cmd)
+			continue
+		default:
+			continue
+		}
+
+		payload, err := route.enc.EncodeDownlink(cmd, route.device, fPort)
+		if err != nil {
+			slog.Error("error while encoding sensor command", "error", err, "sensor_command_id", cmd.ID, "sensor_id", cmd.SensorID)
+			continue
+		}
+
+		topic := strings.ReplaceAll(cfg.Topic, deviceTopicPlaceholder, route.device)
+		if err := publish(topic, payload); err != nil {
+			slog.Error("error while publishing sensor command", "error", err, "sensor_command_id", cmd.ID, "topic", topic)
+			continue
+		}
+
+		if _, err := m.svc.SensorCommandService.MarkSent(ctx, cmd.ID); err != nil {
+			slog.Error("error while marking sensor command as sent", "error", err, "sensor_command_id", cmd.ID)
+			continue
+		}
+
+		slog.Info("published sensor command", "sensor_command_id", cmd.ID, "sensor_id", cmd.SensorID, "command_type", cmd.Type, "topic", topic)
+	}
+}
+
+// expireUnroutableCommand expires a command that can not be routed to a broker once it is queued longer than the
+// downlink expiry. Every broker with downlinks checks the command, so it may already be expired by another one.
+func (m *Mqtt) expireUnroutableCommand(ctx context.Context, cmd *domain.SensorCommand) {
+	if time.Since(cmd.CreatedAt) <= m.downlinkExpiry() {
+		return
+	}
+
+	if _, err := m.svc.SensorCommandService.Expire(ctx, cmd.ID); err != nil {
+		if !errors.Is(err, service.ErrSensorCommandNotQueued) {
+			slog.Error("error while expiring sensor command", "error", err, "sensor_command_id", cmd.ID, "sensor_id", cmd.SensorID)
+		}
+		return
+	}
+
+	slog.Warn("expired sensor command that can not be routed to a broker", "sensor_command_id", cmd.ID, "sensor_id", cmd.SensorID, "created_at", cmd.CreatedAt)
+}
+
+// subscribeDownlinkAck listens for acknowledgements of the network server
+func (m *Mqtt) subscribeDownlinkAck(client MQTT.Client, encs []decoder.DownlinkEncoder, topic string, log *slog.Logger) {
+	token := client.Subscribe(topic, defaultQoS, m.handleDownlinkAck(encs))
+	go func(token MQTT.Token) {
+		_ = token.Wait()
+		if token.Error() != nil {
+			log.Error("error while subscribing to downlink ack topic", "error", token.Error(), "topic", topic)
+		}
+	}(token)
+}
+
+// handleDownlinkAck acknowledges the command of an ack message. The subscriptions of a broker may use different
+// encoders, the ack is decoded by the first one that understands it.
+func (m *Mqtt) handleDownlinkAck(encs []decoder.DownlinkEncoder) MQTT.MessageHandler {
+	return func(_ MQTT.Client, msg MQTT.Message) {
+		ctx := context.Background()
+		var id int32
+		err := ErrNoDownlinkEncoder
+		for _, enc := range encs {
+			if id, err = enc.DecodeDownlinkAck(msg.Payload()); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			slog.Warn("ignoring downlink ack", "error", err, "topic", msg.Topic())
+			return
+		}
+
+		if _, err := m.svc.SensorCommandService.Acknowledge(ctx, id); err != nil {
+			slog.Error("error while acknowledging sensor command", "error", err, "sensor_command_id", id)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testMessage struct {
+	topic   string
+	payload []byte
+}
+
+func (m *testMessage) Duplicate() bool   { return false }
+func (m *testMessage) Qos() byte         { return 1 }
+func (m *testMessage) Retained() bool    { return false }
+func (m *testMessage) Topic() string     { return m.topic }
+func (m *testMessage) MessageID() uint16 { return 1 }
+func (m *testMessage) Payload() []byte   { return m.payload }
+func (m *testMessage) Ack()              {}
+
+type published struct {
+	topic   string
+	payload []byte
+}
+
+func newTestMqtt(t *testing.T) (*Mqtt, *serviceMock.MockSensorCommandService) {
+	cmdSvc := serviceMock.NewMockSensorCommandService(t)
+	cfg := &config.Config{
+		MQTT: config.MQTTConfig{
+			Downlink: config.MQTTDownlinkConfig{
+				Topic: "v3/green-ecolution/devices/{device}/down/push",
+			},
+		},
+	}
+	return NewMqtt(cfg, &service.Services{SensorCommandService: cmdSvc}, decoder.NewDefaultRegistry()), cmdSvc
+}
+
+func TestMqtt_PublishQueuedCommands(t *testing.T) {
+	ctx := context.Background()
+	// routeSensors routes the sensors to the broker as if their uplinks were received on its json subscription
+	routeSensors := func(m *Mqtt, broker string, sensorIDs ...string) {
+		for _, id := range sensorIDs {
+			m.routes.set(id, downlinkRoute{broker: broker, enc: decoder.NewJSONDecoder(), device: id})
+		}
+	}
+	queued := []*domain.SensorCommand{
+		{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeSetInterval, Status: domain.SensorCommandStatusQueued, ReportInterval: utils.P(15 * time.Minute)},
+		{ID: 2, SensorID: "sensor-2", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued},
+	}
+
+	t.Run("should publish queued commands to device topic and mark them as sent", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		routeSensors(m, "default", "sensor-1", "sensor-2")
+		var got []published
+		publish := func(topic string, payload []byte) error {
+			got = append(got, published{topic, payload})
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(queued, nil)
+		cmdSvc.EXPECT().MarkSent(ctx, int32(1)).Return(queued[0], nil)
+		cmdSvc.EXPECT().MarkSent(ctx, int32(2)).Return(queued[1], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, nil, publish)
+
+		// then
+		assert.Len(t, got, 2)
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-1/down/push", got[0].topic)
+		assert.JSONEq(t, `{"command_id": 1, "device": "sensor-1", "type": "set_interval", "report_interval": 900, "f_port": 10, "data": "AQAP"}`, string(got[0].payload))
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-2/down/push", got[1].topic)
+	})
+
+	t.Run("should keep command queued when publishing fails", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		routeSensors(m, "default", "sensor-1", "sensor-2")
+		publish := func(topic string, _ []byte) error {
+			if topic == "v3/green-ecolution/devices/sensor-1/down/push" {
+				return errors.New("not connected")
+			}
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(queued, nil)
+		cmdSvc.EXPECT().MarkSent(ctx, int32(2)).Return(queued[1], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, nil, publish)
+
+		// then
+		cmdSvc.AssertNotCalled(t, "MarkSent", ctx, int32(1))
+	})
+
+	t.Run("should skip commands that can not be encoded", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		routeSensors(m, "default", "sensor-1")
+		invalid := []*domain.SensorCommand{{ID: 3, SensorID: "sensor-1", Type: domain.SensorCommandTypeSetInterval}}
+		publish := func(_ string, _ []byte) error {
+			t.Fatal("publish must not be called")
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(invalid, nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, nil, publish)
+
+		// then
+		cmdSvc.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should only publish commands of sensors heard by the broker", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		routeSensors(m, "chirpstack", "sensor-1")
+		var got []published
+		publish := func(topic string, payload []byte) error {
+			got = append(got, published{topic, payload})
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(queued, nil).Twice()
+		cmdSvc.EXPECT().MarkSent(ctx, int32(1)).Return(queued[0], nil).Once()
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, nil, publish)
+		m.publishQueuedCommands(ctx, "chirpstack", m.cfg.MQTT.Downlink, nil, publish)
+
+		// then
+		assert.Len(t, got, 1)
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-1/down/push", got[0].topic)
+		cmdSvc.AssertNotCalled(t, "MarkSent", ctx, int32(2))
+	})
+
+	t.Run("should publish commands of sensors without uplink with the fallback encoder", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		routeSensors(m, "chirpstack", "sensor-1")
+		var got []published
+		publish := func(topic string, payload []byte) error {
+			got = append(got, published{topic, payload})
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(queued, nil)
+		cmdSvc.EXPECT().MarkSent(ctx, int32(2)).Return(queued[1], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, decoder.NewJSONDecoder(), publish)
+
+		// then
+		assert.Len(t, got, 1)
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-2/down/push", got[0].topic)
+		cmdSvc.AssertNotCalled(t, "MarkSent", ctx, int32(1))
+	})
+
+	t.Run("should publish commands to the DevEUI of the latest ChirpStack uplink", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		downlink := config.MQTTDownlinkConfig{Topic: "application/1/device/{device}/command/down"}
+		m.routes.set("sensor-1", downlinkRoute{broker: "chirpstack", enc: decoder.NewChirpStackDecoder(), device: "0101010101010101"})
+		var got []published
+		publish := func(topic string, payload []byte) error {
+			got = append(got, published{topic, payload})
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(queued[:1], nil)
+		cmdSvc.EXPECT().MarkSent(ctx, int32(1)).Return(queued[0], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "chirpstack", downlink, nil, publish)
+
+		// then
+		assert.Len(t, got, 1)
+		assert.Equal(t, "application/1/device/0101010101010101/command/down", got[0].topic)
+		assert.Contains(t, string(got[0].payload), `"devEui":"0101010101010101"`)
+	})
+
+	t.Run("should not publish commands of sensors without uplink with a fallback encoder that needs the uplink", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		m.cfg.MQTT.DownlinkExpiry = time.Hour
+		old := []*domain.SensorCommand{
+			{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued, CreatedAt: time.Now().Add(-2 * time.Hour)},
+			{ID: 2, SensorID: "sensor-2", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued, CreatedAt: time.Now()},
+		}
+		publish := func(_ string, _ []byte) error {
+			t.Fatal("publish must not be called")
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(old, nil)
+		cmdSvc.EXPECT().Expire(ctx, int32(1)).Return(old[0], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, decoder.NewChirpStackDecoder(), publish)
+
+		// then
+		cmdSvc.AssertNotCalled(t, "Expire", ctx, int32(2))
+	})
+
+	t.Run("should expire commands that can not be routed after the downlink expiry", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		downlink := config.MQTTDownlinkConfig{Topic: "devices/{device}/down"}
+		m.cfg.MQTT.Brokers = []config.MQTTBrokerConfig{{Name: "ttn", Downlink: downlink}, {Name: "chirpstack", Downlink: downlink}}
+		m.cfg.MQTT.DownlinkExpiry = time.Hour
+		unroutable := []*domain.SensorCommand{
+			{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued, CreatedAt: time.Now().Add(-2 * time.Hour)},
+			{ID: 2, SensorID: "sensor-2", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued, CreatedAt: time.Now()},
+		}
+		publish := func(_ string, _ []byte) error {
+			t.Fatal("publish must not be called")
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(unroutable, nil)
+		cmdSvc.EXPECT().Expire(ctx, int32(1)).Return(unroutable[0], nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "ttn", downlink, nil, publish)
+
+		// then
+		cmdSvc.AssertNotCalled(t, "Expire", ctx, int32(2))
+	})
+
+	t.Run("should not expire commands of sensors without uplink when there is a downlink broker", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		old := []*domain.SensorCommand{
+			{ID: 1, SensorID: "sensor-1", Type: domain.SensorCommandTypeReboot, Status: domain.SensorCommandStatusQueued, CreatedAt: time.Now().Add(-48 * time.Hour)},
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(old, nil)
+
+		// when
+		m.publishQueuedCommands(ctx, "chirpstack", m.cfg.MQTT.Downlink, nil, func(_ string, _ []byte) error { return nil })
+
+		// then
+		cmdSvc.AssertNotCalled(t, "Expire", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not publish when queued commands can not be fetched", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		publish := func(_ string, _ []byte) error {
+			t.Fatal("publish must not be called")
+			return nil
+		}
+		cmdSvc.EXPECT().GetAllQueued(ctx).Return(nil, errors.New("db down"))
+
+		// when
+		m.publishQueuedCommands(ctx, "default", m.cfg.MQTT.Downlink, nil, publish)
+	})
+}
+
+func TestMqtt_DownlinkBroker(t *testing.T) {
+	downlink := config.MQTTDownlinkConfig{Topic: "devices/{device}/down"}
+
+	tests := []struct {
+		name     string
+		cfg      config.MQTTConfig
+		expected string
+	}{
+		{
+			name:     "should use the single broker with downlinks",
+			cfg:      config.MQTTConfig{Downlink: downlink},
+			expected: "default",
+		},
+		{
+			name:     "should use the only broker of the list with downlinks",
+			cfg:      config.MQTTConfig{Brokers: []config.MQTTBrokerConfig{{Name: "ttn"}, {Broker: "chirpstack:1883", Downlink: downlink}}},
+			expected: "chirpstack:1883",
+		},
+		{
+			name:     "should use the configured downlink broker",
+			cfg:      config.MQTTConfig{DownlinkBroker: "ttn", Brokers: []config.MQTTBrokerConfig{{Name: "ttn", Downlink: downlink}, {Name: "chirpstack", Downlink: downlink}}},
+			expected: "ttn",
+		},
+		{
+			name:     "should not use a configured downlink broker without downlinks",
+			cfg:      config.MQTTConfig{DownlinkBroker: "ttn", Brokers: []config.MQTTBrokerConfig{{Name: "ttn"}, {Name: "chirpstack", Downlink: downlink}}},
+			expected: "",
+		},
+		{
+			name:     "should have no downlink broker with several brokers with downlinks",
+			cfg:      config.MQTTConfig{Brokers: []config.MQTTBrokerConfig{{Name: "ttn", Downlink: downlink}, {Name: "chirpstack", Downlink: downlink}}},
+			expected: "",
+		},
+		{
+			name:     "should have no downlink broker without downlinks",
+			cfg:      config.MQTTConfig{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			m := NewMqtt(&config.Config{MQTT: tt.cfg}, &service.Services{}, decoder.NewDefaultRegistry())
+
+			// when
+			got := m.downlinkBroker()
+
+			// then
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMqtt_HandleDownlinkAck(t *testing.T) {
+	t.Run("should acknowledge command of ack message", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		cmdSvc.EXPECT().Acknowledge(mock.Anything, int32(42)).Return(&domain.SensorCommand{ID: 42}, nil)
+		handler := m.handleDownlinkAck([]decoder.DownlinkEncoder{decoder.NewJSONDecoder()})
+
+		// when
+		handler(nil, &testMessage{topic: "ack", payload: []byte(`{"command_id": 42}`)})
+	})
+
+	t.Run("should decode ack message with the encoder that understands it", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		cmdSvc.EXPECT().Acknowledge(mock.Anything, int32(42)).Return(&domain.SensorCommand{ID: 42}, nil)
+		handler := m.handleDownlinkAck([]decoder.DownlinkEncoder{decoder.NewTTNDecoder(), decoder.NewJSONDecoder()})
+
+		// when
+		handler(nil, &testMessage{topic: "ack", payload: []byte(`{"command_id": 42}`)})
+	})
+
+	t.Run("should ignore ack message without command id", func(t *testing.T) {
+		// given
+		m, cmdSvc := newTestMqtt(t)
+		handler := m.handleDownlinkAck([]decoder.DownlinkEncoder{decoder.NewJSONDecoder()})
+
+		// when
+		handler(nil, &testMessage{topic: "ack", payload: []byte(`{}`)})
+
+		// then
+		cmdSvc.AssertNotCalled(t, "Acknowledge", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMqtt_DownlinkRoutes(t *testing.T) {
+	t.Run("should route downlinks of a sensor to the broker and subscription of its latest uplink", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		m := NewMqtt(&config.Config{}, &service.Services{SensorService: sensorSvc}, decoder.NewDefaultRegistry())
+		enc := decoder.NewJSONDecoder()
+		sensorSvc.EXPECT().HandleMessage(mock.Anything, mock.Anything).Return(&domain.SensorData{}, nil).Twice()
+		payload := []byte(`{"device": "sensor-1", "battery": 3.1, "watermarks": [{"resistance": 10, "centibar": 12, "depth": 45}]}`)
+
+		// when
+		m.handleMqttMessage("plain", subscription{dec: enc})(nil, &testMessage{topic: "up", payload: payload})
+		_, routedWithoutDownlink := m.routes.get("sensor-1")
+		m.handleMqttMessage("chirpstack", subscription{dec: enc, enc: enc})(nil, &testMessage{topic: "up", payload: payload})
+		got, ok := m.routes.get("sensor-1")
+
+		// then
+		assert.False(t, routedWithoutDownlink)
+		assert.True(t, ok)
+		assert.Equal(t, "chirpstack", got.broker)
+		assert.Same(t, enc, got.enc)
+		assert.Equal(t, "sensor-1", got.device)
+	})
+
+	t.Run("should route downlinks of ChirpStack sensors to their DevEUI", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		m := NewMqtt(&config.Config{}, &service.Services{SensorService: sensorSvc}, decoder.NewDefaultRegistry())
+		dec := decoder.NewChirpStackDecoder()
+		sensorSvc.EXPECT().HandleMessage(mock.Anything, mock.Anything).Return(&domain.SensorData{}, nil)
+		payload := []byte(`{"deviceInfo": {"deviceName": "sensor-1", "devEui": "0101010101010101"}, "object": {"battery": 3.1, "humidity": 50.5, "temperature": 21.5, "watermarks": [{"resistance": 10, "centibar": 12, "depth": 45}]}}`)
+
+		// when
+		m.handleMqttMessage("chirpstack", subscription{dec: dec, enc: dec})(nil, &testMessage{topic: "up", payload: payload})
+		got, ok := m.routes.get("sensor-1")
+
+		// then
+		assert.True(t, ok)
+		assert.Equal(t, "0101010101010101", got.device)
+	})
+}
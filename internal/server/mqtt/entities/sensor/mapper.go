@@ -9,6 +9,7 @@ import (
 type MqttMqttMapper interface {
 	ToResponse(src *domain.MqttPayload) *MqttPayloadResponse
 	ToResponseList(src []*domain.MqttPayload) []*MqttPayloadResponse
+	// goverter:ignore RawWatermarks
 	FromResponse(src *MqttPayloadResponse) *domain.MqttPayload
 	FromResponseList(src []*MqttPayloadResponse) []*domain.MqttPayload
 }
@@ -2,122 +2,282 @@ package mqtt
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
+	"sync"
 
 	MQTT "github.com/eclipse/paho.mqtt.golang"
 	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
+const (
+	defaultDecoder         = decoder.TTNDecoderName
+	defaultQoS        byte = 1
+	disconnectQuiesce      = 250 // milliseconds
+)
+
+var (
+	ErrNoSubscription    = errors.New("mqtt broker has no subscription")
+	ErrEmptyTopic        = errors.New("mqtt subscription has no topic")
+	ErrInvalidQoS        = errors.New("mqtt qos must be 0, 1 or 2")
+	ErrNoDownlinkEncoder = errors.New("mqtt broker has no downlink encoder")
+)
+
 type Mqtt struct {
-	cfg    *config.Config
-	svc    *service.Services
-	mapper sensor.MqttMqttMapper
+	cfg      *config.Config
+	svc      *service.Services
+	mapper   sensor.MqttMqttMapper
+	decoders *decoder.Registry
+	routes   *downlinkRoutes
 }
 
-func NewMqtt(cfg *config.Config, services *service.Services) *Mqtt {
+func NewMqtt(cfg *config.Config, services *service.Services, decoders *decoder.Registry) *Mqtt {
 	return &Mqtt{
-		cfg:    cfg,
-		svc:    services,
-		mapper: &generated.MqttMqttMapperImpl{},
+		cfg:      cfg,
+		svc:      services,
+		mapper:   &generated.MqttMqttMapperImpl{},
+		decoders: decoders,
+		routes:   newDownlinkRoutes(),
 	}
 }
 
+// subscription is a topic of a broker with the decoder that is used for its messages and the encoder for
+// downlinks to the sensors heard on it, nil if the broker has no downlinks
+type subscription struct {
+	topic string
+	qos   byte
+	dec   decoder.Decoder
+	enc   decoder.DownlinkEncoder
+}
+
+// RunSubscriber connects to all configured brokers and subscribes to their topics. It blocks until the context is canceled.
 func (m *Mqtt) RunSubscriber(ctx context.Context) {
-	opts := MQTT.NewClientOptions()
-	opts.AddBroker(m.cfg.MQTT.Broker)
-	opts.SetClientID(m.cfg.MQTT.ClientID)
-	opts.SetUsername(m.cfg.MQTT.Username)
-	opts.SetPassword(m.cfg.MQTT.Password)
+	var wg sync.WaitGroup
+	for _, broker := range m.brokers() {
+		wg.Add(1)
+		go func(broker config.MQTTBrokerConfig) {
+			defer wg.Done()
+			m.runBroker(ctx, broker)
+		}(broker)
+	}
 
-	opts.OnConnect = func(_ MQTT.Client) {
-		slog.Info("connected to mqtt broker")
+	wg.Wait()
+	slog.Info("shutting down mqtt subscriber")
+}
+
+// brokers returns the configured brokers. If no brokers list is configured,
+// the single broker settings are used with one subscription.
+func (m *Mqtt) brokers() []config.MQTTBrokerConfig {
+	cfg := m.cfg.MQTT
+	if len(cfg.Brokers) > 0 {
+		return cfg.Brokers
 	}
-	opts.OnConnectionLost = func(_ MQTT.Client, err error) {
-		slog.Error("lost connection to mqtt broker", "error", err)
+
+	return []config.MQTTBrokerConfig{
+		{
+			Name:     "default",
+			Broker:   cfg.Broker,
+			ClientID: cfg.ClientID,
+			Username: cfg.Username,
+			Password: cfg.Password,
+			TLS:      cfg.TLS,
+			Subscriptions: []config.MQTTSubscriptionConfig{
+				{Topic: cfg.Topic, Decoder: cfg.Decoder},
+			},
+			Downlink: cfg.Downlink,
+		},
 	}
+}
 
-	client := MQTT.NewClient(opts)
-	if token := client.Connect(); token.Wait() && token.Error() != nil {
-		slog.Error("error connecting to mqtt broker", "error", token.Error())
-		return
+// subscriptions resolves the decoder and qos of every subscription of a broker
+func (m *Mqtt) subscriptions(broker config.MQTTBrokerConfig) ([]subscription, error) {
+	if len(broker.Subscriptions) == 0 {
+		return nil, ErrNoSubscription
 	}
 
-	token := client.Subscribe(m.cfg.MQTT.Topic, 1, m.handleMqttMessage)
-	go func(token MQTT.Token) {
-		_ = token.Wait()
-		if token.Error() != nil {
-			slog.Error("error while subscribing to mqtt broker", "error", token.Error())
+	subs := make([]subscription, 0, len(broker.Subscriptions))
+	for _, sub := range broker.Subscriptions {
+		if sub.Topic == "" {
+			return nil, ErrEmptyTopic
 		}
-	}(token)
 
-	<-ctx.Done()
-	slog.Info("shutting down mqtt subscriber")
+		decoderName := sub.Decoder
+		if decoderName == "" {
+			decoderName = defaultDecoder
+		}
+
+		dec, err := m.decoders.Get(decoderName)
+		if err != nil {
+			return nil, err
+		}
+
+		qos := defaultQoS
+		if sub.QoS != nil {
+			qos = *sub.QoS
+		}
+		if qos > 2 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidQoS, qos)
+		}
+
+		subs = append(subs, subscription{topic: sub.Topic, qos: qos, dec: dec})
+	}
+
+	return subs, nil
+}
+
+// brokerName returns the name of the broker, or its address if it has no name
+func brokerName(broker config.MQTTBrokerConfig) string {
+	if broker.Name == "" {
+		return broker.Broker
+	}
+	return broker.Name
 }
 
-func (m *Mqtt) handleMqttMessage(_ MQTT.Client, msg MQTT.Message) {
-	sensorData, err := m.convertToMqttPayloadResponse(msg)
+func (m *Mqtt) runBroker(ctx context.Context, broker config.MQTTBrokerConfig) {
+	name := brokerName(broker)
+	log := slog.With("broker", name)
+
+	subs, err := m.subscriptions(broker)
 	if err != nil {
-		slog.Error("error while converting mqtt payload to sensor data", "error", err)
+		log.Error("error while setting up mqtt subscriptions", "error", err, "available_decoders", m.decoders.Names())
 		return
 	}
 
-	slog.Info("received sensor data", "sensor_id", sensorData.Device)
-	slog.Debug("detailed sensor data", "sensor_raw_data", fmt.Sprintf("%+v", sensorData))
-
-	domainPayload := m.mapper.FromResponse(sensorData)
-	_, err = m.svc.SensorService.HandleMessage(context.Background(), domainPayload)
+	tlsCfg, err := newTLSConfig(broker.TLS)
 	if err != nil {
+		log.Error("error while setting up tls for mqtt broker", "error", err)
+		return
+	}
+
+	opts := MQTT.NewClientOptions()
+	opts.AddBroker(broker.Broker)
+	opts.SetClientID(broker.ClientID)
+	opts.SetUsername(broker.Username)
+	opts.SetPassword(broker.Password)
+	opts.SetAutoReconnect(true)
+	opts.SetConnectRetry(true)
+	if tlsCfg != nil {
+		opts.SetTLSConfig(tlsCfg)
+	}
+
+	encs := make([]decoder.DownlinkEncoder, 0, len(subs))
+	for i := range subs {
+		subs[i].enc = downlinkEncoder(broker.Downlink, subs[i].dec, log.With("topic", subs[i].topic))
+		if subs[i].enc != nil {
+			encs = append(encs, subs[i].enc)
+		}
+	}
+
+	// OnConnect is called after every (re)connect. The session is not resumed, so all topics are subscribed again.
+	opts.OnConnect = func(client MQTT.Client) {
+		log.Info("connected to mqtt broker")
+		m.subscribe(client, name, subs, log)
+		if len(encs) > 0 && broker.Downlink.AckTopic != "" {
+			m.subscribeDownlinkAck(client, encs, broker.Downlink.AckTopic, log)
+		}
+	}
+	opts.OnConnectionLost = func(_ MQTT.Client, err error) {
+		log.Error("lost connection to mqtt broker, trying to reconnect", "error", err)
+	}
+	opts.OnReconnecting = func(_ MQTT.Client, _ *MQTT.ClientOptions) {
+		log.Info("reconnecting to mqtt broker")
+	}
+
+	// with connect retry the token completes as soon as the first connection is established
+	client := MQTT.NewClient(opts)
+	token := client.Connect()
+	select {
+	case <-token.Done():
+		if token.Error() != nil {
+			log.Error("error connecting to mqtt broker", "error", token.Error())
+			return
+		}
+	case <-ctx.Done():
+		client.Disconnect(disconnectQuiesce)
 		return
 	}
+
+	if len(encs) > 0 {
+		// the downlink broker publishes the commands of sensors without a known route with its first encoder
+		var fallback decoder.DownlinkEncoder
+		if name == m.downlinkBroker() {
+			fallback = encs[0]
+		}
+		go m.runDownlink(ctx, client, name, broker.Downlink, fallback, log)
+	}
+
+	<-ctx.Done()
+	client.Disconnect(disconnectQuiesce)
 }
 
-func (m *Mqtt) convertToMqttPayloadResponse(msg MQTT.Message) (*sensor.MqttPayloadResponse, error) {
-	var raw map[string]any
-	if err := json.Unmarshal(msg.Payload(), &raw); err != nil {
-		return nil, fmt.Errorf("error unmarshalling json: %w", err)
+func (m *Mqtt) subscribe(client MQTT.Client, broker string, subs []subscription, log *slog.Logger) {
+	for _, sub := range subs {
+		log.Info("subscribing to mqtt topic", "topic", sub.topic, "decoder", sub.dec.Name(), "qos", sub.qos)
+		token := client.Subscribe(sub.topic, sub.qos, m.handleMqttMessage(broker, sub))
+		go func(token MQTT.Token, topic string) {
+			_ = token.Wait()
+			if token.Error() != nil {
+				log.Error("error while subscribing to mqtt topic", "error", token.Error(), "topic", topic)
+			}
+		}(token, sub.topic)
 	}
+}
 
-	endDevices := raw["end_device_ids"].(map[string]any)
-	uplinkMessage := raw["uplink_message"].(map[string]any)
-	decodedPayload := uplinkMessage["decoded_payload"].(map[string]any)
+// handleMqttMessage passes the decoded uplink to the sensor service and remembers the broker and subscription
+// it was received on, so downlink commands for the sensor are routed back the same way
+func (m *Mqtt) handleMqttMessage(broker string, sub subscription) MQTT.MessageHandler {
+	dec := sub.dec
+	return func(_ MQTT.Client, msg MQTT.Message) {
+		ctx := context.Background()
+		sensorData, err := dec.Decode(msg.Payload())
+		if err != nil {
+			slog.Error("error while converting mqtt payload to sensor data", "error", err, "decoder", dec.Name(), "topic", msg.Topic())
+			m.storeDeadLetter(ctx, msg, dec, domain.DeadLetterReasonDecode, err)
+			return
+		}
 
-	// Parse temperature from string to float64
-	temperature, err := strconv.ParseFloat(decodedPayload["temperature"].(string), 64)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing temperature: %w", err)
-	}
-
-	payload := &sensor.MqttPayloadResponse{
-		Device:      endDevices["device_id"].(string),
-		Battery:     decodedPayload["battery"].(float64),
-		Humidity:    decodedPayload["humidity"].(float64),
-		Temperature: temperature,
-		Latitude:    decodedPayload["latitude"].(float64),
-		Longitude:   decodedPayload["longitude"].(float64),
-		Watermarks: []sensor.WatermarkResponse{
-			{
-				Resistance: int(decodedPayload["watermarkOneResistanceValue"].(float64)),
-				Centibar:   int(decodedPayload["watermarkOneCentibarValue"].(float64)),
-				Depth:      30,
-			},
-			{
-				Resistance: int(decodedPayload["watermarkTwoResistanceValue"].(float64)),
-				Centibar:   int(decodedPayload["watermarkTwoCentibarValue"].(float64)),
-				Depth:      60,
-			},
-			{
-				Resistance: int(decodedPayload["watermarkThreeResistanceValue"].(float64)),
-				Centibar:   int(decodedPayload["watermarkThreeCentibarValue"].(float64)),
-				Depth:      90,
-			},
-		},
+		if sub.enc != nil {
+			m.routes.set(sensorData.Device, downlinkRoute{
+				broker: broker,
+				enc:    sub.enc,
+				device: downlinkDevice(sub.enc, msg.Payload(), sensorData.Device),
+			})
+		}
+
+		slog.Info("received sensor data", "sensor_id", sensorData.Device)
+		slog.Debug("detailed sensor data", "sensor_raw_data", fmt.Sprintf("%+v", sensorData))
+
+		domainPayload := m.mapper.FromResponse(sensorData)
+		_, err = m.svc.SensorService.HandleMessage(ctx, domainPayload)
+		// a retired sensor that still sends data is expected, its messages are not kept for a replay
+		if errors.Is(err, service.ErrSensorDecommissioned) {
+			slog.Info("dropping mqtt message of decommissioned sensor", "sensor_id", domainPayload.Device, "topic", msg.Topic())
+			return
+		}
+
+		if err != nil {
+			m.storeDeadLetter(ctx, msg, dec, domain.DeadLetterReasonProcess, err)
+			return
+		}
 	}
+}
 
-	return payload, nil
+// storeDeadLetter keeps the raw message of a rejected payload, so it can be inspected and replayed later on
+func (m *Mqtt) storeDeadLetter(ctx context.Context, msg MQTT.Message, dec decoder.Decoder, reason domain.DeadLetterReason, cause error) {
+	_, err := m.svc.DeadLetterService.Create(ctx, &domain.DeadLetterCreate{
+		Topic:   msg.Topic(),
+		Decoder: dec.Name(),
+		Payload: msg.Payload(),
+		Reason:  reason,
+		Error:   cause.Error(),
+	})
+	if err != nil {
+		slog.Error("error while storing rejected mqtt message as dead letter", "error", err, "topic", msg.Topic())
+	}
 }
@@ -0,0 +1,198 @@
+package mqtt
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMqtt_Brokers(t *testing.T) {
+	t.Run("should use single broker settings if no brokers are configured", func(t *testing.T) {
+		// given
+		cfg := &config.Config{
+			MQTT: config.MQTTConfig{
+				Broker:   "eu1.cloud.thethings.industries:1883",
+				ClientID: "tree-sensor",
+				Username: "user",
+				Password: "secret",
+				Topic:    "v3/green-ecolution/devices/+/up",
+				Decoder:  decoder.TTNDecoderName,
+				Downlink: config.MQTTDownlinkConfig{Topic: "v3/green-ecolution/devices/{device}/down/push"},
+			},
+		}
+		m := NewMqtt(cfg, &service.Services{}, decoder.NewDefaultRegistry())
+
+		// when
+		got := m.brokers()
+
+		// then
+		assert.Len(t, got, 1)
+		assert.Equal(t, "eu1.cloud.thethings.industries:1883", got[0].Broker)
+		assert.Equal(t, "tree-sensor", got[0].ClientID)
+		assert.Equal(t, "user", got[0].Username)
+		assert.Equal(t, "secret", got[0].Password)
+		assert.Equal(t, []config.MQTTSubscriptionConfig{{Topic: "v3/green-ecolution/devices/+/up", Decoder: decoder.TTNDecoderName}}, got[0].Subscriptions)
+		assert.Equal(t, cfg.MQTT.Downlink, got[0].Downlink)
+	})
+
+	t.Run("should use brokers list if configured", func(t *testing.T) {
+		// given
+		brokers := []config.MQTTBrokerConfig{
+			{Name: "ttn", Broker: "ssl://eu1.cloud.thethings.industries:8883"},
+			{Name: "chirpstack", Broker: "ssl://chirpstack.example.com:8883"},
+		}
+		cfg := &config.Config{
+			MQTT: config.MQTTConfig{
+				Broker:  "ignored:1883",
+				Brokers: brokers,
+			},
+		}
+		m := NewMqtt(cfg, &service.Services{}, decoder.NewDefaultRegistry())
+
+		// when
+		got := m.brokers()
+
+		// then
+		assert.Equal(t, brokers, got)
+	})
+}
+
+func TestMqtt_Subscriptions(t *testing.T) {
+	m := NewMqtt(&config.Config{}, &service.Services{}, decoder.NewDefaultRegistry())
+
+	t.Run("should resolve decoder and qos of every subscription", func(t *testing.T) {
+		// given
+		broker := config.MQTTBrokerConfig{
+			Subscriptions: []config.MQTTSubscriptionConfig{
+				{Topic: "v3/green-ecolution/devices/+/up"},
+				{Topic: "application/+/device/+/event/up", Decoder: decoder.ChirpStackDecoderName, QoS: utils.P[byte](0)},
+				{Topic: "green-ecolution/sensors/+", Decoder: decoder.JSONDecoderName, QoS: utils.P[byte](2)},
+			},
+		}
+
+		// when
+		got, err := m.subscriptions(broker)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, "v3/green-ecolution/devices/+/up", got[0].topic)
+		assert.Equal(t, decoder.TTNDecoderName, got[0].dec.Name())
+		assert.Equal(t, byte(1), got[0].qos)
+		assert.Equal(t, decoder.ChirpStackDecoderName, got[1].dec.Name())
+		assert.Equal(t, byte(0), got[1].qos)
+		assert.Equal(t, decoder.JSONDecoderName, got[2].dec.Name())
+		assert.Equal(t, byte(2), got[2].qos)
+	})
+
+	t.Run("should return error if broker has no subscription", func(t *testing.T) {
+		// when
+		got, err := m.subscriptions(config.MQTTBrokerConfig{})
+
+		// then
+		assert.ErrorIs(t, err, ErrNoSubscription)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error if subscription has no topic", func(t *testing.T) {
+		// given
+		broker := config.MQTTBrokerConfig{
+			Subscriptions: []config.MQTTSubscriptionConfig{{Decoder: decoder.TTNDecoderName}},
+		}
+
+		// when
+		got, err := m.subscriptions(broker)
+
+		// then
+		assert.ErrorIs(t, err, ErrEmptyTopic)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error for unknown decoder", func(t *testing.T) {
+		// given
+		broker := config.MQTTBrokerConfig{
+			Subscriptions: []config.MQTTSubscriptionConfig{{Topic: "sensors/+", Decoder: "unknown"}},
+		}
+
+		// when
+		got, err := m.subscriptions(broker)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error for invalid qos", func(t *testing.T) {
+		// given
+		broker := config.MQTTBrokerConfig{
+			Subscriptions: []config.MQTTSubscriptionConfig{{Topic: "sensors/+", QoS: utils.P[byte](3)}},
+		}
+
+		// when
+		got, err := m.subscriptions(broker)
+
+		// then
+		assert.ErrorIs(t, err, ErrInvalidQoS)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDownlinkEncoder(t *testing.T) {
+	t.Run("should return nil if downlink is disabled", func(t *testing.T) {
+		// when
+		got := downlinkEncoder(config.MQTTDownlinkConfig{}, decoder.NewTTNDecoder(), slog.Default())
+
+		// then
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return encoder of decoder", func(t *testing.T) {
+		// when
+		got := downlinkEncoder(config.MQTTDownlinkConfig{Topic: "down/{device}"}, decoder.NewTTNDecoder(), slog.Default())
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestMqtt_HandleMqttMessage(t *testing.T) {
+	payload := []byte(`{"device": "sensor-1", "battery": 3.1, "watermarks": [{"resistance": 10, "centibar": 12, "depth": 45}]}`)
+	sub := subscription{topic: "green-ecolution/sensors/+", dec: decoder.NewJSONDecoder()}
+
+	t.Run("should store message as dead letter when processing fails", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		deadLetterSvc := serviceMock.NewMockDeadLetterService(t)
+		m := NewMqtt(&config.Config{}, &service.Services{SensorService: sensorSvc, DeadLetterService: deadLetterSvc}, decoder.NewDefaultRegistry())
+		sensorSvc.EXPECT().HandleMessage(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+		deadLetterSvc.EXPECT().Create(mock.Anything, mock.MatchedBy(func(dl *domain.DeadLetterCreate) bool {
+			return dl.Reason == domain.DeadLetterReasonProcess && dl.Error == "db down"
+		})).Return(&domain.DeadLetter{}, nil)
+
+		// when
+		m.handleMqttMessage("default", sub)(nil, &testMessage{topic: "green-ecolution/sensors/sensor-1", payload: payload})
+	})
+
+	t.Run("should drop message of decommissioned sensor without dead letter", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		deadLetterSvc := serviceMock.NewMockDeadLetterService(t)
+		m := NewMqtt(&config.Config{}, &service.Services{SensorService: sensorSvc, DeadLetterService: deadLetterSvc}, decoder.NewDefaultRegistry())
+		sensorSvc.EXPECT().HandleMessage(mock.Anything, mock.Anything).Return(nil, service.ErrSensorDecommissioned)
+
+		// when
+		m.handleMqttMessage("default", sub)(nil, &testMessage{topic: "green-ecolution/sensors/sensor-1", payload: payload})
+
+		// then
+		deadLetterSvc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
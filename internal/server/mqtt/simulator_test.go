@@ -0,0 +1,67 @@
+package mqtt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMqtt_HandleSimulatedUplinks(t *testing.T) {
+	t.Run("should pass simulated uplinks of the subscribed topic to the sensor service", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		m := NewMqtt(&config.Config{}, &service.Services{SensorService: sensorSvc}, decoder.NewDefaultRegistry())
+
+		broker := simulator.NewMemoryBroker("v3/green-ecolution@ttn/devices/{device}/up")
+		broker.Subscribe("v3/green-ecolution@ttn/devices/+/up", m.handleMqttMessage("default", subscription{dec: decoder.NewTTNDecoder()}))
+
+		start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		sim, err := simulator.NewSimulator(&config.SimulatorConfig{
+			Interval: 6 * time.Hour,
+			Seed:     1,
+			Sensors: []config.SimulatorSensorConfig{
+				{ID: "sim-sensor-1", Latitude: 54.79, Longitude: 9.44, Scenario: string(simulator.ScenarioDrying), Centibar: 20},
+				{ID: "sim-sensor-2", Latitude: 54.80, Longitude: 9.45, Scenario: string(simulator.ScenarioRain)},
+			},
+		}, broker, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var received []*domain.MqttPayload
+		sensorSvc.EXPECT().HandleMessage(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, p *domain.MqttPayload) (*domain.SensorData, error) {
+			received = append(received, p)
+			return &domain.SensorData{SensorID: p.Device, Data: p}, nil
+		}).Times(8)
+
+		// when
+		for range 4 {
+			_, err := sim.Step(context.Background())
+			assert.NoError(t, err)
+		}
+
+		// then
+		assert.Len(t, received, 8)
+		var sensor1 []*domain.MqttPayload
+		for _, p := range received {
+			assert.Len(t, p.Watermarks, 3)
+			if p.Device == "sim-sensor-1" {
+				sensor1 = append(sensor1, p)
+			}
+		}
+
+		assert.Len(t, sensor1, 4)
+		assert.Equal(t, 54.79, sensor1[0].Latitude)
+		assert.Equal(t, 9.44, sensor1[0].Longitude)
+		assert.Greater(t, sensor1[3].Watermarks[0].Centibar, sensor1[0].Watermarks[0].Centibar)
+	})
+}
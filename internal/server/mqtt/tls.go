@@ -0,0 +1,56 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+)
+
+var (
+	ErrInvalidCACert     = errors.New("ca file does not contain a valid pem certificate")
+	ErrIncompleteKeyPair = errors.New("client certificate and key file must be set together")
+)
+
+// newTLSConfig creates the tls configuration of a broker connection. The system certificate pool is used
+// if no ca file is configured. It returns nil if tls is disabled.
+func newTLSConfig(cfg config.MQTTTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicitly enabled in the config, e.g. for self signed test brokers
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, ErrInvalidCACert
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, ErrIncompleteKeyPair
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,130 @@
+package mqtt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCertificate creates a self signed certificate and its key in the given directory
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "green-ecolution-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	t.Run("should return nil if tls is disabled", func(t *testing.T) {
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{CAFile: certFile})
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should use system certificates without ca file", func(t *testing.T) {
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{Enabled: true})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Nil(t, got.RootCAs)
+		assert.Empty(t, got.Certificates)
+		assert.False(t, got.InsecureSkipVerify)
+	})
+
+	t.Run("should load ca and client certificate", func(t *testing.T) {
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{
+			Enabled:  true,
+			CAFile:   certFile,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got.RootCAs)
+		assert.Len(t, got.Certificates, 1)
+	})
+
+	t.Run("should return error if ca file does not exist", func(t *testing.T) {
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{Enabled: true, CAFile: filepath.Join(dir, "missing.pem")})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error if ca file is no pem certificate", func(t *testing.T) {
+		// given
+		invalid := filepath.Join(dir, "invalid.pem")
+		if err := os.WriteFile(invalid, []byte("no certificate"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{Enabled: true, CAFile: invalid})
+
+		// then
+		assert.ErrorIs(t, err, ErrInvalidCACert)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error if only client certificate is set", func(t *testing.T) {
+		// when
+		got, err := newTLSConfig(config.MQTTTLSConfig{Enabled: true, CertFile: certFile})
+
+		// then
+		assert.ErrorIs(t, err, ErrIncompleteKeyPair)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,164 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type DeadLetterService struct {
+	deadLetterRepo storage.DeadLetterRepository
+	sensorSvc      service.SensorService
+	decoder        service.SensorPayloadDecoder
+	validator      *validator.Validate
+}
+
+func NewDeadLetterService(
+	deadLetterRepo storage.DeadLetterRepository,
+	sensorSvc service.SensorService,
+	decoder service.SensorPayloadDecoder,
+) service.DeadLetterService {
+	return &DeadLetterService{
+		deadLetterRepo: deadLetterRepo,
+		sensorSvc:      sensorSvc,
+		decoder:        decoder,
+		validator:      validator.New(),
+	}
+}
+
+func (s *DeadLetterService) GetAll(ctx context.Context) ([]*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	deadLetters, err := s.deadLetterRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch dead letters", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return deadLetters, nil
+}
+
+func (s *DeadLetterService) GetAllByStatus(ctx context.Context, status entities.DeadLetterStatus) ([]*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	deadLetters, err := s.deadLetterRepo.GetAllByStatus(ctx, status)
+	if err != nil {
+		log.Debug("failed to fetch dead letters by status", "error", err, "dead_letter_status", status)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return deadLetters, nil
+}
+
+func (s *DeadLetterService) GetByID(ctx context.Context, id int32) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	got, err := s.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch dead letter by id", "error", err, "dead_letter_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return got, nil
+}
+
+func (s *DeadLetterService) Create(ctx context.Context, dlc *entities.DeadLetterCreate) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(dlc); err != nil {
+		log.Debug("failed to validate dead letter struct to create", "error", err, "raw_dead_letter", fmt.Sprintf("%+v", dlc))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	created, err := s.deadLetterRepo.Create(ctx, func(dl *entities.DeadLetter) (bool, error) {
+		dl.Topic = dlc.Topic
+		dl.Decoder = dlc.Decoder
+		dl.Payload = dlc.Payload
+		dl.Reason = dlc.Reason
+		dl.Error = dlc.Error
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create dead letter", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor message stored as dead letter", "dead_letter_id", created.ID, "topic", created.Topic, "reason", created.Reason)
+	return created, nil
+}
+
+// Replay decodes the stored payload again and passes it to the sensor service. The dead letter is claimed
+// first, so concurrent replays of the same dead letter are rejected instead of processing the message twice.
+// On success the dead letter is marked as replayed. If the replay fails again, the dead letter is pending
+// again and the error is updated.
+func (s *DeadLetterService) Replay(ctx context.Context, id int32) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	dl, err := s.deadLetterRepo.Claim(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrDeadLetterNotPending) {
+			return nil, service.ErrDeadLetterReplayed
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	replayErr := s.replay(ctx, dl)
+	if replayErr != nil {
+		log.Info("replay of dead letter failed", "dead_letter_id", id, "error", replayErr)
+	}
+
+	updated, err := s.deadLetterRepo.Update(ctx, id, func(dl *entities.DeadLetter) (bool, error) {
+		now := time.Now()
+		dl.ReplayCount++
+		dl.LastReplayedAt = &now
+		if replayErr != nil {
+			dl.Error = replayErr.Error()
+			dl.Status = entities.DeadLetterStatusPending
+		} else {
+			dl.Status = entities.DeadLetterStatusReplayed
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update dead letter after replay", "error", err, "dead_letter_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if replayErr == nil {
+		log.Info("dead letter replayed successfully", "dead_letter_id", id)
+	}
+
+	return updated, nil
+}
+
+func (s *DeadLetterService) replay(ctx context.Context, dl *entities.DeadLetter) error {
+	payload, err := s.decoder.DecodeSensorPayload(dl.Decoder, dl.Payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.sensorSvc.HandleMessage(ctx, payload)
+	return err
+}
+
+func (s *DeadLetterService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := s.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if err := s.deadLetterRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete dead letter", "error", err, "dead_letter_id", id)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("dead letter deleted successfully", "dead_letter_id", id)
+	return nil
+}
+
+func (s *DeadLetterService) Ready() bool {
+	return s.deadLetterRepo != nil && s.sensorSvc != nil && s.decoder != nil
+}
@@ -0,0 +1,336 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeadLetterService_GetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return all dead letters", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		expected := getTestDeadLetters()
+		repo.EXPECT().GetAll(ctx).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		repo.EXPECT().GetAll(ctx).Return(nil, errors.New("GetAll failed"))
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterService_GetAllByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return dead letters by status", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		expected := getTestDeadLetters()[:1]
+		repo.EXPECT().GetAllByStatus(ctx, entities.DeadLetterStatusPending).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAllByStatus(ctx, entities.DeadLetterStatusPending)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestDeadLetterService_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return dead letter by id", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		expected := getTestDeadLetters()[0]
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		repo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByID(ctx, 99)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestDeadLetterService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create dead letter", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		expected := getTestDeadLetters()[0]
+		input := &entities.DeadLetterCreate{
+			Topic:   expected.Topic,
+			Decoder: expected.Decoder,
+			Payload: expected.Payload,
+			Reason:  expected.Reason,
+			Error:   expected.Error,
+		}
+		repo.EXPECT().Create(ctx, mock.Anything).Return(expected, nil)
+
+		// when
+		got, err := svc.Create(ctx, input)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return validation error when reason is invalid", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		input := &entities.DeadLetterCreate{
+			Decoder: "ttn",
+			Payload: []byte("{}"),
+			Reason:  "unknown",
+			Error:   "failed",
+		}
+
+		// when
+		got, err := svc.Create(ctx, input)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		input := &entities.DeadLetterCreate{
+			Decoder: "ttn",
+			Payload: []byte("{}"),
+			Reason:  entities.DeadLetterReasonDecode,
+			Error:   "failed",
+		}
+		repo.EXPECT().Create(ctx, mock.Anything).Return(nil, errors.New("Create failed"))
+
+		// when
+		got, err := svc.Create(ctx, input)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterService_Replay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should replay dead letter and mark it as replayed", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		decoder := serviceMock.NewMockSensorPayloadDecoder(t)
+		svc := NewDeadLetterService(repo, sensorSvc, decoder)
+
+		dl := getTestDeadLetters()[0]
+		payload := &entities.MqttPayload{Device: "sensor-1", Battery: 3.4}
+		repo.EXPECT().Claim(ctx, dl.ID).Return(dl, nil)
+		decoder.EXPECT().DecodeSensorPayload(dl.Decoder, dl.Payload).Return(payload, nil)
+		sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(&entities.SensorData{}, nil)
+		repo.EXPECT().Update(ctx, dl.ID, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ int32, fn func(*entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error) {
+				updated := *dl
+				ok, err := fn(&updated)
+				assert.True(t, ok)
+				return &updated, err
+			})
+
+		// when
+		got, err := svc.Replay(ctx, dl.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.DeadLetterStatusReplayed, got.Status)
+		assert.Equal(t, dl.ReplayCount+1, got.ReplayCount)
+		assert.NotNil(t, got.LastReplayedAt)
+		assert.Equal(t, dl.Error, got.Error)
+	})
+
+	t.Run("should set dead letter pending again and update error when replay fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		decoder := serviceMock.NewMockSensorPayloadDecoder(t)
+		svc := NewDeadLetterService(repo, sensorSvc, decoder)
+
+		dl := getTestDeadLetters()[0]
+		dl.Status = entities.DeadLetterStatusReplaying
+		repo.EXPECT().Claim(ctx, dl.ID).Return(dl, nil)
+		decoder.EXPECT().DecodeSensorPayload(dl.Decoder, dl.Payload).Return(nil, errors.New("still broken"))
+		repo.EXPECT().Update(ctx, dl.ID, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ int32, fn func(*entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error) {
+				updated := *dl
+				_, err := fn(&updated)
+				return &updated, err
+			})
+
+		// when
+		got, err := svc.Replay(ctx, dl.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.DeadLetterStatusPending, got.Status)
+		assert.Equal(t, "still broken", got.Error)
+		assert.Equal(t, dl.ReplayCount+1, got.ReplayCount)
+		sensorSvc.AssertNotCalled(t, "HandleMessage")
+	})
+
+	t.Run("should return error when dead letter is not pending", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		dl := getTestDeadLetters()[1]
+		repo.EXPECT().Claim(ctx, dl.ID).Return(nil, storage.ErrDeadLetterNotPending)
+
+		// when
+		got, err := svc.Replay(ctx, dl.ID)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrDeadLetterReplayed)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		repo.EXPECT().Claim(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Replay(ctx, 99)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete dead letter", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(getTestDeadLetters()[0], nil)
+		repo.EXPECT().Delete(ctx, int32(1)).Return(nil)
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockDeadLetterRepository(t)
+		svc := NewDeadLetterService(repo, serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		repo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		err := svc.Delete(ctx, 99)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestDeadLetterService_Ready(t *testing.T) {
+	t.Run("should return true when all dependencies are set", func(t *testing.T) {
+		svc := NewDeadLetterService(storageMock.NewMockDeadLetterRepository(t), serviceMock.NewMockSensorService(t), serviceMock.NewMockSensorPayloadDecoder(t))
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false when decoder is missing", func(t *testing.T) {
+		svc := NewDeadLetterService(storageMock.NewMockDeadLetterRepository(t), serviceMock.NewMockSensorService(t), nil)
+		assert.False(t, svc.Ready())
+	})
+}
+
+func getTestDeadLetters() []*entities.DeadLetter {
+	now := time.Now()
+	return []*entities.DeadLetter{
+		{
+			ID:        1,
+			CreatedAt: now,
+			UpdatedAt: now,
+			Topic:     "v3/green-ecolution/devices/sensor-1/up",
+			Decoder:   "ttn",
+			Payload:   []byte(`{"end_device_ids": {"device_id": "sensor-1"}}`),
+			Reason:    entities.DeadLetterReasonDecode,
+			Error:     "invalid mqtt payload",
+			Status:    entities.DeadLetterStatusPending,
+		},
+		{
+			ID:             2,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			Topic:          "v3/green-ecolution/devices/sensor-2/up",
+			Decoder:        "json",
+			Payload:        []byte(`{"device": "sensor-2"}`),
+			Reason:         entities.DeadLetterReasonProcess,
+			Error:          "sensor not found",
+			Status:         entities.DeadLetterStatusReplayed,
+			ReplayCount:    1,
+			LastReplayedAt: &now,
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package sensor
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+)
+
+const (
+	// defaultAnomalyMaxCentibar is the upper limit of the measurable range of watermark probes
+	defaultAnomalyMaxCentibar    = 239
+	defaultAnomalySpikeThreshold = 50
+	defaultAnomalyFlatlineCount  = 12
+	defaultAnomalyHistoryWindow  = 48 * time.Hour
+)
+
+// detectAnomalies scores the watermark readings of new sensor data against the previous readings of the sensor.
+// Sensor data with at least one anomaly is flagged.
+func (s *SensorService) detectAnomalies(ctx context.Context, sensorID string, data *entities.SensorData, hasHistory bool) {
+	log := logger.GetLogger(ctx)
+	if data.Data == nil || len(data.Data.Watermarks) == 0 {
+		return
+	}
+
+	var history []*entities.SensorData
+	if hasHistory {
+		now := time.Now()
+		prev, err := s.sensorRepo.GetSensorDataBySensorID(ctx, sensorID, now.Add(-s.anomalyHistoryWindow()), now)
+		if err != nil {
+			log.Error("failed to get previous sensor data, only checking the range of the new readings", "sensor_id", sensorID, "error", err)
+		}
+		history = prev
+	}
+
+	s.scoreAnomalies(data, history)
+	if data.Flagged {
+		log.Warn("suspicious sensor data detected, it will be excluded from the watering status", "sensor_id", sensorID, "anomaly_score", data.AnomalyScore, "anomalies", data.Anomalies)
+	}
+}
+
+// scoreAnomalies replaces the anomalies of the sensor data. Readings outside of the measurable range,
+// sudden rises and stuck values compared to the history are marked as anomalies.
+// The history has to be ordered by creation time.
+func (s *SensorService) scoreAnomalies(data *entities.SensorData, history []*entities.SensorData) {
+	data.Flagged = false
+	data.Anomalies = nil
+	data.AnomalyScore = 0
+	if data.Data == nil {
+		return
+	}
+
+	for _, w := range data.Data.Watermarks {
+		if anomaly := s.detectOutOfRange(w); anomaly != nil {
+			data.Anomalies = append(data.Anomalies, *anomaly)
+			continue
+		}
+		if anomaly := s.detectSpike(w, history); anomaly != nil {
+			data.Anomalies = append(data.Anomalies, *anomaly)
+		}
+		if anomaly := s.detectFlatline(w, history); anomaly != nil {
+			data.Anomalies = append(data.Anomalies, *anomaly)
+		}
+	}
+
+	for _, anomaly := range data.Anomalies {
+		data.AnomalyScore = max(data.AnomalyScore, anomaly.Score)
+	}
+	data.Flagged = len(data.Anomalies) > 0
+}
+
+// detectOutOfRange marks readings outside of the measurable range of the probes, e.g. sentinel values of a broken probe.
+// The range is a hard limit, watering thresholds above it can never be reached and do not raise it.
+func (s *SensorService) detectOutOfRange(w entities.Watermark) *entities.SensorDataAnomaly {
+	if w.Centibar >= 0 && w.Centibar <= s.anomalyMaxCentibar() && w.Resistance >= 0 {
+		return nil
+	}
+
+	return &entities.SensorDataAnomaly{
+		Type:     entities.SensorDataAnomalyOutOfRange,
+		Depth:    w.Depth,
+		Centibar: w.Centibar,
+		Score:    1,
+	}
+}
+
+// detectSpike compares the reading with the latest reading at the same depth that was not marked as anomaly.
+// Only rises are checked, because soil can get wet within minutes after rain or watering, but never dries out that fast.
+func (s *SensorService) detectSpike(w entities.Watermark, history []*entities.SensorData) *entities.SensorDataAnomaly {
+	threshold := s.anomalySpikeThreshold()
+	for i := len(history) - 1; i >= 0; i-- {
+		prev, ok := findWatermark(history[i], w.Depth)
+		if !ok || hasAnomalyAtDepth(history[i], w.Depth) {
+			continue
+		}
+
+		rise := w.Centibar - prev.Centibar
+		if rise <= threshold {
+			return nil
+		}
+
+		return &entities.SensorDataAnomaly{
+			Type:     entities.SensorDataAnomalySpike,
+			Depth:    w.Depth,
+			Centibar: w.Centibar,
+			Score:    min(float64(rise)/float64(2*threshold), 1),
+		}
+	}
+
+	return nil
+}
+
+// detectFlatline counts the identical consecutive readings at the same depth including the new reading.
+func (s *SensorService) detectFlatline(w entities.Watermark, history []*entities.SensorData) *entities.SensorDataAnomaly {
+	count := 1
+	for i := len(history) - 1; i >= 0; i-- {
+		prev, ok := findWatermark(history[i], w.Depth)
+		if !ok || prev.Centibar != w.Centibar || prev.Resistance != w.Resistance {
+			break
+		}
+		count++
+	}
+
+	flatlineCount := s.anomalyFlatlineCount()
+	if count < flatlineCount {
+		return nil
+	}
+
+	return &entities.SensorDataAnomaly{
+		Type:     entities.SensorDataAnomalyFlatline,
+		Depth:    w.Depth,
+		Centibar: w.Centibar,
+		Score:    min(float64(count)/float64(2*flatlineCount), 1),
+	}
+}
+
+func findWatermark(data *entities.SensorData, depth int) (entities.Watermark, bool) {
+	if data == nil || data.Data == nil {
+		return entities.Watermark{}, false
+	}
+
+	idx := slices.IndexFunc(data.Data.Watermarks, func(w entities.Watermark) bool {
+		return w.Depth == depth
+	})
+	if idx == -1 {
+		return entities.Watermark{}, false
+	}
+
+	return data.Data.Watermarks[idx], true
+}
+
+func hasAnomalyAtDepth(data *entities.SensorData, depth int) bool {
+	return slices.ContainsFunc(data.Anomalies, func(a entities.SensorDataAnomaly) bool {
+		return a.Depth == depth
+	})
+}
+
+// anomalyMaxCentibar returns the configured max centibar value or the measurable range of the probes
+func (s *SensorService) anomalyMaxCentibar() int {
+	if s.anomalyCfg.MaxCentibar > 0 {
+		return s.anomalyCfg.MaxCentibar
+	}
+	return defaultAnomalyMaxCentibar
+}
+
+func (s *SensorService) anomalySpikeThreshold() int {
+	if s.anomalyCfg.SpikeThreshold > 0 {
+		return s.anomalyCfg.SpikeThreshold
+	}
+	return defaultAnomalySpikeThreshold
+}
+
+func (s *SensorService) anomalyFlatlineCount() int {
+	if s.anomalyCfg.FlatlineCount > 0 {
+		return s.anomalyCfg.FlatlineCount
+	}
+	return defaultAnomalyFlatlineCount
+}
+
+func (s *SensorService) anomalyHistoryWindow() time.Duration {
+	if s.anomalyCfg.HistoryWindow > 0 {
+		return s.anomalyCfg.HistoryWindow
+	}
+	return defaultAnomalyHistoryWindow
+}
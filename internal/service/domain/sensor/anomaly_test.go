@@ -0,0 +1,192 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSensorService_HandleMessage_Anomalies(t *testing.T) {
+	cfg := &config.SensorConfig{
+		Anomaly: config.SensorAnomalyConfig{
+			MaxCentibar:    239,
+			SpikeThreshold: 50,
+			FlatlineCount:  3,
+		},
+	}
+
+	newPayload := func(watermarks ...entities.Watermark) *entities.MqttPayload {
+		return &entities.MqttPayload{
+			Device:     TestSensor.ID,
+			Latitude:   TestSensor.Latitude,
+			Longitude:  TestSensor.Longitude,
+			Watermarks: watermarks,
+		}
+	}
+
+	newData := func(watermarks ...entities.Watermark) *entities.SensorData {
+		return &entities.SensorData{SensorID: TestSensor.ID, Data: newPayload(watermarks...)}
+	}
+
+	// handleMessageWithConfig processes the payload with the default watering thresholds and returns the sensor data
+	// that would have been stored
+	handleMessageWithConfig := func(t *testing.T, sensorCfg *config.SensorConfig, payload *entities.MqttPayload, history []*entities.SensorData, historyErr error) *entities.SensorData {
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, sensorCfg, nil)
+
+		var inserted *entities.SensorData
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(history, historyErr)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).RunAndReturn(func(_ context.Context, data *entities.SensorData, _ string) error {
+			inserted = data
+			return nil
+		})
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		_, err := svc.HandleMessage(context.Background(), payload)
+		assert.NoError(t, err)
+		return inserted
+	}
+
+	handleMessage := func(t *testing.T, payload *entities.MqttPayload, history []*entities.SensorData, historyErr error) *entities.SensorData {
+		return handleMessageWithConfig(t, cfg, payload, history, historyErr)
+	}
+
+	t.Run("should not flag plausible readings", func(t *testing.T) {
+		// given
+		history := []*entities.SensorData{
+			newData(entities.Watermark{Centibar: 20, Resistance: 800, Depth: 30}),
+			newData(entities.Watermark{Centibar: 24, Resistance: 900, Depth: 30}),
+		}
+
+		// when
+		got := handleMessage(t, newPayload(entities.Watermark{Centibar: 30, Resistance: 1000, Depth: 30}), history, nil)
+
+		// then
+		assert.False(t, got.Flagged)
+		assert.Zero(t, got.AnomalyScore)
+		assert.Empty(t, got.Anomalies)
+	})
+
+	t.Run("should flag readings out of range", func(t *testing.T) {
+		// when
+		got := handleMessage(t, newPayload(
+			entities.Watermark{Centibar: 255, Resistance: 1000, Depth: 30},
+			entities.Watermark{Centibar: 40, Resistance: -1, Depth: 60},
+			entities.Watermark{Centibar: 40, Resistance: 1200, Depth: 90},
+		), nil, nil)
+
+		// then
+		assert.True(t, got.Flagged)
+		assert.Equal(t, 1.0, got.AnomalyScore)
+		assert.Equal(t, []entities.SensorDataAnomaly{
+			{Type: entities.SensorDataAnomalyOutOfRange, Depth: 30, Centibar: 255, Score: 1},
+			{Type: entities.SensorDataAnomalyOutOfRange, Depth: 60, Centibar: 40, Score: 1},
+		}, got.Anomalies)
+	})
+
+	t.Run("should flag sentinel value with the measurable range although default watering thresholds are higher", func(t *testing.T) {
+		// given
+		assert.Contains(t, svcUtils.DefaultWateringThresholds(), entities.WateringThreshold{MinAge: 3, MaxAge: 3, Depth: 30, Moderate: 1585, Bad: 1585})
+
+		// when
+		got := handleMessageWithConfig(t, &config.SensorConfig{}, newPayload(
+			entities.Watermark{Centibar: 239, Resistance: 30000, Depth: 60},
+			entities.Watermark{Centibar: 255, Resistance: 30000, Depth: 30},
+		), nil, nil)
+
+		// then
+		assert.True(t, got.Flagged)
+		assert.Equal(t, []entities.SensorDataAnomaly{
+			{Type: entities.SensorDataAnomalyOutOfRange, Depth: 30, Centibar: 255, Score: 1},
+		}, got.Anomalies)
+	})
+
+	t.Run("should flag sudden rise as spike", func(t *testing.T) {
+		// given
+		history := []*entities.SensorData{
+			newData(entities.Watermark{Centibar: 20, Resistance: 800, Depth: 30}),
+		}
+
+		// when
+		got := handleMessage(t, newPayload(entities.Watermark{Centibar: 95, Resistance: 3000, Depth: 30}), history, nil)
+
+		// then
+		assert.True(t, got.Flagged)
+		assert.Equal(t, 0.75, got.AnomalyScore)
+		assert.Equal(t, []entities.SensorDataAnomaly{
+			{Type: entities.SensorDataAnomalySpike, Depth: 30, Centibar: 95, Score: 0.75},
+		}, got.Anomalies)
+	})
+
+	t.Run("should not flag sudden drop after watering", func(t *testing.T) {
+		// given
+		history := []*entities.SensorData{
+			newData(entities.Watermark{Centibar: 120, Resistance: 5000, Depth: 30}),
+		}
+
+		// when
+		got := handleMessage(t, newPayload(entities.Watermark{Centibar: 5, Resistance: 300, Depth: 30}), history, nil)
+
+		// then
+		assert.False(t, got.Flagged)
+	})
+
+	t.Run("should compare spikes with the latest reading without anomaly", func(t *testing.T) {
+		// given
+		spike := newData(entities.Watermark{Centibar: 150, Resistance: 6000, Depth: 30})
+		spike.Flagged = true
+		spike.Anomalies = []entities.SensorDataAnomaly{{Type: entities.SensorDataAnomalySpike, Depth: 30, Centibar: 150, Score: 1}}
+		history := []*entities.SensorData{
+			newData(entities.Watermark{Centibar: 20, Resistance: 800, Depth: 30}),
+			spike,
+		}
+
+		// when
+		got := handleMessage(t, newPayload(entities.Watermark{Centibar: 22, Resistance: 850, Depth: 30}), history, nil)
+
+		// then
+		assert.False(t, got.Flagged)
+	})
+
+	t.Run("should flag stuck readings as flatline", func(t *testing.T) {
+		// given
+		history := []*entities.SensorData{
+			newData(entities.Watermark{Centibar: 30, Resistance: 1000, Depth: 30}, entities.Watermark{Centibar: 40, Resistance: 1500, Depth: 60}),
+			newData(entities.Watermark{Centibar: 35, Resistance: 1100, Depth: 30}, entities.Watermark{Centibar: 40, Resistance: 1500, Depth: 60}),
+			newData(entities.Watermark{Centibar: 38, Resistance: 1200, Depth: 30}, entities.Watermark{Centibar: 40, Resistance: 1500, Depth: 60}),
+		}
+
+		// when
+		got := handleMessage(t, newPayload(
+			entities.Watermark{Centibar: 40, Resistance: 1300, Depth: 30},
+			entities.Watermark{Centibar: 40, Resistance: 1500, Depth: 60},
+		), history, nil)
+
+		// then
+		assert.True(t, got.Flagged)
+		assert.Equal(t, []entities.SensorDataAnomaly{
+			{Type: entities.SensorDataAnomalyFlatline, Depth: 60, Centibar: 40, Score: 4.0 / 6.0},
+		}, got.Anomalies)
+	})
+
+	t.Run("should only check range when previous sensor data can not be fetched", func(t *testing.T) {
+		// when
+		got := handleMessage(t, newPayload(entities.Watermark{Centibar: 95, Resistance: 3000, Depth: 30}), nil, errors.New("db error"))
+
+		// then
+		assert.False(t, got.Flagged)
+	})
+}
@@ -0,0 +1,117 @@
+package sensor
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	defaultBatteryTrendWindow        = 14 * 24 * time.Hour
+	defaultBatteryMaintenanceHorizon = 30 * 24 * time.Hour
+)
+
+// GetMaintenanceList returns the battery state of all sensors that are below the configured battery threshold
+// or whose battery is estimated to be empty within the maintenance horizon. The sensors that run empty first are listed first.
+func (s *SensorService) GetMaintenanceList(ctx context.Context) ([]*entities.SensorBattery, error) {
+	log := logger.GetLogger(ctx)
+	now := time.Now()
+
+	batteries, err := s.sensorRepo.GetBatteryTrends(ctx, now.Add(-s.batteryTrendWindow()))
+	if err != nil {
+		log.Debug("failed to fetch sensor battery trends", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	horizon := now.Add(s.batteryMaintenanceHorizon())
+	maintenance := make([]*entities.SensorBattery, 0, len(batteries))
+	for _, battery := range batteries {
+		s.estimateBatteryLifetime(battery)
+		if battery.BelowThreshold || (battery.EstimatedEmptyAt != nil && battery.EstimatedEmptyAt.Before(horizon)) {
+			maintenance = append(maintenance, battery)
+		}
+	}
+
+	slices.SortStableFunc(maintenance, compareBatteryLifetime)
+
+	return maintenance, nil
+}
+
+// estimateBatteryLifetime extrapolates the battery trend linearly to the configured empty level.
+// A sensor without a discharging trend gets no estimation.
+func (s *SensorService) estimateBatteryLifetime(battery *entities.SensorBattery) {
+	battery.BelowThreshold = s.batteryCfg.Threshold > 0 && battery.Level < s.batteryCfg.Threshold
+
+	if battery.DischargePerDay <= 0 {
+		battery.EstimatedEmptyAt = nil
+		return
+	}
+
+	remainingDays := max(battery.Level-s.batteryCfg.EmptyLevel, 0) / battery.DischargePerDay
+	emptyAt := battery.MeasuredAt.Add(time.Duration(remainingDays * float64(24*time.Hour)))
+	battery.EstimatedEmptyAt = &emptyAt
+}
+
+func compareBatteryLifetime(a, b *entities.SensorBattery) int {
+	switch {
+	case a.EstimatedEmptyAt != nil && b.EstimatedEmptyAt != nil:
+		if c := a.EstimatedEmptyAt.Compare(*b.EstimatedEmptyAt); c != 0 {
+			return c
+		}
+	case a.EstimatedEmptyAt != nil:
+		return -1
+	case b.EstimatedEmptyAt != nil:
+		return 1
+	}
+
+	switch {
+	case a.Level < b.Level:
+		return -1
+	case a.Level > b.Level:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// checkBatteryThreshold publishes a low battery event when the battery level of the sensor falls below the configured threshold
+func (s *SensorService) checkBatteryThreshold(ctx context.Context, sensor *entities.Sensor, prev *entities.SensorData, level float64) {
+	threshold := s.batteryCfg.Threshold
+	if threshold <= 0 || level >= threshold {
+		return
+	}
+
+	var prevLevel *float64
+	if prev != nil && prev.Data != nil {
+		if prev.Data.Battery < threshold {
+			return
+		}
+		prevLevel = &prev.Data.Battery
+	}
+
+	log := logger.GetLogger(ctx)
+	log.Info("sensor battery level dropped below threshold", "sensor_id", sensor.ID, "battery", level, "threshold", threshold)
+	log.Debug("publish new event", "event", entities.EventTypeSensorBatteryLow, "service", "SensorService")
+	event := entities.NewEventSensorBatteryLow(sensor, prevLevel, level, threshold)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after sensor battery dropped below threshold", "err", err)
+	}
+}
+
+func (s *SensorService) batteryTrendWindow() time.Duration {
+	if s.batteryCfg.TrendWindow > 0 {
+		return s.batteryCfg.TrendWindow
+	}
+	return defaultBatteryTrendWindow
+}
+
+func (s *SensorService) batteryMaintenanceHorizon() time.Duration {
+	if s.batteryCfg.MaintenanceHorizon > 0 {
+		return s.batteryCfg.MaintenanceHorizon
+	}
+	return defaultBatteryMaintenanceHorizon
+}
@@ -0,0 +1,221 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testBatteryConfig = &config.SensorConfig{
+	Battery: config.SensorBatteryConfig{
+		Threshold:          3.3,
+		EmptyLevel:         3.0,
+		TrendWindow:        7 * 24 * time.Hour,
+		MaintenanceHorizon: 30 * 24 * time.Hour,
+	},
+}
+
+func TestSensorService_GetMaintenanceList(t *testing.T) {
+	t.Run("should return sensors below threshold or running empty within the horizon", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, testBatteryConfig, nil)
+
+		now := time.Now()
+		batteries := []*entities.SensorBattery{
+			// healthy battery, empty in 100 days
+			{SensorID: "sensor-1", Level: 4.0, MeasuredAt: now, DischargePerDay: 0.01},
+			// empty in 10 days
+			{SensorID: "sensor-2", Level: 3.5, MeasuredAt: now, DischargePerDay: 0.05},
+			// below threshold without discharge trend
+			{SensorID: "sensor-3", Level: 3.2, MeasuredAt: now, DischargePerDay: 0},
+			// empty in 2 days
+			{SensorID: "sensor-4", Level: 3.1, MeasuredAt: now, DischargePerDay: 0.05},
+		}
+
+		sensorRepo.EXPECT().GetBatteryTrends(mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+			return since.Before(now.Add(-7*24*time.Hour + time.Minute))
+		})).Return(batteries, nil)
+
+		// when
+		got, err := svc.GetMaintenanceList(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, "sensor-4", got[0].SensorID)
+		assert.Equal(t, "sensor-2", got[1].SensorID)
+		assert.Equal(t, "sensor-3", got[2].SensorID)
+
+		assert.True(t, got[0].BelowThreshold)
+		assert.WithinDuration(t, now.Add(2*24*time.Hour), *got[0].EstimatedEmptyAt, time.Minute)
+		assert.False(t, got[1].BelowThreshold)
+		assert.WithinDuration(t, now.Add(10*24*time.Hour), *got[1].EstimatedEmptyAt, time.Minute)
+		assert.True(t, got[2].BelowThreshold)
+		assert.Nil(t, got[2].EstimatedEmptyAt)
+	})
+
+	t.Run("should return empty list when all batteries are healthy", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, testBatteryConfig, nil)
+
+		sensorRepo.EXPECT().GetBatteryTrends(mock.Anything, mock.Anything).Return([]*entities.SensorBattery{
+			{SensorID: "sensor-1", Level: 4.0, MeasuredAt: time.Now(), DischargePerDay: -0.01},
+		}, nil)
+
+		// when
+		got, err := svc.GetMaintenanceList(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, testBatteryConfig, nil)
+
+		sensorRepo.EXPECT().GetBatteryTrends(mock.Anything, mock.Anything).Return(nil, errors.New("repository error"))
+
+		// when
+		got, err := svc.GetMaintenanceList(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorService_HandleMessage_BatteryThreshold(t *testing.T) {
+	cfg := &config.SensorConfig{Battery: config.SensorBatteryConfig{Threshold: 40}}
+
+	t.Run("should publish event when battery drops below threshold", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorBatteryLow)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorBatteryLow)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		payload := *TestListMQTTPayload[0]
+		payload.Battery = 35
+
+		sensorRepo.EXPECT().GetByID(mock.Anything, payload.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), &payload)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case receivedEvent := <-ch:
+			e, ok := receivedEvent.(entities.EventSensorBatteryLow)
+			assert.True(t, ok)
+			assert.Equal(t, TestSensor, e.Sensor)
+			assert.Equal(t, 45.3, *e.Prev)
+			assert.Equal(t, 35.0, e.Level)
+			assert.Equal(t, 40.0, e.Threshold)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should not publish event when battery was already below threshold", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorBatteryLow)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, eventManager, &config.SensorConfig{
+			Battery: config.SensorBatteryConfig{Threshold: 50},
+		})
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorBatteryLow)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		payload := *TestListMQTTPayload[0]
+		payload.Battery = 35
+
+		sensorRepo.EXPECT().GetByID(mock.Anything, payload.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), &payload)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case <-ch:
+			t.Fatal("event should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("should not publish event when battery is above threshold", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorBatteryLow)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorBatteryLow)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		payload := TestListMQTTPayload[0]
+
+		sensorRepo.EXPECT().GetByID(mock.Anything, payload.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case <-ch:
+			t.Fatal("event should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func (s *SensorService) GetCalibration(ctx context.Context, id string) (*entities.SensorCalibration, error) {
+	log := logger.GetLogger(ctx)
+	calibration, err := s.sensorRepo.GetCalibration(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor calibration", "sensor_id", id, "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return calibration, nil
+}
+
+// UpdateCalibration creates or replaces the calibration profile of a sensor and recomputes
+// all stored sensor data of the sensor from its raw values in the same transaction.
+func (s *SensorService) UpdateCalibration(ctx context.Context, id string, cu *entities.SensorCalibrationUpdate) (*entities.SensorCalibration, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(cu); err != nil {
+		log.Debug("failed to validate sensor calibration struct to update", "error", err, "raw_calibration", fmt.Sprintf("%+v", cu))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if _, err := s.sensorRepo.GetByID(ctx, id); err != nil {
+		log.Debug("failed to fetch sensor by id", "sensor_id", id, "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	calibration := &entities.SensorCalibration{
+		CentibarOffset:         cu.CentibarOffset,
+		TemperatureCoefficient: cu.TemperatureCoefficient,
+		ReferenceTemperature:   cu.ReferenceTemperature,
+		Curve:                  cu.Curve,
+	}
+
+	recalibrate := s.newRecalibration(calibration)
+	if err := s.sensorRepo.SaveCalibration(ctx, id, calibration, recalibrate.apply); err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	saved, err := s.sensorRepo.GetCalibration(ctx, id)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	s.publishRecalibratedSensorData(ctx, id, recalibrate)
+	log.Info("sensor calibration updated successfully", "sensor_id", id)
+	return saved, nil
+}
+
+// DeleteCalibration removes the calibration profile of a sensor and restores the raw values of all stored sensor data.
+func (s *SensorService) DeleteCalibration(ctx context.Context, id string) error {
+	log := logger.GetLogger(ctx)
+	if _, err := s.sensorRepo.GetCalibration(ctx, id); err != nil {
+		log.Debug("failed to fetch sensor calibration", "sensor_id", id, "error", err)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	recalibrate := s.newRecalibration(nil)
+	if err := s.sensorRepo.DeleteCalibration(ctx, id, recalibrate.apply); err != nil {
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	s.publishRecalibratedSensorData(ctx, id, recalibrate)
+	log.Info("sensor calibration deleted successfully", "sensor_id", id)
+	return nil
+}
+
+// recalibration recomputes the watermark values of stored sensor data from the raw values.
+// As the values change, the anomaly detection is run again on every reading. The readings
+// are passed ordered by creation time, so only the readings within the history window of
+// the anomaly detection are kept to score the following ones.
+type recalibration struct {
+	svc         *SensorService
+	calibration *entities.SensorCalibration
+	history     []*entities.SensorData
+	latest      *entities.SensorData
+	count       int
+}
+
+func (s *SensorService) newRecalibration(calibration *entities.SensorCalibration) *recalibration {
+	return &recalibration{svc: s, calibration: calibration}
+}
+
+func (r *recalibration) apply(data *entities.SensorData) error {
+	if data.Data == nil {
+		return nil
+	}
+
+	applyCalibration(r.calibration, data.Data)
+
+	windowStart := data.CreatedAt.Add(-r.svc.anomalyHistoryWindow())
+	r.history = slices.DeleteFunc(r.history, func(prev *entities.SensorData) bool {
+		return prev.CreatedAt.Before(windowStart)
+	})
+	r.svc.scoreAnomalies(data, r.history)
+
+	r.history = append(r.history, data)
+	r.latest = data
+	r.count++
+	return nil
+}
+
+// publishRecalibratedSensorData publishes the latest recomputed sensor data to update the watering status.
+// Flagged sensor data is excluded from the watering status, so no event is published for it.
+// Data that was already rolled up by the retention job is not recomputed.
+func (s *SensorService) publishRecalibratedSensorData(ctx context.Context, id string, r *recalibration) {
+	log := logger.GetLogger(ctx)
+	log.Debug("recomputed sensor data with calibration", "sensor_id", id, "count", r.count)
+	if r.latest == nil || r.latest.Flagged {
+		return
+	}
+
+	s.publishNewSensorDataEvent(ctx, r.latest)
+}
+
+// applyCalibration computes the watermark values of the payload from its raw values.
+// The raw values are kept in RawWatermarks. Without calibration the raw values are restored.
+func applyCalibration(calibration *entities.SensorCalibration, payload *entities.MqttPayload) {
+	raw := payload.RawWatermarks
+	if raw == nil {
+		raw = payload.Watermarks
+	}
+
+	if calibration == nil {
+		payload.Watermarks = raw
+		payload.RawWatermarks = nil
+		return
+	}
+
+	watermarks := make([]entities.Watermark, len(raw))
+	for i, w := range raw {
+		watermarks[i] = entities.Watermark{
+			Centibar:   calibrateCentibar(calibration, w, payload.Temperature),
+			Resistance: w.Resistance,
+			Depth:      w.Depth,
+		}
+	}
+
+	payload.RawWatermarks = raw
+	payload.Watermarks = watermarks
+}
+
+func calibrateCentibar(calibration *entities.SensorCalibration, w entities.Watermark, temperature float64) int {
+	centibar := float64(w.Centibar)
+	if len(calibration.Curve) >= 2 {
+		centibar = interpolateCurve(calibration.Curve, w.Resistance)
+	}
+
+	centibar *= 1 + calibration.TemperatureCoefficient*(temperature-calibration.ReferenceTemperature)
+	centibar += calibration.CentibarOffset
+
+	return int(math.Round(max(centibar, 0)))
+}
+
+// interpolateCurve returns the linear interpolated centibar value of the resistance.
+// Values outside of the curve are clamped to the first or last point.
+func interpolateCurve(curve []entities.CalibrationPoint, resistance int) float64 {
+	points := slices.Clone(curve)
+	slices.SortFunc(points, func(a, b entities.CalibrationPoint) int {
+		return a.Resistance - b.Resistance
+	})
+
+	if resistance <= points[0].Resistance {
+		return points[0].Centibar
+	}
+
+	for i := 1; i < len(points); i++ {
+		lower, upper := points[i-1], points[i]
+		if resistance > upper.Resistance {
+			continue
+		}
+
+		if upper.Resistance == lower.Resistance {
+			return upper.Centibar
+		}
+
+		ratio := float64(resistance-lower.Resistance) / float64(upper.Resistance-lower.Resistance)
+		return lower.Centibar + ratio*(upper.Centibar-lower.Centibar)
+	}
+
+	return points[len(points)-1].Centibar
+}
@@ -0,0 +1,354 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSensorService_HandleMessage_Calibration(t *testing.T) {
+	calibration := &entities.SensorCalibration{
+		SensorID:               "sensor001",
+		CentibarOffset:         2,
+		TemperatureCoefficient: 0.01,
+		ReferenceTemperature:   20,
+		Curve: []entities.CalibrationPoint{
+			{Resistance: 1000, Centibar: 0},
+			{Resistance: 3000, Centibar: 100},
+		},
+	}
+
+	t.Run("should apply calibration and keep raw values", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		payload := &entities.MqttPayload{
+			Device:      "sensor001",
+			Temperature: 30,
+			Latitude:    54.82124518093376,
+			Longitude:   9.485702120628517,
+			Watermarks: []entities.Watermark{
+				{Centibar: 10, Resistance: 500, Depth: 30},
+				{Centibar: 20, Resistance: 2000, Depth: 60},
+				{Centibar: 30, Resistance: 4000, Depth: 90},
+			},
+		}
+		raw := payload.Watermarks
+		calibratedSensor := &entities.Sensor{
+			ID:          "sensor001",
+			Latitude:    payload.Latitude,
+			Longitude:   payload.Longitude,
+			Status:      entities.SensorStatusOnline,
+			Calibration: calibration,
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), payload.Device).Return(calibratedSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), payload.Device).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().InsertSensorData(context.Background(), mock.Anything, payload.Device).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(context.Background(), payload.Device).Return(&entities.SensorData{Data: payload}, nil)
+
+		// when
+		got, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, raw, payload.RawWatermarks)
+		assert.Equal(t, []entities.Watermark{
+			{Centibar: 2, Resistance: 500, Depth: 30},
+			{Centibar: 57, Resistance: 2000, Depth: 60},
+			{Centibar: 112, Resistance: 4000, Depth: 90},
+		}, payload.Watermarks)
+	})
+
+	t.Run("should clamp calibrated values to zero", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		payload := &entities.MqttPayload{
+			Device:      "sensor001",
+			Temperature: 20,
+			Latitude:    54.82124518093376,
+			Longitude:   9.485702120628517,
+			Watermarks:  []entities.Watermark{{Centibar: 3, Resistance: 200, Depth: 30}},
+		}
+		offsetSensor := &entities.Sensor{
+			ID:          "sensor001",
+			Latitude:    payload.Latitude,
+			Longitude:   payload.Longitude,
+			Status:      entities.SensorStatusOnline,
+			Calibration: &entities.SensorCalibration{CentibarOffset: -5, ReferenceTemperature: 20},
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), payload.Device).Return(offsetSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), payload.Device).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().InsertSensorData(context.Background(), mock.Anything, payload.Device).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(context.Background(), payload.Device).Return(&entities.SensorData{Data: payload}, nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 0, payload.Watermarks[0].Centibar)
+		assert.Equal(t, 3, payload.RawWatermarks[0].Centibar)
+	})
+}
+
+func TestSensorService_GetCalibration(t *testing.T) {
+	t.Run("should return calibration", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		calibration := &entities.SensorCalibration{SensorID: "sensor001", CentibarOffset: 1}
+
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(calibration, nil)
+
+		// when
+		got, err := svc.GetCalibration(context.Background(), "sensor001")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, calibration, got)
+	})
+
+	t.Run("should return not found error when sensor has no calibration", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetCalibration(context.Background(), "sensor001")
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "entity not found: not found")
+	})
+}
+
+func TestSensorService_UpdateCalibration(t *testing.T) {
+	update := &entities.SensorCalibrationUpdate{
+		CentibarOffset:       5,
+		ReferenceTemperature: 20,
+	}
+
+	t.Run("should save calibration and recompute stored sensor data from raw values", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		calibration := &entities.SensorCalibration{SensorID: "sensor001", CentibarOffset: 5, ReferenceTemperature: 20}
+		stored := []*entities.SensorData{
+			{ID: 1, Data: &entities.MqttPayload{
+				Device:     "sensor001",
+				Watermarks: []entities.Watermark{{Centibar: 10, Depth: 30}},
+			}},
+			{ID: 2, Data: &entities.MqttPayload{
+				Device:        "sensor001",
+				Watermarks:    []entities.Watermark{{Centibar: 50, Depth: 30}},
+				RawWatermarks: []entities.Watermark{{Centibar: 20, Depth: 30}},
+			}},
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor001").Return(TestSensor, nil)
+		sensorRepo.EXPECT().SaveCalibration(context.Background(), "sensor001", &entities.SensorCalibration{
+			CentibarOffset:       5,
+			ReferenceTemperature: 20,
+		}, mock.Anything).RunAndReturn(recalibrateStored(stored))
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(calibration, nil)
+
+		// when
+		got, err := svc.UpdateCalibration(context.Background(), "sensor001", update)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, calibration, got)
+		assert.Equal(t, 15, stored[0].Data.Watermarks[0].Centibar)
+		assert.Equal(t, 10, stored[0].Data.RawWatermarks[0].Centibar)
+		assert.Equal(t, 25, stored[1].Data.Watermarks[0].Centibar)
+		assert.Equal(t, 20, stored[1].Data.RawWatermarks[0].Centibar)
+	})
+
+	t.Run("should re-run anomaly detection on recomputed sensor data", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		now := time.Now()
+		stored := []*entities.SensorData{
+			{ID: 1, CreatedAt: now.Add(-2 * time.Hour), Data: &entities.MqttPayload{
+				Device:     "sensor001",
+				Watermarks: []entities.Watermark{{Centibar: 10, Depth: 30}},
+			}},
+			{ID: 2, CreatedAt: now.Add(-time.Hour), Flagged: true, AnomalyScore: 1, Anomalies: []entities.SensorDataAnomaly{
+				{Type: entities.SensorDataAnomalySpike, Depth: 30, Centibar: 70, Score: 1},
+			}, Data: &entities.MqttPayload{
+				Device:     "sensor001",
+				Watermarks: []entities.Watermark{{Centibar: 40, Depth: 30}},
+			}},
+			{ID: 3, CreatedAt: now, Data: &entities.MqttPayload{
+				Device:     "sensor001",
+				Watermarks: []entities.Watermark{{Centibar: 10, Depth: 30}},
+			}},
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor001").Return(TestSensor, nil)
+		sensorRepo.EXPECT().SaveCalibration(context.Background(), "sensor001", mock.Anything, mock.Anything).RunAndReturn(recalibrateStored(stored))
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(&entities.SensorCalibration{SensorID: "sensor001"}, nil)
+
+		// when
+		_, err := svc.UpdateCalibration(context.Background(), "sensor001", &entities.SensorCalibrationUpdate{CentibarOffset: 100})
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, stored[0].Flagged)
+		assert.False(t, stored[1].Flagged)
+		assert.Empty(t, stored[1].Anomalies)
+		assert.Equal(t, 0.0, stored[1].AnomalyScore)
+		assert.False(t, stored[2].Flagged)
+		assert.Equal(t, 110, stored[2].Data.Watermarks[0].Centibar)
+	})
+
+	t.Run("should return validation error when curve has only one point", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		// when
+		got, err := svc.UpdateCalibration(context.Background(), "sensor001", &entities.SensorCalibrationUpdate{
+			Curve: []entities.CalibrationPoint{{Resistance: 1000, Centibar: 10}},
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return not found error when sensor does not exist", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor001").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.UpdateCalibration(context.Background(), "sensor001", update)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "entity not found: not found")
+	})
+
+	t.Run("should return error when saving calibration fails", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor001").Return(TestSensor, nil)
+		sensorRepo.EXPECT().SaveCalibration(context.Background(), "sensor001", mock.Anything, mock.Anything).Return(errors.New("save error"))
+
+		// when
+		got, err := svc.UpdateCalibration(context.Background(), "sensor001", update)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "save error")
+	})
+}
+
+func TestSensorService_DeleteCalibration(t *testing.T) {
+	t.Run("should delete calibration and restore raw values", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		stored := []*entities.SensorData{
+			{ID: 1, Data: &entities.MqttPayload{
+				Device:        "sensor001",
+				Watermarks:    []entities.Watermark{{Centibar: 50, Depth: 30}},
+				RawWatermarks: []entities.Watermark{{Centibar: 20, Depth: 30}},
+			}},
+		}
+
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(&entities.SensorCalibration{SensorID: "sensor001"}, nil)
+		sensorRepo.EXPECT().DeleteCalibration(context.Background(), "sensor001", mock.Anything).RunAndReturn(func(_ context.Context, _ string, fn func(*entities.SensorData) error) error {
+			return applyToStored(stored, fn)
+		})
+
+		// when
+		err := svc.DeleteCalibration(context.Background(), "sensor001")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 20, stored[0].Data.Watermarks[0].Centibar)
+		assert.Nil(t, stored[0].Data.RawWatermarks)
+	})
+
+	t.Run("should return not found error when sensor has no calibration", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetCalibration(context.Background(), "sensor001").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		err := svc.DeleteCalibration(context.Background(), "sensor001")
+
+		// then
+		assert.Error(t, err)
+		assert.EqualError(t, err, "entity not found: not found")
+	})
+}
+
+func recalibrateStored(stored []*entities.SensorData) func(context.Context, string, *entities.SensorCalibration, func(*entities.SensorData) error) error {
+	return func(_ context.Context, _ string, _ *entities.SensorCalibration, fn func(*entities.SensorData) error) error {
+		return applyToStored(stored, fn)
+	}
+}
+
+func applyToStored(stored []*entities.SensorData, fn func(*entities.SensorData) error) error {
+	for _, d := range stored {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const defaultLocationDriftMaxDistance = 50.0
+
+// detectLocationDrift compares the reported position of a sensor with the position of its linked tree.
+// Sensors without a linked tree are compared with their registered location.
+// It returns nil if the reported position is within the configured maximum distance or there is no reference
+// position to compare with.
+func (s *SensorService) detectLocationDrift(ctx context.Context, sensor *entities.Sensor, payload *entities.MqttPayload) (drift *entities.SensorLocationDrift, treeID *int32) {
+	log := logger.GetLogger(ctx)
+	refLat, refLng := sensor.Latitude, sensor.Longitude
+
+	tree, err := s.treeRepo.GetBySensorID(ctx, sensor.ID)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if !errors.As(err, &entityNotFoundErr) {
+			log.Error("failed to get linked tree of sensor, using registered sensor location to detect drift", "sensor_id", sensor.ID, "error", err)
+		}
+	} else if tree != nil {
+		refLat, refLng = tree.Latitude, tree.Longitude
+		treeID = &tree.ID
+	}
+
+	if !hasPosition(refLat, refLng) {
+		return nil, treeID
+	}
+
+	distance := utils.DistanceInMeters(refLat, refLng, payload.Latitude, payload.Longitude)
+	if distance <= s.locationDriftMaxDistance() {
+		return nil, treeID
+	}
+
+	return &entities.SensorLocationDrift{
+		Latitude:   payload.Latitude,
+		Longitude:  payload.Longitude,
+		Distance:   distance,
+		DetectedAt: time.Now(),
+	}, treeID
+}
+
+// hasPosition reports if the coordinates are set. The position of an uplink is optional, an uplink without gps
+// position is decoded to 0, 0, which is not a plausible position of a sensor.
+func hasPosition(lat, lng float64) bool {
+	return lat != 0 || lng != 0
+}
+
+func (s *SensorService) locationDriftMaxDistance() float64 {
+	if s.driftCfg.MaxDistance > 0 {
+		return s.driftCfg.MaxDistance
+	}
+	return defaultLocationDriftMaxDistance
+}
+
+func locationDriftChanged(prev, drift *entities.SensorLocationDrift) bool {
+	if prev == nil || drift == nil {
+		return prev != drift
+	}
+	return prev.Latitude != drift.Latitude || prev.Longitude != drift.Longitude || prev.Distance != drift.Distance
+}
+
+func (s *SensorService) publishLocationDriftEvent(ctx context.Context, sensor *entities.Sensor, drift *entities.SensorLocationDrift, treeID *int32) {
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeSensorLocationDrift, "service", "SensorService")
+	event := entities.NewEventSensorLocationDrift(sensor, drift, treeID)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after sensor location drift detected", "err", err)
+	}
+}
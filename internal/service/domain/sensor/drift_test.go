@@ -0,0 +1,400 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSensorService_HandleMessage_LocationDrift(t *testing.T) {
+	cfg := &config.SensorConfig{LocationDrift: config.SensorLocationDriftConfig{MaxDistance: 50}}
+
+	// updateSensor applies the update function on a copy of the given sensor like the repository does
+	updateSensor := func(sen *entities.Sensor) func(context.Context, string, func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+		return func(_ context.Context, _ string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			updated := *sen
+			if _, err := fn(&updated); err != nil {
+				return nil, err
+			}
+			return &updated, nil
+		}
+	}
+
+	newPayload := func(lat, lng float64) *entities.MqttPayload {
+		payload := *TestListMQTTPayload[0]
+		payload.Device = TestSensor.ID
+		payload.Latitude = lat
+		payload.Longitude = lng
+		return &payload
+	}
+
+	t.Run("should flag sensor and publish event when it moved away from its registered location", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorLocationDrift)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorLocationDrift)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		// roughly 110 meters north of the registered location
+		payload := newPayload(TestSensor.Latitude+0.001, TestSensor.Longitude)
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(TestSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensor.Latitude, updated.Latitude)
+		assert.Equal(t, TestSensor.Longitude, updated.Longitude)
+		assert.NotNil(t, updated.LocationDrift)
+		assert.Equal(t, payload.Latitude, updated.LocationDrift.Latitude)
+		assert.Equal(t, payload.Longitude, updated.LocationDrift.Longitude)
+		assert.InDelta(t, 111, updated.LocationDrift.Distance, 1)
+
+		select {
+		case receivedEvent := <-ch:
+			e, ok := receivedEvent.(entities.EventSensorLocationDrift)
+			assert.True(t, ok)
+			assert.Equal(t, TestSensor.ID, e.Sensor.ID)
+			assert.Equal(t, updated.LocationDrift, e.Drift)
+			assert.Nil(t, e.TreeID)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should use linked tree as reference location", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorLocationDrift)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorLocationDrift)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		// the sensor reports its registered location, but the linked tree is roughly 220 meters away
+		payload := newPayload(TestSensor.Latitude, TestSensor.Longitude)
+		tree := &entities.Tree{ID: 7, Latitude: TestSensor.Latitude + 0.002, Longitude: TestSensor.Longitude}
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(tree, nil)
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(TestSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, updated.LocationDrift)
+		assert.InDelta(t, 222, updated.LocationDrift.Distance, 1)
+
+		select {
+		case receivedEvent := <-ch:
+			e, ok := receivedEvent.(entities.EventSensorLocationDrift)
+			assert.True(t, ok)
+			assert.Equal(t, &tree.ID, e.TreeID)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should update coordinates without drift when sensor is within max distance", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorLocationDrift)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorLocationDrift)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		// roughly 11 meters north of the registered location
+		payload := newPayload(TestSensor.Latitude+0.0001, TestSensor.Longitude)
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(TestSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload.Latitude, updated.Latitude)
+		assert.Equal(t, payload.Longitude, updated.Longitude)
+		assert.Nil(t, updated.LocationDrift)
+
+		select {
+		case <-ch:
+			t.Fatal("event should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("should not update or publish event again when sensor reports the same drifted position", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorLocationDrift)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorLocationDrift)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		payload := newPayload(TestSensor.Latitude+0.001, TestSensor.Longitude)
+		driftedSensor := *TestSensor
+		driftedSensor.LocationDrift = &entities.SensorLocationDrift{
+			Latitude:   payload.Latitude,
+			Longitude:  payload.Longitude,
+			Distance:   utils.DistanceInMeters(TestSensor.Latitude, TestSensor.Longitude, payload.Latitude, payload.Longitude),
+			DetectedAt: time.Now().Add(-time.Hour),
+		}
+
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(&driftedSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		sensorRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+
+		select {
+		case <-ch:
+			t.Fatal("event should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("should keep detection time when drifted sensor moves further away", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, cfg, nil)
+
+		detectedAt := time.Now().Add(-time.Hour)
+		payload := newPayload(TestSensor.Latitude+0.002, TestSensor.Longitude)
+		driftedSensor := *TestSensor
+		driftedSensor.LocationDrift = &entities.SensorLocationDrift{
+			Latitude:   TestSensor.Latitude + 0.001,
+			Longitude:  TestSensor.Longitude,
+			Distance:   111.19492664454764,
+			DetectedAt: detectedAt,
+		}
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(&driftedSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(&driftedSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload.Latitude, updated.LocationDrift.Latitude)
+		assert.Equal(t, detectedAt, updated.LocationDrift.DetectedAt)
+		assert.InDelta(t, 222, updated.LocationDrift.Distance, 1)
+	})
+
+	t.Run("should clear drift when sensor returns to its registered location", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, cfg, nil)
+
+		payload := newPayload(TestSensor.Latitude, TestSensor.Longitude)
+		driftedSensor := *TestSensor
+		driftedSensor.LocationDrift = &entities.SensorLocationDrift{
+			Latitude:   TestSensor.Latitude + 0.001,
+			Longitude:  TestSensor.Longitude,
+			Distance:   111.19492664454764,
+			DetectedAt: time.Now().Add(-time.Hour),
+		}
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(&driftedSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(&driftedSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, updated.LocationDrift)
+	})
+
+	t.Run("should neither detect drift nor move sensor when payload has no position", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeNewSensorData, entities.EventTypeSensorLocationDrift)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, cfg, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeSensorLocationDrift)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		// the uplink was sent without gps position
+		payload := newPayload(0, 0)
+
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		treeRepo.AssertNotCalled(t, "GetBySensorID", mock.Anything, mock.Anything)
+		sensorRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+
+		select {
+		case <-ch:
+			t.Fatal("event should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("should take reported position without drift when sensor has no registered location", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, cfg, nil)
+
+		unlocated := *TestSensor
+		unlocated.Latitude, unlocated.Longitude = 0, 0
+		payload := newPayload(TestSensor.Latitude, TestSensor.Longitude)
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(&unlocated, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(&unlocated)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload.Latitude, updated.Latitude)
+		assert.Equal(t, payload.Longitude, updated.Longitude)
+		assert.Nil(t, updated.LocationDrift)
+	})
+
+	t.Run("should fall back to registered location when linked tree can not be fetched", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, cfg, nil)
+
+		payload := newPayload(TestSensor.Latitude+0.001, TestSensor.Longitude)
+
+		var updated *entities.Sensor
+		sensorRepo.EXPECT().GetByID(mock.Anything, TestSensor.ID).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, TestSensor.ID).Return(nil, errors.New("db error"))
+		sensorRepo.EXPECT().Update(mock.Anything, TestSensor.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			var err error
+			updated, err = updateSensor(TestSensor)(ctx, id, fn)
+			return updated, err
+		})
+		sensorRepo.EXPECT().GetSensorDataBySensorID(mock.Anything, TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
+		sensorRepo.EXPECT().InsertSensorData(mock.Anything, mock.Anything, TestSensor.ID).Return(nil)
+		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(mock.Anything, TestSensor.ID).Return(TestSensorData[0], nil)
+
+		// when
+		_, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, updated.LocationDrift)
+		assert.InDelta(t, 111, updated.LocationDrift.Distance, 1)
+	})
+}
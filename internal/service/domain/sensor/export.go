@@ -0,0 +1,315 @@
+package sensor
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/parquet-go/parquet-go"
+)
+
+// exportRowGroupSize is the number of rows after which a parquet row group is flushed,
+// the rows of the current row group are held in memory
+const exportRowGroupSize = 10_000
+
+// exportColumn is a column of the export, the node is the type of the column in parquet files
+type exportColumn struct {
+	name string
+	node parquet.Node
+}
+
+type sensorDataEncoder interface {
+	Write(row *entities.SensorDataExportRow) error
+	Close() error
+}
+
+// ExportSensorData validates the query and streams the matching sensor data in the requested format.
+// The data is encoded while the returned reader is read, a failure during the export is returned by the reader.
+func (s *SensorService) ExportSensorData(ctx context.Context, query *entities.SensorDataExportQuery) (io.ReadCloser, error) {
+	log := logger.GetLogger(ctx)
+	if query == nil {
+		return nil, service.MapError(ctx, errors.Join(errors.New("export query cannot be nil"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		log.Debug("failed to validate sensor data export query", "error", err, "raw_query", fmt.Sprintf("%+v", query))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	for _, id := range query.SensorIDs {
+		if _, err := s.sensorRepo.GetByID(ctx, id); err != nil {
+			return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+		}
+	}
+
+	// the export outlives the request, so it must not hold on to the request context which is reused after the
+	// handler returns. It is canceled when the reader is closed instead.
+	exportCtx, cancel := context.WithCancel(context.WithValue(context.Background(), "logger", log))
+	// the watermarks are flattened into columns per depth of the watering thresholds
+	depths := s.rules.Depths(ctx)
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		err := s.writeSensorDataExport(exportCtx, query, depths, pw)
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			log.Error("failed to export sensor data", "error", err, "format", query.Format)
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	log.Info("exporting sensor data", "format", query.Format, "from", query.From, "to", query.To)
+	return &exportReader{PipeReader: pr, cancel: cancel}, nil
+}
+
+// exportReader cancels the running export when it is closed before the export is complete
+type exportReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (r *exportReader) Close() error {
+	r.cancel()
+	return r.PipeReader.Close()
+}
+
+func (s *SensorService) writeSensorDataExport(ctx context.Context, query *entities.SensorDataExportQuery, depths []int, w io.Writer) error {
+	log := logger.GetLogger(ctx)
+	enc, err := newSensorDataEncoder(query.Format, w, depths)
+	if err != nil {
+		return err
+	}
+
+	skippedDepths := make(map[int]bool)
+
+	for row, err := range s.sensorRepo.StreamSensorData(ctx, query) {
+		if err != nil {
+			return err
+		}
+
+		if row.Data == nil || row.Data.Data == nil {
+			continue
+		}
+
+		for _, wm := range row.Data.Data.Watermarks {
+			if !slices.Contains(depths, wm.Depth) && !skippedDepths[wm.Depth] {
+				skippedDepths[wm.Depth] = true
+				log.Warn("watermark depth has no export column, its readings are not exported", "depth", wm.Depth, "sensor_id", row.Data.SensorID, "export_depths", depths)
+			}
+		}
+
+		if err := enc.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+func newSensorDataEncoder(format entities.SensorDataExportFormat, w io.Writer, depths []int) (sensorDataEncoder, error) {
+	switch format {
+	case entities.SensorDataExportFormatCSV:
+		return newCSVSensorDataEncoder(w, depths)
+	case entities.SensorDataExportFormatParquet:
+		return newParquetSensorDataEncoder(w, depths), nil
+	default:
+		return nil, fmt.Errorf("unsupported sensor data export format: %s", format)
+	}
+}
+
+func sensorDataExportColumns(depths []int) []exportColumn {
+	columns := []exportColumn{
+		{name: "sensor_id", node: parquet.String()},
+		{name: "created_at", node: parquet.Timestamp(parquet.Millisecond)},
+		{name: "tree_id", node: parquet.Optional(parquet.Int(32))},
+		{name: "tree_cluster_id", node: parquet.Optional(parquet.Int(32))},
+		{name: "region_id", node: parquet.Optional(parquet.Int(32))},
+		{name: "battery", node: parquet.Leaf(parquet.DoubleType)},
+		{name: "humidity", node: parquet.Leaf(parquet.DoubleType)},
+		{name: "temperature", node: parquet.Leaf(parquet.DoubleType)},
+		{name: "flagged", node: parquet.Leaf(parquet.BooleanType)},
+		{name: "anomaly_score", node: parquet.Leaf(parquet.DoubleType)},
+	}
+
+	for _, depth := range depths {
+		columns = append(columns,
+			exportColumn{name: fmt.Sprintf("centibar_%d", depth), node: parquet.Optional(parquet.Int(32))},
+			exportColumn{name: fmt.Sprintf("resistance_%d", depth), node: parquet.Optional(parquet.Int(32))},
+		)
+	}
+
+	return columns
+}
+
+// sensorDataExportValues flattens a row in the order of sensorDataExportColumns, missing watermarks are nil
+func sensorDataExportValues(row *entities.SensorDataExportRow, depths []int) []any {
+	data := row.Data
+	values := []any{
+		data.SensorID,
+		data.CreatedAt,
+		row.TreeID,
+		row.TreeClusterID,
+		row.RegionID,
+		data.Data.Battery,
+		data.Data.Humidity,
+		data.Data.Temperature,
+		data.Flagged,
+		data.AnomalyScore,
+	}
+
+	for _, depth := range depths {
+		idx := slices.IndexFunc(data.Data.Watermarks, func(w entities.Watermark) bool { return w.Depth == depth })
+		if idx < 0 {
+			values = append(values, nil, nil)
+			continue
+		}
+
+		w := data.Data.Watermarks[idx]
+		values = append(values, int32(w.Centibar), int32(w.Resistance))
+	}
+
+	return values
+}
+
+type csvSensorDataEncoder struct {
+	w      *csv.Writer
+	depths []int
+}
+
+func newCSVSensorDataEncoder(w io.Writer, depths []int) (*csvSensorDataEncoder, error) {
+	columns := sensorDataExportColumns(depths)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+
+	enc := &csvSensorDataEncoder{w: csv.NewWriter(w), depths: depths}
+	if err := enc.w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return enc, nil
+}
+
+func (e *csvSensorDataEncoder) Write(row *entities.SensorDataExportRow) error {
+	values := sensorDataExportValues(row, e.depths)
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatCSVValue(v)
+	}
+
+	return e.w.Write(record)
+}
+
+func (e *csvSensorDataEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatCSVValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case int32:
+		return strconv.FormatInt(int64(val), 10)
+	case *int32:
+		if val == nil {
+			return ""
+		}
+		return strconv.FormatInt(int64(*val), 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		return ""
+	}
+}
+
+type parquetSensorDataEncoder struct {
+	w      *parquet.Writer
+	rows   int
+	depths []int
+	// leaves are the parquet columns in the order of sensorDataExportColumns
+	leaves []parquet.LeafColumn
+}
+
+func newParquetSensorDataEncoder(w io.Writer, depths []int) *parquetSensorDataEncoder {
+	columns := sensorDataExportColumns(depths)
+	group := make(parquet.Group, len(columns))
+	for _, col := range columns {
+		group[col.name] = col.node
+	}
+
+	schema := parquet.NewSchema("sensor_data", group)
+	leaves := make([]parquet.LeafColumn, len(columns))
+	for i, col := range columns {
+		leaves[i], _ = schema.Lookup(col.name)
+	}
+
+	return &parquetSensorDataEncoder{
+		w:      parquet.NewWriter(w, schema, parquet.Compression(&parquet.Snappy)),
+		depths: depths,
+		leaves: leaves,
+	}
+}
+
+func (e *parquetSensorDataEncoder) Write(row *entities.SensorDataExportRow) error {
+	values := sensorDataExportValues(row, e.depths)
+	record := make(parquet.Row, len(values))
+	for i, v := range values {
+		leaf := e.leaves[i]
+		value := parquetValue(v)
+		definitionLevel := leaf.MaxDefinitionLevel
+		if value.IsNull() {
+			definitionLevel = 0
+		}
+		record[leaf.ColumnIndex] = value.Level(0, definitionLevel, leaf.ColumnIndex)
+	}
+
+	if _, err := e.w.WriteRows([]parquet.Row{record}); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportRowGroupSize == 0 {
+		return e.w.Flush()
+	}
+
+	return nil
+}
+
+func (e *parquetSensorDataEncoder) Close() error {
+	return e.w.Close()
+}
+
+func parquetValue(v any) parquet.Value {
+	switch val := v.(type) {
+	case string:
+		return parquet.ByteArrayValue([]byte(val))
+	case time.Time:
+		return parquet.Int64Value(val.UnixMilli())
+	case int32:
+		return parquet.Int32Value(val)
+	case *int32:
+		if val == nil {
+			return parquet.NullValue()
+		}
+		return parquet.Int32Value(*val)
+	case float64:
+		return parquet.DoubleValue(val)
+	case bool:
+		return parquet.BooleanValue(val)
+	default:
+		return parquet.NullValue()
+	}
+}
@@ -0,0 +1,328 @@
+package sensor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportRowSeq(rows []*entities.SensorDataExportRow, err error) iter.Seq2[*entities.SensorDataExportRow, error] {
+	return func(yield func(*entities.SensorDataExportRow, error) bool) {
+		for _, row := range rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// readParquetRows reads the rows of a parquet file as column name to value, null values are nil
+func readParquetRows(t *testing.T, data []byte) []map[string]any {
+	r := parquet.NewReader(bytes.NewReader(data))
+	defer r.Close()
+
+	columns := r.Schema().Columns()
+	rows := make([]parquet.Row, r.NumRows())
+	n, err := r.ReadRows(rows)
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+
+	got := make([]map[string]any, n)
+	for i, row := range rows[:n] {
+		got[i] = make(map[string]any, len(row))
+		for _, v := range row {
+			name := columns[v.Column()][0]
+			switch {
+			case v.IsNull():
+				got[i][name] = nil
+			case v.Kind() == parquet.ByteArray:
+				got[i][name] = string(v.ByteArray())
+			case v.Kind() == parquet.Int32:
+				got[i][name] = v.Int32()
+			case v.Kind() == parquet.Int64:
+				got[i][name] = v.Int64()
+			case v.Kind() == parquet.Double:
+				got[i][name] = v.Double()
+			case v.Kind() == parquet.Boolean:
+				got[i][name] = v.Boolean()
+			}
+		}
+	}
+
+	return got
+}
+
+func TestSensorService_ExportSensorData(t *testing.T) {
+	to := time.Date(2024, 11, 10, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+	rows := []*entities.SensorDataExportRow{
+		{
+			Data: &entities.SensorData{
+				ID:        1,
+				SensorID:  "sensor-1",
+				CreatedAt: from.Add(time.Hour),
+				Data: &entities.MqttPayload{
+					Battery:     3.4,
+					Humidity:    50.5,
+					Temperature: 20,
+					Watermarks: []entities.Watermark{
+						{Centibar: 30, Resistance: 1200, Depth: 30},
+						{Centibar: 60, Resistance: 2400, Depth: 60},
+						{Centibar: 90, Resistance: 3600, Depth: 90},
+					},
+				},
+			},
+			TreeID:        utils.P(int32(1)),
+			TreeClusterID: utils.P(int32(2)),
+			RegionID:      utils.P(int32(3)),
+		},
+		{
+			Data: &entities.SensorData{
+				ID:           2,
+				SensorID:     "sensor-2",
+				CreatedAt:    from.Add(2 * time.Hour),
+				Flagged:      true,
+				AnomalyScore: 0.5,
+				Data: &entities.MqttPayload{
+					Battery: 3.1,
+					Watermarks: []entities.Watermark{
+						{Centibar: 45, Resistance: 1800, Depth: 60},
+					},
+				},
+			},
+		},
+	}
+
+	newService := func(t *testing.T) (*storageMock.MockSensorRepository, service.SensorService) {
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		return sensorRepo, svc
+	}
+
+	t.Run("should stream sensor data as csv with flattened watermarks", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).Return(exportRowSeq(rows, nil))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+		assert.NoError(t, err)
+		defer reader.Close()
+		records, err := csv.NewReader(reader).ReadAll()
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 3)
+		assert.Equal(t, []string{
+			"sensor_id", "created_at", "tree_id", "tree_cluster_id", "region_id", "battery", "humidity", "temperature", "flagged", "anomaly_score",
+			"centibar_30", "resistance_30", "centibar_60", "resistance_60", "centibar_90", "resistance_90",
+		}, records[0])
+		assert.Equal(t, []string{
+			"sensor-1", "2024-11-09T13:00:00Z", "1", "2", "3", "3.4", "50.5", "20", "false", "0",
+			"30", "1200", "60", "2400", "90", "3600",
+		}, records[1])
+		assert.Equal(t, []string{
+			"sensor-2", "2024-11-09T14:00:00Z", "", "", "", "3.1", "0", "0", "true", "0.5",
+			"", "", "45", "1800", "", "",
+		}, records[2])
+	})
+
+	t.Run("should stream sensor data as parquet", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatParquet}
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).Return(exportRowSeq(rows, nil))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+		assert.NoError(t, err)
+		defer reader.Close()
+		out, err := io.ReadAll(reader)
+
+		// then
+		assert.NoError(t, err)
+		got := readParquetRows(t, out)
+		assert.Len(t, got, 2)
+		assert.Equal(t, map[string]any{
+			"sensor_id": "sensor-1", "created_at": from.Add(time.Hour).UnixMilli(), "tree_id": int32(1), "tree_cluster_id": int32(2), "region_id": int32(3),
+			"battery": 3.4, "humidity": 50.5, "temperature": 20.0, "flagged": false, "anomaly_score": 0.0,
+			"centibar_30": int32(30), "resistance_30": int32(1200), "centibar_60": int32(60), "resistance_60": int32(2400), "centibar_90": int32(90), "resistance_90": int32(3600),
+		}, got[0])
+		assert.Equal(t, map[string]any{
+			"sensor_id": "sensor-2", "created_at": from.Add(2 * time.Hour).UnixMilli(), "tree_id": nil, "tree_cluster_id": nil, "region_id": nil,
+			"battery": 3.1, "humidity": 0.0, "temperature": 0.0, "flagged": true, "anomaly_score": 0.5,
+			"centibar_30": nil, "resistance_30": nil, "centibar_60": int32(45), "resistance_60": int32(1800), "centibar_90": nil, "resistance_90": nil,
+		}, got[1])
+	})
+
+	t.Run("should flatten watermarks at the depths of the watering thresholds", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		wateringCfg := &config.WateringStatusConfig{
+			Thresholds: []config.WateringThresholdConfig{
+				{MinAge: 0, MaxAge: 99, Depth: 45, Moderate: 40, Bad: 60},
+				{MinAge: 0, MaxAge: 99, Depth: 20, Moderate: 30, Bad: 50},
+			},
+		}
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, wateringCfg)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		fourProbes := &entities.SensorDataExportRow{Data: &entities.SensorData{
+			SensorID:  "sensor-4",
+			CreatedAt: from,
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 10, Resistance: 500, Depth: 20},
+					{Centibar: 20, Resistance: 900, Depth: 45},
+					{Centibar: 30, Resistance: 1200, Depth: 70},
+				},
+			},
+		}}
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).Return(exportRowSeq([]*entities.SensorDataExportRow{fourProbes}, nil))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+		assert.NoError(t, err)
+		defer reader.Close()
+		records, err := csv.NewReader(reader).ReadAll()
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, []string{"centibar_20", "resistance_20", "centibar_45", "resistance_45"}, records[0][10:])
+		assert.Equal(t, []string{"10", "500", "20", "900"}, records[1][10:])
+	})
+
+	t.Run("should check that filtered sensors exist", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{SensorIDs: []string{"sensor-1", "sensor-99"}, From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor-1").Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetByID(context.Background(), "sensor-99").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+
+		// then
+		assert.Nil(t, reader)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+		sensorRepo.AssertNotCalled(t, "StreamSensorData")
+	})
+
+	t.Run("should return validation error on invalid query", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query *entities.SensorDataExportQuery
+		}{
+			{name: "nil query", query: nil},
+			{name: "unknown format", query: &entities.SensorDataExportQuery{From: from, To: to, Format: "xlsx"}},
+			{name: "from after to", query: &entities.SensorDataExportQuery{From: to, To: from, Format: entities.SensorDataExportFormatCSV}},
+			{name: "missing time range", query: &entities.SensorDataExportQuery{Format: entities.SensorDataExportFormatCSV}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// given
+				sensorRepo, svc := newService(t)
+
+				// when
+				reader, err := svc.ExportSensorData(context.Background(), tt.query)
+
+				// then
+				assert.Nil(t, reader)
+				var svcErr service.Error
+				assert.ErrorAs(t, err, &svcErr)
+				assert.Equal(t, service.BadRequest, svcErr.Code)
+				sensorRepo.AssertNotCalled(t, "StreamSensorData")
+			})
+		}
+	})
+
+	t.Run("should return error of repository while reading", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).Return(exportRowSeq(rows[:1], errors.New("connection lost")))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+		assert.NoError(t, err)
+		defer reader.Close()
+		_, err = io.ReadAll(reader)
+
+		// then
+		assert.EqualError(t, err, "connection lost")
+	})
+
+	t.Run("should stream with a detached context that carries the logger", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		log := slog.New(slog.NewTextHandler(io.Discard, nil))
+		reqCtx, cancel := context.WithCancel(context.WithValue(context.Background(), "logger", log))
+		var streamCtx context.Context
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).RunAndReturn(func(ctx context.Context, _ *entities.SensorDataExportQuery) iter.Seq2[*entities.SensorDataExportRow, error] {
+			streamCtx = ctx
+			return exportRowSeq(rows, nil)
+		})
+
+		// when
+		reader, err := svc.ExportSensorData(reqCtx, query)
+		cancel()
+		assert.NoError(t, err)
+		_, err = io.ReadAll(reader)
+		assert.NoError(t, err)
+		closeErr := reader.Close()
+
+		// then
+		assert.NoError(t, closeErr)
+		assert.Same(t, log, streamCtx.Value("logger"))
+		assert.ErrorIs(t, streamCtx.Err(), context.Canceled)
+	})
+
+	t.Run("should skip sensor data without payload", func(t *testing.T) {
+		// given
+		sensorRepo, svc := newService(t)
+		query := &entities.SensorDataExportQuery{From: from, To: to, Format: entities.SensorDataExportFormatCSV}
+		withoutPayload := []*entities.SensorDataExportRow{{Data: &entities.SensorData{ID: 3, SensorID: "sensor-3"}}, rows[0]}
+		sensorRepo.EXPECT().StreamSensorData(mock.Anything, query).Return(exportRowSeq(withoutPayload, nil))
+
+		// when
+		reader, err := svc.ExportSensorData(context.Background(), query)
+		assert.NoError(t, err)
+		defer reader.Close()
+		records, err := csv.NewReader(reader).ReadAll()
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "sensor-1", records[1][0])
+	})
+}
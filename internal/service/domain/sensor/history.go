@@ -0,0 +1,77 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func (s *SensorService) GetSensorDataHistory(ctx context.Context, id string, query *entities.SensorDataHistoryQuery) ([]*entities.SensorDataAggregate, error) {
+	log := logger.GetLogger(ctx)
+	if query == nil {
+		return nil, service.MapError(ctx, errors.Join(errors.New("history query cannot be nil"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		log.Debug("failed to validate sensor data history query", "error", err, "raw_query", fmt.Sprintf("%+v", query))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if _, err := s.sensorRepo.GetByID(ctx, id); err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if query.Resolution == entities.SensorDataResolutionRaw {
+		data, err := s.sensorRepo.GetSensorDataBySensorID(ctx, id, query.From, query.To)
+		if err != nil {
+			log.Debug("failed to fetch sensor data history", "sensor_id", id, "error", err)
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+
+		return rawSensorDataToAggregates(data), nil
+	}
+
+	data, err := s.sensorRepo.GetAggregatedSensorDataBySensorID(ctx, id, query.Resolution, query.From, query.To)
+	if err != nil {
+		log.Debug("failed to fetch aggregated sensor data history", "sensor_id", id, "resolution", query.Resolution, "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return data, nil
+}
+
+// rawSensorDataToAggregates wraps every raw reading into a single-sample aggregate,
+// so the history endpoint returns the same shape for every resolution.
+func rawSensorDataToAggregates(data []*entities.SensorData) []*entities.SensorDataAggregate {
+	result := make([]*entities.SensorDataAggregate, 0, len(data))
+	for _, d := range data {
+		if d == nil || d.Data == nil {
+			continue
+		}
+
+		watermarks := make([]entities.WatermarkAggregate, len(d.Data.Watermarks))
+		for i, w := range d.Data.Watermarks {
+			watermarks[i] = entities.WatermarkAggregate{
+				Depth:       w.Depth,
+				Centibar:    float64(w.Centibar),
+				Resistance:  float64(w.Resistance),
+				SampleCount: 1,
+			}
+		}
+
+		result = append(result, &entities.SensorDataAggregate{
+			Timestamp:   d.CreatedAt,
+			SampleCount: 1,
+			Battery:     d.Data.Battery,
+			Humidity:    d.Data.Humidity,
+			Temperature: d.Data.Temperature,
+			Watermarks:  watermarks,
+		})
+	}
+
+	return result
+}
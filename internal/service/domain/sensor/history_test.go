@@ -0,0 +1,161 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorService_GetSensorDataHistory(t *testing.T) {
+	to := time.Date(2024, 11, 10, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+
+	t.Run("should return raw sensor data as single sample aggregates", func(t *testing.T) {
+		// given
+		id := "sensor001"
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: from, To: to, Resolution: entities.SensorDataResolutionRaw}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(context.Background(), id, from, to).Return(TestSensorData, nil)
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), id, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, history, len(TestSensorData))
+		for i, h := range history {
+			payload := TestSensorData[i].Data
+			assert.Equal(t, TestSensorData[i].CreatedAt, h.Timestamp)
+			assert.Equal(t, int32(1), h.SampleCount)
+			assert.Equal(t, payload.Battery, h.Battery)
+			assert.Equal(t, payload.Humidity, h.Humidity)
+			assert.Equal(t, payload.Temperature, h.Temperature)
+			assert.Len(t, h.Watermarks, len(payload.Watermarks))
+			for j, w := range h.Watermarks {
+				assert.Equal(t, payload.Watermarks[j].Depth, w.Depth)
+				assert.Equal(t, float64(payload.Watermarks[j].Centibar), w.Centibar)
+				assert.Equal(t, float64(payload.Watermarks[j].Resistance), w.Resistance)
+			}
+		}
+	})
+
+	t.Run("should return aggregated sensor data for hourly resolution", func(t *testing.T) {
+		// given
+		id := "sensor001"
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: from, To: to, Resolution: entities.SensorDataResolutionHourly}
+
+		expected := []*entities.SensorDataAggregate{
+			{
+				Timestamp:   from,
+				SampleCount: 4,
+				Battery:     34.5,
+				Humidity:    0.7,
+				Temperature: 21.2,
+				Watermarks: []entities.WatermarkAggregate{
+					{Depth: 30, Centibar: 31.5, Resistance: 22.25},
+				},
+			},
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetAggregatedSensorDataBySensorID(context.Background(), id, entities.SensorDataResolutionHourly, from, to).Return(expected, nil)
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), id, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, history)
+	})
+
+	t.Run("should return validation error when time range is invalid", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: to, To: from, Resolution: entities.SensorDataResolutionRaw}
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), "sensor001", query)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return validation error when resolution is unknown", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: from, To: to, Resolution: "weekly"}
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), "sensor001", query)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return error when sensor not found", func(t *testing.T) {
+		// given
+		id := "notFoundID"
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: from, To: to, Resolution: entities.SensorDataResolutionDaily}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), id, query)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.EqualError(t, err, "entity not found: not found")
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		id := "sensor001"
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+		query := &entities.SensorDataHistoryQuery{From: from, To: to, Resolution: entities.SensorDataResolutionDaily}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetAggregatedSensorDataBySensorID(context.Background(), id, entities.SensorDataResolutionDaily, from, to).Return(nil, errors.New("internal error"))
+
+		// when
+		history, err := svc.GetSensorDataHistory(context.Background(), id, query)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.EqualError(t, err, "internal error")
+	})
+}
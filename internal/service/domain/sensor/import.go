@@ -0,0 +1,68 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+func (s *SensorService) Import(ctx context.Context, sensors []*entities.SensorImport) ([]*entities.Sensor, error) {
+	log := logger.GetLogger(ctx)
+	if len(sensors) == 0 {
+		return nil, service.MapError(ctx, errors.Join(errors.New("no sensors to import"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	seen := make(map[string]bool, len(sensors))
+	seenTrees := make(map[int32]bool, len(sensors))
+	linked := make(map[string]bool, len(sensors))
+	for i, si := range sensors {
+		if err := s.validator.Struct(si); err != nil {
+			log.Debug("failed to validate sensor struct to import", "error", err, "row", i, "raw_sensor", fmt.Sprintf("%+v", si))
+			return nil, service.MapError(ctx, errors.Join(fmt.Errorf("invalid sensor at position %d: %w", i, err), service.ErrValidation), service.ErrorLogValidation)
+		}
+
+		if seen[si.ID] {
+			err := fmt.Errorf("duplicate sensor id %s at position %d", si.ID, i)
+			return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+		}
+		seen[si.ID] = true
+
+		if si.TreeID != nil {
+			if seenTrees[*si.TreeID] {
+				err := fmt.Errorf("duplicate tree id %d at position %d", *si.TreeID, i)
+				return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+			}
+			seenTrees[*si.TreeID] = true
+		}
+		linked[si.ID] = si.TreeID != nil
+	}
+
+	imported, err := s.sensorRepo.Import(ctx, sensors)
+	if err != nil {
+		if errors.Is(err, storage.ErrIDAlreadyExists) {
+			log.Debug("failed to import sensors, sensor id is already taken", "error", err)
+			return nil, service.ErrSensorIDTaken
+		}
+		if errors.Is(err, storage.ErrTreeHasSensor) {
+			log.Debug("failed to import sensors, tree is already linked to a sensor", "error", err)
+			return nil, service.ErrTreeHasSensor
+		}
+		log.Debug("failed to import sensors", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("sensors imported successfully", "count", len(imported))
+	// sensors imported without a tree are assigned to a nearby tree like created sensors
+	for _, sensor := range imported {
+		if !linked[sensor.ID] {
+			s.publishCreateSensorEvent(ctx, sensor)
+		}
+	}
+
+	return imported, nil
+}
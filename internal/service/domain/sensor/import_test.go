@@ -0,0 +1,204 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSensorImports = []*entities.SensorImport{
+	{ID: "sensor-10", Latitude: 54.82124518093376, Longitude: 9.485702120628517, TreeID: utils.P(int32(1))},
+	{ID: "sensor-11", Latitude: 54.78780993841013, Longitude: 9.444052105200551},
+}
+
+func TestSensorService_Import(t *testing.T) {
+	t.Run("should import all sensors", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().Import(context.Background(), testSensorImports).Return(TestSensorList[:2], nil)
+
+		// when
+		result, err := svc.Import(context.Background(), testSensorImports)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, TestSensorList[:2], result)
+	})
+
+	t.Run("should publish create sensor event for imported sensors without tree", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeCreateSensor)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, eventManager, globalSensorConfig, nil)
+
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeCreateSensor)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		imported := []*entities.Sensor{
+			{ID: "sensor-10", Latitude: testSensorImports[0].Latitude, Longitude: testSensorImports[0].Longitude},
+			{ID: "sensor-11", Latitude: testSensorImports[1].Latitude, Longitude: testSensorImports[1].Longitude},
+		}
+		sensorRepo.EXPECT().Import(context.Background(), testSensorImports).Return(imported, nil)
+
+		// when
+		_, err := svc.Import(context.Background(), testSensorImports)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case receivedEvent, ok := <-ch:
+			assert.True(t, ok)
+			e := receivedEvent.(entities.EventCreateSensor)
+			assert.Equal(t, imported[1], e.New)
+		case <-time.After(1 * time.Second):
+			t.Fatal("event was not received")
+		}
+
+		select {
+		case <-ch:
+			t.Fatal("event of sensor linked to a tree should not be sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("should return validation error when no sensors are given", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		// when
+		result, err := svc.Import(context.Background(), []*entities.SensorImport{})
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "no sensors to import")
+	})
+
+	t.Run("should return validation error when a sensor is invalid", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensors := []*entities.SensorImport{
+			testSensorImports[0],
+			{ID: "sensor-12", Latitude: 200, Longitude: 9.44},
+		}
+
+		// when
+		result, err := svc.Import(context.Background(), sensors)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "invalid sensor at position 1")
+	})
+
+	t.Run("should return validation error when sensor ids are duplicated", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensors := []*entities.SensorImport{testSensorImports[0], testSensorImports[0]}
+
+		// when
+		result, err := svc.Import(context.Background(), sensors)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "duplicate sensor id sensor-10")
+	})
+
+	t.Run("should return error when sensor id is already taken", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().Import(context.Background(), testSensorImports).Return(nil, storage.ErrIDAlreadyExists)
+
+		// when
+		result, err := svc.Import(context.Background(), testSensorImports)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, service.ErrSensorIDTaken)
+	})
+
+	t.Run("should return validation error when tree ids are duplicated", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensors := []*entities.SensorImport{
+			testSensorImports[0],
+			{ID: "sensor-12", Latitude: 54.82, Longitude: 9.48, TreeID: utils.P(int32(1))},
+		}
+
+		// when
+		result, err := svc.Import(context.Background(), sensors)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "duplicate tree id 1")
+	})
+
+	t.Run("should return error when tree is already linked to a sensor", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().Import(context.Background(), testSensorImports).Return(nil, storage.ErrTreeHasSensor)
+
+		// when
+		result, err := svc.Import(context.Background(), testSensorImports)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, service.ErrTreeHasSensor)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().Import(context.Background(), testSensorImports).Return(nil, errors.New("repository error"))
+
+		// when
+		result, err := svc.Import(context.Background(), testSensorImports)
+
+		// then
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "repository error")
+	})
+}
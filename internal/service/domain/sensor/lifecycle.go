@@ -0,0 +1,99 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// Decommission retires a sensor and unlinks it from its trees and flowerbeds. The sensor and its data are kept.
+func (s *SensorService) Decommission(ctx context.Context, id string, sd *entities.SensorDecommission) (*entities.Sensor, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(sd); err != nil {
+		log.Debug("failed to validate sensor decommission struct", "error", err, "raw_decommission", fmt.Sprintf("%+v", sd))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.checkActiveSensor(ctx, id); err != nil {
+		return nil, err
+	}
+
+	decommissioned, err := s.sensorRepo.Decommission(ctx, id, sd.Reason, nil)
+	if err != nil {
+		log.Debug("failed to decommission sensor", "sensor_id", id, "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor decommissioned successfully", "sensor_id", id)
+	return decommissioned, nil
+}
+
+// Replace retires a sensor and links its trees and flowerbeds to the replacement sensor. A replacement that
+// does not exist yet is created at the location of the retired sensor, an existing one must not be linked
+// to a tree. It returns the replacement sensor.
+func (s *SensorService) Replace(ctx context.Context, id string, sr *entities.SensorReplace) (*entities.Sensor, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(sr); err != nil {
+		log.Debug("failed to validate sensor replace struct", "error", err, "raw_replace", fmt.Sprintf("%+v", sr))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if sr.ReplacementID == id {
+		return nil, service.MapError(ctx, errors.Join(errors.New("sensor cannot be replaced by itself"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.checkActiveSensor(ctx, id); err != nil {
+		return nil, err
+	}
+
+	replacement, err := s.sensorRepo.GetByID(ctx, sr.ReplacementID)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if !errors.As(err, &entityNotFoundErr) {
+			log.Debug("failed to fetch replacement sensor by id", "sensor_id", sr.ReplacementID, "error", err)
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+	}
+
+	if replacement != nil && replacement.DecommissionedAt != nil {
+		log.Debug("replacement sensor is decommissioned", "sensor_id", sr.ReplacementID)
+		return nil, service.ErrSensorDecommissioned
+	}
+
+	if _, err := s.sensorRepo.Decommission(ctx, id, sr.Reason, &sr.ReplacementID); err != nil {
+		log.Debug("failed to replace sensor", "sensor_id", id, "replaced_by", sr.ReplacementID, "error", err)
+		if errors.Is(err, storage.ErrReplacementHasTree) {
+			return nil, service.ErrReplacementHasTree
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	replacement, err = s.sensorRepo.GetByID(ctx, sr.ReplacementID)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor replaced successfully", "sensor_id", id, "replaced_by", sr.ReplacementID)
+	return replacement, nil
+}
+
+func (s *SensorService) checkActiveSensor(ctx context.Context, id string) error {
+	log := logger.GetLogger(ctx)
+	sensor, err := s.sensorRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor by id", "sensor_id", id, "error", err)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if sensor.DecommissionedAt != nil {
+		log.Debug("sensor is already decommissioned", "sensor_id", id)
+		return service.ErrSensorDecommissioned
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package sensor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorService_Decommission(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should decommission sensor", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+		decommissioned := &domain.Sensor{ID: TestSensor.ID, Status: domain.SensorStatusOffline, DecommissionedAt: utils.P(time.Now()), DecommissionReason: utils.P("water damage")}
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().Decommission(ctx, TestSensor.ID, "water damage", (*string)(nil)).Return(decommissioned, nil)
+
+		// when
+		got, err := svc.Decommission(ctx, TestSensor.ID, &domain.SensorDecommission{Reason: "water damage"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, decommissioned, got)
+	})
+
+	t.Run("should return error when sensor is already decommissioned", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(&domain.Sensor{ID: TestSensor.ID, DecommissionedAt: utils.P(time.Now())}, nil)
+
+		// when
+		got, err := svc.Decommission(ctx, TestSensor.ID, &domain.SensorDecommission{})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		assert.Nil(t, got)
+		sensorRepo.AssertNotCalled(t, "Decommission")
+	})
+
+	t.Run("should return error when sensor not found", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(ctx, "unknown").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Decommission(ctx, "unknown", &domain.SensorDecommission{})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorService_Replace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should replace sensor with new sensor", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+		replacement := &domain.Sensor{ID: "sensor-new", Status: domain.SensorStatusUnknown, Latitude: TestSensor.Latitude, Longitude: TestSensor.Longitude}
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetByID(ctx, "sensor-new").Return(nil, storage.ErrEntityNotFound("not found")).Once()
+		sensorRepo.EXPECT().Decommission(ctx, TestSensor.ID, "battery swap", utils.P("sensor-new")).Return(&domain.Sensor{ID: TestSensor.ID}, nil)
+		sensorRepo.EXPECT().GetByID(ctx, "sensor-new").Return(replacement, nil).Once()
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{ReplacementID: "sensor-new", Reason: "battery swap"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, replacement, got)
+	})
+
+	t.Run("should return validation error when replacement id is empty", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{})
+
+		// then
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return validation error when sensor is replaced by itself", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{ReplacementID: TestSensor.ID})
+
+		// then
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when replacement is decommissioned", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetByID(ctx, "sensor-old").Return(&domain.Sensor{ID: "sensor-old", DecommissionedAt: utils.P(time.Now())}, nil)
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{ReplacementID: "sensor-old"})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		assert.Nil(t, got)
+		sensorRepo.AssertNotCalled(t, "Decommission")
+	})
+
+	t.Run("should return error when replacement is linked to a tree", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetByID(ctx, "sensor-linked").Return(&domain.Sensor{ID: "sensor-linked"}, nil)
+		sensorRepo.EXPECT().Decommission(ctx, TestSensor.ID, "", utils.P("sensor-linked")).Return(nil, storage.ErrReplacementHasTree)
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{ReplacementID: "sensor-linked"})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrReplacementHasTree)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, storageMock.NewMockTreeRepository(t), storageMock.NewMockFlowerbedRepository(t), nil, globalEventManager, globalSensorConfig, nil)
+
+		sensorRepo.EXPECT().GetByID(ctx, TestSensor.ID).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetByID(ctx, "sensor-new").Return(nil, storage.ErrEntityNotFound("not found"))
+		sensorRepo.EXPECT().Decommission(ctx, TestSensor.ID, "", utils.P("sensor-new")).Return(nil, errors.New("transaction failed"))
+
+		// when
+		got, err := svc.Replace(ctx, TestSensor.ID, &domain.SensorReplace{ReplacementID: "sensor-new"})
+
+		// then
+		assert.ErrorContains(t, err, "transaction failed")
+		assert.Nil(t, got)
+	})
+}
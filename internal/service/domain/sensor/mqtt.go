@@ -3,10 +3,13 @@ package sensor
 import (
 	"context"
 	"errors"
+	"time"
 
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 func (s *SensorService) HandleMessage(ctx context.Context, payload *domain.MqttPayload) (*domain.SensorData, error) {
@@ -30,15 +33,16 @@ func (s *SensorService) HandleMessage(ctx context.Context, payload *domain.MqttP
 		}
 	}
 
-	if err != nil {
-		var entityNotFoundErr storage.ErrEntityNotFound
-		if !errors.As(err, &entityNotFoundErr) {
-			log.Error("failed to get sensor by id", "error", err)
-			return nil, err
-		}
+	if sensor != nil && sensor.DecommissionedAt != nil {
+		log.Info("dropping data of decommissioned sensor", "sensor_id", sensor.ID)
+		return nil, service.ErrSensorDecommissioned
 	}
 
+	var prevData *domain.SensorData
+	var prevStatus domain.SensorStatus
 	if sensor != nil {
+		prevData = sensor.LatestData
+		prevStatus = sensor.Status
 		updatedSensor, err := s.updateSensorCoordsAndStatus(ctx, payload, sensor)
 		if err != nil {
 			log.Error("failed to update sensor", "error", err)
@@ -59,11 +63,18 @@ func (s *SensorService) HandleMessage(ctx context.Context, payload *domain.MqttP
 			return nil, err
 		}
 		sensor = createdSensor
+		s.publishCreateSensorEvent(ctx, sensor)
+	}
+
+	if sensor.Calibration != nil {
+		applyCalibration(sensor.Calibration, payload)
 	}
 
 	data := domain.SensorData{
 		Data: payload,
 	}
+	s.detectAnomalies(ctx, sensor.ID, &data, prevData != nil)
+
 	err = s.sensorRepo.InsertSensorData(ctx, &data, sensor.ID)
 	if err != nil {
 		log.Error("failed to insert sensor data", "sensor_id", sensor.ID, "error", err)
@@ -76,23 +87,52 @@ func (s *SensorService) HandleMessage(ctx context.Context, payload *domain.MqttP
 	}
 
 	s.publishNewSensorDataEvent(ctx, sensorData)
+	if prevStatus != "" && prevStatus != domain.SensorStatusOnline {
+		s.publishSensorStatusEvent(ctx, sensor.ID, prevStatus, domain.SensorStatusOnline, utils.P(time.Now()))
+	}
+	s.checkBatteryThreshold(ctx, sensor, prevData, payload.Battery)
 
 	return sensorData, nil
 }
 
 func (s *SensorService) updateSensorCoordsAndStatus(ctx context.Context, payload *domain.MqttPayload, sensor *domain.Sensor) (*domain.Sensor, error) {
 	log := logger.GetLogger(ctx)
-	if sensor.Latitude != payload.Latitude || sensor.Longitude != payload.Longitude || sensor.Status != domain.SensorStatusOnline {
+	// an uplink without position neither moves the sensor nor tells anything about its drift
+	lat, lng := sensor.Latitude, sensor.Longitude
+	drift := sensor.LocationDrift
+	var treeID *int32
+	if hasPosition(payload.Latitude, payload.Longitude) {
+		drift, treeID = s.detectLocationDrift(ctx, sensor, payload)
+		lat, lng = payload.Latitude, payload.Longitude
+		if drift != nil {
+			// a drifted sensor keeps its registered location until it returns or is relocated
+			lat, lng = sensor.Latitude, sensor.Longitude
+			if sensor.LocationDrift != nil {
+				drift.DetectedAt = sensor.LocationDrift.DetectedAt
+			}
+		}
+	}
+
+	prevDrift := sensor.LocationDrift
+	if sensor.Latitude != lat || sensor.Longitude != lng || sensor.Status != domain.SensorStatusOnline || locationDriftChanged(prevDrift, drift) {
 		updatedSensor, err := s.sensorRepo.Update(ctx, sensor.ID, func(s *domain.Sensor) (bool, error) {
-			s.Latitude = payload.Latitude
-			s.Longitude = payload.Longitude
+			s.Latitude = lat
+			s.Longitude = lng
 			s.Status = domain.SensorStatusOnline
+			s.LocationDrift = drift
 			return true, nil
 		})
 		if err != nil {
 			return nil, err
 		}
 
+		if prevDrift == nil && drift != nil {
+			log.Warn("sensor reported a position away from its registered location", "sensor_id", sensor.ID, "sensor_latitude", drift.Latitude, "sensor_longitude", drift.Longitude, "distance", drift.Distance, "tree_id", treeID)
+			s.publishLocationDriftEvent(ctx, updatedSensor, drift, treeID)
+		} else if prevDrift != nil && drift == nil {
+			log.Info("sensor returned to its registered location", "sensor_id", sensor.ID)
+		}
+
 		return updatedSensor, err
 	}
 
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/stretchr/testify/mock"
 
@@ -14,14 +19,17 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var globalEventManager = worker.NewEventManager()
+var (
+	globalEventManager = worker.NewEventManager()
+	globalSensorConfig = &config.SensorConfig{}
+)
 
 func TestNewSensorService(t *testing.T) {
 	t.Run("should create a new service", func(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		assert.NotNil(t, svc)
 	})
 }
@@ -32,7 +40,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		testPayLoad := TestListMQTTPayload[0]
 		insertData := &domain.SensorData{
@@ -40,7 +48,9 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		}
 
 		sensorRepo.EXPECT().GetByID(context.Background(), testPayLoad.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
 		sensorRepo.EXPECT().Update(context.Background(), TestSensor.ID, mock.Anything).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(context.Background(), TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
 		sensorRepo.EXPECT().InsertSensorData(context.Background(), insertData, testPayLoad.Device).Return(nil)
 		sensorRepo.EXPECT().GetLatestSensorDataBySensorID(context.Background(), TestSensor.ID).Return(TestSensorData[0], nil)
 
@@ -65,11 +75,12 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		testPayload := TestListMQTTPayload[0]
 
 		sensorRepo.EXPECT().GetByID(context.Background(), testPayload.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
 		sensorRepo.EXPECT().Update(context.Background(), TestSensor.ID, mock.Anything).Return(nil, errors.New("update error"))
 
 		// when
@@ -86,7 +97,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		testPayLoad := TestListMQTTPayload[0]
 		insertData := &domain.SensorData{
@@ -120,7 +131,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		testPayload := TestListMQTTPayload[0]
 
@@ -141,7 +152,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		result, err := svc.HandleMessage(context.Background(), nil)
@@ -156,7 +167,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		result, err := svc.HandleMessage(context.Background(), TestMQTTPayLoadInvalidLat)
@@ -172,7 +183,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		result, err := svc.HandleMessage(context.Background(), TestMQTTPayLoadInvalidLong)
@@ -188,7 +199,7 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		testPayLoad := TestListMQTTPayload[0]
 		insertData := &domain.SensorData{
@@ -196,7 +207,9 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		}
 
 		sensorRepo.EXPECT().GetByID(context.Background(), testPayLoad.Device).Return(TestSensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), TestSensor.ID).Return(nil, storage.ErrEntityNotFound("not found"))
 		sensorRepo.EXPECT().Update(context.Background(), TestSensor.ID, mock.Anything).Return(TestSensor, nil)
+		sensorRepo.EXPECT().GetSensorDataBySensorID(context.Background(), TestSensor.ID, mock.Anything, mock.Anything).Return(nil, nil)
 		sensorRepo.EXPECT().InsertSensorData(context.Background(), insertData, testPayLoad.Device).Return(errors.New("insert error"))
 
 		// when
@@ -207,4 +220,25 @@ func TestSensorService_HandleMessage(t *testing.T) {
 		assert.Nil(t, sensorData)
 		assert.Contains(t, err.Error(), "insert error")
 	})
+	t.Run("should reject data of decommissioned sensor", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		testPayLoad := TestListMQTTPayload[0]
+		decommissioned := *TestSensor
+		decommissioned.DecommissionedAt = utils.P(time.Now())
+
+		sensorRepo.EXPECT().GetByID(context.Background(), testPayLoad.Device).Return(&decommissioned, nil)
+
+		// when
+		sensorData, err := svc.HandleMessage(context.Background(), testPayLoad)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		assert.Nil(t, sensorData)
+		sensorRepo.AssertNotCalled(t, "InsertSensorData")
+	})
 }
@@ -0,0 +1,88 @@
+package sensor
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const (
+	defaultRawDataRetention    = 90 * 24 * time.Hour
+	defaultHourlyDataRetention = 2 * 365 * 24 * time.Hour
+)
+
+type DataRetention struct {
+	sensorRepo storage.SensorRepository
+	rawData    time.Duration
+	hourlyData time.Duration
+}
+
+// NewDataRetention creates a retention job that rolls raw sensor data into hourly and daily aggregates
+// once it is older than the raw data retention and deletes it afterwards. Hourly aggregates are kept
+// at least as long as the raw data.
+func NewDataRetention(sensorRepo storage.SensorRepository, cfg config.SensorRetentionConfig) *DataRetention {
+	rawData := cfg.RawData
+	if rawData <= 0 {
+		rawData = defaultRawDataRetention
+	}
+
+	hourlyData := cfg.HourlyData
+	if hourlyData <= 0 {
+		hourlyData = defaultHourlyDataRetention
+	}
+
+	return &DataRetention{
+		sensorRepo: sensorRepo,
+		rawData:    rawData,
+		hourlyData: max(hourlyData, rawData),
+	}
+}
+
+func (d *DataRetention) RunDataRetention(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := d.applyRetention(ctx, time.Now())
+			if err != nil {
+				log.Error("failure to apply sensor data retention", "error", err.Error())
+			}
+		case <-ctx.Done():
+			log.Info("stopping sensor data retention")
+			return
+		}
+	}
+}
+
+func (d *DataRetention) applyRetention(ctx context.Context, now time.Time) error {
+	log := logger.GetLogger(ctx)
+
+	// only complete days are rolled up, so the raw data of a bucket is never split between two runs
+	rawCutoff := now.UTC().Add(-d.rawData).Truncate(24 * time.Hour)
+	pruned, err := d.sensorRepo.RollupSensorData(ctx, rawCutoff)
+	if err != nil {
+		return err
+	}
+
+	if pruned > 0 {
+		log.Info("rolled up and deleted raw sensor data", "before", rawCutoff, "deleted_rows", pruned)
+	}
+
+	hourlyCutoff := now.UTC().Add(-d.hourlyData).Truncate(24 * time.Hour)
+	pruned, err = d.sensorRepo.PruneHourlySensorData(ctx, hourlyCutoff)
+	if err != nil {
+		return err
+	}
+
+	if pruned > 0 {
+		log.Info("deleted hourly sensor data aggregates", "before", hourlyCutoff, "deleted_rows", pruned)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewDataRetention(t *testing.T) {
+	t.Run("should use default retention when not configured", func(t *testing.T) {
+		// when
+		retention := NewDataRetention(storageMock.NewMockSensorRepository(t), config.SensorRetentionConfig{})
+
+		// then
+		assert.Equal(t, defaultRawDataRetention, retention.rawData)
+		assert.Equal(t, defaultHourlyDataRetention, retention.hourlyData)
+	})
+
+	t.Run("should keep hourly aggregates at least as long as raw data", func(t *testing.T) {
+		// when
+		retention := NewDataRetention(storageMock.NewMockSensorRepository(t), config.SensorRetentionConfig{
+			RawData:    30 * 24 * time.Hour,
+			HourlyData: 7 * 24 * time.Hour,
+		})
+
+		// then
+		assert.Equal(t, 30*24*time.Hour, retention.rawData)
+		assert.Equal(t, 30*24*time.Hour, retention.hourlyData)
+	})
+}
+
+func TestDataRetention_applyRetention(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	t.Run("should roll up raw data and prune hourly aggregates of complete days", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		retention := NewDataRetention(sensorRepo, config.SensorRetentionConfig{
+			RawData:    7 * 24 * time.Hour,
+			HourlyData: 30 * 24 * time.Hour,
+		})
+
+		sensorRepo.EXPECT().RollupSensorData(mock.Anything, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)).Return(int64(42), nil)
+		sensorRepo.EXPECT().PruneHourlySensorData(mock.Anything, time.Date(2025, 2, 8, 0, 0, 0, 0, time.UTC)).Return(int64(0), nil)
+
+		// when
+		err := retention.applyRetention(context.Background(), now)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not prune hourly aggregates when roll up fails", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		retention := NewDataRetention(sensorRepo, config.SensorRetentionConfig{})
+
+		sensorRepo.EXPECT().RollupSensorData(mock.Anything, mock.Anything).Return(int64(0), errors.New("rollup failed"))
+
+		// when
+		err := retention.applyRetention(context.Background(), now)
+
+		// then
+		assert.EqualError(t, err, "rollup failed")
+		sensorRepo.AssertNotCalled(t, "PruneHourlySensorData", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDataRetention_RunDataRetention(t *testing.T) {
+	t.Run("should apply retention periodically", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		retention := NewDataRetention(sensorRepo, config.SensorRetentionConfig{})
+
+		sensorRepo.EXPECT().RollupSensorData(mock.Anything, mock.Anything).Return(int64(0), nil)
+		sensorRepo.EXPECT().PruneHourlySensorData(mock.Anything, mock.Anything).Return(int64(0), nil)
+
+		go func() {
+			retention.RunDataRetention(ctx, 10*time.Millisecond)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+
+		sensorRepo.AssertCalled(t, "RollupSensorData", mock.Anything, mock.Anything)
+		sensorRepo.AssertCalled(t, "PruneHourlySensorData", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should stop when context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		retention := NewDataRetention(sensorRepo, config.SensorRetentionConfig{})
+
+		go func() {
+			retention.RunDataRetention(ctx, 10*time.Millisecond)
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+
+		sensorRepo.AssertNotCalled(t, "RollupSensorData", mock.Anything, mock.Anything)
+	})
+}
@@ -7,11 +7,12 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
-	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 )
 
@@ -21,22 +22,48 @@ type SensorService struct {
 	flowerbedRepo storage.FlowerbedRepository
 	validator     *validator.Validate
 	StatusUpdater *StatusUpdater
+	DataRetention *DataRetention
 	eventManager  *worker.EventManager
+	batteryCfg    config.SensorBatteryConfig
+	driftCfg      config.SensorLocationDriftConfig
+	anomalyCfg    config.SensorAnomalyConfig
+	rules         *svcUtils.WateringRules
 }
 
 func NewSensorService(
 	sensorRepo storage.SensorRepository,
 	treeRepo storage.TreeRepository,
 	flowerbedRepo storage.FlowerbedRepository,
+	wateringRuleRepo storage.WateringRuleRepository,
 	eventManager *worker.EventManager,
+	cfg *config.SensorConfig,
+	wateringCfg *config.WateringStatusConfig,
 ) service.SensorService {
+	var batteryCfg config.SensorBatteryConfig
+	var retentionCfg config.SensorRetentionConfig
+	var driftCfg config.SensorLocationDriftConfig
+	var anomalyCfg config.SensorAnomalyConfig
+	var offlineThreshold time.Duration
+	if cfg != nil {
+		batteryCfg = cfg.Battery
+		retentionCfg = cfg.Retention
+		driftCfg = cfg.LocationDrift
+		anomalyCfg = cfg.Anomaly
+		offlineThreshold = cfg.OfflineThreshold
+	}
+
 	return &SensorService{
 		sensorRepo:    sensorRepo,
 		treeRepo:      treeRepo,
 		flowerbedRepo: flowerbedRepo,
 		validator:     validator.New(),
-		StatusUpdater: &StatusUpdater{sensorRepo: sensorRepo},
+		StatusUpdater: NewStatusUpdater(sensorRepo, eventManager, offlineThreshold),
+		DataRetention: NewDataRetention(sensorRepo, retentionCfg),
 		eventManager:  eventManager,
+		batteryCfg:    batteryCfg,
+		driftCfg:      driftCfg,
+		anomalyCfg:    anomalyCfg,
+		rules:         svcUtils.NewWateringRules(wateringRuleRepo, wateringCfg),
 	}
 }
 
@@ -49,6 +76,19 @@ func (s *SensorService) publishNewSensorDataEvent(ctx context.Context, data *ent
 	}
 }
 
+func (s *SensorService) publishCreateSensorEvent(ctx context.Context, newSensor *entities.Sensor) {
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeCreateSensor, "service", "SensorService")
+	event := entities.NewEventCreateSensor(newSensor)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after creating sensor", "err", err)
+	}
+}
+
+func (s *SensorService) publishSensorStatusEvent(ctx context.Context, sensorID string, prev, newStatus entities.SensorStatus, lastSeenAt *time.Time) {
+	s.StatusUpdater.publishStatusEvent(ctx, sensorID, prev, newStatus, lastSeenAt)
+}
+
 func (s *SensorService) GetAll(ctx context.Context) ([]*entities.Sensor, error) {
 	log := logger.GetLogger(ctx)
 	sensors, err := s.sensorRepo.GetAll(ctx)
@@ -79,8 +119,12 @@ func (s *SensorService) Create(ctx context.Context, sc *entities.SensorCreate) (
 	}
 
 	created, err := s.sensorRepo.Create(ctx, func(s *entities.Sensor) (bool, error) {
+		s.ID = sc.ID
 		s.LatestData = sc.LatestData
 		s.Status = sc.Status
+		s.Latitude = sc.Latitude
+		s.Longitude = sc.Longitude
+		s.OfflineThreshold = sc.OfflineThreshold
 		return true, nil
 	})
 
@@ -90,6 +134,7 @@ func (s *SensorService) Create(ctx context.Context, sc *entities.SensorCreate) (
 	}
 
 	log.Info("sensor created successfully", "sensor_id", created.ID)
+	s.publishCreateSensorEvent(ctx, created)
 	return created, nil
 }
 
@@ -106,8 +151,15 @@ func (s *SensorService) Update(ctx context.Context, id string, su *entities.Sens
 	}
 
 	updated, err := s.sensorRepo.Update(ctx, id, func(s *entities.Sensor) (bool, error) {
+		// relocating a sensor acknowledges a detected location drift
+		if s.Latitude != su.Latitude || s.Longitude != su.Longitude {
+			s.LocationDrift = nil
+		}
 		s.LatestData = su.LatestData
 		s.Status = su.Status
+		s.Latitude = su.Latitude
+		s.Longitude = su.Longitude
+		s.OfflineThreshold = su.OfflineThreshold
 		return true, nil
 	})
 
@@ -152,28 +204,8 @@ func (s *SensorService) RunStatusUpdater(ctx context.Context, interval time.Dura
 	s.StatusUpdater.RunStatusUpdater(ctx, interval)
 }
 
-// TODO: Not called by any function
-func (s *SensorService) MapSensorToTree(ctx context.Context, sen *entities.Sensor) error {
-	log := logger.GetLogger(ctx)
-	if sen == nil {
-		return errors.New("sensor cannot be nil")
-	}
-
-	nearestTree, err := s.treeRepo.FindNearestTree(ctx, sen.Latitude, sen.Longitude)
-	if err != nil {
-		log.Error("failed to calculate nearest tree", "sensor_id", sen.ID, "sensor_latitude", sen.Latitude, "sensor_longitude", sen.Longitude)
-		return err
-	}
-
-	if nearestTree != nil {
-		_, err = s.treeRepo.Update(ctx, nearestTree.ID, tree.WithSensor(sen))
-		if err != nil {
-			log.Error("failed to link sensor to nearest calculated tree", "tree_id", nearestTree.ID, "sensor_id", sen.ID, "error", err)
-			return err
-		}
-	}
-
-	return nil
+func (s *SensorService) RunDataRetention(ctx context.Context, interval time.Duration) {
+	s.DataRetention.RunDataRetention(ctx, interval)
 }
 
 func (s *SensorService) Ready() bool {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
@@ -19,7 +20,7 @@ func TestSensorService_GetAll(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		sensorRepo.EXPECT().GetAll(context.Background()).Return(TestSensorList, nil)
@@ -35,7 +36,7 @@ func TestSensorService_GetAll(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		sensorRepo.EXPECT().GetAll(context.Background()).Return(nil, storage.ErrSensorNotFound)
 		sensors, err := svc.GetAll(context.Background())
@@ -54,7 +55,7 @@ func TestSensorService_GetByID(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
 
@@ -72,7 +73,7 @@ func TestSensorService_GetByID(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		expectedErr := storage.ErrEntityNotFound("not found")
 		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(nil, expectedErr)
@@ -101,7 +102,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		sensorRepo.EXPECT().Create(context.Background(), mock.Anything).Return(TestSensor, nil)
 
@@ -118,7 +119,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		newSensor.LatestData = &entities.SensorData{}
 
@@ -137,7 +138,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		newSensor.Status = ""
 
@@ -155,7 +156,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		newSensor.Status = entities.SensorStatusOffline
 		newSensor.ID = ""
@@ -174,7 +175,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		newSensor.ID = "sensor-23"
 		newSensor.Status = entities.SensorStatusOffline
@@ -195,7 +196,7 @@ func TestSensorService_Create(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		expectedErr := errors.New("Failed to create sensor")
 
 		newSensor.ID = "sensor-23"
@@ -229,7 +230,7 @@ func TestSensorService_Update(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
 
@@ -243,13 +244,46 @@ func TestSensorService_Update(t *testing.T) {
 		assert.Equal(t, TestSensor, result)
 	})
 
+	t.Run("should clear location drift when sensor is relocated", func(t *testing.T) {
+		// given
+		id := "sensor-1"
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
+
+		driftedSensor := *TestSensor
+		driftedSensor.LocationDrift = &entities.SensorLocationDrift{
+			Latitude:   updateSensor.Latitude,
+			Longitude:  updateSensor.Longitude,
+			Distance:   5000,
+			DetectedAt: time.Now(),
+		}
+
+		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(&driftedSensor, nil)
+		sensorRepo.EXPECT().Update(context.Background(), id, mock.Anything).RunAndReturn(func(_ context.Context, _ string, fn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error) {
+			updated := driftedSensor
+			_, err := fn(&updated)
+			return &updated, err
+		})
+
+		// when
+		result, err := svc.Update(context.Background(), id, updateSensor)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, result.LocationDrift)
+		assert.Equal(t, updateSensor.Latitude, result.Latitude)
+		assert.Equal(t, updateSensor.Longitude, result.Longitude)
+	})
+
 	t.Run("should return an error when sensor ID does not exist", func(t *testing.T) {
 		// given
 		id := "notFoundID"
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		expectedErr := errors.New("failed to update cluster")
 
 		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(nil, expectedErr)
@@ -269,7 +303,7 @@ func TestSensorService_Update(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		expectedErr := errors.New("failed to update cluster")
 
 		sensorRepo.EXPECT().GetByID(context.Background(), id).Return(TestSensor, nil)
@@ -291,7 +325,7 @@ func TestSensorService_Update(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		updateSensor.Latitude = 200
 		updateSensor.Longitude = 200
@@ -315,7 +349,7 @@ func TestSensorService_Delete(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		sensorRepo.EXPECT().GetByID(ctx, id).Return(TestSensor, nil)
 		treeRepo.EXPECT().UnlinkSensorID(ctx, id).Return(nil)
@@ -335,7 +369,7 @@ func TestSensorService_Delete(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		expectedErr := storage.ErrEntityNotFound("not found")
 		sensorRepo.EXPECT().GetByID(ctx, id).Return(nil, expectedErr)
@@ -354,7 +388,7 @@ func TestSensorService_Delete(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		expectedErr := errors.New("failed to unlink")
 
@@ -375,7 +409,7 @@ func TestSensorService_Delete(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		expectedErr := errors.New("failed to unlink")
 
 		sensorRepo.EXPECT().GetByID(ctx, id).Return(TestSensor, nil)
@@ -396,7 +430,7 @@ func TestSensorService_Delete(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 		expectedErr := errors.New("failed to delete")
 
 		sensorRepo.EXPECT().GetByID(ctx, id).Return(TestSensor, nil)
@@ -413,102 +447,13 @@ func TestSensorService_Delete(t *testing.T) {
 	})
 }
 
-func TestSensorService_MapSensorToTree(t *testing.T) {
-	t.Run("should successfully map sensor to the nearest tree", func(t *testing.T) {
-		// given
-		sensorRepo := storageMock.NewMockSensorRepository(t)
-		treeRepo := storageMock.NewMockTreeRepository(t)
-		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
-
-		testSensor := TestSensorNearestTree
-		testTree := TestNearestTree
-
-		treeRepo.EXPECT().
-			FindNearestTree(context.Background(), mock.Anything, mock.Anything).
-			Return(testTree, nil)
-
-		treeRepo.EXPECT().
-			Update(context.Background(), testTree.ID, mock.Anything).
-			Return(testTree, nil)
-
-		// when
-		err := svc.MapSensorToTree(context.Background(), testSensor)
-
-		// then
-		assert.NoError(t, err)
-	})
-
-	t.Run("should return error if sensor is nil", func(t *testing.T) {
-		// given
-		sensorRepo := storageMock.NewMockSensorRepository(t)
-		treeRepo := storageMock.NewMockTreeRepository(t)
-		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
-
-		// when
-		err := svc.MapSensorToTree(context.Background(), nil)
-
-		// then
-		assert.Error(t, err)
-		// assert.EqualError(t, err, "sensor cannot be nil")
-	})
-
-	t.Run("should return error if nearest tree is not found", func(t *testing.T) {
-		// given
-		sensorRepo := storageMock.NewMockSensorRepository(t)
-		treeRepo := storageMock.NewMockTreeRepository(t)
-		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
-
-		testSensor := TestSensorNearestTree
-
-		treeRepo.EXPECT().
-			FindNearestTree(context.Background(), testSensor.Latitude, testSensor.Longitude).
-			Return(nil, errors.New("tree not found"))
-
-		// when
-		err := svc.MapSensorToTree(context.Background(), testSensor)
-
-		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "tree not found")
-	})
-
-	t.Run("should return error if updating tree with sensor fails", func(t *testing.T) {
-		// given
-		sensorRepo := storageMock.NewMockSensorRepository(t)
-		treeRepo := storageMock.NewMockTreeRepository(t)
-		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(sensorRepo, treeRepo, flowerbedRepo, globalEventManager)
-
-		testSensor := TestSensorNearestTree
-		testTree := TestNearestTree
-
-		treeRepo.EXPECT().
-			FindNearestTree(context.Background(), mock.Anything, mock.Anything).
-			Return(testTree, nil)
-
-		treeRepo.EXPECT().
-			Update(context.Background(), testTree.ID, mock.Anything).
-			Return(nil, errors.New("update failed"))
-
-		// when
-		err := svc.MapSensorToTree(context.Background(), testSensor)
-
-		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "update failed")
-	})
-}
-
 func TestReady(t *testing.T) {
 	t.Run("should return true if the service is ready", func(t *testing.T) {
 		// given
 		repo := storageMock.NewMockSensorRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		flowerbedRepo := storageMock.NewMockFlowerbedRepository(t)
-		svc := sensor.NewSensorService(repo, treeRepo, flowerbedRepo, globalEventManager)
+		svc := sensor.NewSensorService(repo, treeRepo, flowerbedRepo, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		ready := svc.Ready()
@@ -519,7 +464,7 @@ func TestReady(t *testing.T) {
 
 	t.Run("should return false if the service is not ready", func(t *testing.T) {
 		// give
-		svc := sensor.NewSensorService(nil, nil, nil, globalEventManager)
+		svc := sensor.NewSensorService(nil, nil, nil, nil, globalEventManager, globalSensorConfig, nil)
 
 		// when
 		ready := svc.Ready()
@@ -7,15 +7,28 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 )
 
+const defaultOfflineThreshold = 72 * time.Hour
+
 type StatusUpdater struct {
-	sensorRepo storage.SensorRepository
+	sensorRepo       storage.SensorRepository
+	eventManager     *worker.EventManager
+	offlineThreshold time.Duration
 }
 
-func NewStatusUpdater(sensorRepo storage.SensorRepository) *StatusUpdater {
+// NewStatusUpdater creates a status updater that marks sensors as offline when no data was received
+// within the offline threshold. A sensor specific threshold takes precedence over the given one.
+func NewStatusUpdater(sensorRepo storage.SensorRepository, eventManager *worker.EventManager, offlineThreshold time.Duration) *StatusUpdater {
+	if offlineThreshold <= 0 {
+		offlineThreshold = defaultOfflineThreshold
+	}
+
 	return &StatusUpdater{
-		sensorRepo: sensorRepo,
+		sensorRepo:       sensorRepo,
+		eventManager:     eventManager,
+		offlineThreshold: offlineThreshold,
 	}
 }
 
@@ -27,7 +40,7 @@ func (s *StatusUpdater) RunStatusUpdater(ctx context.Context, interval time.Dura
 	for {
 		select {
 		case <-ticker.C:
-			err := s.updateStaleSensorStatuses(ctx)
+			err := s.updateSensorStatuses(ctx)
 			if err != nil {
 				log.Error("failure to update sensor status", "error", err.Error())
 			}
@@ -38,27 +51,59 @@ func (s *StatusUpdater) RunStatusUpdater(ctx context.Context, interval time.Dura
 	}
 }
 
-func (s *StatusUpdater) updateStaleSensorStatuses(ctx context.Context) error {
+func (s *StatusUpdater) updateSensorStatuses(ctx context.Context) error {
 	log := logger.GetLogger(ctx)
-	sensors, err := s.sensorRepo.GetAll(ctx)
+	activities, err := s.sensorRepo.GetLastSeen(ctx)
 	if err != nil {
 		return err
 	}
 
-	cutoffTime := time.Now().Add(-72 * time.Hour) // 3 days ago
-	for _, sens := range sensors {
-		if sens.UpdatedAt.Before(cutoffTime) {
-			_, err = s.sensorRepo.Update(ctx, sens.ID, func(s *entities.Sensor) (bool, error) {
-				s.Status = entities.SensorStatusOffline
-				return true, nil
-			})
-			if err != nil {
-				log.Error("failed to update sensor status to offline", "sensor_id", sens.ID, "error", err, "prev_sensor_status", sens.Status)
-			} else {
-				log.Info("sensor marked as offline due to inactivity", "sensor_id", sens.ID, "prev_sensor_status", sens.Status)
-			}
+	now := time.Now()
+	for _, activity := range activities {
+		// sensors that never sent data keep their status
+		if activity.LastSeenAt == nil {
+			continue
 		}
+
+		newStatus := s.statusOf(activity, now)
+		if newStatus == activity.Status {
+			continue
+		}
+
+		if err := s.sensorRepo.UpdateStatus(ctx, activity.SensorID, newStatus); err != nil {
+			log.Error("failed to update sensor status", "sensor_id", activity.SensorID, "error", err, "prev_sensor_status", activity.Status, "new_sensor_status", newStatus)
+			continue
+		}
+
+		log.Info("sensor status changed due to activity", "sensor_id", activity.SensorID, "prev_sensor_status", activity.Status, "new_sensor_status", newStatus, "last_seen_at", activity.LastSeenAt)
+		s.publishStatusEvent(ctx, activity.SensorID, activity.Status, newStatus, activity.LastSeenAt)
 	}
 
 	return nil
 }
+
+func (s *StatusUpdater) statusOf(activity *entities.SensorActivity, now time.Time) entities.SensorStatus {
+	threshold := s.offlineThreshold
+	if activity.OfflineThreshold != nil && *activity.OfflineThreshold > 0 {
+		threshold = *activity.OfflineThreshold
+	}
+
+	if now.Sub(*activity.LastSeenAt) > threshold {
+		return entities.SensorStatusOffline
+	}
+
+	return entities.SensorStatusOnline
+}
+
+func (s *StatusUpdater) publishStatusEvent(ctx context.Context, sensorID string, prev, newStatus entities.SensorStatus, lastSeenAt *time.Time) {
+	if s.eventManager == nil {
+		return
+	}
+
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeUpdateSensorStatus, "service", "SensorService")
+	event := entities.NewEventUpdateSensorStatus(sensorID, prev, newStatus, lastSeenAt)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after sensor status changed", "err", err)
+	}
+}
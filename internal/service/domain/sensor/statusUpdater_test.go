@@ -8,26 +8,32 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestSensorService_RunStatusUpdater(t *testing.T) {
-	t.Run("should update stale sensor statuses periodically", func(t *testing.T) {
-		ctx := context.Background()
+	t.Run("should update sensor statuses periodically", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 		sensorRepo := storageMock.NewMockSensorRepository(t)
-		svc := NewStatusUpdater(sensorRepo)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
 
-		staleSensor := &entities.Sensor{
-			ID:        "sensor-1",
-			UpdatedAt: time.Now().Add(-73 * time.Hour), // 73 hours ago
+		staleSensor := &entities.SensorActivity{
+			SensorID:   "sensor-1",
+			Status:     entities.SensorStatusOnline,
+			LastSeenAt: utils.P(time.Now().Add(-73 * time.Hour)), // 73 hours ago
 		}
-		recentSensor := &entities.Sensor{
-			ID:        "sensor-2",
-			UpdatedAt: time.Now().Add(-1 * time.Hour), // 1 hour ago
+		recentSensor := &entities.SensorActivity{
+			SensorID:   "sensor-2",
+			Status:     entities.SensorStatusOnline,
+			LastSeenAt: utils.P(time.Now().Add(-1 * time.Hour)), // 1 hour ago
 		}
 
-		sensorRepo.EXPECT().GetAll(mock.Anything).Return([]*entities.Sensor{staleSensor, recentSensor}, nil)
-		sensorRepo.EXPECT().Update(mock.Anything, staleSensor.ID, mock.Anything).Return(staleSensor, nil)
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{staleSensor, recentSensor}, nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, staleSensor.SensorID, entities.SensorStatusOffline).Return(nil)
 
 		go func() {
 			svc.RunStatusUpdater(ctx, 10*time.Millisecond)
@@ -35,36 +41,38 @@ func TestSensorService_RunStatusUpdater(t *testing.T) {
 
 		time.Sleep(100 * time.Millisecond)
 
-		sensorRepo.AssertCalled(t, "GetAll", mock.Anything)
-		sensorRepo.AssertCalled(t, "Update", mock.Anything, staleSensor.ID, mock.Anything)
-		sensorRepo.AssertExpectations(t) // Verifies all expectations are met
+		sensorRepo.AssertCalled(t, "GetLastSeen", mock.Anything)
+		sensorRepo.AssertCalled(t, "UpdateStatus", mock.Anything, staleSensor.SensorID, entities.SensorStatusOffline)
+		sensorRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, recentSensor.SensorID, mock.Anything)
+		sensorRepo.AssertExpectations(t)
 	})
 
 	t.Run("should stop updating when context is canceled", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 		sensorRepo := storageMock.NewMockSensorRepository(t)
-		svc := NewStatusUpdater(sensorRepo)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
 
-		// No GetAll or Update expected, since context will be canceled early
+		// No GetLastSeen or UpdateStatus expected, since context will be canceled early
 		go func() {
 			svc.RunStatusUpdater(ctx, 10*time.Millisecond)
 		}()
 
 		time.Sleep(20 * time.Millisecond)
 
-		sensorRepo.AssertNotCalled(t, "GetAll")
-		sensorRepo.AssertNotCalled(t, "Update")
+		sensorRepo.AssertNotCalled(t, "GetLastSeen")
+		sensorRepo.AssertNotCalled(t, "UpdateStatus")
 		sensorRepo.AssertExpectations(t)
 	})
 
-	t.Run("should handle error from GetAll", func(t *testing.T) {
+	t.Run("should handle error from GetLastSeen", func(t *testing.T) {
 		// given
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 		sensorRepo := storageMock.NewMockSensorRepository(t)
-		svc := NewStatusUpdater(sensorRepo)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
 
-		sensorRepo.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("db error"))
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return(nil, errors.New("db error"))
 
 		go func() {
 			svc.RunStatusUpdater(ctx, 10*time.Millisecond) // Run every 10ms
@@ -72,33 +80,146 @@ func TestSensorService_RunStatusUpdater(t *testing.T) {
 
 		time.Sleep(50 * time.Millisecond)
 
-		sensorRepo.AssertCalled(t, "GetAll", mock.Anything)
-		sensorRepo.AssertNotCalled(t, "Update")
+		sensorRepo.AssertCalled(t, "GetLastSeen", mock.Anything)
+		sensorRepo.AssertNotCalled(t, "UpdateStatus")
 		sensorRepo.AssertExpectations(t)
 	})
+}
 
-	t.Run("should log error when sensor update fails", func(t *testing.T) {
+func TestStatusUpdater_UpdateSensorStatuses(t *testing.T) {
+	t.Run("should mark stale sensor as offline and publish event", func(t *testing.T) {
 		// given
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 		sensorRepo := storageMock.NewMockSensorRepository(t)
-		svc := NewStatusUpdater(sensorRepo)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateSensorStatus)
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateSensorStatus)
+		go eventManager.Run(ctx)
+		svc := NewStatusUpdater(sensorRepo, eventManager, 72*time.Hour)
+
+		lastSeen := time.Now().Add(-73 * time.Hour)
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{
+			{SensorID: "sensor-1", Status: entities.SensorStatusOnline, LastSeenAt: &lastSeen},
+		}, nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-1", entities.SensorStatusOffline).Return(nil)
+
+		// when
+		err := svc.updateSensorStatuses(ctx)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case recievedEvent := <-ch:
+			e, ok := recievedEvent.(entities.EventUpdateSensorStatus)
+			assert.True(t, ok)
+			assert.Equal(t, "sensor-1", e.SensorID)
+			assert.Equal(t, entities.SensorStatusOnline, e.Prev)
+			assert.Equal(t, entities.SensorStatusOffline, e.New)
+			assert.Equal(t, lastSeen, *e.LastSeenAt)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
+		}
+	})
 
-		staleSensor := &entities.Sensor{
-			ID:        "sensor-1",
-			UpdatedAt: time.Now().Add(-73 * time.Hour), // 73 hours ago
+	t.Run("should mark offline sensor with recent data as online", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateSensorStatus)
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateSensorStatus)
+		go eventManager.Run(ctx)
+		svc := NewStatusUpdater(sensorRepo, eventManager, 72*time.Hour)
+
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{
+			{SensorID: "sensor-1", Status: entities.SensorStatusOffline, LastSeenAt: utils.P(time.Now().Add(-1 * time.Hour))},
+		}, nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-1", entities.SensorStatusOnline).Return(nil)
+
+		// when
+		err := svc.updateSensorStatuses(ctx)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case recievedEvent := <-ch:
+			e, ok := recievedEvent.(entities.EventUpdateSensorStatus)
+			assert.True(t, ok)
+			assert.Equal(t, entities.SensorStatusOffline, e.Prev)
+			assert.Equal(t, entities.SensorStatusOnline, e.New)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
 		}
+	})
 
-		sensorRepo.EXPECT().GetAll(mock.Anything).Return([]*entities.Sensor{staleSensor}, nil)
-		sensorRepo.EXPECT().Update(mock.Anything, staleSensor.ID, mock.Anything).Return(nil, errors.New("update failed"))
+	t.Run("should use offline threshold of sensor over global threshold", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
 
-		go func() {
-			svc.RunStatusUpdater(ctx, 10*time.Millisecond)
-		}()
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{
+			{SensorID: "sensor-1", Status: entities.SensorStatusOnline, OfflineThreshold: utils.P(2 * time.Hour), LastSeenAt: utils.P(time.Now().Add(-3 * time.Hour))},
+			{SensorID: "sensor-2", Status: entities.SensorStatusOffline, OfflineThreshold: utils.P(96 * time.Hour), LastSeenAt: utils.P(time.Now().Add(-80 * time.Hour))},
+		}, nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-1", entities.SensorStatusOffline).Return(nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-2", entities.SensorStatusOnline).Return(nil)
 
-		time.Sleep(50 * time.Millisecond)
+		// when
+		err := svc.updateSensorStatuses(ctx)
 
-		sensorRepo.AssertCalled(t, "GetAll", mock.Anything)
-		sensorRepo.AssertCalled(t, "Update", mock.Anything, staleSensor.ID, mock.Anything)
-		sensorRepo.AssertExpectations(t)
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not update sensors without data or unchanged status", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
+
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{
+			{SensorID: "sensor-1", Status: entities.SensorStatusUnknown},
+			{SensorID: "sensor-2", Status: entities.SensorStatusOnline, LastSeenAt: utils.P(time.Now().Add(-1 * time.Hour))},
+			{SensorID: "sensor-3", Status: entities.SensorStatusOffline, LastSeenAt: utils.P(time.Now().Add(-100 * time.Hour))},
+		}, nil)
+
+		// when
+		err := svc.updateSensorStatuses(ctx)
+
+		// then
+		assert.NoError(t, err)
+		sensorRepo.AssertNotCalled(t, "UpdateStatus")
+	})
+
+	t.Run("should use default threshold when threshold is not set", func(t *testing.T) {
+		// given
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+
+		// when
+		svc := NewStatusUpdater(sensorRepo, nil, 0)
+
+		// then
+		assert.Equal(t, defaultOfflineThreshold, svc.offlineThreshold)
+	})
+
+	t.Run("should continue when sensor status update fails", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		svc := NewStatusUpdater(sensorRepo, nil, 72*time.Hour)
+
+		sensorRepo.EXPECT().GetLastSeen(mock.Anything).Return([]*entities.SensorActivity{
+			{SensorID: "sensor-1", Status: entities.SensorStatusOnline, LastSeenAt: utils.P(time.Now().Add(-73 * time.Hour))},
+			{SensorID: "sensor-2", Status: entities.SensorStatusOnline, LastSeenAt: utils.P(time.Now().Add(-73 * time.Hour))},
+		}, nil)
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-1", entities.SensorStatusOffline).Return(errors.New("update failed"))
+		sensorRepo.EXPECT().UpdateStatus(mock.Anything, "sensor-2", entities.SensorStatusOffline).Return(nil)
+
+		// when
+		err := svc.updateSensorStatuses(ctx)
+
+		// then
+		assert.NoError(t, err)
 	})
 }
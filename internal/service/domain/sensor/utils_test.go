@@ -138,27 +138,4 @@ var (
 			LatestData: &domain.SensorData{},
 		},
 	}
-
-	TestSensorNearestTree = &domain.Sensor{
-		ID:         "sensor-05",
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
-		Latitude:   54.821535,
-		Longitude:  9.487200,
-		Status:     domain.SensorStatusOnline,
-		LatestData: TestSensorData[0],
-	}
-
-	TestNearestTree = &domain.Tree{
-		ID:           5,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-		Species:      "Oak",
-		Number:       "T001",
-		Latitude:     54.8215076622281,
-		Longitude:    9.487153277881877,
-		Description:  "A mature oak tree",
-		PlantingYear: 2023,
-		Readonly:     true,
-	}
 )
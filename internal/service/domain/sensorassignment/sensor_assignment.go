@@ -0,0 +1,250 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
+)
+
+const (
+	defaultRadius          = 3.0
+	defaultAmbiguityMargin = 1.0
+	maxCandidates          = 5
+)
+
+type SensorAssignmentService struct {
+	assignmentRepo  storage.SensorAssignmentRepository
+	treeRepo        storage.TreeRepository
+	sensorRepo      storage.SensorRepository
+	validator       *validator.Validate
+	radius          float64
+	ambiguityMargin float64
+}
+
+func NewSensorAssignmentService(
+	assignmentRepo storage.SensorAssignmentRepository,
+	treeRepo storage.TreeRepository,
+	sensorRepo storage.SensorRepository,
+	cfg *config.SensorAssignmentConfig,
+) service.SensorAssignmentService {
+	radius := defaultRadius
+	ambiguityMargin := defaultAmbiguityMargin
+	if cfg != nil {
+		if cfg.Radius > 0 {
+			radius = cfg.Radius
+		}
+		if cfg.AmbiguityMargin > 0 {
+			ambiguityMargin = cfg.AmbiguityMargin
+		}
+	}
+
+	return &SensorAssignmentService{
+		assignmentRepo:  assignmentRepo,
+		treeRepo:        treeRepo,
+		sensorRepo:      sensorRepo,
+		validator:       validator.New(),
+		radius:          radius,
+		ambiguityMargin: ambiguityMargin,
+	}
+}
+
+func (s *SensorAssignmentService) GetAll(ctx context.Context) ([]*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	reviews, err := s.assignmentRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch sensor assignment reviews", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return reviews, nil
+}
+
+func (s *SensorAssignmentService) GetAllByStatus(ctx context.Context, status entities.SensorAssignmentStatus) ([]*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	reviews, err := s.assignmentRepo.GetAllByStatus(ctx, status)
+	if err != nil {
+		log.Debug("failed to fetch sensor assignment reviews by status", "error", err, "review_status", status)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return reviews, nil
+}
+
+func (s *SensorAssignmentService) GetByID(ctx context.Context, id int32) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	got, err := s.assignmentRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor assignment review by id", "error", err, "review_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return got, nil
+}
+
+// Resolve links the sensor of a pending review to the chosen tree and closes the review. Both happen in one
+// transaction, the checks before only return early with a clear error.
+func (s *SensorAssignmentService) Resolve(ctx context.Context, id int32, resolve *entities.SensorAssignmentResolve) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(resolve); err != nil {
+		log.Debug("failed to validate sensor assignment resolve struct", "error", err, "raw_resolve", fmt.Sprintf("%+v", resolve))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	review, err := s.getPendingReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sensor, err := s.sensorRepo.GetByID(ctx, review.SensorID)
+	if err != nil {
+		log.Debug("failed to fetch sensor of sensor assignment review", "error", err, "review_id", id, "sensor_id", review.SensorID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if sensor.DecommissionedAt != nil {
+		log.Debug("sensor of sensor assignment review is decommissioned", "review_id", id, "sensor_id", sensor.ID)
+		return nil, service.ErrSensorDecommissioned
+	}
+
+	chosenTree, err := s.treeRepo.GetByID(ctx, resolve.TreeID)
+	if err != nil {
+		log.Debug("failed to fetch chosen tree of sensor assignment review", "error", err, "review_id", id, "tree_id", resolve.TreeID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if chosenTree.Sensor != nil {
+		log.Debug("chosen tree is already linked to a sensor", "review_id", id, "tree_id", chosenTree.ID, "linked_sensor_id", chosenTree.Sensor.ID)
+		return nil, service.ErrTreeHasSensor
+	}
+
+	updated, err := s.assignmentRepo.Resolve(ctx, id, chosenTree.ID)
+	if err != nil {
+		log.Debug("failed to resolve sensor assignment review", "error", err, "review_id", id, "tree_id", chosenTree.ID, "sensor_id", sensor.ID)
+		if errors.Is(err, storage.ErrSensorAssignmentClosed) {
+			return nil, service.ErrSensorAssignmentClosed
+		}
+		if errors.Is(err, storage.ErrTreeHasSensor) {
+			return nil, service.ErrTreeHasSensor
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor assignment review resolved", "review_id", id, "sensor_id", sensor.ID, "tree_id", chosenTree.ID)
+	return updated, nil
+}
+
+// Dismiss closes a pending review without linking the sensor to a tree.
+func (s *SensorAssignmentService) Dismiss(ctx context.Context, id int32) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.getPendingReview(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.assignmentRepo.Update(ctx, id, func(r *entities.SensorAssignmentReview) (bool, error) {
+		r.Status = entities.SensorAssignmentStatusDismissed
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update sensor assignment review", "error", err, "review_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor assignment review dismissed", "review_id", id, "sensor_id", updated.SensorID)
+	return updated, nil
+}
+
+func (s *SensorAssignmentService) getPendingReview(ctx context.Context, id int32) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	review, err := s.assignmentRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor assignment review by id", "error", err, "review_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if review.Status != entities.SensorAssignmentStatusPending {
+		log.Debug("sensor assignment review is already closed", "review_id", id, "review_status", review.Status)
+		return nil, service.ErrSensorAssignmentClosed
+	}
+
+	return review, nil
+}
+
+// MapSensorToTree links the sensor to the nearest tree without a sensor within the configured radius.
+// If other trees are within the ambiguity margin of the nearest tree, a review is created instead.
+func (s *SensorAssignmentService) MapSensorToTree(ctx context.Context, sen *entities.Sensor) error {
+	log := logger.GetLogger(ctx)
+	if sen == nil {
+		return errors.New("sensor cannot be nil")
+	}
+
+	candidates, err := s.treeRepo.FindNearestUnassignedTrees(ctx, sen.Latitude, sen.Longitude, s.radius, maxCandidates)
+	if err != nil {
+		log.Error("failed to find nearest unassigned trees", "sensor_id", sen.ID, "sensor_latitude", sen.Latitude, "sensor_longitude", sen.Longitude, "error", err)
+		return err
+	}
+
+	if len(candidates) == 0 {
+		log.Info("no unassigned tree found near sensor", "sensor_id", sen.ID, "radius", s.radius)
+		return nil
+	}
+
+	ambiguous := s.ambiguousCandidates(candidates)
+	if len(ambiguous) > 1 {
+		review, err := s.assignmentRepo.Create(ctx, func(r *entities.SensorAssignmentReview) (bool, error) {
+			r.SensorID = sen.ID
+			r.Candidates = ambiguous
+			return true, nil
+		})
+		if err != nil {
+			log.Error("failed to create sensor assignment review", "sensor_id", sen.ID, "error", err)
+			return err
+		}
+
+		log.Info("sensor could not be assigned unambiguously, created review", "sensor_id", sen.ID, "review_id", review.ID, "candidates", len(ambiguous))
+		return nil
+	}
+
+	nearest := candidates[0]
+	if _, err := s.treeRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseSensorData), nearest.TreeID, tree.WithSensor(sen)); err != nil {
+		log.Error("failed to link sensor to nearest tree", "tree_id", nearest.TreeID, "sensor_id", sen.ID, "error", err)
+		return err
+	}
+
+	log.Info("sensor linked to nearest tree", "sensor_id", sen.ID, "tree_id", nearest.TreeID, "distance", nearest.Distance)
+	return nil
+}
+
+// ambiguousCandidates returns all candidates that are within the ambiguity margin of the nearest one.
+// The candidates have to be sorted by distance.
+func (s *SensorAssignmentService) ambiguousCandidates(candidates []*entities.TreeCandidate) []*entities.TreeCandidate {
+	limit := candidates[0].Distance + s.ambiguityMargin
+	for i, c := range candidates {
+		if c.Distance > limit {
+			return candidates[:i]
+		}
+	}
+
+	return candidates
+}
+
+func (s *SensorAssignmentService) HandleCreateSensor(ctx context.Context, event *entities.EventCreateSensor) error {
+	log := logger.GetLogger(ctx)
+	log.Debug("handle event", "event", event.Type(), "service", "SensorAssignmentService")
+	if event.New == nil {
+		return nil
+	}
+
+	return s.MapSensorToTree(ctx, event.New)
+}
+
+func (s *SensorAssignmentService) Ready() bool {
+	return s.assignmentRepo != nil && s.treeRepo != nil && s.sensorRepo != nil
+}
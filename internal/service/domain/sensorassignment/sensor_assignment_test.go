@@ -0,0 +1,513 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = &config.SensorAssignmentConfig{Radius: 3, AmbiguityMargin: 1}
+
+type testRepos struct {
+	assignmentRepo *storageMock.MockSensorAssignmentRepository
+	treeRepo       *storageMock.MockTreeRepository
+	sensorRepo     *storageMock.MockSensorRepository
+}
+
+func newTestService(t *testing.T) (*SensorAssignmentService, testRepos) {
+	repos := testRepos{
+		assignmentRepo: storageMock.NewMockSensorAssignmentRepository(t),
+		treeRepo:       storageMock.NewMockTreeRepository(t),
+		sensorRepo:     storageMock.NewMockSensorRepository(t),
+	}
+	svc := NewSensorAssignmentService(repos.assignmentRepo, repos.treeRepo, repos.sensorRepo, testConfig)
+	return svc.(*SensorAssignmentService), repos
+}
+
+func getTestReviews() []*entities.SensorAssignmentReview {
+	return []*entities.SensorAssignmentReview{
+		{
+			ID:       1,
+			SensorID: "sensor-1",
+			Status:   entities.SensorAssignmentStatusPending,
+			Candidates: []*entities.TreeCandidate{
+				{TreeID: 1, Distance: 0.5},
+				{TreeID: 2, Distance: 1.2},
+			},
+		},
+		{
+			ID:       2,
+			SensorID: "sensor-2",
+			Status:   entities.SensorAssignmentStatusResolved,
+			TreeID:   utils.P(int32(3)),
+			Candidates: []*entities.TreeCandidate{
+				{TreeID: 3, Distance: 0.1},
+				{TreeID: 4, Distance: 0.2},
+			},
+		},
+	}
+}
+
+var testSensor = &entities.Sensor{
+	ID:        "sensor-1",
+	Latitude:  54.82124518093376,
+	Longitude: 9.485702120628517,
+	Status:    entities.SensorStatusOnline,
+}
+
+func TestNewSensorAssignmentService(t *testing.T) {
+	t.Run("should use default radius and margin when not configured", func(t *testing.T) {
+		// when
+		svc := NewSensorAssignmentService(nil, nil, nil, &config.SensorAssignmentConfig{})
+
+		// then
+		assert.Equal(t, defaultRadius, svc.(*SensorAssignmentService).radius)
+		assert.Equal(t, defaultAmbiguityMargin, svc.(*SensorAssignmentService).ambiguityMargin)
+	})
+
+	t.Run("should use configured radius and margin", func(t *testing.T) {
+		// when
+		svc := NewSensorAssignmentService(nil, nil, nil, &config.SensorAssignmentConfig{Radius: 10, AmbiguityMargin: 2})
+
+		// then
+		assert.Equal(t, 10.0, svc.(*SensorAssignmentService).radius)
+		assert.Equal(t, 2.0, svc.(*SensorAssignmentService).ambiguityMargin)
+	})
+}
+
+func TestSensorAssignmentService_GetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return all reviews", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestReviews()
+		repos.assignmentRepo.EXPECT().GetAll(ctx).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetAll(ctx).Return(nil, errors.New("GetAll failed"))
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorAssignmentService_GetAllByStatus(t *testing.T) {
+	t.Run("should return reviews by status", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		svc, repos := newTestService(t)
+		expected := getTestReviews()[:1]
+		repos.assignmentRepo.EXPECT().GetAllByStatus(ctx, entities.SensorAssignmentStatusPending).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAllByStatus(ctx, entities.SensorAssignmentStatusPending)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestSensorAssignmentService_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return review by id", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestReviews()[0]
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when review does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByID(ctx, 99)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestSensorAssignmentService_Resolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should link sensor to chosen tree and resolve review", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		review := getTestReviews()[0]
+		chosenTree := &entities.Tree{ID: 2}
+		resolved := &entities.SensorAssignmentReview{ID: 1, SensorID: "sensor-1", Status: entities.SensorAssignmentStatusResolved, TreeID: utils.P(int32(2))}
+
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(review, nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(2)).Return(chosenTree, nil)
+		repos.assignmentRepo.EXPECT().Resolve(ctx, int32(1), int32(2)).Return(resolved, nil)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, resolved, got)
+	})
+
+	t.Run("should return validation error when tree id is missing", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return error when review is already closed", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(2)).Return(getTestReviews()[1], nil)
+
+		// when
+		got, err := svc.Resolve(ctx, 2, &entities.SensorAssignmentResolve{TreeID: 4})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorAssignmentClosed)
+	})
+
+	t.Run("should return error when chosen tree already has a sensor", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(2)).Return(&entities.Tree{ID: 2, Sensor: &entities.Sensor{ID: "sensor-9"}}, nil)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrTreeHasSensor)
+	})
+
+	t.Run("should return error when sensor is decommissioned", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(&entities.Sensor{ID: "sensor-1", DecommissionedAt: utils.P(time.Now())}, nil)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		repos.assignmentRepo.AssertNotCalled(t, "Resolve")
+	})
+
+	t.Run("should return not found error when tree does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 99})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+
+	t.Run("should return error when review was closed concurrently", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(2)).Return(&entities.Tree{ID: 2}, nil)
+		repos.assignmentRepo.EXPECT().Resolve(ctx, int32(1), int32(2)).Return(nil, storage.ErrSensorAssignmentClosed)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorAssignmentClosed)
+	})
+
+	t.Run("should return error when chosen tree was linked concurrently", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(2)).Return(&entities.Tree{ID: 2}, nil)
+		repos.assignmentRepo.EXPECT().Resolve(ctx, int32(1), int32(2)).Return(nil, storage.ErrTreeHasSensor)
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrTreeHasSensor)
+	})
+
+	t.Run("should return error when resolving review fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(2)).Return(&entities.Tree{ID: 2}, nil)
+		repos.assignmentRepo.EXPECT().Resolve(ctx, int32(1), int32(2)).Return(nil, errors.New("update failed"))
+
+		// when
+		got, err := svc.Resolve(ctx, 1, &entities.SensorAssignmentResolve{TreeID: 2})
+
+		// then
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "update failed")
+	})
+}
+
+func TestSensorAssignmentService_Dismiss(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should dismiss pending review", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		dismissed := &entities.SensorAssignmentReview{ID: 1, SensorID: "sensor-1", Status: entities.SensorAssignmentStatusDismissed}
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(1)).Return(getTestReviews()[0], nil)
+		repos.assignmentRepo.EXPECT().Update(ctx, int32(1), mock.Anything).Return(dismissed, nil)
+
+		// when
+		got, err := svc.Dismiss(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, dismissed, got)
+	})
+
+	t.Run("should return error when review is already closed", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.assignmentRepo.EXPECT().GetByID(ctx, int32(2)).Return(getTestReviews()[1], nil)
+
+		// when
+		got, err := svc.Dismiss(ctx, 2)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorAssignmentClosed)
+	})
+}
+
+func TestSensorAssignmentService_MapSensorToTree(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should link sensor to the only tree in radius", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, testSensor.Latitude, testSensor.Longitude, 3.0, int32(maxCandidates)).
+			Return([]*entities.TreeCandidate{{TreeID: 5, Distance: 0.8}}, nil)
+		repos.treeRepo.EXPECT().Update(mock.Anything, int32(5), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, _ ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
+			cause, ok := storage.WateringStatusCauseFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, entities.WateringStatusCauseSensorData, cause)
+			return &entities.Tree{ID: 5}, nil
+		})
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should link sensor to nearest tree when other trees are clearly farther away", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*entities.TreeCandidate{{TreeID: 5, Distance: 0.5}, {TreeID: 6, Distance: 2.5}}, nil)
+		repos.treeRepo.EXPECT().Update(mock.Anything, int32(5), mock.Anything).Return(&entities.Tree{ID: 5}, nil)
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should create review when several trees are almost equally close", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*entities.TreeCandidate{{TreeID: 5, Distance: 0.5}, {TreeID: 6, Distance: 1.2}, {TreeID: 7, Distance: 2.9}}, nil)
+
+		var created *entities.SensorAssignmentReview
+		repos.assignmentRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(*entities.SensorAssignmentReview) (bool, error)) (*entities.SensorAssignmentReview, error) {
+			created = &entities.SensorAssignmentReview{ID: 1, Status: entities.SensorAssignmentStatusPending}
+			_, err := fn(created)
+			return created, err
+		})
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", created.SensorID)
+		assert.Len(t, created.Candidates, 2)
+		assert.Equal(t, int32(5), created.Candidates[0].TreeID)
+		assert.Equal(t, int32(6), created.Candidates[1].TreeID)
+		repos.treeRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should do nothing when no tree is in radius", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*entities.TreeCandidate{}, nil)
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error if sensor is nil", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		err := svc.MapSensorToTree(ctx, nil)
+
+		// then
+		assert.EqualError(t, err, "sensor cannot be nil")
+	})
+
+	t.Run("should return error if finding trees fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("query failed"))
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.EqualError(t, err, "query failed")
+	})
+
+	t.Run("should return error if linking tree fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*entities.TreeCandidate{{TreeID: 5, Distance: 0.5}}, nil)
+		repos.treeRepo.EXPECT().Update(mock.Anything, int32(5), mock.Anything).Return(nil, errors.New("update failed"))
+
+		// when
+		err := svc.MapSensorToTree(ctx, testSensor)
+
+		// then
+		assert.EqualError(t, err, "update failed")
+	})
+}
+
+func TestSensorAssignmentService_HandleCreateSensor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should map created sensor to tree", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		event := entities.NewEventCreateSensor(testSensor)
+		repos.treeRepo.EXPECT().FindNearestUnassignedTrees(ctx, testSensor.Latitude, testSensor.Longitude, 3.0, int32(maxCandidates)).
+			Return([]*entities.TreeCandidate{{TreeID: 5, Distance: 0.5}}, nil)
+		repos.treeRepo.EXPECT().Update(mock.Anything, int32(5), mock.Anything).Return(&entities.Tree{ID: 5}, nil)
+
+		// when
+		err := svc.HandleCreateSensor(ctx, &event)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should ignore event without sensor", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		event := entities.NewEventCreateSensor(nil)
+
+		// when
+		err := svc.HandleCreateSensor(ctx, &event)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestSensorAssignmentService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.True(t, ready)
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		// given
+		svc := NewSensorAssignmentService(nil, nil, nil, testConfig)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.False(t, ready)
+	})
+}
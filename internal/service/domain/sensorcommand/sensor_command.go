@@ -0,0 +1,190 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type SensorCommandService struct {
+	commandRepo storage.SensorCommandRepository
+	sensorRepo  storage.SensorRepository
+	validator   *validator.Validate
+}
+
+func NewSensorCommandService(commandRepo storage.SensorCommandRepository, sensorRepo storage.SensorRepository) service.SensorCommandService {
+	return &SensorCommandService{
+		commandRepo: commandRepo,
+		sensorRepo:  sensorRepo,
+		validator:   validator.New(),
+	}
+}
+
+func (s *SensorCommandService) GetAllBySensorID(ctx context.Context, sensorID string) ([]*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.sensorRepo.GetByID(ctx, sensorID); err != nil {
+		log.Debug("failed to fetch sensor of sensor commands", "error", err, "sensor_id", sensorID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	commands, err := s.commandRepo.GetAllBySensorID(ctx, sensorID)
+	if err != nil {
+		log.Debug("failed to fetch sensor commands by sensor id", "error", err, "sensor_id", sensorID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return commands, nil
+}
+
+func (s *SensorCommandService) GetAllQueued(ctx context.Context) ([]*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	commands, err := s.commandRepo.GetAllByStatus(ctx, entities.SensorCommandStatusQueued)
+	if err != nil {
+		log.Debug("failed to fetch queued sensor commands", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return commands, nil
+}
+
+func (s *SensorCommandService) GetByID(ctx context.Context, id int32) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	got, err := s.commandRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor command by id", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return got, nil
+}
+
+// Create queues a new downlink command for the sensor. The command is published
+// to the network server by the mqtt downlink publisher.
+func (s *SensorCommandService) Create(ctx context.Context, sensorID string, createData *entities.SensorCommandCreate) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate sensor command create struct", "error", err, "raw_command", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if _, err := s.sensorRepo.GetByID(ctx, sensorID); err != nil {
+		log.Debug("failed to fetch sensor of sensor command", "error", err, "sensor_id", sensorID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	created, err := s.commandRepo.Create(ctx, func(cmd *entities.SensorCommand) (bool, error) {
+		cmd.SensorID = sensorID
+		cmd.Type = createData.Type
+		if createData.Type == entities.SensorCommandTypeSetInterval {
+			cmd.ReportInterval = createData.ReportInterval
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create sensor command", "error", err, "sensor_id", sensorID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor command queued", "sensor_command_id", created.ID, "sensor_id", sensorID, "command_type", created.Type)
+	return created, nil
+}
+
+// MarkSent marks a queued command as published to the network server.
+func (s *SensorCommandService) MarkSent(ctx context.Context, id int32) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	cmd, err := s.commandRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor command by id", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if cmd.Status != entities.SensorCommandStatusQueued {
+		log.Debug("sensor command is not queued", "sensor_command_id", id, "sensor_command_status", cmd.Status)
+		return nil, service.ErrSensorCommandNotQueued
+	}
+
+	updated, err := s.commandRepo.Update(ctx, id, func(c *entities.SensorCommand) (bool, error) {
+		now := time.Now()
+		c.Status = entities.SensorCommandStatusSent
+		c.SentAt = &now
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update sensor command", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Debug("sensor command sent", "sensor_command_id", id, "sensor_id", updated.SensorID)
+	return updated, nil
+}
+
+// Acknowledge marks a command as acknowledged by the device. Network servers may deliver
+// an acknowledgement more than once, therefore already acknowledged commands are returned unchanged.
+func (s *SensorCommandService) Acknowledge(ctx context.Context, id int32) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	cmd, err := s.commandRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor command by id", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if cmd.Status == entities.SensorCommandStatusAcknowledged {
+		log.Debug("sensor command is already acknowledged", "sensor_command_id", id)
+		return cmd, nil
+	}
+
+	updated, err := s.commandRepo.Update(ctx, id, func(c *entities.SensorCommand) (bool, error) {
+		now := time.Now()
+		c.Status = entities.SensorCommandStatusAcknowledged
+		c.AcknowledgedAt = &now
+		if c.SentAt == nil {
+			c.SentAt = &now
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update sensor command", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor command acknowledged", "sensor_command_id", id, "sensor_id", updated.SensorID, "command_type", updated.Type)
+	return updated, nil
+}
+
+// Expire marks a queued command that can not be routed to a network server as expired, so it is not published anymore.
+func (s *SensorCommandService) Expire(ctx context.Context, id int32) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	cmd, err := s.commandRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch sensor command by id", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if cmd.Status != entities.SensorCommandStatusQueued {
+		log.Debug("sensor command is not queued", "sensor_command_id", id, "sensor_command_status", cmd.Status)
+		return nil, service.ErrSensorCommandNotQueued
+	}
+
+	updated, err := s.commandRepo.Update(ctx, id, func(c *entities.SensorCommand) (bool, error) {
+		c.Status = entities.SensorCommandStatusExpired
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update sensor command", "error", err, "sensor_command_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor command expired", "sensor_command_id", id, "sensor_id", updated.SensorID, "command_type", updated.Type)
+	return updated, nil
+}
+
+func (s *SensorCommandService) Ready() bool {
+	return s.commandRepo != nil && s.sensorRepo != nil
+}
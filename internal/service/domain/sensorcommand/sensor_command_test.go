@@ -0,0 +1,473 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testRepos struct {
+	commandRepo *storageMock.MockSensorCommandRepository
+	sensorRepo  *storageMock.MockSensorRepository
+}
+
+func newTestService(t *testing.T) (*SensorCommandService, testRepos) {
+	repos := testRepos{
+		commandRepo: storageMock.NewMockSensorCommandRepository(t),
+		sensorRepo:  storageMock.NewMockSensorRepository(t),
+	}
+	svc := NewSensorCommandService(repos.commandRepo, repos.sensorRepo)
+	return svc.(*SensorCommandService), repos
+}
+
+func getTestCommands() []*entities.SensorCommand {
+	return []*entities.SensorCommand{
+		{
+			ID:             1,
+			SensorID:       "sensor-1",
+			Type:           entities.SensorCommandTypeSetInterval,
+			Status:         entities.SensorCommandStatusQueued,
+			ReportInterval: utils.P(15 * time.Minute),
+		},
+		{
+			ID:       2,
+			SensorID: "sensor-1",
+			Type:     entities.SensorCommandTypeReboot,
+			Status:   entities.SensorCommandStatusSent,
+			SentAt:   utils.P(time.Date(2025, 1, 25, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			ID:             3,
+			SensorID:       "sensor-1",
+			Type:           entities.SensorCommandTypeRequestReading,
+			Status:         entities.SensorCommandStatusAcknowledged,
+			SentAt:         utils.P(time.Date(2025, 1, 25, 9, 0, 0, 0, time.UTC)),
+			AcknowledgedAt: utils.P(time.Date(2025, 1, 25, 9, 5, 0, 0, time.UTC)),
+		},
+	}
+}
+
+var testSensor = &entities.Sensor{
+	ID:     "sensor-1",
+	Status: entities.SensorStatusOnline,
+}
+
+func TestSensorCommandService_GetAllBySensorID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return all commands of sensor", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestCommands()
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.commandRepo.EXPECT().GetAllBySensorID(ctx, "sensor-1").Return(expected, nil)
+
+		// when
+		got, err := svc.GetAllBySensorID(ctx, "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when sensor does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-99").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetAllBySensorID(ctx, "sensor-99")
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.commandRepo.EXPECT().GetAllBySensorID(ctx, "sensor-1").Return(nil, errors.New("GetAllBySensorID failed"))
+
+		// when
+		got, err := svc.GetAllBySensorID(ctx, "sensor-1")
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandService_GetAllQueued(t *testing.T) {
+	t.Run("should return queued commands", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		svc, repos := newTestService(t)
+		expected := getTestCommands()[:1]
+		repos.commandRepo.EXPECT().GetAllByStatus(ctx, entities.SensorCommandStatusQueued).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAllQueued(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestSensorCommandService_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return command by id", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestCommands()[0]
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when command does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByID(ctx, 99)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestSensorCommandService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should queue set interval command", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		var queued *entities.SensorCommand
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.commandRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			queued = &entities.SensorCommand{ID: 4, Status: entities.SensorCommandStatusQueued}
+			_, err := fn(queued)
+			return queued, err
+		})
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{
+			Type:           entities.SensorCommandTypeSetInterval,
+			ReportInterval: utils.P(30 * time.Minute),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, queued, got)
+		assert.Equal(t, "sensor-1", got.SensorID)
+		assert.Equal(t, entities.SensorCommandTypeSetInterval, got.Type)
+		assert.Equal(t, 30*time.Minute, *got.ReportInterval)
+	})
+
+	t.Run("should ignore interval for other command types", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.commandRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			cmd := &entities.SensorCommand{ID: 4, Status: entities.SensorCommandStatusQueued}
+			_, err := fn(cmd)
+			return cmd, err
+		})
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{
+			Type:           entities.SensorCommandTypeReboot,
+			ReportInterval: utils.P(30 * time.Minute),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandTypeReboot, got.Type)
+		assert.Nil(t, got.ReportInterval)
+	})
+
+	t.Run("should return validation error when interval is missing", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{Type: entities.SensorCommandTypeSetInterval})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return validation error when interval is out of range", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{
+			Type:           entities.SensorCommandTypeSetInterval,
+			ReportInterval: utils.P(30 * time.Second),
+		})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return validation error when type is unknown", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{Type: "self_destruct"})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return not found error when sensor does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-99").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Create(ctx, "sensor-99", &entities.SensorCommandCreate{Type: entities.SensorCommandTypeReboot})
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(testSensor, nil)
+		repos.commandRepo.EXPECT().Create(ctx, mock.Anything).Return(nil, errors.New("Create failed"))
+
+		// when
+		got, err := svc.Create(ctx, "sensor-1", &entities.SensorCommandCreate{Type: entities.SensorCommandTypeReboot})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandService_MarkSent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should mark queued command as sent", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cmd := getTestCommands()[0]
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(1)).Return(cmd, nil)
+		repos.commandRepo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			_, err := fn(cmd)
+			return cmd, err
+		})
+
+		// when
+		got, err := svc.MarkSent(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandStatusSent, got.Status)
+		assert.NotNil(t, got.SentAt)
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should return error when command is not queued", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(2)).Return(getTestCommands()[1], nil)
+
+		// when
+		got, err := svc.MarkSent(ctx, 2)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorCommandNotQueued)
+	})
+
+	t.Run("should return not found error when command does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.MarkSent(ctx, 99)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestSensorCommandService_Acknowledge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should acknowledge sent command", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cmd := getTestCommands()[1]
+		sentAt := *cmd.SentAt
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(2)).Return(cmd, nil)
+		repos.commandRepo.EXPECT().Update(ctx, int32(2), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			_, err := fn(cmd)
+			return cmd, err
+		})
+
+		// when
+		got, err := svc.Acknowledge(ctx, 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandStatusAcknowledged, got.Status)
+		assert.Equal(t, sentAt, *got.SentAt)
+		assert.NotNil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should set sent timestamp when queued command is acknowledged", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cmd := getTestCommands()[0]
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(1)).Return(cmd, nil)
+		repos.commandRepo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			_, err := fn(cmd)
+			return cmd, err
+		})
+
+		// when
+		got, err := svc.Acknowledge(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandStatusAcknowledged, got.Status)
+		assert.NotNil(t, got.SentAt)
+		assert.Equal(t, *got.SentAt, *got.AcknowledgedAt)
+	})
+
+	t.Run("should return already acknowledged command unchanged", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cmd := getTestCommands()[2]
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(3)).Return(cmd, nil)
+
+		// when
+		got, err := svc.Acknowledge(ctx, 3)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, cmd, got)
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(2)).Return(getTestCommands()[1], nil)
+		repos.commandRepo.EXPECT().Update(ctx, int32(2), mock.Anything).Return(nil, errors.New("Update failed"))
+
+		// when
+		got, err := svc.Acknowledge(ctx, 2)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandService_Expire(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should mark queued command as expired", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cmd := getTestCommands()[0]
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(1)).Return(cmd, nil)
+		repos.commandRepo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+			_, err := fn(cmd)
+			return cmd, err
+		})
+
+		// when
+		got, err := svc.Expire(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandStatusExpired, got.Status)
+		assert.Nil(t, got.SentAt)
+	})
+
+	t.Run("should return error when command is not queued", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(2)).Return(getTestCommands()[1], nil)
+
+		// when
+		got, err := svc.Expire(ctx, 2)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorCommandNotQueued)
+	})
+
+	t.Run("should return not found error when command does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.commandRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Expire(ctx, 99)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestSensorCommandService_Ready(t *testing.T) {
+	t.Run("should return true when all repositories are set", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false when repository is missing", func(t *testing.T) {
+		svc := NewSensorCommandService(nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
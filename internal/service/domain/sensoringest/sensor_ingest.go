@@ -0,0 +1,109 @@
+package sensoringest
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	defaultDecoder = "ttn"
+	// deadLetterTopic is stored as topic of rejected webhook payloads, as they are not received by mqtt
+	deadLetterTopic = "http/ingest"
+)
+
+// SensorIngestService runs uplinks received by the http webhook through the same pipeline as the mqtt subscriber
+type SensorIngestService struct {
+	sensorSvc     service.SensorService
+	deadLetterSvc service.DeadLetterService
+	decoder       service.SensorPayloadDecoder
+	cfg           *config.SensorIngestConfig
+}
+
+func NewSensorIngestService(
+	sensorSvc service.SensorService,
+	deadLetterSvc service.DeadLetterService,
+	decoder service.SensorPayloadDecoder,
+	cfg *config.SensorIngestConfig,
+) service.SensorIngestService {
+	return &SensorIngestService{
+		sensorSvc:     sensorSvc,
+		deadLetterSvc: deadLetterSvc,
+		decoder:       decoder,
+		cfg:           cfg,
+	}
+}
+
+// Ingest decodes the raw payload with the given decoder, or the configured default decoder if none is given,
+// and passes it to the sensor service. Rejected payloads are stored as dead letters, so they can be replayed later on.
+// Payloads of decommissioned sensors are dropped without a dead letter.
+func (s *SensorIngestService) Ingest(ctx context.Context, decoderName string, payload []byte) (*entities.SensorData, error) {
+	log := logger.GetLogger(ctx)
+	if decoderName == "" {
+		decoderName = s.defaultDecoder()
+	}
+
+	if !slices.Contains(s.decoder.Names(), decoderName) {
+		log.Debug("requested sensor payload decoder is not registered", "decoder", decoderName)
+		return nil, service.ErrUnknownSensorDecoder
+	}
+
+	decoded, err := s.decoder.DecodeSensorPayload(decoderName, payload)
+	if err != nil {
+		log.Debug("failed to decode ingested sensor payload", "error", err, "decoder", decoderName)
+		s.storeDeadLetter(ctx, decoderName, payload, entities.DeadLetterReasonDecode, err)
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	data, err := s.sensorSvc.HandleMessage(ctx, decoded)
+	// a retired sensor that still sends data is expected, its payloads are not kept for a replay
+	if errors.Is(err, service.ErrSensorDecommissioned) {
+		log.Info("dropping ingested payload of decommissioned sensor", "sensor_id", decoded.Device, "decoder", decoderName)
+		return nil, err
+	}
+
+	if err != nil {
+		log.Debug("failed to handle ingested sensor payload", "error", err, "decoder", decoderName)
+		s.storeDeadLetter(ctx, decoderName, payload, entities.DeadLetterReasonProcess, err)
+
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("sensor data ingested by webhook", "sensor_id", decoded.Device, "decoder", decoderName)
+	return data, nil
+}
+
+func (s *SensorIngestService) storeDeadLetter(ctx context.Context, decoderName string, payload []byte, reason entities.DeadLetterReason, cause error) {
+	log := logger.GetLogger(ctx)
+	_, err := s.deadLetterSvc.Create(ctx, &entities.DeadLetterCreate{
+		Topic:   deadLetterTopic,
+		Decoder: decoderName,
+		Payload: payload,
+		Reason:  reason,
+		Error:   cause.Error(),
+	})
+	if err != nil {
+		log.Error("error while storing rejected webhook payload as dead letter", "error", err)
+	}
+}
+
+func (s *SensorIngestService) defaultDecoder() string {
+	if s.cfg == nil || s.cfg.Decoder == "" {
+		return defaultDecoder
+	}
+	return s.cfg.Decoder
+}
+
+func (s *SensorIngestService) Ready() bool {
+	return s.sensorSvc != nil && s.deadLetterSvc != nil && s.decoder != nil
+}
@@ -0,0 +1,215 @@
+package sensoringest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testServices struct {
+	sensorSvc     *serviceMock.MockSensorService
+	deadLetterSvc *serviceMock.MockDeadLetterService
+	decoder       *serviceMock.MockSensorPayloadDecoder
+}
+
+func newTestService(t *testing.T, cfg *config.SensorIngestConfig) (service.SensorIngestService, testServices) {
+	svcs := testServices{
+		sensorSvc:     serviceMock.NewMockSensorService(t),
+		deadLetterSvc: serviceMock.NewMockDeadLetterService(t),
+		decoder:       serviceMock.NewMockSensorPayloadDecoder(t),
+	}
+	return NewSensorIngestService(svcs.sensorSvc, svcs.deadLetterSvc, svcs.decoder, cfg), svcs
+}
+
+func TestSensorIngestService_Ingest(t *testing.T) {
+	ctx := context.Background()
+	rawPayload := []byte(`{"end_device_ids":{"device_id":"sensor-1"}}`)
+	decoderNames := []string{"chirpstack", "json", "ttn"}
+
+	t.Run("should decode payload and pass it to the sensor service", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{Decoder: "json"})
+		payload := &entities.MqttPayload{Device: "sensor-1", Battery: 3.4}
+		expected := &entities.SensorData{ID: 1, SensorID: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(expected, nil)
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+		svcs.deadLetterSvc.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should use configured decoder when no decoder is given", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{Decoder: "chirpstack"})
+		payload := &entities.MqttPayload{Device: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("chirpstack", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(&entities.SensorData{}, nil)
+
+		// when
+		_, err := svc.Ingest(ctx, "", rawPayload)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fall back to ttn decoder when no decoder is configured", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		payload := &entities.MqttPayload{Device: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(&entities.SensorData{}, nil)
+
+		// when
+		_, err := svc.Ingest(ctx, "", rawPayload)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when decoder is not registered", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+
+		// when
+		got, err := svc.Ingest(ctx, "unknown", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrUnknownSensorDecoder)
+		svcs.decoder.AssertNotCalled(t, "DecodeSensorPayload")
+		svcs.deadLetterSvc.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should store dead letter and return bad request when payload can not be decoded", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(nil, errors.New("invalid json"))
+		svcs.deadLetterSvc.EXPECT().Create(ctx, &entities.DeadLetterCreate{
+			Topic:   deadLetterTopic,
+			Decoder: "ttn",
+			Payload: rawPayload,
+			Reason:  entities.DeadLetterReasonDecode,
+			Error:   "invalid json",
+		}).Return(&entities.DeadLetter{ID: 1}, nil)
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+		svcs.sensorSvc.AssertNotCalled(t, "HandleMessage")
+	})
+
+	t.Run("should store dead letter and return bad request when payload is invalid", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		payload := &entities.MqttPayload{}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(nil, validator.ValidationErrors{})
+		svcs.deadLetterSvc.EXPECT().Create(ctx, mock.MatchedBy(func(dlc *entities.DeadLetterCreate) bool {
+			return dlc.Reason == entities.DeadLetterReasonProcess && dlc.Topic == deadLetterTopic
+		})).Return(&entities.DeadLetter{ID: 1}, nil)
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should store dead letter and return internal error when sensor service fails", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		payload := &entities.MqttPayload{Device: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(nil, errors.New("database down"))
+		svcs.deadLetterSvc.EXPECT().Create(ctx, &entities.DeadLetterCreate{
+			Topic:   deadLetterTopic,
+			Decoder: "ttn",
+			Payload: rawPayload,
+			Reason:  entities.DeadLetterReasonProcess,
+			Error:   "database down",
+		}).Return(&entities.DeadLetter{ID: 1}, nil)
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.InternalError, svcErr.Code)
+	})
+
+	t.Run("should drop payload of decommissioned sensor without storing dead letter", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		payload := &entities.MqttPayload{Device: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(nil, service.ErrSensorDecommissioned)
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		svcs.deadLetterSvc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error of sensor service even when storing dead letter fails", func(t *testing.T) {
+		// given
+		svc, svcs := newTestService(t, &config.SensorIngestConfig{})
+		payload := &entities.MqttPayload{Device: "sensor-1"}
+		svcs.decoder.EXPECT().Names().Return(decoderNames)
+		svcs.decoder.EXPECT().DecodeSensorPayload("ttn", rawPayload).Return(payload, nil)
+		svcs.sensorSvc.EXPECT().HandleMessage(ctx, payload).Return(nil, errors.New("database down"))
+		svcs.deadLetterSvc.EXPECT().Create(ctx, mock.Anything).Return(nil, errors.New("create failed"))
+
+		// when
+		got, err := svc.Ingest(ctx, "ttn", rawPayload)
+
+		// then
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "database down")
+	})
+}
+
+func TestSensorIngestService_Ready(t *testing.T) {
+	t.Run("should be ready when all dependencies are set", func(t *testing.T) {
+		svc, _ := newTestService(t, &config.SensorIngestConfig{})
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should not be ready without decoder", func(t *testing.T) {
+		svc := NewSensorIngestService(serviceMock.NewMockSensorService(t), serviceMock.NewMockDeadLetterService(t), nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
@@ -4,28 +4,45 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/auth"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/deadletter"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/info"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/plugin"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/region"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensorassignment"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensorcommand"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensoringest"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/watering_plan"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/wateringrule"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/wateringstatus"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/weather"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 )
 
-func NewService(cfg *config.Config, repos *storage.Repository, eventMananger *worker.EventManager) *service.Services {
+func NewService(cfg *config.Config, repos *storage.Repository, eventMananger *worker.EventManager, sensorDecoder service.SensorPayloadDecoder) *service.Services {
+	sensorService := sensor.NewSensorService(repos.Sensor, repos.Tree, repos.Flowerbed, repos.WateringRule, eventMananger, &cfg.Sensor, &cfg.WateringStatus)
+	deadLetterService := deadletter.NewDeadLetterService(repos.DeadLetter, sensorService, sensorDecoder)
+
 	return &service.Services{
-		InfoService:         info.NewInfoService(repos.Info),
-		TreeService:         tree.NewTreeService(repos.Tree, repos.Sensor, repos.Image, repos.TreeCluster, eventMananger),
-		AuthService:         auth.NewAuthService(repos.Auth, repos.User, &cfg.IdentityAuth),
-		RegionService:       region.NewRegionService(repos.Region),
-		TreeClusterService:  treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region, eventMananger),
-		VehicleService:      vehicle.NewVehicleService(repos.Vehicle),
-		SensorService:       sensor.NewSensorService(repos.Sensor, repos.Tree, repos.Flowerbed, eventMananger),
-		PluginService:       plugin.NewPluginManager(repos.Auth),
-		WateringPlanService: wateringplan.NewWateringPlanService(repos.WateringPlan, repos.TreeCluster, repos.Vehicle, repos.User, eventMananger, repos.Routing, repos.GpxBucket),
+		InfoService:             info.NewInfoService(repos.Info),
+		TreeService:             tree.NewTreeService(repos.Tree, repos.Sensor, repos.Image, repos.TreeCluster, repos.WateringRule, eventMananger, &cfg.WateringStatus),
+		AuthService:             auth.NewAuthService(repos.Auth, repos.User, &cfg.IdentityAuth),
+		RegionService:           region.NewRegionService(repos.Region),
+		TreeClusterService:      treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region, repos.WateringRule, eventMananger, &cfg.WateringStatus),
+		VehicleService:          vehicle.NewVehicleService(repos.Vehicle),
+		SensorService:           sensorService,
+		PluginService:           plugin.NewPluginManager(repos.Auth),
+		WateringPlanService:     wateringplan.NewWateringPlanService(repos.WateringPlan, repos.TreeCluster, repos.Vehicle, repos.User, eventMananger, repos.Routing, repos.GpxBucket),
+		DeadLetterService:       deadLetterService,
+		SensorAssignmentService: sensorassignment.NewSensorAssignmentService(repos.SensorAssignment, repos.Tree, repos.Sensor, &cfg.Sensor.Assignment),
+		SensorCommandService:    sensorcommand.NewSensorCommandService(repos.SensorCommand, repos.Sensor),
+		SensorIngestService:     sensoringest.NewSensorIngestService(sensorService, deadLetterService, sensorDecoder, &cfg.Sensor.Ingest),
+		WateringRuleService:     wateringrule.NewWateringRuleService(repos.WateringRule, repos.Sensor, repos.Tree, &cfg.WateringStatus),
+		WeatherService:          weather.NewWeatherService(repos.Weather, repos.WeatherProvider, repos.TreeCluster, eventMananger, &cfg.Weather),
+		WateringStatusService:   wateringstatus.NewWateringStatusService(repos.WateringStatus, repos.Tree, repos.TreeCluster),
 	}
 }
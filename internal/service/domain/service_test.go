@@ -6,6 +6,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
@@ -24,21 +25,34 @@ func TestNewService(t *testing.T) {
 		mockUserRepo := storageMock.NewMockUserRepository(t)
 		mockImageRepo := storageMock.NewMockImageRepository(t)
 		mockVehicleRepo := storageMock.NewMockVehicleRepository(t)
+		mockDeadLetterRepo := storageMock.NewMockDeadLetterRepository(t)
+		mockSensorAssignmentRepo := storageMock.NewMockSensorAssignmentRepository(t)
+		mockSensorCommandRepo := storageMock.NewMockSensorCommandRepository(t)
+		mockWateringRuleRepo := storageMock.NewMockWateringRuleRepository(t)
+		mockWeatherRepo := storageMock.NewMockWeatherRepository(t)
+		mockWateringStatusRepo := storageMock.NewMockWateringStatusHistoryRepository(t)
+		mockDecoder := serviceMock.NewMockSensorPayloadDecoder(t)
 
 		mockRepos := &storage.Repository{
-			Auth:        mockAuthRepo,
-			Info:        mockInfoRepo,
-			Sensor:      mockSensorRepo,
-			Tree:        mockTreeRepo,
-			User:        mockUserRepo,
-			Image:       mockImageRepo,
-			TreeCluster: mockClusterRepo,
-			Region:      mockRegionRepo,
-			Vehicle:     mockVehicleRepo,
+			Auth:             mockAuthRepo,
+			Info:             mockInfoRepo,
+			Sensor:           mockSensorRepo,
+			Tree:             mockTreeRepo,
+			User:             mockUserRepo,
+			Image:            mockImageRepo,
+			TreeCluster:      mockClusterRepo,
+			Region:           mockRegionRepo,
+			Vehicle:          mockVehicleRepo,
+			DeadLetter:       mockDeadLetterRepo,
+			SensorAssignment: mockSensorAssignmentRepo,
+			SensorCommand:    mockSensorCommandRepo,
+			WateringRule:     mockWateringRuleRepo,
+			Weather:          mockWeatherRepo,
+			WateringStatus:   mockWateringStatusRepo,
 		}
 
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTree, entities.EventTypeUpdateTreeCluster, entities.EventTypeUpdateWateringPlan)
-		svc := NewService(mockConfig, mockRepos, eventManager)
+		svc := NewService(mockConfig, mockRepos, eventManager, mockDecoder)
 
 		assert.NotNil(t, svc)
 		assert.IsType(t, &service.Services{}, svc)
@@ -49,5 +63,12 @@ func TestNewService(t *testing.T) {
 		assert.NotNil(t, svc.TreeClusterService)
 		assert.NotNil(t, svc.SensorService)
 		assert.NotNil(t, svc.VehicleService)
+		assert.NotNil(t, svc.DeadLetterService)
+		assert.NotNil(t, svc.SensorAssignmentService)
+		assert.NotNil(t, svc.SensorCommandService)
+		assert.NotNil(t, svc.SensorIngestService)
+		assert.NotNil(t, svc.WateringRuleService)
+		assert.NotNil(t, svc.WeatherService)
+		assert.NotNil(t, svc.WateringStatusService)
 	})
 }
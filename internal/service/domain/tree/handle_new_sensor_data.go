@@ -7,26 +7,32 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
 )
 
 func (s *TreeService) HandleNewSensorData(ctx context.Context, event *entities.EventNewSensorData) error {
 	log := logger.GetLogger(ctx)
 	log.Debug("handle event", "event", event.Type(), "service", "TreeService")
+	if event.New.Flagged {
+		log.Info("sensor data is flagged as suspicious. This event will be ignored", "sensor_id", event.New.SensorID)
+		return nil
+	}
+
 	t, err := s.treeRepo.GetBySensorID(ctx, event.New.SensorID)
 	if err != nil {
 		log.Error("failed to get tree by sensor id", "sensor_id", event.New.SensorID, "err", err)
 		return nil
 	}
 
-	status := utils.CalculateWateringStatus(ctx, t.PlantingYear, event.New.Data.Watermarks)
+	status := s.calculateWateringStatus(ctx, t.PlantingYear, t.Species, t.TreeCluster, event.New.Data.Watermarks)
 
 	if status == t.WateringStatus {
 		log.Debug("sensor status has not changed", "sensor_status", status)
 		return nil
 	}
 
-	newTree, err := s.treeRepo.Update(ctx, t.ID, tree.WithWateringStatus(status))
+	newTree, err := s.treeRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseSensorData), t.ID, tree.WithWateringStatus(status))
 	if err != nil {
 		log.Error("failed to update tree with new watering status", "tree_id", t.ID, "watering_status", status, "err", err)
 		return err
@@ -37,3 +43,14 @@ func (s *TreeService) HandleNewSensorData(ctx context.Context, event *entities.E
 	s.publishUpdateTreeEvent(ctx, t, newTree)
 	return nil
 }
+
+// calculateWateringStatus calculates the watering status of a tree with the thresholds that apply to
+// its species and the soil condition of its tree cluster
+func (s *TreeService) calculateWateringStatus(ctx context.Context, plantingYear int32, species string, tc *entities.TreeCluster, watermarks []entities.Watermark) entities.WateringStatus {
+	var soilCondition *entities.TreeSoilCondition
+	if tc != nil {
+		soilCondition = &tc.SoilCondition
+	}
+
+	return utils.CalculateWateringStatus(ctx, plantingYear, watermarks, s.rules.Applicable(ctx, species, soilCondition))
+}
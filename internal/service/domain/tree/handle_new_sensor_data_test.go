@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
@@ -20,7 +21,7 @@ func TestTreeService_HandleNewSensorData(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTree)
-		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, eventManager)
+		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, eventManager, nil)
 
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTree)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -69,13 +70,97 @@ func TestTreeService_HandleNewSensorData(t *testing.T) {
 		}
 	})
 
+	t.Run("should calculate watering status with configured thresholds of the probe depths", func(t *testing.T) {
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		svc := NewTreeService(treeRepo, nil, nil, nil, nil, worker.NewEventManager(), &config.WateringStatusConfig{
+			Thresholds: []config.WateringThresholdConfig{
+				{MinAge: 0, MaxAge: 10, Depth: 20, Moderate: 30, Bad: 60},
+				{MinAge: 0, MaxAge: 10, Depth: 50, Moderate: 40, Bad: 80},
+			},
+		})
+
+		sensorDataEvent := entities.SensorData{
+			SensorID: "sensor-1",
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 25, Depth: 20},
+					{Centibar: 80, Depth: 50},
+				},
+			},
+		}
+
+		tree := entities.Tree{
+			ID:             1,
+			PlantingYear:   int32(time.Now().Year() - 6),
+			WateringStatus: entities.WateringStatusGood,
+		}
+
+		event := entities.NewEventSensorData(&sensorDataEvent)
+
+		var got entities.Tree
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
+		treeRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, fns ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
+			cause, ok := storage.WateringStatusCauseFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, entities.WateringStatusCauseSensorData, cause)
+			for _, fn := range fns {
+				fn(&got)
+			}
+			return &got, nil
+		})
+
+		err := svc.HandleNewSensorData(context.Background(), &event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WateringStatusBad, got.WateringStatus)
+	})
+
+	t.Run("should calculate watering status with thresholds adjusted to the soil condition of the tree cluster", func(t *testing.T) {
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		svc := NewTreeService(treeRepo, nil, nil, nil, nil, worker.NewEventManager(), nil)
+
+		sensorDataEvent := entities.SensorData{
+			SensorID: "sensor-1",
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 22, Depth: 30},
+					{Centibar: 22, Depth: 60},
+					{Centibar: 22, Depth: 90},
+				},
+			},
+		}
+
+		tree := entities.Tree{
+			ID:             1,
+			PlantingYear:   int32(time.Now().Year()),
+			WateringStatus: entities.WateringStatusGood,
+			TreeCluster:    &entities.TreeCluster{ID: 1, SoilCondition: entities.TreeSoilConditionSandig},
+		}
+
+		event := entities.NewEventSensorData(&sensorDataEvent)
+
+		var got entities.Tree
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
+		treeRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fns ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
+			for _, fn := range fns {
+				fn(&got)
+			}
+			return &got, nil
+		})
+
+		err := svc.HandleNewSensorData(context.Background(), &event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WateringStatusBad, got.WateringStatus)
+	})
+
 	t.Run("should not update and not send event if the sensor has no linked tree", func(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTree)
-		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, eventManager)
+		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTree)
@@ -117,7 +202,7 @@ func TestTreeService_HandleNewSensorData(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTree)
-		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, eventManager)
+		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTree)
@@ -159,4 +244,50 @@ func TestTreeService_HandleNewSensorData(t *testing.T) {
 			assert.True(t, true)
 		}
 	})
+
+	t.Run("should not update and not send event if the sensor data is flagged", func(t *testing.T) {
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		imageRepo := storageMock.NewMockImageRepository(t)
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTree)
+		svc := NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, eventManager, nil)
+
+		// event
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTree)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		sensorDataEvent := entities.SensorData{
+			SensorID: "sensor-1",
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 255, Depth: 30},
+					{Centibar: 40, Depth: 60},
+					{Centibar: 50, Depth: 90},
+				},
+			},
+			Flagged:      true,
+			AnomalyScore: 1,
+			Anomalies: []entities.SensorDataAnomaly{
+				{Type: entities.SensorDataAnomalyOutOfRange, Depth: 30, Centibar: 255, Score: 1},
+			},
+		}
+
+		event := entities.NewEventSensorData(&sensorDataEvent)
+
+		// when
+		err := svc.HandleNewSensorData(context.Background(), &event)
+
+		// then
+		assert.NoError(t, err)
+		treeRepo.AssertNotCalled(t, "GetBySensorID", mock.Anything, mock.Anything)
+		select {
+		case <-ch:
+			t.Fatal("event was received. It should not have been sent")
+		case <-time.After(100 * time.Millisecond):
+			assert.True(t, true)
+		}
+	})
 }
@@ -21,7 +21,7 @@ func TestTreeService_ImportTree(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedTree := TestTreesList[0]
 		treeRepo.EXPECT().GetByCoordinates(ctx, TestTreeImport.Latitude, TestTreeImport.Longitude).Return(nil, nil)
@@ -49,7 +49,7 @@ func TestTreeService_ImportTree(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		existingTree := TestTreesList[0]
 		updatedTree := TestTreesList[0]
@@ -81,7 +81,7 @@ func TestTreeService_ImportTree(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		// Define existing tree and tree import data
 		existingTree := TestTreesList[0]
@@ -113,7 +113,7 @@ func TestTreeService_ImportTree(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		existingTree := TestTreesList[0]
 		expectedErr := errors.New("error deleting tree")
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
@@ -23,6 +24,7 @@ type TreeService struct {
 	treeClusterRepo storage.TreeClusterRepository
 	validator       *validator.Validate
 	eventManager    *worker.EventManager
+	rules           *utils.WateringRules
 }
 
 func NewTreeService(
@@ -30,7 +32,9 @@ func NewTreeService(
 	repoSensor storage.SensorRepository,
 	repoImage storage.ImageRepository,
 	treeClusterRepo storage.TreeClusterRepository,
+	wateringRuleRepo storage.WateringRuleRepository,
 	eventManager *worker.EventManager,
+	cfg *config.WateringStatusConfig,
 ) service.TreeService {
 	return &TreeService{
 		treeRepo:        repoTree,
@@ -39,6 +43,7 @@ func NewTreeService(
 		treeClusterRepo: treeClusterRepo,
 		validator:       validator.New(),
 		eventManager:    eventManager,
+		rules:           utils.NewWateringRules(wateringRuleRepo, cfg),
 	}
 }
 
@@ -110,13 +115,15 @@ func (s *TreeService) Create(ctx context.Context, treeCreate *entities.TreeCreat
 	}
 
 	fn := make([]entities.EntityFunc[entities.Tree], 0)
+	var treeCluster *entities.TreeCluster
 	if treeCreate.TreeClusterID != nil {
-		treeClusterID, err := s.treeClusterRepo.GetByID(ctx, *treeCreate.TreeClusterID)
+		var err error
+		treeCluster, err = s.treeClusterRepo.GetByID(ctx, *treeCreate.TreeClusterID)
 		if err != nil {
 			log.Debug("failed to fetch tree cluster by id specified in the tree create request", "tree_cluster_id", treeCreate.TreeClusterID)
 			return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 		}
-		fn = append(fn, tree.WithTreeCluster(treeClusterID))
+		fn = append(fn, tree.WithTreeCluster(treeCluster))
 	}
 
 	if treeCreate.SensorID != nil {
@@ -125,10 +132,14 @@ func (s *TreeService) Create(ctx context.Context, treeCreate *entities.TreeCreat
 			log.Debug("failed to fetch sensor by id specified in the tree create request", "sensor_id", treeCreate.SensorID)
 			return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 		}
+		if sensor.DecommissionedAt != nil {
+			log.Debug("sensor specified in the tree create request is decommissioned", "sensor_id", sensor.ID)
+			return nil, service.ErrSensorDecommissioned
+		}
 		fn = append(fn, tree.WithSensor(sensor))
 
-		if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
-			status := utils.CalculateWateringStatus(ctx, treeCreate.PlantingYear, sensor.LatestData.Data.Watermarks)
+		if sensor.LatestData != nil && !sensor.LatestData.Flagged && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
+			status := s.calculateWateringStatus(ctx, treeCreate.PlantingYear, treeCreate.Species, treeCluster, sensor.LatestData.Data.Watermarks)
 			fn = append(fn, tree.WithWateringStatus(status))
 		}
 	}
@@ -188,8 +199,8 @@ func (s *TreeService) Update(ctx context.Context, id int32, tu *entities.TreeUpd
 	// }
 
 	fn := make([]entities.EntityFunc[entities.Tree], 0)
+	var treeCluster *entities.TreeCluster
 	if tu.TreeClusterID != nil {
-		var treeCluster *entities.TreeCluster
 		treeCluster, err = s.treeClusterRepo.GetByID(ctx, *tu.TreeClusterID)
 		if err != nil {
 			log.Debug("failed to find tree cluster by id specified from update request", "tree_cluster_id", tu.TreeClusterID)
@@ -207,10 +218,14 @@ func (s *TreeService) Update(ctx context.Context, id int32, tu *entities.TreeUpd
 			log.Debug("failed to find sensor by id specified from update request", "sensor_id", tu.SensorID)
 			return nil, service.MapError(ctx, fmt.Errorf("failed to find Sensor with ID %v: %w", *tu.SensorID, err), service.ErrorLogEntityNotFound)
 		}
+		if sensor.DecommissionedAt != nil {
+			log.Debug("sensor specified in the tree update request is decommissioned", "sensor_id", sensor.ID)
+			return nil, service.ErrSensorDecommissioned
+		}
 		fn = append(fn, tree.WithSensor(sensor))
 
-		if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
-			status := utils.CalculateWateringStatus(ctx, tu.PlantingYear, sensor.LatestData.Data.Watermarks)
+		if sensor.LatestData != nil && !sensor.LatestData.Flagged && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
+			status := s.calculateWateringStatus(ctx, tu.PlantingYear, tu.Species, treeCluster, sensor.LatestData.Data.Watermarks)
 			fn = append(fn, tree.WithWateringStatus(status))
 		}
 	} else {
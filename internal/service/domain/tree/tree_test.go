@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
@@ -27,7 +28,7 @@ func TestTreeService_GetAll(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		expectedTrees := TestTreesList
 		treeRepo.EXPECT().GetAll(ctx).Return(expectedTrees, nil)
@@ -46,7 +47,7 @@ func TestTreeService_GetAll(t *testing.T) {
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		treeRepo.EXPECT().GetAll(ctx).Return([]*entities.Tree{}, nil)
 
@@ -65,7 +66,7 @@ func TestTreeService_GetAll(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		expectedError := errors.New("GetAll failed")
 
@@ -90,7 +91,7 @@ func TestTreeService_GetByID(t *testing.T) {
 	imageRepo := storageMock.NewMockImageRepository(t)
 	clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-	svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+	svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 	t.Run("should return tree when found", func(t *testing.T) {
 		id := int32(1)
@@ -145,7 +146,7 @@ func TestTreeService_GetBySensorID(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		id := "sensor-1"
 		expectedTree := TestTreesList[0]
@@ -165,7 +166,7 @@ func TestTreeService_GetBySensorID(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		id := "sensor-2"
 		expectedError := storage.ErrEntityNotFound("not found")
@@ -186,7 +187,7 @@ func TestTreeService_GetBySensorID(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		id := "sensor-2"
 		expectedError := storage.ErrSensorNotFound
@@ -207,7 +208,7 @@ func TestTreeService_GetBySensorID(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, clusterRepo, nil, globalEventManager, nil)
 
 		id := "sensor-3"
 		expectedError := errors.New("unexpected error")
@@ -234,7 +235,7 @@ func TestTreeService_Create(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedTree := TestTreesList[0]
 		expectedCluster := TestTreeClusters[0]
@@ -269,7 +270,7 @@ func TestTreeService_Create(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		invalidTreeCreate := &entities.TreeCreate{
 			Species:      "Oak",
@@ -295,7 +296,7 @@ func TestTreeService_Create(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := storage.ErrTreeClusterNotFound
 
@@ -318,7 +319,7 @@ func TestTreeService_Create(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := storage.ErrSensorNotFound
 		expectedCluster := TestTreeClusters[0]
@@ -336,6 +337,30 @@ func TestTreeService_Create(t *testing.T) {
 		// assert.EqualError(t, err, "404: sensor not found")
 	})
 
+	t.Run("should return error when sensor is decommissioned", func(t *testing.T) {
+		// given
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		sensorRepo := storageMock.NewMockSensorRepository(t)
+		imageRepo := storageMock.NewMockImageRepository(t)
+		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
+
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
+
+		decommissioned := *TestSensors[0]
+		decommissioned.DecommissionedAt = &time.Time{}
+
+		// Mock expectations
+		treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(TestTreeClusters[0], nil)
+		sensorRepo.EXPECT().GetByID(ctx, *TestTreeCreate.SensorID).Return(&decommissioned, nil)
+
+		// when
+		result, err := svc.Create(ctx, TestTreeCreate)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrSensorDecommissioned)
+		assert.Nil(t, result)
+	})
+
 	t.Run("should return error when creating tree fails", func(t *testing.T) {
 		// given
 		treeRepo := storageMock.NewMockTreeRepository(t)
@@ -343,7 +368,7 @@ func TestTreeService_Create(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedCluster := TestTreeClusters[0]
 		expectedSensor := TestSensors[0]
@@ -383,7 +408,7 @@ func TestTreeService_Delete(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedTree := TestTreesList[0]
 		expectedTree.TreeCluster = TestTreeClusters[0]
@@ -407,7 +432,7 @@ func TestTreeService_Delete(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		id := int32(1)
 		expectedError := storage.ErrEntityNotFound("not found")
@@ -430,7 +455,7 @@ func TestTreeService_Delete(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedTree := TestTreesList[0]
 		expectedTree.TreeCluster = TestTreeClusters[0]
@@ -454,7 +479,7 @@ func TestTreeService_Delete(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedTree := TestTreesList[0]
 		expectedTree.TreeCluster = nil // Tree has no cluster
@@ -491,7 +516,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		currentTree := TestTreesList[0]
 		treeCluster := TestTreeClusters[0]
@@ -530,7 +555,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		invalidTreeUpdate := &entities.TreeUpdate{
 			Latitude:     0,
@@ -554,7 +579,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := storage.ErrEntityNotFound("not found")
 
@@ -577,7 +602,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := storage.ErrTreeClusterNotFound
 
@@ -606,7 +631,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := storage.ErrSensorNotFound
 
@@ -637,7 +662,7 @@ func TestTreeService_Update(t *testing.T) {
 		imageRepo := storageMock.NewMockImageRepository(t)
 		treeClusterRepo := storageMock.NewMockTreeClusterRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, globalEventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, globalEventManager, nil)
 
 		expectedError := errors.New("update failed")
 
@@ -700,7 +725,7 @@ func TestTreeService_EventSystem(t *testing.T) {
 		// Mock expectations
 		treeRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&expectedTree, nil)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, eventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, eventManager, nil)
 
 		// when
 		subID, ch, err := eventManager.Subscribe(entities.EventTypeCreateTree)
@@ -752,7 +777,7 @@ func TestTreeService_EventSystem(t *testing.T) {
 			mock.Anything,
 			mock.Anything).Return(&expectedTree, nil)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, eventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, eventManager, nil)
 
 		// when
 		subID, ch, err := eventManager.Subscribe(entities.EventTypeUpdateTree)
@@ -801,7 +826,7 @@ func TestTreeService_EventSystem(t *testing.T) {
 		treeRepo.EXPECT().GetByID(ctx, treeToDelete.ID).Return(&treeToDelete, nil)
 		treeRepo.EXPECT().Delete(ctx, treeToDelete.ID).Return(nil)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, eventManager)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, imageRepo, treeClusterRepo, nil, eventManager, nil)
 
 		// when
 		subID, ch, err := eventManager.Subscribe(entities.EventTypeDeleteTree)
@@ -828,7 +853,7 @@ func TestTreeService_Ready(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, sensorRepo, nil, nil, nil)
+		svc := tree.NewTreeService(treeRepo, sensorRepo, nil, nil, nil, nil, nil)
 
 		// when
 		result := svc.Ready()
@@ -841,7 +866,7 @@ func TestTreeService_Ready(t *testing.T) {
 		// given
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 
-		svc := tree.NewTreeService(nil, sensorRepo, nil, nil, nil)
+		svc := tree.NewTreeService(nil, sensorRepo, nil, nil, nil, nil, nil)
 
 		// when
 		result := svc.Ready()
@@ -854,7 +879,7 @@ func TestTreeService_Ready(t *testing.T) {
 		// given
 		treeRepo := storageMock.NewMockTreeRepository(t)
 
-		svc := tree.NewTreeService(treeRepo, nil, nil, nil, nil)
+		svc := tree.NewTreeService(treeRepo, nil, nil, nil, nil, nil, nil)
 
 		// when
 		result := svc.Ready()
@@ -865,7 +890,7 @@ func TestTreeService_Ready(t *testing.T) {
 
 	t.Run("should return false when both treeRepo and sensorRepo are nil", func(t *testing.T) {
 		// given
-		svc := tree.NewTreeService(nil, nil, nil, nil, nil)
+		svc := tree.NewTreeService(nil, nil, nil, nil, nil, nil, nil)
 
 		// when
 		result := svc.Ready()
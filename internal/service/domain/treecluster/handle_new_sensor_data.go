@@ -15,6 +15,11 @@ import (
 func (s *TreeClusterService) HandleNewSensorData(ctx context.Context, event *entities.EventNewSensorData) error {
 	log := logger.GetLogger(ctx)
 	log.Debug("handle event", "event", event.Type(), "service", "TreeClusterService")
+	if event.New.Flagged {
+		log.Info("sensor data is flagged as suspicious. This event will be ignored", "sensor_id", event.New.SensorID)
+		return nil
+	}
+
 	tree, err := s.treeRepo.GetBySensorID(ctx, event.New.SensorID)
 	if err != nil {
 		// when error, it can be because the sensor has not linked tree or the tree does not exists
@@ -31,41 +36,57 @@ func (s *TreeClusterService) HandleNewSensorData(ctx context.Context, event *ent
 		return nil
 	}
 
-	wateringStatus, err := s.getWateringStatusOfTreeCluster(ctx, tree.TreeCluster.ID)
+	wateringStatus, input, err := s.getWateringStatusOfTreeCluster(ctx, tree.TreeCluster.ID)
 	if err != nil {
 		log.Error("error while calculating watering status of tree cluster", "error", err)
 		return nil
 	}
 
-	if wateringStatus == tree.TreeCluster.WateringStatus {
+	// the drying trend and the moisture metrics change with every sensor data, even if the watering status does not
+	s.updateMoistureTrends(ctx, tree.TreeCluster, input)
+	s.updateMoistureMetrics(ctx, tree.TreeCluster, input)
+
+	// an estimated watering status is replaced by the measured one, even if it is the same
+	if wateringStatus == tree.TreeCluster.WateringStatus && !tree.TreeCluster.WateringStatusEstimated {
 		log.Debug("watering status has not changed", "watering_status", wateringStatus)
 		return nil
 	}
 
 	updateFn := func(tc *entities.TreeCluster) (bool, error) {
 		tc.WateringStatus = wateringStatus
+		tc.WateringStatusEstimated = false
+		tc.WateringStatusInferredAt = nil
 		return true, nil
 	}
 
-	if err := s.treeClusterRepo.Update(ctx, tree.TreeCluster.ID, updateFn); err == nil {
+	if err := s.treeClusterRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseSensorData), tree.TreeCluster.ID, updateFn); err == nil {
 		return s.publishUpdateEvent(ctx, tree.TreeCluster)
 	}
 
 	return nil
 }
 
-func (s *TreeClusterService) getWateringStatusOfTreeCluster(ctx context.Context, clusterID int32) (entities.WateringStatus, error) {
+// wateringInput holds the values the watering status, the moisture trends and the moisture metrics of a tree cluster
+// are calculated from
+type wateringInput struct {
+	sensorData   []*entities.SensorData
+	plantingYear int32
+	watermarks   []entities.Watermark
+	thresholds   []entities.WateringThreshold
+}
+
+func (s *TreeClusterService) getWateringStatusOfTreeCluster(ctx context.Context, clusterID int32) (entities.WateringStatus, *wateringInput, error) {
 	log := logger.GetLogger(ctx)
 	sensorData, err := s.treeClusterRepo.GetAllLatestSensorDataByClusterID(ctx, clusterID)
 	if err != nil {
 		log.Error("failed to get latest sensor data", "cluster_id", clusterID, "err", err)
-		return entities.WateringStatusUnknown, errors.New("failed to get latest sensor data")
+		return entities.WateringStatusUnknown, nil, errors.New("failed to get latest sensor data")
 	}
 
 	// assertion - if there is no sensor data after receiving the event, the world is ending
 	if len(sensorData) == 0 {
 		log.Error("sensor data is empty")
-		return entities.WateringStatusUnknown, errors.New("sensor data is empty")
+		return entities.WateringStatusUnknown, nil, errors.New("sensor data is empty")
 	}
 
 	sensorIDs := utils.Map(sensorData, func(data *entities.SensorData) string {
@@ -74,15 +95,29 @@ func (s *TreeClusterService) getWateringStatusOfTreeCluster(ctx context.Context,
 
 	youngestTree, err := s.getYoungestTree(ctx, sensorIDs)
 	if err != nil {
-		return entities.WateringStatusUnknown, errors.New("failed to get youngest tree")
+		return entities.WateringStatusUnknown, nil, errors.New("failed to get youngest tree")
 	}
 
 	watermarks, err := s.getWatermarkSensorData(ctx, sensorData)
 	if err != nil {
-		return entities.WateringStatusUnknown, errors.New("failed getting watermark sensor data")
+		return entities.WateringStatusUnknown, nil, errors.New("failed getting watermark sensor data")
 	}
 
-	return svcUtils.CalculateWateringStatus(ctx, youngestTree.PlantingYear, watermarks), nil
+	var soilCondition *entities.TreeSoilCondition
+	if youngestTree.TreeCluster != nil {
+		soilCondition = &youngestTree.TreeCluster.SoilCondition
+	}
+
+	// the soil condition shifts the thresholds, sandy soils need water earlier than clay soils
+	thresholds := s.rules.Applicable(ctx, youngestTree.Species, soilCondition)
+	input := &wateringInput{
+		sensorData:   sensorData,
+		plantingYear: youngestTree.PlantingYear,
+		watermarks:   watermarks,
+		thresholds:   thresholds,
+	}
+
+	return svcUtils.CalculateWateringStatus(ctx, input.plantingYear, input.watermarks, input.thresholds), input, nil
 }
 
 func (s *TreeClusterService) getYoungestTree(ctx context.Context, sensorIDs []string) (*entities.Tree, error) {
@@ -100,33 +135,15 @@ func (s *TreeClusterService) getYoungestTree(ctx context.Context, sensorIDs []st
 	return trees[0], nil
 }
 
+// getWatermarkSensorData averages the watermarks of all sensors per probe depth. Sensors may have a
+// different number of probes, a depth is averaged over the sensors that have a probe at this depth.
 func (s *TreeClusterService) getWatermarkSensorData(ctx context.Context, sensorData []*entities.SensorData) ([]entities.Watermark, error) {
 	log := logger.GetLogger(ctx)
-	var w30CentibarAvg, w60CentibarAvg, w90CentibarAvg int
-	for _, data := range sensorData {
-		w30, w60, w90, err := svcUtils.CheckAndSortWatermarks(data.Data.Watermarks)
-		if err != nil {
-			log.Error("sensor data watermarks are malformed", "watermarks", data.Data.Watermarks)
-			return nil, errors.New("sensor data watermarks are malformed")
-		}
-
-		w30CentibarAvg += w30.Centibar
-		w60CentibarAvg += w60.Centibar
-		w90CentibarAvg += w90.Centibar
+	watermarks, err := svcUtils.AverageWatermarks(sensorData)
+	if err != nil {
+		log.Error("sensor data watermarks are malformed", "error", err)
+		return nil, errors.New("sensor data watermarks are malformed")
 	}
 
-	return []entities.Watermark{
-		{
-			Centibar: w30CentibarAvg / len(sensorData),
-			Depth:    30,
-		},
-		{
-			Centibar: w60CentibarAvg / len(sensorData),
-			Depth:    60,
-		},
-		{
-			Centibar: w90CentibarAvg / len(sensorData),
-			Depth:    90,
-		},
-	}, nil
+	return watermarks, nil
 }
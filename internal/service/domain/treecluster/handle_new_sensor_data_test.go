@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
@@ -18,7 +21,7 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
@@ -104,11 +107,18 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
 		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return(allLatestSensorData, nil)
 		treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1", "sensor-2").Return([]*entities.Tree{&treeWithSensorID1, &treeWithSensorID2}, nil)
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return([]*entities.MoistureTrend{}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().SaveMoistureMetrics(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil)
 		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, i int32, f func(*entities.TreeCluster) (bool, error)) error {
 			cluster := entities.TreeCluster{}
 			_, err := f(&cluster)
 			assert.NoError(t, err)
 			assert.Equal(t, entities.WateringStatusGood, cluster.WateringStatus)
+			cause, ok := storage.WateringStatusCauseFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, entities.WateringStatusCauseSensorData, cause)
 			return nil
 		})
 		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(tcNew, nil)
@@ -134,7 +144,7 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
@@ -182,6 +192,10 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
 		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{&sensorDataEvent}, nil)
 		treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1").Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return([]*entities.MoistureTrend{}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().SaveMoistureMetrics(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil)
 		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, i int32, f func(*entities.TreeCluster) (bool, error)) error {
 			cluster := entities.TreeCluster{}
 			_, err := f(&cluster)
@@ -212,7 +226,7 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
@@ -258,6 +272,10 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
 		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{&sensorDataEvent}, nil)
 		treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1").Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return([]*entities.MoistureTrend{}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().SaveMoistureMetrics(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil)
 
 		// when
 		err := svc.HandleNewSensorData(context.Background(), &event)
@@ -272,12 +290,92 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 		}
 	})
 
+	t.Run("should replace estimated watering status with measured one even if it did not change", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
+
+		// event
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		sensorDataEvent := entities.SensorData{
+			SensorID: "sensor-1",
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 61, Depth: 30},
+					{Centibar: 24, Depth: 60},
+					{Centibar: 24, Depth: 90},
+				},
+			},
+		}
+
+		tc := &entities.TreeCluster{
+			ID:                      1,
+			WateringStatus:          entities.WateringStatusBad,
+			WateringStatusEstimated: true,
+		}
+
+		tree := entities.Tree{
+			ID:           1,
+			TreeCluster:  tc,
+			PlantingYear: int32(time.Now().Year() - 2),
+		}
+
+		treeWithSensorID1 := entities.Tree{
+			ID:          2,
+			TreeCluster: tc,
+			Sensor: &entities.Sensor{
+				ID: "sensor-1",
+			},
+			PlantingYear: int32(time.Now().Year() - 1),
+		}
+
+		event := entities.NewEventSensorData(&sensorDataEvent)
+
+		treeRepo.EXPECT().GetBySensorID(mock.Anything, "sensor-1").Return(&tree, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{&sensorDataEvent}, nil)
+		treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1").Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return([]*entities.MoistureTrend{}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return([]*entities.Tree{&treeWithSensorID1}, nil)
+		clusterRepo.EXPECT().SaveMoistureMetrics(mock.Anything, int32(1), mock.Anything, mock.Anything).Return(nil)
+		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, i int32, f func(*entities.TreeCluster) (bool, error)) error {
+			cluster := entities.TreeCluster{WateringStatus: entities.WateringStatusBad, WateringStatusEstimated: true, WateringStatusInferredAt: utils.P(time.Now())}
+			_, err := f(&cluster)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusBad, cluster.WateringStatus)
+			assert.False(t, cluster.WateringStatusEstimated)
+			assert.Nil(t, cluster.WateringStatusInferredAt)
+			return nil
+		})
+		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(&entities.TreeCluster{ID: 1, WateringStatus: entities.WateringStatusBad}, nil)
+
+		// when
+		err := svc.HandleNewSensorData(context.Background(), &event)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case recievedEvent := <-ch:
+			e, ok := recievedEvent.(entities.EventUpdateTreeCluster)
+			assert.True(t, ok)
+			assert.False(t, e.New.WateringStatusEstimated)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("event was not received")
+		}
+	})
+
 	t.Run("should not update and not send event if the tree of the sensor has no tree cluster", func(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		// event
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
@@ -319,4 +417,179 @@ func TestTreeClusterService_HandleNewSensorData(t *testing.T) {
 			assert.True(t, true)
 		}
 	})
+
+	t.Run("should not update and not send event if the sensor data is flagged", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
+
+		// event
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		sensorDataEvent := entities.SensorData{
+			SensorID: "sensor-1",
+			Data: &entities.MqttPayload{
+				Watermarks: []entities.Watermark{
+					{Centibar: 30, Depth: 30},
+					{Centibar: 30, Depth: 60},
+					{Centibar: 30, Depth: 90},
+				},
+			},
+			Flagged:      true,
+			AnomalyScore: 0.5,
+			Anomalies: []entities.SensorDataAnomaly{
+				{Type: entities.SensorDataAnomalyFlatline, Depth: 30, Centibar: 30, Score: 0.5},
+			},
+		}
+
+		event := entities.NewEventSensorData(&sensorDataEvent)
+
+		// when
+		err := svc.HandleNewSensorData(context.Background(), &event)
+
+		// then
+		assert.NoError(t, err)
+		treeRepo.AssertNotCalled(t, "GetBySensorID", mock.Anything, mock.Anything)
+		select {
+		case <-ch:
+			t.Fatal("event was received. It should not have been sent")
+		case <-time.After(100 * time.Millisecond):
+			assert.True(t, true)
+		}
+	})
+}
+
+func TestTreeClusterService_getWateringStatusOfTreeCluster(t *testing.T) {
+	tests := []struct {
+		name          string
+		soilCondition entities.TreeSoilCondition
+		centibar      int
+		want          entities.WateringStatus
+	}{
+		{name: "should lower thresholds on sandy soil", soilCondition: entities.TreeSoilConditionSandig, centibar: 14, want: entities.WateringStatusGood},
+		{name: "should return moderate on sandy soil", soilCondition: entities.TreeSoilConditionSandig, centibar: 18, want: entities.WateringStatusModerate},
+		{name: "should return bad on sandy soil", soilCondition: entities.TreeSoilConditionSandig, centibar: 22, want: entities.WateringStatusBad},
+		{name: "should raise thresholds on silty soil", soilCondition: entities.TreeSoilConditionSchluffig, centibar: 29, want: entities.WateringStatusGood},
+		{name: "should return moderate on silty soil", soilCondition: entities.TreeSoilConditionSchluffig, centibar: 35, want: entities.WateringStatusModerate},
+		{name: "should use default thresholds on loamy soil", soilCondition: entities.TreeSoilConditionLehmig, centibar: 28, want: entities.WateringStatusModerate},
+		{name: "should return bad on loamy soil", soilCondition: entities.TreeSoilConditionLehmig, centibar: 35, want: entities.WateringStatusBad},
+		{name: "should raise thresholds on clay soil", soilCondition: entities.TreeSoilConditionTonig, centibar: 35, want: entities.WateringStatusGood},
+		{name: "should return moderate on clay soil", soilCondition: entities.TreeSoilConditionTonig, centibar: 45, want: entities.WateringStatusModerate},
+		{name: "should use default thresholds on unknown soil", soilCondition: entities.TreeSoilConditionUnknown, centibar: 35, want: entities.WateringStatusBad},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+			treeRepo := storageMock.NewMockTreeRepository(t)
+			regionRepo := storageMock.NewMockRegionRepository(t)
+			svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, nil, nil).(*TreeClusterService)
+
+			cluster := &entities.TreeCluster{ID: 1, SoilCondition: tt.soilCondition}
+			sensorData := []*entities.SensorData{
+				{SensorID: "sensor-1", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{
+					{Centibar: tt.centibar, Depth: 30},
+					{Centibar: tt.centibar, Depth: 60},
+					{Centibar: tt.centibar, Depth: 90},
+				}}},
+			}
+			trees := []*entities.Tree{
+				{ID: 1, TreeCluster: cluster, PlantingYear: int32(time.Now().Year())},
+			}
+
+			clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return(sensorData, nil)
+			treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1").Return(trees, nil)
+
+			// when
+			got, _, err := svc.getWateringStatusOfTreeCluster(context.Background(), 1)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should use configured soil factors", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		cfg := &config.WateringStatusConfig{SoilFactors: map[string]float64{"sandig": 1.0}}
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, nil, cfg).(*TreeClusterService)
+
+		sensorData := []*entities.SensorData{
+			{SensorID: "sensor-1", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Centibar: 22, Depth: 30}}}},
+		}
+		trees := []*entities.Tree{
+			{ID: 1, TreeCluster: &entities.TreeCluster{ID: 1, SoilCondition: entities.TreeSoilConditionSandig}, PlantingYear: int32(time.Now().Year())},
+		}
+
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return(sensorData, nil)
+		treeRepo.EXPECT().GetBySensorIDs(mock.Anything, "sensor-1").Return(trees, nil)
+
+		// when
+		got, _, err := svc.getWateringStatusOfTreeCluster(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WateringStatusGood, got)
+	})
+}
+
+func TestTreeClusterService_getWatermarkSensorData(t *testing.T) {
+	t.Run("should average watermarks per depth of sensors with different probes", func(t *testing.T) {
+		// given
+		svc := &TreeClusterService{}
+		sensorData := []*entities.SensorData{
+			{SensorID: "sensor-1", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{
+				{Centibar: 40, Depth: 40},
+				{Centibar: 20, Depth: 20},
+			}}},
+			{SensorID: "sensor-2", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{
+				{Centibar: 30, Depth: 40},
+			}}},
+			{SensorID: "sensor-3", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{
+				{Centibar: 10, Depth: 20},
+				{Centibar: 50, Depth: 40},
+				{Centibar: 60, Depth: 80},
+				{Centibar: 70, Depth: 120},
+			}}},
+		}
+
+		// when
+		got, err := svc.getWatermarkSensorData(context.Background(), sensorData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Watermark{
+			{Centibar: 15, Depth: 20},
+			{Centibar: 40, Depth: 40},
+			{Centibar: 60, Depth: 80},
+			{Centibar: 70, Depth: 120},
+		}, got)
+	})
+
+	t.Run("should return error on malformed watermarks", func(t *testing.T) {
+		// given
+		svc := &TreeClusterService{}
+		sensorData := []*entities.SensorData{
+			{SensorID: "sensor-1", Data: &entities.MqttPayload{Watermarks: []entities.Watermark{
+				{Centibar: 40, Depth: 30},
+				{Centibar: 20, Depth: 30},
+			}}},
+		}
+
+		// when
+		got, err := svc.getWatermarkSensorData(context.Background(), sensorData)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
 }
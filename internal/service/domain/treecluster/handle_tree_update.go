@@ -5,6 +5,7 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 )
 
 func (s *TreeClusterService) HandleCreateTree(ctx context.Context, event *entities.EventCreateTree) error {
@@ -67,7 +68,7 @@ func (s *TreeClusterService) handleTreeClusterUpdate(ctx context.Context, tc *en
 		return nil
 	}
 
-	wateringStatus, err := s.getWateringStatusOfTreeCluster(ctx, tree.TreeCluster.ID)
+	wateringStatus, _, err := s.getWateringStatusOfTreeCluster(ctx, tree.TreeCluster.ID)
 	if err != nil {
 		log.Error("could not update watering status", "error", err)
 	}
@@ -85,7 +86,8 @@ func (s *TreeClusterService) handleTreeClusterUpdate(ctx context.Context, tc *en
 		return true, nil
 	}
 
-	if err := s.treeClusterRepo.Update(ctx, tc.ID, updateFn); err == nil {
+	// the watering status is recalculated from the sensor data of the trees in the cluster
+	if err := s.treeClusterRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseSensorData), tc.ID, updateFn); err == nil {
 		log.Info("successfully updated new tree cluster position", "cluster_id", tc.ID)
 		return s.publishUpdateEvent(ctx, tc)
 	}
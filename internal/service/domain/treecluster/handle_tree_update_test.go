@@ -286,7 +286,7 @@ func setupTest(t *testing.T) (*storageMock.MockTreeClusterRepository, *storageMo
 	treeRepo := storageMock.NewMockTreeRepository(t)
 	regionRepo := storageMock.NewMockRegionRepository(t)
 	eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 	return clusterRepo, treeRepo, regionRepo, eventManager, svc
 }
 
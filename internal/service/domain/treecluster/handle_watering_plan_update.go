@@ -2,6 +2,7 @@ package treecluster
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -28,26 +29,37 @@ func (s *TreeClusterService) HandleUpdateWateringPlan(ctx context.Context, event
 	return nil
 }
 
+// handleTreeClustersUpdate sets the last watered date of the tree clusters. The watering status is kept,
+// the watering is reflected by the next sensor data or weather update. A failed tree cluster does not stop
+// the update of the remaining ones, the errors are returned together.
 func (s *TreeClusterService) handleTreeClustersUpdate(ctx context.Context, tcs []*entities.TreeCluster, date time.Time) error {
 	log := logger.GetLogger(ctx)
 	if len(tcs) == 0 || tcs == nil {
 		return nil
 	}
 
+	var errs []error
 	for _, tc := range tcs {
 		updateFn := func(tc *entities.TreeCluster) (bool, error) {
 			tc.LastWatered = &date
 			return true, nil
 		}
 
-		if err := s.treeClusterRepo.Update(ctx, tc.ID, updateFn); err == nil {
-			log.Info("successfully updated last watered date in tree cluster", "cluster_id", tc.ID, "last_watered", date)
-			err := s.publishUpdateEvent(ctx, tc)
-			if err != nil {
-				return err
-			}
+		if err := s.treeClusterRepo.Update(ctx, tc.ID, updateFn); err != nil {
+			log.Error("failed to update last watered date in tree cluster", "cluster_id", tc.ID, "last_watered", date, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		log.Info("successfully updated last watered date in tree cluster", "cluster_id", tc.ID, "last_watered", date)
+		// the drying trend before the watering is obsolete, a new one is fitted with the next sensor data
+		if err := s.treeClusterRepo.SaveMoistureTrends(ctx, tc.ID, nil); err != nil {
+			log.Error("failed to reset moisture trends of watered tree cluster", "cluster_id", tc.ID, "error", err)
+		}
+		if err := s.publishUpdateEvent(ctx, tc); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
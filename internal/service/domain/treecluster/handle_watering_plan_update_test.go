@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func TestTreeClusterService_HandleUpdateWateringPlan(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -51,7 +52,16 @@ func TestTreeClusterService_HandleUpdateWateringPlan(t *testing.T) {
 
 		event := entities.NewEventUpdateWateringPlan(&prevWp, &updatedWp)
 
-		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).Return(nil)
+		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{ID: 1, WateringStatus: entities.WateringStatusBad}
+			ok, err := fn(tc)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, &date, tc.LastWatered)
+			assert.Equal(t, entities.WateringStatusBad, tc.WateringStatus)
+			return nil
+		})
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend(nil)).Return(nil)
 		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(&updatedTc, nil)
 
 		// when
@@ -70,12 +80,50 @@ func TestTreeClusterService_HandleUpdateWateringPlan(t *testing.T) {
 		}
 	})
 
+	t.Run("should update remaining tree clusters and return error when update of a tree cluster fails", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
+
+		date := time.Date(2024, 11, 22, 0, 0, 0, 0, time.UTC)
+		clusters := []*entities.TreeCluster{{ID: 1}, {ID: 2}}
+		prevWp := entities.WateringPlan{
+			ID:           1,
+			TreeClusters: clusters,
+			Status:       entities.WateringPlanStatusActive,
+			Date:         date,
+		}
+
+		updatedWp := entities.WateringPlan{
+			ID:           1,
+			TreeClusters: clusters,
+			Status:       entities.WateringPlanStatusFinished,
+			Date:         date,
+		}
+
+		event := entities.NewEventUpdateWateringPlan(&prevWp, &updatedWp)
+
+		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).Return(storage.ErrTreeClusterNotFound)
+		clusterRepo.EXPECT().Update(mock.Anything, int32(2), mock.Anything).Return(nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(2), []*entities.MoistureTrend(nil)).Return(nil)
+		clusterRepo.EXPECT().GetByID(mock.Anything, int32(2)).Return(&entities.TreeCluster{ID: 2, LastWatered: &date}, nil)
+
+		// when
+		err := svc.HandleUpdateWateringPlan(context.Background(), &event)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrTreeClusterNotFound)
+		clusterRepo.AssertNotCalled(t, "SaveMoistureTrends", mock.Anything, int32(1), mock.Anything)
+	})
+
 	t.Run("should not update tree cluster if status has not changed", func(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -123,7 +171,7 @@ func TestTreeClusterService_HandleUpdateWateringPlan(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -171,7 +219,7 @@ func TestTreeClusterService_HandleUpdateWateringPlan(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
 		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
 		ctx, cancel := context.WithCancel(context.Background())
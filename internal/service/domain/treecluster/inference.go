@@ -0,0 +1,128 @@
+package treecluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+const (
+	defaultInferenceInterval   = 1 * time.Hour
+	defaultInferenceMaxDataAge = 24 * time.Hour
+)
+
+func newInferenceMaxDataAge(cfg *config.WateringStatusConfig) time.Duration {
+	if cfg != nil && cfg.Inference.MaxDataAge > 0 {
+		return cfg.Inference.MaxDataAge
+	}
+	return defaultInferenceMaxDataAge
+}
+
+// RunWateringStatusInference infers the watering status of tree clusters without sensor data on start and after
+// every interval. It returns immediately if the inference is disabled.
+func (s *TreeClusterService) RunWateringStatusInference(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger(ctx)
+	if !s.inference.Enabled() {
+		log.Info("watering status inference is disabled, tree clusters without sensor data are not estimated")
+		return
+	}
+
+	if interval <= 0 {
+		interval = defaultInferenceInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.InferWateringStatus(ctx); err != nil {
+			log.Error("failed to infer watering status of tree clusters", "error", err.Error())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Info("stopping watering status inference")
+			return
+		}
+	}
+}
+
+// InferWateringStatus estimates the watering status of tree clusters without sensor data from similar tree clusters
+// with sensor data not older than the max data age. A tree cluster watered at or after the day of the estimate is
+// good. A tree cluster is only estimated again if there is newer sensor data of its neighbors than the estimate
+// is inferred from, so the watering status adjusted by the weather in between is kept.
+func (s *TreeClusterService) InferWateringStatus(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	// the inference runs without a http request, so there are no pagination values in the context
+	clusters, _, err := s.treeClusterRepo.GetAll(pagination.WithoutLimit(ctx), entities.TreeClusterQuery{})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	candidates := make([]*entities.TreeCluster, 0)
+	neighbors := make([]*svcUtils.InferenceNeighbor, 0)
+	for _, cluster := range clusters {
+		if cluster.Archived {
+			continue
+		}
+
+		latest, err := svcUtils.LatestSensorData(ctx, s.treeClusterRepo, cluster.ID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case latest == nil:
+			candidates = append(candidates, cluster)
+		case now.Sub(*latest) <= s.inferenceMaxDataAge:
+			neighbors = append(neighbors, &svcUtils.InferenceNeighbor{Cluster: cluster, MeasuredAt: *latest})
+		}
+	}
+
+	var inferred int
+	for _, cluster := range candidates {
+		estimate := s.inference.Estimate(cluster, neighbors)
+		if estimate == nil {
+			continue
+		}
+
+		if cluster.WateringStatusInferredAt != nil && !estimate.Date.After(*cluster.WateringStatusInferredAt) {
+			continue
+		}
+
+		status := estimate.Status
+		if cluster.LastWatered != nil && !svcUtils.StartOfDay(*cluster.LastWatered).Before(estimate.Date) {
+			status = entities.WateringStatusGood
+		}
+
+		updateFn := func(tc *entities.TreeCluster) (bool, error) {
+			tc.WateringStatus = status
+			tc.WateringStatusEstimated = true
+			tc.WateringStatusInferredAt = &estimate.Date
+			return true, nil
+		}
+
+		if err := s.treeClusterRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseInference), cluster.ID, updateFn); err != nil {
+			log.Error("failed to update inferred watering status of tree cluster", "error", err, "cluster_id", cluster.ID)
+			continue
+		}
+
+		if err := s.publishUpdateEvent(ctx, cluster); err != nil {
+			log.Error("failed to publish update event of tree cluster", "error", err, "cluster_id", cluster.ID)
+		}
+
+		inferred++
+		log.Debug("inferred watering status of tree cluster from neighbors", "cluster_id", cluster.ID, "watering_status", status, "neighbors", estimate.Neighbors)
+	}
+
+	log.Info("watering status of tree clusters without sensor data inferred", "inferred_clusters", inferred, "neighbors", len(neighbors))
+	return nil
+}
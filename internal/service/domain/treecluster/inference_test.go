@@ -0,0 +1,160 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestTreeClusterService_InferWateringStatus(t *testing.T) {
+	cfg := &config.WateringStatusConfig{Inference: config.InferenceConfig{Enable: true}}
+	measuredAt := time.Now().Add(-time.Hour)
+	estimateDate := measuredAt.UTC().Truncate(24 * time.Hour)
+	newCluster := func(id int32, status entities.WateringStatus, lat float64) *entities.TreeCluster {
+		return &entities.TreeCluster{
+			ID:             id,
+			WateringStatus: status,
+			SoilCondition:  entities.TreeSoilConditionSandig,
+			Latitude:       utils.P(lat),
+			Longitude:      utils.P(9.43),
+			Trees:          []*entities.Tree{{PlantingYear: 2020}},
+		}
+	}
+
+	// expectAllClusters expects the tree clusters to be fetched like the tree cluster repository does, which fails
+	// without pagination values in the context
+	expectAllClusters := func(clusterRepo *storageMock.MockTreeClusterRepository, clusters ...*entities.TreeCluster) {
+		clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).RunAndReturn(func(ctx context.Context, _ entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error) {
+			if _, _, err := pagination.GetValues(ctx); err != nil {
+				return nil, 0, err
+			}
+			return clusters, int64(len(clusters)), nil
+		})
+	}
+
+	t.Run("should infer watering status of tree clusters without sensor data from similar neighbors", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, eventManager, cfg).(*TreeClusterService)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+		sensored := newCluster(1, entities.WateringStatusBad, 54.79)
+		unsensored := newCluster(2, entities.WateringStatusUnknown, 54.791)
+		watered := newCluster(3, entities.WateringStatusUnknown, 54.792)
+		watered.LastWatered = utils.P(estimateDate.Add(2 * time.Hour))
+
+		expectAllClusters(clusterRepo, sensored, unsensored, watered)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{{CreatedAt: measuredAt}}, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(2)).Return([]*entities.SensorData{}, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(3)).Return([]*entities.SensorData{}, nil)
+		clusterRepo.EXPECT().Update(mock.Anything, int32(2), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{}
+			ok, err := fn(tc)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusBad, tc.WateringStatus)
+			assert.True(t, tc.WateringStatusEstimated)
+			assert.Equal(t, estimateDate, *tc.WateringStatusInferredAt)
+			cause, ok := storage.WateringStatusCauseFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, entities.WateringStatusCauseInference, cause)
+			return nil
+		})
+		clusterRepo.EXPECT().Update(mock.Anything, int32(3), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{}
+			ok, err := fn(tc)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			// the watering at the day of the estimate is more recent than the sensor data of the neighbors
+			assert.Equal(t, entities.WateringStatusGood, tc.WateringStatus)
+			assert.True(t, tc.WateringStatusEstimated)
+			return nil
+		})
+		clusterRepo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&entities.TreeCluster{}, nil)
+
+		// when
+		err := svc.InferWateringStatus(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not infer watering status again without newer sensor data of neighbors", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, cfg).(*TreeClusterService)
+		sensored := newCluster(1, entities.WateringStatusBad, 54.79)
+		unsensored := newCluster(2, entities.WateringStatusModerate, 54.791)
+		unsensored.WateringStatusInferredAt = utils.P(estimateDate)
+
+		expectAllClusters(clusterRepo, sensored, unsensored)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{{CreatedAt: measuredAt}}, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(2)).Return([]*entities.SensorData{}, nil)
+
+		// when
+		err := svc.InferWateringStatus(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		clusterRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not infer watering status from sensor data older than the max data age", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, cfg).(*TreeClusterService)
+		sensored := newCluster(1, entities.WateringStatusBad, 54.79)
+		unsensored := newCluster(2, entities.WateringStatusUnknown, 54.791)
+
+		expectAllClusters(clusterRepo, sensored, unsensored)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return([]*entities.SensorData{{CreatedAt: time.Now().Add(-2 * defaultInferenceMaxDataAge)}}, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(2)).Return([]*entities.SensorData{}, nil)
+
+		// when
+		err := svc.InferWateringStatus(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		clusterRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when tree clusters can not be fetched", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, cfg).(*TreeClusterService)
+		clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return(nil, int64(0), errors.New("db error"))
+
+		// when
+		err := svc.InferWateringStatus(context.Background())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestTreeClusterService_RunWateringStatusInference(t *testing.T) {
+	t.Run("should return immediately when the inference is disabled", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, nil).(*TreeClusterService)
+
+		// when
+		svc.RunWateringStatusInference(context.Background(), time.Hour)
+
+		// then
+		clusterRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,35 @@
+package treecluster
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+)
+
+// updateMoistureMetrics summarizes the latest sensor data of the tree cluster per probe depth and sets the moisture
+// level of the tree cluster to the mean centibar of all depths. The coverage is related to the trees currently
+// linked to the tree cluster. Errors are only logged, as the watering status does not depend on the metrics.
+func (s *TreeClusterService) updateMoistureMetrics(ctx context.Context, tc *entities.TreeCluster, input *wateringInput) {
+	log := logger.GetLogger(ctx)
+	trees, err := s.treeRepo.GetByTreeClusterID(ctx, tc.ID)
+	if err != nil {
+		log.Error("failed to get trees of tree cluster", "cluster_id", tc.ID, "error", err)
+		return
+	}
+
+	metrics, err := svcUtils.CalculateMoistureMetrics(input.sensorData, len(trees))
+	if err != nil {
+		log.Error("failed to calculate moisture metrics of tree cluster", "cluster_id", tc.ID, "error", err)
+		return
+	}
+
+	moistureLevel := svcUtils.MoistureLevel(metrics)
+	if err := s.treeClusterRepo.SaveMoistureMetrics(ctx, tc.ID, metrics, moistureLevel); err != nil {
+		log.Error("failed to save moisture metrics of tree cluster", "cluster_id", tc.ID, "error", err)
+		return
+	}
+
+	log.Debug("updated moisture metrics of tree cluster", "cluster_id", tc.ID, "moisture_level", moistureLevel)
+}
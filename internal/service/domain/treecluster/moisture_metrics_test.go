@@ -0,0 +1,62 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestTreeClusterService_updateMoistureMetrics(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	input := &wateringInput{
+		sensorData: []*entities.SensorData{
+			{SensorID: "sensor-1", CreatedAt: measuredAt, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Centibar: 20, Depth: 30}, {Centibar: 40, Depth: 60}}}},
+			{SensorID: "sensor-2", CreatedAt: measuredAt, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Centibar: 30, Depth: 30}, {Centibar: 50, Depth: 60}}}},
+		},
+	}
+
+	t.Run("should calculate and save moisture metrics of tree cluster", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, nil, nil, nil, nil).(*TreeClusterService)
+		tc := &entities.TreeCluster{ID: 1}
+
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return([]*entities.Tree{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+		clusterRepo.EXPECT().SaveMoistureMetrics(mock.Anything, int32(1), mock.Anything, 35.0).RunAndReturn(func(ctx context.Context, id int32, metrics *entities.MoistureMetrics, moistureLevel float64) error {
+			assert.Equal(t, int32(4), metrics.TreeCount)
+			assert.Equal(t, int32(2), metrics.SensorCount)
+			assert.Equal(t, 0.5, metrics.Coverage)
+			assert.Equal(t, measuredAt, metrics.LatestMeasuredAt)
+			assert.Len(t, metrics.Depths, 2)
+			assert.Equal(t, 25.0, metrics.Depths[0].MeanCentibar)
+			assert.Equal(t, 45.0, metrics.Depths[1].MeanCentibar)
+			return nil
+		})
+
+		// when
+		svc.updateMoistureMetrics(context.Background(), tc, input)
+	})
+
+	t.Run("should not save moisture metrics when trees can not be fetched", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, nil, nil, nil, nil).(*TreeClusterService)
+		tc := &entities.TreeCluster{ID: 1}
+
+		treeRepo.EXPECT().GetByTreeClusterID(mock.Anything, int32(1)).Return(nil, errors.New("internal error"))
+
+		// when
+		svc.updateMoistureMetrics(context.Background(), tc, input)
+
+		// then
+		clusterRepo.AssertNotCalled(t, "SaveMoistureMetrics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
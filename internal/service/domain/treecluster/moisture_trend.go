@@ -0,0 +1,66 @@
+package treecluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+)
+
+const (
+	defaultMoistureTrendWindow = 7 * 24 * time.Hour
+	// a drying trend needs enough samples to not follow the noise of single measurements
+	minMoistureTrendSamples = 3
+)
+
+func newMoistureTrendWindow(cfg *config.WateringStatusConfig) time.Duration {
+	if cfg != nil && cfg.TrendWindow > 0 {
+		return cfg.TrendWindow
+	}
+	return defaultMoistureTrendWindow
+}
+
+// updateMoistureTrends fits the drying trend per probe depth to the sensor data of the tree cluster and estimates
+// when the tree cluster turns moderate and bad. The sensor data before the last watering is ignored, as watering
+// resets the trend. The trends start at the current watermarks and use the same thresholds as the watering status.
+// Errors are only logged, as the watering status does not depend on the trends.
+func (s *TreeClusterService) updateMoistureTrends(ctx context.Context, tc *entities.TreeCluster, input *wateringInput) {
+	log := logger.GetLogger(ctx)
+	since := time.Now().Add(-s.trendWindow)
+	if tc.LastWatered != nil && tc.LastWatered.After(since) {
+		since = *tc.LastWatered
+	}
+
+	trends, err := s.treeClusterRepo.CalculateMoistureTrends(ctx, tc.ID, since)
+	if err != nil {
+		log.Error("failed to calculate moisture trends of tree cluster", "cluster_id", tc.ID, "error", err)
+		return
+	}
+
+	current := make(map[int]int, len(input.watermarks))
+	for _, w := range input.watermarks {
+		current[w.Depth] = w.Centibar
+	}
+
+	estimated := make([]*entities.MoistureTrend, 0, len(trends))
+	for _, trend := range trends {
+		centibar, ok := current[trend.Depth]
+		if !ok || trend.SampleCount < minMoistureTrendSamples {
+			continue
+		}
+
+		trend.Centibar = float64(centibar)
+		svcUtils.EstimateMoistureTrend(trend, input.plantingYear, input.thresholds)
+		estimated = append(estimated, trend)
+	}
+
+	if err := s.treeClusterRepo.SaveMoistureTrends(ctx, tc.ID, estimated); err != nil {
+		log.Error("failed to save moisture trends of tree cluster", "cluster_id", tc.ID, "error", err)
+		return
+	}
+
+	log.Debug("updated moisture trends of tree cluster", "cluster_id", tc.ID, "trends", len(estimated))
+}
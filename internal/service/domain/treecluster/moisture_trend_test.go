@@ -0,0 +1,103 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestTreeClusterService_updateMoistureTrends(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	input := &wateringInput{
+		plantingYear: int32(time.Now().Year() - 2),
+		watermarks: []entities.Watermark{
+			{Centibar: 52, Depth: 30},
+			{Centibar: 15, Depth: 60},
+			{Centibar: 20, Depth: 90},
+		},
+		thresholds: svcUtils.DefaultWateringThresholds(),
+	}
+
+	t.Run("should estimate and save moisture trends of tree cluster", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, nil).(*TreeClusterService)
+		tc := &entities.TreeCluster{ID: 1}
+
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return([]*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: 5, SampleCount: 12, MeasuredAt: measuredAt},
+			{Depth: 60, CentibarPerDay: 2, SampleCount: 12, MeasuredAt: measuredAt},
+			{Depth: 90, CentibarPerDay: 2, SampleCount: 2, MeasuredAt: measuredAt},
+			{Depth: 120, CentibarPerDay: 2, SampleCount: 12, MeasuredAt: measuredAt},
+		}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, id int32, trends []*entities.MoistureTrend) error {
+			// trends with too few samples or without current watermark are skipped
+			assert.Len(t, trends, 2)
+			assert.Equal(t, 30, trends[0].Depth)
+			assert.Equal(t, 52.0, trends[0].Centibar)
+			assert.Equal(t, measuredAt.Add(2*24*time.Hour), *trends[0].ModerateAt)
+			assert.Equal(t, 60, trends[1].Depth)
+			assert.Equal(t, 15.0, trends[1].Centibar)
+			assert.Equal(t, measuredAt.Add(5*24*time.Hour), *trends[1].ModerateAt)
+			assert.Equal(t, measuredAt.Add(9*24*time.Hour), *trends[1].BadAt)
+			return nil
+		})
+
+		// when
+		svc.updateMoistureTrends(context.Background(), tc, input)
+	})
+
+	t.Run("should fit trends to sensor data of configured window", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		cfg := &config.WateringStatusConfig{TrendWindow: 48 * time.Hour}
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, cfg).(*TreeClusterService)
+		tc := &entities.TreeCluster{ID: 1}
+
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, id int32, since time.Time) ([]*entities.MoistureTrend, error) {
+			assert.WithinDuration(t, time.Now().Add(-48*time.Hour), since, time.Minute)
+			return []*entities.MoistureTrend{}, nil
+		})
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+
+		// when
+		svc.updateMoistureTrends(context.Background(), tc, input)
+	})
+
+	t.Run("should ignore sensor data before last watering", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, nil).(*TreeClusterService)
+		lastWatered := time.Now().Add(-24 * time.Hour)
+		tc := &entities.TreeCluster{ID: 1, LastWatered: &lastWatered}
+
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), lastWatered).Return([]*entities.MoistureTrend{}, nil)
+		clusterRepo.EXPECT().SaveMoistureTrends(mock.Anything, int32(1), []*entities.MoistureTrend{}).Return(nil)
+
+		// when
+		svc.updateMoistureTrends(context.Background(), tc, input)
+	})
+
+	t.Run("should not save moisture trends when calculation fails", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewTreeClusterService(clusterRepo, nil, nil, nil, nil, nil).(*TreeClusterService)
+		tc := &entities.TreeCluster{ID: 1}
+
+		clusterRepo.EXPECT().CalculateMoistureTrends(mock.Anything, int32(1), mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		svc.updateMoistureTrends(context.Background(), tc, input)
+
+		// then
+		clusterRepo.AssertNotCalled(t, "SaveMoistureTrends", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
@@ -21,26 +24,42 @@ type TreeClusterService struct {
 	regionRepo      storage.RegionRepository
 	validator       *validator.Validate
 	eventManager    *worker.EventManager
+	rules           *svcUtils.WateringRules
+	inference       *svcUtils.Inference
+	trendWindow     time.Duration
+	// sensor data of neighbors older than this duration is not used to infer the watering status
+	inferenceMaxDataAge time.Duration
 }
 
 func NewTreeClusterService(
 	treeClusterRepo storage.TreeClusterRepository,
 	treeRepo storage.TreeRepository,
 	regionRepo storage.RegionRepository,
+	wateringRuleRepo storage.WateringRuleRepository,
 	eventManager *worker.EventManager,
+	cfg *config.WateringStatusConfig,
 ) service.TreeClusterService {
+	var inferenceCfg config.InferenceConfig
+	if cfg != nil {
+		inferenceCfg = cfg.Inference
+	}
+
 	return &TreeClusterService{
-		treeClusterRepo: treeClusterRepo,
-		treeRepo:        treeRepo,
-		regionRepo:      regionRepo,
-		validator:       validator.New(),
-		eventManager:    eventManager,
+		treeClusterRepo:     treeClusterRepo,
+		treeRepo:            treeRepo,
+		regionRepo:          regionRepo,
+		validator:           validator.New(),
+		eventManager:        eventManager,
+		rules:               svcUtils.NewWateringRules(wateringRuleRepo, cfg),
+		inference:           svcUtils.NewInference(inferenceCfg),
+		trendWindow:         newMoistureTrendWindow(cfg),
+		inferenceMaxDataAge: newInferenceMaxDataAge(cfg),
 	}
 }
 
-func (s *TreeClusterService) GetAll(ctx context.Context) ([]*domain.TreeCluster, int64, error) {
+func (s *TreeClusterService) GetAll(ctx context.Context, query domain.TreeClusterQuery) ([]*domain.TreeCluster, int64, error) {
 	log := logger.GetLogger(ctx)
-	treeClusters, totalCount, err := s.treeClusterRepo.GetAll(ctx)
+	treeClusters, totalCount, err := s.treeClusterRepo.GetAll(ctx, query)
 	if err != nil {
 		log.Debug("failed to fetch tree clsuters", "error", err)
 		return nil, 0, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
@@ -231,7 +250,7 @@ func (s *TreeClusterService) Ready() bool {
 // otherwise the center point of the tree cluster cannot be set
 func (s *TreeClusterService) updateTreeClusterPosition(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	wateringStatus, err := s.getWateringStatusOfTreeCluster(ctx, id)
+	wateringStatus, _, err := s.getWateringStatusOfTreeCluster(ctx, id)
 	if err != nil {
 		log.Error("could not update watering status", "error", err)
 	}
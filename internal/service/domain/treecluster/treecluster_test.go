@@ -24,13 +24,13 @@ func TestTreeClusterService_GetAll(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedClusters := testClusters
-		clusterRepo.EXPECT().GetAll(ctx).Return(expectedClusters, int64(len(expectedClusters)), nil)
+		clusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(expectedClusters, int64(len(expectedClusters)), nil)
 
 		// when
-		clusters, totalCount, err := svc.GetAll(ctx)
+		clusters, totalCount, err := svc.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -38,16 +38,33 @@ func TestTreeClusterService_GetAll(t *testing.T) {
 		assert.Equal(t, totalCount, int64(len(expectedClusters)))
 	})
 
+	t.Run("should pass sort order to repository", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
+
+		query := entities.TreeClusterQuery{SortBy: entities.TreeClusterSortDaysUntilBad}
+		clusterRepo.EXPECT().GetAll(ctx, query).Return(testClusters, int64(len(testClusters)), nil)
+
+		// when
+		clusters, _, err := svc.GetAll(ctx, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testClusters, clusters)
+	})
+
 	t.Run("should return empty slice when no clusters are found", func(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
-		clusterRepo.EXPECT().GetAll(ctx).Return([]*entities.TreeCluster{}, int64(0), nil)
+		clusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return([]*entities.TreeCluster{}, int64(0), nil)
 
 		// when
-		clusters, totalCount, err := svc.GetAll(ctx)
+		clusters, totalCount, err := svc.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -59,14 +76,14 @@ func TestTreeClusterService_GetAll(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedErr := errors.New("GetAll failed")
 
-		clusterRepo.EXPECT().GetAll(ctx).Return(nil, int64(0), expectedErr)
+		clusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(nil, int64(0), expectedErr)
 
 		// when
-		clusters, totalCount, err := svc.GetAll(ctx)
+		clusters, totalCount, err := svc.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -82,7 +99,7 @@ func TestTreeClusterService_GetByID(t *testing.T) {
 	clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 	treeRepo := storageMock.NewMockTreeRepository(t)
 	regionRepo := storageMock.NewMockRegionRepository(t)
-	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 	t.Run("should return tree cluster when found", func(t *testing.T) {
 		id := int32(1)
@@ -126,7 +143,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedCluster := testClusters[0]
 
@@ -168,7 +185,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		newCluster := &entities.TreeClusterCreate{
 			Name:          "Cluster 1",
@@ -213,7 +230,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedErr := storage.ErrTreeNotFound
 
@@ -235,7 +252,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedErr := errors.New("Failed to create cluster")
 		expectedTrees := testTrees
@@ -263,7 +280,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedCluster := testClusters[0]
 		expectedErr := errors.New("Failed to create cluster")
@@ -309,7 +326,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		newCluster := &entities.TreeClusterCreate{
 			Name:          "",
@@ -344,7 +361,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedCluster := testClusters[0]
 		expectedTrees := testTrees
@@ -384,7 +401,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		updatedClusterEmptyTrees := &entities.TreeClusterUpdate{
 			Name:          "Cluster 1",
@@ -426,7 +443,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		treeRepo.EXPECT().GetTreesByIDs(
 			ctx,
@@ -446,7 +463,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		expectedErr := errors.New("failed to update cluster")
 		expectedTrees := testTrees
@@ -476,7 +493,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		treeRepo.EXPECT().GetTreesByIDs(
 			ctx,
@@ -504,7 +521,7 @@ func TestTreeClusterService_Update(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		updateCluster := &entities.TreeClusterUpdate{
 			Name:          "",
@@ -572,7 +589,7 @@ func TestTreeClusterService_EventSystem(t *testing.T) {
 			mock.Anything,
 		).Return(nil)
 
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, eventManager, nil)
 
 		// when
 		subID, ch, err := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
@@ -600,7 +617,7 @@ func TestTreeClusterService_Delete(t *testing.T) {
 	clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 	treeRepo := storageMock.NewMockTreeRepository(t)
 	regionRepo := storageMock.NewMockRegionRepository(t)
-	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+	svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 	t.Run("should successfully delete a tree cluster", func(t *testing.T) {
 		id := int32(1)
@@ -668,7 +685,7 @@ func TestReady(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, nil, globalEventManager, nil)
 
 		// when
 		ready := svc.Ready()
@@ -678,7 +695,7 @@ func TestReady(t *testing.T) {
 	})
 
 	t.Run("should return false if the service is not ready", func(t *testing.T) {
-		svc := NewTreeClusterService(nil, nil, nil, nil)
+		svc := NewTreeClusterService(nil, nil, nil, nil, nil, nil)
 
 		// when
 		ready := svc.Ready()
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// CalculateMoistureMetrics summarizes the latest sensor data of the sensors in a tree cluster with the given number
+// of trees. The centibar statistics are calculated per probe depth over the sensors that have a probe at this depth.
+// Returns nil without sensor data.
+func CalculateMoistureMetrics(sensorData []*entities.SensorData, treeCount int) (*entities.MoistureMetrics, error) {
+	if len(sensorData) == 0 {
+		return nil, nil
+	}
+
+	metrics := &entities.MoistureMetrics{
+		TreeCount:   int32(treeCount),
+		SensorCount: int32(len(sensorData)),
+	}
+
+	depths := make(map[int]*entities.DepthMoisture)
+	for _, data := range sensorData {
+		watermarks, err := SortWatermarks(data.Data.Watermarks)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range watermarks {
+			centibar := float64(w.Centibar)
+			depth, ok := depths[w.Depth]
+			if !ok {
+				depth = &entities.DepthMoisture{Depth: w.Depth, MinCentibar: centibar, MaxCentibar: centibar}
+				depths[w.Depth] = depth
+			}
+			depth.SensorCount++
+			depth.MinCentibar = math.Min(depth.MinCentibar, centibar)
+			depth.MaxCentibar = math.Max(depth.MaxCentibar, centibar)
+			// the sum is divided by the number of sensors below
+			depth.MeanCentibar += centibar
+		}
+
+		if metrics.LatestMeasuredAt.IsZero() || data.CreatedAt.After(metrics.LatestMeasuredAt) {
+			metrics.LatestMeasuredAt = data.CreatedAt
+		}
+		if metrics.OldestMeasuredAt.IsZero() || data.CreatedAt.Before(metrics.OldestMeasuredAt) {
+			metrics.OldestMeasuredAt = data.CreatedAt
+		}
+	}
+
+	metrics.Depths = make([]*entities.DepthMoisture, 0, len(depths))
+	for _, depth := range slices.Sorted(maps.Keys(depths)) {
+		d := depths[depth]
+		d.MeanCentibar /= float64(d.SensorCount)
+		metrics.Depths = append(metrics.Depths, d)
+	}
+
+	// a tree has at most one sensor, but trees may be unlinked from the tree cluster after their data was measured
+	if treeCount > 0 {
+		metrics.Coverage = math.Min(float64(len(sensorData))/float64(treeCount), 1)
+	}
+
+	return metrics, nil
+}
+
+// MoistureLevel returns the moisture level of a tree cluster as the mean of the mean centibar of all probe depths.
+// Returns 0 without moisture metrics.
+func MoistureLevel(metrics *entities.MoistureMetrics) float64 {
+	if metrics == nil || len(metrics.Depths) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, depth := range metrics.Depths {
+		sum += depth.MeanCentibar
+	}
+
+	return sum / float64(len(metrics.Depths))
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CalculateMoistureMetrics(t *testing.T) {
+	oldest := time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+	latest := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should calculate centibar statistics per depth, coverage and freshness", func(t *testing.T) {
+		// given
+		sensorData := []*entities.SensorData{
+			{SensorID: "sensor-1", CreatedAt: latest, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 60, Centibar: 40}, {Depth: 30, Centibar: 20}}}},
+			{SensorID: "sensor-2", CreatedAt: oldest, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30, Centibar: 30}, {Depth: 60, Centibar: 50}, {Depth: 90, Centibar: 70}}}},
+		}
+
+		// when
+		got, err := CalculateMoistureMetrics(sensorData, 4)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), got.TreeCount)
+		assert.Equal(t, int32(2), got.SensorCount)
+		assert.Equal(t, 0.5, got.Coverage)
+		assert.Equal(t, latest, got.LatestMeasuredAt)
+		assert.Equal(t, oldest, got.OldestMeasuredAt)
+		assert.Equal(t, []*entities.DepthMoisture{
+			{Depth: 30, SensorCount: 2, MinCentibar: 20, MeanCentibar: 25, MaxCentibar: 30},
+			{Depth: 60, SensorCount: 2, MinCentibar: 40, MeanCentibar: 45, MaxCentibar: 50},
+			{Depth: 90, SensorCount: 1, MinCentibar: 70, MeanCentibar: 70, MaxCentibar: 70},
+		}, got.Depths)
+	})
+
+	t.Run("should limit coverage to all trees", func(t *testing.T) {
+		// given
+		sensorData := []*entities.SensorData{
+			{CreatedAt: latest, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30, Centibar: 20}}}},
+			{CreatedAt: latest, Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30, Centibar: 20}}}},
+		}
+
+		// when
+		got, err := CalculateMoistureMetrics(sensorData, 1)
+		gotWithoutTrees, errWithoutTrees := CalculateMoistureMetrics(sensorData, 0)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, got.Coverage)
+		assert.NoError(t, errWithoutTrees)
+		assert.Equal(t, 0.0, gotWithoutTrees.Coverage)
+	})
+
+	t.Run("should return nil without sensor data", func(t *testing.T) {
+		// when
+		got, err := CalculateMoistureMetrics(nil, 4)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return err on malformed watermarks", func(t *testing.T) {
+		// given
+		sensorData := []*entities.SensorData{
+			{Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30}, {Depth: 30}}}},
+		}
+
+		// when
+		got, err := CalculateMoistureMetrics(sensorData, 4)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func Test_MoistureLevel(t *testing.T) {
+	t.Run("should return mean of the mean centibar of all depths", func(t *testing.T) {
+		// given
+		metrics := &entities.MoistureMetrics{Depths: []*entities.DepthMoisture{
+			{Depth: 30, MeanCentibar: 25},
+			{Depth: 60, MeanCentibar: 45},
+			{Depth: 90, MeanCentibar: 70},
+		}}
+
+		// when
+		got := MoistureLevel(metrics)
+
+		// then
+		assert.Equal(t, 140.0/3, got)
+	})
+
+	t.Run("should return 0 without moisture metrics", func(t *testing.T) {
+		// when
+		got := MoistureLevel(nil)
+
+		// then
+		assert.Equal(t, 0.0, got)
+	})
+}
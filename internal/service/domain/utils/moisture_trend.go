@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// maxMoistureTrendDays limits the extrapolation of a drying trend, crossings further ahead are not estimated
+const maxMoistureTrendDays = 365
+
+// EstimateMoistureTrend extrapolates the drying trend of a probe depth linearly to the moderate and bad threshold
+// of the tree's lifetime whose depth is nearest to the depth of the trend, see CalculateWateringStatus.
+// A threshold that is already reached is estimated at the time of the latest measurement. Without a drying trend
+// or a threshold for the tree's lifetime the threshold crossings are not estimated.
+func EstimateMoistureTrend(trend *entities.MoistureTrend, plantingYear int32, thresholds []entities.WateringThreshold) {
+	trend.ModerateAt, trend.BadAt = nil, nil
+
+	treeLifetime := int32(time.Now().Year()) - plantingYear
+	threshold, ok := findWateringThreshold(thresholds, treeLifetime, trend.Depth)
+	if !ok {
+		return
+	}
+
+	trend.ModerateAt = estimateThresholdCrossing(trend, float64(threshold.Moderate))
+	trend.BadAt = estimateThresholdCrossing(trend, float64(threshold.Bad))
+}
+
+func estimateThresholdCrossing(trend *entities.MoistureTrend, threshold float64) *time.Time {
+	if trend.Centibar >= threshold {
+		crossedAt := trend.MeasuredAt
+		return &crossedAt
+	}
+
+	if trend.CentibarPerDay <= 0 {
+		return nil
+	}
+
+	days := (threshold - trend.Centibar) / trend.CentibarPerDay
+	if days > maxMoistureTrendDays {
+		return nil
+	}
+
+	crossedAt := trend.MeasuredAt.Add(time.Duration(days * float64(24*time.Hour)))
+	return &crossedAt
+}
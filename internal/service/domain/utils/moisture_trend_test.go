@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EstimateMoistureTrend(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	secondYear := int32(time.Now().Year() - 2)
+
+	t.Run("should extrapolate drying trend to thresholds of depth", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 60, Centibar: 15, CentibarPerDay: 2, MeasuredAt: measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, secondYear, DefaultWateringThresholds())
+
+		// then
+		assert.Equal(t, measuredAt.Add(5*24*time.Hour), *trend.ModerateAt)
+		assert.Equal(t, measuredAt.Add(9*24*time.Hour), *trend.BadAt)
+	})
+
+	t.Run("should use threshold of nearest depth", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 20, Centibar: 52, CentibarPerDay: 5, MeasuredAt: measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, secondYear, DefaultWateringThresholds())
+
+		// then
+		assert.Equal(t, measuredAt.Add(2*24*time.Hour), *trend.ModerateAt)
+		assert.Equal(t, measuredAt.Add(time.Duration(5.8*float64(24*time.Hour))), *trend.BadAt)
+	})
+
+	t.Run("should estimate reached threshold at time of measurement", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 60, Centibar: 28, CentibarPerDay: -1, MeasuredAt: measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, secondYear, DefaultWateringThresholds())
+
+		// then
+		assert.Equal(t, measuredAt, *trend.ModerateAt)
+		assert.Nil(t, trend.BadAt)
+	})
+
+	t.Run("should not estimate without drying trend", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 60, Centibar: 15, CentibarPerDay: 0, MeasuredAt: measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, secondYear, DefaultWateringThresholds())
+
+		// then
+		assert.Nil(t, trend.ModerateAt)
+		assert.Nil(t, trend.BadAt)
+	})
+
+	t.Run("should not estimate crossings more than a year ahead", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 30, Centibar: 20, CentibarPerDay: 0.01, MeasuredAt: measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, int32(time.Now().Year()-3), DefaultWateringThresholds())
+
+		// then
+		assert.Nil(t, trend.ModerateAt)
+		assert.Nil(t, trend.BadAt)
+	})
+
+	t.Run("should reset estimation without threshold for tree age", func(t *testing.T) {
+		// given
+		trend := &entities.MoistureTrend{Depth: 60, Centibar: 15, CentibarPerDay: 2, MeasuredAt: measuredAt, ModerateAt: &measuredAt, BadAt: &measuredAt}
+
+		// when
+		EstimateMoistureTrend(trend, int32(time.Now().Year()-10), DefaultWateringThresholds())
+
+		// then
+		assert.Nil(t, trend.ModerateAt)
+		assert.Nil(t, trend.BadAt)
+	})
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// LatestSensorData returns the time of the latest not flagged sensor data of the tree cluster or nil without
+// sensor data
+func LatestSensorData(ctx context.Context, clusterRepo storage.TreeClusterRepository, clusterID int32) (*time.Time, error) {
+	sensorData, err := clusterRepo.GetAllLatestSensorDataByClusterID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *time.Time
+	for _, data := range sensorData {
+		if !data.Flagged && (latest == nil || data.CreatedAt.After(*latest)) {
+			latest = &data.CreatedAt
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestSensorData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("should return time of the latest not flagged sensor data", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{
+			{CreatedAt: now.Add(-2 * time.Hour)},
+			{CreatedAt: now, Flagged: true},
+			{CreatedAt: now.Add(-time.Hour)},
+		}, nil)
+
+		// when
+		got, err := LatestSensorData(ctx, clusterRepo, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(-time.Hour), *got)
+	})
+
+	t.Run("should return nil without sensor data", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return(nil, nil)
+
+		// when
+		got, err := LatestSensorData(ctx, clusterRepo, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when sensor data can not be fetched", func(t *testing.T) {
+		// given
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return(nil, errors.New("db error"))
+
+		// when
+		got, err := LatestSensorData(ctx, clusterRepo, 1)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"math"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	defaultInferenceRadius           = 1000
+	defaultInferenceMaxAgeDifference = 5
+	defaultInferenceMinNeighbors     = 1
+
+	// neighbors closer than this distance in meters are weighted as if they were at this distance
+	minInferenceDistance = 10
+)
+
+// InferenceNeighbor is a tree cluster with current sensor data and the time of its latest sensor data
+type InferenceNeighbor struct {
+	Cluster    *entities.TreeCluster
+	MeasuredAt time.Time
+}
+
+// StatusEstimate is the watering status of a tree cluster inferred from its neighbors at the day of their latest
+// sensor data
+type StatusEstimate struct {
+	Status    entities.WateringStatus
+	Date      time.Time
+	Neighbors int
+}
+
+// Inference estimates the watering status of tree clusters without sensor data from similar tree clusters with
+// current sensor data. A neighbor is similar if it lies within the radius, has the same soil condition and the
+// average planting year of its trees differs at most the max age difference from the tree cluster.
+type Inference struct {
+	enabled          bool
+	radius           float64
+	maxAgeDifference float64
+	minNeighbors     int
+}
+
+func NewInference(cfg config.InferenceConfig) *Inference {
+	i := &Inference{
+		enabled:          cfg.Enable,
+		radius:           cfg.Radius,
+		maxAgeDifference: cfg.MaxAgeDifference,
+		minNeighbors:     cfg.MinNeighbors,
+	}
+
+	if i.radius <= 0 {
+		i.radius = defaultInferenceRadius
+	}
+
+	if i.maxAgeDifference <= 0 {
+		i.maxAgeDifference = defaultInferenceMaxAgeDifference
+	}
+
+	if i.minNeighbors <= 0 {
+		i.minNeighbors = defaultInferenceMinNeighbors
+	}
+
+	return i
+}
+
+// Enabled reports if the inference is enabled in the config
+func (i *Inference) Enabled() bool {
+	return i.enabled
+}
+
+// Estimate returns the watering status of the tree cluster inferred from the similar neighbors. Returns nil if the
+// inference is disabled or there are less similar neighbors than required.
+func (i *Inference) Estimate(cluster *entities.TreeCluster, neighbors []*InferenceNeighbor) *StatusEstimate {
+	if !i.enabled || cluster.Latitude == nil || cluster.Longitude == nil {
+		return nil
+	}
+
+	plantingYear, ok := averagePlantingYear(cluster.Trees)
+	if !ok {
+		return nil
+	}
+
+	var weightedSeverity, weights float64
+	var latest time.Time
+	var count int
+	for _, neighbor := range neighbors {
+		nc := neighbor.Cluster
+		if nc.ID == cluster.ID || nc.SoilCondition != cluster.SoilCondition || nc.Latitude == nil || nc.Longitude == nil {
+			continue
+		}
+
+		value := float64(StatusSeverity(nc.WateringStatus))
+		if value == 0 {
+			continue
+		}
+
+		neighborYear, ok := averagePlantingYear(nc.Trees)
+		if !ok || math.Abs(neighborYear-plantingYear) > i.maxAgeDifference {
+			continue
+		}
+
+		distance := utils.DistanceInMeters(*cluster.Latitude, *cluster.Longitude, *nc.Latitude, *nc.Longitude)
+		if distance > i.radius {
+			continue
+		}
+
+		weight := 1 / math.Max(distance, minInferenceDistance)
+		weightedSeverity += value * weight
+		weights += weight
+		count++
+		if neighbor.MeasuredAt.After(latest) {
+			latest = neighbor.MeasuredAt
+		}
+	}
+
+	if count == 0 || count < i.minNeighbors {
+		return nil
+	}
+
+	return &StatusEstimate{
+		// the estimate is the distance weighted mean of the severity of the neighbors
+		Status:    statusOfSeverity(int(math.Round(weightedSeverity / weights))),
+		Date:      StartOfDay(latest),
+		Neighbors: count,
+	}
+}
+
+// StatusSeverity ranks the watering status from good to bad, an unknown watering status ranks lowest
+func StatusSeverity(status entities.WateringStatus) int {
+	switch status {
+	case entities.WateringStatusGood:
+		return 1
+	case entities.WateringStatusModerate:
+		return 2
+	case entities.WateringStatusBad:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func statusOfSeverity(value int) entities.WateringStatus {
+	switch {
+	case value >= StatusSeverity(entities.WateringStatusBad):
+		return entities.WateringStatusBad
+	case value >= StatusSeverity(entities.WateringStatusModerate):
+		return entities.WateringStatusModerate
+	default:
+		return entities.WateringStatusGood
+	}
+}
+
+// averagePlantingYear returns the average planting year of the trees, trees without planting year are ignored
+func averagePlantingYear(trees []*entities.Tree) (float64, bool) {
+	var sum float64
+	var count int
+	for _, tree := range trees {
+		if tree == nil || tree.PlantingYear <= 0 {
+			continue
+		}
+		sum += float64(tree.PlantingYear)
+		count++
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / float64(count), true
+}
+
+// StartOfDay returns the start of the day of the time in UTC
+func StartOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
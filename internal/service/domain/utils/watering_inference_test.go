@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// testInferenceCluster returns a tree cluster with sandy soil, trees planted in the given year and a latitude
+// offset of about 111m per 0.001 to the tree cluster at 54.79, 9.43
+func testInferenceCluster(id int32, status entities.WateringStatus, latOffset float64, plantingYear int32) *entities.TreeCluster {
+	return &entities.TreeCluster{
+		ID:             id,
+		WateringStatus: status,
+		SoilCondition:  entities.TreeSoilConditionSandig,
+		Latitude:       utils.P(54.79 + latOffset),
+		Longitude:      utils.P(9.43),
+		Trees:          []*entities.Tree{{PlantingYear: plantingYear}, {PlantingYear: plantingYear}},
+	}
+}
+
+func TestNewInference(t *testing.T) {
+	t.Run("should use configured values", func(t *testing.T) {
+		// when
+		got := NewInference(config.InferenceConfig{Enable: true, Radius: 500, MaxAgeDifference: 2, MinNeighbors: 3})
+
+		// then
+		assert.True(t, got.enabled)
+		assert.Equal(t, 500.0, got.radius)
+		assert.Equal(t, 2.0, got.maxAgeDifference)
+		assert.Equal(t, 3, got.minNeighbors)
+	})
+
+	t.Run("should use defaults without config", func(t *testing.T) {
+		// when
+		got := NewInference(config.InferenceConfig{})
+
+		// then
+		assert.False(t, got.enabled)
+		assert.Equal(t, float64(defaultInferenceRadius), got.radius)
+		assert.Equal(t, float64(defaultInferenceMaxAgeDifference), got.maxAgeDifference)
+		assert.Equal(t, defaultInferenceMinNeighbors, got.minNeighbors)
+	})
+}
+
+func TestInference_Estimate(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 3, 14, 0, 0, 0, time.UTC)
+	inference := NewInference(config.InferenceConfig{Enable: true})
+
+	t.Run("should estimate distance weighted watering status of similar neighbors", func(t *testing.T) {
+		// given
+		cluster := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		neighbors := []*InferenceNeighbor{
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.001, 2021), MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(3, entities.WateringStatusGood, 0.005, 2019), MeasuredAt: measuredAt.Add(-24 * time.Hour)},
+		}
+
+		// when
+		got := inference.Estimate(cluster, neighbors)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, entities.WateringStatusBad, got.Status)
+		assert.Equal(t, StartOfDay(measuredAt), got.Date)
+		assert.Equal(t, 2, got.Neighbors)
+	})
+
+	t.Run("should estimate moderate watering status between good and bad neighbors at same distance", func(t *testing.T) {
+		// given
+		cluster := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		neighbors := []*InferenceNeighbor{
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.002, 2020), MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(3, entities.WateringStatusGood, -0.002, 2020), MeasuredAt: measuredAt},
+		}
+
+		// when
+		got := inference.Estimate(cluster, neighbors)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, entities.WateringStatusModerate, got.Status)
+	})
+
+	t.Run("should ignore neighbors that are not similar", func(t *testing.T) {
+		// given
+		cluster := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		otherSoil := testInferenceCluster(3, entities.WateringStatusBad, 0.001, 2020)
+		otherSoil.SoilCondition = entities.TreeSoilConditionTonig
+		withoutTrees := testInferenceCluster(7, entities.WateringStatusBad, 0.001, 2020)
+		withoutTrees.Trees = nil
+		neighbors := []*InferenceNeighbor{
+			{Cluster: cluster, MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.02, 2020), MeasuredAt: measuredAt},
+			{Cluster: otherSoil, MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(4, entities.WateringStatusBad, 0.001, 2010), MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(5, entities.WateringStatusUnknown, 0.001, 2020), MeasuredAt: measuredAt},
+			{Cluster: withoutTrees, MeasuredAt: measuredAt},
+			{Cluster: testInferenceCluster(6, entities.WateringStatusGood, 0.003, 2024), MeasuredAt: measuredAt},
+		}
+
+		// when
+		got := inference.Estimate(cluster, neighbors)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, entities.WateringStatusGood, got.Status)
+		assert.Equal(t, 1, got.Neighbors)
+	})
+
+	t.Run("should return nil with less similar neighbors than required", func(t *testing.T) {
+		// given
+		inference := NewInference(config.InferenceConfig{Enable: true, MinNeighbors: 2})
+		cluster := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		neighbors := []*InferenceNeighbor{
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.001, 2020), MeasuredAt: measuredAt},
+		}
+
+		// when
+		got := inference.Estimate(cluster, neighbors)
+
+		// then
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return nil for tree cluster without location or trees", func(t *testing.T) {
+		// given
+		withoutLocation := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		withoutLocation.Latitude = nil
+		withoutTrees := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		withoutTrees.Trees = []*entities.Tree{{PlantingYear: 0}}
+		neighbors := []*InferenceNeighbor{
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.001, 2020), MeasuredAt: measuredAt},
+		}
+
+		// when
+		gotWithoutLocation := inference.Estimate(withoutLocation, neighbors)
+		gotWithoutTrees := inference.Estimate(withoutTrees, neighbors)
+
+		// then
+		assert.Nil(t, gotWithoutLocation)
+		assert.Nil(t, gotWithoutTrees)
+	})
+
+	t.Run("should return nil when inference is disabled", func(t *testing.T) {
+		// given
+		inference := NewInference(config.InferenceConfig{})
+		cluster := testInferenceCluster(1, entities.WateringStatusUnknown, 0, 2020)
+		neighbors := []*InferenceNeighbor{
+			{Cluster: testInferenceCluster(2, entities.WateringStatusBad, 0.001, 2020), MeasuredAt: measuredAt},
+		}
+
+		// when
+		got := inference.Estimate(cluster, neighbors)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// WateringRules provides the thresholds used to calculate the watering status. The thresholds of the
+// active watering rule set are used, without an active rule set the configured thresholds apply.
+type WateringRules struct {
+	ruleRepo    storage.WateringRuleRepository
+	configured  []entities.WateringThreshold
+	soilFactors map[entities.TreeSoilCondition]float64
+}
+
+func NewWateringRules(ruleRepo storage.WateringRuleRepository, cfg *config.WateringStatusConfig) *WateringRules {
+	return &WateringRules{
+		ruleRepo:    ruleRepo,
+		configured:  NewWateringThresholds(cfg),
+		soilFactors: NewSoilFactors(cfg),
+	}
+}
+
+// Thresholds returns the thresholds of the active watering rule set or the configured thresholds
+func (r *WateringRules) Thresholds(ctx context.Context) []entities.WateringThreshold {
+	if r.ruleRepo == nil {
+		return r.configured
+	}
+
+	ruleSet, err := r.ruleRepo.GetActive(ctx)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if !errors.As(err, &entityNotFoundErr) {
+			logger.GetLogger(ctx).Error("failed to get active watering rule set, using configured thresholds", "error", err)
+		}
+		return r.configured
+	}
+
+	return ruleSet.Thresholds
+}
+
+// Applicable returns the thresholds that apply to a tree of the given species in a tree cluster with the given
+// soil condition, see Select
+func (r *WateringRules) Applicable(ctx context.Context, species string, soilCondition *entities.TreeSoilCondition) []entities.WateringThreshold {
+	return r.Select(r.Thresholds(ctx), species, soilCondition)
+}
+
+// Select filters the thresholds with ApplicableWateringThresholds and adjusts the remaining ones to the soil condition
+func (r *WateringRules) Select(thresholds []entities.WateringThreshold, species string, soilCondition *entities.TreeSoilCondition) []entities.WateringThreshold {
+	return AdjustToSoilCondition(ApplicableWateringThresholds(thresholds, species, soilCondition), soilCondition, r.soilFactors)
+}
+
+// Depths returns the sorted watermark depths in cm the active or configured thresholds are defined for
+func (r *WateringRules) Depths(ctx context.Context) []int {
+	var depths []int
+	for _, t := range r.Thresholds(ctx) {
+		if !slices.Contains(depths, t.Depth) {
+			depths = append(depths, t.Depth)
+		}
+	}
+
+	slices.Sort(depths)
+	return depths
+}
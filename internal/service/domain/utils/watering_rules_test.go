@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRules_Thresholds(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.WateringStatusConfig{
+		Thresholds: []config.WateringThresholdConfig{
+			{MinAge: 0, MaxAge: 5, Depth: 20, Moderate: 30, Bad: 50},
+		},
+	}
+	active := []entities.WateringThreshold{
+		{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 40, Bad: 60},
+	}
+
+	t.Run("should return thresholds of the active rule set", func(t *testing.T) {
+		// given
+		ruleRepo := storageMock.NewMockWateringRuleRepository(t)
+		ruleRepo.EXPECT().GetActive(ctx).Return(&entities.WateringRuleSet{ID: 1, Active: true, Thresholds: active}, nil)
+		rules := NewWateringRules(ruleRepo, cfg)
+
+		// when
+		got := rules.Thresholds(ctx)
+
+		// then
+		assert.Equal(t, active, got)
+	})
+
+	t.Run("should return configured thresholds without active rule set", func(t *testing.T) {
+		// given
+		ruleRepo := storageMock.NewMockWateringRuleRepository(t)
+		ruleRepo.EXPECT().GetActive(ctx).Return(nil, storage.ErrEntityNotFound("not found"))
+		rules := NewWateringRules(ruleRepo, cfg)
+
+		// when
+		got := rules.Thresholds(ctx)
+
+		// then
+		assert.Equal(t, NewWateringThresholds(cfg), got)
+	})
+
+	t.Run("should return configured thresholds when fetching the active rule set fails", func(t *testing.T) {
+		// given
+		ruleRepo := storageMock.NewMockWateringRuleRepository(t)
+		ruleRepo.EXPECT().GetActive(ctx).Return(nil, errors.New("internal error"))
+		rules := NewWateringRules(ruleRepo, nil)
+
+		// when
+		got := rules.Thresholds(ctx)
+
+		// then
+		assert.Equal(t, DefaultWateringThresholds(), got)
+	})
+
+	t.Run("should return configured thresholds without repository", func(t *testing.T) {
+		assert.Equal(t, NewWateringThresholds(cfg), NewWateringRules(nil, cfg).Thresholds(ctx))
+	})
+}
+
+func TestWateringRules_Depths(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return depths of the default thresholds", func(t *testing.T) {
+		// given
+		rules := NewWateringRules(nil, nil)
+
+		// when
+		got := rules.Depths(ctx)
+
+		// then
+		assert.Equal(t, []int{30, 60, 90}, got)
+	})
+
+	t.Run("should return sorted depths of the active rule set once", func(t *testing.T) {
+		// given
+		ruleRepo := storageMock.NewMockWateringRuleRepository(t)
+		ruleRepo.EXPECT().GetActive(ctx).Return(&entities.WateringRuleSet{ID: 1, Active: true, Thresholds: []entities.WateringThreshold{
+			{MinAge: 0, MaxAge: 5, Depth: 45, Moderate: 40, Bad: 60},
+			{MinAge: 0, MaxAge: 5, Depth: 15, Moderate: 30, Bad: 50},
+			{MinAge: 6, MaxAge: 99, Depth: 45, Moderate: 60, Bad: 80},
+		}}, nil)
+		rules := NewWateringRules(ruleRepo, nil)
+
+		// when
+		got := rules.Depths(ctx)
+
+		// then
+		assert.Equal(t, []int{15, 45}, got)
+	})
+}
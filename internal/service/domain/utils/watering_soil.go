@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// DefaultSoilFactors returns the factors the thresholds are scaled with per soil condition. The default
+// thresholds are calibrated for loamy soil. Sandy soils release most of their plant available water at low
+// tension, so the trees need water at lower centibar values. Silty and clay soils hold more water at higher tension.
+func DefaultSoilFactors() map[entities.TreeSoilCondition]float64 {
+	return map[entities.TreeSoilCondition]float64{
+		entities.TreeSoilConditionSchluffig: 1.2,
+		entities.TreeSoilConditionSandig:    0.6,
+		entities.TreeSoilConditionLehmig:    1.0,
+		entities.TreeSoilConditionTonig:     1.5,
+	}
+}
+
+// NewSoilFactors returns the default soil factors overwritten by the configured ones.
+// Unknown soil conditions and factors that are not positive are skipped.
+func NewSoilFactors(cfg *config.WateringStatusConfig) map[entities.TreeSoilCondition]float64 {
+	factors := DefaultSoilFactors()
+	if cfg == nil {
+		return factors
+	}
+
+	for soil, factor := range cfg.SoilFactors {
+		if _, ok := factors[entities.TreeSoilCondition(soil)]; !ok || factor <= 0 {
+			slog.Warn("skipping invalid soil factor", "soil_condition", soil, "factor", factor)
+			continue
+		}
+		factors[entities.TreeSoilCondition(soil)] = factor
+	}
+
+	return factors
+}
+
+// AdjustToSoilCondition scales the thresholds with the factor of the soil condition. Thresholds that
+// are defined for a soil condition are already calibrated for it and returned unchanged, as are all
+// thresholds if the soil condition is unknown.
+func AdjustToSoilCondition(thresholds []entities.WateringThreshold, soilCondition *entities.TreeSoilCondition, factors map[entities.TreeSoilCondition]float64) []entities.WateringThreshold {
+	if soilCondition == nil {
+		return thresholds
+	}
+
+	factor, ok := factors[*soilCondition]
+	if !ok || factor == 1 {
+		return thresholds
+	}
+
+	adjusted := make([]entities.WateringThreshold, 0, len(thresholds))
+	for _, t := range thresholds {
+		if t.SoilCondition == nil {
+			t.Moderate = int(math.Round(float64(t.Moderate) * factor))
+			t.Bad = int(math.Round(float64(t.Bad) * factor))
+		}
+		adjusted = append(adjusted, t)
+	}
+
+	return adjusted
+}
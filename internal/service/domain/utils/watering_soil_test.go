@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewSoilFactors(t *testing.T) {
+	t.Run("should return default soil factors without config", func(t *testing.T) {
+		assert.Equal(t, DefaultSoilFactors(), NewSoilFactors(nil))
+		assert.Equal(t, DefaultSoilFactors(), NewSoilFactors(&config.WateringStatusConfig{}))
+	})
+
+	t.Run("should overwrite defaults with configured factors and skip invalid ones", func(t *testing.T) {
+		// given
+		cfg := &config.WateringStatusConfig{
+			SoilFactors: map[string]float64{
+				"sandig": 0.5,
+				"tonig":  0,
+				"moorig": 2,
+			},
+		}
+
+		// when
+		got := NewSoilFactors(cfg)
+
+		// then
+		assert.Equal(t, 0.5, got[entities.TreeSoilConditionSandig])
+		assert.Equal(t, DefaultSoilFactors()[entities.TreeSoilConditionTonig], got[entities.TreeSoilConditionTonig])
+		assert.NotContains(t, got, entities.TreeSoilCondition("moorig"))
+	})
+}
+
+func Test_AdjustToSoilCondition(t *testing.T) {
+	thresholds := []entities.WateringThreshold{
+		{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+		{MinAge: 2, MaxAge: 2, Depth: 30, Moderate: 62, Bad: 81},
+	}
+
+	tests := []struct {
+		name          string
+		soilCondition entities.TreeSoilCondition
+		want          []entities.WateringThreshold
+	}{
+		{
+			name:          "should scale thresholds on silty soil",
+			soilCondition: entities.TreeSoilConditionSchluffig,
+			want: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 30, Bad: 40},
+				{MinAge: 2, MaxAge: 2, Depth: 30, Moderate: 74, Bad: 97},
+			},
+		},
+		{
+			name:          "should scale thresholds on sandy soil",
+			soilCondition: entities.TreeSoilConditionSandig,
+			want: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 15, Bad: 20},
+				{MinAge: 2, MaxAge: 2, Depth: 30, Moderate: 37, Bad: 49},
+			},
+		},
+		{
+			name:          "should keep thresholds on loamy soil",
+			soilCondition: entities.TreeSoilConditionLehmig,
+			want:          thresholds,
+		},
+		{
+			name:          "should scale thresholds on clay soil",
+			soilCondition: entities.TreeSoilConditionTonig,
+			want: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 38, Bad: 50},
+				{MinAge: 2, MaxAge: 2, Depth: 30, Moderate: 93, Bad: 122},
+			},
+		},
+		{
+			name:          "should keep thresholds on unknown soil",
+			soilCondition: entities.TreeSoilConditionUnknown,
+			want:          thresholds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := AdjustToSoilCondition(thresholds, &tt.soilCondition, DefaultSoilFactors())
+
+			// then
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should keep thresholds without soil condition", func(t *testing.T) {
+		assert.Equal(t, thresholds, AdjustToSoilCondition(thresholds, nil, DefaultSoilFactors()))
+	})
+
+	t.Run("should not scale thresholds defined for the soil condition", func(t *testing.T) {
+		// given
+		sandig := entities.TreeSoilConditionSandig
+		thresholds := []entities.WateringThreshold{
+			{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+			{SoilCondition: &sandig, MinAge: 0, MaxAge: 1, Depth: 60, Moderate: 10, Bad: 12},
+		}
+
+		// when
+		got := AdjustToSoilCondition(thresholds, &sandig, DefaultSoilFactors())
+
+		// then
+		assert.Equal(t, []entities.WateringThreshold{
+			{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 15, Bad: 20},
+			{SoilCondition: &sandig, MinAge: 0, MaxAge: 1, Depth: 60, Moderate: 10, Bad: 12},
+		}, got)
+	})
+}
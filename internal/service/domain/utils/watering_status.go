@@ -3,9 +3,14 @@ package utils
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 )
@@ -26,18 +31,194 @@ func mapKpaRange(centibar, lower, higher int) int {
 	}
 }
 
-func CheckAndSortWatermarks(w []entities.Watermark) (w30, w60, w90 entities.Watermark, err error) {
+// DefaultWateringThresholds returns the thresholds for probes at 30, 60 and 90 cm of trees up to the third year
+func DefaultWateringThresholds() []entities.WateringThreshold {
+	/*
+		Tree 1st year:
+		30cm: <25kPA: green; 25-32kPA orange; >32kPA red
+		60cm: <25kPA: green; 25-32kPA orange; >32kPA red
+		90cm: <25kPA: green; 25-32kPA orange; >32kPA red
+
+		Tree 2nd year:
+		30cm: <62kPA: green; 62-80kPA orange; >80kPA red
+		60cm: <25kPA: green; 25-32kPA orange; >32kPA red
+		90cm: <25kPA: green; 25-32kPA orange; >32kPA red
+
+		Tree 3rd year:
+		30cm: <1585kPa: green;
+		60cm: <80kPA: green; >80kPA red
+		90cm: <80kPA: green; >80kPA red
+	*/
+	return []entities.WateringThreshold{
+		{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+		{MinAge: 0, MaxAge: 1, Depth: 60, Moderate: 25, Bad: 33},
+		{MinAge: 0, MaxAge: 1, Depth: 90, Moderate: 25, Bad: 33},
+		{MinAge: 2, MaxAge: 2, Depth: 30, Moderate: 62, Bad: 81},
+		{MinAge: 2, MaxAge: 2, Depth: 60, Moderate: 25, Bad: 33},
+		{MinAge: 2, MaxAge: 2, Depth: 90, Moderate: 25, Bad: 33},
+		{MinAge: 3, MaxAge: 3, Depth: 30, Moderate: 1585, Bad: 1585},
+		{MinAge: 3, MaxAge: 3, Depth: 60, Moderate: 80, Bad: 80},
+		{MinAge: 3, MaxAge: 3, Depth: 90, Moderate: 80, Bad: 80},
+	}
+}
+
+// NewWateringThresholds returns the configured thresholds or the default thresholds if none are configured.
+// Invalid thresholds are skipped.
+func NewWateringThresholds(cfg *config.WateringStatusConfig) []entities.WateringThreshold {
+	if cfg == nil || len(cfg.Thresholds) == 0 {
+		return DefaultWateringThresholds()
+	}
+
+	thresholds := make([]entities.WateringThreshold, 0, len(cfg.Thresholds))
+	for _, t := range cfg.Thresholds {
+		if t.Depth <= 0 || t.MinAge > t.MaxAge || t.Moderate > t.Bad {
+			slog.Warn("skipping invalid watering status threshold", "threshold", fmt.Sprintf("%+v", t))
+			continue
+		}
+
+		thresholds = append(thresholds, entities.WateringThreshold{
+			MinAge:   t.MinAge,
+			MaxAge:   t.MaxAge,
+			Depth:    t.Depth,
+			Moderate: t.Moderate,
+			Bad:      t.Bad,
+		})
+	}
+
+	return thresholds
+}
+
+// SortWatermarks returns the watermarks sorted by depth. Any number of probes is supported, but
+// every probe needs a positive depth that is not used by another probe.
+func SortWatermarks(w []entities.Watermark) ([]entities.Watermark, error) {
+	if len(w) == 0 {
+		return nil, errors.New("sensor data has no watermarks")
+	}
+
 	watermarks := slices.SortedFunc(slices.Values(w), func(a, b entities.Watermark) int {
 		return a.Depth - b.Depth
 	})
 
-	if len(watermarks) != 3 || watermarks[0].Depth != 30 || watermarks[1].Depth != 60 || watermarks[2].Depth != 90 {
-		err = errors.New("sensor data watermarks are malformed")
-		return
+	for i, wm := range watermarks {
+		if wm.Depth <= 0 {
+			return nil, fmt.Errorf("sensor data watermark has invalid depth %d", wm.Depth)
+		}
+		if i > 0 && watermarks[i-1].Depth == wm.Depth {
+			return nil, fmt.Errorf("sensor data has more than one watermark at depth %d", wm.Depth)
+		}
+	}
+
+	return watermarks, nil
+}
+
+// AverageWatermarks averages the watermarks of the sensor data per probe depth. Sensors may have a
+// different number of probes, a depth is averaged over the sensors that have a probe at this depth.
+func AverageWatermarks(sensorData []*entities.SensorData) ([]entities.Watermark, error) {
+	sums := make(map[int]int)
+	counts := make(map[int]int)
+	for _, data := range sensorData {
+		watermarks, err := SortWatermarks(data.Data.Watermarks)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range watermarks {
+			sums[w.Depth] += w.Centibar
+			counts[w.Depth]++
+		}
+	}
+
+	averaged := make([]entities.Watermark, 0, len(sums))
+	for _, depth := range slices.Sorted(maps.Keys(sums)) {
+		averaged = append(averaged, entities.Watermark{
+			Centibar: sums[depth] / counts[depth],
+			Depth:    depth,
+		})
+	}
+
+	return averaged, nil
+}
+
+// ApplicableWateringThresholds returns the thresholds that apply to a tree of the given species in a tree
+// cluster with the given soil condition. Thresholds without species group or soil condition apply to all trees.
+// A species group matches the species itself and all species of the genus, e.g. "Acer" matches "Acer platanoides".
+func ApplicableWateringThresholds(thresholds []entities.WateringThreshold, species string, soilCondition *entities.TreeSoilCondition) []entities.WateringThreshold {
+	applicable := make([]entities.WateringThreshold, 0, len(thresholds))
+	for _, t := range thresholds {
+		if t.SpeciesGroup != nil && !matchesSpeciesGroup(*t.SpeciesGroup, species) {
+			continue
+		}
+		if t.SoilCondition != nil && (soilCondition == nil || *t.SoilCondition != *soilCondition) {
+			continue
+		}
+		applicable = append(applicable, t)
 	}
 
-	w30, w60, w90 = watermarks[0], watermarks[1], watermarks[2]
-	return
+	return applicable
+}
+
+func matchesSpeciesGroup(group, species string) bool {
+	group = strings.ToLower(strings.TrimSpace(group))
+	species = strings.ToLower(strings.TrimSpace(species))
+	return species == group || strings.HasPrefix(species, group+" ")
+}
+
+// specificity ranks thresholds of a species group above thresholds of a soil condition above general thresholds
+func specificity(t *entities.WateringThreshold) int {
+	rank := 0
+	if t.SpeciesGroup != nil {
+		rank += 2
+	}
+	if t.SoilCondition != nil {
+		rank++
+	}
+	return rank
+}
+
+// findWateringThreshold returns the threshold of the given tree age with the depth nearest to the
+// given depth. Thresholds at the same depth are ranked by their specificity, on a tie of the
+// distance the deeper threshold is used.
+func findWateringThreshold(thresholds []entities.WateringThreshold, treeAge int32, depth int) (entities.WateringThreshold, bool) {
+	var found entities.WateringThreshold
+	ok := false
+	for _, t := range thresholds {
+		if treeAge < t.MinAge || treeAge > t.MaxAge {
+			continue
+		}
+
+		if !ok {
+			found, ok = t, true
+			continue
+		}
+
+		dist, foundDist := abs(t.Depth-depth), abs(found.Depth-depth)
+		if dist != foundDist {
+			if dist < foundDist {
+				found = t
+			}
+			continue
+		}
+
+		if rank, foundRank := specificity(&t), specificity(&found); rank != foundRank {
+			if rank > foundRank {
+				found = t
+			}
+			continue
+		}
+
+		if t.Depth > found.Depth {
+			found = t
+		}
+	}
+
+	return found, ok
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
 }
 
 // CalculateWateringStatus determines the watering status of a plant based on its planting year and sensor watermarks.
@@ -45,97 +226,50 @@ func CheckAndSortWatermarks(w []entities.Watermark) (w30, w60, w90 entities.Wate
 // Parameters:
 //   - plantingYear: The year the plant was planted.
 //   - watermarks: A slice of entities.Watermark containing sensor readings at different depths.
+//   - thresholds: The centibar thresholds per tree age and probe depth, see DefaultWateringThresholds and ApplicableWateringThresholds.
 //
 // Returns:
 //   - entities.WateringStatus: The calculated watering status based on the plant's lifetime and sensor watermarks.
 //
 // Behavior:
 //  1. Calculates the plant's lifetime in years based on the current year.
-//  2. Validates the watermarks to ensure exactly three readings exist at depths of 30cm, 60cm, and 90cm.
+//  2. Validates the watermarks to ensure there is at least one probe and every probe has a unique positive depth.
 //     If validation fails, logs an error and returns `WateringStatusUnknown`.
-//  3. Based on the tree's lifetime, applies specific centibar ranges to the watermarks at different depths:
-//     - Year 1: Centibar ranges for 30cm, 60cm, and 90cm are 25–33.
-//     - Year 2: Centibar ranges for 30cm are 62–81, while 60cm and 90cm remain 25–33.
-//     - Year 3: Centibar ranges for 30cm are 1585–infinity, while 60cm and 90cm are 80–infinity.
+//  3. Every probe is evaluated with the threshold of the tree's lifetime whose depth is nearest to the depth of the probe.
+//     Of several thresholds at this depth the most specific one is used.
+//     If there is no threshold for the tree's lifetime, `WateringStatusUnknown` is returned.
 //  4. Maps the centibar values to a status (green, yellow, or red) and determines the final watering status
 //     based on the most severe status.
 //
-// Errors:
-//   - If the watermarks are malformed or do not contain exactly three readings at the required depths, logs an error
-//     with details and returns `WateringStatusUnknown`.
-//
 // Example:
 //
 //	plantingYear := 2020
 //	watermarks := []entities.Watermark{
-//	    {Depth: 30, Centibar: 28},
-//	    {Depth: 60, Centibar: 30},
-//	    {Depth: 90, Centibar: 35},
+//	    {Depth: 20, Centibar: 28},
+//	    {Depth: 40, Centibar: 30},
 //	}
 //
-//	status := CalculateWateringStatus(plantingYear, watermarks)
+//	status := CalculateWateringStatus(ctx, plantingYear, watermarks, DefaultWateringThresholds())
 //	fmt.Printf("Watering Status: %v\n", status)
-//
-// Notes:
-//   - The function assumes that watermarks are provided as a slice, where each entry represents a sensor reading at a specific depth.
-//   - Any changes to the mapping of centibar ranges or tree lifetime logic should be reflected here.
-func CalculateWateringStatus(ctx context.Context, plantingYear int32, watermarks []entities.Watermark) entities.WateringStatus {
-	/*
-		Tree 1st year:
-		30cm: <25kPA: green; 25-32kPA orange; >32kPA red
-		60cm: <25kPA: green; 25-32kPA orange; >32kPA red
-		90cm: <25kPA: green; 25-32kPA orange; >32kPA red
-
-		Tree 2nd year:
-		30cm: <62kPA: green; 62-80kPA orange; >80kPA red
-		60cm: <25kPA: green; 25-32kPA orange; >32kPA red
-		90cm: <25kPA: green; 25-32kPA orange; >32kPA red
-
-		Tree 3rd year:
-		30cm: <1585kPa: green;
-		60cm: <80kPA: green; >80kPA red
-		90cm: <80kPA: green; >80kPA red
-	*/
+func CalculateWateringStatus(ctx context.Context, plantingYear int32, watermarks []entities.Watermark, thresholds []entities.WateringThreshold) entities.WateringStatus {
 	log := logger.GetLogger(ctx)
 	currentYear := int32(time.Now().Year())
 	treeLifetime := currentYear - plantingYear
-	w30, w60, w90, err := CheckAndSortWatermarks(watermarks)
+	sorted, err := SortWatermarks(watermarks)
 	if err != nil {
-		log.Error("sensor data watermarks are malformed", "watermarks", watermarks)
+		log.Error("sensor data watermarks are malformed", "watermarks", watermarks, "error", err)
 		return entities.WateringStatusUnknown
 	}
 
-	statusList := make([]int, 3)
-	const (
-		lowerCentibarDefault  = 25
-		higherCentibarDefault = 33
-
-		lowerCentibarYear2Depth30  = 62
-		higherCentibarYear2Depth30 = 81
-
-		lowerCentibarYear3Depth30 = 1585
-		lowerCentibarYear3Depth60 = 80
-		lowerCentibarYear3Depth90 = 80
-		noModerate                = -1
-	)
-
-	switch treeLifetime {
-	case 0, 1:
-		statusList[0] = mapKpaRange(w30.Centibar, lowerCentibarDefault, higherCentibarDefault)
-		statusList[1] = mapKpaRange(w60.Centibar, lowerCentibarDefault, higherCentibarDefault)
-		statusList[2] = mapKpaRange(w90.Centibar, lowerCentibarDefault, higherCentibarDefault)
-	case 2:
-		statusList[0] = mapKpaRange(w30.Centibar, lowerCentibarYear2Depth30, higherCentibarYear2Depth30)
-		statusList[1] = mapKpaRange(w60.Centibar, lowerCentibarDefault, higherCentibarDefault)
-		statusList[2] = mapKpaRange(w90.Centibar, lowerCentibarDefault, higherCentibarDefault)
-	case 3:
-		statusList[0] = mapKpaRange(w30.Centibar, lowerCentibarYear3Depth30, noModerate)
-		statusList[1] = mapKpaRange(w60.Centibar, lowerCentibarYear3Depth60, noModerate)
-		statusList[2] = mapKpaRange(w90.Centibar, lowerCentibarYear3Depth90, noModerate)
-	default:
-		return entities.WateringStatusUnknown
+	worst := 0
+	for _, w := range sorted {
+		threshold, ok := findWateringThreshold(thresholds, treeLifetime, w.Depth)
+		if !ok {
+			return entities.WateringStatusUnknown
+		}
+
+		worst = max(worst, mapKpaRange(w.Centibar, threshold.Moderate, threshold.Bad))
 	}
 
-	slices.Sort(statusList)
-	return mapWateringStatus[statusList[2]]
+	return mapWateringStatus[worst]
 }
@@ -5,52 +5,98 @@ import (
 	"testing"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_CheckAndSortWatermarks(t *testing.T) {
-	t.Run("should split watermarks sensors in correct objects", func(t *testing.T) {
+func Test_SortWatermarks(t *testing.T) {
+	t.Run("should sort watermarks by depth", func(t *testing.T) {
 		// given
 		watermarks := []entities.Watermark{
 			{Depth: 90}, {Depth: 30}, {Depth: 60},
 		}
 
 		// when
-		w30, w60, w90, err := CheckAndSortWatermarks(watermarks)
+		got, err := SortWatermarks(watermarks)
 
 		//then
 		assert.NoError(t, err)
-		assert.Equal(t, w30.Depth, 30)
-		assert.Equal(t, w60.Depth, 60)
-		assert.Equal(t, w90.Depth, 90)
+		assert.Equal(t, []entities.Watermark{{Depth: 30}, {Depth: 60}, {Depth: 90}}, got)
 	})
 
-	t.Run("should return err on unsupported depth", func(t *testing.T) {
+	t.Run("should support any number of probes at any depth", func(t *testing.T) {
 		// given
 		watermarks := []entities.Watermark{
-			{Depth: 42}, {Depth: 69}, {Depth: 420},
+			{Depth: 120}, {Depth: 15}, {Depth: 45}, {Depth: 80},
 		}
 
 		// when
-		_, _, _, err := CheckAndSortWatermarks(watermarks)
+		got, err := SortWatermarks(watermarks)
+
+		//then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Watermark{{Depth: 15}, {Depth: 45}, {Depth: 80}, {Depth: 120}}, got)
+	})
+
+	t.Run("should return err on empty watermarks", func(t *testing.T) {
+		// when
+		_, err := SortWatermarks(nil)
 
 		//then
 		assert.Error(t, err)
 	})
 
-	t.Run("should return err on length not three", func(t *testing.T) {
+	t.Run("should return err on duplicate depth", func(t *testing.T) {
 		// given
 		watermarks := []entities.Watermark{
-			{Depth: 30},
+			{Depth: 30}, {Depth: 30},
 		}
 
 		// when
-		_, _, _, err := CheckAndSortWatermarks(watermarks)
+		_, err := SortWatermarks(watermarks)
 
 		//then
 		assert.Error(t, err)
 	})
+
+	t.Run("should return err on invalid depth", func(t *testing.T) {
+		// given
+		watermarks := []entities.Watermark{
+			{Depth: 0}, {Depth: 30},
+		}
+
+		// when
+		_, err := SortWatermarks(watermarks)
+
+		//then
+		assert.Error(t, err)
+	})
+}
+
+func Test_NewWateringThresholds(t *testing.T) {
+	t.Run("should return default thresholds without config", func(t *testing.T) {
+		assert.Equal(t, DefaultWateringThresholds(), NewWateringThresholds(nil))
+		assert.Equal(t, DefaultWateringThresholds(), NewWateringThresholds(&config.WateringStatusConfig{}))
+	})
+
+	t.Run("should return configured thresholds and skip invalid ones", func(t *testing.T) {
+		// given
+		cfg := &config.WateringStatusConfig{
+			Thresholds: []config.WateringThresholdConfig{
+				{MinAge: 0, MaxAge: 5, Depth: 20, Moderate: 30, Bad: 50},
+				{MinAge: 0, MaxAge: 5, Depth: 0, Moderate: 30, Bad: 50},
+				{MinAge: 3, MaxAge: 1, Depth: 40, Moderate: 30, Bad: 50},
+				{MinAge: 0, MaxAge: 5, Depth: 40, Moderate: 60, Bad: 50},
+			},
+		}
+
+		// when
+		got := NewWateringThresholds(cfg)
+
+		// then
+		assert.Equal(t, []entities.WateringThreshold{{MinAge: 0, MaxAge: 5, Depth: 20, Moderate: 30, Bad: 50}}, got)
+	})
 }
 
 func Test_CalculateWateringStatus(t *testing.T) {
@@ -206,11 +252,67 @@ func Test_CalculateWateringStatus(t *testing.T) {
 				plantingYear: int32(time.Now().Year() - 2),
 				watermarks: []entities.Watermark{
 					{Depth: 30, Centibar: 1586},
-					{Depth: 90, Centibar: 31},
+					{Depth: 30, Centibar: 31},
 				},
 			},
 			output: entities.WateringStatusUnknown,
 		},
+		{
+			name: "should evaluate sensor with two probes",
+			input: struct {
+				plantingYear int32
+				watermarks   []entities.Watermark
+			}{
+				plantingYear: int32(time.Now().Year() - 2),
+				watermarks: []entities.Watermark{
+					{Depth: 30, Centibar: 70},
+					{Depth: 90, Centibar: 12},
+				},
+			},
+			output: entities.WateringStatusModerate,
+		},
+		{
+			name: "should evaluate single probe with threshold of nearest depth",
+			input: struct {
+				plantingYear int32
+				watermarks   []entities.Watermark
+			}{
+				plantingYear: int32(time.Now().Year() - 2),
+				watermarks: []entities.Watermark{
+					{Depth: 20, Centibar: 70},
+				},
+			},
+			output: entities.WateringStatusModerate,
+		},
+		{
+			name: "should evaluate four probes at other depths",
+			input: struct {
+				plantingYear int32
+				watermarks   []entities.Watermark
+			}{
+				plantingYear: int32(time.Now().Year() - 2),
+				watermarks: []entities.Watermark{
+					{Depth: 15, Centibar: 50},
+					{Depth: 45, Centibar: 20},
+					{Depth: 75, Centibar: 10},
+					{Depth: 120, Centibar: 40},
+				},
+			},
+			output: entities.WateringStatusBad,
+		},
+		{
+			name: "should use deeper threshold when probe is between two depths",
+			input: struct {
+				plantingYear int32
+				watermarks   []entities.Watermark
+			}{
+				plantingYear: int32(time.Now().Year() - 2),
+				watermarks: []entities.Watermark{
+					{Depth: 45, Centibar: 40},
+				},
+			},
+			output: entities.WateringStatusBad,
+		},
 		{
 			name: "should calculate first year when treeLifetime is 0",
 			input: struct {
@@ -235,10 +337,148 @@ func Test_CalculateWateringStatus(t *testing.T) {
 			plantingYear := tt.input.plantingYear
 
 			// when
-			got := CalculateWateringStatus(context.Background(), plantingYear, watermarks)
+			got := CalculateWateringStatus(context.Background(), plantingYear, watermarks, DefaultWateringThresholds())
 
 			// then
 			assert.Equal(t, tt.output, got)
 		})
 	}
 }
+
+func Test_CalculateWateringStatusWithConfiguredThresholds(t *testing.T) {
+	thresholds := NewWateringThresholds(&config.WateringStatusConfig{
+		Thresholds: []config.WateringThresholdConfig{
+			{MinAge: 0, MaxAge: 10, Depth: 20, Moderate: 30, Bad: 60},
+			{MinAge: 0, MaxAge: 10, Depth: 50, Moderate: 40, Bad: 80},
+		},
+	})
+
+	t.Run("should evaluate every probe with the threshold of its depth", func(t *testing.T) {
+		// given
+		watermarks := []entities.Watermark{
+			{Depth: 20, Centibar: 29},
+			{Depth: 50, Centibar: 79},
+		}
+
+		// when
+		got := CalculateWateringStatus(context.Background(), int32(time.Now().Year()-5), watermarks, thresholds)
+
+		// then
+		assert.Equal(t, entities.WateringStatusModerate, got)
+	})
+
+	t.Run("should return unknown when no threshold matches the tree age", func(t *testing.T) {
+		// given
+		watermarks := []entities.Watermark{{Depth: 20, Centibar: 10}}
+
+		// when
+		got := CalculateWateringStatus(context.Background(), int32(time.Now().Year()-11), watermarks, thresholds)
+
+		// then
+		assert.Equal(t, entities.WateringStatusUnknown, got)
+	})
+}
+
+func Test_AverageWatermarks(t *testing.T) {
+	t.Run("should average watermarks per depth", func(t *testing.T) {
+		// given
+		sensorData := []*entities.SensorData{
+			{Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 60, Centibar: 40}, {Depth: 30, Centibar: 20}}}},
+			{Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30, Centibar: 30}, {Depth: 60, Centibar: 50}, {Depth: 90, Centibar: 70}}}},
+		}
+
+		// when
+		got, err := AverageWatermarks(sensorData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Watermark{
+			{Depth: 30, Centibar: 25},
+			{Depth: 60, Centibar: 45},
+			{Depth: 90, Centibar: 70},
+		}, got)
+	})
+
+	t.Run("should return err on malformed watermarks", func(t *testing.T) {
+		// given
+		sensorData := []*entities.SensorData{
+			{Data: &entities.MqttPayload{Watermarks: []entities.Watermark{{Depth: 30}, {Depth: 30}}}},
+		}
+
+		// when
+		_, err := AverageWatermarks(sensorData)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func Test_ApplicableWateringThresholds(t *testing.T) {
+	sandig := entities.TreeSoilConditionSandig
+	lehmig := entities.TreeSoilConditionLehmig
+	acer := "Acer"
+	general := entities.WateringThreshold{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33}
+	species := entities.WateringThreshold{SpeciesGroup: &acer, MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 80, Bad: 120}
+	soil := entities.WateringThreshold{SoilCondition: &sandig, MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 15, Bad: 20}
+	thresholds := []entities.WateringThreshold{general, species, soil}
+
+	tests := []struct {
+		name          string
+		species       string
+		soilCondition *entities.TreeSoilCondition
+		want          []entities.WateringThreshold
+	}{
+		{name: "should match genus of species", species: "Acer platanoides", soilCondition: &lehmig, want: []entities.WateringThreshold{general, species}},
+		{name: "should match species group case insensitive", species: "acer", want: []entities.WateringThreshold{general, species}},
+		{name: "should not match species with group as prefix of the genus", species: "Acerola", want: []entities.WateringThreshold{general}},
+		{name: "should match soil condition", species: "Tilia cordata", soilCondition: &sandig, want: []entities.WateringThreshold{general, soil}},
+		{name: "should skip soil thresholds without soil condition", species: "Tilia cordata", want: []entities.WateringThreshold{general}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := ApplicableWateringThresholds(thresholds, tt.species, tt.soilCondition)
+
+			// then
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CalculateWateringStatusWithSpecificThresholds(t *testing.T) {
+	sandig := entities.TreeSoilConditionSandig
+	acer := "Acer"
+	thresholds := []entities.WateringThreshold{
+		{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33},
+		{SoilCondition: &sandig, MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 15, Bad: 20},
+		{SpeciesGroup: &acer, MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 80, Bad: 120},
+		{SpeciesGroup: &acer, MinAge: 0, MaxAge: 99, Depth: 90, Moderate: 10, Bad: 10},
+	}
+	plantingYear := int32(time.Now().Year() - 5)
+	watermarks := []entities.Watermark{{Depth: 30, Centibar: 30}}
+
+	t.Run("should prefer species group over soil condition at the same depth", func(t *testing.T) {
+		// when
+		got := CalculateWateringStatus(context.Background(), plantingYear, watermarks, ApplicableWateringThresholds(thresholds, "Acer campestre", &sandig))
+
+		// then
+		assert.Equal(t, entities.WateringStatusGood, got)
+	})
+
+	t.Run("should prefer soil condition over general thresholds at the same depth", func(t *testing.T) {
+		// when
+		got := CalculateWateringStatus(context.Background(), plantingYear, watermarks, ApplicableWateringThresholds(thresholds, "Tilia cordata", &sandig))
+
+		// then
+		assert.Equal(t, entities.WateringStatusBad, got)
+	})
+
+	t.Run("should use general thresholds for other trees", func(t *testing.T) {
+		// when
+		got := CalculateWateringStatus(context.Background(), plantingYear, watermarks, ApplicableWateringThresholds(thresholds, "Tilia cordata", nil))
+
+		// then
+		assert.Equal(t, entities.WateringStatusModerate, got)
+	})
+}
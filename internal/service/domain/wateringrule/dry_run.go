@@ -0,0 +1,137 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+)
+
+// DryRun classifies the latest sensor data of all trees and tree clusters with sensors with the active and the
+// proposed thresholds. Trees are classified like in the tree service, tree clusters like in the tree cluster service.
+func (s *WateringRuleService) DryRun(ctx context.Context, proposed *entities.WateringRuleSetCreate) (*entities.WateringRuleDryRun, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(proposed); err != nil {
+		log.Debug("failed to validate proposed watering rule set", "error", err, "raw_rule_set", fmt.Sprintf("%+v", proposed))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	trees, err := s.getTreesWithSensorData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := s.rules.Thresholds(ctx)
+	result := &entities.WateringRuleDryRun{
+		Trees:        make([]*entities.TreeWateringStatusDryRun, 0, len(trees)),
+		TreeClusters: make([]*entities.TreeClusterWateringStatusDryRun, 0),
+	}
+
+	clusterTrees := make(map[int32][]*entities.Tree)
+	for _, t := range trees {
+		watermarks := t.Sensor.LatestData.Data.Watermarks
+		result.Trees = append(result.Trees, &entities.TreeWateringStatusDryRun{
+			TreeID:   t.ID,
+			Number:   t.Number,
+			SensorID: t.Sensor.ID,
+			Active:   s.classify(ctx, active, t, watermarks),
+			Proposed: s.classify(ctx, proposed.Thresholds, t, watermarks),
+		})
+
+		if t.TreeCluster != nil {
+			clusterTrees[t.TreeCluster.ID] = append(clusterTrees[t.TreeCluster.ID], t)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(clusterTrees)) {
+		result.TreeClusters = append(result.TreeClusters, s.classifyTreeCluster(ctx, active, proposed.Thresholds, clusterTrees[id]))
+	}
+
+	log.Debug("dry run of watering rule set finished", "trees", len(result.Trees), "tree_clusters", len(result.TreeClusters))
+	return result, nil
+}
+
+// getTreesWithSensorData returns all trees, ordered by id, whose sensor is active and has latest sensor data with watermarks
+func (s *WateringRuleService) getTreesWithSensorData(ctx context.Context) ([]*entities.Tree, error) {
+	log := logger.GetLogger(ctx)
+	sensors, err := s.sensorRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch sensors for dry run", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	sensorIDs := make([]string, 0, len(sensors))
+	for _, sn := range sensors {
+		if sn.DecommissionedAt == nil && hasWatermarks(sn.LatestData) {
+			sensorIDs = append(sensorIDs, sn.ID)
+		}
+	}
+
+	if len(sensorIDs) == 0 {
+		return nil, nil
+	}
+
+	trees, err := s.treeRepo.GetBySensorIDs(ctx, sensorIDs...)
+	if err != nil {
+		log.Debug("failed to fetch trees of sensors for dry run", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	trees = slices.DeleteFunc(trees, func(t *entities.Tree) bool {
+		return t.Sensor == nil || !hasWatermarks(t.Sensor.LatestData)
+	})
+	slices.SortFunc(trees, func(a, b *entities.Tree) int {
+		return int(a.ID - b.ID)
+	})
+
+	return trees, nil
+}
+
+func hasWatermarks(data *entities.SensorData) bool {
+	return data != nil && !data.Flagged && data.Data != nil && len(data.Data.Watermarks) > 0
+}
+
+func (s *WateringRuleService) classify(ctx context.Context, thresholds []entities.WateringThreshold, t *entities.Tree, watermarks []entities.Watermark) entities.WateringStatus {
+	var soilCondition *entities.TreeSoilCondition
+	if t.TreeCluster != nil {
+		soilCondition = &t.TreeCluster.SoilCondition
+	}
+
+	return utils.CalculateWateringStatus(ctx, t.PlantingYear, watermarks, s.rules.Select(thresholds, t.Species, soilCondition))
+}
+
+// classifyTreeCluster averages the sensor data of the trees per depth and uses the youngest tree of the cluster
+func (s *WateringRuleService) classifyTreeCluster(ctx context.Context, active, proposed []entities.WateringThreshold, trees []*entities.Tree) *entities.TreeClusterWateringStatusDryRun {
+	cluster := trees[0].TreeCluster
+	result := &entities.TreeClusterWateringStatusDryRun{
+		TreeClusterID: cluster.ID,
+		Name:          cluster.Name,
+		Active:        entities.WateringStatusUnknown,
+		Proposed:      entities.WateringStatusUnknown,
+	}
+
+	sensorData := make([]*entities.SensorData, 0, len(trees))
+	for _, t := range trees {
+		sensorData = append(sensorData, t.Sensor.LatestData)
+	}
+
+	watermarks, err := utils.AverageWatermarks(sensorData)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("sensor data watermarks of tree cluster are malformed", "cluster_id", cluster.ID, "error", err)
+		return result
+	}
+
+	youngestTree := slices.MaxFunc(trees, func(a, b *entities.Tree) int {
+		return int(a.PlantingYear - b.PlantingYear)
+	})
+
+	result.Active = s.classify(ctx, active, youngestTree, watermarks)
+	result.Proposed = s.classify(ctx, proposed, youngestTree, watermarks)
+	return result
+}
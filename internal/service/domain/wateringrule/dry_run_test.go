@@ -0,0 +1,140 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRuleService_DryRun(t *testing.T) {
+	ctx := context.Background()
+	year := int32(time.Now().Year())
+
+	proposed := &entities.WateringRuleSetCreate{
+		Description: "stricter thresholds for young trees",
+		Thresholds: []entities.WateringThreshold{
+			{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 10, Bad: 30},
+			{MinAge: 0, MaxAge: 1, Depth: 60, Moderate: 10, Bad: 30},
+			{MinAge: 0, MaxAge: 1, Depth: 90, Moderate: 10, Bad: 30},
+		},
+	}
+
+	t.Run("should classify trees and tree clusters with active and proposed thresholds", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cluster := &entities.TreeCluster{ID: 1, Name: "Cluster 1", SoilCondition: entities.TreeSoilConditionLehmig}
+		sensors := []*entities.Sensor{
+			{ID: "sensor-1", LatestData: testSensorData("sensor-1", 20)},
+			{ID: "sensor-2", LatestData: testSensorData("sensor-2", 40)},
+			{ID: "sensor-3", LatestData: testSensorData("sensor-3", 40), DecommissionedAt: utils.P(time.Now())},
+			{ID: "sensor-4", LatestData: &entities.SensorData{SensorID: "sensor-4", Flagged: true, Data: testSensorData("sensor-4", 40).Data}},
+			{ID: "sensor-5"},
+		}
+		trees := []*entities.Tree{
+			{ID: 2, Number: "T2", PlantingYear: year - 1, TreeCluster: cluster, Sensor: sensors[1]},
+			{ID: 1, Number: "T1", PlantingYear: year, TreeCluster: cluster, Sensor: sensors[0]},
+		}
+
+		repos.ruleRepo.EXPECT().GetActive(ctx).Return(nil, storage.ErrEntityNotFound("not found"))
+		repos.sensorRepo.EXPECT().GetAll(ctx).Return(sensors, nil)
+		repos.treeRepo.EXPECT().GetBySensorIDs(ctx, "sensor-1", "sensor-2").Return(trees, nil)
+
+		// when
+		got, err := svc.DryRun(ctx, proposed)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeWateringStatusDryRun{
+			{TreeID: 1, Number: "T1", SensorID: "sensor-1", Active: entities.WateringStatusGood, Proposed: entities.WateringStatusModerate},
+			{TreeID: 2, Number: "T2", SensorID: "sensor-2", Active: entities.WateringStatusBad, Proposed: entities.WateringStatusBad},
+		}, got.Trees)
+		assert.Equal(t, []*entities.TreeClusterWateringStatusDryRun{
+			{TreeClusterID: 1, Name: "Cluster 1", Active: entities.WateringStatusModerate, Proposed: entities.WateringStatusBad},
+		}, got.TreeClusters)
+	})
+
+	t.Run("should use thresholds of the active rule set", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		sensor := &entities.Sensor{ID: "sensor-1", LatestData: testSensorData("sensor-1", 20)}
+		trees := []*entities.Tree{
+			{ID: 1, Number: "T1", PlantingYear: year, Sensor: sensor},
+		}
+
+		repos.ruleRepo.EXPECT().GetActive(ctx).Return(&entities.WateringRuleSet{ID: 1, Active: true, Thresholds: proposed.Thresholds}, nil)
+		repos.sensorRepo.EXPECT().GetAll(ctx).Return([]*entities.Sensor{sensor}, nil)
+		repos.treeRepo.EXPECT().GetBySensorIDs(ctx, "sensor-1").Return(trees, nil)
+
+		// when
+		got, err := svc.DryRun(ctx, proposed)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Trees, 1)
+		assert.Equal(t, entities.WateringStatusModerate, got.Trees[0].Active)
+		assert.Equal(t, entities.WateringStatusModerate, got.Trees[0].Proposed)
+		assert.Empty(t, got.TreeClusters)
+	})
+
+	t.Run("should return empty result when no sensor has data", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().GetActive(ctx).Return(nil, storage.ErrEntityNotFound("not found"))
+		repos.sensorRepo.EXPECT().GetAll(ctx).Return([]*entities.Sensor{{ID: "sensor-1"}}, nil)
+
+		// when
+		got, err := svc.DryRun(ctx, proposed)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got.Trees)
+		assert.Empty(t, got.TreeClusters)
+		repos.treeRepo.AssertNotCalled(t, "GetBySensorIDs")
+	})
+
+	t.Run("should return validation error when proposed thresholds are invalid", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+
+		// when
+		got, err := svc.DryRun(ctx, &entities.WateringRuleSetCreate{})
+
+		// then
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, got)
+		repos.sensorRepo.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("should return error when fetching sensors fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.sensorRepo.EXPECT().GetAll(ctx).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.DryRun(ctx, proposed)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func testSensorData(sensorID string, centibar int) *entities.SensorData {
+	return &entities.SensorData{
+		SensorID: sensorID,
+		Data: &entities.MqttPayload{
+			Device: sensorID,
+			Watermarks: []entities.Watermark{
+				{Centibar: centibar, Depth: 30},
+				{Centibar: centibar, Depth: 60},
+				{Centibar: centibar, Depth: 90},
+			},
+		},
+	}
+}
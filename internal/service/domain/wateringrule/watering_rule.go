@@ -0,0 +1,111 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type WateringRuleService struct {
+	ruleRepo   storage.WateringRuleRepository
+	sensorRepo storage.SensorRepository
+	treeRepo   storage.TreeRepository
+	rules      *utils.WateringRules
+	validator  *validator.Validate
+}
+
+func NewWateringRuleService(
+	ruleRepo storage.WateringRuleRepository,
+	sensorRepo storage.SensorRepository,
+	treeRepo storage.TreeRepository,
+	cfg *config.WateringStatusConfig,
+) service.WateringRuleService {
+	return &WateringRuleService{
+		ruleRepo:   ruleRepo,
+		sensorRepo: sensorRepo,
+		treeRepo:   treeRepo,
+		rules:      utils.NewWateringRules(ruleRepo, cfg),
+		validator:  validator.New(),
+	}
+}
+
+func (s *WateringRuleService) GetAll(ctx context.Context) ([]*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	ruleSets, err := s.ruleRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch watering rule sets", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return ruleSets, nil
+}
+
+func (s *WateringRuleService) GetByID(ctx context.Context, id int32) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	ruleSet, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch watering rule set by id", "error", err, "rule_set_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return ruleSet, nil
+}
+
+func (s *WateringRuleService) GetActive(ctx context.Context) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	ruleSet, err := s.ruleRepo.GetActive(ctx)
+	if err != nil {
+		log.Debug("failed to fetch active watering rule set", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return ruleSet, nil
+}
+
+// Create stores the thresholds as the next version of the watering rule sets. The new version
+// is not used for the watering status until it is activated.
+func (s *WateringRuleService) Create(ctx context.Context, createData *entities.WateringRuleSetCreate) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate watering rule set create struct", "error", err, "raw_rule_set", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	created, err := s.ruleRepo.Create(ctx, func(rs *entities.WateringRuleSet) (bool, error) {
+		rs.Description = createData.Description
+		rs.Thresholds = createData.Thresholds
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create watering rule set", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("watering rule set created", "rule_set_id", created.ID, "version", created.Version)
+	return created, nil
+}
+
+// Activate uses the watering rule set for all further watering status calculations
+func (s *WateringRuleService) Activate(ctx context.Context, id int32) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	activated, err := s.ruleRepo.Activate(ctx, id)
+	if err != nil {
+		log.Debug("failed to activate watering rule set", "error", err, "rule_set_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("watering rule set activated", "rule_set_id", activated.ID, "version", activated.Version)
+	return activated, nil
+}
+
+func (s *WateringRuleService) Ready() bool {
+	return s.ruleRepo != nil && s.sensorRepo != nil && s.treeRepo != nil
+}
@@ -0,0 +1,274 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testRepos struct {
+	ruleRepo   *storageMock.MockWateringRuleRepository
+	sensorRepo *storageMock.MockSensorRepository
+	treeRepo   *storageMock.MockTreeRepository
+}
+
+func newTestService(t *testing.T) (*WateringRuleService, testRepos) {
+	repos := testRepos{
+		ruleRepo:   storageMock.NewMockWateringRuleRepository(t),
+		sensorRepo: storageMock.NewMockSensorRepository(t),
+		treeRepo:   storageMock.NewMockTreeRepository(t),
+	}
+	svc := NewWateringRuleService(repos.ruleRepo, repos.sensorRepo, repos.treeRepo, nil)
+	return svc.(*WateringRuleService), repos
+}
+
+func getTestRuleSets() []*entities.WateringRuleSet {
+	return []*entities.WateringRuleSet{
+		{
+			ID:      2,
+			Version: 2,
+			Active:  true,
+			Thresholds: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33},
+				{SpeciesGroup: utils.P("Acer"), MinAge: 4, MaxAge: 99, Depth: 30, Moderate: 80, Bad: 120},
+			},
+		},
+		{
+			ID:          1,
+			Version:     1,
+			Description: "initial thresholds",
+			Thresholds: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+			},
+		},
+	}
+}
+
+func TestWateringRuleService_GetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return all rule sets", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestRuleSets()
+		repos.ruleRepo.EXPECT().GetAll(ctx).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().GetAll(ctx).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleService_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return rule set by id", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestRuleSets()[1]
+		repos.ruleRepo.EXPECT().GetByID(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when rule set does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByID(ctx, 99)
+
+		// then
+		assert.ErrorContains(t, err, "not found")
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleService_GetActive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return active rule set", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestRuleSets()[0]
+		repos.ruleRepo.EXPECT().GetActive(ctx).Return(expected, nil)
+
+		// when
+		got, err := svc.GetActive(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when no rule set is active", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().GetActive(ctx).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetActive(ctx)
+
+		// then
+		assert.ErrorContains(t, err, "not found")
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create rule set with thresholds", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		createData := &entities.WateringRuleSetCreate{
+			Description: "older trees",
+			Thresholds: []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33},
+				{SoilCondition: utils.P(entities.TreeSoilConditionSandig), MinAge: 4, MaxAge: 99, Depth: 60, Moderate: 60, Bad: 60},
+			},
+		}
+
+		repos.ruleRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(*entities.WateringRuleSet) (bool, error)) (*entities.WateringRuleSet, error) {
+			rs := &entities.WateringRuleSet{ID: 3, Version: 3}
+			ok, err := fn(rs)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return rs, nil
+		})
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), got.Version)
+		assert.Equal(t, "older trees", got.Description)
+		assert.Equal(t, createData.Thresholds, got.Thresholds)
+		assert.False(t, got.Active)
+	})
+
+	t.Run("should return validation error when thresholds are missing", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+
+		// when
+		got, err := svc.Create(ctx, &entities.WateringRuleSetCreate{Description: "empty"})
+
+		// then
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, got)
+		repos.ruleRepo.AssertNotCalled(t, "Create")
+	})
+
+	tests := []struct {
+		name      string
+		threshold entities.WateringThreshold
+	}{
+		{name: "max age below min age", threshold: entities.WateringThreshold{MinAge: 3, MaxAge: 2, Depth: 30, Moderate: 25, Bad: 33}},
+		{name: "depth is zero", threshold: entities.WateringThreshold{MinAge: 0, MaxAge: 2, Depth: 0, Moderate: 25, Bad: 33}},
+		{name: "bad below moderate", threshold: entities.WateringThreshold{MinAge: 0, MaxAge: 2, Depth: 30, Moderate: 33, Bad: 25}},
+		{name: "unknown soil condition", threshold: entities.WateringThreshold{SoilCondition: utils.P(entities.TreeSoilCondition("moorig")), MinAge: 0, MaxAge: 2, Depth: 30, Moderate: 25, Bad: 33}},
+		{name: "empty species group", threshold: entities.WateringThreshold{SpeciesGroup: utils.P(""), MinAge: 0, MaxAge: 2, Depth: 30, Moderate: 25, Bad: 33}},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return validation error when "+tt.name, func(t *testing.T) {
+			// given
+			svc, _ := newTestService(t)
+
+			// when
+			got, err := svc.Create(ctx, &entities.WateringRuleSetCreate{Thresholds: []entities.WateringThreshold{tt.threshold}})
+
+			// then
+			assert.ErrorContains(t, err, "validation error")
+			assert.Nil(t, got)
+		})
+	}
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().Create(ctx, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.Create(ctx, &entities.WateringRuleSetCreate{Thresholds: getTestRuleSets()[1].Thresholds})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleService_Activate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should activate rule set", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		expected := getTestRuleSets()[1]
+		expected.Active = true
+		repos.ruleRepo.EXPECT().Activate(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.Activate(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, got.Active)
+	})
+
+	t.Run("should return not found error when rule set does not exist", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.ruleRepo.EXPECT().Activate(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Activate(ctx, 99)
+
+		// then
+		assert.ErrorContains(t, err, "not found")
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleService_Ready(t *testing.T) {
+	t.Run("should return true when all repositories are set", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false when repositories are missing", func(t *testing.T) {
+		svc := NewWateringRuleService(nil, nil, nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
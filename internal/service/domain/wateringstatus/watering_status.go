@@ -0,0 +1,134 @@
+package wateringstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type WateringStatusService struct {
+	historyRepo     storage.WateringStatusHistoryRepository
+	treeRepo        storage.TreeRepository
+	treeClusterRepo storage.TreeClusterRepository
+}
+
+func NewWateringStatusService(
+	historyRepo storage.WateringStatusHistoryRepository,
+	treeRepo storage.TreeRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+) service.WateringStatusService {
+	return &WateringStatusService{
+		historyRepo:     historyRepo,
+		treeRepo:        treeRepo,
+		treeClusterRepo: treeClusterRepo,
+	}
+}
+
+func (s *WateringStatusService) GetTreeTimeline(ctx context.Context, treeID int32, from, to time.Time) (*entities.WateringStatusTimeline, error) {
+	log := logger.GetLogger(ctx)
+	tree, err := s.treeRepo.GetByID(ctx, treeID)
+	if err != nil {
+		log.Debug("failed to fetch tree by id", "error", err, "tree_id", treeID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	transitions, err := s.historyRepo.GetByTreeID(ctx, treeID, from.UTC())
+	if err != nil {
+		log.Debug("failed to fetch watering status transitions of tree", "error", err, "tree_id", treeID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return newTimeline(tree.CreatedAt, tree.WateringStatus, transitions, from, to), nil
+}
+
+func (s *WateringStatusService) GetTreeClusterTimeline(ctx context.Context, clusterID int32, from, to time.Time) (*entities.WateringStatusTimeline, error) {
+	log := logger.GetLogger(ctx)
+	cluster, err := s.treeClusterRepo.GetByID(ctx, clusterID)
+	if err != nil {
+		log.Debug("failed to fetch tree cluster by id", "error", err, "cluster_id", clusterID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	transitions, err := s.historyRepo.GetByTreeClusterID(ctx, clusterID, from.UTC())
+	if err != nil {
+		log.Debug("failed to fetch watering status transitions of tree cluster", "error", err, "cluster_id", clusterID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return newTimeline(cluster.CreatedAt, cluster.WateringStatus, transitions, from, to), nil
+}
+
+func (s *WateringStatusService) Ready() bool {
+	return s.historyRepo != nil && s.treeRepo != nil && s.treeClusterRepo != nil
+}
+
+// newTimeline splits the time between from and to into periods of the same watering status. The transitions
+// must be ordered by time and contain all transitions since from, the status before the first transition is
+// its old status, without transitions the status has not changed since from.
+func newTimeline(createdAt time.Time, current entities.WateringStatus, transitions []*entities.WateringStatusTransition, from, to time.Time) *entities.WateringStatusTimeline {
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+
+	timeline := &entities.WateringStatusTimeline{
+		From:        from,
+		To:          to,
+		Transitions: make([]*entities.WateringStatusTransition, 0),
+		Periods:     make([]*entities.WateringStatusPeriod, 0),
+	}
+
+	start := from
+	if createdAt.After(start) {
+		start = createdAt
+	}
+
+	status := current
+	if len(transitions) > 0 {
+		status = transitions[0].OldStatus
+	}
+
+	var cause *entities.WateringStatusCause
+	for _, t := range transitions {
+		if !t.CreatedAt.Before(to) {
+			break
+		}
+
+		timeline.Transitions = append(timeline.Transitions, t)
+		addPeriod(timeline, status, cause, start, t.CreatedAt)
+		status = t.NewStatus
+		cause = &t.Cause
+		start = t.CreatedAt
+	}
+	addPeriod(timeline, status, cause, start, to)
+
+	return timeline
+}
+
+func addPeriod(timeline *entities.WateringStatusTimeline, status entities.WateringStatus, cause *entities.WateringStatusCause, from, to time.Time) {
+	if !to.After(from) {
+		return
+	}
+
+	timeline.Periods = append(timeline.Periods, &entities.WateringStatusPeriod{
+		Status: status,
+		Cause:  cause,
+		From:   from,
+		To:     to,
+	})
+
+	days := to.Sub(from).Hours() / 24
+	switch status {
+	case entities.WateringStatusGood:
+		timeline.Days.Good += days
+	case entities.WateringStatusModerate:
+		timeline.Days.Moderate += days
+	case entities.WateringStatusBad:
+		timeline.Days.Bad += days
+	default:
+		timeline.Days.Unknown += days
+	}
+}
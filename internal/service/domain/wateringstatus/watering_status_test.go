@@ -0,0 +1,233 @@
+package wateringstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRepos struct {
+	historyRepo     *storageMock.MockWateringStatusHistoryRepository
+	treeRepo        *storageMock.MockTreeRepository
+	treeClusterRepo *storageMock.MockTreeClusterRepository
+}
+
+func newTestService(t *testing.T) (*WateringStatusService, testRepos) {
+	repos := testRepos{
+		historyRepo:     storageMock.NewMockWateringStatusHistoryRepository(t),
+		treeRepo:        storageMock.NewMockTreeRepository(t),
+		treeClusterRepo: storageMock.NewMockTreeClusterRepository(t),
+	}
+	svc := NewWateringStatusService(repos.historyRepo, repos.treeRepo, repos.treeClusterRepo)
+	return svc.(*WateringStatusService), repos
+}
+
+var (
+	from = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func getTestTransitions() []*entities.WateringStatusTransition {
+	return []*entities.WateringStatusTransition{
+		{
+			ID:        1,
+			CreatedAt: time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC),
+			OldStatus: entities.WateringStatusGood,
+			NewStatus: entities.WateringStatusModerate,
+			Cause:     entities.WateringStatusCauseSensorData,
+		},
+		{
+			ID:        2,
+			CreatedAt: time.Date(2025, 1, 16, 12, 0, 0, 0, time.UTC),
+			OldStatus: entities.WateringStatusModerate,
+			NewStatus: entities.WateringStatusBad,
+			Cause:     entities.WateringStatusCauseWeather,
+		},
+		{
+			ID:        3,
+			CreatedAt: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+			OldStatus: entities.WateringStatusBad,
+			NewStatus: entities.WateringStatusGood,
+			Cause:     entities.WateringStatusCauseWateringPlan,
+		},
+	}
+}
+
+func TestWateringStatusService_GetTreeClusterTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should split the time into periods and sum up the days per status", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cluster := &entities.TreeCluster{ID: 1, CreatedAt: from.AddDate(-1, 0, 0), WateringStatus: entities.WateringStatusGood}
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(cluster, nil)
+		repos.historyRepo.EXPECT().GetByTreeClusterID(ctx, int32(1), from).Return(getTestTransitions(), nil)
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, to)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, from, got.From)
+		assert.Equal(t, to, got.To)
+		assert.Len(t, got.Transitions, 3)
+		assert.Len(t, got.Periods, 4)
+
+		assert.Equal(t, entities.WateringStatusGood, got.Periods[0].Status)
+		assert.Nil(t, got.Periods[0].Cause)
+		assert.Equal(t, from, got.Periods[0].From)
+		assert.Equal(t, getTestTransitions()[0].CreatedAt, got.Periods[0].To)
+
+		assert.Equal(t, entities.WateringStatusBad, got.Periods[2].Status)
+		assert.Equal(t, entities.WateringStatusCauseWeather, *got.Periods[2].Cause)
+
+		assert.Equal(t, entities.WateringStatusGood, got.Periods[3].Status)
+		assert.Equal(t, entities.WateringStatusCauseWateringPlan, *got.Periods[3].Cause)
+		assert.Equal(t, to, got.Periods[3].To)
+
+		assert.InDelta(t, 10+11, got.Days.Good, 0.001)
+		assert.InDelta(t, 5.5, got.Days.Moderate, 0.001)
+		assert.InDelta(t, 3.5, got.Days.Bad, 0.001)
+		assert.InDelta(t, 0, got.Days.Unknown, 0.001)
+	})
+
+	t.Run("should use the current status without transitions", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cluster := &entities.TreeCluster{ID: 1, CreatedAt: from.AddDate(-1, 0, 0), WateringStatus: entities.WateringStatusModerate}
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(cluster, nil)
+		repos.historyRepo.EXPECT().GetByTreeClusterID(ctx, int32(1), from).Return([]*entities.WateringStatusTransition{}, nil)
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, to)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got.Transitions)
+		assert.Len(t, got.Periods, 1)
+		assert.Equal(t, entities.WateringStatusModerate, got.Periods[0].Status)
+		assert.InDelta(t, 30, got.Days.Moderate, 0.001)
+	})
+
+	t.Run("should start the timeline with the creation of the tree cluster", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		createdAt := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+		cluster := &entities.TreeCluster{ID: 1, CreatedAt: createdAt, WateringStatus: entities.WateringStatusUnknown}
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(cluster, nil)
+		repos.historyRepo.EXPECT().GetByTreeClusterID(ctx, int32(1), from).Return([]*entities.WateringStatusTransition{}, nil)
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, to)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Periods, 1)
+		assert.Equal(t, createdAt, got.Periods[0].From)
+		assert.InDelta(t, 10, got.Days.Unknown, 0.001)
+	})
+
+	t.Run("should ignore transitions after the end of the timeline", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		cluster := &entities.TreeCluster{ID: 1, CreatedAt: from.AddDate(-1, 0, 0), WateringStatus: entities.WateringStatusGood}
+		end := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(cluster, nil)
+		repos.historyRepo.EXPECT().GetByTreeClusterID(ctx, int32(1), from).Return(getTestTransitions(), nil)
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, end)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Transitions, 1)
+		assert.Len(t, got.Periods, 2)
+		assert.Equal(t, entities.WateringStatusModerate, got.Periods[1].Status)
+		assert.Equal(t, end, got.Periods[1].To)
+		assert.InDelta(t, 10, got.Days.Good, 0.001)
+		assert.InDelta(t, 4, got.Days.Moderate, 0.001)
+	})
+
+	t.Run("should return error when tree cluster is not found", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, to)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+
+	t.Run("should return error when transitions can not be fetched", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+		repos.historyRepo.EXPECT().GetByTreeClusterID(ctx, int32(1), from).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetTreeClusterTimeline(ctx, 1, from, to)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestWateringStatusService_GetTreeTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return timeline of tree", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		tree := &entities.Tree{ID: 1, CreatedAt: from.AddDate(-1, 0, 0), WateringStatus: entities.WateringStatusGood}
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(1)).Return(tree, nil)
+		repos.historyRepo.EXPECT().GetByTreeID(ctx, int32(1), from).Return(getTestTransitions(), nil)
+
+		// when
+		got, err := svc.GetTreeTimeline(ctx, 1, from, to)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Periods, 4)
+		assert.InDelta(t, 30, got.Days.Good+got.Days.Moderate+got.Days.Bad+got.Days.Unknown, 0.001)
+	})
+
+	t.Run("should return error when tree is not found", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t)
+		repos.treeRepo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetTreeTimeline(ctx, 1, from, to)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestWateringStatusService_Ready(t *testing.T) {
+	t.Run("should return true if all repositories are set", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if a repository is nil", func(t *testing.T) {
+		svc := NewWateringStatusService(nil, nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
@@ -0,0 +1,206 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+// UpdateWeather fetches the weather of all regions with tree clusters from the provider and recalculates the water
+// balance of all tree clusters. The watering status of tree clusters without current sensor data is set to the
+// adjusted status of their water balance. The water balance of tree clusters without sensor data starts at the day
+// their watering status is inferred from similar tree clusters nearby.
+func (s *WeatherService) UpdateWeather(ctx context.Context) error {
+	now := time.Now()
+	if s.provider != nil {
+		if err := s.fetchWeather(ctx, now); err != nil {
+			return err
+		}
+	}
+
+	return s.updateWaterBalances(ctx, now)
+}
+
+func (s *WeatherService) fetchWeather(ctx context.Context, now time.Time) error {
+	log := logger.GetLogger(ctx)
+	locations, err := s.weatherRepo.GetLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, location := range locations {
+		days, err := s.provider.GetDaily(ctx, location.Latitude, location.Longitude, s.pastDays, s.forecastDays)
+		if err != nil {
+			log.Error("failed to fetch weather of region", "error", err, "region_id", location.RegionID, "provider", s.provider.Name())
+			continue
+		}
+
+		for _, day := range days {
+			day.RegionID = location.RegionID
+		}
+
+		if err := s.weatherRepo.Save(ctx, days...); err != nil {
+			return err
+		}
+
+		log.Debug("fetched weather of region", "region_id", location.RegionID, "days", len(days), "provider", s.provider.Name())
+	}
+
+	deleted, err := s.weatherRepo.DeleteBefore(ctx, svcUtils.StartOfDay(now.Add(-weatherRetention)))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		log.Info("deleted outdated weather days", "deleted_days", deleted)
+	}
+
+	return nil
+}
+
+func (s *WeatherService) updateWaterBalances(ctx context.Context, now time.Time) error {
+	log := logger.GetLogger(ctx)
+	// the updater runs without a http request, so there are no pagination values in the context
+	clusters, _, err := s.treeClusterRepo.GetAll(pagination.WithoutLimit(ctx), entities.TreeClusterQuery{})
+	if err != nil {
+		return err
+	}
+
+	today := svcUtils.StartOfDay(now)
+	weather := make(map[int32][]*entities.WeatherDay)
+	var flagged, adjusted int
+	for _, cluster := range clusters {
+		if cluster.Archived || cluster.Region == nil {
+			continue
+		}
+
+		latest, err := svcUtils.LatestSensorData(ctx, s.treeClusterRepo, cluster.ID)
+		if err != nil {
+			return err
+		}
+
+		// the watering status inferred from similar tree clusters is as current as the sensor data it is inferred
+		// from, so it is not replaced by the water balance until it is stale
+		if latest == nil {
+			latest = cluster.WateringStatusInferredAt
+		}
+
+		fresh := latest != nil && now.Sub(*latest) <= s.staleAfter
+		balance, err := s.newWaterBalance(ctx, cluster, latest, fresh)
+		if err != nil {
+			return err
+		}
+
+		if balance == nil {
+			log.Debug("tree cluster has no known watering status, skipping water balance", "cluster_id", cluster.ID)
+			continue
+		}
+
+		days, ok := weather[cluster.Region.ID]
+		if !ok {
+			days, err = s.weatherRepo.GetByRegionID(ctx, cluster.Region.ID, today.Add(-weatherRetention), today.AddDate(0, 0, s.forecastDays))
+			if err != nil {
+				return err
+			}
+			weather[cluster.Region.ID] = days
+		}
+
+		s.model.Simulate(balance, cluster.SoilCondition, days, today)
+		if err := s.weatherRepo.SaveWaterBalance(ctx, balance); err != nil {
+			return err
+		}
+
+		if balance.Flagged {
+			flagged++
+			log.Info("tree cluster turns bad within the forecast horizon", "cluster_id", cluster.ID, "bad_at", balance.BadAt, "adjusted_status", balance.AdjustedStatus)
+		}
+
+		// the watering status of tree clusters without current data is modeled and therefore marked as estimated,
+		// also for tree clusters with sensors
+		if fresh || (balance.AdjustedStatus == cluster.WateringStatus && cluster.WateringStatusEstimated) {
+			continue
+		}
+
+		if err := s.updateWateringStatus(ctx, cluster, balance.AdjustedStatus); err != nil {
+			log.Error("failed to update watering status of tree cluster with water balance", "error", err, "cluster_id", cluster.ID)
+			continue
+		}
+		adjusted++
+	}
+
+	log.Info("water balances of tree clusters updated", "flagged_clusters", flagged, "adjusted_clusters", adjusted)
+	return nil
+}
+
+// newWaterBalance determines the watering status and date the water balance of a tree cluster starts with. With
+// sensor data or an inferred watering status newer than the stale duration it starts with the current watering
+// status at the day of the data, otherwise with the start of the previous water balance. A watering after that
+// starts the balance as good at the day of the watering. Returns nil if the watering status of the tree cluster is
+// not known.
+func (s *WeatherService) newWaterBalance(ctx context.Context, cluster *entities.TreeCluster, latestData *time.Time, fresh bool) (*entities.WaterBalance, error) {
+	balance := &entities.WaterBalance{
+		TreeClusterID:  cluster.ID,
+		RegionID:       cluster.Region.ID,
+		WateringStatus: cluster.WateringStatus,
+		BaseStatus:     cluster.WateringStatus,
+		BaseDate:       svcUtils.StartOfDay(cluster.UpdatedAt),
+	}
+
+	if latestData != nil {
+		balance.BaseDate = svcUtils.StartOfDay(*latestData)
+	}
+
+	if !fresh {
+		prev, err := s.weatherRepo.GetWaterBalanceByTreeClusterID(ctx, cluster.ID)
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if err != nil && !errors.As(err, &entityNotFoundErr) {
+			return nil, err
+		}
+
+		if prev != nil && (latestData == nil || !prev.BaseDate.Before(svcUtils.StartOfDay(*latestData))) {
+			balance.BaseStatus = prev.BaseStatus
+			balance.BaseDate = prev.BaseDate
+		}
+	}
+
+	if cluster.LastWatered != nil && svcUtils.StartOfDay(*cluster.LastWatered).After(balance.BaseDate) {
+		balance.BaseStatus = entities.WateringStatusGood
+		balance.BaseDate = svcUtils.StartOfDay(*cluster.LastWatered)
+	}
+
+	if balance.BaseStatus == entities.WateringStatusUnknown || balance.BaseStatus == "" {
+		return nil, nil
+	}
+
+	return balance, nil
+}
+
+func (s *WeatherService) updateWateringStatus(ctx context.Context, cluster *entities.TreeCluster, status entities.WateringStatus) error {
+	log := logger.GetLogger(ctx)
+	err := s.treeClusterRepo.Update(storage.WithWateringStatusCause(ctx, entities.WateringStatusCauseWeather), cluster.ID, func(tc *entities.TreeCluster) (bool, error) {
+		tc.WateringStatus = status
+		tc.WateringStatusEstimated = true
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.treeClusterRepo.GetByID(ctx, cluster.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("publish new event", "event", entities.EventTypeUpdateTreeCluster, "service", "WeatherService")
+	if err := s.eventManager.Publish(ctx, entities.NewEventUpdateTreeCluster(cluster, updated)); err != nil {
+		log.Error("error while sending event after updating tree cluster", "err", err)
+	}
+
+	return nil
+}
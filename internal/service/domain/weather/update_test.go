@@ -0,0 +1,361 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// dryDays returns weather days from the offset to today with 6mm ET0 and no precipitation
+func dryDays(today time.Time, offset int) []*entities.WeatherDay {
+	days := make([]*entities.WeatherDay, 0)
+	for i := offset; i <= 0; i++ {
+		days = append(days, &entities.WeatherDay{RegionID: 1, Date: today.AddDate(0, 0, i), ET0: 6})
+	}
+	return days
+}
+
+// expectAllClusters expects the tree clusters to be fetched like the tree cluster repository does, which fails
+// without pagination values in the context
+func expectAllClusters(repos testRepos, clusters []*entities.TreeCluster) {
+	repos.treeClusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).RunAndReturn(func(ctx context.Context, _ entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error) {
+		if _, _, err := pagination.GetValues(ctx); err != nil {
+			return nil, 0, err
+		}
+		return clusters, int64(len(clusters)), nil
+	})
+}
+
+func TestWeatherService_UpdateWeather(t *testing.T) {
+	ctx := context.Background()
+	today := svcUtils.StartOfDay(time.Now())
+
+	t.Run("should fetch and save weather of all locations", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		locations := []*entities.WeatherLocation{
+			{RegionID: 1, Latitude: 54.8, Longitude: 9.46},
+			{RegionID: 4, Latitude: 54.77, Longitude: 9.45},
+		}
+
+		repos.weatherRepo.EXPECT().GetLocations(ctx).Return(locations, nil)
+		repos.provider.EXPECT().Name().Return("file")
+		repos.provider.EXPECT().GetDaily(ctx, 54.8, 9.46, defaultPastDays, defaultForecastDays).Return([]*entities.WeatherDay{{Date: today, ET0: 1}}, nil)
+		repos.provider.EXPECT().GetDaily(ctx, 54.77, 9.45, defaultPastDays, defaultForecastDays).Return(nil, errors.New("provider error"))
+		repos.weatherRepo.EXPECT().Save(ctx, mock.Anything).RunAndReturn(func(_ context.Context, days ...*entities.WeatherDay) error {
+			assert.Len(t, days, 1)
+			assert.Equal(t, int32(1), days[0].RegionID)
+			return nil
+		})
+		repos.weatherRepo.EXPECT().DeleteBefore(ctx, svcUtils.StartOfDay(time.Now().Add(-weatherRetention))).Return(int64(2), nil)
+		expectAllClusters(repos, []*entities.TreeCluster{})
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when saving weather fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		repos.weatherRepo.EXPECT().GetLocations(ctx).Return([]*entities.WeatherLocation{{RegionID: 1}}, nil)
+		repos.provider.EXPECT().GetDaily(ctx, 0.0, 0.0, defaultPastDays, defaultForecastDays).Return([]*entities.WeatherDay{{Date: today}}, nil)
+		repos.weatherRepo.EXPECT().Save(ctx, mock.Anything).Return(errors.New("internal error"))
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should adjust watering status of tree cluster without current sensor data", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		cluster := &entities.TreeCluster{
+			ID:             1,
+			UpdatedAt:      today.AddDate(0, 0, -5).Add(10 * time.Hour),
+			WateringStatus: entities.WateringStatusGood,
+			Region:         &entities.Region{ID: 1},
+			SoilCondition:  entities.TreeSoilConditionSandig,
+		}
+
+		expectAllClusters(repos, []*entities.TreeCluster{cluster})
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{}, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), today.Add(-weatherRetention), today.AddDate(0, 0, defaultForecastDays)).Return(dryDays(today, -4), nil)
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).RunAndReturn(func(_ context.Context, balance *entities.WaterBalance) error {
+			assert.Equal(t, int32(1), balance.RegionID)
+			assert.Equal(t, entities.WateringStatusGood, balance.BaseStatus)
+			assert.Equal(t, today.AddDate(0, 0, -5), balance.BaseDate)
+			assert.Equal(t, entities.WateringStatusGood, balance.WateringStatus)
+			assert.Equal(t, entities.WateringStatusModerate, balance.AdjustedStatus)
+			assert.InDelta(t, 31.5, balance.Deficit, 0.001)
+			return nil
+		})
+		repos.treeClusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{}
+			ok, err := fn(tc)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusModerate, tc.WateringStatus)
+			assert.True(t, tc.WateringStatusEstimated)
+			cause, ok := storage.WateringStatusCauseFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, entities.WateringStatusCauseWeather, cause)
+			return nil
+		})
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1, WateringStatus: entities.WateringStatusModerate}, nil)
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep watering status of tree cluster with current sensor data and flag it", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		cluster := &entities.TreeCluster{
+			ID:             1,
+			WateringStatus: entities.WateringStatusModerate,
+			Region:         &entities.Region{ID: 1},
+			SoilCondition:  entities.TreeSoilConditionSandig,
+		}
+		forecast := []*entities.WeatherDay{
+			{RegionID: 1, Date: today.AddDate(0, 0, 1), ET0: 5},
+			{RegionID: 1, Date: today.AddDate(0, 0, 2), ET0: 5},
+		}
+
+		expectAllClusters(repos, []*entities.TreeCluster{cluster})
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{
+			{CreatedAt: time.Now().Add(-time.Hour)},
+		}, nil)
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return(forecast, nil)
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).RunAndReturn(func(_ context.Context, balance *entities.WaterBalance) error {
+			assert.Equal(t, entities.WateringStatusModerate, balance.BaseStatus)
+			assert.Equal(t, svcUtils.StartOfDay(time.Now().Add(-time.Hour)), balance.BaseDate)
+			assert.Equal(t, entities.WateringStatusModerate, balance.AdjustedStatus)
+			assert.Equal(t, entities.WateringStatusBad, balance.ForecastStatus)
+			assert.True(t, balance.Flagged)
+			return nil
+		})
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should start water balance at the previous base and a later watering", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		clusters := []*entities.TreeCluster{
+			{
+				ID:             1,
+				WateringStatus: entities.WateringStatusBad,
+				Region:         &entities.Region{ID: 1},
+				SoilCondition:  entities.TreeSoilConditionLehmig,
+			},
+			{
+				ID:             2,
+				WateringStatus: entities.WateringStatusBad,
+				LastWatered:    utils.P(today.AddDate(0, 0, -1).Add(8 * time.Hour)),
+				Region:         &entities.Region{ID: 1},
+				SoilCondition:  entities.TreeSoilConditionLehmig,
+			},
+		}
+
+		expectAllClusters(repos, clusters)
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, mock.Anything).Return(nil, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, mock.Anything).Return(&entities.WaterBalance{
+			BaseStatus: entities.WateringStatusModerate,
+			BaseDate:   today.AddDate(0, 0, -3),
+		}, nil)
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return([]*entities.WeatherDay{}, nil).Once()
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).RunAndReturn(func(_ context.Context, balance *entities.WaterBalance) error {
+			if balance.TreeClusterID == 1 {
+				assert.Equal(t, entities.WateringStatusModerate, balance.BaseStatus)
+				assert.Equal(t, today.AddDate(0, 0, -3), balance.BaseDate)
+			} else {
+				assert.Equal(t, entities.WateringStatusGood, balance.BaseStatus)
+				assert.Equal(t, today.AddDate(0, 0, -1), balance.BaseDate)
+			}
+			return nil
+		})
+		repos.treeClusterRepo.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, mock.Anything).Return(&entities.TreeCluster{}, nil)
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep inferred watering status of tree cluster without sensor data until it is stale", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		cluster := &entities.TreeCluster{
+			ID:                       1,
+			WateringStatus:           entities.WateringStatusBad,
+			WateringStatusEstimated:  true,
+			WateringStatusInferredAt: utils.P(today),
+			Region:                   &entities.Region{ID: 1},
+			SoilCondition:            entities.TreeSoilConditionSandig,
+		}
+
+		expectAllClusters(repos, []*entities.TreeCluster{cluster})
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{}, nil)
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return(dryDays(today, 0), nil)
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).RunAndReturn(func(_ context.Context, balance *entities.WaterBalance) error {
+			assert.Equal(t, entities.WateringStatusBad, balance.BaseStatus)
+			assert.Equal(t, today, balance.BaseDate)
+			return nil
+		})
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+		repos.treeClusterRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should mark unchanged watering status of tree cluster without sensor data as estimated", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		cluster := &entities.TreeCluster{
+			ID:             1,
+			WateringStatus: entities.WateringStatusGood,
+			Region:         &entities.Region{ID: 1},
+			SoilCondition:  entities.TreeSoilConditionLehmig,
+		}
+
+		expectAllClusters(repos, []*entities.TreeCluster{cluster})
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return(nil, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return([]*entities.WeatherDay{}, nil)
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).Return(nil)
+		repos.treeClusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{WateringStatus: entities.WateringStatusGood}
+			_, err := fn(tc)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusGood, tc.WateringStatus)
+			assert.True(t, tc.WateringStatusEstimated)
+			return nil
+		})
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should mark watering status of tree cluster with stale sensor data as estimated", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		measuredAt := today.AddDate(0, 0, -5).Add(10 * time.Hour)
+		cluster := &entities.TreeCluster{
+			ID:             1,
+			WateringStatus: entities.WateringStatusGood,
+			Region:         &entities.Region{ID: 1},
+			SoilCondition:  entities.TreeSoilConditionSandig,
+		}
+
+		expectAllClusters(repos, []*entities.TreeCluster{cluster})
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{{CreatedAt: measuredAt}}, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return(dryDays(today, -4), nil)
+		repos.weatherRepo.EXPECT().SaveWaterBalance(ctx, mock.Anything).Return(nil)
+		repos.treeClusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.TreeCluster) (bool, error)) error {
+			tc := &entities.TreeCluster{}
+			_, err := fn(tc)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusModerate, tc.WateringStatus)
+			assert.True(t, tc.WateringStatusEstimated)
+			return nil
+		})
+		repos.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should skip archived tree clusters, without region or with unknown watering status", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		clusters := []*entities.TreeCluster{
+			{ID: 1, Archived: true, WateringStatus: entities.WateringStatusGood, Region: &entities.Region{ID: 1}},
+			{ID: 2, WateringStatus: entities.WateringStatusGood},
+			{ID: 3, WateringStatus: entities.WateringStatusUnknown, Region: &entities.Region{ID: 1}},
+		}
+
+		expectAllClusters(repos, clusters)
+		repos.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(3)).Return(nil, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(3)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fetch all tree clusters with a context without pagination values", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		repos.treeClusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).RunAndReturn(func(ctx context.Context, _ entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error) {
+			page, limit, err := pagination.GetValues(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), page)
+			assert.Equal(t, int32(-1), limit)
+			return []*entities.TreeCluster{}, 0, nil
+		})
+
+		// when
+		err := svc.UpdateWeather(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when tree clusters can not be fetched", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		svc.provider = nil
+		repos.treeClusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return(nil, int64(0), errors.New("internal error"))
+
+		// when
+		err := svc.UpdateWeather(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+}
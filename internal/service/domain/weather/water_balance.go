@@ -0,0 +1,120 @@
+package weather
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+)
+
+const (
+	defaultCropCoefficient = 0.7
+	defaultRainEfficiency  = 0.8
+
+	// share of the available water capacity that is depleted when a tree cluster turns moderate or bad
+	moderateDepletion = 0.35
+	badDepletion      = 0.55
+)
+
+// availableWaterCapacity is the plant available water of the root zone in mm per soil condition
+var availableWaterCapacity = map[entities.TreeSoilCondition]float64{
+	entities.TreeSoilConditionSandig:    60,
+	entities.TreeSoilConditionLehmig:    110,
+	entities.TreeSoilConditionSchluffig: 130,
+	entities.TreeSoilConditionTonig:     90,
+	entities.TreeSoilConditionUnknown:   90,
+}
+
+// baseDepletion is the depletion a known watering status starts with, the middle of the range of the status
+var baseDepletion = map[entities.WateringStatus]float64{
+	entities.WateringStatusGood:     moderateDepletion / 2,
+	entities.WateringStatusModerate: (moderateDepletion + badDepletion) / 2,
+	entities.WateringStatusBad:      (badDepletion + 1) / 2,
+}
+
+// WaterBalanceModel is a single bucket model of the root zone of a tree cluster. Every day the trees use the
+// reference evapotranspiration scaled by the crop coefficient and the share of the precipitation given by the
+// rain efficiency refills the bucket. The deficit is limited by the available water capacity of the soil.
+type WaterBalanceModel struct {
+	cropCoefficient float64
+	rainEfficiency  float64
+}
+
+func NewWaterBalanceModel(cropCoefficient, rainEfficiency float64) *WaterBalanceModel {
+	if cropCoefficient <= 0 {
+		cropCoefficient = defaultCropCoefficient
+	}
+
+	if rainEfficiency <= 0 || rainEfficiency > 1 {
+		rainEfficiency = defaultRainEfficiency
+	}
+
+	return &WaterBalanceModel{
+		cropCoefficient: cropCoefficient,
+		rainEfficiency:  rainEfficiency,
+	}
+}
+
+// Simulate starts with the deficit of the base status at the end of the base date and applies the weather days
+// after it. Days without weather keep the deficit. The status at the end of today is the adjusted status, the most
+// severe status of today and the following days the forecast status.
+func (m *WaterBalanceModel) Simulate(balance *entities.WaterBalance, soil entities.TreeSoilCondition, weather []*entities.WeatherDay, today time.Time) {
+	capacity, ok := availableWaterCapacity[soil]
+	if !ok {
+		capacity = availableWaterCapacity[entities.TreeSoilConditionUnknown]
+	}
+
+	deficit := baseDepletion[balance.BaseStatus] * capacity
+	balance.Deficit = deficit
+	balance.AdjustedStatus = balance.BaseStatus
+	balance.Days = make([]entities.WaterBalanceDay, 0, len(weather))
+	balance.BadAt = nil
+
+	for _, day := range weather {
+		if !day.Date.After(balance.BaseDate) {
+			continue
+		}
+
+		deficit += m.cropCoefficient*day.ET0 - m.rainEfficiency*day.Precipitation
+		deficit = min(max(deficit, 0), capacity)
+		status := statusOfDepletion(deficit / capacity)
+		forecast := day.Date.After(today)
+
+		balance.Days = append(balance.Days, entities.WaterBalanceDay{
+			Date:           day.Date,
+			Precipitation:  day.Precipitation,
+			ET0:            day.ET0,
+			Deficit:        deficit,
+			WateringStatus: status,
+			Forecast:       forecast,
+		})
+
+		if !forecast {
+			balance.AdjustedStatus = status
+			balance.Deficit = deficit
+		} else if status == entities.WateringStatusBad && balance.BadAt == nil {
+			badAt := day.Date
+			balance.BadAt = &badAt
+		}
+	}
+
+	balance.ForecastStatus = balance.AdjustedStatus
+	for _, day := range balance.Days {
+		if day.Forecast && svcUtils.StatusSeverity(day.WateringStatus) > svcUtils.StatusSeverity(balance.ForecastStatus) {
+			balance.ForecastStatus = day.WateringStatus
+		}
+	}
+
+	balance.Flagged = balance.BadAt != nil && balance.AdjustedStatus != entities.WateringStatusBad
+}
+
+func statusOfDepletion(depletion float64) entities.WateringStatus {
+	switch {
+	case depletion >= badDepletion:
+		return entities.WateringStatusBad
+	case depletion >= moderateDepletion:
+		return entities.WateringStatusModerate
+	default:
+		return entities.WateringStatusGood
+	}
+}
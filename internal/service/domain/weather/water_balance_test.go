@@ -0,0 +1,135 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBaseDate = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testWeatherDays(offset int, days ...[2]float64) []*entities.WeatherDay {
+	result := make([]*entities.WeatherDay, 0, len(days))
+	for i, d := range days {
+		result = append(result, &entities.WeatherDay{
+			RegionID:      1,
+			Date:          testBaseDate.AddDate(0, 0, offset+i),
+			Precipitation: d[0],
+			ET0:           d[1],
+		})
+	}
+	return result
+}
+
+func TestNewWaterBalanceModel(t *testing.T) {
+	t.Run("should use given coefficients", func(t *testing.T) {
+		// when
+		got := NewWaterBalanceModel(1.1, 0.5)
+
+		// then
+		assert.Equal(t, 1.1, got.cropCoefficient)
+		assert.Equal(t, 0.5, got.rainEfficiency)
+	})
+
+	t.Run("should use defaults for invalid coefficients", func(t *testing.T) {
+		// when
+		got := NewWaterBalanceModel(0, 1.5)
+
+		// then
+		assert.Equal(t, defaultCropCoefficient, got.cropCoefficient)
+		assert.Equal(t, defaultRainEfficiency, got.rainEfficiency)
+	})
+}
+
+func TestWaterBalanceModel_Simulate(t *testing.T) {
+	model := NewWaterBalanceModel(0.7, 0.8)
+
+	t.Run("should flag good tree cluster turning bad within forecast on dry days", func(t *testing.T) {
+		// given
+		balance := &entities.WaterBalance{BaseStatus: entities.WateringStatusGood, BaseDate: testBaseDate}
+		weather := testWeatherDays(1, [2]float64{0, 5}, [2]float64{0, 5}, [2]float64{0, 5}, [2]float64{0, 5}, [2]float64{0, 5}, [2]float64{0, 5}, [2]float64{0, 5})
+		today := testBaseDate.AddDate(0, 0, 2)
+
+		// when
+		model.Simulate(balance, entities.TreeSoilConditionSandig, weather, today)
+
+		// then
+		assert.Len(t, balance.Days, 7)
+		assert.InDelta(t, 17.5, balance.Deficit, 0.001)
+		assert.Equal(t, entities.WateringStatusGood, balance.AdjustedStatus)
+		assert.Equal(t, entities.WateringStatusBad, balance.ForecastStatus)
+		assert.Equal(t, testBaseDate.AddDate(0, 0, 7), *balance.BadAt)
+		assert.True(t, balance.Flagged)
+
+		assert.False(t, balance.Days[1].Forecast)
+		assert.True(t, balance.Days[2].Forecast)
+		assert.Equal(t, entities.WateringStatusModerate, balance.Days[2].WateringStatus)
+		assert.InDelta(t, 35, balance.Days[6].Deficit, 0.001)
+	})
+
+	t.Run("should adjust status to observed weather", func(t *testing.T) {
+		// given
+		balance := &entities.WaterBalance{BaseStatus: entities.WateringStatusGood, BaseDate: testBaseDate}
+		weather := testWeatherDays(1, [2]float64{0, 6}, [2]float64{0, 6}, [2]float64{0, 6}, [2]float64{0, 6}, [2]float64{0, 6})
+		today := testBaseDate.AddDate(0, 0, 5)
+
+		// when
+		model.Simulate(balance, entities.TreeSoilConditionSandig, weather, today)
+
+		// then
+		assert.InDelta(t, 31.5, balance.Deficit, 0.001)
+		assert.Equal(t, entities.WateringStatusModerate, balance.AdjustedStatus)
+		assert.Equal(t, entities.WateringStatusModerate, balance.ForecastStatus)
+		assert.Nil(t, balance.BadAt)
+		assert.False(t, balance.Flagged)
+	})
+
+	t.Run("should refill root zone with rain without negative deficit", func(t *testing.T) {
+		// given
+		balance := &entities.WaterBalance{BaseStatus: entities.WateringStatusModerate, BaseDate: testBaseDate}
+		weather := testWeatherDays(1, [2]float64{80, 1})
+		today := testBaseDate.AddDate(0, 0, 1)
+
+		// when
+		model.Simulate(balance, entities.TreeSoilConditionLehmig, weather, today)
+
+		// then
+		assert.Equal(t, 0.0, balance.Deficit)
+		assert.Equal(t, entities.WateringStatusGood, balance.AdjustedStatus)
+	})
+
+	t.Run("should limit deficit to available water capacity of soil", func(t *testing.T) {
+		// given
+		balance := &entities.WaterBalance{BaseStatus: entities.WateringStatusBad, BaseDate: testBaseDate}
+		weather := testWeatherDays(1, [2]float64{0, 50}, [2]float64{0, 50})
+		today := testBaseDate.AddDate(0, 0, 1)
+
+		// when
+		model.Simulate(balance, entities.TreeSoilConditionTonig, weather, today)
+
+		// then
+		assert.Equal(t, 90.0, balance.Deficit)
+		assert.Equal(t, 90.0, balance.Days[1].Deficit)
+		assert.Equal(t, entities.WateringStatusBad, balance.AdjustedStatus)
+		assert.NotNil(t, balance.BadAt)
+		assert.False(t, balance.Flagged)
+	})
+
+	t.Run("should ignore weather up to base date and keep base status without weather", func(t *testing.T) {
+		// given
+		balance := &entities.WaterBalance{BaseStatus: entities.WateringStatusModerate, BaseDate: testBaseDate}
+		weather := testWeatherDays(-1, [2]float64{0, 5}, [2]float64{0, 5})
+
+		// when
+		model.Simulate(balance, entities.TreeSoilConditionUnknown, weather, testBaseDate)
+
+		// then
+		assert.Empty(t, balance.Days)
+		assert.InDelta(t, 40.5, balance.Deficit, 0.001)
+		assert.Equal(t, entities.WateringStatusModerate, balance.AdjustedStatus)
+		assert.Equal(t, entities.WateringStatusModerate, balance.ForecastStatus)
+		assert.False(t, balance.Flagged)
+	})
+}
@@ -0,0 +1,144 @@
+package weather
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+)
+
+const (
+	defaultInterval     = 3 * time.Hour
+	defaultPastDays     = 7
+	defaultForecastDays = 7
+	defaultStaleAfter   = 24 * time.Hour
+
+	// weather days are kept for this duration, the water balance of tree clusters without sensor data starts at the
+	// last known watering status and uses the weather since
+	weatherRetention = 90 * 24 * time.Hour
+)
+
+type WeatherService struct {
+	weatherRepo     storage.WeatherRepository
+	provider        storage.WeatherProvider
+	treeClusterRepo storage.TreeClusterRepository
+	eventManager    *worker.EventManager
+	model           *WaterBalanceModel
+	enabled         bool
+	pastDays        int
+	forecastDays    int
+	staleAfter      time.Duration
+}
+
+func NewWeatherService(
+	weatherRepo storage.WeatherRepository,
+	provider storage.WeatherProvider,
+	treeClusterRepo storage.TreeClusterRepository,
+	eventManager *worker.EventManager,
+	cfg *config.WeatherConfig,
+) service.WeatherService {
+	if cfg == nil {
+		cfg = &config.WeatherConfig{}
+	}
+
+	s := &WeatherService{
+		weatherRepo:     weatherRepo,
+		provider:        provider,
+		treeClusterRepo: treeClusterRepo,
+		eventManager:    eventManager,
+		model:           NewWaterBalanceModel(cfg.WaterBalance.CropCoefficient, cfg.WaterBalance.RainEfficiency),
+		enabled:         cfg.Enable,
+		pastDays:        cfg.PastDays,
+		forecastDays:    cfg.ForecastDays,
+		staleAfter:      cfg.WaterBalance.StaleAfter,
+	}
+
+	if s.pastDays <= 0 {
+		s.pastDays = defaultPastDays
+	}
+
+	if s.forecastDays <= 0 {
+		s.forecastDays = defaultForecastDays
+	}
+
+	if s.staleAfter <= 0 {
+		s.staleAfter = defaultStaleAfter
+	}
+
+	return s
+}
+
+// GetByRegionID returns the weather of the region from the past days until the end of the forecast horizon
+func (s *WeatherService) GetByRegionID(ctx context.Context, regionID int32) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	today := svcUtils.StartOfDay(time.Now())
+	days, err := s.weatherRepo.GetByRegionID(ctx, regionID, today.AddDate(0, 0, -s.pastDays), today.AddDate(0, 0, s.forecastDays))
+	if err != nil {
+		log.Debug("failed to fetch weather of region", "error", err, "region_id", regionID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return days, nil
+}
+
+func (s *WeatherService) GetAllWaterBalances(ctx context.Context, flagged bool) ([]*entities.WaterBalance, error) {
+	log := logger.GetLogger(ctx)
+	balances, err := s.weatherRepo.GetAllWaterBalances(ctx, flagged)
+	if err != nil {
+		log.Debug("failed to fetch water balances", "error", err, "flagged", flagged)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return balances, nil
+}
+
+func (s *WeatherService) GetWaterBalanceByTreeClusterID(ctx context.Context, clusterID int32) (*entities.WaterBalance, error) {
+	log := logger.GetLogger(ctx)
+	balance, err := s.weatherRepo.GetWaterBalanceByTreeClusterID(ctx, clusterID)
+	if err != nil {
+		log.Debug("failed to fetch water balance of tree cluster", "error", err, "cluster_id", clusterID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return balance, nil
+}
+
+// RunWeatherUpdater fetches the weather and updates the water balances on start and after every interval. It
+// returns immediately if the weather is disabled.
+func (s *WeatherService) RunWeatherUpdater(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger(ctx)
+	if !s.enabled || s.provider == nil {
+		log.Info("weather is disabled, water balances of tree clusters are not updated")
+		return
+	}
+
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.UpdateWeather(ctx); err != nil {
+			log.Error("failed to update weather and water balances", "error", err.Error())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Info("stopping weather updater")
+			return
+		}
+	}
+}
+
+func (s *WeatherService) Ready() bool {
+	return s.weatherRepo != nil && s.treeClusterRepo != nil
+}
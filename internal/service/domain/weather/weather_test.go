@@ -0,0 +1,201 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	svcUtils "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testRepos struct {
+	weatherRepo     *storageMock.MockWeatherRepository
+	provider        *storageMock.MockWeatherProvider
+	treeClusterRepo *storageMock.MockTreeClusterRepository
+}
+
+func newTestService(t *testing.T, cfg *config.WeatherConfig) (*WeatherService, testRepos) {
+	repos := testRepos{
+		weatherRepo:     storageMock.NewMockWeatherRepository(t),
+		provider:        storageMock.NewMockWeatherProvider(t),
+		treeClusterRepo: storageMock.NewMockTreeClusterRepository(t),
+	}
+	svc := NewWeatherService(repos.weatherRepo, repos.provider, repos.treeClusterRepo, worker.NewEventManager(), cfg)
+	return svc.(*WeatherService), repos
+}
+
+func TestNewWeatherService(t *testing.T) {
+	t.Run("should use configured values", func(t *testing.T) {
+		// when
+		svc, _ := newTestService(t, &config.WeatherConfig{
+			Enable:       true,
+			PastDays:     3,
+			ForecastDays: 10,
+			WaterBalance: config.WaterBalanceConfig{CropCoefficient: 0.9, RainEfficiency: 0.6, StaleAfter: 48 * time.Hour},
+		})
+
+		// then
+		assert.True(t, svc.enabled)
+		assert.Equal(t, 3, svc.pastDays)
+		assert.Equal(t, 10, svc.forecastDays)
+		assert.Equal(t, 48*time.Hour, svc.staleAfter)
+		assert.Equal(t, 0.9, svc.model.cropCoefficient)
+		assert.Equal(t, 0.6, svc.model.rainEfficiency)
+	})
+
+	t.Run("should use defaults without config", func(t *testing.T) {
+		// when
+		svc, _ := newTestService(t, nil)
+
+		// then
+		assert.False(t, svc.enabled)
+		assert.Equal(t, defaultPastDays, svc.pastDays)
+		assert.Equal(t, defaultForecastDays, svc.forecastDays)
+		assert.Equal(t, defaultStaleAfter, svc.staleAfter)
+		assert.Equal(t, defaultCropCoefficient, svc.model.cropCoefficient)
+	})
+}
+
+func TestWeatherService_GetByRegionID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return weather of past days and forecast", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		today := svcUtils.StartOfDay(time.Now())
+		expected := []*entities.WeatherDay{{RegionID: 1, Date: today, Precipitation: 1.2, ET0: 3.4}}
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), today.AddDate(0, 0, -defaultPastDays), today.AddDate(0, 0, defaultForecastDays)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetByRegionID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		repos.weatherRepo.EXPECT().GetByRegionID(ctx, int32(1), mock.Anything, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetByRegionID(ctx, 1)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherService_GetAllWaterBalances(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return flagged water balances", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		expected := []*entities.WaterBalance{{TreeClusterID: 1, Flagged: true}}
+		repos.weatherRepo.EXPECT().GetAllWaterBalances(ctx, true).Return(expected, nil)
+
+		// when
+		got, err := svc.GetAllWaterBalances(ctx, true)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		repos.weatherRepo.EXPECT().GetAllWaterBalances(ctx, false).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetAllWaterBalances(ctx, false)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherService_GetWaterBalanceByTreeClusterID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return water balance of tree cluster", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		expected := &entities.WaterBalance{TreeClusterID: 1}
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(1)).Return(expected, nil)
+
+		// when
+		got, err := svc.GetWaterBalanceByTreeClusterID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return not found error when tree cluster has no water balance", func(t *testing.T) {
+		// given
+		svc, repos := newTestService(t, nil)
+		repos.weatherRepo.EXPECT().GetWaterBalanceByTreeClusterID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetWaterBalanceByTreeClusterID(ctx, 1)
+
+		// then
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherService_RunWeatherUpdater(t *testing.T) {
+	t.Run("should return immediately when weather is disabled", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t, &config.WeatherConfig{Enable: false})
+
+		// when
+		done := make(chan struct{})
+		go func() {
+			svc.RunWeatherUpdater(context.Background(), time.Hour)
+			close(done)
+		}()
+
+		// then
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("weather updater did not return")
+		}
+	})
+}
+
+func TestWeatherService_Ready(t *testing.T) {
+	t.Run("should be ready with repositories", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t, nil)
+
+		// then
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should not be ready without repositories", func(t *testing.T) {
+		// given
+		svc := NewWeatherService(nil, nil, nil, nil, nil)
+
+		// then
+		assert.False(t, svc.Ready())
+	})
+}
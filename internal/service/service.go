@@ -25,6 +25,14 @@ var (
 	ErrVehiclePlateTaken      = NewError(BadRequest, "number plate is already taken")
 	ErrVehicleUnsupportedType = NewError(BadRequest, "vehicle type is not supported")
 	ErrUserNotCorrectRole     = NewError(BadRequest, "user has an incorrect role")
+	ErrDeadLetterReplayed     = NewError(BadRequest, "dead letter was already replayed or is being replayed")
+	ErrSensorIDTaken          = NewError(BadRequest, "sensor id is already taken")
+	ErrSensorAssignmentClosed = NewError(BadRequest, "sensor assignment review is already closed")
+	ErrTreeHasSensor          = NewError(BadRequest, "tree is already linked to a sensor")
+	ErrSensorCommandNotQueued = NewError(BadRequest, "sensor command is not queued")
+	ErrUnknownSensorDecoder   = NewError(BadRequest, "sensor payload decoder is not registered")
+	ErrSensorDecommissioned   = NewError(BadRequest, "sensor is decommissioned")
+	ErrReplacementHasTree     = NewError(BadRequest, "replacement sensor is already linked to a tree")
 )
 
 type Error struct {
@@ -125,7 +133,7 @@ type TreeClusterService interface {
 	Service
 	// TODO: use CrudService as soon as every service has pagination
 	// CrudService[domain.TreeCluster, domain.TreeClusterCreate, domain.TreeClusterUpdate]
-	GetAll(ctx context.Context) ([]*domain.TreeCluster, int64, error)
+	GetAll(ctx context.Context, query domain.TreeClusterQuery) ([]*domain.TreeCluster, int64, error)
 	GetByID(ctx context.Context, id int32) (*domain.TreeCluster, error)
 	Create(ctx context.Context, createData *domain.TreeClusterCreate) (*domain.TreeCluster, error)
 	Update(ctx context.Context, id int32, updateData *domain.TreeClusterUpdate) (*domain.TreeCluster, error)
@@ -136,6 +144,7 @@ type TreeClusterService interface {
 	HandleDeleteTree(context.Context, *domain.EventDeleteTree) error
 	HandleNewSensorData(context.Context, *domain.EventNewSensorData) error
 	HandleUpdateWateringPlan(context.Context, *domain.EventUpdateWateringPlan) error
+	RunWateringStatusInference(ctx context.Context, interval time.Duration)
 }
 
 type SensorService interface {
@@ -145,9 +154,92 @@ type SensorService interface {
 	Create(ctx context.Context, createData *domain.SensorCreate) (*domain.Sensor, error)
 	Update(ctx context.Context, id string, updateData *domain.SensorUpdate) (*domain.Sensor, error)
 	Delete(ctx context.Context, id string) error
+	Import(ctx context.Context, sensors []*domain.SensorImport) ([]*domain.Sensor, error)
 	HandleMessage(ctx context.Context, payload *domain.MqttPayload) (*domain.SensorData, error)
-	MapSensorToTree(ctx context.Context, sen *domain.Sensor) error
+	GetSensorDataHistory(ctx context.Context, id string, query *domain.SensorDataHistoryQuery) ([]*domain.SensorDataAggregate, error)
+	ExportSensorData(ctx context.Context, query *domain.SensorDataExportQuery) (io.ReadCloser, error)
+	GetMaintenanceList(ctx context.Context) ([]*domain.SensorBattery, error)
+	GetCalibration(ctx context.Context, id string) (*domain.SensorCalibration, error)
+	UpdateCalibration(ctx context.Context, id string, updateData *domain.SensorCalibrationUpdate) (*domain.SensorCalibration, error)
+	DeleteCalibration(ctx context.Context, id string) error
+	Decommission(ctx context.Context, id string, decommission *domain.SensorDecommission) (*domain.Sensor, error)
+	Replace(ctx context.Context, id string, replace *domain.SensorReplace) (*domain.Sensor, error)
 	RunStatusUpdater(ctx context.Context, interval time.Duration)
+	RunDataRetention(ctx context.Context, interval time.Duration)
+}
+
+type DeadLetterService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.DeadLetter, error)
+	GetAllByStatus(ctx context.Context, status domain.DeadLetterStatus) ([]*domain.DeadLetter, error)
+	GetByID(ctx context.Context, id int32) (*domain.DeadLetter, error)
+	Create(ctx context.Context, createData *domain.DeadLetterCreate) (*domain.DeadLetter, error)
+	Replay(ctx context.Context, id int32) (*domain.DeadLetter, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+type SensorAssignmentService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.SensorAssignmentReview, error)
+	GetAllByStatus(ctx context.Context, status domain.SensorAssignmentStatus) ([]*domain.SensorAssignmentReview, error)
+	GetByID(ctx context.Context, id int32) (*domain.SensorAssignmentReview, error)
+	Resolve(ctx context.Context, id int32, resolve *domain.SensorAssignmentResolve) (*domain.SensorAssignmentReview, error)
+	Dismiss(ctx context.Context, id int32) (*domain.SensorAssignmentReview, error)
+	MapSensorToTree(ctx context.Context, sen *domain.Sensor) error
+	HandleCreateSensor(ctx context.Context, event *domain.EventCreateSensor) error
+}
+
+type SensorCommandService interface {
+	Service
+	GetAllBySensorID(ctx context.Context, sensorID string) ([]*domain.SensorCommand, error)
+	GetAllQueued(ctx context.Context) ([]*domain.SensorCommand, error)
+	GetByID(ctx context.Context, id int32) (*domain.SensorCommand, error)
+	Create(ctx context.Context, sensorID string, createData *domain.SensorCommandCreate) (*domain.SensorCommand, error)
+	MarkSent(ctx context.Context, id int32) (*domain.SensorCommand, error)
+	Acknowledge(ctx context.Context, id int32) (*domain.SensorCommand, error)
+	Expire(ctx context.Context, id int32) (*domain.SensorCommand, error)
+}
+
+type WateringRuleService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.WateringRuleSet, error)
+	GetByID(ctx context.Context, id int32) (*domain.WateringRuleSet, error)
+	GetActive(ctx context.Context) (*domain.WateringRuleSet, error)
+	Create(ctx context.Context, createData *domain.WateringRuleSetCreate) (*domain.WateringRuleSet, error)
+	Activate(ctx context.Context, id int32) (*domain.WateringRuleSet, error)
+	// DryRun classifies the latest sensor data with the active and the proposed thresholds without storing anything
+	DryRun(ctx context.Context, proposed *domain.WateringRuleSetCreate) (*domain.WateringRuleDryRun, error)
+}
+
+type WeatherService interface {
+	Service
+	// GetByRegionID returns the weather of the region from the past days until the end of the forecast horizon
+	GetByRegionID(ctx context.Context, regionID int32) ([]*domain.WeatherDay, error)
+	// GetAllWaterBalances returns the water balances of all tree clusters, only the flagged ones if flagged is true
+	GetAllWaterBalances(ctx context.Context, flagged bool) ([]*domain.WaterBalance, error)
+	GetWaterBalanceByTreeClusterID(ctx context.Context, clusterID int32) (*domain.WaterBalance, error)
+	// UpdateWeather fetches the weather and recalculates the water balances of the tree clusters
+	UpdateWeather(ctx context.Context) error
+	RunWeatherUpdater(ctx context.Context, interval time.Duration)
+}
+
+type WateringStatusService interface {
+	Service
+	// GetTreeTimeline returns the watering status history of a tree between from and to
+	GetTreeTimeline(ctx context.Context, treeID int32, from, to time.Time) (*domain.WateringStatusTimeline, error)
+	// GetTreeClusterTimeline returns the watering status history of a tree cluster between from and to
+	GetTreeClusterTimeline(ctx context.Context, clusterID int32, from, to time.Time) (*domain.WateringStatusTimeline, error)
+}
+
+type SensorIngestService interface {
+	Service
+	Ingest(ctx context.Context, decoder string, payload []byte) (*domain.SensorData, error)
+}
+
+// SensorPayloadDecoder decodes a raw sensor message with the decoder registered under the given name
+type SensorPayloadDecoder interface {
+	DecodeSensorPayload(decoder string, payload []byte) (*domain.MqttPayload, error)
+	Names() []string
 }
 
 type CrudService[T any, CreateType any, UpdateType any] interface {
@@ -182,15 +274,22 @@ type Service interface {
 }
 
 type Services struct {
-	InfoService         InfoService
-	TreeService         TreeService
-	AuthService         AuthService
-	RegionService       RegionService
-	TreeClusterService  TreeClusterService
-	SensorService       SensorService
-	VehicleService      VehicleService
-	PluginService       PluginService
-	WateringPlanService WateringPlanService
+	InfoService             InfoService
+	TreeService             TreeService
+	AuthService             AuthService
+	RegionService           RegionService
+	TreeClusterService      TreeClusterService
+	SensorService           SensorService
+	VehicleService          VehicleService
+	PluginService           PluginService
+	WateringPlanService     WateringPlanService
+	DeadLetterService       DeadLetterService
+	SensorAssignmentService SensorAssignmentService
+	SensorCommandService    SensorCommandService
+	SensorIngestService     SensorIngestService
+	WateringRuleService     WateringRuleService
+	WeatherService          WeatherService
+	WateringStatusService   WateringStatusService
 }
 
 type ServicesInterface interface {
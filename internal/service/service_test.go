@@ -19,16 +19,30 @@ func TestAllServiceReady(t *testing.T) {
 		vehicleSvc := serviceMock.NewMockVehicleService(t)
 		pluginSvc := serviceMock.NewMockPluginService(t)
 		wateringPlanSvc := serviceMock.NewMockWateringPlanService(t)
+		deadLetterSvc := serviceMock.NewMockDeadLetterService(t)
+		sensorAssignmentSvc := serviceMock.NewMockSensorAssignmentService(t)
+		sensorCommandSvc := serviceMock.NewMockSensorCommandService(t)
+		sensorIngestSvc := serviceMock.NewMockSensorIngestService(t)
+		wateringRuleSvc := serviceMock.NewMockWateringRuleService(t)
+		weatherSvc := serviceMock.NewMockWeatherService(t)
+		wateringStatusSvc := serviceMock.NewMockWateringStatusService(t)
 		svc := Services{
-			InfoService:         infoSvc,
-			TreeService:         treeSvc,
-			AuthService:         authSvc,
-			RegionService:       regionSvc,
-			TreeClusterService:  treeClusterSvc,
-			SensorService:       sensorSvc,
-			VehicleService:      vehicleSvc,
-			PluginService:       pluginSvc,
-			WateringPlanService: wateringPlanSvc,
+			InfoService:             infoSvc,
+			TreeService:             treeSvc,
+			AuthService:             authSvc,
+			RegionService:           regionSvc,
+			TreeClusterService:      treeClusterSvc,
+			SensorService:           sensorSvc,
+			VehicleService:          vehicleSvc,
+			PluginService:           pluginSvc,
+			WateringPlanService:     wateringPlanSvc,
+			DeadLetterService:       deadLetterSvc,
+			SensorAssignmentService: sensorAssignmentSvc,
+			SensorCommandService:    sensorCommandSvc,
+			SensorIngestService:     sensorIngestSvc,
+			WateringRuleService:     wateringRuleSvc,
+			WeatherService:          weatherSvc,
+			WateringStatusService:   wateringStatusSvc,
 		}
 
 		// when
@@ -41,6 +55,13 @@ func TestAllServiceReady(t *testing.T) {
 		vehicleSvc.EXPECT().Ready().Return(true)
 		pluginSvc.EXPECT().Ready().Return(true)
 		wateringPlanSvc.EXPECT().Ready().Return(true)
+		deadLetterSvc.EXPECT().Ready().Return(true)
+		sensorAssignmentSvc.EXPECT().Ready().Return(true)
+		sensorCommandSvc.EXPECT().Ready().Return(true)
+		sensorIngestSvc.EXPECT().Ready().Return(true)
+		wateringRuleSvc.EXPECT().Ready().Return(true)
+		weatherSvc.EXPECT().Ready().Return(true)
+		wateringStatusSvc.EXPECT().Ready().Return(true)
 
 		ready := svc.AllServicesReady()
 
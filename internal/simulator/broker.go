@@ -0,0 +1,100 @@
+package simulator
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MemoryBroker is an in-memory stand-in for a mqtt broker. Published uplinks are delivered synchronously
+// to every subscription with a matching topic filter, so the mqtt message handlers can be tested without
+// a running broker. It implements Publisher.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	topic    string
+	subs     []memorySubscription
+	messages []MQTT.Message
+}
+
+type memorySubscription struct {
+	filter  string
+	handler MQTT.MessageHandler
+}
+
+// NewMemoryBroker returns a broker that publishes uplinks to the given device topic, {device} is replaced with the sensor id
+func NewMemoryBroker(topic string) *MemoryBroker {
+	return &MemoryBroker{topic: topic}
+}
+
+// Subscribe registers the handler for all topics matching the filter, the wildcards + and # are supported.
+// The handler is called without a client.
+func (b *MemoryBroker) Subscribe(filter string, handler MQTT.MessageHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, memorySubscription{filter: filter, handler: handler})
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, deviceID string, payload []byte) error {
+	msg := &memoryMessage{topic: deviceTopic(b.topic, deviceID), payload: payload}
+
+	b.mu.Lock()
+	b.messages = append(b.messages, msg)
+	subs := make([]memorySubscription, len(b.subs))
+	copy(subs, b.subs)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if matchTopic(sub.filter, msg.topic) {
+			sub.handler(nil, msg)
+		}
+	}
+
+	return nil
+}
+
+// Messages returns all published messages in the order they were published
+func (b *MemoryBroker) Messages() []MQTT.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	messages := make([]MQTT.Message, len(b.messages))
+	copy(messages, b.messages)
+	return messages
+}
+
+// matchTopic reports if the topic matches the mqtt topic filter
+func matchTopic(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+
+		if i >= len(topicLevels) {
+			return false
+		}
+
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
+
+type memoryMessage struct {
+	topic   string
+	payload []byte
+}
+
+func (m *memoryMessage) Duplicate() bool   { return false }
+func (m *memoryMessage) Qos() byte         { return publishQoS }
+func (m *memoryMessage) Retained() bool    { return false }
+func (m *memoryMessage) Topic() string     { return m.topic }
+func (m *memoryMessage) MessageID() uint16 { return 0 }
+func (m *memoryMessage) Payload() []byte   { return m.payload }
+func (m *memoryMessage) Ack()              {}
@@ -0,0 +1,71 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		filter string
+		topic  string
+		want   bool
+	}{
+		{filter: "v3/app/devices/sensor-1/up", topic: "v3/app/devices/sensor-1/up", want: true},
+		{filter: "v3/app/devices/+/up", topic: "v3/app/devices/sensor-1/up", want: true},
+		{filter: "v3/+/devices/+/up", topic: "v3/app/devices/sensor-1/up", want: true},
+		{filter: "v3/app/#", topic: "v3/app/devices/sensor-1/up", want: true},
+		{filter: "v3/app/#", topic: "v3/app", want: true},
+		{filter: "#", topic: "v3/app/devices/sensor-1/up", want: true},
+		{filter: "v3/app/devices/+/up", topic: "v3/app/devices/sensor-1/down/ack", want: false},
+		{filter: "v3/app/devices/+", topic: "v3/app/devices/sensor-1/up", want: false},
+		{filter: "v3/app/devices/+/up", topic: "v3/app/devices/up", want: false},
+		{filter: "v3/other/devices/+/up", topic: "v3/app/devices/sensor-1/up", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter+" "+tt.topic, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchTopic(tt.filter, tt.topic))
+		})
+	}
+}
+
+func TestMemoryBroker_Publish(t *testing.T) {
+	t.Run("should deliver message to matching subscriptions", func(t *testing.T) {
+		// given
+		b := NewMemoryBroker("v3/app/devices/{device}/up")
+		var up, down []string
+		b.Subscribe("v3/app/devices/+/up", func(_ MQTT.Client, msg MQTT.Message) {
+			up = append(up, msg.Topic())
+		})
+		b.Subscribe("v3/app/devices/+/down/#", func(_ MQTT.Client, msg MQTT.Message) {
+			down = append(down, msg.Topic())
+		})
+
+		// when
+		err := b.Publish(context.Background(), "sensor-1", []byte(`{}`))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"v3/app/devices/sensor-1/up"}, up)
+		assert.Empty(t, down)
+	})
+
+	t.Run("should keep published messages", func(t *testing.T) {
+		// given
+		b := NewMemoryBroker("")
+
+		// when
+		_ = b.Publish(context.Background(), "sensor-1", []byte(`1`))
+		_ = b.Publish(context.Background(), "sensor-2", []byte(`2`))
+
+		// then
+		messages := b.Messages()
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "v3/green-ecolution@ttn/devices/sensor-1/up", messages[0].Topic())
+		assert.Equal(t, []byte(`2`), messages[1].Payload())
+	})
+}
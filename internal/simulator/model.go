@@ -0,0 +1,232 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+)
+
+// Scenario is the behavior of a simulated sensor
+type Scenario string
+
+const (
+	// ScenarioDrying dries the soil continuously without any rain
+	ScenarioDrying Scenario = "drying"
+	// ScenarioRain dries the soil and rewets it with regular rain events
+	ScenarioRain Scenario = "rain"
+	// ScenarioDeadBattery drains the battery within a few days until the sensor stops sending
+	ScenarioDeadBattery Scenario = "dead_battery"
+)
+
+const (
+	// maxCentibar is the highest soil water tension a watermark probe reports
+	maxCentibar = 239
+	// batteryEmpty is the battery level at which the sensor stops sending
+	batteryEmpty = 3.0
+	// batteryBrownout is the battery level below which uplinks get lost
+	batteryBrownout = 3.1
+	// brownoutLossRate is the share of uplinks that is lost during a brownout
+	brownoutLossRate = 0.3
+)
+
+// depths of the three watermark probes in cm
+var depths = [3]int{30, 60, 90}
+
+// dryingFactor is the share of the drying rate that applies to the probe, deeper soil dries slower
+var dryingFactor = [3]float64{1, 0.6, 0.35}
+
+// infiltration is the share of the rain that reaches the probe
+var infiltration = [3]float64{1, 0.55, 0.25}
+
+type scenarioDefaults struct {
+	centibar     float64
+	dryingRate   float64
+	battery      float64
+	batteryDrain float64
+	rainInterval time.Duration
+	rainAmount   float64
+}
+
+var scenarios = map[Scenario]scenarioDefaults{
+	ScenarioDrying: {
+		centibar:     10,
+		dryingRate:   8,
+		battery:      3.6,
+		batteryDrain: 0.002,
+	},
+	ScenarioRain: {
+		centibar:     10,
+		dryingRate:   8,
+		battery:      3.6,
+		batteryDrain: 0.002,
+		rainInterval: 72 * time.Hour,
+		rainAmount:   20,
+	},
+	ScenarioDeadBattery: {
+		centibar:     10,
+		dryingRate:   8,
+		battery:      3.3,
+		batteryDrain: 0.05,
+	},
+}
+
+// Reading is a single measurement of a simulated sensor
+type Reading struct {
+	DeviceID    string
+	Time        time.Time
+	FCnt        uint32
+	Battery     float64
+	Humidity    float64
+	Temperature float64
+	Latitude    float64
+	Longitude   float64
+	Watermarks  [3]WatermarkReading
+}
+
+type WatermarkReading struct {
+	Depth      int
+	Centibar   int
+	Resistance int
+}
+
+// sensorModel holds the soil and battery state of a simulated sensor
+type sensorModel struct {
+	id           string
+	latitude     float64
+	longitude    float64
+	dryingRate   float64
+	batteryDrain float64
+	rainInterval time.Duration
+	rainAmount   float64
+
+	centibar [3]float64
+	battery  float64
+	lastRain time.Time
+	fCnt     uint32
+}
+
+func newSensorModel(cfg config.SimulatorSensorConfig, start time.Time) (*sensorModel, error) {
+	if cfg.ID == "" {
+		return nil, ErrEmptySensorID
+	}
+
+	scenario := Scenario(cfg.Scenario)
+	if scenario == "" {
+		scenario = ScenarioDrying
+	}
+
+	defaults, ok := scenarios[scenario]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScenario, cfg.Scenario)
+	}
+
+	m := &sensorModel{
+		id:           cfg.ID,
+		latitude:     cfg.Latitude,
+		longitude:    cfg.Longitude,
+		dryingRate:   orDefault(cfg.DryingRate, defaults.dryingRate),
+		batteryDrain: orDefault(cfg.BatteryDrain, defaults.batteryDrain),
+		rainInterval: orDefault(cfg.RainInterval, defaults.rainInterval),
+		rainAmount:   orDefault(cfg.RainAmount, defaults.rainAmount),
+		battery:      orDefault(cfg.Battery, defaults.battery),
+		lastRain:     start,
+	}
+
+	initial := orDefault(cfg.Centibar, defaults.centibar)
+	for i := range m.centibar {
+		m.centibar[i] = initial
+	}
+
+	return m, nil
+}
+
+func orDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
+// alive reports if the battery still powers the sensor
+func (m *sensorModel) alive() bool {
+	return m.battery > batteryEmpty
+}
+
+// advance moves the state of the sensor forward by dt ending at now. The soil dries faster
+// the drier it already is, a rain event lowers the tension depending on how much water reaches the probe.
+func (m *sensorModel) advance(now time.Time, dt time.Duration) {
+	days := dt.Hours() / 24
+	for i := range m.centibar {
+		c := m.centibar[i]
+		c += m.dryingRate * dryingFactor[i] * (1 + c/60) * days
+		m.centibar[i] = math.Min(c, maxCentibar)
+	}
+
+	if m.rainInterval > 0 && now.Sub(m.lastRain) >= m.rainInterval {
+		m.rain(m.rainAmount)
+		m.lastRain = now
+	}
+
+	m.battery = math.Max(m.battery-m.batteryDrain*days, 0)
+}
+
+// rain lowers the soil water tension by the amount of rain in mm, 25mm saturate the top soil
+func (m *sensorModel) rain(amount float64) {
+	for i := range m.centibar {
+		wetted := math.Min(1, amount/25*infiltration[i])
+		m.centibar[i] = math.Max(m.centibar[i]*(1-wetted), 0)
+	}
+}
+
+// read returns the current state of the sensor with measurement noise
+func (m *sensorModel) read(now time.Time, rng *rand.Rand) *Reading {
+	m.fCnt++
+
+	// daily temperature cycle with the maximum in the afternoon
+	cycle := math.Sin(2 * math.Pi * (float64(now.Hour()) + float64(now.Minute())/60 - 9) / 24)
+	temperature := 14 + 6*cycle + rng.NormFloat64()*0.3
+	humidity := clamp(70-15*cycle+rng.NormFloat64(), 0, 100)
+
+	r := &Reading{
+		DeviceID:    m.id,
+		Time:        now,
+		FCnt:        m.fCnt,
+		Battery:     round(m.battery+rng.NormFloat64()*0.005, 2),
+		Humidity:    round(humidity, 1),
+		Temperature: round(temperature, 1),
+		Latitude:    m.latitude,
+		Longitude:   m.longitude,
+	}
+
+	for i, depth := range depths {
+		centibar := int(math.Round(clamp(m.centibar[i]+rng.NormFloat64()*0.5, 0, maxCentibar)))
+		r.Watermarks[i] = WatermarkReading{
+			Depth:      depth,
+			Centibar:   centibar,
+			Resistance: resistance(centibar, temperature),
+		}
+	}
+
+	return r
+}
+
+// resistance converts the soil water tension into the resistance of a watermark probe in ohm.
+// It is the inverse of the calibration by Shock et al. (1998) that is used by the device firmware.
+func resistance(centibar int, temperature float64) int {
+	c := float64(centibar)
+	kOhm := (c*(1-0.01205*temperature) - 4.093) / (3.213 + 0.009733*c)
+	return int(math.Round(math.Max(kOhm, 0) * 1000))
+}
+
+func clamp(v, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(v, upper))
+}
+
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,187 @@
+package simulator
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStart = time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func TestNewSensorModel(t *testing.T) {
+	t.Run("should use defaults of the scenario", func(t *testing.T) {
+		// when
+		m, err := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Scenario: string(ScenarioRain)}, testStart)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, [3]float64{10, 10, 10}, m.centibar)
+		assert.Equal(t, 3.6, m.battery)
+		assert.Equal(t, 72*time.Hour, m.rainInterval)
+		assert.Equal(t, 20.0, m.rainAmount)
+	})
+
+	t.Run("should override defaults of the scenario", func(t *testing.T) {
+		// given
+		cfg := config.SimulatorSensorConfig{ID: "sensor-1", Scenario: string(ScenarioDeadBattery), Centibar: 40, Battery: 3.2, BatteryDrain: 0.1}
+
+		// when
+		m, err := newSensorModel(cfg, testStart)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, [3]float64{40, 40, 40}, m.centibar)
+		assert.Equal(t, 3.2, m.battery)
+		assert.Equal(t, 0.1, m.batteryDrain)
+		assert.Zero(t, m.rainInterval)
+	})
+
+	t.Run("should use drying scenario if none is set", func(t *testing.T) {
+		// when
+		m, err := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1"}, testStart)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 8.0, m.dryingRate)
+		assert.Zero(t, m.rainInterval)
+	})
+
+	t.Run("should return error on unknown scenario", func(t *testing.T) {
+		// when
+		m, err := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Scenario: "flood"}, testStart)
+
+		// then
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrUnknownScenario)
+	})
+
+	t.Run("should return error on empty id", func(t *testing.T) {
+		// when
+		m, err := newSensorModel(config.SimulatorSensorConfig{}, testStart)
+
+		// then
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrEmptySensorID)
+	})
+}
+
+func TestSensorModel_Advance(t *testing.T) {
+	t.Run("should dry the top soil faster than the deeper soil", func(t *testing.T) {
+		// given
+		m, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1"}, testStart)
+
+		// when
+		m.advance(testStart.Add(24*time.Hour), 24*time.Hour)
+
+		// then
+		assert.Greater(t, m.centibar[0], m.centibar[1])
+		assert.Greater(t, m.centibar[1], m.centibar[2])
+		assert.Greater(t, m.centibar[2], 10.0)
+	})
+
+	t.Run("should dry faster when the soil is already dry", func(t *testing.T) {
+		// given
+		wet, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Centibar: 10}, testStart)
+		dry, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-2", Centibar: 100}, testStart)
+
+		// when
+		wet.advance(testStart.Add(24*time.Hour), 24*time.Hour)
+		dry.advance(testStart.Add(24*time.Hour), 24*time.Hour)
+
+		// then
+		assert.Greater(t, dry.centibar[0]-100, wet.centibar[0]-10)
+	})
+
+	t.Run("should not exceed the maximum tension of the probe", func(t *testing.T) {
+		// given
+		m, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Centibar: 230}, testStart)
+
+		// when
+		m.advance(testStart.Add(240*time.Hour), 240*time.Hour)
+
+		// then
+		assert.Equal(t, float64(maxCentibar), m.centibar[0])
+	})
+
+	t.Run("should rewet the soil on rain events", func(t *testing.T) {
+		// given
+		cfg := config.SimulatorSensorConfig{ID: "sensor-1", Scenario: string(ScenarioRain), Centibar: 80, RainInterval: 48 * time.Hour}
+		m, _ := newSensorModel(cfg, testStart)
+		m.advance(testStart.Add(24*time.Hour), 24*time.Hour)
+		before := m.centibar
+
+		// when
+		m.advance(testStart.Add(48*time.Hour), 24*time.Hour)
+
+		// then
+		assert.Less(t, m.centibar[0], before[0]/2)
+		assert.Less(t, m.centibar[2], before[2])
+		assert.Equal(t, testStart.Add(48*time.Hour), m.lastRain)
+	})
+
+	t.Run("should drain the battery until the sensor is dead", func(t *testing.T) {
+		// given
+		m, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Scenario: string(ScenarioDeadBattery)}, testStart)
+
+		// when
+		m.advance(testStart.Add(5*24*time.Hour), 5*24*time.Hour)
+		aliveAfterFiveDays := m.alive()
+		m.advance(testStart.Add(10*24*time.Hour), 5*24*time.Hour)
+
+		// then
+		assert.True(t, aliveAfterFiveDays)
+		assert.False(t, m.alive())
+	})
+}
+
+func TestSensorModel_Read(t *testing.T) {
+	t.Run("should read watermarks of all depths and count frames", func(t *testing.T) {
+		// given
+		m, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1", Latitude: 54.79, Longitude: 9.44, Centibar: 50}, testStart)
+		rng := rand.New(rand.NewPCG(1, 1))
+
+		// when
+		first := m.read(testStart, rng)
+		second := m.read(testStart.Add(time.Hour), rng)
+
+		// then
+		assert.Equal(t, "sensor-1", first.DeviceID)
+		assert.Equal(t, uint32(1), first.FCnt)
+		assert.Equal(t, uint32(2), second.FCnt)
+		assert.Equal(t, 54.79, first.Latitude)
+		assert.Equal(t, 9.44, first.Longitude)
+		for i, w := range first.Watermarks {
+			assert.Equal(t, depths[i], w.Depth)
+			assert.InDelta(t, 50, w.Centibar, 3)
+			assert.Positive(t, w.Resistance)
+		}
+	})
+
+	t.Run("should be warmer in the afternoon than at night", func(t *testing.T) {
+		// given
+		m, _ := newSensorModel(config.SimulatorSensorConfig{ID: "sensor-1"}, testStart)
+		rng := rand.New(rand.NewPCG(1, 1))
+
+		// when
+		night := m.read(testStart.Add(3*time.Hour), rng)
+		afternoon := m.read(testStart.Add(15*time.Hour), rng)
+
+		// then
+		assert.Greater(t, afternoon.Temperature, night.Temperature)
+		assert.Less(t, afternoon.Humidity, night.Humidity)
+	})
+}
+
+func TestResistance(t *testing.T) {
+	t.Run("should increase with the soil water tension", func(t *testing.T) {
+		assert.Less(t, resistance(10, 20), resistance(50, 20))
+		assert.Less(t, resistance(50, 20), resistance(150, 20))
+	})
+
+	t.Run("should not be negative for saturated soil", func(t *testing.T) {
+		assert.Zero(t, resistance(0, 20))
+	})
+}
@@ -0,0 +1,104 @@
+package simulator
+
+import (
+	"context"
+	"strings"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	// DefaultTopic is the uplink topic of a TTN application, {device} is replaced with the sensor id
+	DefaultTopic                = "v3/green-ecolution@ttn/devices/{device}/up"
+	deviceTopicPlaceholder      = "{device}"
+	publishQoS             byte = 1
+	disconnectQuiesce           = 250 // milliseconds
+)
+
+const (
+	// TargetMQTT publishes the uplinks to a mqtt broker
+	TargetMQTT = "mqtt"
+	// TargetService passes the uplinks directly to the sensor service
+	TargetService = "service"
+)
+
+func deviceTopic(topic, deviceID string) string {
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	return strings.ReplaceAll(topic, deviceTopicPlaceholder, deviceID)
+}
+
+// MQTTPublisher publishes uplinks to the device topic of a mqtt broker
+type MQTTPublisher struct {
+	client MQTT.Client
+	topic  string
+}
+
+func NewMQTTPublisher(client MQTT.Client, topic string) *MQTTPublisher {
+	return &MQTTPublisher{
+		client: client,
+		topic:  topic,
+	}
+}
+
+// ConnectMQTT connects to the broker of the simulator config and returns a publisher for it.
+// The returned function disconnects from the broker.
+func ConnectMQTT(ctx context.Context, cfg *config.SimulatorConfig) (*MQTTPublisher, func(), error) {
+	opts := MQTT.NewClientOptions()
+	opts.AddBroker(cfg.Broker)
+	opts.SetClientID(cfg.ClientID)
+	opts.SetUsername(cfg.Username)
+	opts.SetPassword(cfg.Password)
+	opts.SetAutoReconnect(true)
+
+	client := MQTT.NewClient(opts)
+	token := client.Connect()
+	select {
+	case <-token.Done():
+		if token.Error() != nil {
+			return nil, nil, token.Error()
+		}
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	return NewMQTTPublisher(client, cfg.Topic), func() { client.Disconnect(disconnectQuiesce) }, nil
+}
+
+func (p *MQTTPublisher) Publish(ctx context.Context, deviceID string, payload []byte) error {
+	token := p.client.Publish(deviceTopic(p.topic, deviceID), publishQoS, false, payload)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ServicePublisher decodes uplinks with the ttn decoder and passes them directly to the sensor service,
+// the same way the mqtt subscriber does.
+type ServicePublisher struct {
+	sensorSvc service.SensorService
+	decoder   service.SensorPayloadDecoder
+}
+
+func NewServicePublisher(sensorSvc service.SensorService, decoder service.SensorPayloadDecoder) *ServicePublisher {
+	return &ServicePublisher{
+		sensorSvc: sensorSvc,
+		decoder:   decoder,
+	}
+}
+
+func (p *ServicePublisher) Publish(ctx context.Context, _ string, payload []byte) error {
+	decoded, err := p.decoder.DecodeSensorPayload(decoder.TTNDecoderName, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.sensorSvc.HandleMessage(ctx, decoded)
+	return err
+}
@@ -0,0 +1,76 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceTopic(t *testing.T) {
+	t.Run("should replace device placeholder", func(t *testing.T) {
+		assert.Equal(t, "application/1/device/sensor-1/event/up", deviceTopic("application/1/device/{device}/event/up", "sensor-1"))
+	})
+
+	t.Run("should use default topic if none is set", func(t *testing.T) {
+		assert.Equal(t, "v3/green-ecolution@ttn/devices/sensor-1/up", deviceTopic("", "sensor-1"))
+	})
+}
+
+func TestServicePublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"end_device_ids":{"device_id":"sensor-1"}}`)
+	decoded := &entities.MqttPayload{Device: "sensor-1", Battery: 3.4}
+
+	t.Run("should decode uplink with ttn decoder and pass it to sensor service", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		dec := serviceMock.NewMockSensorPayloadDecoder(t)
+		pub := NewServicePublisher(sensorSvc, dec)
+
+		dec.EXPECT().DecodeSensorPayload(decoder.TTNDecoderName, payload).Return(decoded, nil)
+		sensorSvc.EXPECT().HandleMessage(ctx, decoded).Return(&entities.SensorData{SensorID: "sensor-1"}, nil)
+
+		// when
+		err := pub.Publish(ctx, "sensor-1", payload)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when decoding fails", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		dec := serviceMock.NewMockSensorPayloadDecoder(t)
+		pub := NewServicePublisher(sensorSvc, dec)
+
+		dec.EXPECT().DecodeSensorPayload(decoder.TTNDecoderName, payload).Return(nil, decoder.ErrInvalidPayload)
+
+		// when
+		err := pub.Publish(ctx, "sensor-1", payload)
+
+		// then
+		assert.ErrorIs(t, err, decoder.ErrInvalidPayload)
+		sensorSvc.AssertNotCalled(t, "HandleMessage")
+	})
+
+	t.Run("should return error of sensor service", func(t *testing.T) {
+		// given
+		sensorSvc := serviceMock.NewMockSensorService(t)
+		dec := serviceMock.NewMockSensorPayloadDecoder(t)
+		pub := NewServicePublisher(sensorSvc, dec)
+
+		dec.EXPECT().DecodeSensorPayload(decoder.TTNDecoderName, payload).Return(decoded, nil)
+		sensorSvc.EXPECT().HandleMessage(ctx, decoded).Return(nil, errors.New("database unavailable"))
+
+		// when
+		err := pub.Publish(ctx, "sensor-1", payload)
+
+		// then
+		assert.EqualError(t, err, "database unavailable")
+	})
+}
@@ -0,0 +1,168 @@
+// Package simulator generates uplinks of watermark sensors without real LoRaWAN devices.
+//
+// Every configured sensor follows a scenario: the soil dries continuously, regular rain events rewet it
+// or the battery drains until the sensor stops sending. The uplinks are encoded like messages of
+// The Things Network and handed to a Publisher, which sends them to a mqtt broker, passes them directly
+// to the sensor service or delivers them to an in-memory broker in tests.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+)
+
+const (
+	defaultInterval = 15 * time.Minute
+	defaultSpeed    = 1
+)
+
+var (
+	ErrNoSensors        = errors.New("simulator has no sensors")
+	ErrEmptySensorID    = errors.New("simulated sensor has no id")
+	ErrDuplicateSensor  = errors.New("simulated sensor id is not unique")
+	ErrUnknownScenario  = errors.New("unknown simulator scenario")
+	ErrNegativeInterval = errors.New("simulator interval must not be negative")
+)
+
+// Publisher delivers the encoded uplink of a sensor
+type Publisher interface {
+	Publish(ctx context.Context, deviceID string, payload []byte) error
+}
+
+// Simulator advances the simulated sensors in steps of the configured interval and publishes their uplinks.
+// It is not safe for concurrent use.
+type Simulator struct {
+	sensors  []*sensorModel
+	pub      Publisher
+	interval time.Duration
+	speed    float64
+	now      time.Time
+	end      time.Time
+	rng      *rand.Rand
+}
+
+// NewSimulator creates a simulator whose simulated clock starts at start. The same seed and start generate the same uplinks.
+func NewSimulator(cfg *config.SimulatorConfig, pub Publisher, start time.Time) (*Simulator, error) {
+	if len(cfg.Sensors) == 0 {
+		return nil, ErrNoSensors
+	}
+
+	if cfg.Interval < 0 {
+		return nil, ErrNegativeInterval
+	}
+
+	ids := make(map[string]bool, len(cfg.Sensors))
+	sensors := make([]*sensorModel, 0, len(cfg.Sensors))
+	for _, sensorCfg := range cfg.Sensors {
+		if ids[sensorCfg.ID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSensor, sensorCfg.ID)
+		}
+		ids[sensorCfg.ID] = true
+
+		m, err := newSensorModel(sensorCfg, start)
+		if err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, m)
+	}
+
+	s := &Simulator{
+		sensors:  sensors,
+		pub:      pub,
+		interval: orDefault(cfg.Interval, defaultInterval),
+		speed:    defaultSpeed,
+		now:      start,
+		rng:      rand.New(rand.NewPCG(cfg.Seed, cfg.Seed)),
+	}
+
+	if cfg.Speed > 0 {
+		s.speed = cfg.Speed
+	}
+
+	if cfg.Duration > 0 {
+		s.end = start.Add(cfg.Duration)
+	}
+
+	return s, nil
+}
+
+// Now returns the current simulated time
+func (s *Simulator) Now() time.Time {
+	return s.now
+}
+
+// Done reports if the configured duration has been simulated
+func (s *Simulator) Done() bool {
+	return !s.end.IsZero() && !s.now.Before(s.end)
+}
+
+// Step advances the simulated time by one interval and publishes an uplink of every sensor that is still alive.
+// A failed publish does not stop the other sensors, all errors are returned joined. It returns the number of published uplinks.
+func (s *Simulator) Step(ctx context.Context) (int, error) {
+	s.now = s.now.Add(s.interval)
+
+	var errs []error
+	published := 0
+	for _, m := range s.sensors {
+		if !m.alive() {
+			continue
+		}
+
+		m.advance(s.now, s.interval)
+		if !m.alive() {
+			slog.Info("simulated sensor ran out of battery", "sensor_id", m.id, "time", s.now)
+			continue
+		}
+
+		// uplinks get lost when the battery can not power the radio anymore
+		if m.battery < batteryBrownout && s.rng.Float64() < brownoutLossRate {
+			slog.Debug("simulated sensor lost uplink during brownout", "sensor_id", m.id, "battery", m.battery)
+			continue
+		}
+
+		payload, err := EncodeTTNUplink(m.read(s.now, s.rng))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := s.pub.Publish(ctx, m.id, payload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish uplink of sensor %s: %w", m.id, err))
+			continue
+		}
+		published++
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// Run publishes a step every interval divided by the speed. It blocks until the context is canceled
+// or the configured duration has been simulated.
+func (s *Simulator) Run(ctx context.Context) {
+	tick := time.Duration(float64(s.interval) / s.speed)
+	slog.Info("starting sensor simulator", "sensors", len(s.sensors), "interval", s.interval, "speed", s.speed, "tick", tick)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for !s.Done() {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sensor simulator")
+			return
+		case <-ticker.C:
+			published, err := s.Step(ctx)
+			if err != nil {
+				slog.Error("error while publishing simulated uplinks", "error", err)
+			}
+			slog.Debug("published simulated uplinks", "count", published, "time", s.now)
+		}
+	}
+
+	slog.Info("sensor simulator finished", "time", s.now)
+}
@@ -0,0 +1,189 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, string, []byte) error {
+	return errors.New("broker unavailable")
+}
+
+func TestNewSimulator(t *testing.T) {
+	t.Run("should use default interval and speed", func(t *testing.T) {
+		// given
+		cfg := &config.SimulatorConfig{Sensors: []config.SimulatorSensorConfig{{ID: "sensor-1"}}}
+
+		// when
+		sim, err := NewSimulator(cfg, NewMemoryBroker(""), testStart)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, defaultInterval, sim.interval)
+		assert.Equal(t, float64(defaultSpeed), sim.speed)
+		assert.Equal(t, testStart, sim.Now())
+		assert.False(t, sim.Done())
+	})
+
+	t.Run("should return error on invalid config", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			cfg     *config.SimulatorConfig
+			wantErr error
+		}{
+			{name: "no sensors", cfg: &config.SimulatorConfig{}, wantErr: ErrNoSensors},
+			{name: "negative interval", cfg: &config.SimulatorConfig{Interval: -time.Minute, Sensors: []config.SimulatorSensorConfig{{ID: "sensor-1"}}}, wantErr: ErrNegativeInterval},
+			{name: "duplicate sensor", cfg: &config.SimulatorConfig{Sensors: []config.SimulatorSensorConfig{{ID: "sensor-1"}, {ID: "sensor-1"}}}, wantErr: ErrDuplicateSensor},
+			{name: "unknown scenario", cfg: &config.SimulatorConfig{Sensors: []config.SimulatorSensorConfig{{ID: "sensor-1", Scenario: "flood"}}}, wantErr: ErrUnknownScenario},
+			{name: "empty sensor id", cfg: &config.SimulatorConfig{Sensors: []config.SimulatorSensorConfig{{}}}, wantErr: ErrEmptySensorID},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// when
+				sim, err := NewSimulator(tt.cfg, NewMemoryBroker(""), testStart)
+
+				// then
+				assert.Nil(t, sim)
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+}
+
+func TestSimulator_Step(t *testing.T) {
+	cfg := &config.SimulatorConfig{
+		Interval: time.Hour,
+		Duration: 3 * time.Hour,
+		Seed:     42,
+		Sensors: []config.SimulatorSensorConfig{
+			{ID: "sensor-1", Scenario: string(ScenarioDrying)},
+			{ID: "sensor-2", Scenario: string(ScenarioRain)},
+		},
+	}
+
+	t.Run("should publish an uplink of every sensor per step", func(t *testing.T) {
+		// given
+		broker := NewMemoryBroker("v3/app/devices/{device}/up")
+		sim, _ := NewSimulator(cfg, broker, testStart)
+
+		// when
+		published, err := sim.Step(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, testStart.Add(time.Hour), sim.Now())
+		messages := broker.Messages()
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "v3/app/devices/sensor-1/up", messages[0].Topic())
+		assert.Equal(t, "v3/app/devices/sensor-2/up", messages[1].Topic())
+	})
+
+	t.Run("should be done after the configured duration", func(t *testing.T) {
+		// given
+		sim, _ := NewSimulator(cfg, NewMemoryBroker(""), testStart)
+
+		// when
+		for range 3 {
+			_, _ = sim.Step(context.Background())
+		}
+
+		// then
+		assert.True(t, sim.Done())
+	})
+
+	t.Run("should generate the same uplinks with the same seed", func(t *testing.T) {
+		// given
+		first := NewMemoryBroker("")
+		second := NewMemoryBroker("")
+		sim1, _ := NewSimulator(cfg, first, testStart)
+		sim2, _ := NewSimulator(cfg, second, testStart)
+
+		// when
+		_, _ = sim1.Step(context.Background())
+		_, _ = sim2.Step(context.Background())
+
+		// then
+		assert.Equal(t, first.Messages()[0].Payload(), second.Messages()[0].Payload())
+	})
+
+	t.Run("should stop publishing when the battery is empty", func(t *testing.T) {
+		// given
+		deadCfg := &config.SimulatorConfig{
+			Interval: 24 * time.Hour,
+			Sensors:  []config.SimulatorSensorConfig{{ID: "sensor-1", Scenario: string(ScenarioDeadBattery), Battery: 3.15}},
+		}
+		broker := NewMemoryBroker("")
+		sim, _ := NewSimulator(deadCfg, broker, testStart)
+
+		// when
+		for range 10 {
+			_, _ = sim.Step(context.Background())
+		}
+
+		// then
+		assert.NotEmpty(t, broker.Messages())
+		assert.LessOrEqual(t, len(broker.Messages()), 3)
+		published, err := sim.Step(context.Background())
+		assert.NoError(t, err)
+		assert.Zero(t, published)
+	})
+
+	t.Run("should return errors of all failed sensors", func(t *testing.T) {
+		// given
+		sim, _ := NewSimulator(cfg, failingPublisher{}, testStart)
+
+		// when
+		published, err := sim.Step(context.Background())
+
+		// then
+		assert.Zero(t, published)
+		assert.ErrorContains(t, err, "failed to publish uplink of sensor sensor-1: broker unavailable")
+		assert.ErrorContains(t, err, "failed to publish uplink of sensor sensor-2: broker unavailable")
+	})
+}
+
+func TestSimulator_Run(t *testing.T) {
+	t.Run("should return after the configured duration", func(t *testing.T) {
+		// given
+		cfg := &config.SimulatorConfig{
+			Interval: time.Hour,
+			Duration: 5 * time.Hour,
+			Speed:    float64(time.Hour / time.Millisecond),
+			Sensors:  []config.SimulatorSensorConfig{{ID: "sensor-1"}},
+		}
+		broker := NewMemoryBroker("")
+		sim, _ := NewSimulator(cfg, broker, testStart)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// when
+		sim.Run(ctx)
+
+		// then
+		assert.NoError(t, ctx.Err())
+		assert.Len(t, broker.Messages(), 5)
+	})
+
+	t.Run("should return when the context is canceled", func(t *testing.T) {
+		// given
+		cfg := &config.SimulatorConfig{Sensors: []config.SimulatorSensorConfig{{ID: "sensor-1"}}}
+		sim, _ := NewSimulator(cfg, NewMemoryBroker(""), testStart)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		sim.Run(ctx)
+
+		// then
+		assert.Equal(t, testStart, sim.Now())
+	})
+}
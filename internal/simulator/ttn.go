@@ -0,0 +1,76 @@
+package simulator
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ttnApplicationID = "green-ecolution-simulator"
+	ttnFPort         = 1
+)
+
+type ttnUplink struct {
+	EndDeviceIDs  ttnEndDeviceIDs  `json:"end_device_ids"`
+	ReceivedAt    time.Time        `json:"received_at"`
+	UplinkMessage ttnUplinkMessage `json:"uplink_message"`
+}
+
+type ttnEndDeviceIDs struct {
+	DeviceID       string `json:"device_id"`
+	ApplicationIDs struct {
+		ApplicationID string `json:"application_id"`
+	} `json:"application_ids"`
+}
+
+type ttnUplinkMessage struct {
+	FPort          uint8             `json:"f_port"`
+	FCnt           uint32            `json:"f_cnt"`
+	DecodedPayload ttnDecodedPayload `json:"decoded_payload"`
+	ReceivedAt     time.Time         `json:"received_at"`
+}
+
+// ttnDecodedPayload matches the output of the device codec of our watermark sensors
+type ttnDecodedPayload struct {
+	Battery                  float64 `json:"battery"`
+	Humidity                 float64 `json:"humidity"`
+	Temperature              float64 `json:"temperature"`
+	Latitude                 float64 `json:"latitude"`
+	Longitude                float64 `json:"longitude"`
+	WatermarkOneResistance   int     `json:"watermarkOneResistanceValue"`
+	WatermarkOneCentibar     int     `json:"watermarkOneCentibarValue"`
+	WatermarkTwoResistance   int     `json:"watermarkTwoResistanceValue"`
+	WatermarkTwoCentibar     int     `json:"watermarkTwoCentibarValue"`
+	WatermarkThreeResistance int     `json:"watermarkThreeResistanceValue"`
+	WatermarkThreeCentibar   int     `json:"watermarkThreeCentibarValue"`
+}
+
+// EncodeTTNUplink encodes the reading as uplink message of The Things Network (TTS v3), as it is published
+// to the v3/{application}/devices/{device}/up topic.
+func EncodeTTNUplink(r *Reading) ([]byte, error) {
+	uplink := ttnUplink{
+		EndDeviceIDs: ttnEndDeviceIDs{DeviceID: r.DeviceID},
+		ReceivedAt:   r.Time.UTC(),
+		UplinkMessage: ttnUplinkMessage{
+			FPort: ttnFPort,
+			FCnt:  r.FCnt,
+			DecodedPayload: ttnDecodedPayload{
+				Battery:                  r.Battery,
+				Humidity:                 r.Humidity,
+				Temperature:              r.Temperature,
+				Latitude:                 r.Latitude,
+				Longitude:                r.Longitude,
+				WatermarkOneResistance:   r.Watermarks[0].Resistance,
+				WatermarkOneCentibar:     r.Watermarks[0].Centibar,
+				WatermarkTwoResistance:   r.Watermarks[1].Resistance,
+				WatermarkTwoCentibar:     r.Watermarks[1].Centibar,
+				WatermarkThreeResistance: r.Watermarks[2].Resistance,
+				WatermarkThreeCentibar:   r.Watermarks[2].Centibar,
+			},
+			ReceivedAt: r.Time.UTC(),
+		},
+	}
+	uplink.EndDeviceIDs.ApplicationIDs.ApplicationID = ttnApplicationID
+
+	return json.Marshal(uplink)
+}
@@ -0,0 +1,62 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/entities/sensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeTTNUplink(t *testing.T) {
+	reading := &Reading{
+		DeviceID:    "sensor-1",
+		Time:        time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		FCnt:        42,
+		Battery:     3.45,
+		Humidity:    61.5,
+		Temperature: 19.8,
+		Latitude:    54.79,
+		Longitude:   9.44,
+		Watermarks: [3]WatermarkReading{
+			{Depth: 30, Centibar: 25, Resistance: 6100},
+			{Depth: 60, Centibar: 18, Resistance: 4300},
+			{Depth: 90, Centibar: 12, Resistance: 2500},
+		},
+	}
+
+	t.Run("should encode uplink that is accepted by the ttn decoder", func(t *testing.T) {
+		// when
+		payload, err := EncodeTTNUplink(reading)
+		assert.NoError(t, err)
+		got, err := decoder.NewTTNDecoder().Decode(payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &sensor.MqttPayloadResponse{
+			Device:      "sensor-1",
+			Battery:     3.45,
+			Humidity:    61.5,
+			Temperature: 19.8,
+			Latitude:    54.79,
+			Longitude:   9.44,
+			Watermarks: []sensor.WatermarkResponse{
+				{Resistance: 6100, Centibar: 25, Depth: 30},
+				{Resistance: 4300, Centibar: 18, Depth: 60},
+				{Resistance: 2500, Centibar: 12, Depth: 90},
+			},
+		}, got)
+	})
+
+	t.Run("should encode envelope of the things stack", func(t *testing.T) {
+		// when
+		payload, err := EncodeTTNUplink(reading)
+
+		// then
+		assert.NoError(t, err)
+		assert.Contains(t, string(payload), `"end_device_ids":{"device_id":"sensor-1","application_ids":{"application_id":"green-ecolution-simulator"}}`)
+		assert.Contains(t, string(payload), `"received_at":"2024-07-01T12:00:00Z"`)
+		assert.Contains(t, string(payload), `"f_port":1,"f_cnt":42`)
+	})
+}
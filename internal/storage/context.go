@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// WithWateringStatusCause returns a context in which the repositories record changes of the watering status with the given cause
+func WithWateringStatusCause(ctx context.Context, cause entities.WateringStatusCause) context.Context {
+	return context.WithValue(ctx, enums.ContextKeyWateringStatusCause, cause)
+}
+
+// WateringStatusCauseFromContext returns the cause of watering status changes and false if the context has no cause
+func WateringStatusCauseFromContext(ctx context.Context) (entities.WateringStatusCause, bool) {
+	cause, ok := ctx.Value(enums.ContextKeyWateringStatusCause).(entities.WateringStatusCause)
+	return cause, ok
+}
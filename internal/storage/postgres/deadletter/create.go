@@ -0,0 +1,95 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func defaultDeadLetter() *entities.DeadLetter {
+	return &entities.DeadLetter{
+		Topic:       "",
+		Decoder:     "",
+		Payload:     []byte{},
+		Reason:      entities.DeadLetterReasonProcess,
+		Error:       "",
+		Status:      entities.DeadLetterStatusPending,
+		ReplayCount: 0,
+	}
+}
+
+func (r *DeadLetterRepository) Create(ctx context.Context, createFn func(*entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdDl *entities.DeadLetter
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity := defaultDeadLetter()
+		created, err := createFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := r.validateDeadLetter(entity); err != nil {
+			return err
+		}
+
+		id, err := r.store.CreateSensorDeadLetter(ctx, &sqlc.CreateSensorDeadLetterParams{
+			Topic:   entity.Topic,
+			Decoder: entity.Decoder,
+			Payload: entity.Payload,
+			Reason:  sqlc.DeadLetterReason(entity.Reason),
+			Error:   entity.Error,
+			Status:  sqlc.DeadLetterStatus(entity.Status),
+		})
+		if err != nil {
+			return err
+		}
+
+		createdDl, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create dead letter entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdDl != nil {
+		log.Debug("dead letter entity created successfully in db", "dead_letter_id", createdDl.ID)
+	}
+
+	return createdDl, nil
+}
+
+func (r *DeadLetterRepository) validateDeadLetter(entity *entities.DeadLetter) error {
+	if entity.Decoder == "" {
+		return errors.New("decoder is required")
+	}
+
+	if entity.Payload == nil {
+		return errors.New("payload is required")
+	}
+
+	if entity.Error == "" {
+		return errors.New("error message is required")
+	}
+
+	return nil
+}
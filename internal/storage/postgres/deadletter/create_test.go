@@ -0,0 +1,102 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterRepository_Create(t *testing.T) {
+	t.Run("should create dead letter", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+		payload := []byte{0x01, 0x02, 0xff}
+
+		// when
+		got, err := r.Create(context.Background(), func(dl *entities.DeadLetter) (bool, error) {
+			dl.Topic = "v3/green-ecolution/devices/sensor-1/up"
+			dl.Decoder = "ttn"
+			dl.Payload = payload
+			dl.Reason = entities.DeadLetterReasonDecode
+			dl.Error = "invalid mqtt payload"
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-1/up", got.Topic)
+		assert.Equal(t, "ttn", got.Decoder)
+		assert.Equal(t, payload, got.Payload)
+		assert.Equal(t, entities.DeadLetterReasonDecode, got.Reason)
+		assert.Equal(t, "invalid mqtt payload", got.Error)
+		assert.Equal(t, entities.DeadLetterStatusPending, got.Status)
+		assert.Equal(t, int32(0), got.ReplayCount)
+		assert.NotZero(t, got.CreatedAt)
+		assert.Nil(t, got.LastReplayedAt)
+	})
+
+	t.Run("should not create dead letter when createFn returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(dl *entities.DeadLetter) (bool, error) {
+			return false, nil
+		})
+		all, _ := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Empty(t, all)
+	})
+
+	t.Run("should return error when decoder is empty", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(dl *entities.DeadLetter) (bool, error) {
+			dl.Payload = []byte("{}")
+			dl.Error = "failed"
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when createFn returns error", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(dl *entities.DeadLetter) (bool, error) {
+			return true, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when createFn is nil", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Create(context.Background(), nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,45 @@
+package deadletter
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type DeadLetterRepository struct {
+	store *store.Store
+	DeadLetterRepositoryMappers
+}
+
+type DeadLetterRepositoryMappers struct {
+	mapper mapper.InternalDeadLetterRepoMapper
+}
+
+func NewDeadLetterRepositoryMappers(dlMapper mapper.InternalDeadLetterRepoMapper) DeadLetterRepositoryMappers {
+	return DeadLetterRepositoryMappers{
+		mapper: dlMapper,
+	}
+}
+
+func NewDeadLetterRepository(s *store.Store, mappers DeadLetterRepositoryMappers) storage.DeadLetterRepository {
+	return &DeadLetterRepository{
+		store:                       s,
+		DeadLetterRepositoryMappers: mappers,
+	}
+}
+
+func (r *DeadLetterRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteSensorDeadLetter(ctx, id)
+	if err != nil {
+		log.Error("failed to delete dead letter entity in db", "error", err, "dead_letter_id", id)
+		return err
+	}
+
+	log.Debug("dead letter entity deleted successfully in db", "dead_letter_id", id)
+	return nil
+}
@@ -0,0 +1,70 @@
+package deadletter
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultDeadLetterMappers() DeadLetterRepositoryMappers {
+	return NewDeadLetterRepositoryMappers(&generated.InternalDeadLetterRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestDeadLetterRepository_Delete(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+
+	t.Run("should delete dead letter", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		err := r.Delete(context.Background(), 1)
+		got, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Error(t, getErr)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		err := r.Delete(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		err := r.Delete(ctx, 2)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,42 @@
+package deadletter
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *DeadLetterRepository) GetAll(ctx context.Context) ([]*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorDeadLetters(ctx)
+	if err != nil {
+		log.Debug("failed to get dead letter entities in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.SensorDeadLetter{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *DeadLetterRepository) GetAllByStatus(ctx context.Context, status entities.DeadLetterStatus) ([]*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorDeadLettersByStatus(ctx, sqlc.DeadLetterStatus(status))
+	if err != nil {
+		log.Debug("failed to get dead letter entities by status in db", "error", err, "dead_letter_status", status)
+		return nil, r.store.MapError(err, sqlc.SensorDeadLetter{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id int32) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetSensorDeadLetterByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get dead letter entity by provided id", "error", err, "dead_letter_id", id)
+		return nil, r.store.MapError(err, sqlc.SensorDeadLetter{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
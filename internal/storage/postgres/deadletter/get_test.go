@@ -0,0 +1,118 @@
+package deadletter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterRepository_GetAll(t *testing.T) {
+	t.Run("should return all dead letters newest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, int32(3), got[0].ID)
+		assert.Equal(t, int32(1), got[2].ID)
+		assert.Equal(t, "ttn", got[2].Decoder)
+		assert.Equal(t, entities.DeadLetterReasonDecode, got[2].Reason)
+		assert.Equal(t, `{"end_device_ids": {"device_id": "sensor-1"}}`, string(got[2].Payload))
+		assert.NotZero(t, got[2].CreatedAt)
+		assert.Nil(t, got[2].LastReplayedAt)
+	})
+
+	t.Run("should return empty slice when db is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterRepository_GetAllByStatus(t *testing.T) {
+	t.Run("should return dead letters by status", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		pending, err := r.GetAllByStatus(context.Background(), entities.DeadLetterStatusPending)
+		replayed, errReplayed := r.GetAllByStatus(context.Background(), entities.DeadLetterStatusReplayed)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errReplayed)
+		assert.Len(t, pending, 2)
+		assert.Len(t, replayed, 1)
+		for _, dl := range pending {
+			assert.Equal(t, entities.DeadLetterStatusPending, dl.Status)
+		}
+		assert.Equal(t, int32(3), replayed[0].ID)
+	})
+}
+
+func TestDeadLetterRepository_GetByID(t *testing.T) {
+	t.Run("should return dead letter by id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.Equal(t, "v3/green-ecolution/devices/sensor-2/up", got.Topic)
+		assert.Equal(t, "json", got.Decoder)
+		assert.Equal(t, entities.DeadLetterReasonProcess, got.Reason)
+		assert.Equal(t, "validation failed for latitude", got.Error)
+		assert.Equal(t, int32(1), got.ReplayCount)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,90 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *DeadLetterRepository) Update(ctx context.Context, id int32, updateFn func(*entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	if updateFn == nil {
+		return nil, errors.New("updateFn is nil")
+	}
+
+	var updatedDl *entities.DeadLetter
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err := updateFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			updatedDl = entity
+			return nil
+		}
+
+		if err := r.validateDeadLetter(entity); err != nil {
+			return err
+		}
+
+		if err := r.store.UpdateSensorDeadLetter(ctx, &sqlc.UpdateSensorDeadLetterParams{
+			ID:             entity.ID,
+			Error:          entity.Error,
+			Status:         sqlc.DeadLetterStatus(entity.Status),
+			ReplayCount:    entity.ReplayCount,
+			LastReplayedAt: utils.TimeToPgTimestamp(entity.LastReplayedAt),
+		}); err != nil {
+			log.Error("failed to update dead letter entity in db", "error", err, "dead_letter_id", id)
+			return err
+		}
+
+		updatedDl, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("dead letter entity updated successfully in db", "dead_letter_id", id)
+	return updatedDl, nil
+}
+
+func (r *DeadLetterRepository) Claim(ctx context.Context, id int32) (*entities.DeadLetter, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.ClaimSensorDeadLetter(ctx, id)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error("failed to claim dead letter entity in db", "error", err, "dead_letter_id", id)
+			return nil, err
+		}
+
+		// no row was claimed, either the dead letter does not exist or it is not pending
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, storage.ErrDeadLetterNotPending
+	}
+
+	log.Debug("dead letter entity claimed for replay in db", "dead_letter_id", id)
+	return r.mapper.FromSql(row), nil
+}
@@ -0,0 +1,182 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterRepository_Update(t *testing.T) {
+	t.Run("should update replay information of dead letter", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+		replayedAt := time.Date(2025, 1, 21, 12, 0, 0, 0, time.UTC)
+
+		// when
+		got, err := r.Update(context.Background(), 1, func(dl *entities.DeadLetter) (bool, error) {
+			dl.Status = entities.DeadLetterStatusReplayed
+			dl.ReplayCount++
+			dl.LastReplayedAt = &replayedAt
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, entities.DeadLetterStatusReplayed, got.Status)
+		assert.Equal(t, int32(1), got.ReplayCount)
+		assert.NotNil(t, got.LastReplayedAt)
+		assert.True(t, replayedAt.Equal(*got.LastReplayedAt))
+		assert.Equal(t, "ttn", got.Decoder)
+	})
+
+	t.Run("should update error message of dead letter", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 2, func(dl *entities.DeadLetter) (bool, error) {
+			dl.Error = "sensor not found"
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor not found", got.Error)
+		assert.Equal(t, entities.DeadLetterStatusPending, got.Status)
+	})
+
+	t.Run("should not update dead letter when updateFn returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 2, func(dl *entities.DeadLetter) (bool, error) {
+			dl.Error = "should not be saved"
+			return false, nil
+		})
+		stored, _ := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, "validation failed for latitude", stored.Error)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 99, func(dl *entities.DeadLetter) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when updateFn returns error", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, func(dl *entities.DeadLetter) (bool, error) {
+			return true, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when updateFn is nil", func(t *testing.T) {
+		// given
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterRepository_Claim(t *testing.T) {
+	t.Run("should mark pending dead letter as replaying", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Claim(context.Background(), 1)
+		stored, _ := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.DeadLetterStatusReplaying, got.Status)
+		assert.Equal(t, "ttn", got.Decoder)
+		assert.Equal(t, entities.DeadLetterStatusReplaying, stored.Status)
+	})
+
+	t.Run("should claim dead letter only once", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+		_, err := r.Claim(context.Background(), 1)
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.Claim(context.Background(), 1)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrDeadLetterNotPending)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when dead letter was already replayed", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/deadletter")
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Claim(context.Background(), 3)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrDeadLetterNotPending)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when dead letter not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewDeadLetterRepository(suite.Store, defaultDeadLetterMappers())
+
+		// when
+		got, err := r.Claim(context.Background(), 99)
+
+		// then
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, err, &notFound)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapDeadLetterReason MapDeadLetterStatus
+type InternalDeadLetterRepoMapper interface {
+	FromSql(src *sqlc.SensorDeadLetter) *entities.DeadLetter
+	FromSqlList(src []*sqlc.SensorDeadLetter) []*entities.DeadLetter
+}
+
+func MapDeadLetterReason(reason sqlc.DeadLetterReason) entities.DeadLetterReason {
+	return entities.DeadLetterReason(reason)
+}
+
+func MapDeadLetterStatus(status sqlc.DeadLetterStatus) entities.DeadLetterStatus {
+	return entities.DeadLetterStatus(status)
+}
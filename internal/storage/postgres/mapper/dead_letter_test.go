@@ -0,0 +1,132 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterMapper_FromSql(t *testing.T) {
+	dlMapper := &generated.InternalDeadLetterRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestDeadLetters[1]
+
+		// when
+		got := dlMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.Topic, got.Topic)
+		assert.Equal(t, src.Decoder, got.Decoder)
+		assert.Equal(t, src.Payload, got.Payload)
+		assert.Equal(t, src.Error, got.Error)
+		assert.Equal(t, src.ReplayCount, got.ReplayCount)
+		assert.Equal(t, src.Reason, sqlc.DeadLetterReason(got.Reason))
+		assert.Equal(t, src.Status, sqlc.DeadLetterStatus(got.Status))
+		assert.NotNil(t, got.LastReplayedAt)
+		assert.Equal(t, src.LastReplayedAt.Time, *got.LastReplayedAt)
+	})
+
+	t.Run("should map invalid last replayed timestamp to nil", func(t *testing.T) {
+		// given
+		src := allTestDeadLetters[0]
+
+		// when
+		got := dlMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Nil(t, got.LastReplayedAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.SensorDeadLetter = nil
+
+		// when
+		got := dlMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestDeadLetterMapper_FromSqlList(t *testing.T) {
+	dlMapper := &generated.InternalDeadLetterRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestDeadLetters
+
+		// when
+		got := dlMapper.FromSqlList(src)
+
+		// then
+		assert.Len(t, got, len(src))
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.Payload, got[i].Payload)
+			assert.Equal(t, src.Status, sqlc.DeadLetterStatus(got[i].Status))
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src []*sqlc.SensorDeadLetter = nil
+
+		// when
+		got := dlMapper.FromSqlList(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestMapDeadLetterReason(t *testing.T) {
+	assert.Equal(t, entities.DeadLetterReasonDecode, mapper.MapDeadLetterReason(sqlc.DeadLetterReasonDecode))
+	assert.Equal(t, entities.DeadLetterReasonProcess, mapper.MapDeadLetterReason(sqlc.DeadLetterReasonProcess))
+}
+
+func TestMapDeadLetterStatus(t *testing.T) {
+	assert.Equal(t, entities.DeadLetterStatusPending, mapper.MapDeadLetterStatus(sqlc.DeadLetterStatusPending))
+	assert.Equal(t, entities.DeadLetterStatusReplayed, mapper.MapDeadLetterStatus(sqlc.DeadLetterStatusReplayed))
+}
+
+var allTestDeadLetters = []*sqlc.SensorDeadLetter{
+	{
+		ID:          1,
+		CreatedAt:   pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:   pgtype.Timestamp{Time: time.Now()},
+		Topic:       "v3/green-ecolution/devices/sensor-1/up",
+		Decoder:     "ttn",
+		Payload:     []byte(`{"end_device_ids": {}}`),
+		Reason:      sqlc.DeadLetterReasonDecode,
+		Error:       "invalid mqtt payload",
+		Status:      sqlc.DeadLetterStatusPending,
+		ReplayCount: 0,
+	},
+	{
+		ID:             2,
+		CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+		Topic:          "v3/green-ecolution/devices/sensor-2/up",
+		Decoder:        "json",
+		Payload:        []byte(`{"device": "sensor-2"}`),
+		Reason:         sqlc.DeadLetterReasonProcess,
+		Error:          "sensor not found",
+		Status:         sqlc.DeadLetterStatusReplayed,
+		ReplayCount:    2,
+		LastReplayedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	},
+}
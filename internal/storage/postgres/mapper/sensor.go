@@ -12,26 +12,93 @@ import (
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
-// goverter:extend MapSensorStatus MapSensorData
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:SecondsToDurationPtr
+// goverter:extend MapSensorStatus MapSensorData MapSensorDataAnomalyType
 type InternalSensorRepoMapper interface {
-	// goverter:ignore LatestData
+	// goverter:ignore LatestData Calibration
+	// goverter:map . LocationDrift | MapSensorLocationDrift
 	FromSql(src *sqlc.Sensor) *entities.Sensor
 	FromSqlList(src []*sqlc.Sensor) []*entities.Sensor
 	// goverter:map Data | MapSensorData
+	// goverter:map Anomalies | MapSensorDataAnomalies
 	FromSqlSensorData(src *sqlc.SensorDatum) (*entities.SensorData, error)
 	FromSqlSensorDataList(src []*sqlc.SensorDatum) ([]*entities.SensorData, error)
 	FromDomainSensorData(src *entities.MqttPayload) *mqtt.MqttPayload
+	FromDomainSensorDataAnomalies(src []entities.SensorDataAnomaly) []mqtt.SensorDataAnomaly
+	// goverter:map ResistanceCurve Curve | MapCalibrationCurve
+	FromSqlCalibration(src *sqlc.SensorCalibration) (*entities.SensorCalibration, error)
+	FromDomainCalibrationCurve(src []entities.CalibrationPoint) []mqtt.CalibrationPoint
 }
 
+// MapSensorData decodes the stored sensor data with the json layout of the storage entity
 func MapSensorData(src []byte) (*entities.MqttPayload, error) {
-	var payload entities.MqttPayload
+	var payload mqtt.MqttPayload
 	err := json.Unmarshal(src, &payload)
 	if err != nil {
 		return nil, err
 	}
-	return &payload, nil
+
+	return &entities.MqttPayload{
+		Device:        payload.Device,
+		Battery:       payload.Battery,
+		Humidity:      payload.Humidity,
+		Temperature:   payload.Temperature,
+		Watermarks:    mapWatermarks(payload.Watermarks),
+		RawWatermarks: mapWatermarks(payload.RawWatermarks),
+	}, nil
+}
+
+func mapWatermarks(src []mqtt.Watermark) []entities.Watermark {
+	if src == nil {
+		return nil
+	}
+
+	watermarks := make([]entities.Watermark, len(src))
+	for i, w := range src {
+		watermarks[i] = entities.Watermark{Centibar: w.Centibar, Resistance: w.Resistance, Depth: w.Depth}
+	}
+	return watermarks
+}
+
+func MapSensorDataAnomalies(src []byte) ([]entities.SensorDataAnomaly, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var anomalies []entities.SensorDataAnomaly
+	err := json.Unmarshal(src, &anomalies)
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+func MapCalibrationCurve(src []byte) ([]entities.CalibrationPoint, error) {
+	var curve []entities.CalibrationPoint
+	err := json.Unmarshal(src, &curve)
+	if err != nil {
+		return nil, err
+	}
+	return curve, nil
 }
 
 func MapSensorStatus(src sqlc.SensorStatus) entities.SensorStatus {
 	return entities.SensorStatus(src)
 }
+
+func MapSensorLocationDrift(src *sqlc.Sensor) *entities.SensorLocationDrift {
+	if src.DriftLatitude == nil || src.DriftLongitude == nil || src.DriftDistance == nil || !src.DriftDetectedAt.Valid {
+		return nil
+	}
+
+	return &entities.SensorLocationDrift{
+		Latitude:   *src.DriftLatitude,
+		Longitude:  *src.DriftLongitude,
+		Distance:   *src.DriftDistance,
+		DetectedAt: src.DriftDetectedAt.Time,
+	}
+}
+
+func MapSensorDataAnomalyType(src entities.SensorDataAnomalyType) string {
+	return string(src)
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend MapSensorAssignmentStatus
+type InternalSensorAssignmentRepoMapper interface {
+	// goverter:ignore Candidates
+	FromSql(src *sqlc.SensorAssignmentReview) *entities.SensorAssignmentReview
+	FromSqlList(src []*sqlc.SensorAssignmentReview) []*entities.SensorAssignmentReview
+	FromSqlCandidate(src *sqlc.SensorAssignmentCandidate) *entities.TreeCandidate
+	FromSqlCandidateList(src []*sqlc.SensorAssignmentCandidate) []*entities.TreeCandidate
+}
+
+func MapSensorAssignmentStatus(status sqlc.SensorAssignmentStatus) entities.SensorAssignmentStatus {
+	return entities.SensorAssignmentStatus(status)
+}
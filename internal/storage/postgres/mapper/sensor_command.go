@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:SecondsToDurationPtr
+// goverter:extend MapSensorCommandType MapSensorCommandStatus
+type InternalSensorCommandRepoMapper interface {
+	FromSql(src *sqlc.SensorCommand) *entities.SensorCommand
+	FromSqlList(src []*sqlc.SensorCommand) []*entities.SensorCommand
+}
+
+func MapSensorCommandType(commandType sqlc.SensorCommandType) entities.SensorCommandType {
+	return entities.SensorCommandType(commandType)
+}
+
+func MapSensorCommandStatus(status sqlc.SensorCommandStatus) entities.SensorCommandStatus {
+	return entities.SensorCommandStatus(status)
+}
@@ -0,0 +1,129 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorCommandMapper_FromSql(t *testing.T) {
+	cmdMapper := &generated.InternalSensorCommandRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestSensorCommands[0]
+
+		// when
+		got := cmdMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.SensorID, got.SensorID)
+		assert.Equal(t, src.Type, sqlc.SensorCommandType(got.Type))
+		assert.Equal(t, src.Status, sqlc.SensorCommandStatus(got.Status))
+		assert.NotNil(t, got.ReportInterval)
+		assert.Equal(t, 15*time.Minute, *got.ReportInterval)
+		assert.NotNil(t, got.SentAt)
+		assert.Equal(t, src.SentAt.Time, *got.SentAt)
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should map missing interval and timestamps to nil", func(t *testing.T) {
+		// given
+		src := allTestSensorCommands[1]
+
+		// when
+		got := cmdMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Nil(t, got.ReportInterval)
+		assert.Nil(t, got.SentAt)
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.SensorCommand = nil
+
+		// when
+		got := cmdMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandMapper_FromSqlList(t *testing.T) {
+	cmdMapper := &generated.InternalSensorCommandRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestSensorCommands
+
+		// when
+		got := cmdMapper.FromSqlList(src)
+
+		// then
+		assert.Len(t, got, len(src))
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.SensorID, got[i].SensorID)
+			assert.Equal(t, src.Status, sqlc.SensorCommandStatus(got[i].Status))
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src []*sqlc.SensorCommand = nil
+
+		// when
+		got := cmdMapper.FromSqlList(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestMapSensorCommandType(t *testing.T) {
+	assert.Equal(t, entities.SensorCommandTypeSetInterval, mapper.MapSensorCommandType(sqlc.SensorCommandTypeSetInterval))
+	assert.Equal(t, entities.SensorCommandTypeRequestReading, mapper.MapSensorCommandType(sqlc.SensorCommandTypeRequestReading))
+	assert.Equal(t, entities.SensorCommandTypeReboot, mapper.MapSensorCommandType(sqlc.SensorCommandTypeReboot))
+}
+
+func TestMapSensorCommandStatus(t *testing.T) {
+	assert.Equal(t, entities.SensorCommandStatusQueued, mapper.MapSensorCommandStatus(sqlc.SensorCommandStatusQueued))
+	assert.Equal(t, entities.SensorCommandStatusSent, mapper.MapSensorCommandStatus(sqlc.SensorCommandStatusSent))
+	assert.Equal(t, entities.SensorCommandStatusAcknowledged, mapper.MapSensorCommandStatus(sqlc.SensorCommandStatusAcknowledged))
+}
+
+var allTestSensorCommands = []*sqlc.SensorCommand{
+	{
+		ID:             1,
+		CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+		SensorID:       "sensor-1",
+		Type:           sqlc.SensorCommandTypeSetInterval,
+		Status:         sqlc.SensorCommandStatusSent,
+		ReportInterval: utils.P(int32(900)),
+		SentAt:         pgtype.Timestamp{Time: time.Now(), Valid: true},
+	},
+	{
+		ID:        2,
+		CreatedAt: pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+		SensorID:  "sensor-2",
+		Type:      sqlc.SensorCommandTypeReboot,
+		Status:    sqlc.SensorCommandStatusQueued,
+	},
+}
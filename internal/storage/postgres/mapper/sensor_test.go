@@ -4,8 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,6 +29,27 @@ func TestSensorMapper_FromSql(t *testing.T) {
 		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
 		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
 		assert.Equal(t, src.Status, sqlc.SensorStatus(got.Status))
+		assert.Nil(t, got.LocationDrift)
+	})
+
+	t.Run("should convert location drift from sql to entity", func(t *testing.T) {
+		// given
+		detectedAt := time.Date(2025, 1, 26, 9, 0, 0, 0, time.UTC)
+		src := *allTestSensors[0]
+		src.DriftLatitude = utils.P(54.9)
+		src.DriftLongitude = utils.P(9.5)
+		src.DriftDistance = utils.P(8912.4)
+		src.DriftDetectedAt = pgtype.Timestamp{Time: detectedAt, Valid: true}
+
+		// when
+		got := sensorMapper.FromSql(&src)
+
+		// then
+		assert.NotNil(t, got.LocationDrift)
+		assert.Equal(t, 54.9, got.LocationDrift.Latitude)
+		assert.Equal(t, 9.5, got.LocationDrift.Longitude)
+		assert.Equal(t, 8912.4, got.LocationDrift.Distance)
+		assert.Equal(t, detectedAt, got.LocationDrift.DetectedAt)
 	})
 
 	t.Run("should return nil for nil input", func(t *testing.T) {
@@ -75,6 +99,47 @@ func TestSensorMapper_FromSqlList(t *testing.T) {
 	})
 }
 
+func TestSensorMapper_MapSensorData(t *testing.T) {
+	t.Run("should decode stored sensor data with raw watermarks", func(t *testing.T) {
+		// given
+		src := []byte(`{"device": "sensor-1", "battery": 3.4, "humidity": 50.5, "temperature": 20.5,
+			"watermarks": [{"resistance": 23, "centibar": 30, "depth": 30}],
+			"raw_watermarks": [{"resistance": 23, "centibar": 38, "depth": 30}]}`)
+
+		// when
+		got, err := mapper.MapSensorData(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", got.Device)
+		assert.Equal(t, 3.4, got.Battery)
+		assert.Equal(t, []entities.Watermark{{Resistance: 23, Centibar: 30, Depth: 30}}, got.Watermarks)
+		assert.Equal(t, []entities.Watermark{{Resistance: 23, Centibar: 38, Depth: 30}}, got.RawWatermarks)
+	})
+
+	t.Run("should decode stored sensor data without raw watermarks", func(t *testing.T) {
+		// given
+		src := []byte(`{"device": "sensor-1", "watermarks": [{"resistance": 23, "centibar": 38, "depth": 30}]}`)
+
+		// when
+		got, err := mapper.MapSensorData(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Watermarks, 1)
+		assert.Nil(t, got.RawWatermarks)
+	})
+
+	t.Run("should return error on invalid json", func(t *testing.T) {
+		// when
+		got, err := mapper.MapSensorData([]byte(`[]`))
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
 var allTestSensors = []*sqlc.Sensor{
 	{
 		ID:        "sensor-1",
@@ -3,16 +3,20 @@ package mapper
 import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
-// goverter:extend MapWateringStatus MapSoilCondition
+// goverter:extend MapWateringStatus MapSoilCondition MapMoistureTrend MapMoistureDepth
 // goverter:ignoreMissing
 type InternalTreeClusterRepoMapper interface {
 	FromSql(*sqlc.TreeCluster) *entities.TreeCluster
 	FromSqlList([]*sqlc.TreeCluster) []*entities.TreeCluster
+	FromSqlMoistureTrendList([]*sqlc.TreeClusterMoistureTrend) []*entities.MoistureTrend
+	FromSqlMoistureMetrics(*sqlc.TreeClusterMoistureMetric) *entities.MoistureMetrics
+	FromSqlMoistureDepthList([]*sqlc.TreeClusterMoistureDepth) []*entities.DepthMoisture
 }
 
 func MapWateringStatus(status sqlc.WateringStatus) entities.WateringStatus {
@@ -22,3 +26,25 @@ func MapWateringStatus(status sqlc.WateringStatus) entities.WateringStatus {
 func MapSoilCondition(condition sqlc.TreeSoilCondition) entities.TreeSoilCondition {
 	return entities.TreeSoilCondition(condition)
 }
+
+func MapMoistureTrend(src *sqlc.TreeClusterMoistureTrend) *entities.MoistureTrend {
+	return &entities.MoistureTrend{
+		Depth:          int(src.Depth),
+		Centibar:       src.Centibar,
+		CentibarPerDay: src.CentibarPerDay,
+		SampleCount:    src.SampleCount,
+		MeasuredAt:     utils.PgTimestampToTime(src.MeasuredAt),
+		ModerateAt:     utils.PgTimestampToTimePtr(src.ModerateAt),
+		BadAt:          utils.PgTimestampToTimePtr(src.BadAt),
+	}
+}
+
+func MapMoistureDepth(src *sqlc.TreeClusterMoistureDepth) *entities.DepthMoisture {
+	return &entities.DepthMoisture{
+		Depth:        int(src.Depth),
+		SensorCount:  src.SensorCount,
+		MinCentibar:  src.MinCentibar,
+		MeanCentibar: src.MeanCentibar,
+		MaxCentibar:  src.MaxCentibar,
+	}
+}
@@ -167,3 +167,57 @@ func TestMapSoilCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestMapMoistureTrend(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moderateAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should convert moisture trend with estimation", func(t *testing.T) {
+		// given
+		src := &sqlc.TreeClusterMoistureTrend{
+			TreeClusterID:  1,
+			Depth:          30,
+			Centibar:       20.5,
+			CentibarPerDay: 2.25,
+			SampleCount:    48,
+			MeasuredAt:     pgtype.Timestamp{Time: measuredAt, Valid: true},
+			ModerateAt:     pgtype.Timestamp{Time: moderateAt, Valid: true},
+		}
+
+		// when
+		got := mapper.MapMoistureTrend(src)
+
+		// then
+		assert.Equal(t, 30, got.Depth)
+		assert.Equal(t, 20.5, got.Centibar)
+		assert.Equal(t, 2.25, got.CentibarPerDay)
+		assert.Equal(t, int32(48), got.SampleCount)
+		assert.Equal(t, measuredAt, got.MeasuredAt)
+		assert.Equal(t, moderateAt, *got.ModerateAt)
+		assert.Nil(t, got.BadAt)
+	})
+}
+
+func TestMapMoistureDepth(t *testing.T) {
+	t.Run("should convert moisture depth", func(t *testing.T) {
+		// given
+		src := &sqlc.TreeClusterMoistureDepth{
+			TreeClusterID: 1,
+			Depth:         60,
+			SensorCount:   3,
+			MinCentibar:   12.5,
+			MeanCentibar:  20.25,
+			MaxCentibar:   31,
+		}
+
+		// when
+		got := mapper.MapMoistureDepth(src)
+
+		// then
+		assert.Equal(t, 60, got.Depth)
+		assert.Equal(t, int32(3), got.SensorCount)
+		assert.Equal(t, 12.5, got.MinCentibar)
+		assert.Equal(t, 20.25, got.MeanCentibar)
+		assert.Equal(t, 31.0, got.MaxCentibar)
+	})
+}
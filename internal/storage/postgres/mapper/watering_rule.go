@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapWateringThreshold
+type InternalWateringRuleRepoMapper interface {
+	// goverter:ignore Thresholds
+	FromSql(src *sqlc.WateringRuleSet) *entities.WateringRuleSet
+	FromSqlList(src []*sqlc.WateringRuleSet) []*entities.WateringRuleSet
+	FromSqlThresholdList(src []*sqlc.WateringThreshold) []entities.WateringThreshold
+}
+
+func MapWateringThreshold(src *sqlc.WateringThreshold) entities.WateringThreshold {
+	threshold := entities.WateringThreshold{
+		SpeciesGroup: src.SpeciesGroup,
+		MinAge:       src.MinAge,
+		MaxAge:       src.MaxAge,
+		Depth:        int(src.Depth),
+		Moderate:     int(src.Moderate),
+		Bad:          int(src.Bad),
+	}
+
+	if src.SoilCondition.Valid {
+		soilCondition := entities.TreeSoilCondition(src.SoilCondition.TreeSoilCondition)
+		threshold.SoilCondition = &soilCondition
+	}
+
+	return threshold
+}
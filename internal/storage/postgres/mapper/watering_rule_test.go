@@ -0,0 +1,148 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRuleMapper_FromSql(t *testing.T) {
+	ruleMapper := &generated.InternalWateringRuleRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestWateringRuleSets[0]
+
+		// when
+		got := ruleMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.Version, got.Version)
+		assert.Equal(t, src.Description, got.Description)
+		assert.True(t, got.Active)
+		assert.NotNil(t, got.ActivatedAt)
+		assert.Equal(t, src.ActivatedAt.Time, *got.ActivatedAt)
+		assert.Nil(t, got.Thresholds)
+	})
+
+	t.Run("should map missing activation time to nil", func(t *testing.T) {
+		// given
+		src := allTestWateringRuleSets[1]
+
+		// when
+		got := ruleMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.False(t, got.Active)
+		assert.Nil(t, got.ActivatedAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.WateringRuleSet = nil
+
+		// when
+		got := ruleMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleMapper_FromSqlThresholdList(t *testing.T) {
+	ruleMapper := &generated.InternalWateringRuleRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestWateringThresholds
+
+		// when
+		got := ruleMapper.FromSqlThresholdList(src)
+
+		// then
+		assert.Len(t, got, len(src))
+		for i, src := range src {
+			assert.Equal(t, src.MinAge, got[i].MinAge)
+			assert.Equal(t, src.MaxAge, got[i].MaxAge)
+			assert.Equal(t, int(src.Depth), got[i].Depth)
+			assert.Equal(t, int(src.Moderate), got[i].Moderate)
+			assert.Equal(t, int(src.Bad), got[i].Bad)
+		}
+	})
+}
+
+func TestMapWateringThreshold(t *testing.T) {
+	t.Run("should map threshold for all species and soil conditions", func(t *testing.T) {
+		// when
+		got := mapper.MapWateringThreshold(allTestWateringThresholds[0])
+
+		// then
+		assert.Nil(t, got.SpeciesGroup)
+		assert.Nil(t, got.SoilCondition)
+		assert.Equal(t, 30, got.Depth)
+	})
+
+	t.Run("should map species group and soil condition", func(t *testing.T) {
+		// when
+		got := mapper.MapWateringThreshold(allTestWateringThresholds[1])
+
+		// then
+		assert.Equal(t, utils.P("Acer"), got.SpeciesGroup)
+		assert.Equal(t, utils.P(entities.TreeSoilConditionSandig), got.SoilCondition)
+	})
+}
+
+var allTestWateringRuleSets = []*sqlc.WateringRuleSet{
+	{
+		ID:          1,
+		CreatedAt:   pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:   pgtype.Timestamp{Time: time.Now()},
+		Version:     1,
+		Description: "initial thresholds",
+		Active:      true,
+		ActivatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	},
+	{
+		ID:          2,
+		CreatedAt:   pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:   pgtype.Timestamp{Time: time.Now()},
+		Version:     2,
+		Description: "",
+		Active:      false,
+	},
+}
+
+var allTestWateringThresholds = []*sqlc.WateringThreshold{
+	{
+		ID:        1,
+		RuleSetID: 1,
+		MinAge:    0,
+		MaxAge:    1,
+		Depth:     30,
+		Moderate:  25,
+		Bad:       33,
+	},
+	{
+		ID:            2,
+		RuleSetID:     1,
+		SpeciesGroup:  utils.P("Acer"),
+		SoilCondition: sqlc.NullTreeSoilCondition{TreeSoilCondition: sqlc.TreeSoilConditionSandig, Valid: true},
+		MinAge:        4,
+		MaxAge:        99,
+		Depth:         60,
+		Moderate:      80,
+		Bad:           120,
+	},
+}
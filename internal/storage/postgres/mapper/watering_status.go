@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend MapWateringStatus MapWateringStatusCause
+type InternalWateringStatusRepoMapper interface {
+	FromSql(src *sqlc.WateringStatusTransition) *entities.WateringStatusTransition
+	FromSqlList(src []*sqlc.WateringStatusTransition) []*entities.WateringStatusTransition
+}
+
+func MapWateringStatusCause(cause sqlc.WateringStatusCause) entities.WateringStatusCause {
+	return entities.WateringStatusCause(cause)
+}
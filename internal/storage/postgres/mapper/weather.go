@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	weather "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/weather/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTimePtr
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapWateringStatus MapWaterBalanceDays
+type InternalWeatherRepoMapper interface {
+	// goverter:map Et0 ET0
+	FromSql(src *sqlc.WeatherDay) *entities.WeatherDay
+	FromSqlList(src []*sqlc.WeatherDay) []*entities.WeatherDay
+	FromSqlLocationList(src []*sqlc.GetWeatherLocationsRow) []*entities.WeatherLocation
+	FromSqlWaterBalance(src *sqlc.TreeClusterWaterBalance) (*entities.WaterBalance, error)
+	FromSqlWaterBalanceList(src []*sqlc.TreeClusterWaterBalance) ([]*entities.WaterBalance, error)
+	FromDomainWaterBalanceDays(src []entities.WaterBalanceDay) []weather.WaterBalanceDay
+}
+
+func MapWaterBalanceDays(src []byte) ([]entities.WaterBalanceDay, error) {
+	var days []weather.WaterBalanceDay
+	if err := json.Unmarshal(src, &days); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.WaterBalanceDay, len(days))
+	for i, day := range days {
+		result[i] = entities.WaterBalanceDay(day)
+	}
+	return result, nil
+}
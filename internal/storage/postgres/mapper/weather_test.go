@@ -0,0 +1,159 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeatherMapper_FromSql(t *testing.T) {
+	weatherMapper := &generated.InternalWeatherRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestWeatherDays[0]
+
+		// when
+		got := weatherMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.RegionID, got.RegionID)
+		assert.Equal(t, src.Date.Time, got.Date)
+		assert.Equal(t, src.Precipitation, got.Precipitation)
+		assert.Equal(t, src.TemperatureMin, got.TemperatureMin)
+		assert.Equal(t, src.TemperatureMax, got.TemperatureMax)
+		assert.Equal(t, src.Et0, got.ET0)
+		assert.Equal(t, src.Provider, got.Provider)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.WeatherDay = nil
+
+		// when
+		got := weatherMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherMapper_FromSqlWaterBalance(t *testing.T) {
+	weatherMapper := &generated.InternalWeatherRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestWaterBalances[0]
+
+		// when
+		got, err := weatherMapper.FromSqlWaterBalance(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, src.TreeClusterID, got.TreeClusterID)
+		assert.Equal(t, entities.WateringStatusGood, got.BaseStatus)
+		assert.Equal(t, src.BaseDate.Time, got.BaseDate)
+		assert.Equal(t, entities.WateringStatusBad, got.ForecastStatus)
+		assert.Equal(t, src.BadAt.Time, *got.BadAt)
+		assert.True(t, got.Flagged)
+		assert.Len(t, got.Days, 1)
+		assert.Equal(t, entities.WateringStatusBad, got.Days[0].WateringStatus)
+		assert.True(t, got.Days[0].Forecast)
+	})
+
+	t.Run("should map missing bad date to nil", func(t *testing.T) {
+		// given
+		src := allTestWaterBalances[1]
+
+		// when
+		got, err := weatherMapper.FromSqlWaterBalance(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got.BadAt)
+		assert.Empty(t, got.Days)
+	})
+
+	t.Run("should return error for malformed days", func(t *testing.T) {
+		// given
+		src := &sqlc.TreeClusterWaterBalance{Days: []byte("{")}
+
+		// when
+		got, err := weatherMapper.FromSqlWaterBalance(src)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestMapWaterBalanceDays(t *testing.T) {
+	t.Run("should map json days", func(t *testing.T) {
+		// when
+		got, err := mapper.MapWaterBalanceDays([]byte(`[{"date":"2025-01-30T00:00:00Z","precipitation":1.5,"et0":0.4,"deficit":12.3,"watering_status":"good","forecast":false}]`))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.WaterBalanceDay{
+			{
+				Date:           time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC),
+				Precipitation:  1.5,
+				ET0:            0.4,
+				Deficit:        12.3,
+				WateringStatus: entities.WateringStatusGood,
+			},
+		}, got)
+	})
+}
+
+var allTestWeatherDays = []*sqlc.WeatherDay{
+	{
+		RegionID:       1,
+		Date:           pgtype.Date{Time: time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC), Valid: true},
+		CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+		Precipitation:  4.2,
+		TemperatureMin: 2,
+		TemperatureMax: 7.5,
+		Et0:            0.3,
+		Provider:       "open-meteo",
+	},
+}
+
+var allTestWaterBalances = []*sqlc.TreeClusterWaterBalance{
+	{
+		TreeClusterID:  1,
+		CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+		RegionID:       1,
+		BaseStatus:     sqlc.WateringStatusGood,
+		BaseDate:       pgtype.Date{Time: time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC), Valid: true},
+		WateringStatus: sqlc.WateringStatusGood,
+		AdjustedStatus: sqlc.WateringStatusModerate,
+		ForecastStatus: sqlc.WateringStatusBad,
+		Deficit:        25.5,
+		BadAt:          pgtype.Date{Time: time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+		Flagged:        true,
+		Days:           []byte(`[{"date":"2025-01-30T00:00:00Z","precipitation":0.1,"et0":0.5,"deficit":30.2,"watering_status":"bad","forecast":true}]`),
+	},
+	{
+		TreeClusterID:  2,
+		CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+		RegionID:       1,
+		BaseStatus:     sqlc.WateringStatusModerate,
+		BaseDate:       pgtype.Date{Time: time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC), Valid: true},
+		WateringStatus: sqlc.WateringStatusModerate,
+		AdjustedStatus: sqlc.WateringStatusModerate,
+		ForecastStatus: sqlc.WateringStatusModerate,
+		Deficit:        50,
+		Days:           []byte(`[]`),
+	},
+}
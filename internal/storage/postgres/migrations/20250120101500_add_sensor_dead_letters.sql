@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE dead_letter_reason AS ENUM ('decode', 'process');
+CREATE TYPE dead_letter_status AS ENUM ('pending', 'replayed');
+
+CREATE TABLE IF NOT EXISTS sensor_dead_letters (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  topic TEXT NOT NULL,
+  decoder TEXT NOT NULL,
+  payload BYTEA NOT NULL,
+  reason dead_letter_reason NOT NULL,
+  error TEXT NOT NULL,
+  status dead_letter_status NOT NULL DEFAULT 'pending',
+  replay_count INT NOT NULL DEFAULT 0,
+  last_replayed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_sensor_dead_letters_status ON sensor_dead_letters (status, created_at);
+
+CREATE TRIGGER update_sensor_dead_letters_updated_at
+BEFORE UPDATE ON sensor_dead_letters
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_sensor_dead_letters_updated_at ON sensor_dead_letters;
+DROP TABLE IF EXISTS sensor_dead_letters;
+DROP TYPE IF EXISTS dead_letter_status;
+DROP TYPE IF EXISTS dead_letter_reason;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- offline threshold of the sensor in seconds, NULL uses the global threshold
+ALTER TABLE sensors ADD COLUMN offline_threshold INTEGER CHECK (offline_threshold > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sensors DROP COLUMN IF EXISTS offline_threshold;
+-- +goose StatementEnd
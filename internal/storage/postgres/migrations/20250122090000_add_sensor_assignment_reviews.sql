@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE sensor_assignment_status AS ENUM ('pending', 'resolved', 'dismissed');
+
+CREATE TABLE IF NOT EXISTS sensor_assignment_reviews (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  sensor_id VARCHAR NOT NULL,
+  status sensor_assignment_status NOT NULL DEFAULT 'pending',
+  tree_id INT,
+  FOREIGN KEY (sensor_id) REFERENCES sensors(id) ON DELETE CASCADE,
+  FOREIGN KEY (tree_id) REFERENCES trees(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS sensor_assignment_candidates (
+  review_id INT NOT NULL,
+  tree_id INT NOT NULL,
+  distance DOUBLE PRECISION NOT NULL,
+  PRIMARY KEY (review_id, tree_id),
+  FOREIGN KEY (review_id) REFERENCES sensor_assignment_reviews(id) ON DELETE CASCADE,
+  FOREIGN KEY (tree_id) REFERENCES trees(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sensor_assignment_reviews_status ON sensor_assignment_reviews (status, created_at);
+
+CREATE TRIGGER update_sensor_assignment_reviews_updated_at
+BEFORE UPDATE ON sensor_assignment_reviews
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_sensor_assignment_reviews_updated_at ON sensor_assignment_reviews;
+DROP TABLE IF EXISTS sensor_assignment_candidates;
+DROP TABLE IF EXISTS sensor_assignment_reviews;
+DROP TYPE IF EXISTS sensor_assignment_status;
+-- +goose StatementEnd
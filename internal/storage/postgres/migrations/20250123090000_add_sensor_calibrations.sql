@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sensor_calibrations (
+  sensor_id VARCHAR PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  centibar_offset DOUBLE PRECISION NOT NULL DEFAULT 0,
+  temperature_coefficient DOUBLE PRECISION NOT NULL DEFAULT 0,
+  reference_temperature DOUBLE PRECISION NOT NULL DEFAULT 20,
+  resistance_curve JSONB NOT NULL DEFAULT '[]',
+  FOREIGN KEY (sensor_id) REFERENCES sensors(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_sensor_calibrations_updated_at
+BEFORE UPDATE ON sensor_calibrations
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_sensor_calibrations_updated_at ON sensor_calibrations;
+DROP TABLE IF EXISTS sensor_calibrations;
+-- +goose StatementEnd
@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sensor_data_hourly (
+  sensor_id VARCHAR NOT NULL,
+  bucket TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  sample_count INT NOT NULL,
+  battery DOUBLE PRECISION NOT NULL,
+  humidity DOUBLE PRECISION NOT NULL,
+  temperature DOUBLE PRECISION NOT NULL,
+  watermarks JSONB NOT NULL DEFAULT '[]',
+  PRIMARY KEY (sensor_id, bucket),
+  FOREIGN KEY (sensor_id) REFERENCES sensors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS sensor_data_daily (
+  sensor_id VARCHAR NOT NULL,
+  bucket TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  sample_count INT NOT NULL,
+  battery DOUBLE PRECISION NOT NULL,
+  humidity DOUBLE PRECISION NOT NULL,
+  temperature DOUBLE PRECISION NOT NULL,
+  watermarks JSONB NOT NULL DEFAULT '[]',
+  PRIMARY KEY (sensor_id, bucket),
+  FOREIGN KEY (sensor_id) REFERENCES sensors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sensor_data_created_at ON sensor_data (created_at);
+
+CREATE TRIGGER update_sensor_data_hourly_updated_at
+BEFORE UPDATE ON sensor_data_hourly
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_sensor_data_daily_updated_at
+BEFORE UPDATE ON sensor_data_daily
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- merges two lists of averaged watermarks, every depth is weighted by the number of readings with a watermark at this depth
+CREATE OR REPLACE FUNCTION merge_watermark_aggregates(a JSONB, b JSONB)
+RETURNS JSONB AS $$
+  SELECT COALESCE(jsonb_agg(jsonb_build_object('depth', depth, 'centibar', centibar, 'resistance', resistance, 'sample_count', sample_count) ORDER BY depth), '[]'::jsonb)
+  FROM (
+    SELECT
+      (w->>'depth')::int AS depth,
+      SUM((w->>'centibar')::float * (w->>'sample_count')::int) / SUM((w->>'sample_count')::int) AS centibar,
+      SUM((w->>'resistance')::float * (w->>'sample_count')::int) / SUM((w->>'sample_count')::int) AS resistance,
+      SUM((w->>'sample_count')::int)::int AS sample_count
+    FROM (
+      SELECT jsonb_array_elements(a) AS w
+      UNION ALL
+      SELECT jsonb_array_elements(b) AS w
+    ) merged
+    GROUP BY 1
+  ) averaged;
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS merge_watermark_aggregates(JSONB, JSONB);
+DROP TRIGGER IF EXISTS update_sensor_data_daily_updated_at ON sensor_data_daily;
+DROP TRIGGER IF EXISTS update_sensor_data_hourly_updated_at ON sensor_data_hourly;
+DROP INDEX IF EXISTS idx_sensor_data_created_at;
+DROP TABLE IF EXISTS sensor_data_daily;
+DROP TABLE IF EXISTS sensor_data_hourly;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE sensor_command_type AS ENUM ('set_interval', 'request_reading', 'reboot');
+CREATE TYPE sensor_command_status AS ENUM ('queued', 'sent', 'acknowledged');
+
+CREATE TABLE IF NOT EXISTS sensor_commands (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  sensor_id VARCHAR NOT NULL,
+  type sensor_command_type NOT NULL,
+  status sensor_command_status NOT NULL DEFAULT 'queued',
+  report_interval INTEGER CHECK (report_interval > 0),
+  sent_at TIMESTAMP,
+  acknowledged_at TIMESTAMP,
+  FOREIGN KEY (sensor_id) REFERENCES sensors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sensor_commands_status ON sensor_commands (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_sensor_commands_sensor_id ON sensor_commands (sensor_id, created_at);
+
+CREATE TRIGGER update_sensor_commands_updated_at
+BEFORE UPDATE ON sensor_commands
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_sensor_commands_updated_at ON sensor_commands;
+DROP TABLE IF EXISTS sensor_commands;
+DROP TYPE IF EXISTS sensor_command_status;
+DROP TYPE IF EXISTS sensor_command_type;
+-- +goose StatementEnd
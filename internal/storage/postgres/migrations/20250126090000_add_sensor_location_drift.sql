@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- last reported position of a sensor that moved away from its registered location, NULL if no drift is detected
+ALTER TABLE sensors ADD COLUMN drift_latitude DOUBLE PRECISION;
+ALTER TABLE sensors ADD COLUMN drift_longitude DOUBLE PRECISION;
+-- distance in meters between the reported position and the reference location
+ALTER TABLE sensors ADD COLUMN drift_distance DOUBLE PRECISION;
+ALTER TABLE sensors ADD COLUMN drift_detected_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sensors DROP COLUMN IF EXISTS drift_detected_at;
+ALTER TABLE sensors DROP COLUMN IF EXISTS drift_distance;
+ALTER TABLE sensors DROP COLUMN IF EXISTS drift_longitude;
+ALTER TABLE sensors DROP COLUMN IF EXISTS drift_latitude;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- suspicious readings are kept but excluded from the watering status computation
+ALTER TABLE sensor_data ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE;
+-- highest score of all detected anomalies between 0 and 1
+ALTER TABLE sensor_data ADD COLUMN anomaly_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE sensor_data ADD COLUMN anomalies JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_sensor_data_sensor_id_unflagged ON sensor_data (sensor_id, created_at DESC) WHERE NOT flagged;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sensor_data_sensor_id_unflagged;
+ALTER TABLE sensor_data DROP COLUMN IF EXISTS anomalies;
+ALTER TABLE sensor_data DROP COLUMN IF EXISTS anomaly_score;
+ALTER TABLE sensor_data DROP COLUMN IF EXISTS flagged;
+-- +goose StatementEnd
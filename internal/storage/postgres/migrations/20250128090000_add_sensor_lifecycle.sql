@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+-- decommissioned sensors are kept with their data, they don't accept new data and can't be linked to a tree
+ALTER TABLE sensors ADD COLUMN decommissioned_at TIMESTAMP;
+ALTER TABLE sensors ADD COLUMN decommission_reason TEXT;
+-- device that took over the trees and flowerbeds of a decommissioned sensor
+ALTER TABLE sensors ADD COLUMN replaced_by VARCHAR REFERENCES sensors(id) ON DELETE SET NULL;
+
+-- tree the sensor was linked to when the data was measured, it is kept when the sensor is replaced
+ALTER TABLE sensor_data ADD COLUMN tree_id INT REFERENCES trees(id) ON DELETE SET NULL;
+
+UPDATE sensor_data SET tree_id = trees.id
+FROM trees
+WHERE trees.sensor_id = sensor_data.sensor_id;
+
+CREATE INDEX IF NOT EXISTS idx_sensor_data_tree_id ON sensor_data (tree_id, created_at);
+
+-- the rollups keep the tree as well, so the data stays attributed to it after the raw data is pruned. There is no
+-- foreign key, deleting a tree would otherwise merge its aggregates into the ones without a tree.
+ALTER TABLE sensor_data_hourly ADD COLUMN tree_id INT;
+ALTER TABLE sensor_data_daily ADD COLUMN tree_id INT;
+
+UPDATE sensor_data_hourly SET tree_id = trees.id
+FROM trees
+WHERE trees.sensor_id = sensor_data_hourly.sensor_id;
+
+UPDATE sensor_data_daily SET tree_id = trees.id
+FROM trees
+WHERE trees.sensor_id = sensor_data_daily.sensor_id;
+
+ALTER TABLE sensor_data_hourly DROP CONSTRAINT sensor_data_hourly_pkey;
+ALTER TABLE sensor_data_daily DROP CONSTRAINT sensor_data_daily_pkey;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sensor_data_hourly_bucket ON sensor_data_hourly (sensor_id, bucket, COALESCE(tree_id, 0));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sensor_data_daily_bucket ON sensor_data_daily (sensor_id, bucket, COALESCE(tree_id, 0));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sensor_data_daily_bucket;
+DROP INDEX IF EXISTS idx_sensor_data_hourly_bucket;
+
+-- aggregates of one bucket measured at different trees can not be merged back, only the largest one is kept
+DELETE FROM sensor_data_hourly a USING sensor_data_hourly b
+WHERE a.sensor_id = b.sensor_id AND a.bucket = b.bucket
+  AND (a.sample_count, COALESCE(a.tree_id, 0)) < (b.sample_count, COALESCE(b.tree_id, 0));
+
+DELETE FROM sensor_data_daily a USING sensor_data_daily b
+WHERE a.sensor_id = b.sensor_id AND a.bucket = b.bucket
+  AND (a.sample_count, COALESCE(a.tree_id, 0)) < (b.sample_count, COALESCE(b.tree_id, 0));
+
+ALTER TABLE sensor_data_daily DROP COLUMN IF EXISTS tree_id;
+ALTER TABLE sensor_data_hourly DROP COLUMN IF EXISTS tree_id;
+ALTER TABLE sensor_data_daily ADD PRIMARY KEY (sensor_id, bucket);
+ALTER TABLE sensor_data_hourly ADD PRIMARY KEY (sensor_id, bucket);
+
+DROP INDEX IF EXISTS idx_sensor_data_tree_id;
+ALTER TABLE sensor_data DROP COLUMN IF EXISTS tree_id;
+ALTER TABLE sensors DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE sensors DROP COLUMN IF EXISTS decommission_reason;
+ALTER TABLE sensors DROP COLUMN IF EXISTS decommissioned_at;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS watering_rule_sets (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  version INT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  active BOOLEAN NOT NULL DEFAULT FALSE,
+  activated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS watering_thresholds (
+  id SERIAL PRIMARY KEY,
+  rule_set_id INT NOT NULL,
+  species_group VARCHAR,
+  soil_condition tree_soil_condition,
+  min_age INT NOT NULL,
+  max_age INT NOT NULL,
+  depth INT NOT NULL,
+  moderate INT NOT NULL,
+  bad INT NOT NULL,
+  FOREIGN KEY (rule_set_id) REFERENCES watering_rule_sets(id) ON DELETE CASCADE,
+  CHECK (min_age >= 0 AND min_age <= max_age),
+  CHECK (depth > 0),
+  CHECK (moderate <= bad)
+);
+
+-- only one rule set can be active
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watering_rule_sets_active ON watering_rule_sets (active) WHERE active;
+CREATE INDEX IF NOT EXISTS idx_watering_thresholds_rule_set_id ON watering_thresholds (rule_set_id);
+
+CREATE TRIGGER update_watering_rule_sets_updated_at
+BEFORE UPDATE ON watering_rule_sets
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_watering_rule_sets_updated_at ON watering_rule_sets;
+DROP TABLE IF EXISTS watering_thresholds;
+DROP TABLE IF EXISTS watering_rule_sets;
+-- +goose StatementEnd
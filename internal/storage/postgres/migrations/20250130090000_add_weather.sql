@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS weather_days (
+  region_id INT NOT NULL,
+  date DATE NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  precipitation DOUBLE PRECISION NOT NULL DEFAULT 0,
+  temperature_min DOUBLE PRECISION NOT NULL DEFAULT 0,
+  temperature_max DOUBLE PRECISION NOT NULL DEFAULT 0,
+  et0 DOUBLE PRECISION NOT NULL DEFAULT 0,
+  provider VARCHAR NOT NULL DEFAULT '',
+  PRIMARY KEY (region_id, date),
+  FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tree_cluster_water_balances (
+  tree_cluster_id INT PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  region_id INT NOT NULL,
+  base_status watering_status NOT NULL,
+  base_date DATE NOT NULL,
+  watering_status watering_status NOT NULL,
+  adjusted_status watering_status NOT NULL,
+  forecast_status watering_status NOT NULL,
+  deficit DOUBLE PRECISION NOT NULL DEFAULT 0,
+  bad_at DATE,
+  flagged BOOLEAN NOT NULL DEFAULT FALSE,
+  days JSONB NOT NULL DEFAULT '[]',
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tree_cluster_water_balances_flagged ON tree_cluster_water_balances (flagged) WHERE flagged;
+
+CREATE TRIGGER update_weather_days_updated_at
+BEFORE UPDATE ON weather_days
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_tree_cluster_water_balances_updated_at
+BEFORE UPDATE ON tree_cluster_water_balances
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_tree_cluster_water_balances_updated_at ON tree_cluster_water_balances;
+DROP TRIGGER IF EXISTS update_weather_days_updated_at ON weather_days;
+DROP INDEX IF EXISTS idx_tree_cluster_water_balances_flagged;
+DROP TABLE IF EXISTS tree_cluster_water_balances;
+DROP TABLE IF EXISTS weather_days;
+-- +goose StatementEnd
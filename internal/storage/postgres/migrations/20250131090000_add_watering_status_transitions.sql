@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE watering_status_cause AS ENUM ('sensor_data', 'watering_plan', 'manual', 'weather');
+
+CREATE TABLE IF NOT EXISTS watering_status_transitions (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tree_id INT,
+  tree_cluster_id INT,
+  old_status watering_status NOT NULL,
+  new_status watering_status NOT NULL,
+  cause watering_status_cause NOT NULL,
+  FOREIGN KEY (tree_id) REFERENCES trees(id) ON DELETE CASCADE,
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  -- a transition belongs either to a tree or to a tree cluster
+  CHECK ((tree_id IS NULL) <> (tree_cluster_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_watering_status_transitions_tree_id ON watering_status_transitions (tree_id, created_at) WHERE tree_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_watering_status_transitions_tree_cluster_id ON watering_status_transitions (tree_cluster_id, created_at) WHERE tree_cluster_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_watering_status_transitions_tree_cluster_id;
+DROP INDEX IF EXISTS idx_watering_status_transitions_tree_id;
+DROP TABLE IF EXISTS watering_status_transitions;
+DROP TYPE IF EXISTS watering_status_cause;
+-- +goose StatementEnd
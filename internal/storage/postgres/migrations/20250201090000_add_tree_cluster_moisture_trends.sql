@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tree_cluster_moisture_trends (
+  tree_cluster_id INT NOT NULL,
+  depth INT NOT NULL,
+  centibar FLOAT NOT NULL,
+  centibar_per_day FLOAT NOT NULL,
+  sample_count INT NOT NULL,
+  measured_at TIMESTAMP NOT NULL,
+  moderate_at TIMESTAMP,
+  bad_at TIMESTAMP,
+  PRIMARY KEY (tree_cluster_id, depth),
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tree_cluster_moisture_trends;
+-- +goose StatementEnd
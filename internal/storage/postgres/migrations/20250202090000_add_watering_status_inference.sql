@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the watering status of tree clusters without sensor data is estimated and not measured
+ALTER TABLE tree_clusters ADD COLUMN watering_status_estimated BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TYPE watering_status_cause ADD VALUE 'inference';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TYPE watering_status_cause RENAME TO watering_status_cause_old;
+
+CREATE TYPE watering_status_cause AS ENUM ('sensor_data', 'watering_plan', 'manual', 'weather');
+
+-- inferred transitions were modeled with the weather
+UPDATE watering_status_transitions SET cause = 'weather' WHERE cause = 'inference';
+
+ALTER TABLE watering_status_transitions
+    ALTER COLUMN cause TYPE watering_status_cause USING cause::text::watering_status_cause;
+
+DROP TYPE watering_status_cause_old;
+
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS watering_status_estimated;
+-- +goose StatementEnd
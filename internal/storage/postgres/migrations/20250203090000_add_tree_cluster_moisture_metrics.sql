@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tree_cluster_moisture_metrics (
+  tree_cluster_id INT PRIMARY KEY,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tree_count INT NOT NULL,
+  sensor_count INT NOT NULL,
+  coverage FLOAT NOT NULL,
+  latest_measured_at TIMESTAMP NOT NULL,
+  oldest_measured_at TIMESTAMP NOT NULL,
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tree_cluster_moisture_depths (
+  tree_cluster_id INT NOT NULL,
+  depth INT NOT NULL,
+  sensor_count INT NOT NULL,
+  min_centibar FLOAT NOT NULL,
+  mean_centibar FLOAT NOT NULL,
+  max_centibar FLOAT NOT NULL,
+  PRIMARY KEY (tree_cluster_id, depth),
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tree_cluster_moisture_depths;
+DROP TABLE IF EXISTS tree_cluster_moisture_metrics;
+-- +goose StatementEnd
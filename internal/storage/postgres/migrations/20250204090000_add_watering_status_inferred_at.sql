@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- time of the sensor data of the similar tree clusters the estimated watering status is inferred from
+ALTER TABLE tree_clusters ADD COLUMN watering_status_inferred_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS watering_status_inferred_at;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- commands that can not be routed to a network server expire instead of staying queued forever
+ALTER TYPE sensor_command_status ADD VALUE 'expired';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sensor_commands ALTER COLUMN status DROP DEFAULT;
+
+ALTER TYPE sensor_command_status RENAME TO sensor_command_status_old;
+
+CREATE TYPE sensor_command_status AS ENUM ('queued', 'sent', 'acknowledged');
+
+-- expired commands were never published
+DELETE FROM sensor_commands WHERE status = 'expired';
+
+ALTER TABLE sensor_commands
+    ALTER COLUMN status TYPE sensor_command_status USING status::text::sensor_command_status;
+
+ALTER TABLE sensor_commands ALTER COLUMN status SET DEFAULT 'queued';
+
+DROP TYPE sensor_command_status_old;
+-- +goose StatementEnd
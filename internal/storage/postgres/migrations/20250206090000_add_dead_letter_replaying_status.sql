@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a dead letter is claimed while it is replayed, so two concurrent replays can not process it twice
+ALTER TYPE dead_letter_status ADD VALUE 'replaying';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sensor_dead_letters ALTER COLUMN status DROP DEFAULT;
+
+ALTER TYPE dead_letter_status RENAME TO dead_letter_status_old;
+
+CREATE TYPE dead_letter_status AS ENUM ('pending', 'replayed');
+
+-- an interrupted replay was never finished
+UPDATE sensor_dead_letters SET status = 'pending' WHERE status = 'replaying';
+
+ALTER TABLE sensor_dead_letters
+    ALTER COLUMN status TYPE dead_letter_status USING status::text::dead_letter_status;
+
+ALTER TABLE sensor_dead_letters ALTER COLUMN status SET DEFAULT 'pending';
+
+DROP TYPE dead_letter_status_old;
+-- +goose StatementEnd
@@ -32,6 +32,9 @@ DELETE FROM flowerbed_images WHERE flowerbed_id = $1 RETURNING flowerbed_id;
 -- name: UnlinkSensorIDFromFlowerbeds :exec
 UPDATE flowerbeds SET sensor_id = NULL WHERE sensor_id = $1;
 
+-- name: TransferFlowerbedSensorID :exec
+UPDATE flowerbeds SET sensor_id = sqlc.arg(new_sensor_id) WHERE sensor_id = sqlc.arg(old_sensor_id);
+
 -- name: UpdateFlowerbed :exec
 UPDATE flowerbeds SET
   sensor_id = $2,
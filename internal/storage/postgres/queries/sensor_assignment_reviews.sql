@@ -0,0 +1,43 @@
+-- name: GetAllSensorAssignmentReviews :many
+SELECT * FROM sensor_assignment_reviews ORDER BY created_at DESC, id DESC;
+
+-- name: GetAllSensorAssignmentReviewsByStatus :many
+SELECT * FROM sensor_assignment_reviews WHERE status = $1 ORDER BY created_at DESC, id DESC;
+
+-- name: GetSensorAssignmentReviewByID :one
+SELECT * FROM sensor_assignment_reviews WHERE id = $1;
+
+-- name: GetSensorAssignmentCandidatesByReviewID :many
+SELECT * FROM sensor_assignment_candidates WHERE review_id = $1 ORDER BY distance ASC, tree_id ASC;
+
+-- name: CreateSensorAssignmentReview :one
+INSERT INTO sensor_assignment_reviews (
+  sensor_id,
+  status,
+  tree_id
+) VALUES (
+  $1, $2, $3
+) RETURNING id;
+
+-- name: CreateSensorAssignmentCandidate :exec
+INSERT INTO sensor_assignment_candidates (
+  review_id,
+  tree_id,
+  distance
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: UpdateSensorAssignmentReview :exec
+UPDATE sensor_assignment_reviews SET
+  status = $2,
+  tree_id = $3
+WHERE id = $1;
+
+-- name: ResolveSensorAssignmentReview :one
+-- only a pending review is resolved, so concurrent resolves can not link the sensor twice
+UPDATE sensor_assignment_reviews SET
+  status = 'resolved',
+  tree_id = $2
+WHERE id = $1 AND status = 'pending'
+RETURNING id;
@@ -0,0 +1,25 @@
+-- name: GetAllSensorCommandsBySensorID :many
+SELECT * FROM sensor_commands WHERE sensor_id = $1 ORDER BY created_at DESC, id DESC;
+
+-- name: GetAllSensorCommandsByStatus :many
+SELECT * FROM sensor_commands WHERE status = $1 ORDER BY created_at ASC, id ASC;
+
+-- name: GetSensorCommandByID :one
+SELECT * FROM sensor_commands WHERE id = $1;
+
+-- name: CreateSensorCommand :one
+INSERT INTO sensor_commands (
+  sensor_id,
+  type,
+  status,
+  report_interval
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id;
+
+-- name: UpdateSensorCommand :exec
+UPDATE sensor_commands SET
+  status = $2,
+  sent_at = $3,
+  acknowledged_at = $4
+WHERE id = $1;
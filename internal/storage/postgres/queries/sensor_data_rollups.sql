@@ -0,0 +1,133 @@
+-- name: RollupSensorDataHourly :execrows
+WITH readings AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    date_trunc('hour', created_at)::timestamp AS bucket,
+    data,
+    CASE WHEN jsonb_typeof(data->'watermarks') = 'array' THEN data->'watermarks' ELSE '[]'::jsonb END AS watermarks
+  FROM sensor_data
+  WHERE created_at < sqlc.arg(before)::timestamp
+    -- readings flagged as anomalous are pruned without being rolled up
+    AND NOT flagged
+), depths AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    bucket,
+    (w->>'depth')::int AS depth,
+    AVG((w->>'centibar')::float) AS centibar,
+    AVG((w->>'resistance')::float) AS resistance,
+    COUNT(*)::int AS sample_count
+  FROM readings, jsonb_array_elements(readings.watermarks) AS w
+  GROUP BY 1, 2, 3, 4
+), marks AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    bucket,
+    jsonb_agg(jsonb_build_object('depth', depth, 'centibar', centibar, 'resistance', resistance, 'sample_count', sample_count) ORDER BY depth) AS watermarks
+  FROM depths
+  GROUP BY 1, 2, 3
+)
+INSERT INTO sensor_data_hourly (
+  sensor_id, tree_id, bucket, sample_count, battery, humidity, temperature, watermarks
+)
+SELECT
+  readings.sensor_id,
+  readings.tree_id,
+  readings.bucket,
+  COUNT(*)::int,
+  COALESCE(AVG((readings.data->>'battery')::float), 0)::float,
+  COALESCE(AVG((readings.data->>'humidity')::float), 0)::float,
+  COALESCE(AVG((readings.data->>'temperature')::float), 0)::float,
+  COALESCE(marks.watermarks, '[]'::jsonb)
+FROM readings
+LEFT JOIN marks ON marks.sensor_id = readings.sensor_id
+  AND marks.tree_id IS NOT DISTINCT FROM readings.tree_id
+  AND marks.bucket = readings.bucket
+GROUP BY readings.sensor_id, readings.tree_id, readings.bucket, marks.watermarks
+ON CONFLICT (sensor_id, bucket, COALESCE(tree_id, 0)) DO UPDATE SET
+  battery = (sensor_data_hourly.battery * sensor_data_hourly.sample_count + EXCLUDED.battery * EXCLUDED.sample_count) / (sensor_data_hourly.sample_count + EXCLUDED.sample_count),
+  humidity = (sensor_data_hourly.humidity * sensor_data_hourly.sample_count + EXCLUDED.humidity * EXCLUDED.sample_count) / (sensor_data_hourly.sample_count + EXCLUDED.sample_count),
+  temperature = (sensor_data_hourly.temperature * sensor_data_hourly.sample_count + EXCLUDED.temperature * EXCLUDED.sample_count) / (sensor_data_hourly.sample_count + EXCLUDED.sample_count),
+  watermarks = merge_watermark_aggregates(sensor_data_hourly.watermarks, EXCLUDED.watermarks),
+  sample_count = sensor_data_hourly.sample_count + EXCLUDED.sample_count;
+
+-- name: RollupSensorDataDaily :execrows
+WITH readings AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    date_trunc('day', created_at)::timestamp AS bucket,
+    data,
+    CASE WHEN jsonb_typeof(data->'watermarks') = 'array' THEN data->'watermarks' ELSE '[]'::jsonb END AS watermarks
+  FROM sensor_data
+  WHERE created_at < sqlc.arg(before)::timestamp
+    -- readings flagged as anomalous are pruned without being rolled up
+    AND NOT flagged
+), depths AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    bucket,
+    (w->>'depth')::int AS depth,
+    AVG((w->>'centibar')::float) AS centibar,
+    AVG((w->>'resistance')::float) AS resistance,
+    COUNT(*)::int AS sample_count
+  FROM readings, jsonb_array_elements(readings.watermarks) AS w
+  GROUP BY 1, 2, 3, 4
+), marks AS (
+  SELECT
+    sensor_id,
+    tree_id,
+    bucket,
+    jsonb_agg(jsonb_build_object('depth', depth, 'centibar', centibar, 'resistance', resistance, 'sample_count', sample_count) ORDER BY depth) AS watermarks
+  FROM depths
+  GROUP BY 1, 2, 3
+)
+INSERT INTO sensor_data_daily (
+  sensor_id, tree_id, bucket, sample_count, battery, humidity, temperature, watermarks
+)
+SELECT
+  readings.sensor_id,
+  readings.tree_id,
+  readings.bucket,
+  COUNT(*)::int,
+  COALESCE(AVG((readings.data->>'battery')::float), 0)::float,
+  COALESCE(AVG((readings.data->>'humidity')::float), 0)::float,
+  COALESCE(AVG((readings.data->>'temperature')::float), 0)::float,
+  COALESCE(marks.watermarks, '[]'::jsonb)
+FROM readings
+LEFT JOIN marks ON marks.sensor_id = readings.sensor_id
+  AND marks.tree_id IS NOT DISTINCT FROM readings.tree_id
+  AND marks.bucket = readings.bucket
+GROUP BY readings.sensor_id, readings.tree_id, readings.bucket, marks.watermarks
+ON CONFLICT (sensor_id, bucket, COALESCE(tree_id, 0)) DO UPDATE SET
+  battery = (sensor_data_daily.battery * sensor_data_daily.sample_count + EXCLUDED.battery * EXCLUDED.sample_count) / (sensor_data_daily.sample_count + EXCLUDED.sample_count),
+  humidity = (sensor_data_daily.humidity * sensor_data_daily.sample_count + EXCLUDED.humidity * EXCLUDED.sample_count) / (sensor_data_daily.sample_count + EXCLUDED.sample_count),
+  temperature = (sensor_data_daily.temperature * sensor_data_daily.sample_count + EXCLUDED.temperature * EXCLUDED.sample_count) / (sensor_data_daily.sample_count + EXCLUDED.sample_count),
+  watermarks = merge_watermark_aggregates(sensor_data_daily.watermarks, EXCLUDED.watermarks),
+  sample_count = sensor_data_daily.sample_count + EXCLUDED.sample_count;
+
+-- name: DeleteSensorDataBefore :execrows
+DELETE FROM sensor_data WHERE created_at < sqlc.arg(before)::timestamp;
+
+-- name: DeleteSensorDataHourlyBefore :execrows
+DELETE FROM sensor_data_hourly WHERE bucket < sqlc.arg(before)::timestamp;
+
+-- name: GetSensorDataHourlyBySensorIDAndTimeRange :many
+SELECT *
+FROM sensor_data_hourly
+WHERE sensor_id = sqlc.arg(sensor_id)
+  AND bucket >= sqlc.arg(from_time)::timestamp
+  AND bucket < sqlc.arg(to_time)::timestamp
+ORDER BY bucket ASC, tree_id ASC NULLS FIRST;
+
+-- name: GetSensorDataDailyBySensorIDAndTimeRange :many
+SELECT *
+FROM sensor_data_daily
+WHERE sensor_id = sqlc.arg(sensor_id)
+  AND bucket >= sqlc.arg(from_time)::timestamp
+  AND bucket < sqlc.arg(to_time)::timestamp
+ORDER BY bucket ASC, tree_id ASC NULLS FIRST;
@@ -0,0 +1,36 @@
+-- name: GetAllSensorDeadLetters :many
+SELECT * FROM sensor_dead_letters ORDER BY created_at DESC, id DESC;
+
+-- name: GetAllSensorDeadLettersByStatus :many
+SELECT * FROM sensor_dead_letters WHERE status = $1 ORDER BY created_at DESC, id DESC;
+
+-- name: GetSensorDeadLetterByID :one
+SELECT * FROM sensor_dead_letters WHERE id = $1;
+
+-- name: CreateSensorDeadLetter :one
+INSERT INTO sensor_dead_letters (
+  topic,
+  decoder,
+  payload,
+  reason,
+  error,
+  status
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id;
+
+-- name: UpdateSensorDeadLetter :exec
+UPDATE sensor_dead_letters SET
+  error = $2,
+  status = $3,
+  replay_count = $4,
+  last_replayed_at = $5
+WHERE id = $1;
+
+-- name: ClaimSensorDeadLetter :one
+UPDATE sensor_dead_letters SET status = 'replaying'
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: DeleteSensorDeadLetter :one
+DELETE FROM sensor_dead_letters WHERE id = $1 RETURNING id;
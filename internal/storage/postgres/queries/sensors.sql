@@ -16,12 +16,22 @@ LIMIT 1;
 
 -- name: CreateSensor :one
 INSERT INTO sensors (
-    id, status, latitude, longitude
+    id, status, latitude, longitude, offline_threshold
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 ) RETURNING id;
 
 -- name: UpdateSensor :exec
+UPDATE sensors SET
+  status = $2,
+  offline_threshold = $3,
+  drift_latitude = $4,
+  drift_longitude = $5,
+  drift_distance = $6,
+  drift_detected_at = $7
+WHERE id = $1;
+
+-- name: UpdateSensorStatus :exec
 UPDATE sensors SET
   status = $2
 WHERE id = $1;
@@ -35,10 +45,129 @@ WHERE id = $1;
 
 -- name: InsertSensorData :exec
 INSERT INTO sensor_data (
-  sensor_id, data
+  sensor_id, data, flagged, anomaly_score, anomalies, tree_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, (SELECT trees.id FROM trees WHERE trees.sensor_id = $1 LIMIT 1)
 ) RETURNING id;
 
+-- name: DecommissionSensor :exec
+UPDATE sensors SET
+  status = 'offline',
+  decommissioned_at = CURRENT_TIMESTAMP,
+  decommission_reason = $2,
+  replaced_by = $3
+WHERE id = $1;
+
 -- name: DeleteSensor :exec
-DELETE FROM sensors WHERE id = $1;
\ No newline at end of file
+DELETE FROM sensors WHERE id = $1;
+
+-- name: GetSensorDataBySensorIDAndTimeRange :many
+SELECT *
+FROM sensor_data
+WHERE sensor_id = sqlc.arg(sensor_id)
+  AND created_at >= sqlc.arg(from_time)::timestamp
+  AND created_at < sqlc.arg(to_time)::timestamp
+ORDER BY created_at ASC;
+
+-- name: GetAggregatedSensorDataBySensorIDAndTimeRange :many
+SELECT
+  date_trunc(sqlc.arg(resolution)::text, created_at)::timestamp AS bucket,
+  COUNT(*)::int AS sample_count,
+  COALESCE(AVG((data->>'battery')::float), 0)::float AS battery,
+  COALESCE(AVG((data->>'humidity')::float), 0)::float AS humidity,
+  COALESCE(AVG((data->>'temperature')::float), 0)::float AS temperature,
+  jsonb_agg(data->'watermarks')::jsonb AS watermarks
+FROM sensor_data
+WHERE sensor_id = sqlc.arg(sensor_id)
+  AND created_at >= sqlc.arg(from_time)::timestamp
+  AND created_at < sqlc.arg(to_time)::timestamp
+  -- like the hourly and daily rollups, readings flagged as anomalous are not aggregated
+  AND NOT flagged
+GROUP BY 1
+ORDER BY 1 ASC;
+
+-- name: GetSensorDataForExport :many
+SELECT
+  sqlc.embed(sensor_data),
+  trees.id AS tree_id,
+  tree_clusters.id AS tree_cluster_id,
+  tree_clusters.region_id
+FROM sensor_data
+LEFT JOIN trees ON trees.id = sensor_data.tree_id
+LEFT JOIN tree_clusters ON tree_clusters.id = trees.tree_cluster_id
+WHERE sensor_data.created_at >= sqlc.arg(from_time)::timestamp
+  AND sensor_data.created_at < sqlc.arg(to_time)::timestamp
+  AND (cardinality(sqlc.arg(sensor_ids)::text[]) = 0 OR sensor_data.sensor_id = ANY(sqlc.arg(sensor_ids)::text[]))
+  AND (sqlc.narg(tree_cluster_id)::int IS NULL OR tree_clusters.id = sqlc.narg(tree_cluster_id)::int)
+  AND (sqlc.narg(region_id)::int IS NULL OR tree_clusters.region_id = sqlc.narg(region_id)::int)
+  AND (sensor_data.created_at, sensor_data.id) > (sqlc.arg(after_created_at)::timestamp, sqlc.arg(after_id)::int)
+ORDER BY sensor_data.created_at ASC, sensor_data.id ASC
+LIMIT sqlc.arg(batch_size)::int;
+
+-- name: GetSensorBatteryTrends :many
+SELECT
+  sensor_id,
+  (array_agg((data->>'battery')::float ORDER BY created_at DESC))[1]::float AS battery,
+  MAX(created_at)::timestamp AS measured_at,
+  COALESCE(regr_slope((data->>'battery')::float, EXTRACT(EPOCH FROM created_at)), 0)::float AS slope,
+  COUNT(*)::int AS sample_count
+FROM sensor_data
+WHERE created_at >= sqlc.arg(since)::timestamp
+  AND data ? 'battery'
+  AND NOT flagged
+  AND sensor_id NOT IN (SELECT id FROM sensors WHERE decommissioned_at IS NOT NULL)
+GROUP BY sensor_id
+ORDER BY sensor_id;
+
+-- name: GetSensorsLastSeen :many
+-- the raw sensor data is pruned after the retention period, the latest rolled up bucket is used instead
+SELECT
+  sensors.id,
+  sensors.status,
+  sensors.offline_threshold,
+  COALESCE(
+    MAX(sensor_data.created_at),
+    GREATEST(
+      (SELECT MAX(bucket) FROM sensor_data_hourly WHERE sensor_data_hourly.sensor_id = sensors.id),
+      (SELECT MAX(bucket) FROM sensor_data_daily WHERE sensor_data_daily.sensor_id = sensors.id)
+    )
+  )::timestamp AS last_seen_at
+FROM sensors
+LEFT JOIN sensor_data ON sensor_data.sensor_id = sensors.id
+WHERE sensors.decommissioned_at IS NULL
+GROUP BY sensors.id
+ORDER BY sensors.id;
+
+-- name: GetSensorDataBatchBySensorID :many
+SELECT *
+FROM sensor_data
+WHERE sensor_id = sqlc.arg(sensor_id)
+  AND (created_at, id) > (sqlc.arg(after_created_at)::timestamp, sqlc.arg(after_id)::int)
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(batch_size)::int;
+
+-- name: UpdateSensorData :exec
+UPDATE sensor_data SET
+  data = $2,
+  flagged = $3,
+  anomaly_score = $4,
+  anomalies = $5
+WHERE id = $1;
+
+-- name: GetSensorCalibrationBySensorID :one
+SELECT * FROM sensor_calibrations WHERE sensor_id = $1;
+
+-- name: UpsertSensorCalibration :exec
+INSERT INTO sensor_calibrations (
+  sensor_id, centibar_offset, temperature_coefficient, reference_temperature, resistance_curve
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (sensor_id) DO UPDATE SET
+  centibar_offset = EXCLUDED.centibar_offset,
+  temperature_coefficient = EXCLUDED.temperature_coefficient,
+  reference_temperature = EXCLUDED.reference_temperature,
+  resistance_curve = EXCLUDED.resistance_curve;
+
+-- name: DeleteSensorCalibration :exec
+DELETE FROM sensor_calibrations WHERE sensor_id = $1;
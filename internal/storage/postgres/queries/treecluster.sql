@@ -1,10 +1,32 @@
 -- name: GetAllTreeClusters :many
-SELECT * FROM tree_clusters 
-ORDER BY name ASC
-LIMIT $1 OFFSET $2;
+SELECT tree_clusters.* FROM tree_clusters
+LEFT JOIN (
+  SELECT tree_cluster_id, MIN(moderate_at) AS moderate_at, MIN(bad_at) AS bad_at
+  FROM tree_cluster_moisture_trends
+  GROUP BY tree_cluster_id
+) AS forecast ON forecast.tree_cluster_id = tree_clusters.id
+LEFT JOIN tree_cluster_moisture_metrics AS metrics ON metrics.tree_cluster_id = tree_clusters.id
+WHERE (sqlc.narg(min_coverage)::float IS NULL OR metrics.coverage >= sqlc.narg(min_coverage)::float)
+  AND (sqlc.narg(max_coverage)::float IS NULL OR COALESCE(metrics.coverage, 0) <= sqlc.narg(max_coverage)::float)
+  AND (sqlc.narg(measured_after)::timestamp IS NULL OR metrics.latest_measured_at >= sqlc.narg(measured_after)::timestamp)
+  AND (sqlc.narg(measured_before)::timestamp IS NULL OR metrics.latest_measured_at < sqlc.narg(measured_before)::timestamp)
+  AND (sqlc.narg(min_moisture_level)::float IS NULL OR (metrics.tree_cluster_id IS NOT NULL AND tree_clusters.moisture_level >= sqlc.narg(min_moisture_level)::float))
+  AND (sqlc.narg(max_moisture_level)::float IS NULL OR (metrics.tree_cluster_id IS NOT NULL AND tree_clusters.moisture_level <= sqlc.narg(max_moisture_level)::float))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::text = 'days_until_moderate' THEN forecast.moderate_at END ASC NULLS LAST,
+  CASE WHEN sqlc.arg(sort_by)::text = 'days_until_bad' THEN forecast.bad_at END ASC NULLS LAST,
+  tree_clusters.name ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetAllTreeClustersCount :one
-SELECT COUNT(*) FROM tree_clusters;
+SELECT COUNT(*) FROM tree_clusters
+LEFT JOIN tree_cluster_moisture_metrics AS metrics ON metrics.tree_cluster_id = tree_clusters.id
+WHERE (sqlc.narg(min_coverage)::float IS NULL OR metrics.coverage >= sqlc.narg(min_coverage)::float)
+  AND (sqlc.narg(max_coverage)::float IS NULL OR COALESCE(metrics.coverage, 0) <= sqlc.narg(max_coverage)::float)
+  AND (sqlc.narg(measured_after)::timestamp IS NULL OR metrics.latest_measured_at >= sqlc.narg(measured_after)::timestamp)
+  AND (sqlc.narg(measured_before)::timestamp IS NULL OR metrics.latest_measured_at < sqlc.narg(measured_before)::timestamp)
+  AND (sqlc.narg(min_moisture_level)::float IS NULL OR (metrics.tree_cluster_id IS NOT NULL AND tree_clusters.moisture_level >= sqlc.narg(min_moisture_level)::float))
+  AND (sqlc.narg(max_moisture_level)::float IS NULL OR (metrics.tree_cluster_id IS NOT NULL AND tree_clusters.moisture_level <= sqlc.narg(max_moisture_level)::float));
 
 -- name: GetTreeClusterByID :one
 SELECT * FROM tree_clusters WHERE id = $1;
@@ -52,7 +74,9 @@ UPDATE tree_clusters SET
   watering_status = $7,
   soil_condition = $8,
   last_watered = $9,
-  archived = $10
+  archived = $10,
+  watering_status_estimated = $11,
+  watering_status_inferred_at = $12
 WHERE id = $1;
 
 -- name: ArchiveTreeCluster :one
@@ -77,6 +101,91 @@ WHERE tc.id = $1
     SELECT id
     FROM sensor_data
     WHERE sensor_id = s.id
+      AND NOT flagged
     ORDER BY created_at DESC
     LIMIT 1
   );
+
+-- name: GetMoistureTrendsByTreeClusterID :many
+SELECT * FROM tree_cluster_moisture_trends WHERE tree_cluster_id = $1 ORDER BY depth;
+
+-- name: DeleteMoistureTrendsByTreeClusterID :exec
+DELETE FROM tree_cluster_moisture_trends WHERE tree_cluster_id = $1;
+
+-- name: CreateMoistureTrend :exec
+INSERT INTO tree_cluster_moisture_trends (
+  tree_cluster_id, depth, centibar, centibar_per_day, sample_count, measured_at, moderate_at, bad_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: CalculateMoistureTrendsByTreeClusterID :many
+WITH readings AS (
+  SELECT
+    sd.sensor_id,
+    sd.created_at,
+    (w->>'depth')::int AS depth,
+    (w->>'centibar')::float AS centibar
+  FROM sensor_data sd
+  JOIN trees t ON t.sensor_id = sd.sensor_id
+  CROSS JOIN LATERAL jsonb_array_elements(
+    CASE WHEN jsonb_typeof(sd.data->'watermarks') = 'array' THEN sd.data->'watermarks' ELSE '[]'::jsonb END
+  ) AS w
+  WHERE t.tree_cluster_id = sqlc.arg(tree_cluster_id)
+    AND sd.created_at >= sqlc.arg(since)::timestamp
+    AND NOT sd.flagged
+), sensor_trends AS (
+  -- the trend is fitted per sensor, as the absolute values of the sensors of a tree cluster differ
+  SELECT
+    sensor_id,
+    depth,
+    regr_slope(centibar, EXTRACT(EPOCH FROM created_at) / 86400) AS slope,
+    MAX(created_at) AS measured_at,
+    COUNT(*) AS sample_count
+  FROM readings
+  GROUP BY sensor_id, depth
+  HAVING COUNT(*) > 1
+)
+SELECT
+  depth,
+  COALESCE(AVG(slope), 0)::float AS centibar_per_day,
+  MAX(measured_at)::timestamp AS measured_at,
+  SUM(sample_count)::int AS sample_count
+FROM sensor_trends
+GROUP BY depth
+ORDER BY depth;
+
+-- name: GetMoistureMetricsByTreeClusterID :one
+SELECT * FROM tree_cluster_moisture_metrics WHERE tree_cluster_id = $1;
+
+-- name: GetMoistureDepthsByTreeClusterID :many
+SELECT * FROM tree_cluster_moisture_depths WHERE tree_cluster_id = $1 ORDER BY depth;
+
+-- name: UpsertMoistureMetrics :exec
+INSERT INTO tree_cluster_moisture_metrics (
+  tree_cluster_id, tree_count, sensor_count, coverage, latest_measured_at, oldest_measured_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) ON CONFLICT (tree_cluster_id) DO UPDATE SET
+  updated_at = CURRENT_TIMESTAMP,
+  tree_count = EXCLUDED.tree_count,
+  sensor_count = EXCLUDED.sensor_count,
+  coverage = EXCLUDED.coverage,
+  latest_measured_at = EXCLUDED.latest_measured_at,
+  oldest_measured_at = EXCLUDED.oldest_measured_at;
+
+-- name: DeleteMoistureMetricsByTreeClusterID :exec
+DELETE FROM tree_cluster_moisture_metrics WHERE tree_cluster_id = $1;
+
+-- name: DeleteMoistureDepthsByTreeClusterID :exec
+DELETE FROM tree_cluster_moisture_depths WHERE tree_cluster_id = $1;
+
+-- name: CreateMoistureDepth :exec
+INSERT INTO tree_cluster_moisture_depths (
+  tree_cluster_id, depth, sensor_count, min_centibar, mean_centibar, max_centibar
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
+
+-- name: SetTreeClusterMoistureLevel :exec
+UPDATE tree_clusters SET moisture_level = $2 WHERE id = $1;
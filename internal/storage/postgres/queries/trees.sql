@@ -81,10 +81,12 @@ DELETE FROM trees WHERE id = $1 RETURNING id;
 -- name: UnlinkTreeClusterID :many
 UPDATE trees SET tree_cluster_id = NULL WHERE tree_cluster_id = $1 RETURNING id;
 
--- name: UnlinkSensorIDFromTrees :exec
+-- name: UnlinkSensorIDFromTrees :many
 UPDATE trees
 SET sensor_id = NULL, watering_status = 'unknown'
-WHERE sensor_id = $1;
+FROM (SELECT id, watering_status FROM trees WHERE sensor_id = $1 FOR UPDATE) AS prev
+WHERE trees.id = prev.id
+RETURNING trees.id, prev.watering_status AS prev_watering_status;
 
 -- name: CalculateGroupedCentroids :one
 SELECT ST_AsText(ST_Centroid(ST_Collect(geometry)))::text AS centroid FROM trees WHERE id = ANY($1::int[]);
@@ -94,3 +96,18 @@ SELECT * FROM trees
 WHERE ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) <= 3
 ORDER BY ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) ASC
     LIMIT 1;
+
+-- name: FindNearestUnassignedTrees :many
+SELECT id, ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint(sqlc.arg(latitude)::float8, sqlc.arg(longitude)::float8), 4326)::geography)::float8 AS distance
+FROM trees
+WHERE sensor_id IS NULL
+  AND ST_DWithin(geometry::geography, ST_SetSRID(ST_MakePoint(sqlc.arg(latitude)::float8, sqlc.arg(longitude)::float8), 4326)::geography, sqlc.arg(radius)::float8)
+ORDER BY distance ASC, id ASC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: LinkSensorToTree :one
+-- a tree that is already linked to a sensor is not updated, so an existing link is never overwritten
+UPDATE trees SET sensor_id = $2 WHERE id = $1 AND sensor_id IS NULL RETURNING id;
+
+-- name: TransferTreeSensorID :many
+UPDATE trees SET sensor_id = sqlc.arg(new_sensor_id) WHERE sensor_id = sqlc.arg(old_sensor_id) RETURNING id;
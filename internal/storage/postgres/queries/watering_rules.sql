@@ -0,0 +1,44 @@
+-- name: GetAllWateringRuleSets :many
+SELECT * FROM watering_rule_sets ORDER BY version DESC;
+
+-- name: GetWateringRuleSetByID :one
+SELECT * FROM watering_rule_sets WHERE id = $1;
+
+-- name: GetActiveWateringRuleSet :one
+SELECT * FROM watering_rule_sets WHERE active = TRUE;
+
+-- name: GetWateringThresholdsByRuleSetID :many
+SELECT * FROM watering_thresholds WHERE rule_set_id = $1 ORDER BY min_age ASC, depth ASC, id ASC;
+
+-- name: CreateWateringRuleSet :one
+INSERT INTO watering_rule_sets (
+  version,
+  description
+) VALUES (
+  (SELECT COALESCE(MAX(version), 0) + 1 FROM watering_rule_sets), $1
+) RETURNING id;
+
+-- name: CreateWateringThreshold :exec
+INSERT INTO watering_thresholds (
+  rule_set_id,
+  species_group,
+  soil_condition,
+  min_age,
+  max_age,
+  depth,
+  moderate,
+  bad
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: DeactivateWateringRuleSets :exec
+UPDATE watering_rule_sets SET
+  active = FALSE
+WHERE active = TRUE;
+
+-- name: ActivateWateringRuleSet :exec
+UPDATE watering_rule_sets SET
+  active = TRUE,
+  activated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
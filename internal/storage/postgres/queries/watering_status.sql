@@ -0,0 +1,16 @@
+-- name: CreateWateringStatusTransition :exec
+INSERT INTO watering_status_transitions (
+  tree_id, tree_cluster_id, old_status, new_status, cause
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: GetWateringStatusTransitionsByTreeID :many
+SELECT * FROM watering_status_transitions
+WHERE tree_id = $1 AND created_at >= sqlc.arg(since)::timestamp
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetWateringStatusTransitionsByTreeClusterID :many
+SELECT * FROM watering_status_transitions
+WHERE tree_cluster_id = $1 AND created_at >= sqlc.arg(since)::timestamp
+ORDER BY created_at ASC, id ASC;
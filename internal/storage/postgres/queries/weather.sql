@@ -0,0 +1,55 @@
+-- name: GetWeatherLocations :many
+SELECT
+  region_id::int AS region_id,
+  AVG(latitude)::float AS latitude,
+  AVG(longitude)::float AS longitude
+FROM tree_clusters
+WHERE region_id IS NOT NULL AND latitude IS NOT NULL AND longitude IS NOT NULL AND archived = FALSE
+GROUP BY region_id
+ORDER BY region_id;
+
+-- name: GetWeatherDaysByRegionID :many
+SELECT * FROM weather_days
+WHERE region_id = $1 AND date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+ORDER BY date;
+
+-- name: UpsertWeatherDay :exec
+INSERT INTO weather_days (
+  region_id, date, precipitation, temperature_min, temperature_max, et0, provider
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (region_id, date) DO UPDATE SET
+  precipitation = EXCLUDED.precipitation,
+  temperature_min = EXCLUDED.temperature_min,
+  temperature_max = EXCLUDED.temperature_max,
+  et0 = EXCLUDED.et0,
+  provider = EXCLUDED.provider;
+
+-- name: DeleteWeatherDaysBefore :execrows
+DELETE FROM weather_days WHERE date < sqlc.arg(before)::date;
+
+-- name: GetAllWaterBalances :many
+SELECT * FROM tree_cluster_water_balances ORDER BY tree_cluster_id;
+
+-- name: GetFlaggedWaterBalances :many
+SELECT * FROM tree_cluster_water_balances WHERE flagged = TRUE ORDER BY bad_at, tree_cluster_id;
+
+-- name: GetWaterBalanceByTreeClusterID :one
+SELECT * FROM tree_cluster_water_balances WHERE tree_cluster_id = $1;
+
+-- name: UpsertWaterBalance :exec
+INSERT INTO tree_cluster_water_balances (
+  tree_cluster_id, region_id, base_status, base_date, watering_status, adjusted_status, forecast_status, deficit, bad_at, flagged, days
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) ON CONFLICT (tree_cluster_id) DO UPDATE SET
+  region_id = EXCLUDED.region_id,
+  base_status = EXCLUDED.base_status,
+  base_date = EXCLUDED.base_date,
+  watering_status = EXCLUDED.watering_status,
+  adjusted_status = EXCLUDED.adjusted_status,
+  forecast_status = EXCLUDED.forecast_status,
+  deficit = EXCLUDED.deficit,
+  bad_at = EXCLUDED.bad_at,
+  flagged = EXCLUDED.flagged,
+  days = EXCLUDED.days;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO sensor_dead_letters (topic, decoder, payload, reason, error, status, replay_count, created_at)
+VALUES
+    ('v3/green-ecolution/devices/sensor-1/up', 'ttn', convert_to('{"end_device_ids": {"device_id": "sensor-1"}}', 'UTF8'), 'decode', 'invalid mqtt payload: missing uplink_message.decoded_payload', 'pending', 0, '2025-01-20 08:00:00'),
+    ('v3/green-ecolution/devices/sensor-2/up', 'json', convert_to('{"device": "sensor-2", "battery": 3.4, "latitude": 120}', 'UTF8'), 'process', 'validation failed for latitude', 'pending', 1, '2025-01-20 09:00:00'),
+    ('v3/green-ecolution/devices/sensor-3/up', 'json', convert_to('{"device": "sensor-3", "battery": 3.1}', 'UTF8'), 'process', 'sensor could not be created', 'replayed', 2, '2025-01-20 10:00:00');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM sensor_dead_letters;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO sensors (id, status, latitude, longitude, geometry)
+VALUES
+    ('sensor-1', 'online', 54.82124518093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124518093376, 9.485702120628517), 4326)),
+    ('sensor-2', 'online', 54.78780993841013, 9.444052105200551, ST_SetSRID(ST_MakePoint(54.78780993841013, 9.444052105200551), 4326));
+
+INSERT INTO trees (id, tree_cluster_id, sensor_id, planting_year, species, number, latitude, longitude, geometry, readonly, watering_status, description)
+VALUES
+    (1, NULL, NULL, 2021, 'Quercus robur', 1005, 54.82124818093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124818093376, 9.485702120628517), 4326), true, 'unknown', 'Sample description 1'),
+    (2, NULL, NULL, 2022, 'Quercus robur', 1006, 54.82124218093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124218093376, 9.485702120628517), 4326), true, 'unknown', 'Sample description 2'),
+    (3, NULL, 'sensor-2', 2023, 'Betula pendula', 1010, 54.78780993841013, 9.444052105200551, ST_SetSRID(ST_MakePoint(54.78780993841013, 9.444052105200551), 4326), false, 'unknown', 'Sample description 3');
+ALTER SEQUENCE trees_id_seq RESTART WITH 4;
+
+INSERT INTO sensor_assignment_reviews (id, sensor_id, status, tree_id, created_at)
+VALUES
+    (1, 'sensor-1', 'pending', NULL, '2025-01-22 08:00:00'),
+    (2, 'sensor-2', 'resolved', 3, '2025-01-22 09:00:00');
+ALTER SEQUENCE sensor_assignment_reviews_id_seq RESTART WITH 3;
+
+INSERT INTO sensor_assignment_candidates (review_id, tree_id, distance)
+VALUES
+    (1, 1, 0.33),
+    (1, 2, 0.34),
+    (2, 3, 0.0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM sensor_assignment_candidates;
+DELETE FROM sensor_assignment_reviews;
+DELETE FROM trees;
+DELETE FROM sensors;
+-- +goose StatementEnd
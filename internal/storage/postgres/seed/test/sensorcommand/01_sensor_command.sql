@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO sensors (id, status, latitude, longitude, geometry)
+VALUES
+    ('sensor-1', 'online', 54.82124518093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124518093376, 9.485702120628517), 4326)),
+    ('sensor-2', 'online', 54.78780993841013, 9.444052105200551, ST_SetSRID(ST_MakePoint(54.78780993841013, 9.444052105200551), 4326));
+
+INSERT INTO sensor_commands (id, sensor_id, type, status, report_interval, sent_at, acknowledged_at, created_at)
+VALUES
+    (1, 'sensor-1', 'set_interval', 'acknowledged', 900, '2025-01-25 08:01:00', '2025-01-25 08:05:00', '2025-01-25 08:00:00'),
+    (2, 'sensor-1', 'request_reading', 'queued', NULL, NULL, NULL, '2025-01-25 09:00:00'),
+    (3, 'sensor-2', 'reboot', 'sent', NULL, '2025-01-25 09:31:00', NULL, '2025-01-25 09:30:00'),
+    (4, 'sensor-2', 'request_reading', 'queued', NULL, NULL, NULL, '2025-01-25 10:00:00');
+ALTER SEQUENCE sensor_commands_id_seq RESTART WITH 5;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM sensor_commands;
+DELETE FROM sensors;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO watering_rule_sets (id, version, description, active, activated_at, created_at)
+VALUES
+    (1, 1, 'initial thresholds', FALSE, '2025-01-29 08:00:00', '2025-01-29 08:00:00'),
+    (2, 2, 'thresholds for older trees', TRUE, '2025-01-29 09:00:00', '2025-01-29 09:00:00');
+ALTER SEQUENCE watering_rule_sets_id_seq RESTART WITH 3;
+
+INSERT INTO watering_thresholds (rule_set_id, species_group, soil_condition, min_age, max_age, depth, moderate, bad)
+VALUES
+    (1, NULL, NULL, 0, 1, 30, 25, 33),
+    (2, NULL, NULL, 0, 1, 30, 25, 33),
+    (2, NULL, NULL, 4, 99, 60, 100, 150),
+    (2, 'Acer', 'sandig', 4, 99, 30, 80, 120);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM watering_thresholds;
+DELETE FROM watering_rule_sets;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO regions (id, name, geometry) VALUES (
+  1,
+  'Mürwik',
+  '0106000020E610000001000000010300000001000000AC0000000977DFC4FEE622406886B8084D674B404C63FC0170E72240B73AC3D525674B40F5F70064F6E72240CB908A2D2F674B408DCB9B6344E82240DBB817C833674B404C0980DB92E8224071CAB22636674B406260DE81B7E8224071CAB22636674B40AD4D6F28B5E82240803773D532674B400235EDEA91E82240BF4B3FFF1E674B40661658C94DE82240E8C545DDF7664B40696152EB43E82240716C05B4F1664B4070DE487933E82240CF1C661DEE664B40E3991B7024E822407EDC50A4D3664B40EDF68CFA69E82240D749FBC6D3664B40EF0F8BB066E822404A2B05AFCD664B40E3159E9487E82240733BB069CD664B409FA5037C79E82240E7E2C445AA664B405AA4B68AC0E822402966513AA8664B40E5E458881FE922405C43582DA6664B40A928AFB43CE92240A1BC76A3A6664B403C11822373E9224067E215E5A6664B40B9D475CDDCE92240431BB9EBA5664B40CF0A1AC6F6E92240FA72BA82A5664B404178AA6310EA22401A485CF2A4664B40DED09DC82BEA2240123F1F06A4664B4097884BC16AEA22402270ED689F664B4014E7E17E9BEA2240D4E6BD9099664B401CD8E79EB2EA22406326B07C9E664B40AD234DA6BDEA224043C6A396A2664B409FC22690C1EA22409A6E3820A6664B4015844FE6C6EA22407B57345BA7664B4005EB1855D8EA224039E0B061A8664B404C19AE7204EB22402CF632C4A7664B405FB2E403F3EA224056BF6EC3B1664B40F939F02DB9EB2240251A5BAFB6664B40F4C9CF37D4EB2240F669FD1EB6664B400B1CFC6DE7EB22401C503BA2B6664B40329BD4E6CCEC2240955FA759BB664B4067AF4D49D8EC22409DFDA194BC664B40870E08DDFBEC22408CB2D1F0B8664B4092371E7805ED224040A4B37AB8664B409E6034130FED22407D127460B8664B40F85F103D1CED2240A6FE12A2B8664B402669C6957BED2240BF9D1B04BE664B408B10D33060ED22407287A7FCC8664B4019BFCAD03FED2240F9773FADD0664B401C1363892BED2240AD316FA6D3664B40C5671F180AED2240685B3F78D6664B40A3ECDC46EDEC2240557BD45DD8664B404F4199D5CBEC2240E06CEF3CD9664B405B8637AECEEC22403169DBDFE6664B40AB0A30D84AED224082943777E2664B40C2552FAC7AEE2240B15AFD8AE1664B40218D1B517AEE2240F4781A01E2664B407AD5CC29C7EE22402A6FBBD9E1664B406BAD582BCDEE22409ED8639DDD664B40E05FE0B3FBEE2240C18D82BCCD664B4081D09E2138EF2240BC82701DBF664B4010E3A13360EF22408636E8F7B8664B4043E4861579EF2240705D3F3EB5664B40F9711210A4EF2240E2E1B264AA664B404BECBFACDAEF22407B16A06DA0664B40C033F89AFCEF2240CF7158B39A664B405C8B69D714F0224041DCB09994664B404E46FBAC24F0224062A11B748E664B40B2C428A525F02240A65EEB9C8B664B408949A0BC22F02240B19C410D89664B40D391B3F610F02240CEE121A082664B409E959AE200F0224060BE40AB7B664B4094E51AAEFEEF2240D14ED54478664B406F4267C401F022409F14086C72664B40897AA64707F02240F430606D67664B407DCA261305F02240B113DDC563664B402D677457FFEF224014DE3E635F664B409EE3C360ECEF2240245D6B2F53664B40237B0705D3EF224059D97E154D664B40FD92484DCBEF2240645E49724A664B40FD92484DCBEF2240F7A666A647664B4051C51C73F1EF2240362559E233664B400195392535F022404FDC4BF81A664B40F427D4E6D3EF2240C68A588516664B40459DC2029BEF22401D0C376310664B408AE138FE2AEF2240AC16CC100C664B406D64EEAC1AEF22401798463D0B664B409DF30EE943EF22408B0F7546D3654B4057BFE3750BEF2240F378A73CCD654B40F29EB1254FEE224078BB1616C6654B40EB81663D43EE2240E7E229A5BA654B40F3740068E6ED2240C6B89038B0654B40D8DAB7F3F5ED2240506E660688654B409439C1CCB3ED22408203501287654B4080268A81C9ED224027B3BD3A7A654B40045FF8BE9BEE2240B31177A884654B4043BD872A11EF2240FD8876D28C654B4061C387B765EF22407B94FD0F94654B4055AD98BE97EF22408A19A3EE99654B40BD88CE97FBEF224022FDFA95A3654B402C9C7CE10FF12240721CFE69BF654B4062D7A55CFFF122401598D2BFD9654B40A741179B09F3224049913C88FB654B407CA22B8A1DF422405C9FB5B223664B405F8750B49FF42240A966C6EA38664B40976472720EF52240884D664B4B664B4071CDEAA6B4F5224058EAA7C367664B404BA5460815F62240BFD7F73776664B40EE7A402EC2F62240055E3F158A664B409A0954356EF7224052F1D52E99664B40F683A8D411F82240F81A7299A3664B40167D158ABFF8224098EAA620AD664B4061629D08D6F92240203BC594BB664B4017404B73F0FA2240F3E8841DCA664B40FB9437E018FC224072D8E27CD8664B40B893890648FD224022564412E8664B40D10C30CC1AFF2240C5BC43CFFF664B401F4347511A002340876B64C20B674B40FC822D8D90002340EF79DB4F0F674B40EB9CD1651A012340741CF2BB11674B40BEA418124F012340D05BF4F911674B4046002B3191012340FD869DD011674B40D095D1828F0123400BE8C9A816674B4087AD132066022340C4E288EB18674B40CFB729A086022340BDE53CF819674B406B8F1869AE022340E29A581E1D674B405F62D622370323405D10D4E42B674B40F31821304A032340D3F70AEB32674B40FC0BCF4305032340E06AC0D54C674B4008500C5EA9022340B87921B14E674B403431CADD470223409379982E54674B4094084C0E370223403700A41E56674B405266C0682C02234096E8CEA05D674B4055E545176F02234032B2A92879674B40AA9B238C49022340CF54B1A393674B4089FEA2264F022340FB7B1D479C674B400739118A7C022340E408D471A1674B40B0823315A20223406FA0E139A8674B4063D6FE87AF02234031F79174AB674B4008A4D148A5002340DD0E707EFF674B40D35C7E916B002340B9E09D750D684B40830F6C383FFF2240CBBA371928684B4065958431D4FE2240C46A13D92F684B40C8A69A947FFE22403100BB8E40684B408BA62DFC46FE22404646A42752684B4026223075BBFD2240E088926738684B40F77CFBCA53FD2240D84D8D4E48684B40BA7C8E321BFD2240510521054C684B40D332FF0EBDFC2240687DB2F94F684B401C60AEED6CFC2240617E598A50684B40593225DE4BFC224009ECB03750684B407C20354A2FFC22407ABBB8014F684B40DF48468107FC2240510521054C684B40788AB4C7BFFB2240B6AE2D1B63684B40FCB7D03EA8FB22407B8934C577684B40CE40923C9AFB2240F10B1F1BBD684B406BEA8A5D68FB2240E7E24165E2684B40471376C5B1FB22406D00C09FE5684B40AC80787810FC2240925E5C75E0684B40A376CF9028FC2240C380AF9EE0684B40CC62EA5991FC2240B52ADA78DD684B40F82CAD0503FC22405B815A97F8684B406B2F7CD9EEFB22401088BD0003694B40C89E732FC8FB224004BF0C2128694B40B6B8170852FC224015FB56C628694B40FCBFFC7151FB2240F3AF76608B694B4034FA4654C8F62240FFC60F8990694B4079C4AD9BB6F222404F0B5D848A694B4099B10A6B01EF2240F664122C77694B4012BE6ACE64EC22409955B70A1F694B40CBA3C69A3CEA2240AA8338B0AF684B401A5EDA8A83E9224035CF599791684B40B3405E7E02E82240E71738B734684B403E8CDF2482E72240F47CA87411684B4033200A9928E722404CCC3DD7AF674B400977DFC4FEE622406886B8084D674B40'),
+  (2, 'Fruerlund', '0106000020E6100000010000000103000000010000008C000000CF691D8EFEE62240BE517D1D4D674B40153D1DDC6FE72240AC9A04FE25674B4039650E10FBE722407781037F2F674B40D063E2C148E82240341F69ED33674B40890DC58D7CE82240F888459E35674B40B99C9378A9E82240DEAD883336674B40CDD1BB3AB7E82240A273AE1536674B4025478C6BB6E822406FB80A3834674B40856C9565B4E82240E210417832674B4039E95C4E44E8224091F1530AF2664B402D14A61E34E82240AC6B1240EE664B40CCC91EBE24E82240C80E599AD3664B4089987FF069E8224019A60ED6D3664B40E9BD88EA67E82240ADFDAEB6CD664B4003182F1387E82240C314314ECD664B40E632CE877AE82240CAF4EA15AA664B4092EA183A1FE92240E4E0C02DA6664B4047B886D535E922408E98D589A6664B4051EBB7BE42E92240BA2AC1BEA6664B40311B1BD669E922409CF336D9A6664B40BE7BEB7677E9224000D871E6A6664B4098084CA4E6E92240AE149DD0A5664B40C379D2D918EA2240C77652A0A4664B4091F94FFD28EA2240747A051CA4664B40E1B727263EEA2240E0DB7FDEA2664B40878F15E769EA2240BA30DA5A9F664B402F2E3BD49CEA22409C94275C99664B404383E258B2EA2240006BA1A19E664B404B009763BCEA224025985BF0A1664B40506C9020C2EA224091EA2462A6664B40D34FACB6C5EA2240CB150E43A7664B40A4E0E427DAEA2240028C5873A8664B4048B8D2E805EB2240ED51AA9FA7664B40C41E3A74FFEA2240E349B4A0AB664B4000518486F3EA224070A3C9B1B1664B40B4DFAD5096EB22409A74983EB6664B40C04C89D3B6EB22409A12C3AEB6664B407198DF87D4EB22400701B624B6664B4000E7A943CFEC22401A3C9780BB664B4083B819EBD7EC224063756F83BC664B402879C44EEFEC2240883C3516BA664B40301CA49D00ED2240725332A3B8664B40455917240BED2240CAD12B5EB8664B40DE8E16371CED22404983919AB8664B408E8FE4687BED22400BB3B307BE664B40F745D61160ED2240C194B0D1C8664B40A883250440ED2240F5CBAFBCD0664B40197FE9062EED2240FE92D3FFD2664B40CF32DA8314ED2240FE416C97D5664B40E39DEBE1EFEC2240DF9B042FD8664B40F708FD3FCBEC2240FDA77438D9664B40DF0909D9CEEC2240BD9B84FBE6664B40C96E5C8049ED22403BFB5181E2664B4052BC58517BEE22407289149CE1664B4052BC58517BEE2240A87E89F0E1664B40AB43DE37C6EE2240A87E89F0E1664B40228ED588D8EE2240F34810F0D8664B40C5AF466B01EF2240B0343512CC664B407D155B6F23EF22406C293427C4664B40C4616AF23CEF2240F1F7BE12BE664B409DB2B57268EF2240E3B804CEB7664B404A2C87617DEF224047355B75B4664B40DFA664E995EF22406A4246A9AE664B4041ADB818AFEF224039CEDEEBA7664B404990CE6FF4EF2240B8CACD6B9C664B402026C9AA1CF02240D916B15592664B400F9D76CE26F02240C5B84E598C664B40F227E1DC23F02240A3D08AA989664B40557C118501F0224069246E0A7C664B4051C1C03FFEEF22405EA467C077664B408D663C6807F022400559530E68664B400C0994C505F022404897E27B63664B40A7024096ECEF2240BF27145153664B402D42A7BAD3EF22400E118E484D664B4040864ADCCCEF2240A80406C94A664B40A66E5D8DCBEF22402FFED2D047664B4078490783F0EF2240D83BEB1C34664B40B4A0A63235F022400F89F5CC1A664B4045FCEB66D3EF22402708B86A16664B40DF61B52E99EF2240002CEC3D10664B40C0CD87E91BEF224042E2DD4A0B664B4057EAC19B43EF22409BDA262AD3654B40D1F770910AEF224009CECD1DCD654B40E43DCC554EEE2240377E58FBC5654B40FDA655ED42EE22407B4496BCBA654B404BCDB870E6ED22403724A61BB0654B40C9DC5B20F6ED2240D31B03F187654B404598641FB4ED224018DDFE0787654B40085490EBC9ED2240308FB63A7A654B40B2D79EFD8BED2240CE5F6F3C76654B40AA4697996BED224020A6CA5B73654B40E79478E93AED2240D9524C406D654B40606FC74718ED2240A9F6B7E067654B40EE7F3FA600ED2240C93DF40F63654B40DFC0075EEDEC2240427EE4055F654B40FAE05E5EDAEC22407A5B184C59654B40E66A59ADCEEC2240E4FAFD3255654B405B246EF243EC22403A0CFCA65C654B40E06C439788EB22405ADD6EFE64654B4052CB3D86F3EA224042CBD7986A654B4071E0355B07EA2240BDD6705671654B40FBCF73A087E922409EDF65B273654B405A3D6F6CD9E822400BCE4F7F74654B40FA16886733E822402C18428773654B406549132870E722406B56348F72654B40031C08B68AE62240A6E3A74B71654B40115F7ACB28E6224059544AC271654B400AA0BC3489E52240B858D6F076654B401F8A97EA62E5224050B33E0978654B40476F7EDF3AE52240E71D4F6A78654B404D83981705E52240E8462EA877654B401EF02415B2E422404BA5025775654B404F59C08888E32240B3C7A9C96C654B4078E1BB48C5E222403B373C1067654B40E64D96F389E22240A76D26C465654B40E12802F403E22240A42F0FF364654B40D4A1E49EBEE12240243FAB1564654B40B033B6498EE12240BA41E35A62654B403B7F494AD7E02240C8F947CB58654B40125C2AF593E0224011ABE67C56654B40D702BF4A45E0224076E1B34B56654B4079A932A01FE0224076E1B34B56654B40E8CC93F510E02240DB1340285C654B409BABE94A10E02240F39792BF63654B40A11335A01CE02240F5929E6868654B40DCB383F524E02240EAF6483E6B654B40AFE135A01BE022407BBBCB1072654B405F0B9BF507E02240C99746547A654B40061A5AA0EEDF2240D1E10AAF97654B40DBFA66A0DEDF2240F83BE0C9B3654B403B9BD6F5BDDF2240B4751FF3D0654B406EA805E38EDF2240443BBD6620664B40F090EA0955DF22402F538B295A664B4067BCD00493DF2240DC6D172E8C664B40EFEE566E3CE022402B8B76A1D3664B406F35A8F9E9E02240E2D18491F5664B409B00E70249E12240780F413D06674B4092D08AED1BE32240D02F3FD122674B40679FC9F8FAE622409C84908B4E674B40CF691D8EFEE62240BE517D1D4D674B40'),
+  (3, 'Jürgensby', '0106000020E61000000100000001030000000100000060000000C91C87BF25E02240D975AF4E56654B407DA362CC83E02240B7AFF12E56654B400B7C11DB93E02240CD3B6D6E56654B404331A986D7E02240B331EEBE58654B4094E2FBBA88E1224025F4830B62654B404845C121A9E12240147F815363654B408E85837DBDE1224007155F0764654B4042BCB338EAE122408134D1C564654B406E9344C359E22240272E1A6F65654B403926D96391E2224095567CE365654B402D1C3D97C9E222407DF7BB0B67654B40FDC2E9D2D2E32240F4CE90F06E654B40F0776C69C7E422407C0EC80176654B4014522E1309E522404D9191B377654B40AA5341AE3AE52240C55B1C3D78654B4039D4C56563E522403D45CA1278654B40F89C847791E52240F00ACECA76654B409EE9FB84D5E52240C1A3A2A474654B409F19C4B727E62240851413C771654B40FA2CDD0F88E6224054A71C4871654B40FCBC35A87EE722404824C9A872654B4015D1544A15E82240006A486E73654B40A0ECE1BEBCE822404D67FE4F74654B40FA1FDE35FCE82240B485507A74654B402AB8C3E132E92240CFC6195E74654B4029E88B1485E92240374AD1B473654B404F064783E3E9224081FA9B0D72654B403F7F4F5A71EA22401E9E8C6A6E654B40A9392A032AEB224059BB86BD68654B4035957DB58FEB2240B05F9AA964654B40882B3BB145EC2240B9A0DA8F5C654B402A8BC8F1CEEC2240B0AF451155654B40AE291301C5EC224000C943FB51654B405CF3E56AB3EC2240BCDE67C947654B40BFC0EF3DAAEC22405F56607D3C654B408BCB9154A8EC2240130E9FFA36654B40CB8AC5CCB3EC2240C13AC13F2A654B401DC1F262C5EC224030E4766220654B401247C825EDEC22406F8AB3C114654B40A18F05A50DED2240DB5464790E654B402C7BCAE662ED224079F1199401654B4061306292A6ED22408E55849AF7644B405B635BE4CAED22400A384EE1F0644B409F723AAE07EE224082E34C1EE1644B4032A8879827EE22408A705F7CD4644B40E0D36DCD27EE2240A959DE5ED3644B407CF13D1F76ED2240C5C359D9C8644B40454D23E045ED22402A98BBBFC6644B40566D7D8B85EC2240B0E1FFA9C2644B400A2A0BA671EC22407D13415CC1644B407265F8F132EC2240C09BC0E1C1644B407446617D27EC2240F5EBC088C1644B4038965D45FAEB2240DDB07EE1C3644B40FDC6C298C1EB224094C0887FB2644B408ECD8B8D44EA2240BEEC08EFB0644B40A53CA34DC6E82240ACBE03F1A0644B4014E42077BEE82240DBD9853EA4644B405EF2AC4342E8224064FAFE609B644B40E9FFF95C35E72240E852B54B93644B40DBA171C8DEE72240E5498D5D58644B4094ABF84BF6E72240D60E3C7454644B40EA5377EAD0E722407325762653644B40FC8CE0206CE622400CE1813A3D644B4031AC6F1561E622408A69C9083C644B400B824A035FE6224079C7C26940644B40357AD57845E622404ADEFE3E4E644B400A3A3FF6B7E52240DF14B4424B644B40C0E5F4D1B3E522405C523B404D644B40454F12279CE32240B0B1DA0340644B40145A630F38E322406387BA243A644B40B4B710ED16E32240C35E908F36644B405D3CC24904E32240D238B71D2B644B4023AB476BD7E22240A5FAC52822644B401244F29EACE22240369297931E644B40F883FEB657E22240EEA0BE4F17644B400D2E812E21E222408655A55413644B403385627B36E122407E97D73E10644B405F6AB01A39E1224098970DE318644B40F86EEF7E37E122408C1B1DF522644B4044694DB22CE122407F7DF69031644B40A97B494326E122409483E5C43B644B40B1DF5078F6E02240C2B319005C644B40D2343818BFE02240FB9154257F644B4053EA972E99E022400B95FA0195644B406D94B3AB5DE0224044F8126DAC644B4017A8C56730E0224075703B7DB7644B405AC0168801E022407B4B0D41C2644B40DA5570A3B3DF2240B0762953D1644B4027BB8D035DDF2240D544E5ECE0644B401ED980F038DF22401B4DD16AE7644B4073B5EB3652DF22405A72D65BEB644B40BFE1AB097FDF2240C55DFAF9FF644B40272381267ADF2240779559D516654B40F5A52B6070DF22407C1FA8A025654B40CABFE1E8A0DF2240C2407F1A56654B40C91C87BF25E02240D975AF4E56654B40'),
+  (4, 'Sandberg', '0106000020E61000000100000001030000000100000085000000826A98E334E12240E408646D10644B40578D469628E12240DB2F894F03644B40A0B31D8E10E12240082C898BF8634B407DEFCBCBEEE02240F7A21839EF634B40A4D01306C3E022401B0CF6C6E5634B40AA6CCC8283E022407FD6DCA3DA634B408729B8BB53E02240F12F1C3FD4634B4011A9476835E022400FCD69A8D0634B403D03E1C36BE02240FCF0BD22BA634B40E0AEFFA276E022409269FD0CB2634B40A2A1283679E0224064DAB22BA6634B40A6ED097E75E02240C31EA3949E634B40A0A3A32C5DE02240DF3E34E58F634B40044466954EE022401AEB229D86634B407C1233274DE022404FD943F182634B40D04D14BD57E0224014CC9C5F7D634B4019F132C369E0224022C845AE77634B4085D9E0868FE022406EEC06496D634B40BBCDB768A2E02240226632046B634B404B495128BCE0224034C0939269634B405016706BAAE02240A25C005560634B40614870817FE022403712267B51634B40044466954EE022401FCCB83C40634B40CF0088050BE02240C922AE9D28634B407E83882E05E02240F2E230CE26634B405907C8BA0AE022405E3FDDAD22634B40E91EB47BEEE02240D0B398D310634B409DB260B0CCE1224069448111FC624B40876A0A99CBE22240976E18F9E6624B409D97C5C802E322403E13F8B2E0624B402EBE40433AE322405FB03DE6E7624B40CA90C6B1AEE322401124C364DB624B40B846DA493BE42240B8BC7939CE624B40AA5EAE80C3E422407000EB56C1624B40EA2DC9EDB5E5224091736E71AA624B401362FE7031E622401C5B03C29E624B408B5E41470FE62240A1AF433095624B40E7EBA101C0E622403E82130287624B406768A8D82CE72240515133E47E624B40E2202E72A2E7224042720F2A78624B4094F514580BE82240FF079F8D73624B40FDD8CB9573E82240B598708570624B408F5B0198EBE822401FFD7EB06E624B4001297AEF3DE9224078E011886E624B40F57063CD8AE922407319ECD86E624B40E0304B30E9E922405C232F6D70624B40FC22D2C550EA2240358B00F472624B40564FBD093FEB2240D3EE262A7B624B405151535AB1EB2240A3056AB67F624B40A2690F07DEEB22404E995FEC81624B40FBE5A6E9D9EB22407E3C8B1E87624B403FB2D55CC7EB22404C4CFC0892624B40065E006CA3EB2240F0C583EEA5624B40D403958D8EEB22405A364422B1624B4068BD84DC63EB22404F29256BC4624B40AEFC324E53EB22402FEACA2FCF624B40544A601643EB22406064CCA7DF624B40D655F0573DEB2240E29175EEED624B40A9E5E6B840EB224032BF5CCBFA624B40AF1353D147EB22407EB1FD4A06634B405A61ACAB55EB224028F62CA511634B40E083888262EB22400EA6C0761A634B400381C57F7AEB22408897C80226634B40EEB14CBFA5EB22404F18D2D136634B403ADA32D2DCEB2240F672142447634B406D33741221EC2240248B89E657634B40B8724E962EEC2240C22F9E625A634B40E0EC145B3FEC2240CE2AB9745D634B40D8835C6F5AEC2240245D8D4664634B4065B1FDDE7DEC2240D69EE4346D634B40114DE7DD8BEC2240B6D410A171634B406C02DF79A5EC22400001929279634B40619EF72EDAEC2240DE8825858B634B40260179DF03ED22404E70C9D29C634B4089B2B9B718ED2240E616A8A4A7634B4065873ABB31ED2240A70069EAB7634B4086892D7847ED224065766D89CB634B40B6C2C47C52ED224096793CCDDF634B4080D5636D4CED2240B825A4BCFE634B40931505AC4BED2240C13BCA000D644B40D775390749ED2240617FF79E17644B409DB5554B4BED224029CC077B29644B40E7D4597852ED2240A3A951BB35644B40DF1258DD66ED2240C7C275C244644B400771E95E79ED22407631D9F94F644B40B0CDE1FB9AED22404A0C34DC5D644B403288E61FD2ED2240EBE5971970644B40B2831B72FFED2240B6BB274081644B407E60568C1FEE22409BADA4A890644B40645EB3B234EE22401EB2B1C79D644B40983D16A13CEE22408C95B16AA7644B4026BD4E2941EE22404B7AB068B5644B405E7D32E53EEE224005B25493BD644B401CA53C1437EE2240B6716249C8644B40B6A5B11828EE2240E6AB9380D3644B4003DD2DF174ED224002CCCEBAC8644B40F8AE5B3C46ED2240A475DDB6C6644B40CC20BB7082EC22408A63E285C2644B40E7F4986D71EC224040E6AF51C1644B40AF9A6EFF2DEC2240773C7EF1C1644B40B4C4160725EC2240D966EE73C1644B408B411C6AF9EB2240B4F0FB09C4644B403468210CC2EB2240F7D5FA83B2644B404AF723F944EA2240A260B416B1644B402B067E1DC5E82240348BEAFDA0644B4042B0B312BDE82240B0A5DE6CA4644B40589616D542E82240DDB7D4A19B644B40AD71091F34E72240D407AA3693644B407D5E7B4BDBE72240FB83B52E59644B407CE72754F5E72240D5F0179354644B40B3102E39E1E722400523D5D453644B407ED5CE42C1E722406C12423252644B40AC7A462B13E722406EF306B547644B4065BFD3326FE62240FCF0B4663D644B40D1AF253768E62240BC7C38FA3C644B40BD5F65915FE622409BF35DE23B644B40CC5DABA15EE62240FC8387E840644B40BDBD89D444E6224080B7CC9B4E644B406039388AB6E522401F7CD7414B644B400E59A184B5E522406EF2AC224D644B4096686534C4E4224037F5FA5C47644B40B0DCF7DD54E42240EFCBF65844644B4024785081E3E32240B81677D141644B404CD967A79CE32240BF2E321140644B401547D1CF3AE32240E5E5C46C3A644B4035FE7D0C17E32240F9C96ABA36644B40AF873BCE02E32240230C975F2A644B40E38022FCF5E22240EC0509DF26644B40F1A0DFD2CCE2224087A2C50821644B406791EDEA49E2224022F28E0A16644B40C4621ECE24E2224048A6D8B413644B402B66A76979E122408F783A4611644B40826A98E334E12240E408646D10644B40'),
+  (5, 'Engelsby', '0106000020E610000001000000010300000001000000D800000073B49BD390012340E8E2E3F811674B4004852FED910123404D4C97AC0F674B40B07EFFD6EB00234005CB71140F674B40F77336B8B0002340D83B1B8307674B408335170D5F0023408C231AF506674B40A406147442002340194B85B005674B40EBD2BDE72B0023404088CAD303674B40E7BCC25CD3FF22407FB3AC9FF7664B4030672729E7FF22402C89F7DEE1664B400D9BA6D2C8FF22400001925BDE664B403286AA12490023400649D564D2664B402AC3D11DC9012340FE98C85FA7664B4049A666E8E201234006728A6FA2664B40FD6CBDF7E3012340D4B9CB429E664B4085E8D842E30123408D501D309A664B4010FE5D8CDC012340787F36C192664B4001120058C101234082742A1E8B664B405B4DB940A2012340CCAB97B885664B404B0993FD6B02234006D9636A85664B4081018097B10223409ECEB3DE82664B407BC2D338DA022340DF87F3377D664B404843C577E4022340D067A23A78664B40195E20B5E8022340467D5E546A664B40FC091C87E502234001D10E6362664B404BDD2E76DE022340BC7445BC5C664B4071FF6F9FCF022340D2D0FDD857664B40F885644CB20223403EC9751454664B403B0783058A0223400FDF6E8D52664B40D629F1A848022340EC565D7352664B40AE8ED1A6070223406116819C58664B40B5CD7D05DF012340F544832257664B4024BB62A86201234008390E1549664B40AAC8787CF5002340B0BDE5EB42664B40589D12DA5600234047242D1633664B402529DD7A44002340EB06617030664B40F3B4A71B32002340EAFAAD7816664B40EF25177F1B0023402864770709664B40400403D0F7FF22407A59451DFD654B408C488522DAFF22400D51E76FE5654B4021216E05DEFF2240EFB1D16EE2654B40998FA0F61700234066675932E3654B400AD5D84C41002340B40E5CC5E1654B402D52D71555002340229750A3D7654B40C10935D3AE002340217D4F2BB8654B4026FD78F3B60023406CC9575EB5654B400893C201ED002340309EFD80A9654B4029EF35A556012340E91A9CFC97654B406AAA86D8A60123409B36A3288E654B40BE90E19EE901234024C3B2CE85654B4021BE57396A0223409EF51B5D78654B40D80866839E02234021FD283D74654B400D01531DE4022340422F514470654B40EF969C2B1A03234034377CEB6A654B4072C832B65E03234020A5694A5E654B40158D79CD7D03234016EB709D54654B407D25004E63032340BA2D253B42654B40379C721F2503234006D2A3E437654B4083E0F47107032340013B867D2F654B40D1B3076100032340F0103FE226654B409816D866DD02234076D4715020654B4075AF8B6190022340B1BAD74C17654B40D2F51DAC54022340273143560E654B40C06F56793F0223400A2511A208654B40DE52EB435902234077D1A39F02654B40C71C084F83022340D77B3D31F3644B40C71C084F830223407972F062ED644B400D2DB7A4710223408DFF6CA6E8644B4055D7CFF859022340551DE08FE6644B4023639A9947022340E8035379E4644B40FB904EB1390223407853C703D8644B4070061AC851022340A4853697D1644B4063F9E61A4F0223400A61144FC6644B400A0C7C694502234030F6DD0FC4644B40BCAD9E28370223409ABDE789C1644B40C7497FA72402234098D9F5B2BB644B406CA50460A9012340D78CF6D1C8644B40102A849E900123407D75C91BC9644B4073301F1E8A01234056608498CB644B40B27BB19A510123407FE40F6DD0644B40BE4376571C01234036DF1ACED2644B40F403EE150301234073F27805D3644B4097551B12C4002340B4DF80B4CF644B4091C3924F9A0023403FE366CED6644B40FE76D40E8E00234074B002DAD7644B40B666678D76002340AC5F2608D8644B40651AB5C1B5FF2240A97D2648D0644B40CCA561AF87FE224097D6EF09D1644B40F14836ED63FE22400E4BF991D0644B4089F42AAE73FE22409C3CFB83CE644B406BFEFCAF91FE224099E85F05B4644B409D0D4AE2AFFD2240C9C85DE9AF644B40A3B4E7D1A1FC22400EA58367A7644B406639EDCA2EFC22409956E55BA6644B4076FA4383B0FB2240C03D55019F644B4065CCDA4199FB2240BB3CA328A0644B409DC9FCFE69FB2240272FE29EA7644B4075D149FD4DFB2240E683FA87A9644B402E871C35C7FA224061BCEC34A9644B401E59B3F3AFFA22400391B5E8A7644B40C4E78AEE5AFA22402DE5E89196644B40E4F8D6690DFA22400522078A8B644B40C5ADCAA7EBF92240BE55033988644B408A987425C5F92240C35FAD4686644B40D9EFE3618AF92240C852824D85644B40ED386B2072F9224090BECFDE9F644B4041222E3F4EF72240591904BFA5644B40176B74A8D7F52240C9DECDD1A9644B4074A5C61E38F52240AFEFFDA3AD644B4069EEC7227AF5224051DA2DDED1644B40D253992277F52240B218D815D6644B40FE44DE1F4AF5224061F6BD6FD5644B401A3B0C1E2CF522409B2893ABD3644B40996E764AE9F3224035CF7EC2D1644B408A32310040F32240A9A4339BD0644B408A32310040F322406817CCDBD0644B40A75BB14048F32240B035F2BBD6644B409D48667C01F3224022F00E54D5644B4042B5B1F592F2224071F27805D3644B400A5F2D28B4F122402C4CAA9FCE644B40949C295504F12240F49CBB19CB644B40D79172BE91F02240E4800F50EF644B40AA3E825143F02240279BDF3BF0644B40D20C94559EEF22405C734EF5EA644B40803E11A1A7EF2240B710B9A9E1644B4030C5E9E2A5EF22408C8B9EB2D7644B40D461EF4B93EF22405282BE65CD644B405295D72043EF22404F24812AAF644B404C54CD17FBEE22402E9AC51D94644B4003D8DB0296EE2240A8AA8CDA6F644B4009D441A38BEE22400BF029606A644B401F61DD2F5FEE22400A5C4EA56B644B4039C8F62744EE2240C60780C56C644B40A8C00EBD34EE2240492CEAD66D644B40122C888422EE224050D4F6EA6F644B40C567E7E40DEE22402F28B9E673644B40211248A8EDED22404132897B7A644B40CD3445E204EE22408F37F3C683644B40EEB676EA20EE22407A5FFA5791644B4059494DBB2EEE224067D42C089A644B40B267EA253DEE2240184E17BEA7644B40A3804F8D40EE22405FDFB536B7644B402D947CF138EE2240AF539D56C6644B401A46C51F28EE2240BE5C3096D3644B4037C71BB312EE224067DEC60DDD644B40891780ABF8ED2240223B75B6E5644B40900A603DDEED2240BF0E59C1EC644B4014B77068C2ED224014BCACA4F2644B40CA328FF8A1ED22407AAB2919F8644B40B2A33D826BED2240F406CD3C00654B40AB88257234ED2240961B6D6008654B40A769F60E05ED2240C4E369E60F654B4022DE75B0E5EC22409D9160C716654B4005582D55D1EC2240206FACCA1C654B4017EED1FCC6EC22409264CADE1F654B4055A8914DB7EC22405FE6216C27654B40CD47854BB0EC2240E5B46EE32F654B40EEA2594AACEC2240F7FABD9E31654B4089990AF4A8EC2240C24EE4EB37654B40EEA2594AACEC22403576B38A41654B401CBF464DB6EC2240069A54E949654B409389AEA7C7EC2240EE66B6DB52654B400087B804E2EC2240F92BF6175C654B4074787D6813ED224012021D2867654B4001F8B8CD49ED2240D9321D496F654B403137EC867BED2240A16EBD0E75654B40AB208344BCED2240D087359479654B40BD08F951EAED2240307241E37B654B409B223C6F4EEE224014E439CB80654B4093A3988497EE224021347A3584654B40F1508AA60BEF22407B177E318C654B40686595C064EF2240DCE2EDEF93654B40FBB545768AEF22406C8B6E5098654B401E7E03EBF5EF224012AAE322A3654B408CA26A5130F022405395D20AA8654B40FD0847D1C1F02240C4236931B7654B40B125CC4E4BF12240D79483D0C5654B406A13C61CC4F122405C6DD216D3654B40DC565CE62DF222402F74B8B0DF654B404C9E090390F22240E6CB16E8EB654B409AFFF93C56F32240D367FA1106664B408B20E2CA17F422408B82D30523664B40B1C1423663F4224057C83ACE2E664B406CAA2D9CCCF42240672ECC5340664B40FB762BFF2BF522405B9B214750664B40010A7A69A4F522409EDBC4EA64664B4051FC9003CDF52240D913169C6B664B40A764EECD08F62240A6BB194474664B40C60D7DDF44F6224016B5650A7C664B4036D6BD108CF6224081D1032D84664B40BA889B78CBF62240550554078B664B4089E4E4CF02F72240E163C03290664B401E82578351F7224004840C0D97664B40B9C2ABEC95F7224022E9AE199C664B4095371D2AD6F72240B9A69D44A0664B40F5425CAA07F82240D724001DA3664B403F366B5947F82240BDFCC8A3A6664B40091C6E806BF8224023D4BAA4A8664B401B2313F1C8F822408DC44F88AD664B407F1CCFFB4EF92240D533DD95B4664B40EE8AD921D1F9224042C0DA65BB664B401FE0DE838EFA22403527B822C5664B40BEB4796C62FB2240C7D3BCACCF664B408FD1A4C80BFC2240C32C8402D8664B40A0F64988E5FC2240B03C1D1CE3664B4001C36A0D89FD2240E36C9A67EB664B40C998DAE82FFE22405ED65EE6F3664B405981E870DDFE2240958B30B7FC664B405971552560FF22409D1C473F03674B40A60936B4C3FF22403621C62208674B40F6728B9316002340D86BBFB30B674B40D830A87B870023409E82A7F20E674B40103B1209E600234033028FF310674B40DD69C0DA3A012340AAD0860812674B40C28113CE70012340EBAE02F411674B40278B62247401234083E1C81212674B4073B49BD390012340E8E2E3F811674B40'),
+  (6, 'Tarup', '0106000020E610000001000000010300000001000000DD00000035EC7C7986EB22404B3260CB84614B40C50F41B56FEB224048B0EE6F89614B40DC41839860EB2240CE879A9FA3614B40B76C728C2EEB2240DE32AF1CC2614B406D8B0C3B15EB22400EFD343CC4614B400DA218660CEB224071AE6CC6CD614B404723997BFDEA22406ECC3385CD614B402CF630B792EA224015408265C8614B406188309F6DEA224015155C76C7614B401C7D16EE52EA224013EDB4E0C8614B40CA43811B3FEA2240B0EB7241CB614B408F2F9E5268EA224009149811D3614B40518858D6ABEA22404389A9EBDF614B40BCE2B0B9C6EA2240591F8786E5614B40C5039C010CEB2240A244BC37F7614B406A225EE730EB2240E6420D3D01624B409CFB35B55AEB22400CD527E90E624B40214D9D497BEB2240EEAD214F1B624B4098D5704E8FEB2240A44C6EEC22624B40C11330AEA5EB22400BBAC31A2E624B400649FEBEC1EB2240E6A37FB33D624B4079FB8523D7EB22404376D12C4E624B40645FD603E7EB2240D773733D6B624B40187B9658E1EB2240C8C17BEB7C624B402EA11E8DD9EB224086664E8C87624B4033CED656D0EB2240BD0C5B458D624B40DA1D7F0AC0EB22400EC45EC696624B40FA9D77D2A5EB22407FB2A643A4624B4016F1B7D094EB2240370AB718AF624B40D893A08273EB2240C8FDB2B5BD624B4090DC18A164EB22407506225AC4624B40103A217F51EB2240D52F212FCF624B40D9E491FA3FEB224002C0F403E4624B40343AA3483DEB224010A0261AF0624B400F18972B3FEB2240401EBB95F9624B408AA05FCB47EB2240568199A406634B40304B4E7D4AEB224002369EAC09634B40FF39B9AA61EB2240C3B58EAF19634B405CC2FF807EEB224081A3722E28634B4034D30EF7A3EB22409EE7DC3F36634B40F6C1453AC3EB2240EEE28A5040634B400D39A95E03EC22400211CCB650634B40E338F3AA1FEC22406C17DE6F57634B40B138230240EC22405923A79D5D634B40A27CB5DF78EC224010B9D1C26B634B40B6C0E37B99EC2240055B72C975634B405D759DC2D7EC2240D12D19CB8A634B40D05534F505ED2240DC66990F9E634B4079FDC51021ED224068C395B6AB634B402094920233ED224059A0BC14BA634B40638EDC683BED224064AF68A6BE634B40A68826CF43ED22406435DD0DC9634B4069B066BC4DED2240131043D3D5634B40AC4FC41453ED2240ECA2C97EE1634B402B22CE8D51ED22404D7326B0EA634B400A25A95A4DED2240EF8869D3F9634B40C9E037104BED2240EB1DD6E30A644B40E927842749ED22401E7887EF17644B400BCABC4C4AED224041B2EDA126644B40CC3B241E4EED224071FB5C5B32644B400F366E8456ED224070F74CF638644B40B4CC3A7668ED22403EC03D3A46644B409C0265C07FED22409613E05353644B40A890A04B9EED2240673A1EFF5E644B40CFF976B6AEED224027B9D24764644B40985A09DBE6ED22406D5427E477644B40977ABCA3EDED2240EE688A697A644B401AC3EB2A1BEE2240141BB71671644B408FDA3B2C26EE22403BC5BE646F644B4006123FF637EE22403FC643866D644B406878AFAB4DEE22403E6B42546C644B40FD23A03C64EE224088CF53826B644B403628F39672EE2240709781FA6A644B400B83EA3B8CEE2240E13A65546A644B401EB924C195EE2240DC4BCD976F644B40A98E9D75A8EE22402598ABA276644B40B0392C70C3EE224041B2AC5980644B40E9F8D99DEFEE224029107D7790644B4043075FAE16EF2240E54C78A89E644B409FFE742046EF2240F23200F1AF644B407A48902277EF2240D3B931F6C1644B40F100E2F595EF22401C78463ACE644B40E0391796A5EF2240268D484ED9644B405D8C1C26A7EF22404DD84C5BE2644B40C45CA4319FEF2240932212B6EA644B40B5283DB8D9EF22405CDA3ED7EC644B409038624244F02240F76F7D13F0644B4012C89D3D93F0224080C94A4CEF644B4097406A9AEAF0224002680F5ED3644B409601ACBD04F12240E0793818CB644B40F330303662F12240B48780FACC644B40348809AFD8F122401959985ACF644B401F97679F61F2224079C78723D2644B40A7303364EFF222407ED1B4F1D4644B401EAF8EBF47F32240EBFE03BFD6644B40847F16CB3FF32240A11DD2B9D0644B407360967ED9F3224033ECFD95D1644B406D36BFCE52F42240E6B2EB6CD2644B4013ADFDF7E6F422407F6B9353D3644B409053A8912DF52240A3476BBCD3644B40DCD9201F4AF52240D999C274D5644B40C578A20277F52240B4520612D6644B40E5B00A477AF52240C0CBCD13D2644B400795CD8138F52240E2C0609BAD644B400525FE1CDDF5224093A275CDA9644B4078CEE4C22BF72240DF846429A6644B407579CB9EA2F8224075116BFEA1644B40714A35FD71F92240116001BE9F644B409F44F8EE8AF92240B057744985644B40FDE7AC25C7F92240491F2D3286644B4003117B46F0F92240CC8CD88C88644B409A1B6D8A11FA224085B90BDD8B644B4059BEAB1E5AFA22403FD18E5396644B4009E8F636B1FA22400FCAA806A8644B40481E6168C9FA22403BC21B82A9644B407AB480E350FB2240F7CF6D98A9644B40A2A0964A6AFB22400FCAA806A8644B40210D6BAD9AFB22409EC07B17A0644B406D68FF43B2FB2240317E50F59E644B402EE042E231FC224012C787A1A6644B40D13CD167A5FC2240D26BBC80A7644B40AA584D89C9FD2240B1E21092B0644B400904518C93FE22402089853BB4644B4066D434E372FE22400A9384A6CE644B4096BCA68E62FE2240DB248E91D0644B40B22FC9798AFE224014737917D1644B40CBD578E3B8FF2240717C984ED0644B40D3ACF4D379002340AEE50E11D8644B40499C31FF8E002340617BC7B7D7644B401BB4BF539F002340F9090626D6644B40404C0CA4C600234021D2C142CF644B40CA894155040123405789262FD3644B40DB2B03F21E012340F8483BA9D2644B4030042FC05101234073994638D0644B40E5D50C068D0123406340AE6CCB644B402DCDD58B900123405E57E32FC9644B4043E80F42DB012340F483DCDC95644B40E7E2BCA4EE012340CF7398C087644B4024B3309E03022340F80149B771644B4017268D161C02234045E66E5770644B40AF631169F301234056E60BFD4E644B40946462EE2C02234004CE96E54E644B40401895CA2F022340A88773F444644B4072467848110223407F1B8D2A3D644B40158E4AD2F1012340396DD0B637644B4004CB7621F9012340AC76BCFD26644B40C1417DAEF4012340946840E51A644B4040E80F42DB012340D81D38650F644B4030104F9D96012340D91AA36208644B40A391FB646B012340FFDB7619EA634B40282199FA9501234018A70F52E9634B404B2A96AC650123400A55E43AD8634B40ED56D0A13D01234000B437E2BD634B40C79AF8163B01234021D08EF5BB634B4095D4D2542F0123408AE8062AB9634B4036994F8E3101234065413E39B5634B40D2A4464E440123408B61B68AAF634B408E1B4DDB3F0123401EB061CEA8634B406DB03D0E5701234044C611B09F634B40249E43AAB7012340BEC6A1E68C634B403476044FFC012340D0052C3C78634B40E0F1FEF77D022340BB76DB715F634B404B52688A8D0223401849C5AB59634B405C153C3B860223407F8759AC4E634B406EA88A632A0223405EFBC3114D634B40C45C1543FD01234091FC6F0949634B4040353569A8012340DB0A634F0B634B40DCF30602EE0123405709B4C9F7624B40875469166702234067B52472FA624B4018EE54E39A022340D7B2C61EFF624B40F1C9BF9CC202234032D8680900634B409A1535BDEF022340F72631D4FC624B40408D8CCF2403234012A29F55F2624B409FD0B9B70E03234034949DE7EE624B40B71380659B022340CA891BA0E9624B40E3AF26AB21022340557697FDDF624B406C5A9C3A8F0123400D6BC04CD5624B40D64FB76AC50023402634ABE0C3624B409EDC331D8AFF2240E442FF09B4624B40588C16155CFE22400022457CAF624B408D87D15164FE2240E5AD0380A7624B40AF311F3C65FE2240049910D3A1624B40EE11E77852FE2240701D4670A0624B40509CFC9F40FE2240701D4670A0624B407FA79D672DFE224049FCDA35A3624B40ACB71745C8FD22401829302DBA624B40DB43D8FF69FD224035477462B9624B40045F5F523BFD22409F4D785EB4624B40890F094131FD2240898339DCA4624B40812289FB59FD224034077BCC9D624B40EA2072BA5CFD2240BB1D93B798624B40BE0AC47D39FD2240D37FC39F87624B403BDB3982F8FC224078C5C2707F624B4072CBE749CAFC2240D545EE9D74624B408995014A94FC22403272CC867D624B404A33BFD017FC2240BBEAC8628B624B4060791FAEE8FB2240FA75A11C8C624B4018B294DE61FA22406BD92ADA84624B40D35F38F0C9F92240DB3388239D624B40BF94C3A625F922407BE097C3A7624B404E0E319CA9F7224067D7459A9F624B40F9285C6B56F622405F945E2CA1624B402E7CC08596F52240839A5CBCA5624B401E02BEDE97F422403B7215DDB6624B4041342479EBF2224072AC2A26C5624B40B225A29DD3F2224090D2CACCBB624B40432087F3DCF222409E6E6889B7624B408CCE43BAD6F22240491E6D6EB0624B40D4E9A12DF1F222404B82DD569B624B4042342479EBF222405A7FB3938D624B4024AA7E159BF222409D48E39285624B407C99A8B4DBF122406F7D997A55624B4046214C51F2F02240D66F07F623624B40DC24C1B7D3F02240CBB7A74421624B406D0B0988CEF022407077792C1F624B4093A7CE0CCFF02240CE17CA5117624B4030A9C60E52F02240C97B531512624B40A2B771C920F022403E745A980D624B40DD4DC02006EF224004C2026E00624B409E61AF6482EE224076D87BAFF4614B4035EC7C7986EB22404B3260CB84614B40'),
+  (7, 'Altstadt', '0106000020E610000001000000010300000001000000990000006AF9DEDB38DF224011682C80E7644B409B30A50B53DF22404DCB7B64EB644B40361CB943E9DE2240D5EC7FFAFB644B407AAC8525C3DE2240BC5F562E02654B40338570187FDE2240F5A788CF10654B408CC55D405CDE22401DECD0B218654B40704743F24ADE2240B4B965EC26654B409C8E818258DE224028AE2F2036654B409C18981D4CDE2240EFB45F5F52654B402A84814645DE22407C20D66B62654B40FACB2F173DDE2240068F99DD75654B40FABEB61638DE224028163CBC81654B4069ED1B8344DE22403E3AFBE481654B40941729B226DE2240440DCCD594654B40BB2D09DEF8DD2240714DDC2BB0654B4081B088A8C9DD22400E34BB38AE654B4081999CABACDD2240276018C4B8654B40DB8E390896DD2240A41B5212C3654B40DF8B7A0E89DD22408E1EBAA5C7654B403B6A2B6E55DD224053D42368DE654B404878DA7D4BDD22406443BEF6DE654B40E02FCA1A15DD2240C157A536EC654B4042AC3E7FF9DC22400355C0AAE9654B4028BEC66DA1DC2240116521D4E1654B40E5B96E3077DC2240DD9DC59DDC654B40F896864C26DC224073ABF172D6654B4034CF428BFEDB2240E911041ED1654B40F3F8C2470EDC2240114E1BDCCC654B40D9D9A52E15DC224039FDAD35C7654B408257B3D616DC2240338204CBC2654B4026D87F7825DC22402238335ABB654B407E715ECD40DC2240F83AA52CB7654B4053BAFBA249DC2240DF4C1CF4AB654B403A11488848DC2240CB37B71AA6654B4021F22A6F4FDC224042721320A2654B40F6DE104737DC2240ACD474AB99654B40795D31CA30DC2240F0F945CA95654B40A6CC09FA16DC22402C4C74A48F654B40C3C0379016DC22401196ECA586654B40003264DC14DC2240A523A19C80654B403EA3902813DC2240CAD40E6973654B4036A7EBB50DDC22408D65967C3F654B40675F777902DC2240840742EF3C654B408C9D0B11EFDB2240E7BBC5693B654B40AD5D4DEFD8DB2240368B70C63A654B40330CF90DAFDB224098F100D33A654B4063C484D1A3DB2240FB5249E439654B4095BBB9F199DB2240E778A59235654B4047B4E1D0D8DB224094C6957232654B4039F37F3E15DC224094C6957232654B4039F37F3E15DC22406CDD0C482B654B407E27D4BC1ADC2240073CEAE728654B40053FAD351DDC2240D888133220654B408478A34023DC2240F55D7E1B1D654B40FE60CAC720DC224043A0897E1A654B40736B0EE121DC22403C99683F17654B40CB390BA91CDC224052D1F43214654B401C2A25031BDC2240D1EF040D13654B4027EB8695DEDB22404F5FEACF16654B40D636B1A1CBDB22405301F04618654B40D63B4D5896DB2240DD01C00E1E654B40AC7F849182DB2240301147161F654B40B4EA7AE972DB2240301147161F654B40215320FB62DB2240EC459D041E654B40A5A63DDA50DB2240FE8349E11B654B402D4B2A3D47DB224050043BD219654B409D049FD23FDB2240205B002117654B40CCDA8E6D3BDB22402856C56F14654B400CBE136838DB2240D1DABC6D0D654B40658C103033DB22406A5B2BCE0B654B40C438F0652ADB22408480BC380A654B4086E27E5521DB2240E140E26309654B4051F7039D08DB2240EF66FBD508654B40FC79D65816DB2240C66B4D5A01654B40E1EDDAC6F9DB2240D929BDC7FA644B403A2A28EE35DC2240388EE413F9644B406637C03852DC2240392FC64BF7644B405FCCC9E061DC2240894B4186F4644B4009C288B27BDC22407E43E45BE9644B400E547B01A3DC224030EB5F36D7644B40035B7209B9DC22408D6D77F6CA644B40AEDD44C5C6DC2240519C6B6AC7644B40F652D837D4DC22407EBFFB0DC6644B40CFDF451CEDDC2240EBF17594C3644B40425F74B705DD2240677559B1C0644B409D0AB31540DD22407E2118BEB7644B40DA3B68507DDD224017ECEEAAAA644B4086AFA93290DD22406CCDEA0EA5644B408864D4F2A9DD22404CD5562B9A644B404295C5B4E8DD2240A25D0F087E644B40F046938B34DE22403FC16F0662644B40F5C63C794FDE2240D2BD9BF657644B400494444C50DE224055C12DC154644B40D52C2DD34DDE224079DC526D51644B40216E7F7B44DE2240B847530F4E644B40A863478223DE224086A6BD1344644B40127FD4590EDE2240DAE2B3E53D644B405B407D14EADD2240D751598C34644B400261A668E5DD224087DED50033644B40D757958EC8DD2240B331290D2E644B40CEE8935AADDD22405FF9FCEB2A644B406C7C3340D0DD22406C857C8C13644B409E8F594DDFDD22403A1B195714644B4044C033C0E7DD224032E3E2AC0B644B401DDDB5ADE4DD2240C320D78507644B4009F5E1B9DEDD22406022190F05644B40905E3EADCCDD22403BBDF5E000644B409CDF857FAADD224047756DE4FC634B4028D644B99ADD2240DE74C229FA634B401A6686F692DD2240AEBC33FAF7634B407EADC34C8ADD224064F0C28DF3634B402D51E2F785DD22402A8AD33FE9634B40C4EC3C458EDD2240AA863012E4634B40978D40D299DD22402C7FFA65DE634B40F19D7525D0DD2240E74B840CDF634B4079513C2725DE2240C3677C34DF634B405E571FFB54DE2240043B02EFDF634B40BDF098BE99DE2240937ECEA3E2634B40D8808793E1DE22402A6D966CE5634B40EF10766829DF22406103565DE8634B400F88DE8F6ADF2240631F2DD6EA634B40A567CE189DDF2240FE3F8DF5ED634B406FC32271C4DF22405E893B36F1634B403AF5CD29EBDF2240599A74E7E7634B40DB680A290EE02240435CB39DDF634B400BC3E1441FE02240506F7B70DA634B400ACBEA1C36E02240A1B5FED0D0634B40EA55D95C43E02240C3E0B35FD2634B40F0AEC1914AE02240AC963327D3634B4091BFD36868E022405AB38908D7634B40CC2E122386E0224084DD5E2CDB634B4056D5E0BCB7E0224001F7AEB9E3634B4092CAB856DBE022409A5731DFEA634B400983CE8F11E122404B2B89EDF8634B4096AB6EBE27E1224051B47B8C03644B400BE7028533E12240088CDCDD0C644B4064358BE334E122401D1A2EA810644B4056633F4539E12240DF16611418644B401CD29BA037E1224040B2D6A523644B40EB10D14D2BE12240A51DBF8B34644B403D5DE65F26E122402BC91B0C3C644B404514723413E12240C0C6847849644B40F063282DF6E022404C8A5DDE5B644B4082DF1F3BD7E022405AB16AD86F644B404FD36D70B1E02240C4CE688887644B40E950D8CA98E022401DF9944F95644B4012ACFBDB7CE02240A72BDEEAA0644B40C5E4D7A35DE0224099094735AC644B402D882A2E30E02240935816A8B7644B40F97B78630AE02240E353564DC0644B4059B923ACB4DF2240B9D6BB32D1644B406A6145B750DF2240A4190A33E3644B406AF9DEDB38DF224011682C80E7644B40'),
+  (8, 'Südstadt', '0106000020E610000001000000010300000001000000A9000000FAD226E4CDDD2240FF5A41FBDE634B40AC4C72DACDDD22402E5F46FCDE634B40F31D349A24DE22402212802BDF634B4044613C1254DE2240A2695705E0634B4066166BE9B3DE2240549FDEB8E3634B409CBF2EDC28DF22402CEA7B49E8634B40F122E8E567DF2240AFA0B1CBEA634B40FB76E73C9DDF2240CDB459F6ED634B40303BCABEC3DF2240FC490121F1634B4030E487A9F5DF22402557B0A7E5634B401F68399A19E02240BB55EC7BDC634B4000D40EBA24E022402903E20AD8634B403CD6984339E0224075D517DFCE634B400CF7963261E022409D1795DFBE634B40D0FFB85070E02240EFC8FF82B7634B40CFCE417277E02240E861763BB0634B403E1D687179E02240552DF5BBA9634B40E4553C2977E022405974AFFC9F634B404A2A989A6EE022409B03935D99634B4034677A5D52E022407E760F508A634B408E5F1D844DE022404E4158DE82634B40D66ED73657E022401250F0037E634B40E2B72AE668E02240DAD212CE77634B403E8A02D68EE02240CD31F2AF6D634B40FC21E68999E02240442104F86B634B402AA22924ACE0224017AE025C6A634B40DF64F482BCE02240F8959BA269634B406187398EB3E0224092F9C5A864634B40D9BCDDF0A6E0224095A47A645F634B40905297E880E022408B516BD851634B40BF3D8ED34EE0224090B5001E40634B403164956205E02240B1D32ED326634B409125509B0AE02240037438DF22634B40AEA4511DECE02240F0164E0911634B4053C48B8655E122408EC5410F07634B40C620F88CE0E12240943B5642FA624B401E2B031145E22240E7210E12F2624B40C1586E52C5E2224007CD90B7E7624B4094876F17D0E22240B9A63A66E6624B402E692FFB03E32240C99E5A9CE0624B4076AB4C7B3AE32240A33CA9CFE7624B401F5251B538E422407C73F889CE624B404A4D95DDFBE42240AB952317BC624B4042933C7BF7E5224064DBBB46A4624B4094E6E5E531E622404C75FAAC9E624B40ADFD27F50FE6224047AA4A4395624B4083C12463C6E62240FD8D0C7C86624B40D341BC6832E72240D3E7D3877E624B4045713AD48BE7224018E9523679624B4052558A88E7E722408237E3ED74624B40657754C278E82240FB27023970624B402BEC4AC0F5E82240F0B94C936E624B40775B56DB5DE92240E239276F6E624B40B9B9D50BC2E9224000326CA86F624B40B9A8FB8547EA2240724B98B772624B404657C90EF9EA2240563FA48D78624B407444DC5EA5EB2240DDC1A7547F624B40418F3FD4DDEB224071F154EB81624B400DADB4AEE4EB2240A592BDB776624B40D2B99D9EE7EB224094277ED56C624B40C76AAB23E5EB22408084A2E15F624B407CF857F7DEEB224004A3B82B58624B40CACA2B71BBEB224063A9EC123A624B40CD1DACE099EB22400AF4660B29624B40BE766CEA75EB2240071ADDB418624B407C346D3229EB2240B833F0DBFE614B40F9319EB4C2EA22409AD03A94E4614B4018785FD83EEA22404E8EF655CB614B40BA421F6C54EA224068B1E4A7C8614B40A5F5DE9673EA2240BF32A92FC7614B40297ADD060DEB2240C949A3C1CD614B40E4668DD114EB22405F1568BAC3614B40A825CD302FEB224065A9A5E9C1614B402EA66CBC62EB224090FC8FA7A3614B4015847C8570EB22406308867589614B403D4DACB286EB22400DD83DB484614B404852FCA884EB2240DC6A6EF47B614B40962F6CAF92EB2240C334C9C077614B4040225C1498EB2240798A1A9E72614B409E579C8082EB22400F4DB95868614B40FC944CB069EB22403F59D94167614B40A9AF6CE65EEB2240F1B9063663614B40765F0BD6E6EB2240652585242E614B405AA7FA4631EC224040E7550E05614B4058873A393EEC2240A1F68456CD604B40BAE4AA7618EC22405744824AA1604B40C72C9B55FBEB22402711883E9D604B407D775B20DDEB2240EE3CC7F999604B40E3E4ABE4B0EB2240C93F49588B604B40443AAC5E8EEB22402B8C2D156E604B40CA9AEFD130EA2240B78D358250604B407B00D2AE38E922402887724951604B40576E25BDD5E722405F0BEEAE4B604B407956D6E077E7224066EC6D1A48604B40817E06B267E7224041C86BF140604B4020E025B4D2E52240FA62D8363B604B40C6E291C0CDE32240C12D2D5E5F604B403DF405A666E22240D868B0E46B604B4067707EA31CE0224095F6ECB576604B4020BC3C8300DF22407C6F100A6B604B40E7036FCD50DD2240E7FD74BA6D604B405F3D3DE935DD22406056ABBE66604B408F49A19260DC22408CB32B8E68604B404CCBDCF9E3DB2240847BD0F36C604B40BE222052D1DB2240849B474F6E604B408AAEEA4FE6DB224090DAADB776604B4021AAC28AF9DB2240B926B1F47E604B40FACA492F0CDC2240723D5EE086604B401BF37FA918DC22408616A4D28D604B405C362FA71DDC224032AE2FEA90604B40B5D0575026DC224036CFD2DE95604B40769A654935DC2240AB87A9C29F604B40892DDF1C44DC2240258430F1AA604B4006418C9050DC2240342125EBB4604B405C52217D5DDC22402899C2D5BE604B40A405251F6CDC224030092343CE604B4088576F0A75DC22403E04CC79E9604B40B5096CB777DC22405389DCB6FB604B4026B040FE66DC224057A249FC18614B40ECF9FCB858DC2240E45DB7282B614B40E34AEDDE42DC2240A528E2343C614B407AB5C0DE21DC2240EF5ACFA950614B4088877A0BF3DB2240ECA25ABA66614B40A3308FC2BFDB2240E656E79A7A614B40366E76B48EDB2240FC8A57968B614B40B0FDA79166DB22403AE3BBA696614B40C751858A3DDB2240D32E7009A2614B40240615450EDB2240CEDAECC5AE614B40ED9F6760CBDA2240C3C6B9B0BF614B407301536A74DA2240449354B0D5614B404D6D5FB202DA224044EE5772F0614B40A09A2A43A3D92240900AF20E06624B40528126EC3ED92240097E3C051D624B40F036EFEEBED82240C161233A38624B40A79F0E6460D8224096E5A0A64B624B4060082ED901D8224022C5B8C05E624B40421ED657E4D62240750028D293624B40419F8DB068D622408571A614AB624B408C7D08668FD6224081554A78B5624B404461FD62C8D622404CE26233C4624B40D1920DFA09D7224019ECC7CAD4624B40CDC997D85DD7224084D3F408EA624B40998E275697D7224014CA6E1FF9624B400811EC0DB5D72240874400B100634B40E3BCFB56CED7224073419CDD06634B4000A820D9DDD7224075DB1C1A09634B40D0C8BE23DAD72240222012D30B634B405ED25A94E3D72240AB80650110634B40613B0F6B4BD82240B993332725634B40FCA7097B6ED8224081F7785E2D634B408D3FC205BCD8224059289BCE34634B40AEFF11AF31D922402CC56BCB4D634B4044941F1E58D922403B4B2C1655634B4053D2157A95D92240AC07B8325F634B40C57EA982D3D922400C27AE0B68634B404AD5A9374DDA22406EF9366877634B40213A9415D5DA2240C0D15D6F84634B407FD02DC369DB2240F6E15F1F91634B409ADDE1B04FDC2240D25353CFA4634B40D3695B2EB6DC22402E1F827DAC634B403522B20300DD224025FC25B8B0634B40C106E6302DDD2240F90CCDC2B2634B40713F0A1541DD2240C3E3F95ECE634B40DDCCA26F60DD2240224E7E62D1634B40BE2B37169ADD2240E918578BD4634B40D0FA0B06B2DD22407ECBBDA7D7634B40209D7FDEC1DD2240A56BB2B7DA634B40A749C35DCDDD22409BFA30DEDE634B40FAD226E4CDDD2240FF5A41FBDE634B40'),
+  (9, 'Weiche', '0106000020E610000001000000010300000001000000FA0000008BD63188F4D622400C178D1C6D604B40BDBD509E83D8224060A4CF906F604B40EC251AADA3D822409994F9A86F604B4034AFC3A366D92240918304B873604B40116C1B627BDA224064CC17A576604B4022E72F8458DB22401C398BDE72604B40FE09F244BDDB22400BB4A5786F604B4097DD1110D2DB22407B8A87536E604B40168C1BCCFDDB2240184D697C80604B40D71825BB0CDC2240B251F3E586604B40AA1971CC2DDC2240F35E63159A604B40DD4B4B7748DC224061052131AE604B40A7D02B7D63DC22402FE1440BC4604B4029D9DC716DDC2240E205628FD0604B40148F870B77DC2240A3099114EF604B40423494C177DC22400CBC548BF9604B4000C1F6766FDC2240BCEA45EB0C614B40E79543D559DC2240075A7EDF29614B4042F0B4763DDC2240A64701903F614B40E975548C1DDC2240B624B64153614B4091FBF3A1FDDB224062305C9461614B40A8D0BBD2CEDB22406977760475614B40B1E6C8B19ADB22404616996E87614B40FEF107DD5ADB2240BA5662B199614B40B8A7FF292DDB22400B36719FA6614B40E9E87316D7DA2240709621EDBC614B4093C8A4D79EDA2240B2439B1FCB614B40498CAFA06DDA224099D8EA43D7614B40608EC99939DA2240DF4793F9E3614B40C5063FB3F4D9224001671286F3614B40C90352BD92D9224036DDD4C309624B4069FFA87653D922402238A04C18624B4073D1F50F16D922402B858B6D26624B403F206109D3D822401D2BFB0334624B40C607115B83D82240F8AA984E44624B401741199C52D822405DE1FF944E624B406E89C15504D82240982A7B325E624B40C7A6A39EDAD722403F7DC53D66624B402B1F19B895D72240C539847D73624B40BD615CDA2AD722402DF4AD7F87624B40CCCB8F23F2D622408181C62D92624B404BCF656D9BD6224089797C55A2624B40579E9F7869D62240A6C9BD21AB624B409A1F5DA74DD622406C402D11A4624B403549E26F3BD6224089AAF2C09F624B40AE9495D112D62240347842759B624B40658E2710FBD522404AE8882F9F624B4077B17D48DBD522400F38AA9B9D624B407F4E4FD4C3D522405A221D5C9D624B409370D2D19ED522400EEEDB289F624B40A77A35B583D522405C29D0A19E624B40FFB2803D7AD522402E1894509F624B408DF4826168D522401787DE999E624B40988544E055D522400EEEDB289F624B40CD9A0B8948D52240DE1643629E624B401B72D5C63DD52240855488C99E624B4051879C6F30D52240FF1F7FB39D624B40D743ED212CD522407E3984959C624B40B520694228D5224099F03E2E9C624B400C65C4D719D52240667D054E9C624B4009AD2426FCD42240EEA9F8189F624B401232D697EED4224039DD4EE99E624B40AEBC39ECE7D42240548D7C429E624B40FB7BE30FE7D422401CC50E649D624B40EF3E92ECD6D422409AF402DD9C624B40190BF864BED42240043A48449D624B40248499C9B5D42240FF689E149D624B40502CCF1AACD422405E4D4D269C624B40BE261D38A5D4224099F03E2E9C624B400191551E9ED422408C97F4E49C624B404E5C0F4F98D422409AF402DD9C624B404C681F5C93D42240D9657DF09A624B40DE85F15890D42240E32863719A624B40BB869DA07DD42240A058B45F9B624B40A3D0AA1876D422400737223E9C624B40AD555C8A68D4224021ADBD759C624B40D9FD91DB5ED42240AF9F976F9B624B40EDEFD4A44DD422403F0208BF9B624B405A1A63F632D4224064F464349D624B40F3E0168C13D42240DD0B901C9D624B40281A0E5CF7D32240855488C99E624B40ACFA8E35E4D32240C02721909F624B40033FEACAD5D32240D8852F889F624B4089FB3A7DD1D32240855488C99E624B407EA6C93FCBD322408442FB899E624B40B184F3BA2AD32240DFF0FD95A9624B40E5BDEA8A0ED32240600B85F3AA624B40E2ED2ABFFAD2224021A6BB62AB624B4031D1040AEBD22240F3CE6A37AD624B400DD2B051D8D22240CC3F2EE6AD624B40F5B4E06751D22240C1A0EE23AF624B40D9768E6218D22240F06A59CEAD624B405AB78FA4DDD12240CC3F2EE6AD624B401D3E99A5AAD12240F5DA25D0AC624B40B3409D7C72D12240D20451B8AC624B4065B1338D5FD12240C74FAAF9AB624B404B3791463FD12240D79E625EA9624B407257671A04D122406DC1B86BA6624B4091B63B3BEFD0224038C51752A7624B40856DDA0AE4D02240EF971AC3A6624B4057252522C6D0224064F7D498A3624B401C70DEE1ABD02240D35D4D3BA2624B4026F58F539ED0224098291027A0624B409F5C6FC893D02240DE066A169C624B400E57BDE58CD02240E73B27209B624B40B45AC29E7DD022401D000DA19A624B407108AAD27AD0224072FC73DA99624B405B3A97307DD02240B84CE90B99624B409E748FE289D0224087BAEB7C98624B4052B5E5BE8AD02240DDB27D9E97624B40921F1EA583D022405B4AF54096624B400A9F1D346FD0224024E1AFD995624B40C67C459C58D02240F708822E93624B40D40A590F07D02240B26FBABC8F624B4073AA2E72B7CF2240D2FD6CB08D624B40E1B08C9CABCF22404D40F24A8C624B40D55B1B5FA5CF2240C925EE8789624B40E0D4BCC39CCF2240B5D0E10089624B40E2318F2036CF2240499E956586624B40BE3E4B751ECF22404F63044487624B402B69D9C603CF2240D0BF35418D624B4075880353DBCE2240B878304092624B40972C8AB669CE22404006552797624B4089E328865ECE2240A2A10FC096624B40FADD76A357CE22409816800F97624B40C0F8EF2E51CE22405F9695AC98624B40D6DE22EB44CE22409BC7BBB299624B404F5E227A30CE224033C1F2219A624B40B7F44041E9CD22406924EEED97624B40305C20B6DECD2240D626ABF796624B40ED0908EADBCD2240F19A1B4797624B400F45ACE3D5CD2240491631E498624B40308050DDCFCD2240DC15FA7498624B403AEDE134CCCD22406E95D85096624B40B448B19CC6CD22400CB42E2196624B403805024FC2CD2240A991E4D796624B4092313DCABDCD22404006552797624B40D49B75B0B6CD224071AB462F97624B409BB6EE3BB0CD2240AA8BB4BB94624B401612BEA3AACD224041CA6CE394624B406AAA899479CD2240C70306FC98624B40B78153D26ECD2240ED5052089B624B4068DAC9C865CD2240ED5052089B624B4088217ECF5ACD22403A09D6319A624B40D5EC370055CD224032F5AA499A624B405AA988B250CD22409A63780E9C624B4049CCB7F718CD2240C251C84EA0624B40F1B79C9613CD2240E9F2CABF9F624B40B8D215220DCD22408836AECF9F624B40CFA028C40ACD2240B6FF4696A0624B40F1C3ACA30ECD22401520EE54A1624B4028C153320BCD22408498A30BA2624B40C26FE7ADF5CC2240457A28F8A3624B40B626867DEACC2240FE37B8A8A3624B40677FFC73E1CC224065FB7B57A4624B405B2A8B36DBCC2240322DADE4A5624B40AECE6634A5CC224034971DF7A8624B40E4EF3DEA92CC2240FA804B13AB624B40C1F0E93180CC224033671DBAAB624B40B4CBB82866CC22406EEE8CCCAE624B40FB8A56524DCC2240903B5AD7B0624B40C12BEA1A37CC2240AD9EE833BD624B40CC2E300B06CC22402A6945B9DF624B4071E469D5D6CB22401983FF6704634B404FA4806C50CB22405C0557CD00634B40F461810B52CA2240554C371BF3624B4041149AE16EC92240BE676420E2624B40F4CE65F451C822403B6CF4E4C6624B40BCBD131330C822409B73357DC2624B40969A1B17D0C62240B537CDE70C634B40D7CEB6898FC52240858A7BFE53634B402295E14477C522406F1EA90C59634B406E11A4229BC422406BD34E075E634B406DC9A165C4C3224061D09E6262634B40DFC56311C7C122407B6E1FEB61634B4072B08B7251C122406B8FF41262634B40628F735B98BE2240004B2AA657634B4083DAA28189BD224055B657C253634B4075280075D7BC224007B5D4F557634B4080290F9835BC22401E1049B262634B40205EC257F8BB22401788E16064634B40657D2000DCBA2240757D2E1D51634B409D3EF7D233B92240515656A334634B403AD039FD0FB92240ADFDD05532634B40CBACB2C04CB92240058D1CC421634B408C400A6F9BB92240FF6FC35D0C634B40EB30DB9A29BA2240231AFC11E7624B402746415BC7BA22402FB65305BF624B40261178AE2ABB22408B50C87C93624B406E371B9B86BB2240D02D86E26B624B40E61393EABDBB22408E33178454624B402D26DC86BDBB2240E537D9E84F624B407DDEC56D0BBC22409D9FA6FA28624B4080835C3023BC22409010FD5024624B403F721D1C5ABC2240C7C3F56D01624B4022AC1689FCBC224066B1FE98CF614B4009D3BF2D79BD2240EF5A6AC9A4614B409A9CE82890BD2240256792089D614B40269F017D18BE22402886546F76614B40B49CE99C95BD22405FA7A6295E614B40BF7D8E50C2BE2240D080B5AC37614B40BBA32EE10DBF2240B5AB41F42D614B40676D58502ABF2240748F9E4126614B40FA36814B41BF2240F623DC6E13614B40BC264CBFAEBF22402B4419C1F9604B40EF74A2D8BDC022404783B1FED8604B40BDD013B6D1C022409DBD944ECD604B40D252288041C122405A948D26A2604B40C7E681169BC12240A082EC407F604B405DF9CD0EABC122406BC0D16578604B4057678714B9C12240A6CF699C77604B40698C1E91D3C122401819790867604B40FCFF0E830DC3224048553E9E69604B40DC6DC71416C322401A7DCF926A604B40F424A58B22C32240727E49146B604B40EF022CA7ABC32240984AC41C69604B4065833031C4C3224069450F316B604B4041A8C5C5D3C322409228F8BD6A604B4063F2F3BE08C422408CF842D26C604B404F761AB1DAC422407C63311A6D604B405FAE01F61AC52240CABC99E36D604B40C365E2C837C5224092095A456D604B4070FA428BB7C522407AD1D5D468604B40F757C51C28C62240D2E53E2567604B4046DBE556D9C6224016179C7866604B4094CDC91ECFC72240148C788169604B407406B9033BC822403461C20E6E604B407A42C7F44FC92240399F6BFD6C604B4087B2F3DCCCCA2240DA074ECA6B604B40EB9570ECCCCB2240A1A273A76A604B40347AA71E28CC22407303DD366A604B4038F6DE8A52CC22403E6CA0CB67604B40C26BB3E975CD2240A1A273A76A604B40C51B387C86CE2240D49964DA6C604B40546C27E1BECE22400D4999416D604B40E01E37C6D7CE2240126FB7986C604B40F6071F24DFCE22407399F3C46D604B400ED29B54E7CF2240E153550D6F604B4053D4C12D8BD02240A3D9FAC86F604B405E3900460FD12240015764586F604B40F379ECA943D22240D9074ECA6B604B403ECE2F02C4D322408FA4DCB867604B40C3004F0E06D422406B9CFA0F67604B40881F36744ED42240ECF8B69C67604B404AA03D5A77D42240D9A0115F69604B404A6B74ADDAD42240A5216C216B604B40F11CCA6F98D522406898E4FB6A604B4081DE4181ACD522402BBD095B67604B4023AB4904BDD52240FE6EB7596B604B408BD63188F4D622400C178D1C6D604B40'),
+  (10, 'Friesischer Berg', '0106000020E610000001000000010300000001000000360100000BD44F9069D62240F74D2AFEAA624B40DFE1E9503DD622406530E1EB9F624B40F49B97F512D62240BC4F1A499B624B40AE100A83FBD52240252A69289F624B40D27B06B8D8D522409F2696779D624B40078DCFACC4D5224096DB9F699D624B4096E3D3B89DD52240252A69289F624B402724D42385D52240F28FC0AA9E624B4069E6EBA878D52240415142609F624B40320CADC668D522407CFBD38E9E624B40B6855A0057D5224042E0721A9F624B40C1572F0249D522401ED2FA569E624B401E92E8483DD52240BB45CA9C9E624B40605400CE30D52240840779A19D624B403F6893FD2BD522402F531BB89C624B406CED8BA027D52240048AED3F9C624B40CCE57F7F19D52240215099479C624B40149A05D3FCD42240F8306EF79E624B4013DDD272EDD42240DE6BC2EF9E624B40F139DFCEE8D4224097EAE86F9E624B407C7AFD7DE7D42240A581FB659D624B400404E899D8D422407899E7DB9C624B401F33754AC0D4224052DFF4379D624B4066B02B98B6D42240A61908F89C624B40E8C89334ACD42240A5F25E3D9C624B4040560461A4D42240F84462549C624B40E70B61D49CD42240A61908F89C624B409D54F70C98D4224093D33BD49C624B402C89172293D422405161EFC29A624B40F65EE8528FD422400FE1C4619A624B40E01A7AC780D42240D5F9C8429B624B40DA69450175D4224080E7274A9C624B401154C28968D42240B1737F599C624B4030BD02B45ED4224051EF914F9B624B40CCCD28FB4ED42240C6A410A99B624B403B96247232D422408ABC0E269D624B404C267AC313D42240266B0B0F9D624B40BE65F786F4D32240DE6BC2EF9E624B402352EDCBE2D3224090FD4A8E9F624B40573C6A54D6D32240772B68939F624B4071B7A7E5D1D322408FB34DDB9E624B4015F0833FCBD322400DDEB17C9E624B40113950ACBDD322404E4C95419F624B40BC6E7C9123D32240FD7DDA10AA624B40551F43510ED32240349E3FE1AA624B40DBF910B9FBD222400071D55BAB624B408BE3E61DECD22240F2BBE108AD624B406279AE32D7D222407A63C2C0AD624B406299456251D222402A1ABD0BAF624B40310EB0EE19D222407A63C2C0AD624B40115913D3DFD1224099EA88E5AD624B40DECD7D5FA8D12240FCD796CBAC624B40290DEA9571D12240DE4ED0A6AC624B40E161BCA660D1224081CB31FBAB624B407AEC400A3ED122403FAC314FA9624B405259981D05D12240912BDE4FA6624B400DB72017EFD02240FE8D5F40A7624B4074C4D829E5D022403CCED8CDA6624B4056EE5724C6D022404AA3CD89A3624B4083117B15ACD022400FD4DA4EA2624B405BE4CD1F9ED022402CD53551A0624B400E94BD7D92D02240AA29D8EE9B624B4004DCDA988CD022409801F81A9B624B40E90FA4DC7DD022409409B7A29A624B4078F39C397BD02240B5F8A8BD99624B401FF0EEB47DD022408E89B00099624B4029FF3D458AD022401FE49F5A98624B407BFDE6828BD02240F08A798C97624B4029A8D19983D02240A4442A2F96624B40D8A4E3596ED022409AEC44D995624B401EE15F6959D02240F9F8192A93624B40E22F195C07D0224087E4F5BD8F624B40F87C7B8CB6CF2240FE7251818D624B406AEBA9D8ABCF22403255A3408C624B40961312CCA5CF2240A132498089624B40FC20CADE9BCF2240FDFEABE588624B40C26A3ECF35CF22405202377B86624B4060AA68631ECF22407617EA3D87624B40B1F0EE0002CF22401796696A8D624B4082233E5DDCCE224004330D1B92624B40D8BFF68C68CE2240DFBCAA4997624B40684C833E5FCE22407B99F6B096624B40D5B5AC4358CE22402030671097624B40401FD64851CE22402AF6127B98624B40A80F6A2245CE22404C9F91BF99624B409E8C8AAD2FCE22403F6560029A624B40B0F4D569E9CD22404ACFF70798624B4040A18392DFCD22407A4B41FE96624B408E14120CDCCD2240E9F2705A97624B40EC8F9951D6CD2240DCB69BD098624B402EC9B800D0CD22406C83447F98624B40D7E944BECBCD2240ED0BFF6096624B40071B6BA1C7CD22400806141596624B401A0A29EEC2CD22402CB62EBD96624B407885B033BDCD2240F416C04497624B4031AE35BDB6CD2240C55E5E1997624B40FAF7EE91B0CD2240B65D72BF94624B401DEFA5AAA9CD22402F8AACF594624B407E7FD8E678CD2240B6C7B8EB98624B4059B84F086ECD2240069D421A9B624B40412B36F465CD2240FDD2AE1F9B624B401C64AD155BCD2240F595324C9A624B40F3CE9A3555CD22408038775C9A624B4013DF8CCD50CD2240DF7203FE9B624B401156DCFC18CD224084CE3D50A0624B4060B02FF712CD22402B7D5DDE9F624B40DD6D1FD30DCD22408CE635E99F624B40492316E30ACD224086E294A1A0624B4009D1BBB40ECD22405DD2D63EA1624B4057444A2E0BCD2240C4C65212A2624B401CD76CBCF5CC2240F79BA10AA4624B4015524C74EBCC2240AC954AB9A3624B400CCD2B2CE1CC2240F407D166A4624B40119BB52DDCCC2240A70D67E2A5624B40D0A66FAFA5CC22403BF31BFAA8624B40B094355393CC2240148725E2AA624B40DE0EC5EF7FCC2240ADB3C8AAAB624B404BC2733B66CC22400949C1D2AE624B4034A70D674DCC2240064F47C5B0624B402A7A8FF736CC2240DAF0B40FBD624B4037938F6B1CCC2240F6A96C3BCF624B40D327B870D6CB22403A7BBF0304634B40F5EEBC69D6CB224036D2F83904634B4094B0B28747CB22408B80BF9100634B40DCE3100E4DCA22403EFBEAD2F2624B409414D4046DC9224001F61EDEE1624B4028A91BEE53C82240A2F30F14C7624B40A6FDDC5B2EC8224062FCD25CC2624B40B16240BD97C72240D2E7E493E2624B40BE5AE63C08C72240E131321001634B402D9AB7046AC62240294809F922634B40FE584385DBC5224047E8BDF342634B40B654ABD778C5224036650A3059634B40022EF880B8C42240B12F32185D634B40CF24137248C42240A8A4224760634B40ED67A60CD7C32240D576093562634B40E194EF046FC322407F66BB4D62634B40AF93434F03C32240F37EB02862634B4087E2B0EE37C222401BA7F3EA61634B40CD13716DA7C122400987571C62634B404BDEF7194AC122400CAF9ADE61634B40912763A4C6C0224013C7650960634B40989C6B716FBF22406EFE82C75A634B40F255B38E12BE2240A7D6A9AA55634B40F7B53E96BBBD22406ED9F87554634B404F5A09EE5EBD2240E1D9AA8E54634B407FB8338CF1BC2240C4B0867357634B40C069849EA6BC2240F5D8FC425B634B40514769F548BC2240212A0B1961634B407FDD2204FABB2240CFFDFA4764634B40B88B66F72CBB224040CA927C56634B40FEE94E47A9B9224071DA633F3C634B406F3159680FB92240E14FB74F32634B40AC4A8F02FDB82240568B4B8537634B40FE563FE2D8B8224061232D6644634B40282DC1BAACB82240852C34A34F634B40E4000C4F34B8224012AC690070634B40B3EFEDE6BFB7224065735AF78E634B4039F8DD0F44B72240CB26D6C3AF634B40FA0754FAF6B62240788CE7E5C3634B40B80D5365EFB622400448C017F6634B40287D3B6919BA224051764AA322644B40B2A1130FC5BC224073B5642B49644B40750F58CDD7BE22400C47A3F466644B40310BE0B815BF224055637A7959644B40CE89E7D94FBF2240EACE6C0860644B40E7097651C0BF2240F5FB57E16E644B40E29BB6BDC4BF22403F7B1F4072644B4072DAF6A2C6BF2240E8CF2CFF7B644B40CB5C28BB27C0224038FDF2BA8A644B40F7BB5A132DC02240054532B588644B40CA17269365C0224032E1CC8E82644B403935C94EEDC02240C62F68AA73644B4078BE75465CC12240111B747967644B406EB19AE911C22240F81BDDE552644B40AF34222E8EC2224035AF260444644B4092AE6BCFF4C222401D7469DA37644B40901CCC7C3AC322401875386E3B644B40D12F9D850AC4224056AD6849E2634B405F02245EEEC422402C599B5DF2634B4086DCF8501BC62240531D6C8501644B40CF698CD821C722404F26A7D413644B406FDB94DC4FC8224059ACC5AD2B644B40D0B09C3465C822401DDA344B26644B40D790A23675C8224066B0C15E1F644B40DA6F185E9FC82240205A6B4A04644B40B1DC53C7B5C822407798EF84F0634B4054D534BDECC822405A831E84D7634B40A396FFAB72C92240BBCAA535E0634B40025B7A2AAAC922400A586D84E0634B40601FF5A8E1C92240C1B6EF5CDD634B4025DC210DD0C922405A831E84D7634B400E2B832DC2C9224016E6D148D1634B4062308282BFC9224006D9B1BEC9634B40AD44EEFBCEC92240E496CE0CB9634B40485DC9EFF5C92240F1A0BE8088634B403616E5F9CCCA22405CF528328C634B400E407CD751CB2240D3CE44F78F634B40D7A907B1B6CB2240930B3D8192634B404EDC7C836ACC2240A52D149595634B408DFEC546A6CC224010667A8197634B40ECD3CD9EBBCC2240443C9BF796634B4063F5B59791CD2240F3E30EA99B634B40580278656ACE2240B192CB8D9F634B407BF832F9ECCE2240CF6B61D3A1634B401E17C3656BD02240919E4A63B5634B40DBAF7B1DFAD0224026DB6AECBC634B40134969B792D1224023E6C5F7C8634B40B7B95C7A1FD22240D7E95110D4634B40AFA0CCC215D322401CD7B9BDE5634B4087B0155D59D3224094ACCCA1EB634B40ED7FF50C7AD3224065E5D246E6634B40ADAD2B32B9D322403E234307E1634B40784CEB91FAD3224099C1ABD0DD634B40D1F1D5E227D42240C8B80DA3DC634B402997C03355D422405D91F419DC634B409CFF0D3858D422403F07992BE3634B40B75A5A0568D422408EDDC5DFEC634B402567F25A8ED422404F2AF6AFF8634B40A0DF0C96D4D422401DE85B6907644B40075F5AFB05D522403725B57610644B40A5C4C5018BD522403C66713922644B40922BB66AFDD52240C4C4326F31644B4067822A5755D62240C80FC39C39644B4074D6B7A4ABD62240F4B68E3640644B401FED6F6573D7224041EC86604A644B40CE1F2940B6D722400BEA26A44E644B407220A4EEDBD72240314CAE0553644B40E8EC748008D82240CBA3D02759644B40E0AFDCF1AED822403054C4C76D644B404A3FBEAE46D92240D47FBB477F644B4036E204F462D92240BE3FD86482644B408E20279BBBD92240BC1F4A8979644B406D35C0F10CDA22407E8BB5E370644B406CC892CF06DB22401B78682E7B644B403661B68DBBDB22406B35B9B782644B40147FCDF7B7DB2240A39625EC85644B4094D74D4CD5DB224086CC010C88644B400ADDCC730BDC22407E88546A8B644B404221FC8838DC2240CEB4A6C88E644B4032EE8DA34CDC224033CAEE6D8F644B4086CAC5C71BDC2240A4FFD466A3644B406629A3AC55DC2240EDAFD242A7644B40E6DC055D5BDC2240F58E2B46A8644B40DCB7761367DC224050A14063AD644B40ECCF1F6866DC22408E48AF95B1644B40BF87246A68DC22400866D1E9B4644B40E2AA901E72DC2240812BBFA0B8644B40BB55AF2B7FDC22408177BA85BB644B40E8C51457AADC2240A65F5028C4644B406AD35673C6DC2240B5E02EBAC7644B409843BC9EF1DC22403BE6450CC3644B40BBCDEDB306DD2240C6BECAA2C0644B40B95C1D423FDD224010C126DBB7644B40F2B2B68380DD22401FE9761BAA644B40C2D180E68DDD22401A7D130EA6644B4064DE589CF2DD2240CF1E2D787A644B40AF711E0F4FDE2240D96D79FD57644B40DC8C5B964FDE22404EA49AF154644B40AF711E0F4FDE2240E444C07051644B409288D28C45DE22402718E23D4E644B40F86BD42722DE2240D7304F9443644B40686DDB75E6DD2240DD44EFD232644B40B67B7DE0C8DD2240D5DF191A2E644B405EC09959ACDD2240C9C6340E2B644B4023F8D445D0DD2240E08CF49813644B40DA25247FDFDD22408BDCF56E14644B408E205C6BE7DD224032F78ED00B644B400934781FE6DD22408266CF0209644B400CB04AA8E1DD224059F317C005644B40608EDA53DCDD2240E5C5573104644B40A836AD58CDDD2240DD0ED71301644B40F9718CF3ACDD224027AF8610FD634B407351110BA2DD22406860CD8BFB634B4032CB139F92DD2240AAF54913F8634B405BBBA4588ADD224025921C85F3634B406F336D3586DD2240C5B13245E9634B40A2C931F28CDD2240C0A5BCA4E4634B40B0B86B9499DD2240E9066392DE634B40864C40B6CDDD2240776795FDDE634B40E8AFDCEEC2DD224061C45F24DB634B40BDD35C27AFDD224008920D60D7634B4032CDA07499DD224058528D80D4634B40457DF05460DD22403ACE7675D1634B402A142F1241DD224012739B71CE634B4076D40E372FDD22400A3A5600B3634B40CF9DEFE7DBDC2240CED1E814AF634B4095AA2B3872DC224026A75AA4A7634B40F882D45DF1DB2240B5D010BB9C634B40268C45BE1CDB2240FAD8F46B8A634B403E1CE5F7C7DA22400BCC0D2B83634B4039E64D5783DA224085F613967C634B40570CAA7042DA224080FE673A76634B40F10C1792FCD92240934CAC8A6D634B4075D3EBD7BDD92240ABC0D9F164634B40A3F21FBE88D92240F10B91E25C634B40E94BEC7F4CD922401386381453634B408F0E8F4042D92240F36A01EE50634B407B75DCB205D922405436F4ED44634B40F519561ABCD82240D79EBCDA34634B40F66F5D786ED822405FC2C9822D634B4023E48D2F60D82240EA797E9D29634B40E62189DA3BD82240CAA8E5D221634B408463B60AE3D722409BE31BDE0F634B40D904DCB9D9D72240849334E20B634B4020A3BD31DFD7224026C5245209634B4018B30B5FD0D722406CD2922B07634B40FD3D754C8FD722400B3989AEF6624B40BB451539CED622403C77F354C5624B400BD44F9069D62240F74D2AFEAA624B40'),
+  (11, 'Westliche Höhe', '0106000020E610000001000000010300000001000000ED0000008E7B49B227C02240949879BC8A644B404A56363770C0224003DB34B297644B40EAF5852F8BC02240D826DA6F97644B40F12E228AB4C02240CC787C26A2644B40518E517DE1C022402456AD6BA7644B407258367208C12240C8CBAE5BAA644B409F95D4303AC12240991C0496C3644B40D50B0F4A95C122405465B491D3644B407B3AA6AAA9C122408DF1CF1FD5644B405436A446A1C12240173B440FDF644B407600893BC8C12240FDDAE3BCE1644B40AE068CD1D4C122404EF4918FEF644B4033161444AAC1224076C1D2C603654B40AC1E161EE3C222403578292E0F654B40304628DDC6C3224044DD45F118654B405C2E9CA2E0C32240CC27F43617654B40FA3AA0E021C52240231F294529654B40CB158C6EFEC52240CCEB082138654B40C572EC61BAC62240BD092CD147654B40B52D2E5DEAC722406AF597BA5F654B40BBB3105152C82240A5B32BA068654B40A2B3507761C92240B87D381C7D654B403B99824A04CA2240532A93238A654B40CF094F6FE7CA224010ABA9829B654B40D2C6C22582CB22407A2E95B3A6654B40EDF82A923BCC22409FC1C164B2654B40D4193303CDCC2240D0176154BB654B40ACD3189F2CCD2240CF8DB457C1654B407BA6A3E989CD2240DF39065BC7654B40F0BA20074CCE22404385E94DD6654B40E0E9FA58CACE22407770913EE1654B40AE950E143DCF22401250B784EC654B40172AC4ADCFCF224095665C37FB654B40C579886121D02240D190D47B03664B40C5C4067579D0224081DD6B000C664B40D16B458893D02240AA3303570E664B406ABB7A3486D02240B18038881A664B40B381EB23B5D022409E4FEA731C664B4003562EF4D0D02240044432DD19664B40498332B1D2D022403B5B57DF1D664B40EC522FE1F4D022403A81E0DF1E664B4079FB257ACDD02240256859A121664B40B7B0547D92D12240CE86085736664B40B789DDEDA7D12240EB50E84C23664B40106CF29EB0D122404F610CDB15664B40DF16BA36B5D122401435F28F0E664B400215BA76DCD12240F38356FF0E664B409D8B60A46AD222402344C7B210664B404F35C17A98D222404DEA4B2C11664B40C5FEC20BAAD22240523C0E6911664B40B5D099C4C5D22240D2B1FFB30C664B40A12D0E56C9D22240862998F30B664B4048930A3443D32240CD0CC11010664B4049B711B8B0D32240308FB8FD0B664B405B368D0D49D42240B1E2A06600664B40D574F1C572D4224036A03F4800664B405A5A9491B6D4224091C03F59FA654B40A5200581E5D422404DE9408AF7654B40B9F91F8001D522401BAE41BBF4654B40004B2E4818D522408A89D798F9654B40E15AFADA8ED522403D00DBD8FF654B40C36AC66D05D6224080585AF2F6654B4069ACBBC711D622400DAF5C0100664B40EBE66CDB1DD62240320C03A503664B40A48C1A0D2ED622408B1FBC0E06664B40FD80B44341D6224097D78EE007664B40372240F264D62240E269E97C0A664B407477015F99D622401149A6E8FD654B4096F75A71CFD62240FC399C68F1654B40C0342E48EFD6224023577AF1E8654B40F421843802D722408FBDC5DCE1654B40020896E214D7224086E137F6D8654B4026D006921CD72240D92F694CD0654B40F55713C921D722406BC0855EC6654B40A7498B3C21D7224033BD10D3BD654B40E4322E8816D722407D527D34B1654B40E871011F0FD7224033049EB7AA654B40462CC7B20DD7224045D4B2BCA9654B404476CF1B46D72240B052340AA8654B40A5674ADFCAD72240839FFFE7A3654B402423865BD8D722406C93FDE0A3654B40D04715A0DED72240111D41C8A4654B40B5E94315E5D722402C06C68FA6654B40BAFD12C432D82240BDB22E89A4654B401BF699123CD82240B29810FFAA654B4080B399BB59D9224030129374A2654B40482D306051D922402B8FFA019D654B40383F47FCA7D92240D0D730539A654B400FEAC2E2FCD922409C6A9C5A98654B400AB24ECFD4D9224083ED1F3089654B40B19E6900B3D9224032881CBF7B654B400FC45FDE9ED9224003F29B6C73654B406D1F8F7675D92240744A7DD165654B40C38C52645CD92240081818F65D654B40398791C55CD922403D8AD8E159654B4037905BF064D922400FBCD89F56654B403A5A22367AD9224038F5A8C352654B40BFDF24F89CD9224073CA05744D654B4047C14032C2D92240ACE25C1648654B407862080EE3D92240FCD5E66043654B40B7171C520BDA224012A91CA13D654B40339910B548DA22409D5BCD8934654B404246A6749DDA2240A12A4A8828654B401416F2E5C3DA2240FB9EE5F920654B4071FEB2F1DCDA224004DA25A31A654B40343375BFF0DA2240AEC6607714654B40745B6F5608DB22409318C4CB08654B40C38F165116DB22403287506801654B40850805165DDB22402B25B759FF644B40A11E049ED3DB22402B4D77E8FB644B4026E5449E26DC224054C7A28EF9644B40405A5BCA3EDC2240BB7771C2F8644B404702C87753DC224060C00E2AF7644B4094366F7261DC22408FBB3CA5F4644B407C05853D7CDC2240BF5B00BBE9644B409E5428F299DC2240FE48F774DB644B40DE63921BB9DC224007CBC60ACB644B40BC71ABCBC6DC22401062FF83C7644B4084AA9218C6DC22402557C9B6C7644B40B2A2C0E6C3DC2240393E7344C7644B407513DD82BDDC224034C1FA79C6644B40C748D1A9AADC2240B9AB3D2BC4644B40C673AC7171DC224028A3EFA9B8644B40CBC46A2D6ADC22408E809A58B5644B406E38DC0569DC22409EAED4B8B4644B40B61FBFB666DC224044E8027AB1644B40FB086A9467DC22402AC0815BAD644B402F4711755BDC22404C9B0E28A8644B40A672F38C56DC2240B6AAB83DA7644B4069C2543F1CDC2240538C7754A3644B40BF56031A2ADC224044C746619D644B407C26D4724CDC2240A2AFEF708F644B40A0BB3F8336DC22408C3A8DA68E644B40172A160B08DC22407419CC278B644B40DE302BC7EDDB224092D1069389644B40BCDCEBF9D6DB224061B22A3E88644B4013B2C617B8DB2240CE45A9E985644B402957728EBBDB22407DC41DC082644B408D1C0D4683DB22406CD0F46080644B4001D09F6A2EDB2240101FD8EC7C644B407803C3E87CDA2240A8F2C98475644B409B1A877F0DDA22403B5F10F170644B408BF56B62ADD92240A978DA027B644B404A495D6E79D922407005A74B80644B40563BE53463D92240A3BD3F6082644B404CCBB5F41FD922407D06F1C27A644B4099ED6C62E7D822401E593A4574644B402C7FB78D5CD822406CF109AB63644B40BD8CE10528D82240E20109F85C644B40C3C5ED95EED72240EA20EF8F55644B40BA1002B9D1D72240F731ECBB51644B40B35DDE08B8D722408F83DEFC4E644B4026D044EA8FD72240606CF1DD4B644B4069AF5AA4BCD62240BFEDBA2741644B40FE62DDB47FD62240C55ACE103D644B401DD8D80C1FD6224002E705D634644B402B69C0D2F0D5224056FC82C92F644B40449E2922BBD5224009FBABBE28644B4089F65CDC77D522401E8B589A1F644B40E3CD95D43BD52240B869A4C217644B40B45874C2F4D422407455A68C0D644B409544B856B5D42240574C9C0601644B404C5FBD168FD4224002F9C385F8634B404353324B6CD42240C689ED61EE634B40D8BB51B55FD422406A50D970E8634B40C03B939057D42240724EC12EE1634B408062231C54D42240AA399633DC634B40B4CA48F326D42240AE961AB3DC634B4061613563F2D322406821701FDE634B40F6DC2280C3D32240B574435DE0634B405603B9789FD32240E6D22BF6E2634B40CEA90D9683D322404922F885E5634B4088A15FAC56D3224014370D77EB634B40605B7C0F39D32240BBA07FA7E8634B40E41045B4F4D222408F907863E3634B40AE7CCA23ADD2224024DE8B28DE634B40FDB0E6EAFED12240DA2AA262D1634B4040AFBB0390D12240716A3808C9634B40150874027CD1224073255500C7634B404FDC76ED28D1224066C4FDC2C0634B40172EFCABF2D02240BCED0CBCBC634B404EC91E4FE1D0224042194FBABB634B40765B7B868BD02240A495A421B7634B40E519192E02D022401CDDFCE4AF634B409A6326347ACF2240683AB1EBA8634B4007F1D070FACE22403E16D09AA2634B40AAEE7773E5CE22408D587D7CA1634B40FA3DB47DC0CE2240EF2F6F17A1634B40F7B04A0C4DCE224080FAFF2E9F634B402F2A6F89DFCD22406C0040689D634B403B84CD5D54CD2240FEBD2C839A634B40995BA3BFE2CC2240F957418D97634B40D0D10F55BDCC2240DBCFABF596634B40982D683CA4CC2240699E697C97634B407CC72E5630CC2240E30AA67594634B40E985CCFDA6CB2240CAF69FF591634B40D17EC10568CB224014CF9CB590634B406EBE0B2CE9CA22406D5CE3D38C634B400771ECE0F6C92240D44DF29D88634B40D92033CDE2C92240CF5C1CF99F634B4035C45DDCC2C92240EF46D110C6634B40BB864E94C0C92240AF358CD7CC634B4096289DAFC4C9224064D25383D1634B403EE75876D1C9224066F2B96BD8634B40C1476358E2C9224016DCE9BFDD634B40A17C8BB4C9C9224073C661CDDE634B40FBEEC258B3C92240C60EBE50E0634B40887580BC8AC922401578A2C6E0634B4037F8082F71C92240C5140F2FE0634B40126FD4AEEEC822406CCC4EC3D7634B40B8ED1C3BECC822404B371C8AD7634B4034D288ACE2C8224014FCE411DC634B40F4C5C041CEC82240C43194FCE5634B401B9920E7B3C82240C439BB0DF2634B403028C535A3C822400512AC6C00644B40C56D02E989C822401DD0711A13644B40CB1D8AE475C82240713D46201E644B4025DB253665C82240BEF1A19F26644B409CE1D97851C822403A6601982B644B4068F4F307D6C72240605CD2E421644B40926AA26333C722404B3D33E814644B4068D33C698DC622404021F45C09644B40D93CD9A727C6224005C89AF201644B401F24DCF68BC52240E8A38192FA634B40F4AA237EEDC4224043CCF383F2634B4078E9AAB5A7C42240218D3758ED634B40CFB9B6FE30C42240C3AF8CF7E4634B40F576FCE70AC42240AEA23C29E2634B40CCEAD377D2C322407E71A121FA634B40379A593A7EC32240B7F3CB341E644B40D93517643AC32240696A504E3B644B404221F729F5C222401C4722E637644B40A8140A5DDCC122402995191659644B4026D37808E1C02240086A2BC874644B4015CB148349C0224039D28B9D85644B40C7596A272DC02240DC7B4A8088644B408E7B49B227C02240949879BC8A644B40'),
+  (12, 'Neustadt', '0106000020E6100000010000000103000000010000007A000000CCDF3CD35CD92240D043DEF95D654B40CCDF3CD35CD9224070602E1E5A654B40668227A660D9224020A0508257654B40BAF5874280D922405586AAAA51654B4025539407B5D92240385045F349654B400F87ED35E5D92240D12F082743654B4002FF253218DA2240CD9105793B654B40DB8CB20536DA22408F89D53537654B40F7F6597A67DA2240C206B92730654B403F44E90791DA2240B0E3662A2A654B40FFE7C9B299DA2240C361F9F328654B402A69BBD6BADA2240E42E162F23654B4000F747AAD8DA224073F548CC1B654B40AA3FFFD7EEDA22405155052F15654B407D6B9E1EFDDA2240072A452A0E654B40EA2AA1D808DB2240D40A3DD608654B4003154D011EDB22407734F05909654B402A25CA3829DB2240A7EC7C1F0A654B4013BB195C30DB22402381AA0A0B654B40AB5D042F34DB2240EE0AD8F50B654B408AAF748438DB2240B46D03DF0D654B402F87B7C538DB224060F9176611654B408ABEFBE33EDB2240497156D616654B40CE7C08C645DB2240E08E334319654B40ACEC86DA56DB2240B01D46CA1C654B40BD83CCD562DB22409AEAEC1C1E654B407C3634E071DB2240EB66B1FE1E654B40971159A980DB2240E854BA2D1F654B40942F67688DDB224075B907AA1E654B40904D75279ADB2240C2A80AAC1D654B409D02C9E1B2DB22407BB854FD1A654B40435A076FCFDB224083D65C0318654B406FCC7133EADB22408A01620716654B4094EB75CAFBDB2240664AF3D014654B405800CB611ADC22409864690D13654B404ECB72F31DDC2240D6A6B18514654B40D2F4258A22DC22403AD9417617654B40570F52C120DC22403FFAAAA81A654B406153318F23DC2240E99490441D654B4077BDE16B1CDC2240E2DFEF4720654B40458793251BDC2240C28C61FD28654B40EA4F4F0715DC224035FF034E2B654B4046780CC614DC2240F7598A6532654B40F9C88FABDBDB22400EE6514932654B40489EEFA499DB2240E2A727B435654B40DD4F61D7A3DB224019981FDB39654B402E524D87ADDB2240B4461BD93A654B406026A5D8D7DB2240B4461BD93A654B40CA03B651F0DB2240676F9D6F3B654B407E81C5ED02DC2240A4ADBB293D654B40476985660EDC2240B4D05B7A3F654B4090180BE90EDC2240689DFE2850654B40CB9238FD12DC224036A842656A654B408E27924815DC2240471D84A980654B4079AE5A0C16DC22405E0D42A78F654B405E713F4E30DC2240CA1E76A495654B4098FAF3C13ADC2240D3DA303A9B654B404E697CFE46DC2240FC20743B9F654B400E0D5DA94FDC22408DC9C76DA2654B40DDC7870348DC22402BC1395CA6654B4010FED54949DC2240C684F720AC654B40F43138E040DC2240D7045F4CB7654B40DC38055825DC224055EDCC3ABB654B401D869D4D16DC22409B39762AC3654B40EB4F4F0715DC224055101206C7654B40C97194110DDC2240697BF416CD654B40593CB3F4FDDB2240CB985131D1654B4017991A091EDC2240ECDDAD5CD5654B40CA6FEA4026DC2240F958B385D6654B4021DCEF653DDC224096E3FB5BD8654B409D3A949B79DC2240C216F0DADC654B409DE4DF829FDC22407FB184B0E1654B40E302FF0FDDDC2240CD659A1AE7654B404B76BD13FADC22400F58E4B6E9654B40B9E174D5D7DC2240FE299DE7F8654B4052A83852B1DC224037B549090A664B403E352BEC77DC2240B4A5D50624664B402CA5EAE43EDC224094E516FC20664B4033AB0A0EEDDB2240B6BEE6703A664B40FCBAC28C94DB22401CD2A54153664B40E364E8C75ADB22409431708863664B40911CB3E451DB22407C5D976665664B40B5FCC635CCDA2240630031285F664B403A71BD79A3DA2240421672F758664B4065B6A4EF39DA2240FA2C2A4851664B40D052BAA8BBD922404115B65B4B664B401F19E9C5A8D9224029818C7D49664B405B6C0A7D5CD922403F3F14F03F664B40EC0B6EA350D92240EFC08B713E664B40ED6C343C35D92240A54FA5523D664B403A5096FA21D922408F36F86D3D664B409CC54B5DBED822404991059440664B405C4DDDEC9BD822407A8E2FC327664B40BDE3D27C6FD82240A35F80051C664B40E2229AC957D82240FBC5F9C409664B40D87933AD56D82240FE8E660E00664B4017D161F041D8224080073114FA654B40EBAAC0B821D82240792D027BFC654B4069591D3FF8D722403694C95CFF654B4064F4491ADCD7224027FF367502664B40E5A4B93D29D72240449C805007664B40C88A585865D62240FD90E9910A664B407C29FD5F94D622406C1E2326FF654B406F5D4379E5D622407E2544ABEB654B4071DF493F01D72240AC863DCFE2654B40EAE84C350ED72240ED7D13B6DB654B404EA082F218D7224002C8340AD5654B40A3CB843422D722402D151512C8654B409079B7FB1FD722404324871DBC654B40384EB5B916D72240EBD391DAB0654B402B486D5E0DD722401706DBAEA9654B40BA369796CED7224082B9B1B7A3654B40708EA113D9D7224082B9B1B7A3654B40AF6102BBE4D722401D6C2E52A6654B40463A4EA531D822405B9730A4A4654B40FF9158223CD82240E1572731AB654B402C07483C5AD922403F4CB35FA2654B4080A6EA1352D92240BE9F38159D654B408A949363FDD9224044163C8C98654B40C501AC9FBFD922407153BD4680654B406138B8BDA0D9224029C632EE73654B40CCDF3CD35CD92240D043DEF95D654B40'),
+  (13, 'Nordstadt', '0106000020E610000001000000010300000001000000A3000000A4CD7B85A9D22240C6D2E03211664B400CE769FD99D2224029B9634F11664B404C2FCFF355D222401903A97410664B401FAD0172B6D122403020E06B0E664B40C41C0357B3D122409F925AC612664B40DE8AF3AEA2D1224042CC787127664B407963580A93D12240105A346136664B4092FAB9448DD12240A16ACE143A664B40C87D464671D1224066CA02CC4C664B40790B96DB55D1224017932C0757664B40FCD471B036D122400292C71663664B409BBF39CEF6D02240745A084879664B40FB5C561775D02240F7BDE4719E664B4038C4221DBCCF2240847F5AC8CD664B40F891E9DAA5CF22405DCD234AD8664B40C20812E570CF2240079288BCEF664B407B6556675BCF22408CE2B1FFFE664B4005AB09494BCF2240A3721C8121674B4063D92B8A40CF2240DB5BF6F734674B40D122F66C51CF224062AE739341674B40DDCC1BD36DCF224098EEEB9259674B402F52DCC781CF2240159075EE6B674B40681393CE98CF2240DC5D8D7373674B406C4C36F9B5CF22407E717EA27B674B4066A498C60FD022406AA4062E95674B40BD62FCE540D02240449FC51BA4674B405FFCFA9369D022400A7C5F7EB0674B404E1C607810D122408A61771AD0674B406973F791FAD12240076CCF7EFC674B404CF7612038D12240B0F4977F03684B40F3535662A0D12240124A485D3D684B402A78D9468BD12240157ABF8241684B40FF74ED78D5D12240787AA8EF4C684B4042573B9621D2224032B00EFA4F684B40C4693AC20DD2224064C50ED574684B40BDB0430AD4D222404E3182468F684B40675734FCECD22240C2BD7E8393684B4039B7140C0BD32240AFB8C844A3684B403DC34236C0D2224097B0F90DC3684B402275E6DEA0D22240AB23EABDCD684B400CE0803FBBD12240A287AF59D9684B40B6D06DE184D12240866013EEDB684B409323ACED34D12240D2394B53DE684B40B9EC307792D02240C44548E1E5684B400525A96E44D022405687391BF0684B407B161184FBCF224019CA32A6FD684B4026B4C6B349CF2240BF9E291B27694B4006A438E225CF2240C8974B6049694B40D531520CE7CF2240A49FB38B41694B401EB1D34B5FD0224061AF090543694B4075381C74E9D022404C076BC436694B4043D6CA0903D12240C12C6CDF37694B406F51ECA10CD122403DCA740844694B40B81A6A9135D1224024A88A0C53694B404F6F97EA8BD1224084D6815060694B403699705DC0D122409FAC69FC62694B40E1DC947105D222405AE2D95D5D694B404BDB9E8A2AD22240D4EAD8785E694B400B0EBA595ED222404F74D03566694B40D5E9367573D22240605778587E694B40825259E190D22240F14BF11B81694B401C69B8B4EBD22240B6B63FB083694B406170042A10D322408BEE04C181694B40479ADD9C44D32240BB3940B670694B40FF23971F97D322405A90433F16694B402124908562D42240DA03FE821C694B4024C1C3A78ED42240B19505D41F694B406D65433FDFD4224060A27E3335694B408C7E0CD3CDD522400D33839F39694B40E18D1F3104D62240DAFEFDA534694B405E31245567D62240FB60B6634A694B40B24037B39DD62240A981CBD248694B400A504A11D4D622408CB70B134E694B401D1A4374EAD622403AD9B45654694B40C98265E007D72240990A889358694B4061D792395ED72240BB3E325059694B4084A9528586D72240EAD39EE755694B400EB8EA6FCFD72240EAD39EE755694B402C2B451FC7D722400380316B5A694B4039C4110050D82240CF1D1A175D694B404023779C80D822408A0361714E694B408C8928AED5D82240BC3D1E7441694B40A1A6588367D922400DDCB19020694B4090F1C80CD1D92240C65CB11209694B40602CABC416DA2240431777F4D6684B4032678D7C5CDA2240A86A39FCB6684B40C1510FA28ADA2240FBA3C54E93684B4036207F3FC0DA22409C2A49877B684B40B32D315A25DB2240B1F0668C60684B404C242D465CDB224033F2852C53684B4081AACB9E5BDB2240F2211D434B684B40E0E3FA8A70DB224077DF40D243684B403C04AF12E7DB2240ABD36F7A28684B4088FCA85E3DDC224063C9F9C417684B404BE5C113E0DC22405522DC27FB674B40F2A46FCB01DE22409E21AE75B6674B40CDDB41F2DEDE224017CD71E5BE674B4007B7704613DF2240D3DAAEFFBD674B40D07CF329CEDF224082B1C6E087674B4022FF5AEB18E02240EC7B4BC389674B40B6DFB49B2BE0224003425AC979674B4080A3B71870DF2240FF844D0476674B402447B86C7EDF2240A358DAD567674B4057E4831EC1E02240892388216D674B408A844C59C7E0224021C47E4569674B403CEEF0D994DF22401C42CA1064674B400465E61FAFDF2240A9962CCF46674B40D72084CD55DF224001D7BF2D45674B40BFE7F2D9D2DE2240DF0091D30A674B409EC497C4CADE22405AC0BFB50A674B404955ACCA58DE2240AFA4A7A7D5664B406C65BEDE12DE224075540324D4664B40150BBACE0CDE22404C584853D3664B40EFCCE725A6DD2240E993F9CFCB664B40DFAC2FDD63DD22401384C75DC9664B405319E4E941DD22400525997BC9664B40B3677E96FBDC22404EEFE4CACD664B4055FCCE6DC5DC22404E524667D1664B40BA5B1433AFDC224025C7E8FED0664B40AE438A7C7DDC2240D6477301D0664B40B5409D9F50DC2240038B4233CE664B406DF307D73EDC2240304DFB17CD664B4054CDBFE409DC22401C0A33F3C3664B406EE0BED5F0DB2240C292E25BBE664B4076D45472A4DB2240E2EF171CAE664B40D92A1DB16EDB22409D1D9387A1664B40347B554646DB2240486BB93393664B4076C0BC7C47DB2240F978D84668664B40952A84A452DB22401820C57765664B40C7F7875CCCDA2240AF94FE325F664B400CBC9803A4DA22407B1736EE58664B401B39EC5C3BDA22407E4E8D4C51664B40B4AA71C1BAD922404A9D44364B664B409170C8735DD9224045E6840940664B40914C188A4AD92240914852DB3D664B40192F722029D92240BA97C54F3D664B4019C36163F0D82240EE66E7C33E664B404B32FE36BFD822404B84119540664B40D41458CD9DD82240D0F808F627664B408FAEAE0A71D82240B862E1F71B664B403A2919D357D82240430CA2570A664B405CAF519056D82240CDCA982A00664B407E11DA6342D82240943EF5FCF9654B409F07527AF5D722402E414359FF654B4098A64FA4DDD7224048A5126E02664B4060AA7C1F2AD72240B9DFBC3207664B4074894CCA65D62240D45DF68E0A664B40212DAAD141D62240CECBF0EF07664B40CAD156CD2CD622404DAC08E605664B40CED007D91DD62240ED09A88203664B40DEDD762012D6224092A6F40300664B406A67F69805D622406303B9EDF6654B40C4A4E52C8FD52240E7114FC8FF654B40DA9C6D8A17D5224019CAC08CF9654B403FFCB24F01D522405DBFE5D4F4654B405208D3A2E6D42240D2F41A56F7654B40BC657A7FB2D42240799D13A8FA654B40BA53807273D422408B3A9A3F00664B40D05E51D149D422406C9F834E00664B401B8505A3B0D32240E66CC0030C664B40D323D8E441D32240E288BCF90F664B40EA1B6042CAD222405BB4EDE50B664B40A4CD7B85A9D22240C6D2E03211664B40');
+
+ALTER SEQUENCE regions_id_seq RESTART WITH 14;
+
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM regions;
+ALTER SEQUENCE regions_id_seq RESTART WITH 1;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO tree_clusters (id, name, watering_status, moisture_level, region_id, address, description, soil_condition, latitude, longitude, geometry)
+VALUES
+  (1, 'Solitüde Strand', 'bad', 0.75, 1, 'Solitüde Strand', 'Alle Bäume am Strand', 'sandig', 54.82, 9.48, ST_SetSRID(ST_MakePoint(54.82, 9.48), 4326)),
+  (2, 'Sankt-Jürgen-Platz', 'moderate', 0.5, 1, 'Ulmenstraße', 'Bäume beim Sankt-Jürgen-Platz', 'schluffig', 54.78, 9.44, ST_SetSRID(ST_MakePoint(54.78, 9.44), 4326));
+ALTER SEQUENCE tree_clusters_id_seq RESTART WITH 3;
+
+INSERT INTO trees (id, tree_cluster_id, sensor_id, planting_year, species, number, latitude, longitude, geometry, readonly, watering_status, description)
+VALUES
+  (1, 1, NULL, 2021, 'Quercus robur', 1005, 54.82124518093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124518093376, 9.485702120628517), 4326), true, 'moderate', 'Sample description 1'),
+  (2, 1, NULL, 2022, 'Quercus robur', 1006, 54.8215076622281, 9.487153277881877, ST_SetSRID(ST_MakePoint(54.8215076622281, 9.487153277881877), 4326), true, 'good', 'Sample description 2');
+ALTER SEQUENCE trees_id_seq RESTART WITH 3;
+
+INSERT INTO watering_status_transitions (id, created_at, tree_id, tree_cluster_id, old_status, new_status, cause)
+VALUES
+  (1, '2025-01-10 08:00:00', NULL, 1, 'unknown', 'good', 'sensor_data'),
+  (2, '2025-01-15 12:00:00', NULL, 1, 'good', 'moderate', 'sensor_data'),
+  (3, '2025-01-20 06:00:00', NULL, 1, 'moderate', 'bad', 'weather'),
+  (4, '2025-01-12 09:30:00', 1, NULL, 'good', 'moderate', 'sensor_data'),
+  (5, '2025-01-18 10:00:00', NULL, 2, 'unknown', 'moderate', 'manual');
+ALTER SEQUENCE watering_status_transitions_id_seq RESTART WITH 6;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM watering_status_transitions;
+DELETE FROM trees;
+DELETE FROM tree_clusters;
+ALTER SEQUENCE watering_status_transitions_id_seq RESTART WITH 1;
+-- +goose StatementEnd
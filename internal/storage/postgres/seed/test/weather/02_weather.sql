@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO tree_clusters (id, name, watering_status, moisture_level, region_id, address, description, soil_condition, latitude, longitude, geometry)
+VALUES
+  (1, 'Solitüde Strand', 'good', 0.75, 1, 'Solitüde Strand', 'Alle Bäume am Strand', 'sandig', 54.82, 9.48, ST_SetSRID(ST_MakePoint(54.82, 9.48), 4326)),
+  (2, 'Sankt-Jürgen-Platz', 'moderate', 0.5, 1, 'Ulmenstraße', 'Bäume beim Sankt-Jürgen-Platz', 'schluffig', 54.78, 9.44, ST_SetSRID(ST_MakePoint(54.78, 9.44), 4326)),
+  (3, 'Campus Hochschule', 'bad', 0.1, 4, 'Thomas-Finke Straße', 'Gruppe ist besonders anfällig', 'schluffig', 54.77, 9.45, ST_SetSRID(ST_MakePoint(54.77, 9.45), 4326)),
+  (4, 'Gewerbegebiet Süd', 'bad', 0.1, NULL, 'Schleswiger Straße', 'Sehr viel versiegelter Boden.', 'sandig', 54.76, 9.43, ST_SetSRID(ST_MakePoint(54.76, 9.43), 4326));
+ALTER SEQUENCE tree_clusters_id_seq RESTART WITH 5;
+
+INSERT INTO weather_days (region_id, date, precipitation, temperature_min, temperature_max, et0, provider)
+VALUES
+  (1, '2025-01-28', 0.0, 1.5, 6.0, 0.4, 'open-meteo'),
+  (1, '2025-01-29', 4.2, 2.0, 7.5, 0.3, 'open-meteo'),
+  (1, '2025-01-30', 0.1, 0.5, 5.0, 0.5, 'open-meteo'),
+  (4, '2025-01-29', 3.0, 1.0, 6.5, 0.3, 'open-meteo');
+
+INSERT INTO tree_cluster_water_balances (tree_cluster_id, region_id, base_status, base_date, watering_status, adjusted_status, forecast_status, deficit, bad_at, flagged, days)
+VALUES
+  (1, 1, 'good', '2025-01-28', 'good', 'moderate', 'bad', 25.5, '2025-01-30', TRUE, '[{"date":"2025-01-29T00:00:00Z","precipitation":4.2,"et0":0.3,"deficit":25.5,"watering_status":"moderate","forecast":false},{"date":"2025-01-30T00:00:00Z","precipitation":0.1,"et0":0.5,"deficit":30.2,"watering_status":"bad","forecast":true}]'),
+  (2, 1, 'moderate', '2025-01-28', 'moderate', 'moderate', 'moderate', 50.0, NULL, FALSE, '[]');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tree_cluster_water_balances;
+DELETE FROM weather_days;
+DELETE FROM tree_clusters;
+ALTER SEQUENCE tree_clusters_id_seq RESTART WITH 1;
+-- +goose StatementEnd
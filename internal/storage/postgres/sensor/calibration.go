@@ -0,0 +1,163 @@
+package sensor
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/pkg/errors"
+)
+
+func (r *SensorRepository) GetCalibration(ctx context.Context, id string) (*entities.SensorCalibration, error) {
+	log := logger.GetLogger(ctx)
+	calibration, err := r.store.GetSensorCalibration(ctx, id)
+	if err != nil {
+		log.Debug("failed to get sensor calibration by sensor id in db", "error", err, "sensor_id", id)
+		return nil, err
+	}
+
+	return calibration, nil
+}
+
+// recalibrateBatchSize is the number of rows that are recomputed at once while recalibrating sensor data
+const recalibrateBatchSize = 1000
+
+// SaveCalibration creates or replaces the calibration profile of a sensor. In the same transaction all stored
+// sensor data of the sensor is passed to recalibrateFn ordered by creation time and written back afterwards.
+func (r *SensorRepository) SaveCalibration(ctx context.Context, id string, calibration *entities.SensorCalibration, recalibrateFn func(*entities.SensorData) error) error {
+	log := logger.GetLogger(ctx)
+	if calibration == nil {
+		return errors.New("calibration cannot be empty")
+	}
+
+	curve, err := json.Marshal(r.mapper.FromDomainCalibrationCurve(calibration.Curve))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal calibration curve")
+	}
+
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.UpsertSensorCalibration(ctx, &sqlc.UpsertSensorCalibrationParams{
+			SensorID:               id,
+			CentibarOffset:         calibration.CentibarOffset,
+			TemperatureCoefficient: calibration.TemperatureCoefficient,
+			ReferenceTemperature:   calibration.ReferenceTemperature,
+			ResistanceCurve:        curve,
+		}); err != nil {
+			log.Error("failed to save sensor calibration in db", "error", err, "sensor_id", id)
+			return err
+		}
+
+		if err := r.recalibrateSensorData(ctx, s, id, recalibrateFn); err != nil {
+			return err
+		}
+
+		log.Debug("sensor calibration saved successfully in db", "sensor_id", id)
+		return nil
+	})
+}
+
+// DeleteCalibration removes the calibration profile of a sensor. Like SaveCalibration the stored sensor data
+// of the sensor is passed to recalibrateFn in the same transaction.
+func (r *SensorRepository) DeleteCalibration(ctx context.Context, id string, recalibrateFn func(*entities.SensorData) error) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.DeleteSensorCalibration(ctx, id); err != nil {
+			log.Error("failed to delete sensor calibration in db", "error", err, "sensor_id", id)
+			return err
+		}
+
+		if err := r.recalibrateSensorData(ctx, s, id, recalibrateFn); err != nil {
+			return err
+		}
+
+		log.Debug("sensor calibration deleted successfully in db", "sensor_id", id)
+		return nil
+	})
+}
+
+// recalibrateSensorData fetches the sensor data of a sensor in batches, so the history is never loaded into memory at once
+func (r *SensorRepository) recalibrateSensorData(ctx context.Context, s *store.Store, id string, recalibrateFn func(*entities.SensorData) error) error {
+	log := logger.GetLogger(ctx)
+	if recalibrateFn == nil {
+		return errors.New("recalibrate function cannot be empty")
+	}
+
+	// keyset pagination on (created_at, id), the ids of the sensor data start at 1
+	var afterCreatedAt time.Time
+	afterID := int32(0)
+	count := 0
+	for {
+		rows, err := s.GetSensorDataBatchBySensorID(ctx, &sqlc.GetSensorDataBatchBySensorIDParams{
+			SensorID:       id,
+			AfterCreatedAt: utils.TimeToPgTimestamp(&afterCreatedAt),
+			AfterID:        afterID,
+			BatchSize:      recalibrateBatchSize,
+		})
+		if err != nil {
+			log.Debug("failed to get sensor data batch by sensor id in db", "error", err, "sensor_id", id, "after_id", afterID)
+			return s.MapError(err, sqlc.SensorDatum{})
+		}
+
+		data, err := r.mapper.FromSqlSensorDataList(rows)
+		if err != nil {
+			return errors.Wrap(err, "failed to map sensor data")
+		}
+
+		for _, d := range data {
+			if err := recalibrateFn(d); err != nil {
+				return err
+			}
+
+			if err := r.updateSensorData(ctx, s, d); err != nil {
+				return err
+			}
+
+			afterCreatedAt = d.CreatedAt.UTC()
+			afterID = d.ID
+		}
+
+		count += len(data)
+		if len(rows) < recalibrateBatchSize {
+			log.Debug("sensor data recalibrated successfully in db", "sensor_id", id, "count", count)
+			return nil
+		}
+	}
+}
+
+func (r *SensorRepository) updateSensorData(ctx context.Context, s *store.Store, data *entities.SensorData) error {
+	log := logger.GetLogger(ctx)
+	if data.Data == nil {
+		return errors.New("sensor data cannot be empty")
+	}
+
+	raw, err := json.Marshal(r.mapper.FromDomainSensorData(data.Data))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal mqtt data")
+	}
+
+	var anomalies []byte
+	if len(data.Anomalies) > 0 {
+		anomalies, err = json.Marshal(r.mapper.FromDomainSensorDataAnomalies(data.Anomalies))
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal sensor data anomalies")
+		}
+	}
+
+	if err := s.UpdateSensorData(ctx, &sqlc.UpdateSensorDataParams{
+		ID:           data.ID,
+		Data:         raw,
+		Flagged:      data.Flagged,
+		AnomalyScore: data.AnomalyScore,
+		Anomalies:    anomalies,
+	}); err != nil {
+		log.Error("failed to update sensor data in db", "error", err, "sensor_data_id", data.ID)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCalibration = &entities.SensorCalibration{
+	CentibarOffset:         -2.5,
+	TemperatureCoefficient: 0.02,
+	ReferenceTemperature:   21,
+	Curve: []entities.CalibrationPoint{
+		{Resistance: 500, Centibar: 0},
+		{Resistance: 10000, Centibar: 100},
+	},
+}
+
+func TestSensorRepository_SaveCalibration(t *testing.T) {
+	t.Run("should create calibration and attach it to the sensor", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		err := r.SaveCalibration(context.Background(), "sensor-1", testCalibration, keepSensorData)
+		got, getErr := r.GetByID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.NotNil(t, got.Calibration)
+		assert.Equal(t, "sensor-1", got.Calibration.SensorID)
+		assert.Equal(t, testCalibration.CentibarOffset, got.Calibration.CentibarOffset)
+		assert.Equal(t, testCalibration.TemperatureCoefficient, got.Calibration.TemperatureCoefficient)
+		assert.Equal(t, testCalibration.ReferenceTemperature, got.Calibration.ReferenceTemperature)
+		assert.Equal(t, testCalibration.Curve, got.Calibration.Curve)
+		assert.NotZero(t, got.Calibration.CreatedAt)
+	})
+
+	t.Run("should replace existing calibration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.SaveCalibration(context.Background(), "sensor-1", testCalibration, keepSensorData)
+		assert.NoError(t, err)
+
+		// when
+		err = r.SaveCalibration(context.Background(), "sensor-1", &entities.SensorCalibration{CentibarOffset: 3}, keepSensorData)
+		got, getErr := r.GetCalibration(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, 3.0, got.CentibarOffset)
+		assert.Empty(t, got.Curve)
+	})
+
+	t.Run("should return error when sensor not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		err := r.SaveCalibration(context.Background(), "notFoundID", testCalibration, keepSensorData)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when calibration is nil", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		err := r.SaveCalibration(context.Background(), "sensor-1", nil, keepSensorData)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestSensorRepository_GetCalibration(t *testing.T) {
+	t.Run("should return entity not found error when sensor has no calibration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.GetCalibration(context.Background(), "sensor-1")
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorRepository_DeleteCalibration(t *testing.T) {
+	t.Run("should delete calibration successfully", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.SaveCalibration(context.Background(), "sensor-1", testCalibration, keepSensorData)
+		assert.NoError(t, err)
+
+		// when
+		err = r.DeleteCalibration(context.Background(), "sensor-1", keepSensorData)
+		got, getErr := r.GetByID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Nil(t, got.Calibration)
+	})
+}
+
+func TestSensorRepository_RecalibrateSensorData(t *testing.T) {
+	t.Run("should write back recomputed sensor data with the calibration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		watermarks := []entities.Watermark{
+			{Centibar: 10, Resistance: 23, Depth: 30},
+			{Centibar: 20, Resistance: 23, Depth: 60},
+			{Centibar: 300, Resistance: 23, Depth: 90},
+		}
+		anomalies := []entities.SensorDataAnomaly{{Type: entities.SensorDataAnomalyOutOfRange, Depth: 90, Centibar: 300, Score: 1}}
+
+		var raw []entities.Watermark
+		count := 0
+		recalibrateFn := func(d *entities.SensorData) error {
+			raw = d.Data.Watermarks
+			d.Data.RawWatermarks = d.Data.Watermarks
+			d.Data.Watermarks = watermarks
+			d.Flagged = true
+			d.AnomalyScore = 1
+			d.Anomalies = anomalies
+			count++
+			return nil
+		}
+
+		// when
+		err := r.SaveCalibration(context.Background(), "sensor-1", testCalibration, recalibrateFn)
+		got, getErr := r.GetLatestSensorDataBySensorID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.NotZero(t, count)
+		assert.Equal(t, watermarks, got.Data.Watermarks)
+		assert.Equal(t, raw, got.Data.RawWatermarks)
+		assert.True(t, got.Flagged)
+		assert.Equal(t, 1.0, got.AnomalyScore)
+		assert.Equal(t, anomalies, got.Anomalies)
+	})
+
+	t.Run("should keep calibration and sensor data unchanged when recalibration fails", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		before, err := r.GetLatestSensorDataBySensorID(context.Background(), "sensor-1")
+		assert.NoError(t, err)
+
+		recalibrateFn := func(d *entities.SensorData) error {
+			return errors.New("recalibration failed")
+		}
+
+		// when
+		err = r.SaveCalibration(context.Background(), "sensor-1", testCalibration, recalibrateFn)
+		_, calErr := r.GetCalibration(context.Background(), "sensor-1")
+		got, getErr := r.GetLatestSensorDataBySensorID(context.Background(), "sensor-1")
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, calErr, new(storage.ErrEntityNotFound))
+		assert.NoError(t, getErr)
+		assert.Equal(t, before.Data, got.Data)
+	})
+
+	t.Run("should return error when recalibrate function is nil", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		err := r.SaveCalibration(context.Background(), "sensor-1", testCalibration, nil)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func keepSensorData(*entities.SensorData) error {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 
@@ -103,9 +104,20 @@ func (r *SensorRepository) InsertSensorData(ctx context.Context, latestData *ent
 		return errors.Wrap(err, "failed to marshal mqtt data")
 	}
 
+	var anomalies []byte
+	if len(latestData.Anomalies) > 0 {
+		anomalies, err = json.Marshal(r.mapper.FromDomainSensorDataAnomalies(latestData.Anomalies))
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal sensor data anomalies")
+		}
+	}
+
 	params := &sqlc.InsertSensorDataParams{
-		SensorID: id,
-		Data:     raw,
+		SensorID:     id,
+		Data:         raw,
+		Flagged:      latestData.Flagged,
+		AnomalyScore: latestData.AnomalyScore,
+		Anomalies:    anomalies,
 	}
 
 	err = r.store.InsertSensorData(ctx, params)
@@ -119,8 +131,9 @@ func (r *SensorRepository) InsertSensorData(ctx context.Context, latestData *ent
 
 func (r *SensorRepository) createEntity(ctx context.Context, sensor *entities.Sensor) (string, error) {
 	id, err := r.store.CreateSensor(ctx, &sqlc.CreateSensorParams{
-		ID:     sensor.ID,
-		Status: sqlc.SensorStatus(sensor.Status),
+		ID:               sensor.ID,
+		Status:           sqlc.SensorStatus(sensor.Status),
+		OfflineThreshold: utils.DurationPtrToSeconds(sensor.OfflineThreshold),
 	})
 	if err != nil {
 		return "", err
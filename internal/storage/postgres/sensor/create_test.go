@@ -182,6 +182,30 @@ func TestSensorRepository_InsertSensorData(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should insert flagged sensor data with anomalies", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		data := &entities.SensorData{
+			Data:         input.LatestData.Data,
+			Flagged:      true,
+			AnomalyScore: 1,
+			Anomalies: []entities.SensorDataAnomaly{
+				{Type: entities.SensorDataAnomalyOutOfRange, Depth: 30, Centibar: 255, Score: 1},
+			},
+		}
+
+		// when
+		err := r.InsertSensorData(context.Background(), data, input.ID)
+		got, errGet := r.GetLatestSensorDataBySensorID(context.Background(), input.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGet)
+		assert.True(t, got.Flagged)
+		assert.Equal(t, 1.0, got.AnomalyScore)
+		assert.Equal(t, data.Anomalies, got.Anomalies)
+	})
+
 	t.Run("should return error when data is empty", func(t *testing.T) {
 		r := NewSensorRepository(suite.Store, defaultSensorMappers())
 
@@ -0,0 +1,150 @@
+package sensor
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/pkg/errors"
+)
+
+// Decommission retires the sensor in a single transaction. If a replacement id is given, the trees and
+// flowerbeds of the sensor are linked to the replacement, which is created at the location of the
+// retired sensor if it does not exist yet. Otherwise the sensor is unlinked from its trees and flowerbeds.
+// The sensor data is kept and stays assigned to the tree it was measured at. An existing replacement that
+// is already linked to a tree is rejected.
+func (r *SensorRepository) Decommission(ctx context.Context, id string, reason string, replacementID *string) (*entities.Sensor, error) {
+	log := logger.GetLogger(ctx)
+	if replacementID != nil && *replacementID == id {
+		return nil, errors.New("sensor cannot be replaced by itself")
+	}
+
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		sensor, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if replacementID != nil {
+			if err := r.createReplacement(ctx, sensor, *replacementID); err != nil {
+				return err
+			}
+		}
+
+		var decommissionReason *string
+		if reason != "" {
+			decommissionReason = &reason
+		}
+
+		if err := r.store.DecommissionSensor(ctx, &sqlc.DecommissionSensorParams{
+			ID:                 id,
+			DecommissionReason: decommissionReason,
+			ReplacedBy:         replacementID,
+		}); err != nil {
+			log.Error("failed to decommission sensor in db", "error", err, "sensor_id", id)
+			return err
+		}
+
+		if replacementID != nil {
+			return r.transferSensorLinks(ctx, id, *replacementID)
+		}
+
+		if err := r.store.UnlinkTreesOfSensor(ctx, id, 0); err != nil {
+			log.Error("failed to unlink decommissioned sensor from trees", "error", err, "sensor_id", id)
+			return err
+		}
+
+		if err := r.store.UnlinkSensorIDFromFlowerbeds(ctx, &id); err != nil {
+			log.Error("failed to unlink decommissioned sensor from flowerbeds", "error", err, "sensor_id", id)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	decommissioned, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("sensor decommissioned successfully in db", "sensor_id", id, "replaced_by", replacementID)
+	return decommissioned, nil
+}
+
+func (r *SensorRepository) createReplacement(ctx context.Context, sensor *entities.Sensor, replacementID string) error {
+	log := logger.GetLogger(ctx)
+	existing, err := r.GetByID(ctx, replacementID)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if !errors.As(err, &entityNotFoundErr) {
+			return err
+		}
+	}
+
+	if existing != nil {
+		// linking the trees of the old sensor would silently unlink the trees of the replacement
+		trees, err := r.store.GetTreesBySensorIDs(ctx, []string{replacementID})
+		if err != nil {
+			log.Error("failed to get trees of replacement sensor", "error", err, "sensor_id", replacementID)
+			return err
+		}
+		if len(trees) > 0 {
+			return storage.ErrReplacementHasTree
+		}
+		return nil
+	}
+
+	entity := defaultSensor()
+	entity.ID = replacementID
+	entity.Latitude = sensor.Latitude
+	entity.Longitude = sensor.Longitude
+	entity.OfflineThreshold = sensor.OfflineThreshold
+
+	if err := r.validateSensorEntity(entity); err != nil {
+		return errors.Wrapf(err, "invalid replacement sensor %s", replacementID)
+	}
+
+	if _, err := r.createEntity(ctx, entity); err != nil {
+		log.Error("failed to create replacement sensor in db", "error", err, "sensor_id", replacementID)
+		return err
+	}
+
+	log.Debug("replacement sensor created successfully in db", "sensor_id", replacementID, "replaces", sensor.ID)
+	return nil
+}
+
+func (r *SensorRepository) transferSensorLinks(ctx context.Context, oldID, newID string) error {
+	log := logger.GetLogger(ctx)
+	treeIDs, err := r.store.TransferTreeSensorID(ctx, &sqlc.TransferTreeSensorIDParams{
+		NewSensorID: &newID,
+		OldSensorID: &oldID,
+	})
+	if err != nil {
+		log.Error("failed to link trees to replacement sensor", "error", err, "sensor_id", oldID, "replaced_by", newID)
+		return err
+	}
+
+	if err := r.store.TransferFlowerbedSensorID(ctx, &sqlc.TransferFlowerbedSensorIDParams{
+		NewSensorID: &newID,
+		OldSensorID: &oldID,
+	}); err != nil {
+		log.Error("failed to link flowerbeds to replacement sensor", "error", err, "sensor_id", oldID, "replaced_by", newID)
+		return err
+	}
+
+	log.Debug("linked trees of decommissioned sensor to replacement", "sensor_id", oldID, "replaced_by", newID, "tree_ids", treeIDs)
+	return nil
+}
@@ -0,0 +1,136 @@
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorRepository_Decommission(t *testing.T) {
+	t.Run("should decommission sensor and unlink it from its tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.Decommission(context.Background(), "sensor-1", "broken housing", nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", got.ID)
+		assert.Equal(t, entities.SensorStatusOffline, got.Status)
+		assert.NotNil(t, got.DecommissionedAt)
+		assert.Equal(t, utils.P("broken housing"), got.DecommissionReason)
+		assert.Nil(t, got.ReplacedBy)
+
+		_, err = suite.Store.GetSensorByTreeID(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("should create replacement at the same location and link it to the trees of the old sensor", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		old, err := r.GetByID(context.Background(), "sensor-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// when
+		got, err := r.Decommission(context.Background(), "sensor-1", "", utils.P("sensor-new"))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got.DecommissionedAt)
+		assert.Nil(t, got.DecommissionReason)
+		assert.Equal(t, utils.P("sensor-new"), got.ReplacedBy)
+
+		replacement, err := r.GetByID(context.Background(), "sensor-new")
+		assert.NoError(t, err)
+		assert.Equal(t, old.Latitude, replacement.Latitude)
+		assert.Equal(t, old.Longitude, replacement.Longitude)
+		assert.Equal(t, entities.SensorStatusUnknown, replacement.Status)
+		assert.Nil(t, replacement.DecommissionedAt)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-new", linked.ID)
+	})
+
+	t.Run("should keep sensor data of the old sensor assigned to its tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(context.Background(), &entities.SensorData{Data: &entities.MqttPayload{Device: "sensor-1", Battery: 3.4}}, "sensor-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// when
+		_, err = r.Decommission(context.Background(), "sensor-1", "", utils.P("sensor-4"))
+
+		// then
+		assert.NoError(t, err)
+		data, err := r.GetLatestSensorDataBySensorID(context.Background(), "sensor-1")
+		assert.NoError(t, err)
+		assert.Equal(t, utils.P(int32(1)), data.TreeID)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-4", linked.ID)
+	})
+
+	t.Run("should return error and keep sensor active when replacement is linked to a tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.Decommission(context.Background(), "sensor-1", "", utils.P("sensor-2"))
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrReplacementHasTree)
+		assert.Nil(t, got)
+
+		old, err := r.GetByID(context.Background(), "sensor-1")
+		assert.NoError(t, err)
+		assert.Nil(t, old.DecommissionedAt)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-2", linked.ID)
+	})
+
+	t.Run("should return error when sensor is replaced by itself", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.Decommission(context.Background(), "sensor-1", "", utils.P("sensor-1"))
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when sensor not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.Decommission(context.Background(), "notFoundID", "", nil)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
@@ -7,9 +7,29 @@ type Watermark struct {
 }
 
 type MqttPayload struct {
-	Device      string      `json:"device"`
-	Battery     float64     `json:"battery"`
-	Humidity    float64     `json:"humidity"`
-	Temperature float64     `json:"temperature"`
-	Watermarks  []Watermark `json:"watermarks"`
+	Device        string      `json:"device"`
+	Battery       float64     `json:"battery"`
+	Humidity      float64     `json:"humidity"`
+	Temperature   float64     `json:"temperature"`
+	Watermarks    []Watermark `json:"watermarks"`
+	RawWatermarks []Watermark `json:"raw_watermarks,omitempty"`
+}
+
+type CalibrationPoint struct {
+	Resistance int     `json:"resistance"`
+	Centibar   float64 `json:"centibar"`
+}
+
+type WatermarkAggregate struct {
+	Depth       int     `json:"depth"`
+	Centibar    float64 `json:"centibar"`
+	Resistance  float64 `json:"resistance"`
+	SampleCount int32   `json:"sample_count"`
+}
+
+type SensorDataAnomaly struct {
+	Type     string  `json:"type"`
+	Depth    int     `json:"depth"`
+	Centibar int     `json:"centibar"`
+	Score    float64 `json:"score"`
 }
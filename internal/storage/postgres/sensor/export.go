@@ -0,0 +1,72 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+// exportBatchSize is the number of rows that are fetched at once while streaming sensor data
+const exportBatchSize = 1000
+
+func (r *SensorRepository) StreamSensorData(ctx context.Context, query *entities.SensorDataExportQuery) iter.Seq2[*entities.SensorDataExportRow, error] {
+	return func(yield func(*entities.SensorDataExportRow, error) bool) {
+		log := logger.GetLogger(ctx)
+
+		// a nil slice is sent as NULL and would not match any sensor
+		sensorIDs := query.SensorIDs
+		if sensorIDs == nil {
+			sensorIDs = []string{}
+		}
+
+		// keyset pagination on (created_at, id), the ids of the sensor data start at 1
+		afterCreatedAt := query.From.UTC()
+		afterID := int32(0)
+		for {
+			rows, err := r.store.GetSensorDataForExport(ctx, &sqlc.GetSensorDataForExportParams{
+				FromTime:       utils.TimeToPgTimestamp(utils.P(query.From.UTC())),
+				ToTime:         utils.TimeToPgTimestamp(utils.P(query.To.UTC())),
+				SensorIds:      sensorIDs,
+				TreeClusterID:  query.TreeClusterID,
+				RegionID:       query.RegionID,
+				AfterCreatedAt: utils.TimeToPgTimestamp(&afterCreatedAt),
+				AfterID:        afterID,
+				BatchSize:      exportBatchSize,
+			})
+			if err != nil {
+				log.Debug("failed to get sensor data for export in db", "error", err, "after_id", afterID)
+				yield(nil, r.store.MapError(err, sqlc.SensorDatum{}))
+				return
+			}
+
+			for _, row := range rows {
+				data, err := r.mapper.FromSqlSensorData(&row.SensorDatum)
+				if err != nil {
+					yield(nil, errors.Join(err, errors.New("failed to map sensor data")))
+					return
+				}
+
+				if !yield(&entities.SensorDataExportRow{
+					Data:          data,
+					TreeID:        row.TreeID,
+					TreeClusterID: row.TreeClusterID,
+					RegionID:      row.RegionID,
+				}, nil) {
+					return
+				}
+
+				afterCreatedAt = data.CreatedAt.UTC()
+				afterID = data.ID
+			}
+
+			if len(rows) < exportBatchSize {
+				return
+			}
+		}
+	}
+}
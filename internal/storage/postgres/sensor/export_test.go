@@ -0,0 +1,180 @@
+package sensor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorRepository_StreamSensorData(t *testing.T) {
+	insertExportData := func(t *testing.T, r storage.SensorRepository) {
+		t.Helper()
+		for _, id := range []string{"sensor-1", "sensor-2", "sensor-3", "sensor-1"} {
+			if err := r.InsertSensorData(context.Background(), TestSensorList[0].LatestData, id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	collect := func(ctx context.Context, r storage.SensorRepository, query *entities.SensorDataExportQuery) ([]*entities.SensorDataExportRow, error) {
+		var rows []*entities.SensorDataExportRow
+		for row, err := range r.StreamSensorData(ctx, query) {
+			if err != nil {
+				return rows, err
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+
+	newQuery := func() *entities.SensorDataExportQuery {
+		return &entities.SensorDataExportQuery{
+			From:   time.Now().Add(-time.Hour),
+			To:     time.Now().Add(time.Hour),
+			Format: entities.SensorDataExportFormatCSV,
+		}
+	}
+
+	t.Run("should return all sensor data in time range with linked tree, cluster and region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+
+		// when
+		rows, err := collect(context.Background(), r, newQuery())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, rows, 4)
+		for i := 1; i < len(rows); i++ {
+			assert.False(t, rows[i].Data.CreatedAt.Before(rows[i-1].Data.CreatedAt))
+		}
+
+		assert.Equal(t, "sensor-1", rows[0].Data.SensorID)
+		assert.Equal(t, TestSensorList[0].LatestData.Data, rows[0].Data.Data)
+		assert.Equal(t, utils.P(int32(1)), rows[0].TreeID)
+		assert.Equal(t, utils.P(int32(1)), rows[0].TreeClusterID)
+		assert.Equal(t, utils.P(int32(1)), rows[0].RegionID)
+
+		assert.Equal(t, "sensor-3", rows[2].Data.SensorID)
+		assert.Equal(t, utils.P(int32(4)), rows[2].TreeID)
+		assert.Nil(t, rows[2].TreeClusterID)
+		assert.Nil(t, rows[2].RegionID)
+	})
+
+	t.Run("should filter by sensor ids", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+		query := newQuery()
+		query.SensorIDs = []string{"sensor-2", "sensor-3"}
+
+		// when
+		rows, err := collect(context.Background(), r, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "sensor-2", rows[0].Data.SensorID)
+		assert.Equal(t, "sensor-3", rows[1].Data.SensorID)
+	})
+
+	t.Run("should filter by tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+		query := newQuery()
+		query.TreeClusterID = utils.P(int32(2))
+
+		// when
+		rows, err := collect(context.Background(), r, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, "sensor-2", rows[0].Data.SensorID)
+		assert.Equal(t, utils.P(int32(3)), rows[0].TreeID)
+	})
+
+	t.Run("should filter by region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+		query := newQuery()
+		query.RegionID = utils.P(int32(1))
+
+		// when
+		rows, err := collect(context.Background(), r, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, rows, 3)
+		for _, row := range rows {
+			assert.NotEqual(t, "sensor-3", row.Data.SensorID)
+		}
+	})
+
+	t.Run("should return nothing when no data in time range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+		query := newQuery()
+		query.From = time.Now().Add(-48 * time.Hour)
+		query.To = time.Now().Add(-24 * time.Hour)
+
+		// when
+		rows, err := collect(context.Background(), r, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, rows)
+	})
+
+	t.Run("should stop when iteration is stopped", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		insertExportData(t, r)
+
+		// when
+		count := 0
+		for _, err := range r.StreamSensorData(context.Background(), newQuery()) {
+			assert.NoError(t, err)
+			count++
+			break
+		}
+
+		// then
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		rows, err := collect(ctx, r, newQuery())
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, rows)
+	})
+}
@@ -2,10 +2,17 @@ package sensor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	mqtt "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 func (r *SensorRepository) GetAll(ctx context.Context) ([]*entities.Sensor, error) {
@@ -52,3 +59,165 @@ func (r *SensorRepository) GetLatestSensorDataBySensorID(ctx context.Context, id
 
 	return data, nil
 }
+
+func (r *SensorRepository) GetSensorDataBySensorID(ctx context.Context, id string, from, to time.Time) ([]*entities.SensorData, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetSensorDataBySensorIDAndTimeRange(ctx, &sqlc.GetSensorDataBySensorIDAndTimeRangeParams{
+		SensorID: id,
+		FromTime: utils.TimeToPgTimestamp(utils.P(from.UTC())),
+		ToTime:   utils.TimeToPgTimestamp(utils.P(to.UTC())),
+	})
+	if err != nil {
+		log.Debug("failed to get sensor data by sensor id and time range in db", "error", err, "sensor_id", id, "from", from, "to", to)
+		return nil, r.store.MapError(err, sqlc.SensorDatum{})
+	}
+
+	data, err := r.mapper.FromSqlSensorDataList(rows)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to map sensor data"))
+	}
+
+	return data, nil
+}
+
+func (r *SensorRepository) GetAggregatedSensorDataBySensorID(ctx context.Context, id string, resolution entities.SensorDataResolution, from, to time.Time) ([]*entities.SensorDataAggregate, error) {
+	log := logger.GetLogger(ctx)
+	unit, ok := resolutionTruncUnits[resolution]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sensor data resolution: %s", resolution)
+	}
+
+	rows, err := r.store.GetAggregatedSensorDataBySensorIDAndTimeRange(ctx, &sqlc.GetAggregatedSensorDataBySensorIDAndTimeRangeParams{
+		SensorID:   id,
+		Resolution: unit,
+		FromTime:   utils.TimeToPgTimestamp(utils.P(from.UTC())),
+		ToTime:     utils.TimeToPgTimestamp(utils.P(to.UTC())),
+	})
+	if err != nil {
+		log.Debug("failed to get aggregated sensor data by sensor id in db", "error", err, "sensor_id", id, "resolution", resolution)
+		return nil, r.store.MapError(err, sqlc.SensorDatum{})
+	}
+
+	// raw sensor data older than the retention period only exists as rolled up aggregates
+	data, err := r.getRolledUpSensorData(ctx, id, resolution, from, to)
+	if err != nil {
+		log.Debug("failed to get rolled up sensor data by sensor id in db", "error", err, "sensor_id", id, "resolution", resolution)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		watermarks, err := averageWatermarks(row.Watermarks)
+		if err != nil {
+			log.Debug("failed to aggregate watermarks of sensor data", "error", err, "sensor_id", id)
+			return nil, errors.Join(err, errors.New("failed to map sensor data"))
+		}
+
+		data = append(data, &entities.SensorDataAggregate{
+			Timestamp:   row.Bucket.Time,
+			SampleCount: row.SampleCount,
+			Battery:     row.Battery,
+			Humidity:    row.Humidity,
+			Temperature: row.Temperature,
+			Watermarks:  watermarks,
+		})
+	}
+
+	slices.SortStableFunc(data, func(a, b *entities.SensorDataAggregate) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return data, nil
+}
+
+func (r *SensorRepository) GetBatteryTrends(ctx context.Context, since time.Time) ([]*entities.SensorBattery, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetSensorBatteryTrends(ctx, utils.TimeToPgTimestamp(utils.P(since.UTC())))
+	if err != nil {
+		log.Debug("failed to get sensor battery trends in db", "error", err, "since", since)
+		return nil, r.store.MapError(err, sqlc.SensorDatum{})
+	}
+
+	data := make([]*entities.SensorBattery, len(rows))
+	for i, row := range rows {
+		data[i] = &entities.SensorBattery{
+			SensorID:    row.SensorID,
+			Level:       row.Battery,
+			MeasuredAt:  row.MeasuredAt.Time,
+			SampleCount: row.SampleCount,
+			// the slope is the battery change per second, a discharging battery has a negative slope
+			DischargePerDay: -row.Slope * (24 * time.Hour).Seconds(),
+		}
+	}
+
+	return data, nil
+}
+
+func (r *SensorRepository) GetLastSeen(ctx context.Context) ([]*entities.SensorActivity, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetSensorsLastSeen(ctx)
+	if err != nil {
+		log.Debug("failed to get last seen time of sensors in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.Sensor{})
+	}
+
+	data := make([]*entities.SensorActivity, len(rows))
+	for i, row := range rows {
+		data[i] = &entities.SensorActivity{
+			SensorID:         row.ID,
+			Status:           entities.SensorStatus(row.Status),
+			OfflineThreshold: utils.SecondsToDurationPtr(row.OfflineThreshold),
+			LastSeenAt:       utils.PgTimestampToTimePtr(row.LastSeenAt),
+		}
+	}
+
+	return data, nil
+}
+
+var resolutionTruncUnits = map[entities.SensorDataResolution]string{
+	entities.SensorDataResolutionHourly: "hour",
+	entities.SensorDataResolutionDaily:  "day",
+}
+
+// averageWatermarks takes the aggregated watermark lists of every reading inside
+// one time bucket and returns the mean centibar and resistance value per depth.
+func averageWatermarks(raw []byte) ([]entities.WatermarkAggregate, error) {
+	var readings [][]mqtt.Watermark
+	if err := json.Unmarshal(raw, &readings); err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		centibar, resistance float64
+		count                int
+	}
+
+	sums := make(map[int]*sum)
+	for _, reading := range readings {
+		for _, w := range reading {
+			s, ok := sums[w.Depth]
+			if !ok {
+				s = &sum{}
+				sums[w.Depth] = s
+			}
+			s.centibar += float64(w.Centibar)
+			s.resistance += float64(w.Resistance)
+			s.count++
+		}
+	}
+
+	result := make([]entities.WatermarkAggregate, 0, len(sums))
+	for depth, s := range sums {
+		result = append(result, entities.WatermarkAggregate{
+			Depth:       depth,
+			Centibar:    s.centibar / float64(s.count),
+			Resistance:  s.resistance / float64(s.count),
+			SampleCount: int32(s.count),
+		})
+	}
+
+	slices.SortFunc(result, func(a, b entities.WatermarkAggregate) int {
+		return a.Depth - b.Depth
+	})
+
+	return result, nil
+}
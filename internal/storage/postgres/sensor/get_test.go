@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -186,6 +187,335 @@ func TestSensorRepository_GetLastSensorDataByID(t *testing.T) {
 	})
 }
 
+func TestSensorRepository_GetSensorDataBySensorID(t *testing.T) {
+	t.Run("should return sensor data in time range", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(ctx, TestSensorList[0].LatestData, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		data, err := r.GetSensorDataBySensorID(ctx, "sensor-1", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, data, 2)
+		for _, d := range data {
+			assert.Equal(t, "sensor-1", d.SensorID)
+			assert.Equal(t, TestSensorList[0].LatestData.Data, d.Data)
+		}
+		assert.False(t, data[1].CreatedAt.Before(data[0].CreatedAt))
+	})
+
+	t.Run("should return empty slice when no data in time range", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		data, err := r.GetSensorDataBySensorID(ctx, "sensor-1", time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		data, err := r.GetSensorDataBySensorID(ctx, "sensor-1", time.Now().Add(-time.Hour), time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+}
+
+func TestSensorRepository_GetAggregatedSensorDataBySensorID(t *testing.T) {
+	t.Run("should return daily aggregated sensor data", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(ctx, &entities.SensorData{
+			Data: &entities.MqttPayload{
+				Device:      "sensor-123",
+				Battery:     36.0,
+				Humidity:    52.0,
+				Temperature: 22.0,
+				Watermarks: []entities.Watermark{
+					{Centibar: 42, Resistance: 27, Depth: 30},
+					{Centibar: 42, Resistance: 27, Depth: 60},
+					{Centibar: 42, Resistance: 27, Depth: 90},
+				},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		data, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotEmpty(t, data)
+
+		var samples int32
+		for _, d := range data {
+			samples += d.SampleCount
+			assert.NotZero(t, d.Timestamp)
+			assert.Len(t, d.Watermarks, 3)
+		}
+		assert.Equal(t, int32(2), samples)
+
+		// both readings fall into the same day most of the time, except around midnight
+		if len(data) == 1 {
+			assert.InDelta(t, 35.0, data[0].Battery, 0.001)
+			assert.InDelta(t, 51.0, data[0].Humidity, 0.001)
+			assert.InDelta(t, 21.0, data[0].Temperature, 0.001)
+			for _, w := range data[0].Watermarks {
+				assert.InDelta(t, 40.0, w.Centibar, 0.001)
+				assert.InDelta(t, 25.0, w.Resistance, 0.001)
+			}
+		}
+	})
+
+	t.Run("should not aggregate flagged sensor data", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(ctx, &entities.SensorData{
+			Flagged: true,
+			Data: &entities.MqttPayload{
+				Device:     "sensor-123",
+				Battery:    100.0,
+				Watermarks: []entities.Watermark{{Centibar: 1000, Resistance: 1000, Depth: 30}},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		data, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		var samples int32
+		for _, d := range data {
+			samples += d.SampleCount
+			for _, w := range d.Watermarks {
+				assert.Less(t, w.Centibar, 1000.0)
+			}
+		}
+		assert.Equal(t, int32(1), samples)
+	})
+
+	t.Run("should return error for raw resolution", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		data, err := r.GetAggregatedSensorDataBySensorID(context.Background(), "sensor-1", entities.SensorDataResolutionRaw, time.Now().Add(-time.Hour), time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		data, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionHourly, time.Now().Add(-time.Hour), time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+}
+
+func TestSensorRepository_GetLastSeen(t *testing.T) {
+	t.Run("should return last seen time of all sensors", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.GetLastSeen(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 4)
+		assert.Equal(t, "sensor-1", got[0].SensorID)
+		assert.Equal(t, entities.SensorStatusOnline, got[0].Status)
+		assert.Nil(t, got[0].OfflineThreshold)
+		assert.NotNil(t, got[0].LastSeenAt)
+		for _, activity := range got[1:] {
+			assert.Nil(t, activity.LastSeenAt)
+		}
+	})
+
+	t.Run("should return latest rolled up bucket when raw sensor data was pruned", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		hourly := time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)
+		daily := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+		rows, err := suite.ExecQuery(t, `INSERT INTO sensor_data_hourly (sensor_id, bucket, sample_count, battery, humidity, temperature) VALUES ('sensor-2', $1, 1, 3.3, 50, 20)`, hourly)
+		assert.NoError(t, err)
+		rows.Close()
+		rows, err = suite.ExecQuery(t, `INSERT INTO sensor_data_daily (sensor_id, bucket, sample_count, battery, humidity, temperature) VALUES ('sensor-2', $1, 1, 3.3, 50, 20), ('sensor-3', $1, 1, 3.3, 50, 20)`, daily)
+		assert.NoError(t, err)
+		rows.Close()
+
+		// when
+		got, err := r.GetLastSeen(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-2", got[1].SensorID)
+		assert.Equal(t, hourly, got[1].LastSeenAt.UTC())
+		assert.Equal(t, "sensor-3", got[2].SensorID)
+		assert.Equal(t, daily, got[2].LastSeenAt.UTC())
+		assert.Nil(t, got[3].LastSeenAt)
+	})
+
+	t.Run("should return offline threshold of sensor", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		_, err := r.Update(ctx, "sensor-2", func(s *entities.Sensor) (bool, error) {
+			s.OfflineThreshold = utils.P(6 * time.Hour)
+			return true, nil
+		})
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetLastSeen(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-2", got[1].SensorID)
+		assert.Equal(t, 6*time.Hour, *got[1].OfflineThreshold)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetLastSeen(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorRepository_GetBatteryTrends(t *testing.T) {
+	t.Run("should return latest battery level and trend per sensor", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		rows, err := suite.ExecQuery(t, `INSERT INTO sensor_data (sensor_id, data, created_at) VALUES ('sensor-1', '{"device": "sensor-1", "battery": 40.0}', NOW() - INTERVAL '1 day')`)
+		assert.NoError(t, err)
+		rows.Close()
+
+		// when
+		got, err := r.GetBatteryTrends(ctx, time.Now().Add(-7*24*time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "sensor-1", got[0].SensorID)
+		assert.Equal(t, 34.0, got[0].Level)
+		assert.Equal(t, int32(2), got[0].SampleCount)
+		assert.InDelta(t, 6.0, got[0].DischargePerDay, 0.1)
+		assert.WithinDuration(t, time.Now(), got[0].MeasuredAt, time.Hour)
+	})
+
+	t.Run("should ignore sensor data older than the given time", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.GetBatteryTrends(ctx, time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetBatteryTrends(ctx, time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestAverageWatermarks(t *testing.T) {
+	t.Run("should average watermarks per depth and sort by depth", func(t *testing.T) {
+		// given
+		raw := []byte(`[
+			[{"centibar": 30, "resistance": 20, "depth": 60}, {"centibar": 10, "resistance": 5, "depth": 30}],
+			[{"centibar": 20, "resistance": 15, "depth": 30}, {"centibar": 40, "resistance": 30, "depth": 60}]
+		]`)
+
+		// when
+		got, err := averageWatermarks(raw)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.WatermarkAggregate{
+			{Depth: 30, Centibar: 15, Resistance: 10, SampleCount: 2},
+			{Depth: 60, Centibar: 35, Resistance: 25, SampleCount: 2},
+		}, got)
+	})
+
+	t.Run("should return error on invalid json", func(t *testing.T) {
+		// when
+		got, err := averageWatermarks([]byte("invalid"))
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
 var TestSensorList = []*entities.Sensor{
 	{
 		ID:        "sensor-1",
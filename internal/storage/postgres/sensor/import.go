@@ -0,0 +1,98 @@
+package sensor
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+func (r *SensorRepository) Import(ctx context.Context, sensors []*entities.SensorImport) ([]*entities.Sensor, error) {
+	log := logger.GetLogger(ctx)
+	if len(sensors) == 0 {
+		return nil, errors.New("no sensors to import")
+	}
+
+	createdSensors := make([]*entities.Sensor, 0, len(sensors))
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		for _, si := range sensors {
+			if si == nil {
+				return errors.New("sensor to import cannot be nil")
+			}
+
+			existingSensor, _ := r.GetByID(ctx, si.ID)
+			if existingSensor != nil {
+				return errors.Wrapf(storage.ErrIDAlreadyExists, "sensor %s", si.ID)
+			}
+
+			entity := defaultSensor()
+			entity.ID = si.ID
+			entity.Latitude = si.Latitude
+			entity.Longitude = si.Longitude
+
+			if err := r.validateSensorEntity(entity); err != nil {
+				return errors.Wrapf(err, "invalid sensor %s", si.ID)
+			}
+
+			id, err := r.createEntity(ctx, entity)
+			if err != nil {
+				log.Error("failed to create sensor entity in db", "error", err, "sensor_id", si.ID)
+				return err
+			}
+
+			if si.TreeID != nil {
+				if err := r.linkImportedSensorToTree(ctx, id, *si.TreeID); err != nil {
+					return err
+				}
+			}
+
+			created, err := r.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			createdSensors = append(createdSensors, created)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("sensor entities imported successfully in db", "count", len(createdSensors))
+	return createdSensors, nil
+}
+
+func (r *SensorRepository) linkImportedSensorToTree(ctx context.Context, sensorID string, treeID int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.LinkSensorToTree(ctx, &sqlc.LinkSensorToTreeParams{
+		ID:       treeID,
+		SensorID: &sensorID,
+	})
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Error("failed to link sensor to tree", "error", err, "sensor_id", sensorID, "tree_id", treeID)
+		return err
+	}
+
+	// no tree was updated, either it does not exist or it is already linked to a sensor
+	if _, err := r.store.GetTreeByID(ctx, treeID); err != nil {
+		return r.store.MapError(err, sqlc.Tree{})
+	}
+	return errors.Wrapf(storage.ErrTreeHasSensor, "tree %d", treeID)
+}
@@ -0,0 +1,155 @@
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorRepository_Import(t *testing.T) {
+	t.Run("should create all sensors and link them to trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		sensors := []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: 54.82, Longitude: 9.48, TreeID: utils.P(int32(2))},
+			{ID: "sensor-import-2", Latitude: 54.79, Longitude: 9.44},
+		}
+
+		// when
+		got, err := r.Import(context.Background(), sensors)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, len(sensors))
+		for i, sensor := range got {
+			assert.Equal(t, sensors[i].ID, sensor.ID)
+			assert.Equal(t, sensors[i].Latitude, sensor.Latitude)
+			assert.Equal(t, sensors[i].Longitude, sensor.Longitude)
+			assert.Equal(t, entities.SensorStatusUnknown, sensor.Status)
+			assert.Nil(t, sensor.LatestData)
+		}
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-import-1", linked.ID)
+	})
+
+	t.Run("should not create any sensor if one sensor already exists", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		sensors := []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: 54.82, Longitude: 9.48},
+			{ID: "sensor-1", Latitude: 54.79, Longitude: 9.44},
+		}
+
+		// when
+		got, err := r.Import(context.Background(), sensors)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrIDAlreadyExists)
+		assert.Nil(t, got)
+
+		_, err = r.GetByID(context.Background(), "sensor-import-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("should not create any sensor if tree does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		sensors := []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: 54.82, Longitude: 9.48, TreeID: utils.P(int32(99))},
+		}
+
+		// when
+		got, err := r.Import(context.Background(), sensors)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("Tree"))
+		assert.Nil(t, got)
+
+		_, err = r.GetByID(context.Background(), "sensor-import-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("should not create any sensor if tree is already linked to a sensor", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		sensors := []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: 54.82, Longitude: 9.48, TreeID: utils.P(int32(1))},
+		}
+
+		// when
+		got, err := r.Import(context.Background(), sensors)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrTreeHasSensor)
+		assert.Nil(t, got)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", linked.ID)
+
+		_, err = r.GetByID(context.Background(), "sensor-import-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error if latitude is out of bounds", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		sensors := []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: -200, Longitude: 9.48},
+		}
+
+		// when
+		got, err := r.Import(context.Background(), sensors)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrInvalidLatitude)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error if no sensors are given", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		got, err := r.Import(context.Background(), nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.Import(ctx, []*entities.SensorImport{
+			{ID: "sensor-import-1", Latitude: 54.82, Longitude: 9.48},
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,200 @@
+package sensor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	mqtt "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *SensorRepository) RollupSensorData(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.GetLogger(ctx)
+	beforeTs := utils.TimeToPgTimestamp(utils.P(before.UTC()))
+
+	var pruned int64
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		hourly, err := s.RollupSensorDataHourly(ctx, beforeTs)
+		if err != nil {
+			log.Error("failed to roll up sensor data into hourly aggregates", "error", err, "before", before)
+			return err
+		}
+
+		daily, err := s.RollupSensorDataDaily(ctx, beforeTs)
+		if err != nil {
+			log.Error("failed to roll up sensor data into daily aggregates", "error", err, "before", before)
+			return err
+		}
+
+		pruned, err = s.DeleteSensorDataBefore(ctx, beforeTs)
+		if err != nil {
+			log.Error("failed to delete rolled up sensor data", "error", err, "before", before)
+			return err
+		}
+
+		log.Debug("sensor data rolled up successfully in db", "before", before, "hourly_buckets", hourly, "daily_buckets", daily, "pruned_rows", pruned)
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return pruned, nil
+}
+
+func (r *SensorRepository) PruneHourlySensorData(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.GetLogger(ctx)
+	pruned, err := r.store.DeleteSensorDataHourlyBefore(ctx, utils.TimeToPgTimestamp(utils.P(before.UTC())))
+	if err != nil {
+		log.Error("failed to delete hourly sensor data aggregates", "error", err, "before", before)
+		return 0, err
+	}
+
+	log.Debug("hourly sensor data aggregates pruned successfully in db", "before", before, "pruned_rows", pruned)
+	return pruned, nil
+}
+
+// getRolledUpSensorData returns the aggregates of raw sensor data that has already been pruned by the retention job.
+// The aggregates are kept per tree the data was measured at, they are merged into a single aggregate per bucket.
+func (r *SensorRepository) getRolledUpSensorData(ctx context.Context, id string, resolution entities.SensorDataResolution, from, to time.Time) ([]*entities.SensorDataAggregate, error) {
+	fromTs := utils.TimeToPgTimestamp(utils.P(from.UTC()))
+	toTs := utils.TimeToPgTimestamp(utils.P(to.UTC()))
+
+	var data []*entities.SensorDataAggregate
+	switch resolution {
+	case entities.SensorDataResolutionHourly:
+		rows, err := r.store.GetSensorDataHourlyBySensorIDAndTimeRange(ctx, &sqlc.GetSensorDataHourlyBySensorIDAndTimeRangeParams{
+			SensorID: id,
+			FromTime: fromTs,
+			ToTime:   toTs,
+		})
+		if err != nil {
+			return nil, r.store.MapError(err, sqlc.SensorDataHourly{})
+		}
+
+		data = make([]*entities.SensorDataAggregate, len(rows))
+		for i, row := range rows {
+			watermarks, err := mapWatermarkAggregates(row.Watermarks)
+			if err != nil {
+				return nil, errors.Join(err, errors.New("failed to map sensor data"))
+			}
+			data[i] = &entities.SensorDataAggregate{
+				Timestamp:   row.Bucket.Time,
+				SampleCount: row.SampleCount,
+				Battery:     row.Battery,
+				Humidity:    row.Humidity,
+				Temperature: row.Temperature,
+				Watermarks:  watermarks,
+			}
+		}
+	case entities.SensorDataResolutionDaily:
+		rows, err := r.store.GetSensorDataDailyBySensorIDAndTimeRange(ctx, &sqlc.GetSensorDataDailyBySensorIDAndTimeRangeParams{
+			SensorID: id,
+			FromTime: fromTs,
+			ToTime:   toTs,
+		})
+		if err != nil {
+			return nil, r.store.MapError(err, sqlc.SensorDataDaily{})
+		}
+
+		data = make([]*entities.SensorDataAggregate, len(rows))
+		for i, row := range rows {
+			watermarks, err := mapWatermarkAggregates(row.Watermarks)
+			if err != nil {
+				return nil, errors.Join(err, errors.New("failed to map sensor data"))
+			}
+			data[i] = &entities.SensorDataAggregate{
+				Timestamp:   row.Bucket.Time,
+				SampleCount: row.SampleCount,
+				Battery:     row.Battery,
+				Humidity:    row.Humidity,
+				Temperature: row.Temperature,
+				Watermarks:  watermarks,
+			}
+		}
+	default:
+		return []*entities.SensorDataAggregate{}, nil
+	}
+
+	return mergeAggregateBuckets(data), nil
+}
+
+// mergeAggregateBuckets merges aggregates of the same bucket weighted by their sample count. The aggregates
+// have to be sorted by their bucket.
+func mergeAggregateBuckets(data []*entities.SensorDataAggregate) []*entities.SensorDataAggregate {
+	result := make([]*entities.SensorDataAggregate, 0, len(data))
+	for _, d := range data {
+		if len(result) == 0 || !result[len(result)-1].Timestamp.Equal(d.Timestamp) {
+			result = append(result, d)
+			continue
+		}
+
+		prev := result[len(result)-1]
+		total := float64(prev.SampleCount + d.SampleCount)
+		result[len(result)-1] = &entities.SensorDataAggregate{
+			Timestamp:   prev.Timestamp,
+			SampleCount: prev.SampleCount + d.SampleCount,
+			Battery:     (prev.Battery*float64(prev.SampleCount) + d.Battery*float64(d.SampleCount)) / total,
+			Humidity:    (prev.Humidity*float64(prev.SampleCount) + d.Humidity*float64(d.SampleCount)) / total,
+			Temperature: (prev.Temperature*float64(prev.SampleCount) + d.Temperature*float64(d.SampleCount)) / total,
+			Watermarks:  mergeWatermarkAggregates(prev.Watermarks, d.Watermarks),
+		}
+	}
+
+	return result
+}
+
+// mergeWatermarkAggregates merges two lists of watermark aggregates, every depth is weighted by its sample count
+func mergeWatermarkAggregates(a, b []entities.WatermarkAggregate) []entities.WatermarkAggregate {
+	merged := make(map[int]*entities.WatermarkAggregate)
+	for _, w := range slices.Concat(a, b) {
+		m, ok := merged[w.Depth]
+		if !ok {
+			merged[w.Depth] = &entities.WatermarkAggregate{Depth: w.Depth, Centibar: w.Centibar, Resistance: w.Resistance, SampleCount: w.SampleCount}
+			continue
+		}
+
+		total := float64(m.SampleCount + w.SampleCount)
+		m.Centibar = (m.Centibar*float64(m.SampleCount) + w.Centibar*float64(w.SampleCount)) / total
+		m.Resistance = (m.Resistance*float64(m.SampleCount) + w.Resistance*float64(w.SampleCount)) / total
+		m.SampleCount += w.SampleCount
+	}
+
+	result := make([]entities.WatermarkAggregate, 0, len(merged))
+	for _, w := range merged {
+		result = append(result, *w)
+	}
+
+	slices.SortFunc(result, func(a, b entities.WatermarkAggregate) int {
+		return a.Depth - b.Depth
+	})
+
+	return result
+}
+
+func mapWatermarkAggregates(raw []byte) ([]entities.WatermarkAggregate, error) {
+	var aggregates []mqtt.WatermarkAggregate
+	if err := json.Unmarshal(raw, &aggregates); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.WatermarkAggregate, len(aggregates))
+	for i, w := range aggregates {
+		result[i] = entities.WatermarkAggregate{
+			Depth:       w.Depth,
+			Centibar:    w.Centibar,
+			Resistance:  w.Resistance,
+			SampleCount: w.SampleCount,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,278 @@
+package sensor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorRepository_RollupSensorData(t *testing.T) {
+	t.Run("should roll up raw sensor data into aggregates and delete it", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(ctx, &entities.SensorData{
+			Data: &entities.MqttPayload{
+				Device:      "sensor-123",
+				Battery:     36.0,
+				Humidity:    52.0,
+				Temperature: 22.0,
+				Watermarks: []entities.Watermark{
+					{Centibar: 42, Resistance: 27, Depth: 30},
+					{Centibar: 42, Resistance: 27, Depth: 60},
+					{Centibar: 42, Resistance: 27, Depth: 90},
+				},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		pruned, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), pruned)
+
+		_, err = r.GetLatestSensorDataBySensorID(ctx, "sensor-1")
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+
+		for _, resolution := range []entities.SensorDataResolution{entities.SensorDataResolutionHourly, entities.SensorDataResolutionDaily} {
+			data, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", resolution, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, data)
+
+			var samples int32
+			for _, d := range data {
+				samples += d.SampleCount
+				assert.Len(t, d.Watermarks, 3)
+			}
+			assert.Equal(t, int32(2), samples)
+
+			// both readings fall into the same bucket most of the time, except around the bucket boundary
+			if len(data) == 1 {
+				assert.InDelta(t, 35.0, data[0].Battery, 0.001)
+				for _, w := range data[0].Watermarks {
+					assert.InDelta(t, 40.0, w.Centibar, 0.001)
+					assert.InDelta(t, 25.0, w.Resistance, 0.001)
+				}
+			}
+		}
+	})
+
+	t.Run("should merge late sensor data into existing aggregates", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		_, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		err = r.InsertSensorData(ctx, &entities.SensorData{
+			Data: &entities.MqttPayload{
+				Device:     "sensor-123",
+				Battery:    36.0,
+				Watermarks: []entities.Watermark{{Centibar: 42, Resistance: 27, Depth: 30}},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		pruned, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		data, getErr := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, int64(1), pruned)
+
+		var samples int32
+		for _, d := range data {
+			samples += d.SampleCount
+		}
+		assert.Equal(t, int32(2), samples)
+	})
+
+	t.Run("should weight merged watermarks by the readings at their depth", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		_, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		err = r.InsertSensorData(ctx, &entities.SensorData{
+			Data: &entities.MqttPayload{
+				Device:     "sensor-123",
+				Battery:    36.0,
+				Watermarks: []entities.Watermark{{Centibar: 42, Resistance: 27, Depth: 30}},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+		err = r.InsertSensorData(ctx, &entities.SensorData{
+			Data: &entities.MqttPayload{
+				Device:  "sensor-123",
+				Battery: 36.0,
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		_, err = r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		data, getErr := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, data, 1)
+		assert.Equal(t, int32(3), data[0].SampleCount)
+		assert.Len(t, data[0].Watermarks, 3)
+		assert.InDelta(t, 40.0, data[0].Watermarks[0].Centibar, 0.001)
+		assert.Equal(t, int32(2), data[0].Watermarks[0].SampleCount)
+		for _, w := range data[0].Watermarks[1:] {
+			assert.InDelta(t, 38.0, w.Centibar, 0.001)
+			assert.Equal(t, int32(1), w.SampleCount)
+		}
+	})
+
+	t.Run("should exclude flagged sensor data from aggregates", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		err := r.InsertSensorData(ctx, &entities.SensorData{
+			Flagged: true,
+			Data: &entities.MqttPayload{
+				Device:     "sensor-123",
+				Battery:    100.0,
+				Watermarks: []entities.Watermark{{Centibar: 1000, Resistance: 1000, Depth: 30}},
+			},
+		}, "sensor-1")
+		assert.NoError(t, err)
+
+		// when
+		pruned, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		data, getErr := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, int64(2), pruned)
+		assert.Len(t, data, 1)
+		assert.Equal(t, int32(1), data[0].SampleCount)
+		assert.InDelta(t, 34.0, data[0].Battery, 0.001)
+		assert.InDelta(t, 38.0, data[0].Watermarks[0].Centibar, 0.001)
+	})
+
+	t.Run("should keep sensor data newer than the given time", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		pruned, err := r.RollupSensorData(ctx, time.Now().Add(-24*time.Hour))
+		latest, getErr := r.GetLatestSensorDataBySensorID(ctx, "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Zero(t, pruned)
+		assert.NotNil(t, latest)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		_, err := r.RollupSensorData(ctx, time.Now())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestSensorRepository_PruneHourlySensorData(t *testing.T) {
+	t.Run("should delete hourly aggregates and keep daily aggregates", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		_, err := r.RollupSensorData(ctx, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		// when
+		pruned, err := r.PruneHourlySensorData(ctx, time.Now().Add(time.Hour))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), pruned)
+
+		hourly, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionHourly, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, hourly)
+
+		daily, err := r.GetAggregatedSensorDataBySensorID(ctx, "sensor-1", entities.SensorDataResolutionDaily, time.Now().Add(-48*time.Hour), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, daily, 1)
+	})
+}
+
+func TestMergeAggregateBuckets(t *testing.T) {
+	t.Run("should merge aggregates of different trees in the same bucket weighted by sample count", func(t *testing.T) {
+		// given
+		bucket := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+		next := bucket.Add(time.Hour)
+		data := []*entities.SensorDataAggregate{
+			{
+				Timestamp:   bucket,
+				SampleCount: 1,
+				Battery:     3.0,
+				Humidity:    10,
+				Temperature: 10,
+				Watermarks:  []entities.WatermarkAggregate{{Depth: 30, Centibar: 10, Resistance: 100, SampleCount: 1}},
+			},
+			{
+				Timestamp:   bucket,
+				SampleCount: 3,
+				Battery:     4.0,
+				Humidity:    30,
+				Temperature: 20,
+				Watermarks: []entities.WatermarkAggregate{
+					{Depth: 60, Centibar: 40, Resistance: 400, SampleCount: 3},
+					{Depth: 30, Centibar: 30, Resistance: 300, SampleCount: 1},
+				},
+			},
+			{Timestamp: next, SampleCount: 2, Battery: 3.5},
+		}
+
+		// when
+		got := mergeAggregateBuckets(data)
+
+		// then
+		assert.Len(t, got, 2)
+		assert.Equal(t, bucket, got[0].Timestamp)
+		assert.Equal(t, int32(4), got[0].SampleCount)
+		assert.InDelta(t, 3.75, got[0].Battery, 0.001)
+		assert.InDelta(t, 25, got[0].Humidity, 0.001)
+		assert.InDelta(t, 17.5, got[0].Temperature, 0.001)
+		assert.Equal(t, []entities.WatermarkAggregate{
+			{Depth: 30, Centibar: 20, Resistance: 200, SampleCount: 2},
+			{Depth: 60, Centibar: 40, Resistance: 400, SampleCount: 3},
+		}, got[0].Watermarks)
+		assert.Equal(t, next, got[1].Timestamp)
+	})
+}
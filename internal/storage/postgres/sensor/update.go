@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -73,8 +74,16 @@ func (r *SensorRepository) Update(ctx context.Context, id string, updateFn func(
 
 func (r *SensorRepository) updateEntity(ctx context.Context, sensor *entities.Sensor) error {
 	params := sqlc.UpdateSensorParams{
-		ID:     sensor.ID,
-		Status: sqlc.SensorStatus(sensor.Status),
+		ID:               sensor.ID,
+		Status:           sqlc.SensorStatus(sensor.Status),
+		OfflineThreshold: utils.DurationPtrToSeconds(sensor.OfflineThreshold),
+	}
+
+	if sensor.LocationDrift != nil {
+		params.DriftLatitude = &sensor.LocationDrift.Latitude
+		params.DriftLongitude = &sensor.LocationDrift.Longitude
+		params.DriftDistance = &sensor.LocationDrift.Distance
+		params.DriftDetectedAt = utils.TimeToPgTimestamp(&sensor.LocationDrift.DetectedAt)
 	}
 
 	locationParams := &sqlc.SetSensorLocationParams{
@@ -103,3 +112,17 @@ func (r *SensorRepository) validateCoordinates(locationParams *sqlc.SetSensorLoc
 
 	return nil
 }
+
+func (r *SensorRepository) UpdateStatus(ctx context.Context, id string, status entities.SensorStatus) error {
+	log := logger.GetLogger(ctx)
+	if err := r.store.UpdateSensorStatus(ctx, &sqlc.UpdateSensorStatusParams{
+		ID:     id,
+		Status: sqlc.SensorStatus(status),
+	}); err != nil {
+		log.Error("failed to update sensor status in db", "error", err, "sensor_id", id, "status", status)
+		return err
+	}
+
+	log.Debug("sensor status updated successfully in db", "sensor_id", id, "status", status)
+	return nil
+}
@@ -45,6 +45,37 @@ func TestSensorRepository_Update(t *testing.T) {
 		assert.Equal(t, TestMqttPayload, got.LatestData.Data)
 	})
 
+	t.Run("should store and clear location drift", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		drift := &entities.SensorLocationDrift{
+			Latitude:   54.83,
+			Longitude:  9.49,
+			Distance:   1024.5,
+			DetectedAt: time.Date(2025, 1, 26, 9, 0, 0, 0, time.UTC),
+		}
+
+		// when
+		got, err := r.Update(context.Background(), "sensor-2", func(sensor *entities.Sensor) (bool, error) {
+			sensor.LocationDrift = drift
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, drift, got.LocationDrift)
+
+		// when
+		got, err = r.Update(context.Background(), "sensor-2", func(sensor *entities.Sensor) (bool, error) {
+			sensor.LocationDrift = nil
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got.LocationDrift)
+	})
+
 	t.Run("should return error when update sensor with empty name", func(t *testing.T) {
 		// given
 		r := NewSensorRepository(suite.Store, defaultSensorMappers())
@@ -105,3 +136,35 @@ func TestSensorRepository_Update(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+func TestSensorRepository_UpdateStatus(t *testing.T) {
+	t.Run("should update sensor status successfully", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		// when
+		err := r.UpdateStatus(context.Background(), "sensor-1", entities.SensorStatusOffline)
+		got, getErr := r.GetByID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, entities.SensorStatusOffline, got.Status)
+		assert.NotNil(t, got.LatestData)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		err := r.UpdateStatus(ctx, "sensor-1", entities.SensorStatusOffline)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,83 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func defaultSensorAssignmentReview() *entities.SensorAssignmentReview {
+	return &entities.SensorAssignmentReview{
+		SensorID:   "",
+		Status:     entities.SensorAssignmentStatusPending,
+		TreeID:     nil,
+		Candidates: make([]*entities.TreeCandidate, 0),
+	}
+}
+
+func (r *SensorAssignmentRepository) Create(ctx context.Context, createFn func(*entities.SensorAssignmentReview) (bool, error)) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdReview *entities.SensorAssignmentReview
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity := defaultSensorAssignmentReview()
+		created, err := createFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if entity.SensorID == "" {
+			return errors.New("sensor id is required")
+		}
+
+		id, err := r.store.CreateSensorAssignmentReview(ctx, &sqlc.CreateSensorAssignmentReviewParams{
+			SensorID: entity.SensorID,
+			Status:   sqlc.SensorAssignmentStatus(entity.Status),
+			TreeID:   entity.TreeID,
+		})
+		if err != nil {
+			return r.store.MapError(err, sqlc.SensorAssignmentReview{})
+		}
+
+		for _, candidate := range entity.Candidates {
+			if err := r.store.CreateSensorAssignmentCandidate(ctx, &sqlc.CreateSensorAssignmentCandidateParams{
+				ReviewID: id,
+				TreeID:   candidate.TreeID,
+				Distance: candidate.Distance,
+			}); err != nil {
+				return r.store.MapError(err, sqlc.SensorAssignmentCandidate{})
+			}
+		}
+
+		createdReview, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create sensor assignment review entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdReview != nil {
+		log.Debug("sensor assignment review entity created successfully in db", "review_id", createdReview.ID, "sensor_id", createdReview.SensorID)
+	}
+
+	return createdReview, nil
+}
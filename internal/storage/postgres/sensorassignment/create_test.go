@@ -0,0 +1,118 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorAssignmentRepository_Create(t *testing.T) {
+	t.Run("should create review with candidates", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(review *entities.SensorAssignmentReview) (bool, error) {
+			review.SensorID = "sensor-2"
+			review.Candidates = []*entities.TreeCandidate{
+				{TreeID: 2, Distance: 1.5},
+				{TreeID: 1, Distance: 1.2},
+			}
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, int32(3), got.ID)
+		assert.Equal(t, "sensor-2", got.SensorID)
+		assert.Equal(t, entities.SensorAssignmentStatusPending, got.Status)
+		assert.Nil(t, got.TreeID)
+		assert.Len(t, got.Candidates, 2)
+		assert.Equal(t, int32(1), got.Candidates[0].TreeID)
+		assert.Equal(t, int32(2), got.Candidates[1].TreeID)
+	})
+
+	t.Run("should not create review when function returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(review *entities.SensorAssignmentReview) (bool, error) {
+			return false, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when function returns error", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(review *entities.SensorAssignmentReview) (bool, error) {
+			return false, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when sensor id is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(review *entities.SensorAssignmentReview) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should not create review when candidate tree does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(review *entities.SensorAssignmentReview) (bool, error) {
+			review.SensorID = "sensor-2"
+			review.Candidates = []*entities.TreeCandidate{{TreeID: 99, Distance: 1}}
+			return true, nil
+		})
+		all, getErr := r.GetAll(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		assert.NoError(t, getErr)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("should return error when createFn is nil", func(t *testing.T) {
+		// given
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Create(context.Background(), nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
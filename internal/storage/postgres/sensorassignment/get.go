@@ -0,0 +1,72 @@
+package sensorassignment
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *SensorAssignmentRepository) GetAll(ctx context.Context) ([]*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorAssignmentReviews(ctx)
+	if err != nil {
+		log.Debug("failed to get sensor assignment review entities in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.SensorAssignmentReview{})
+	}
+
+	reviews := r.mapper.FromSqlList(rows)
+	if err := r.mapCandidates(ctx, reviews...); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *SensorAssignmentRepository) GetAllByStatus(ctx context.Context, status entities.SensorAssignmentStatus) ([]*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorAssignmentReviewsByStatus(ctx, sqlc.SensorAssignmentStatus(status))
+	if err != nil {
+		log.Debug("failed to get sensor assignment review entities by status in db", "error", err, "review_status", status)
+		return nil, r.store.MapError(err, sqlc.SensorAssignmentReview{})
+	}
+
+	reviews := r.mapper.FromSqlList(rows)
+	if err := r.mapCandidates(ctx, reviews...); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *SensorAssignmentRepository) GetByID(ctx context.Context, id int32) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetSensorAssignmentReviewByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get sensor assignment review entity by provided id", "error", err, "review_id", id)
+		return nil, r.store.MapError(err, sqlc.SensorAssignmentReview{})
+	}
+
+	review := r.mapper.FromSql(row)
+	if err := r.mapCandidates(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *SensorAssignmentRepository) mapCandidates(ctx context.Context, reviews ...*entities.SensorAssignmentReview) error {
+	log := logger.GetLogger(ctx)
+	for _, review := range reviews {
+		rows, err := r.store.GetSensorAssignmentCandidatesByReviewID(ctx, review.ID)
+		if err != nil {
+			log.Debug("failed to get candidates of sensor assignment review", "error", err, "review_id", review.ID)
+			return r.store.MapError(err, sqlc.SensorAssignmentCandidate{})
+		}
+
+		review.Candidates = r.mapper.FromSqlCandidateList(rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package sensorassignment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorAssignmentRepository_GetAll(t *testing.T) {
+	t.Run("should return all reviews newest first with candidates", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[0].ID)
+		assert.Equal(t, int32(1), got[1].ID)
+		assert.Equal(t, "sensor-1", got[1].SensorID)
+		assert.Equal(t, entities.SensorAssignmentStatusPending, got[1].Status)
+		assert.Nil(t, got[1].TreeID)
+		assert.Len(t, got[1].Candidates, 2)
+		assert.Equal(t, int32(1), got[1].Candidates[0].TreeID)
+		assert.Equal(t, 0.33, got[1].Candidates[0].Distance)
+		assert.NotZero(t, got[1].CreatedAt)
+	})
+
+	t.Run("should return empty slice when db is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorAssignmentRepository_GetAllByStatus(t *testing.T) {
+	t.Run("should return reviews with given status", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.GetAllByStatus(context.Background(), entities.SensorAssignmentStatusResolved)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(2), got[0].ID)
+		assert.Equal(t, int32(3), *got[0].TreeID)
+		assert.Len(t, got[0].Candidates, 1)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAllByStatus(ctx, entities.SensorAssignmentStatusPending)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorAssignmentRepository_GetByID(t *testing.T) {
+	t.Run("should return review by id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.ID)
+		assert.Equal(t, "sensor-1", got.SensorID)
+		assert.Len(t, got.Candidates, 2)
+	})
+
+	t.Run("should return error when review not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
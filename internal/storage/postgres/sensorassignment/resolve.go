@@ -0,0 +1,85 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *SensorAssignmentRepository) Resolve(ctx context.Context, id, treeID int32) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+
+	var resolved *entities.SensorAssignmentReview
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// the conditional update locks the review, a concurrent resolve waits and finds it closed afterwards
+		if _, err := r.store.ResolveSensorAssignmentReview(ctx, &sqlc.ResolveSensorAssignmentReviewParams{
+			ID:     id,
+			TreeID: &treeID,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrSensorAssignmentClosed
+			}
+			log.Error("failed to resolve sensor assignment review entity in db", "error", err, "review_id", id)
+			return err
+		}
+
+		if err := r.linkSensorToTree(ctx, entity.SensorID, treeID); err != nil {
+			return err
+		}
+
+		resolved, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("sensor assignment review entity resolved successfully in db", "review_id", id, "tree_id", treeID)
+	return resolved, nil
+}
+
+func (r *SensorAssignmentRepository) linkSensorToTree(ctx context.Context, sensorID string, treeID int32) error {
+	log := logger.GetLogger(ctx)
+	// a sensor is linked to one tree only, like on a tree update
+	if err := r.store.UnlinkTreesOfSensor(ctx, sensorID, treeID); err != nil {
+		log.Error("failed to unlink other trees of sensor", "error", err, "sensor_id", sensorID, "tree_id", treeID)
+		return err
+	}
+
+	_, err := r.store.LinkSensorToTree(ctx, &sqlc.LinkSensorToTreeParams{
+		ID:       treeID,
+		SensorID: &sensorID,
+	})
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Error("failed to link sensor to tree", "error", err, "sensor_id", sensorID, "tree_id", treeID)
+		return err
+	}
+
+	// no tree was updated, either it does not exist or it is already linked to a sensor
+	if _, err := r.store.GetTreeByID(ctx, treeID); err != nil {
+		return r.store.MapError(err, sqlc.Tree{})
+	}
+	return storage.ErrTreeHasSensor
+}
@@ -0,0 +1,100 @@
+package sensorassignment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorAssignmentRepository_Resolve(t *testing.T) {
+	t.Run("should link sensor to tree and resolve review", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Resolve(context.Background(), 1, 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorAssignmentStatusResolved, got.Status)
+		assert.Equal(t, int32(2), *got.TreeID)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-1", linked.ID)
+	})
+
+	t.Run("should resolve review only once", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+		_, err := r.Resolve(context.Background(), 1, 2)
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.Resolve(context.Background(), 1, 1)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrSensorAssignmentClosed)
+		assert.Nil(t, got)
+
+		_, err = suite.Store.GetSensorByTreeID(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not resolve review when tree is already linked to a sensor", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Resolve(context.Background(), 1, 3)
+		review, _ := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrTreeHasSensor)
+		assert.Nil(t, got)
+		assert.Equal(t, entities.SensorAssignmentStatusPending, review.Status)
+		assert.Nil(t, review.TreeID)
+
+		linked, err := suite.Store.GetSensorByTreeID(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "sensor-2", linked.ID)
+	})
+
+	t.Run("should return error when tree not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Resolve(context.Background(), 1, 99)
+		review, _ := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("Tree"))
+		assert.Nil(t, got)
+		assert.Equal(t, entities.SensorAssignmentStatusPending, review.Status)
+	})
+
+	t.Run("should return error when review not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Resolve(context.Background(), 99, 1)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,29 @@
+package sensorassignment
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type SensorAssignmentRepository struct {
+	store *store.Store
+	SensorAssignmentRepositoryMappers
+}
+
+type SensorAssignmentRepositoryMappers struct {
+	mapper mapper.InternalSensorAssignmentRepoMapper
+}
+
+func NewSensorAssignmentRepositoryMappers(saMapper mapper.InternalSensorAssignmentRepoMapper) SensorAssignmentRepositoryMappers {
+	return SensorAssignmentRepositoryMappers{
+		mapper: saMapper,
+	}
+}
+
+func NewSensorAssignmentRepository(s *store.Store, mappers SensorAssignmentRepositoryMappers) storage.SensorAssignmentRepository {
+	return &SensorAssignmentRepository{
+		store:                             s,
+		SensorAssignmentRepositoryMappers: mappers,
+	}
+}
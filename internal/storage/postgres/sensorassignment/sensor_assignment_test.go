@@ -0,0 +1,26 @@
+package sensorassignment
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultSensorAssignmentMappers() SensorAssignmentRepositoryMappers {
+	return NewSensorAssignmentRepositoryMappers(&generated.InternalSensorAssignmentRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
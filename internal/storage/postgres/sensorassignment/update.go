@@ -0,0 +1,61 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *SensorAssignmentRepository) Update(ctx context.Context, id int32, updateFn func(*entities.SensorAssignmentReview) (bool, error)) (*entities.SensorAssignmentReview, error) {
+	log := logger.GetLogger(ctx)
+	if updateFn == nil {
+		return nil, errors.New("updateFn is nil")
+	}
+
+	var updatedReview *entities.SensorAssignmentReview
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err := updateFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			updatedReview = entity
+			return nil
+		}
+
+		if err := r.store.UpdateSensorAssignmentReview(ctx, &sqlc.UpdateSensorAssignmentReviewParams{
+			ID:     entity.ID,
+			Status: sqlc.SensorAssignmentStatus(entity.Status),
+			TreeID: entity.TreeID,
+		}); err != nil {
+			log.Error("failed to update sensor assignment review entity in db", "error", err, "review_id", id)
+			return r.store.MapError(err, sqlc.SensorAssignmentReview{})
+		}
+
+		updatedReview, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("sensor assignment review entity updated successfully in db", "review_id", id)
+	return updatedReview, nil
+}
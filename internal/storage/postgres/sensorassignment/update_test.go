@@ -0,0 +1,96 @@
+package sensorassignment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorAssignmentRepository_Update(t *testing.T) {
+	t.Run("should resolve review with chosen tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, func(review *entities.SensorAssignmentReview) (bool, error) {
+			review.Status = entities.SensorAssignmentStatusResolved
+			review.TreeID = utils.P(int32(2))
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorAssignmentStatusResolved, got.Status)
+		assert.Equal(t, int32(2), *got.TreeID)
+		assert.Len(t, got.Candidates, 2)
+	})
+
+	t.Run("should not update review when function returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, func(review *entities.SensorAssignmentReview) (bool, error) {
+			review.Status = entities.SensorAssignmentStatusDismissed
+			return false, nil
+		})
+		stored, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.NoError(t, getErr)
+		assert.Equal(t, entities.SensorAssignmentStatusPending, stored.Status)
+	})
+
+	t.Run("should return error when function returns error", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorassignment")
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, func(review *entities.SensorAssignmentReview) (bool, error) {
+			return false, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when review not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 99, func(review *entities.SensorAssignmentReview) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when updateFn is nil", func(t *testing.T) {
+		// given
+		r := NewSensorAssignmentRepository(suite.Store, defaultSensorAssignmentMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 1, nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,91 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultSensorCommand() *entities.SensorCommand {
+	return &entities.SensorCommand{
+		SensorID:       "",
+		Type:           "",
+		Status:         entities.SensorCommandStatusQueued,
+		ReportInterval: nil,
+	}
+}
+
+func (r *SensorCommandRepository) Create(ctx context.Context, createFn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdCmd *entities.SensorCommand
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity := defaultSensorCommand()
+		created, err := createFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := r.validateSensorCommand(entity); err != nil {
+			return err
+		}
+
+		id, err := r.store.CreateSensorCommand(ctx, &sqlc.CreateSensorCommandParams{
+			SensorID:       entity.SensorID,
+			Type:           sqlc.SensorCommandType(entity.Type),
+			Status:         sqlc.SensorCommandStatus(entity.Status),
+			ReportInterval: utils.DurationPtrToSeconds(entity.ReportInterval),
+		})
+		if err != nil {
+			return err
+		}
+
+		createdCmd, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create sensor command entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdCmd != nil {
+		log.Debug("sensor command entity created successfully in db", "sensor_command_id", createdCmd.ID, "sensor_id", createdCmd.SensorID)
+	}
+
+	return createdCmd, nil
+}
+
+func (r *SensorCommandRepository) validateSensorCommand(entity *entities.SensorCommand) error {
+	if entity.SensorID == "" {
+		return errors.New("sensor id is required")
+	}
+
+	if entity.Type == "" {
+		return errors.New("command type is required")
+	}
+
+	if entity.Type == entities.SensorCommandTypeSetInterval && entity.ReportInterval == nil {
+		return errors.New("report interval is required for set interval command")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorCommandRepository_Create(t *testing.T) {
+	t.Run("should create queued command", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.SensorID = "sensor-2"
+			cmd.Type = entities.SensorCommandTypeSetInterval
+			cmd.ReportInterval = utils.P(30 * time.Minute)
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, int32(5), got.ID)
+		assert.Equal(t, "sensor-2", got.SensorID)
+		assert.Equal(t, entities.SensorCommandTypeSetInterval, got.Type)
+		assert.Equal(t, entities.SensorCommandStatusQueued, got.Status)
+		assert.Equal(t, 30*time.Minute, *got.ReportInterval)
+		assert.NotZero(t, got.CreatedAt)
+		assert.Nil(t, got.SentAt)
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should not create command when createFn returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.SensorID = "sensor-2"
+			cmd.Type = entities.SensorCommandTypeReboot
+			return false, nil
+		})
+		all, _ := r.GetAllBySensorID(context.Background(), "sensor-2")
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("should return error when set interval command has no interval", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.SensorID = "sensor-1"
+			cmd.Type = entities.SensorCommandTypeSetInterval
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when sensor does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.SensorID = "sensor-99"
+			cmd.Type = entities.SensorCommandTypeReboot
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when createFn returns error", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(cmd *entities.SensorCommand) (bool, error) {
+			return true, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when createFn is nil", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Create(context.Background(), nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
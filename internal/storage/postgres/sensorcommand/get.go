@@ -0,0 +1,42 @@
+package sensorcommand
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *SensorCommandRepository) GetAllBySensorID(ctx context.Context, sensorID string) ([]*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorCommandsBySensorID(ctx, sensorID)
+	if err != nil {
+		log.Debug("failed to get sensor command entities by sensor id in db", "error", err, "sensor_id", sensorID)
+		return nil, r.store.MapError(err, sqlc.SensorCommand{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *SensorCommandRepository) GetAllByStatus(ctx context.Context, status entities.SensorCommandStatus) ([]*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllSensorCommandsByStatus(ctx, sqlc.SensorCommandStatus(status))
+	if err != nil {
+		log.Debug("failed to get sensor command entities by status in db", "error", err, "sensor_command_status", status)
+		return nil, r.store.MapError(err, sqlc.SensorCommand{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *SensorCommandRepository) GetByID(ctx context.Context, id int32) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetSensorCommandByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get sensor command entity by provided id", "error", err, "sensor_command_id", id)
+		return nil, r.store.MapError(err, sqlc.SensorCommand{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
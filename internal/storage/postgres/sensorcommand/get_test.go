@@ -0,0 +1,133 @@
+package sensorcommand
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorCommandRepository_GetAllBySensorID(t *testing.T) {
+	t.Run("should return all commands of sensor newest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.GetAllBySensorID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[0].ID)
+		assert.Equal(t, int32(1), got[1].ID)
+		assert.Equal(t, entities.SensorCommandTypeSetInterval, got[1].Type)
+		assert.Equal(t, entities.SensorCommandStatusAcknowledged, got[1].Status)
+		assert.Equal(t, 15*time.Minute, *got[1].ReportInterval)
+		assert.NotNil(t, got[1].SentAt)
+		assert.NotNil(t, got[1].AcknowledgedAt)
+	})
+
+	t.Run("should return empty slice when sensor has no commands", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.GetAllBySensorID(context.Background(), "sensor-1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAllBySensorID(ctx, "sensor-1")
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandRepository_GetAllByStatus(t *testing.T) {
+	t.Run("should return commands by status oldest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		queued, err := r.GetAllByStatus(context.Background(), entities.SensorCommandStatusQueued)
+		sent, errSent := r.GetAllByStatus(context.Background(), entities.SensorCommandStatusSent)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errSent)
+		assert.Len(t, queued, 2)
+		assert.Equal(t, int32(2), queued[0].ID)
+		assert.Equal(t, int32(4), queued[1].ID)
+		assert.Len(t, sent, 1)
+		assert.Equal(t, int32(3), sent[0].ID)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAllByStatus(ctx, entities.SensorCommandStatusQueued)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSensorCommandRepository_GetByID(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+
+	t.Run("should return command by id", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 3)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), got.ID)
+		assert.Equal(t, "sensor-2", got.SensorID)
+		assert.Equal(t, entities.SensorCommandTypeReboot, got.Type)
+		assert.Equal(t, entities.SensorCommandStatusSent, got.Status)
+		assert.Nil(t, got.ReportInterval)
+		assert.NotNil(t, got.SentAt)
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should return error when command not found", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
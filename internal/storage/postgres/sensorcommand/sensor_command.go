@@ -0,0 +1,29 @@
+package sensorcommand
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type SensorCommandRepository struct {
+	store *store.Store
+	SensorCommandRepositoryMappers
+}
+
+type SensorCommandRepositoryMappers struct {
+	mapper mapper.InternalSensorCommandRepoMapper
+}
+
+func NewSensorCommandRepositoryMappers(cmdMapper mapper.InternalSensorCommandRepoMapper) SensorCommandRepositoryMappers {
+	return SensorCommandRepositoryMappers{
+		mapper: cmdMapper,
+	}
+}
+
+func NewSensorCommandRepository(s *store.Store, mappers SensorCommandRepositoryMappers) storage.SensorCommandRepository {
+	return &SensorCommandRepository{
+		store:                          s,
+		SensorCommandRepositoryMappers: mappers,
+	}
+}
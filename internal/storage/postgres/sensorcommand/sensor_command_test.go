@@ -0,0 +1,26 @@
+package sensorcommand
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultSensorCommandMappers() SensorCommandRepositoryMappers {
+	return NewSensorCommandRepositoryMappers(&generated.InternalSensorCommandRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
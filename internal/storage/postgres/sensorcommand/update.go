@@ -0,0 +1,67 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *SensorCommandRepository) Update(ctx context.Context, id int32, updateFn func(*entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error) {
+	log := logger.GetLogger(ctx)
+	if updateFn == nil {
+		return nil, errors.New("updateFn is nil")
+	}
+
+	var updatedCmd *entities.SensorCommand
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err := updateFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			updatedCmd = entity
+			return nil
+		}
+
+		if err := r.validateSensorCommand(entity); err != nil {
+			return err
+		}
+
+		if err := r.store.UpdateSensorCommand(ctx, &sqlc.UpdateSensorCommandParams{
+			ID:             entity.ID,
+			Status:         sqlc.SensorCommandStatus(entity.Status),
+			SentAt:         utils.TimeToPgTimestamp(entity.SentAt),
+			AcknowledgedAt: utils.TimeToPgTimestamp(entity.AcknowledgedAt),
+		}); err != nil {
+			log.Error("failed to update sensor command entity in db", "error", err, "sensor_command_id", id)
+			return err
+		}
+
+		updatedCmd, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("sensor command entity updated successfully in db", "sensor_command_id", id)
+	return updatedCmd, nil
+}
@@ -0,0 +1,97 @@
+package sensorcommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensorCommandRepository_Update(t *testing.T) {
+	t.Run("should mark command as sent", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+		sentAt := time.Date(2025, 1, 25, 11, 0, 0, 0, time.UTC)
+
+		// when
+		got, err := r.Update(context.Background(), 2, func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.Status = entities.SensorCommandStatusSent
+			cmd.SentAt = &sentAt
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.SensorCommandStatusSent, got.Status)
+		assert.Equal(t, sentAt, got.SentAt.UTC())
+		assert.Nil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should not update command when function returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 2, func(cmd *entities.SensorCommand) (bool, error) {
+			cmd.Status = entities.SensorCommandStatusSent
+			return false, nil
+		})
+		stored, getErr := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.NoError(t, getErr)
+		assert.Equal(t, entities.SensorCommandStatusQueued, stored.Status)
+	})
+
+	t.Run("should return error when function returns error", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensorcommand")
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 2, func(cmd *entities.SensorCommand) (bool, error) {
+			return true, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when command not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 99, func(cmd *entities.SensorCommand) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when updateFn is nil", func(t *testing.T) {
+		// given
+		r := NewSensorCommandRepository(suite.Store, defaultSensorCommandMappers())
+
+		// when
+		got, err := r.Update(context.Background(), 2, nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -5,16 +5,22 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/deadletter"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/flowerbed"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/image"
 	mapper "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/region"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensorassignment"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensorcommand"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/watering_plan"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringrule"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringstatus"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/weather"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -78,14 +84,56 @@ func NewRepository(conn *pgxpool.Pool) *storage.Repository {
 	wateringPlanRepo := wateringplan.NewWateringPlanRepository(store.NewStore(conn, sqlc.New(conn)), wateringPlanMappers)
 	slog.Info("successfully initialized wateringplan repository", "service", "postgres")
 
+	deadLetterMappers := deadletter.NewDeadLetterRepositoryMappers(
+		&mapper.InternalDeadLetterRepoMapperImpl{},
+	)
+	deadLetterRepo := deadletter.NewDeadLetterRepository(store.NewStore(conn, sqlc.New(conn)), deadLetterMappers)
+	slog.Info("successfully initialized dead letter repository", "service", "postgres")
+
+	sensorAssignmentMappers := sensorassignment.NewSensorAssignmentRepositoryMappers(
+		&mapper.InternalSensorAssignmentRepoMapperImpl{},
+	)
+	sensorAssignmentRepo := sensorassignment.NewSensorAssignmentRepository(store.NewStore(conn, sqlc.New(conn)), sensorAssignmentMappers)
+	slog.Info("successfully initialized sensor assignment repository", "service", "postgres")
+
+	sensorCommandMappers := sensorcommand.NewSensorCommandRepositoryMappers(
+		&mapper.InternalSensorCommandRepoMapperImpl{},
+	)
+	sensorCommandRepo := sensorcommand.NewSensorCommandRepository(store.NewStore(conn, sqlc.New(conn)), sensorCommandMappers)
+	slog.Info("successfully initialized sensor command repository", "service", "postgres")
+
+	wateringRuleMappers := wateringrule.NewWateringRuleRepositoryMappers(
+		&mapper.InternalWateringRuleRepoMapperImpl{},
+	)
+	wateringRuleRepo := wateringrule.NewWateringRuleRepository(store.NewStore(conn, sqlc.New(conn)), wateringRuleMappers)
+	slog.Info("successfully initialized watering rule repository", "service", "postgres")
+
+	weatherMappers := weather.NewWeatherRepositoryMappers(
+		&mapper.InternalWeatherRepoMapperImpl{},
+	)
+	weatherRepo := weather.NewWeatherRepository(store.NewStore(conn, sqlc.New(conn)), weatherMappers)
+	slog.Info("successfully initialized weather repository", "service", "postgres")
+
+	wateringStatusMappers := wateringstatus.NewWateringStatusRepositoryMappers(
+		&mapper.InternalWateringStatusRepoMapperImpl{},
+	)
+	wateringStatusRepo := wateringstatus.NewWateringStatusRepository(store.NewStore(conn, sqlc.New(conn)), wateringStatusMappers)
+	slog.Info("successfully initialized watering status repository", "service", "postgres")
+
 	return &storage.Repository{
-		Tree:         treeRepo,
-		TreeCluster:  treeClusterRepo,
-		Image:        imageRepo,
-		Vehicle:      vehicleRepo,
-		Sensor:       sensorRepo,
-		Flowerbed:    flowerbedRepo,
-		Region:       regionRepo,
-		WateringPlan: wateringPlanRepo,
+		Tree:             treeRepo,
+		TreeCluster:      treeClusterRepo,
+		Image:            imageRepo,
+		Vehicle:          vehicleRepo,
+		Sensor:           sensorRepo,
+		Flowerbed:        flowerbedRepo,
+		Region:           regionRepo,
+		WateringPlan:     wateringPlanRepo,
+		DeadLetter:       deadLetterRepo,
+		SensorAssignment: sensorAssignmentRepo,
+		SensorCommand:    sensorCommandRepo,
+		WateringRule:     wateringRuleRepo,
+		Weather:          weatherRepo,
+		WateringStatus:   wateringStatusRepo,
 	}
 }
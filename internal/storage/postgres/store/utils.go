@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
@@ -12,9 +13,10 @@ import (
 )
 
 var (
-	regionMapper = generated.InternalRegionRepoMapperImpl{}
-	treeMapper   = generated.InternalTreeRepoMapperImpl{}
-	sensorMapper = generated.InternalSensorRepoMapperImpl{}
+	regionMapper      = generated.InternalRegionRepoMapperImpl{}
+	treeMapper        = generated.InternalTreeRepoMapperImpl{}
+	sensorMapper      = generated.InternalSensorRepoMapperImpl{}
+	treeClusterMapper = generated.InternalTreeClusterRepoMapperImpl{}
 )
 
 // This function is required as soon as you want to add data to the tree cluster object
@@ -29,6 +31,14 @@ func (s *Store) MapClusterFields(ctx context.Context, tc *entities.TreeCluster)
 		return err
 	}
 
+	if err := s.mapMoistureTrends(ctx, tc); err != nil {
+		return err
+	}
+
+	if err := s.mapMoistureMetrics(ctx, tc); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -42,9 +52,29 @@ func (s *Store) MapSensorFields(ctx context.Context, sn *entities.Sensor) error
 		return err
 	}
 
+	sn.Calibration, err = s.GetSensorCalibration(ctx, sn.ID)
+	if err != nil && !errors.As(err, &entityNotFoundErr) {
+		return err
+	}
+
 	return nil
 }
 
+// This function provides the calibration profile of a specific sensor
+func (s *Store) GetSensorCalibration(ctx context.Context, id string) (*entities.SensorCalibration, error) {
+	row, err := s.GetSensorCalibrationBySensorID(ctx, id)
+	if err != nil {
+		return nil, s.MapError(err, sqlc.SensorCalibration{})
+	}
+
+	calibration, err := sensorMapper.FromSqlCalibration(row)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to map sensor calibration"))
+	}
+
+	return calibration, nil
+}
+
 // This function provides the latest data from a specific sensor
 func (s *Store) GetLatestSensorDataBySensorID(ctx context.Context, id string) (*entities.SensorData, error) {
 	row, err := s.GetLatestSensorDataByID(ctx, id)
@@ -60,6 +90,52 @@ func (s *Store) GetLatestSensorDataBySensorID(ctx context.Context, id string) (*
 	return domainData, nil
 }
 
+// This function records a change of the watering status of a tree or a tree cluster with the cause from the context.
+// As the watering status is changed in different repositories, it has been outsourced. It has to be called on the
+// store of the transaction the watering status is updated in, so the transition is only kept with the update.
+// A change without a cause in the context is rejected, every caller that changes the watering status has to set one.
+func (s *Store) RecordWateringStatusTransition(ctx context.Context, treeID, treeClusterID *int32, oldStatus, newStatus entities.WateringStatus) error {
+	if oldStatus == newStatus {
+		return nil
+	}
+
+	cause, ok := storage.WateringStatusCauseFromContext(ctx)
+	if !ok {
+		logger.GetLogger(ctx).Error("watering status changed without a cause", "tree_id", treeID, "cluster_id", treeClusterID, "old_status", oldStatus, "new_status", newStatus)
+		return storage.ErrWateringStatusCauseMissing
+	}
+
+	return s.CreateWateringStatusTransition(ctx, &sqlc.CreateWateringStatusTransitionParams{
+		TreeID:        treeID,
+		TreeClusterID: treeClusterID,
+		OldStatus:     sqlc.WateringStatus(oldStatus),
+		NewStatus:     sqlc.WateringStatus(newStatus),
+		Cause:         sqlc.WateringStatusCause(cause),
+	})
+}
+
+// This function removes the sensor from all trees except the given one and records the reset of their watering status.
+// As sensors are unlinked from trees in the tree and the sensor repository, it has been outsourced.
+func (s *Store) UnlinkTreesOfSensor(ctx context.Context, sensorID string, exceptTreeID int32) error {
+	rows, err := s.UnlinkSensorIDFromTrees(ctx, &sensorID)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if row.ID == exceptTreeID {
+			continue
+		}
+
+		prevStatus := entities.WateringStatus(row.PrevWateringStatus)
+		if err := s.RecordWateringStatusTransition(ctx, &row.ID, nil, prevStatus, entities.WateringStatusUnknown); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Store) mapRegion(ctx context.Context, tc *entities.TreeCluster) error {
 	region, err := s.getRegionByTreeClusterID(ctx, tc.ID)
 	if err != nil {
@@ -83,6 +159,39 @@ func (s *Store) mapTrees(ctx context.Context, tc *entities.TreeCluster) error {
 	return nil
 }
 
+func (s *Store) mapMoistureTrends(ctx context.Context, tc *entities.TreeCluster) error {
+	rows, err := s.GetMoistureTrendsByTreeClusterID(ctx, tc.ID)
+	if err != nil {
+		return err
+	}
+	tc.MoistureTrends = treeClusterMapper.FromSqlMoistureTrendList(rows)
+
+	return nil
+}
+
+func (s *Store) mapMoistureMetrics(ctx context.Context, tc *entities.TreeCluster) error {
+	row, err := s.GetMoistureMetricsByTreeClusterID(ctx, tc.ID)
+	if err != nil {
+		// If the tree cluster has no sensor data, there are no moisture metrics
+		if errors.Is(err, pgx.ErrNoRows) {
+			tc.MoistureMetrics = nil
+			return nil
+		}
+		return err
+	}
+
+	depths, err := s.GetMoistureDepthsByTreeClusterID(ctx, tc.ID)
+	if err != nil {
+		return err
+	}
+
+	metrics := treeClusterMapper.FromSqlMoistureMetrics(row)
+	metrics.Depths = treeClusterMapper.FromSqlMoistureDepthList(depths)
+	tc.MoistureMetrics = metrics
+
+	return nil
+}
+
 func (s *Store) getRegionByTreeClusterID(ctx context.Context, id int32) (*entities.Region, error) {
 	row, err := s.GetRegionByTreeClusterID(ctx, id)
 	if err != nil {
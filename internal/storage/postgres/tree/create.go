@@ -82,7 +82,7 @@ func (r *TreeRepository) createEntity(ctx context.Context, entity *entities.Tree
 	var sensorID *string
 	if entity.Sensor != nil {
 		sensorID = &entity.Sensor.ID
-		if err := r.store.UnlinkSensorIDFromTrees(ctx, sensorID); err != nil {
+		if err := r.store.UnlinkTreesOfSensor(ctx, entity.Sensor.ID, 0); err != nil {
 			return -1, err
 		}
 	}
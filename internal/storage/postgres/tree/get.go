@@ -238,3 +238,27 @@ func (r *TreeRepository) FindNearestTree(ctx context.Context, latitude, longitud
 	}
 	return tree, nil
 }
+
+func (r *TreeRepository) FindNearestUnassignedTrees(ctx context.Context, latitude, longitude, radius float64, limit int32) ([]*entities.TreeCandidate, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.FindNearestUnassignedTrees(ctx, &sqlc.FindNearestUnassignedTreesParams{
+		Latitude:   latitude,
+		Longitude:  longitude,
+		Radius:     radius,
+		MaxResults: limit,
+	})
+	if err != nil {
+		log.Debug("failed to find nearest unassigned trees on given coordinates", "error", err, "latitude", latitude, "longitude", longitude, "radius", radius)
+		return nil, r.store.MapError(err, sqlc.Tree{})
+	}
+
+	candidates := make([]*entities.TreeCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = &entities.TreeCandidate{
+			TreeID:   row.ID,
+			Distance: row.Distance,
+		}
+	}
+
+	return candidates, nil
+}
@@ -699,6 +699,66 @@ func TestTreeRepository_FindNearestTree(t *testing.T) {
 	})
 }
 
+func TestTreeRepository_FindNearestUnassignedTrees(t *testing.T) {
+	t.Run("should return unassigned trees within radius nearest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.FindNearestUnassignedTrees(context.Background(), 54.821517, 9.487169, 1000, 5)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(2), got[0].TreeID)
+		assert.Less(t, got[0].Distance, 3.0)
+	})
+
+	t.Run("should not return trees which already have a sensor", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.FindNearestUnassignedTrees(context.Background(), 54.82124518093376, 9.485702120628517, 3, 5)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should limit the number of returned trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.FindNearestUnassignedTrees(context.Background(), 54.821517, 9.487169, 1000, 0)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error if context is canceled", func(t *testing.T) {
+		// given
+		r := NewTreeRepository(suite.Store, mappers)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.FindNearestUnassignedTrees(ctx, 54.821517, 9.487169, 3, 5)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
 func assertExpectedEqualToTree(t *testing.T, expectedTree, tree *entities.Tree) {
 	assert.Equal(t, expectedTree.ID, tree.ID, "ID does not match")
 	assert.Equal(t, expectedTree.PlantingYear, tree.PlantingYear, "PlantingYear does not match")
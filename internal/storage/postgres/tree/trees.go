@@ -187,5 +187,5 @@ func (r *TreeRepository) UnlinkSensorID(ctx context.Context, sensorID string) er
 	if sensorID == "" {
 		return errors.New("sensorID cannot be empty")
 	}
-	return r.store.UnlinkSensorIDFromTrees(ctx, &sensorID)
+	return r.store.UnlinkTreesOfSensor(ctx, sensorID, 0)
 }
@@ -6,23 +6,42 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/pkg/errors"
 )
 
 func (r *TreeRepository) Update(ctx context.Context, id int32, tFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
 	log := logger.GetLogger(ctx)
-	entity, err := r.GetByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	for _, fn := range tFn {
-		fn(entity)
-	}
+		prevStatus := entity.WateringStatus
+		for _, fn := range tFn {
+			fn(entity)
+		}
 
-	err = r.updateEntity(ctx, entity)
+		if err := r.updateEntity(ctx, entity); err != nil {
+			log.Error("failed to update tree entity in db", "error", err, "tree_id", id)
+			return err
+		}
+
+		if err := r.store.RecordWateringStatusTransition(ctx, &id, nil, prevStatus, entity.WateringStatus); err != nil {
+			log.Error("failed to record watering status transition of tree in db", "error", err, "tree_id", id)
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		log.Error("failed to update tree entity in db", "error", err, "tree_id", id)
 		return nil, err
 	}
 
@@ -64,7 +83,7 @@ func (r *TreeRepository) updateEntity(ctx context.Context, t *entities.Tree) err
 	if t.Sensor != nil {
 		sensorID = &t.Sensor.ID
 
-		if err := r.store.UnlinkSensorIDFromTrees(ctx, sensorID); err != nil {
+		if err := r.store.UnlinkTreesOfSensor(ctx, t.Sensor.ID, t.ID); err != nil {
 			return err
 		}
 	}
@@ -3,8 +3,12 @@ package tree
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +29,7 @@ func TestTreeRepository_Update(t *testing.T) {
 
 		// when
 		updatedTree, err := r.Update(
-			context.Background(),
+			storage.WithWateringStatusCause(context.Background(), entities.WateringStatusCauseManual),
 			treeID,
 			WithSpecies(newSpecies),
 			WithNumber(newNumber),
@@ -50,6 +54,73 @@ func TestTreeRepository_Update(t *testing.T) {
 		assert.Equal(t, newWateringStatus, updatedTree.WateringStatus, "Watering Status should match")
 	})
 
+	t.Run("should record watering status transition with cause from context", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+		ctx := storage.WithWateringStatusCause(context.Background(), entities.WateringStatusCauseSensorData)
+
+		// when
+		_, err := r.Update(ctx, 1, WithWateringStatus(entities.WateringStatusBad))
+		transitions, getErr := suite.Store.GetWateringStatusTransitionsByTreeID(context.Background(), &sqlc.GetWateringStatusTransitionsByTreeIDParams{
+			TreeID: utils.P(int32(1)),
+			Since:  utils.TimeToPgTimestamp(utils.P(time.Time{})),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, transitions, 1)
+		assert.Equal(t, sqlc.WateringStatusUnknown, transitions[0].OldStatus)
+		assert.Equal(t, sqlc.WateringStatusBad, transitions[0].NewStatus)
+		assert.Equal(t, sqlc.WateringStatusCauseSensorData, transitions[0].Cause)
+	})
+
+	t.Run("should record reset of watering status when sensor is moved to another tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+		ctx := storage.WithWateringStatusCause(context.Background(), entities.WateringStatusCauseManual)
+		_, err := r.Update(ctx, 1, WithWateringStatus(entities.WateringStatusGood))
+		assert.NoError(t, err)
+		sensor, err := r.GetSensorByTreeID(context.Background(), 1)
+		assert.NoError(t, err)
+
+		// when
+		_, err = r.Update(ctx, 2, WithSensor(sensor))
+		transitions, getErr := suite.Store.GetWateringStatusTransitionsByTreeID(context.Background(), &sqlc.GetWateringStatusTransitionsByTreeIDParams{
+			TreeID: utils.P(int32(1)),
+			Since:  utils.TimeToPgTimestamp(utils.P(time.Time{})),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, transitions, 2)
+		assert.Equal(t, sqlc.WateringStatusGood, transitions[1].OldStatus)
+		assert.Equal(t, sqlc.WateringStatusUnknown, transitions[1].NewStatus)
+		assert.Equal(t, sqlc.WateringStatusCauseManual, transitions[1].Cause)
+	})
+
+	t.Run("should return error when watering status changes without a cause", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		updatedTree, err := r.Update(context.Background(), 1, WithWateringStatus(entities.WateringStatusBad))
+		got, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrWateringStatusCauseMissing)
+		assert.Nil(t, updatedTree)
+		assert.NoError(t, getErr)
+		assert.Equal(t, entities.WateringStatusUnknown, got.WateringStatus)
+	})
+
 	t.Run("should return error when is not found", func(t *testing.T) {
 		// given
 		suite.ResetDB(t)
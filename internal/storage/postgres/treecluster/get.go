@@ -8,18 +8,26 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 	"github.com/twpayne/go-geos"
 )
 
-func (r *TreeClusterRepository) GetAll(ctx context.Context) ([]*entities.TreeCluster, int64, error) {
+func (r *TreeClusterRepository) GetAll(ctx context.Context, query entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error) {
 	log := logger.GetLogger(ctx)
 	page, limit, err := pagination.GetValues(ctx)
 	if err != nil {
 		return nil, 0, r.store.MapError(err, sqlc.TreeCluster{})
 	}
 
-	totalCount, err := r.store.GetAllTreeClustersCount(ctx)
+	totalCount, err := r.store.GetAllTreeClustersCount(ctx, &sqlc.GetAllTreeClustersCountParams{
+		MinCoverage:      query.MinCoverage,
+		MaxCoverage:      query.MaxCoverage,
+		MeasuredAfter:    utils.TimeToPgTimestamp(query.MeasuredAfter),
+		MeasuredBefore:   utils.TimeToPgTimestamp(query.MeasuredBefore),
+		MinMoistureLevel: query.MinMoistureLevel,
+		MaxMoistureLevel: query.MaxMoistureLevel,
+	})
 	if err != nil {
 		log.Debug("failed to get total tree cluster count in db", "error", err)
 		return nil, 0, r.store.MapError(err, sqlc.TreeCluster{})
@@ -35,8 +43,15 @@ func (r *TreeClusterRepository) GetAll(ctx context.Context) ([]*entities.TreeClu
 	}
 
 	rows, err := r.store.GetAllTreeClusters(ctx, &sqlc.GetAllTreeClustersParams{
-		Limit:  limit,
-		Offset: (page - 1) * limit,
+		MinCoverage:      query.MinCoverage,
+		MaxCoverage:      query.MaxCoverage,
+		MeasuredAfter:    utils.TimeToPgTimestamp(query.MeasuredAfter),
+		MeasuredBefore:   utils.TimeToPgTimestamp(query.MeasuredBefore),
+		MinMoistureLevel: query.MinMoistureLevel,
+		MaxMoistureLevel: query.MaxMoistureLevel,
+		SortBy:           string(query.SortBy),
+		Limit:            limit,
+		Offset:           (page - 1) * limit,
 	})
 
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +21,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "page", int32(1))
 		ctx = context.WithValue(ctx, "limit", int32(-1))
 
-		got, totalCount, err := r.GetAll(ctx)
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -65,7 +68,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx)
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -83,6 +86,144 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		}
 	})
 
+	t.Run("should return tree clusters ordered by estimated bad watering status", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+		soon, later := measuredAt.Add(24*time.Hour), measuredAt.Add(72*time.Hour)
+		assert.NoError(t, r.SaveMoistureTrends(context.Background(), 4, []*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: 1, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &measuredAt, BadAt: &later},
+			{Depth: 60, CentibarPerDay: 2, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &measuredAt, BadAt: &soon},
+		}))
+		assert.NoError(t, r.SaveMoistureTrends(context.Background(), 3, []*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: 1, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &soon, BadAt: &later},
+		}))
+		assert.NoError(t, r.SaveMoistureTrends(context.Background(), 2, []*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: -1, SampleCount: 3, MeasuredAt: measuredAt},
+		}))
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{SortBy: entities.TreeClusterSortDaysUntilBad})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, len(allTestCluster))
+		assert.Equal(t, totalCount, int64(len(allTestCluster)))
+		assert.Equal(t, int32(4), got[0].ID)
+		assert.Equal(t, int32(3), got[1].ID)
+		assert.Len(t, got[0].MoistureTrends, 2)
+
+		// tree clusters without estimation are ordered by name
+		sortedTestCluster := sortClusterByName(allTestCluster)
+		rest := make([]string, 0, len(sortedTestCluster))
+		for _, tc := range sortedTestCluster {
+			if tc.ID != 3 && tc.ID != 4 {
+				rest = append(rest, tc.Name)
+			}
+		}
+		for i, tc := range got[2:] {
+			assert.Equal(t, rest[i], tc.Name)
+		}
+	})
+
+	t.Run("should return tree clusters ordered by estimated moderate watering status", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+		soon := measuredAt.Add(24 * time.Hour)
+		assert.NoError(t, r.SaveMoistureTrends(context.Background(), 5, []*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: 1, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &soon},
+		}))
+		assert.NoError(t, r.SaveMoistureTrends(context.Background(), 6, []*entities.MoistureTrend{
+			{Depth: 30, CentibarPerDay: 1, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &measuredAt},
+		}))
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(2))
+
+		// when
+		got, _, err := r.GetAll(ctx, entities.TreeClusterQuery{SortBy: entities.TreeClusterSortDaysUntilModerate})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(6), got[0].ID)
+		assert.Equal(t, int32(5), got[1].ID)
+	})
+
+	t.Run("should return tree clusters filtered by moisture metrics", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+		outdated := measuredAt.Add(-72 * time.Hour)
+		assert.NoError(t, r.SaveMoistureMetrics(context.Background(), 1, &entities.MoistureMetrics{
+			TreeCount: 4, SensorCount: 4, Coverage: 1, LatestMeasuredAt: measuredAt, OldestMeasuredAt: measuredAt,
+		}, 40))
+		assert.NoError(t, r.SaveMoistureMetrics(context.Background(), 2, &entities.MoistureMetrics{
+			TreeCount: 4, SensorCount: 2, Coverage: 0.5, LatestMeasuredAt: measuredAt, OldestMeasuredAt: outdated,
+		}, 20))
+		assert.NoError(t, r.SaveMoistureMetrics(context.Background(), 3, &entities.MoistureMetrics{
+			TreeCount: 4, SensorCount: 1, Coverage: 0.25, LatestMeasuredAt: outdated, OldestMeasuredAt: outdated,
+		}, 10))
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+		measuredAfter := measuredAt.Add(-24 * time.Hour)
+
+		// when
+		gotCoverage, totalCoverage, errCoverage := r.GetAll(ctx, entities.TreeClusterQuery{MinCoverage: utils.P(0.5)})
+		gotFresh, totalFresh, errFresh := r.GetAll(ctx, entities.TreeClusterQuery{MeasuredAfter: &measuredAfter})
+		gotMoisture, totalMoisture, errMoisture := r.GetAll(ctx, entities.TreeClusterQuery{MinMoistureLevel: utils.P(15.0), MaxMoistureLevel: utils.P(30.0)})
+		gotUncovered, totalUncovered, errUncovered := r.GetAll(ctx, entities.TreeClusterQuery{MaxCoverage: utils.P(0.25)})
+
+		// then
+		assert.NoError(t, errCoverage)
+		assert.Equal(t, int64(2), totalCoverage)
+		assert.ElementsMatch(t, []int32{1, 2}, clusterIDs(gotCoverage))
+		assert.NotNil(t, gotCoverage[0].MoistureMetrics)
+
+		assert.NoError(t, errFresh)
+		assert.Equal(t, int64(2), totalFresh)
+		assert.ElementsMatch(t, []int32{1, 2}, clusterIDs(gotFresh))
+
+		assert.NoError(t, errMoisture)
+		assert.Equal(t, int64(1), totalMoisture)
+		assert.Equal(t, []int32{2}, clusterIDs(gotMoisture))
+
+		// tree clusters without sensor data have no coverage
+		assert.NoError(t, errUncovered)
+		assert.Equal(t, int64(len(allTestCluster)-2), totalUncovered)
+		assert.NotContains(t, clusterIDs(gotUncovered), int32(1))
+		assert.NotContains(t, clusterIDs(gotUncovered), int32(2))
+	})
+
+	t.Run("should return empty slice when no tree cluster matches the filters", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{MinCoverage: utils.P(0.1)})
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Equal(t, int64(0), totalCount)
+	})
+
 	t.Run("should return error on invalid page value", func(t *testing.T) {
 		// given
 		suite.ResetDB(t)
@@ -93,7 +234,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx)
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -111,7 +252,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(0))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx)
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -128,7 +269,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx)
+		got, totalCount, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -143,7 +284,7 @@ func TestTreeClusterRepository_GetAll(t *testing.T) {
 		cancel()
 
 		// when
-		_, _, err := r.GetAll(ctx)
+		_, _, err := r.GetAll(ctx, entities.TreeClusterQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -417,3 +558,12 @@ func sortClusterByName(data []*testTreeCluster) []*testTreeCluster {
 
 	return sorted
 }
+
+func clusterIDs(data []*entities.TreeCluster) []int32 {
+	ids := make([]int32, len(data))
+	for i, tc := range data {
+		ids[i] = tc.ID
+	}
+
+	return ids
+}
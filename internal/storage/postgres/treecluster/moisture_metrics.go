@@ -0,0 +1,67 @@
+package treecluster
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *TreeClusterRepository) SaveMoistureMetrics(ctx context.Context, tcID int32, metrics *entities.MoistureMetrics, moistureLevel float64) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.DeleteMoistureDepthsByTreeClusterID(ctx, tcID); err != nil {
+			return s.MapError(err, sqlc.TreeClusterMoistureDepth{})
+		}
+
+		if metrics == nil {
+			if err := s.DeleteMoistureMetricsByTreeClusterID(ctx, tcID); err != nil {
+				return s.MapError(err, sqlc.TreeClusterMoistureMetric{})
+			}
+		} else {
+			if err := s.UpsertMoistureMetrics(ctx, &sqlc.UpsertMoistureMetricsParams{
+				TreeClusterID:    tcID,
+				TreeCount:        metrics.TreeCount,
+				SensorCount:      metrics.SensorCount,
+				Coverage:         metrics.Coverage,
+				LatestMeasuredAt: utils.TimeToPgTimestamp(&metrics.LatestMeasuredAt),
+				OldestMeasuredAt: utils.TimeToPgTimestamp(&metrics.OldestMeasuredAt),
+			}); err != nil {
+				return s.MapError(err, sqlc.TreeClusterMoistureMetric{})
+			}
+
+			for _, depth := range metrics.Depths {
+				if err := s.CreateMoistureDepth(ctx, &sqlc.CreateMoistureDepthParams{
+					TreeClusterID: tcID,
+					Depth:         int32(depth.Depth),
+					SensorCount:   depth.SensorCount,
+					MinCentibar:   depth.MinCentibar,
+					MeanCentibar:  depth.MeanCentibar,
+					MaxCentibar:   depth.MaxCentibar,
+				}); err != nil {
+					return s.MapError(err, sqlc.TreeClusterMoistureDepth{})
+				}
+			}
+		}
+
+		if err := s.SetTreeClusterMoistureLevel(ctx, &sqlc.SetTreeClusterMoistureLevelParams{
+			ID:            tcID,
+			MoistureLevel: moistureLevel,
+		}); err != nil {
+			return s.MapError(err, sqlc.TreeCluster{})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error("failed to save moisture metrics of tree cluster in db", "error", err, "cluster_id", tcID)
+		return err
+	}
+
+	log.Debug("moisture metrics of tree cluster saved successfully in db", "cluster_id", tcID, "moisture_level", moistureLevel)
+	return nil
+}
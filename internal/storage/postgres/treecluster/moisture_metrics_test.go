@@ -0,0 +1,123 @@
+package treecluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeClusterRepository_SaveMoistureMetrics(t *testing.T) {
+	latest := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	oldest := time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+
+	t.Run("should replace moisture metrics and set moisture level of tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		err := r.SaveMoistureMetrics(context.Background(), 1, &entities.MoistureMetrics{
+			TreeCount:        4,
+			SensorCount:      1,
+			Coverage:         0.25,
+			LatestMeasuredAt: oldest,
+			OldestMeasuredAt: oldest,
+			Depths: []*entities.DepthMoisture{
+				{Depth: 30, SensorCount: 1, MinCentibar: 10, MeanCentibar: 10, MaxCentibar: 10},
+				{Depth: 90, SensorCount: 1, MinCentibar: 30, MeanCentibar: 30, MaxCentibar: 30},
+			},
+		}, 20)
+		assert.NoError(t, err)
+
+		// when
+		err = r.SaveMoistureMetrics(context.Background(), 1, &entities.MoistureMetrics{
+			TreeCount:        4,
+			SensorCount:      2,
+			Coverage:         0.5,
+			LatestMeasuredAt: latest,
+			OldestMeasuredAt: oldest,
+			Depths: []*entities.DepthMoisture{
+				{Depth: 30, SensorCount: 2, MinCentibar: 10, MeanCentibar: 15, MaxCentibar: 20},
+				{Depth: 60, SensorCount: 2, MinCentibar: 20, MeanCentibar: 25, MaxCentibar: 30},
+			},
+		}, 20)
+		got, errGot := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGot)
+		assert.Equal(t, 20.0, got.MoistureLevel)
+		assert.NotNil(t, got.MoistureMetrics)
+		assert.Equal(t, int32(4), got.MoistureMetrics.TreeCount)
+		assert.Equal(t, int32(2), got.MoistureMetrics.SensorCount)
+		assert.Equal(t, 0.5, got.MoistureMetrics.Coverage)
+		assert.Equal(t, latest, got.MoistureMetrics.LatestMeasuredAt)
+		assert.Equal(t, oldest, got.MoistureMetrics.OldestMeasuredAt)
+		assert.False(t, got.MoistureMetrics.UpdatedAt.IsZero())
+		assert.Len(t, got.MoistureMetrics.Depths, 2)
+		assert.Equal(t, 30, got.MoistureMetrics.Depths[0].Depth)
+		assert.Equal(t, int32(2), got.MoistureMetrics.Depths[0].SensorCount)
+		assert.Equal(t, 10.0, got.MoistureMetrics.Depths[0].MinCentibar)
+		assert.Equal(t, 15.0, got.MoistureMetrics.Depths[0].MeanCentibar)
+		assert.Equal(t, 20.0, got.MoistureMetrics.Depths[0].MaxCentibar)
+		assert.Equal(t, 60, got.MoistureMetrics.Depths[1].Depth)
+	})
+
+	t.Run("should delete moisture metrics of tree cluster when saving no metrics", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		err := r.SaveMoistureMetrics(context.Background(), 1, &entities.MoistureMetrics{
+			TreeCount:        4,
+			SensorCount:      1,
+			Coverage:         0.25,
+			LatestMeasuredAt: latest,
+			OldestMeasuredAt: latest,
+			Depths: []*entities.DepthMoisture{
+				{Depth: 30, SensorCount: 1, MinCentibar: 10, MeanCentibar: 10, MaxCentibar: 10},
+			},
+		}, 10)
+		assert.NoError(t, err)
+
+		// when
+		err = r.SaveMoistureMetrics(context.Background(), 1, nil, 0)
+		got, errGot := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGot)
+		assert.Nil(t, got.MoistureMetrics)
+		assert.Equal(t, 0.0, got.MoistureLevel)
+	})
+
+	t.Run("should return error when tree cluster does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		// when
+		err := r.SaveMoistureMetrics(context.Background(), 99, &entities.MoistureMetrics{
+			LatestMeasuredAt: latest,
+			OldestMeasuredAt: latest,
+		}, 10)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		err := r.SaveMoistureMetrics(ctx, 1, nil, 0)
+
+		// then
+		assert.Error(t, err)
+	})
+}
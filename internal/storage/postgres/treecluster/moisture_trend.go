@@ -0,0 +1,70 @@
+package treecluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *TreeClusterRepository) CalculateMoistureTrends(ctx context.Context, tcID int32, since time.Time) ([]*entities.MoistureTrend, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.CalculateMoistureTrendsByTreeClusterID(ctx, &sqlc.CalculateMoistureTrendsByTreeClusterIDParams{
+		TreeClusterID: &tcID,
+		Since:         utils.TimeToPgTimestamp(&since),
+	})
+	if err != nil {
+		log.Debug("failed to calculate moisture trends of tree cluster in db", "error", err, "cluster_id", tcID)
+		return nil, r.store.MapError(err, sqlc.TreeClusterMoistureTrend{})
+	}
+
+	trends := make([]*entities.MoistureTrend, len(rows))
+	for i, row := range rows {
+		trends[i] = &entities.MoistureTrend{
+			Depth:          int(row.Depth),
+			CentibarPerDay: row.CentibarPerDay,
+			SampleCount:    row.SampleCount,
+			MeasuredAt:     utils.PgTimestampToTime(row.MeasuredAt),
+		}
+	}
+
+	return trends, nil
+}
+
+func (r *TreeClusterRepository) SaveMoistureTrends(ctx context.Context, tcID int32, trends []*entities.MoistureTrend) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.DeleteMoistureTrendsByTreeClusterID(ctx, tcID); err != nil {
+			return s.MapError(err, sqlc.TreeClusterMoistureTrend{})
+		}
+
+		for _, trend := range trends {
+			if err := s.CreateMoistureTrend(ctx, &sqlc.CreateMoistureTrendParams{
+				TreeClusterID:  tcID,
+				Depth:          int32(trend.Depth),
+				Centibar:       trend.Centibar,
+				CentibarPerDay: trend.CentibarPerDay,
+				SampleCount:    trend.SampleCount,
+				MeasuredAt:     utils.TimeToPgTimestamp(&trend.MeasuredAt),
+				ModerateAt:     utils.TimeToPgTimestamp(trend.ModerateAt),
+				BadAt:          utils.TimeToPgTimestamp(trend.BadAt),
+			}); err != nil {
+				return s.MapError(err, sqlc.TreeClusterMoistureTrend{})
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error("failed to save moisture trends of tree cluster in db", "error", err, "cluster_id", tcID)
+		return err
+	}
+
+	log.Debug("moisture trends of tree cluster saved successfully in db", "cluster_id", tcID, "trends", len(trends))
+	return nil
+}
@@ -0,0 +1,142 @@
+package treecluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeClusterRepository_CalculateMoistureTrends(t *testing.T) {
+	t.Run("should fit trend per depth to sensor data of tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		rows, err := suite.ExecQuery(t, `INSERT INTO sensor_data (sensor_id, created_at, flagged, data) VALUES
+			('sensor-1', '2025-05-20 12:00:00', false, '{"watermarks": [{"centibar": 40, "resistance": 23, "depth": 30}, {"centibar": 40, "resistance": 23, "depth": 60}]}'),
+			('sensor-1', '2025-06-01 12:00:00', false, '{"watermarks": [{"centibar": 10, "resistance": 23, "depth": 30}, {"centibar": 20, "resistance": 23, "depth": 60}]}'),
+			('sensor-1', '2025-06-02 12:00:00', false, '{"watermarks": [{"centibar": 12, "resistance": 23, "depth": 30}, {"centibar": 21, "resistance": 23, "depth": 60}]}'),
+			('sensor-1', '2025-06-02 18:00:00', true, '{"watermarks": [{"centibar": 99, "resistance": 23, "depth": 30}, {"centibar": 99, "resistance": 23, "depth": 60}]}'),
+			('sensor-1', '2025-06-03 12:00:00', false, '{"watermarks": [{"centibar": 14, "resistance": 23, "depth": 30}, {"centibar": 22, "resistance": 23, "depth": 60}]}')`)
+		assert.NoError(t, err)
+		rows.Close()
+
+		// when
+		got, err := r.CalculateMoistureTrends(context.Background(), 1, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, 30, got[0].Depth)
+		assert.InDelta(t, 2.0, got[0].CentibarPerDay, 0.001)
+		assert.Equal(t, int32(3), got[0].SampleCount)
+		assert.Equal(t, time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC), got[0].MeasuredAt)
+		assert.Equal(t, 60, got[1].Depth)
+		assert.InDelta(t, 1.0, got[1].CentibarPerDay, 0.001)
+	})
+
+	t.Run("should return empty slice when sensors have less than two readings", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.CalculateMoistureTrends(context.Background(), 50, time.Date(2025, 1, 3, 0, 41, 8, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.CalculateMoistureTrends(ctx, 1, time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeClusterRepository_SaveMoistureTrends(t *testing.T) {
+	measuredAt := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	moderateAt := time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC)
+	badAt := time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should replace moisture trends of tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		err := r.SaveMoistureTrends(context.Background(), 1, []*entities.MoistureTrend{
+			{Depth: 30, Centibar: 10, CentibarPerDay: 1, SampleCount: 2, MeasuredAt: measuredAt},
+			{Depth: 90, Centibar: 10, CentibarPerDay: 1, SampleCount: 2, MeasuredAt: measuredAt},
+		})
+		assert.NoError(t, err)
+
+		// when
+		err = r.SaveMoistureTrends(context.Background(), 1, []*entities.MoistureTrend{
+			{Depth: 30, Centibar: 14, CentibarPerDay: 2, SampleCount: 3, MeasuredAt: measuredAt, ModerateAt: &moderateAt, BadAt: &badAt},
+			{Depth: 60, Centibar: 22, CentibarPerDay: -1, SampleCount: 3, MeasuredAt: measuredAt},
+		})
+		got, errGot := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGot)
+		assert.Len(t, got.MoistureTrends, 2)
+		assert.Equal(t, 30, got.MoistureTrends[0].Depth)
+		assert.Equal(t, 14.0, got.MoistureTrends[0].Centibar)
+		assert.Equal(t, 2.0, got.MoistureTrends[0].CentibarPerDay)
+		assert.Equal(t, int32(3), got.MoistureTrends[0].SampleCount)
+		assert.Equal(t, measuredAt, got.MoistureTrends[0].MeasuredAt)
+		assert.Equal(t, moderateAt, *got.MoistureTrends[0].ModerateAt)
+		assert.Equal(t, badAt, *got.MoistureTrends[0].BadAt)
+		assert.Equal(t, 60, got.MoistureTrends[1].Depth)
+		assert.Nil(t, got.MoistureTrends[1].ModerateAt)
+		assert.Nil(t, got.MoistureTrends[1].BadAt)
+	})
+
+	t.Run("should delete moisture trends of tree cluster when saving no trends", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		err := r.SaveMoistureTrends(context.Background(), 1, []*entities.MoistureTrend{
+			{Depth: 30, Centibar: 10, CentibarPerDay: 1, SampleCount: 2, MeasuredAt: measuredAt},
+		})
+		assert.NoError(t, err)
+
+		// when
+		err = r.SaveMoistureTrends(context.Background(), 1, nil)
+		got, errGot := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGot)
+		assert.Empty(t, got.MoistureTrends)
+	})
+
+	t.Run("should return error when tree cluster does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		// when
+		err := r.SaveMoistureTrends(context.Background(), 99, []*entities.MoistureTrend{
+			{Depth: 30, Centibar: 10, CentibarPerDay: 1, SampleCount: 2, MeasuredAt: measuredAt},
+		})
+
+		// then
+		assert.Error(t, err)
+	})
+}
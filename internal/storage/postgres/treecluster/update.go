@@ -29,6 +29,7 @@ func (r *TreeClusterRepository) Update(ctx context.Context, id int32, updateFn f
 			return errors.New("updateFn is nil")
 		}
 
+		prevStatus := tc.WateringStatus
 		updated, err := updateFn(tc)
 		if err != nil {
 			return err
@@ -38,8 +39,15 @@ func (r *TreeClusterRepository) Update(ctx context.Context, id int32, updateFn f
 			return nil
 		}
 
+		// the transition is only recorded together with the update, a failed update rolls back both
 		if err := r.updateEntity(ctx, tc); err != nil {
 			log.Error("failed to update tree cluster entity in db", "error", err, "cluster_id", id)
+			return err
+		}
+
+		if err := r.store.RecordWateringStatusTransition(ctx, nil, &id, prevStatus, tc.WateringStatus); err != nil {
+			log.Error("failed to record watering status transition of tree cluster in db", "error", err, "cluster_id", id)
+			return err
 		}
 
 		log.Debug("tree cluster updated successfully in db", "cluster_id", id)
@@ -54,16 +62,18 @@ func (r *TreeClusterRepository) updateEntity(ctx context.Context, tc *entities.T
 		regionID = &tc.Region.ID
 	}
 	args := sqlc.UpdateTreeClusterParams{
-		ID:             tc.ID,
-		RegionID:       regionID,
-		Address:        tc.Address,
-		Description:    tc.Description,
-		MoistureLevel:  tc.MoistureLevel,
-		WateringStatus: sqlc.WateringStatus(tc.WateringStatus),
-		SoilCondition:  sqlc.TreeSoilCondition(tc.SoilCondition),
-		LastWatered:    utils.TimeToPgTimestamp(tc.LastWatered),
-		Archived:       tc.Archived,
-		Name:           tc.Name,
+		ID:                       tc.ID,
+		RegionID:                 regionID,
+		Address:                  tc.Address,
+		Description:              tc.Description,
+		MoistureLevel:            tc.MoistureLevel,
+		WateringStatus:           sqlc.WateringStatus(tc.WateringStatus),
+		WateringStatusEstimated:  tc.WateringStatusEstimated,
+		WateringStatusInferredAt: utils.TimeToPgTimestamp(tc.WateringStatusInferredAt),
+		SoilCondition:            sqlc.TreeSoilCondition(tc.SoilCondition),
+		LastWatered:              utils.TimeToPgTimestamp(tc.LastWatered),
+		Archived:                 tc.Archived,
+		Name:                     tc.Name,
 	}
 
 	_, err := r.store.UnlinkTreeClusterID(ctx, &tc.ID)
@@ -3,8 +3,11 @@ package treecluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +34,92 @@ func TestTreeClusterRepository_Update(t *testing.T) {
 		assert.Equal(t, "updated", got.Name)
 	})
 
+	t.Run("should record watering status transition with cause from context", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		before, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		ctx := storage.WithWateringStatusCause(context.Background(), entities.WateringStatusCauseSensorData)
+		updateFn := func(tc *entities.TreeCluster) (bool, error) {
+			tc.WateringStatus = entities.WateringStatusBad
+			return true, nil
+		}
+
+		// when
+		updateErr := r.Update(ctx, 1, updateFn)
+		transitions, getErr := suite.Store.GetWateringStatusTransitionsByTreeClusterID(context.Background(), &sqlc.GetWateringStatusTransitionsByTreeClusterIDParams{
+			TreeClusterID: utils.P(int32(1)),
+			Since:         utils.TimeToPgTimestamp(utils.P(time.Time{})),
+		})
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.Len(t, transitions, 1)
+		assert.Equal(t, sqlc.WateringStatus(before.WateringStatus), transitions[0].OldStatus)
+		assert.Equal(t, sqlc.WateringStatusBad, transitions[0].NewStatus)
+		assert.Equal(t, sqlc.WateringStatusCauseSensorData, transitions[0].Cause)
+		assert.Nil(t, transitions[0].TreeID)
+	})
+
+	t.Run("should update estimated watering status with inference as cause", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx := storage.WithWateringStatusCause(context.Background(), entities.WateringStatusCauseInference)
+		inferredAt := time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC)
+		updateFn := func(tc *entities.TreeCluster) (bool, error) {
+			tc.WateringStatus = entities.WateringStatusBad
+			tc.WateringStatusEstimated = true
+			tc.WateringStatusInferredAt = &inferredAt
+			return true, nil
+		}
+
+		// when
+		updateErr := r.Update(ctx, 1, updateFn)
+		got, getErr := r.GetByID(context.Background(), 1)
+		transitions, transitionErr := suite.Store.GetWateringStatusTransitionsByTreeClusterID(context.Background(), &sqlc.GetWateringStatusTransitionsByTreeClusterIDParams{
+			TreeClusterID: utils.P(int32(1)),
+			Since:         utils.TimeToPgTimestamp(utils.P(time.Time{})),
+		})
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.NoError(t, transitionErr)
+		assert.Equal(t, entities.WateringStatusBad, got.WateringStatus)
+		assert.True(t, got.WateringStatusEstimated)
+		assert.Equal(t, inferredAt, *got.WateringStatusInferredAt)
+		assert.Len(t, transitions, 1)
+		assert.Equal(t, sqlc.WateringStatusCauseInference, transitions[0].Cause)
+	})
+
+	t.Run("should not record watering status transition when status is unchanged", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		updateFn := func(tc *entities.TreeCluster) (bool, error) {
+			tc.Name = "updated"
+			return true, nil
+		}
+
+		// when
+		updateErr := r.Update(context.Background(), 1, updateFn)
+		transitions, getErr := suite.Store.GetWateringStatusTransitionsByTreeClusterID(context.Background(), &sqlc.GetWateringStatusTransitionsByTreeClusterIDParams{
+			TreeClusterID: utils.P(int32(1)),
+			Since:         utils.TimeToPgTimestamp(utils.P(time.Time{})),
+		})
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.Empty(t, transitions)
+	})
+
 	t.Run("should return error when update tree cluster with non-existing id", func(t *testing.T) {
 		// given
 		r := NewTreeClusterRepository(suite.Store, mappers)
@@ -0,0 +1,47 @@
+package wateringrule
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *WateringRuleRepository) Activate(ctx context.Context, id int32) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+
+	var activatedRuleSet *entities.WateringRuleSet
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := r.store.DeactivateWateringRuleSets(ctx); err != nil {
+			return r.store.MapError(err, sqlc.WateringRuleSet{})
+		}
+
+		if err := r.store.ActivateWateringRuleSet(ctx, id); err != nil {
+			return r.store.MapError(err, sqlc.WateringRuleSet{})
+		}
+
+		var err error
+		activatedRuleSet, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to activate watering rule set entity in db", "error", err, "rule_set_id", id)
+		return nil, err
+	}
+
+	log.Debug("watering rule set entity activated successfully in db", "rule_set_id", id, "version", activatedRuleSet.Version)
+	return activatedRuleSet, nil
+}
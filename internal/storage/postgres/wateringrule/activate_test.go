@@ -0,0 +1,67 @@
+package wateringrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRuleRepository_Activate(t *testing.T) {
+	t.Run("should activate rule set and deactivate the previously active one", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Activate(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.ID)
+		assert.True(t, got.Active)
+		assert.NotNil(t, got.ActivatedAt)
+
+		active, err := r.GetActive(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), active.ID)
+
+		previous, err := r.GetByID(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.False(t, previous.Active)
+	})
+
+	t.Run("should keep active rule set active", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Activate(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, got.Active)
+	})
+
+	t.Run("should return error and keep active rule set when rule set not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Activate(context.Background(), 99)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+
+		active, err := r.GetActive(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), active.ID)
+	})
+}
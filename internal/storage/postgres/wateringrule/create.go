@@ -0,0 +1,95 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func defaultWateringRuleSet() *entities.WateringRuleSet {
+	return &entities.WateringRuleSet{
+		Description: "",
+		Active:      false,
+		Thresholds:  make([]entities.WateringThreshold, 0),
+	}
+}
+
+func (r *WateringRuleRepository) Create(ctx context.Context, createFn func(*entities.WateringRuleSet) (bool, error)) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdRuleSet *entities.WateringRuleSet
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		oldStore := r.store
+		defer func() {
+			r.store = oldStore
+		}()
+		r.store = s
+
+		entity := defaultWateringRuleSet()
+		created, err := createFn(entity)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if len(entity.Thresholds) == 0 {
+			return errors.New("watering rule set needs at least one threshold")
+		}
+
+		id, err := r.store.CreateWateringRuleSet(ctx, entity.Description)
+		if err != nil {
+			return r.store.MapError(err, sqlc.WateringRuleSet{})
+		}
+
+		for _, threshold := range entity.Thresholds {
+			if err := r.store.CreateWateringThreshold(ctx, thresholdParams(id, &threshold)); err != nil {
+				return r.store.MapError(err, sqlc.WateringThreshold{})
+			}
+		}
+
+		createdRuleSet, err = r.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create watering rule set entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdRuleSet != nil {
+		log.Debug("watering rule set entity created successfully in db", "rule_set_id", createdRuleSet.ID, "version", createdRuleSet.Version)
+	}
+
+	return createdRuleSet, nil
+}
+
+func thresholdParams(ruleSetID int32, t *entities.WateringThreshold) *sqlc.CreateWateringThresholdParams {
+	params := &sqlc.CreateWateringThresholdParams{
+		RuleSetID:    ruleSetID,
+		SpeciesGroup: t.SpeciesGroup,
+		MinAge:       t.MinAge,
+		MaxAge:       t.MaxAge,
+		Depth:        int32(t.Depth),
+		Moderate:     int32(t.Moderate),
+		Bad:          int32(t.Bad),
+	}
+
+	if t.SoilCondition != nil {
+		params.SoilCondition = sqlc.NullTreeSoilCondition{
+			TreeSoilCondition: sqlc.TreeSoilCondition(*t.SoilCondition),
+			Valid:             true,
+		}
+	}
+
+	return params
+}
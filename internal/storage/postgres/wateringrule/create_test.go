@@ -0,0 +1,126 @@
+package wateringrule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRuleRepository_Create(t *testing.T) {
+	t.Run("should create rule set as next version with thresholds", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			rs.Description = "clay soil"
+			rs.Thresholds = []entities.WateringThreshold{
+				{SoilCondition: utils.P(entities.TreeSoilConditionTonig), MinAge: 0, MaxAge: 3, Depth: 60, Moderate: 40, Bad: 60},
+				{MinAge: 0, MaxAge: 3, Depth: 30, Moderate: 25, Bad: 33},
+			}
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, int32(3), got.ID)
+		assert.Equal(t, int32(3), got.Version)
+		assert.Equal(t, "clay soil", got.Description)
+		assert.False(t, got.Active)
+		assert.Nil(t, got.ActivatedAt)
+		assert.Len(t, got.Thresholds, 2)
+		assert.Equal(t, 30, got.Thresholds[0].Depth)
+		assert.Equal(t, utils.P(entities.TreeSoilConditionTonig), got.Thresholds[1].SoilCondition)
+	})
+
+	t.Run("should create first version in empty db", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			rs.Thresholds = []entities.WateringThreshold{{MinAge: 0, MaxAge: 99, Depth: 30, Moderate: 25, Bad: 33}}
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.Version)
+	})
+
+	t.Run("should not create rule set when function returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			return false, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when function returns error", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			return false, errors.New("test error")
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when rule set has no thresholds", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error and create nothing when a threshold is invalid", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(rs *entities.WateringRuleSet) (bool, error) {
+			rs.Thresholds = []entities.WateringThreshold{
+				{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+				{MinAge: 3, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33},
+			}
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+
+		all, err := r.GetAll(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+	})
+}
@@ -0,0 +1,72 @@
+package wateringrule
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *WateringRuleRepository) GetAll(ctx context.Context) ([]*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllWateringRuleSets(ctx)
+	if err != nil {
+		log.Debug("failed to get watering rule set entities in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.WateringRuleSet{})
+	}
+
+	ruleSets := r.mapper.FromSqlList(rows)
+	if err := r.mapThresholds(ctx, ruleSets...); err != nil {
+		return nil, err
+	}
+
+	return ruleSets, nil
+}
+
+func (r *WateringRuleRepository) GetByID(ctx context.Context, id int32) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetWateringRuleSetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get watering rule set entity by provided id", "error", err, "rule_set_id", id)
+		return nil, r.store.MapError(err, sqlc.WateringRuleSet{})
+	}
+
+	ruleSet := r.mapper.FromSql(row)
+	if err := r.mapThresholds(ctx, ruleSet); err != nil {
+		return nil, err
+	}
+
+	return ruleSet, nil
+}
+
+func (r *WateringRuleRepository) GetActive(ctx context.Context) (*entities.WateringRuleSet, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetActiveWateringRuleSet(ctx)
+	if err != nil {
+		log.Debug("failed to get active watering rule set entity in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.WateringRuleSet{})
+	}
+
+	ruleSet := r.mapper.FromSql(row)
+	if err := r.mapThresholds(ctx, ruleSet); err != nil {
+		return nil, err
+	}
+
+	return ruleSet, nil
+}
+
+func (r *WateringRuleRepository) mapThresholds(ctx context.Context, ruleSets ...*entities.WateringRuleSet) error {
+	log := logger.GetLogger(ctx)
+	for _, ruleSet := range ruleSets {
+		rows, err := r.store.GetWateringThresholdsByRuleSetID(ctx, ruleSet.ID)
+		if err != nil {
+			log.Debug("failed to get thresholds of watering rule set", "error", err, "rule_set_id", ruleSet.ID)
+			return r.store.MapError(err, sqlc.WateringThreshold{})
+		}
+
+		ruleSet.Thresholds = r.mapper.FromSqlThresholdList(rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package wateringrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringRuleRepository_GetAll(t *testing.T) {
+	t.Run("should return all rule sets newest version first with thresholds", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[0].Version)
+		assert.True(t, got[0].Active)
+		assert.Len(t, got[0].Thresholds, 3)
+		assert.Equal(t, int32(1), got[1].Version)
+		assert.False(t, got[1].Active)
+		assert.Equal(t, "initial thresholds", got[1].Description)
+		assert.Len(t, got[1].Thresholds, 1)
+		assert.NotZero(t, got[1].CreatedAt)
+	})
+
+	t.Run("should return empty slice when db is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetAll(ctx)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleRepository_GetByID(t *testing.T) {
+	t.Run("should return rule set by id with thresholds ordered by age and depth", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.NotNil(t, got.ActivatedAt)
+		assert.Len(t, got.Thresholds, 3)
+		assert.Equal(t, entities.WateringThreshold{MinAge: 0, MaxAge: 1, Depth: 30, Moderate: 25, Bad: 33}, got.Thresholds[0])
+		assert.Equal(t, entities.WateringThreshold{
+			SpeciesGroup:  utils.P("Acer"),
+			SoilCondition: utils.P(entities.TreeSoilConditionSandig),
+			MinAge:        4,
+			MaxAge:        99,
+			Depth:         30,
+			Moderate:      80,
+			Bad:           120,
+		}, got.Thresholds[1])
+		assert.Equal(t, 60, got.Thresholds[2].Depth)
+	})
+
+	t.Run("should return error when rule set not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringRuleRepository_GetActive(t *testing.T) {
+	t.Run("should return active rule set", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringrule")
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetActive(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.True(t, got.Active)
+		assert.Len(t, got.Thresholds, 3)
+	})
+
+	t.Run("should return error when no rule set is active", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringRuleRepository(suite.Store, defaultWateringRuleMappers())
+
+		// when
+		got, err := r.GetActive(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,29 @@
+package wateringrule
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type WateringRuleRepository struct {
+	store *store.Store
+	WateringRuleRepositoryMappers
+}
+
+type WateringRuleRepositoryMappers struct {
+	mapper mapper.InternalWateringRuleRepoMapper
+}
+
+func NewWateringRuleRepositoryMappers(wrMapper mapper.InternalWateringRuleRepoMapper) WateringRuleRepositoryMappers {
+	return WateringRuleRepositoryMappers{
+		mapper: wrMapper,
+	}
+}
+
+func NewWateringRuleRepository(s *store.Store, mappers WateringRuleRepositoryMappers) storage.WateringRuleRepository {
+	return &WateringRuleRepository{
+		store:                         s,
+		WateringRuleRepositoryMappers: mappers,
+	}
+}
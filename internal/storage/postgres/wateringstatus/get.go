@@ -0,0 +1,39 @@
+package wateringstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WateringStatusRepository) GetByTreeID(ctx context.Context, treeID int32, since time.Time) ([]*entities.WateringStatusTransition, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetWateringStatusTransitionsByTreeID(ctx, &sqlc.GetWateringStatusTransitionsByTreeIDParams{
+		TreeID: &treeID,
+		Since:  utils.TimeToPgTimestamp(&since),
+	})
+	if err != nil {
+		log.Debug("failed to get watering status transitions of tree in db", "error", err, "tree_id", treeID)
+		return nil, r.store.MapError(err, sqlc.WateringStatusTransition{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *WateringStatusRepository) GetByTreeClusterID(ctx context.Context, clusterID int32, since time.Time) ([]*entities.WateringStatusTransition, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetWateringStatusTransitionsByTreeClusterID(ctx, &sqlc.GetWateringStatusTransitionsByTreeClusterIDParams{
+		TreeClusterID: &clusterID,
+		Since:         utils.TimeToPgTimestamp(&since),
+	})
+	if err != nil {
+		log.Debug("failed to get watering status transitions of tree cluster in db", "error", err, "cluster_id", clusterID)
+		return nil, r.store.MapError(err, sqlc.WateringStatusTransition{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
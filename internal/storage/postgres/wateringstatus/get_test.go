@@ -0,0 +1,98 @@
+package wateringstatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringStatusRepository_GetByTreeClusterID(t *testing.T) {
+	t.Run("should return transitions of tree cluster ordered by time", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringstatus")
+		r := NewWateringStatusRepository(suite.Store, defaultWateringStatusMappers())
+
+		// when
+		got, err := r.GetByTreeClusterID(context.Background(), 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, int32(1), got[0].ID)
+		assert.Equal(t, int32(1), *got[0].TreeClusterID)
+		assert.Nil(t, got[0].TreeID)
+		assert.Equal(t, entities.WateringStatusUnknown, got[0].OldStatus)
+		assert.Equal(t, entities.WateringStatusGood, got[0].NewStatus)
+		assert.Equal(t, entities.WateringStatusCauseSensorData, got[0].Cause)
+		assert.Equal(t, time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC), got[0].CreatedAt)
+		assert.Equal(t, entities.WateringStatusCauseWeather, got[2].Cause)
+	})
+
+	t.Run("should only return transitions since the given time", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringstatus")
+		r := NewWateringStatusRepository(suite.Store, defaultWateringStatusMappers())
+
+		// when
+		got, err := r.GetByTreeClusterID(context.Background(), 1, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[0].ID)
+		assert.Equal(t, int32(3), got[1].ID)
+	})
+
+	t.Run("should return empty slice when tree cluster has no transitions", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringStatusRepository(suite.Store, defaultWateringStatusMappers())
+
+		// when
+		got, err := r.GetByTreeClusterID(context.Background(), 99, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestWateringStatusRepository_GetByTreeID(t *testing.T) {
+	t.Run("should return transitions of tree", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/wateringstatus")
+		r := NewWateringStatusRepository(suite.Store, defaultWateringStatusMappers())
+
+		// when
+		got, err := r.GetByTreeID(context.Background(), 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(4), got[0].ID)
+		assert.Equal(t, int32(1), *got[0].TreeID)
+		assert.Nil(t, got[0].TreeClusterID)
+		assert.Equal(t, entities.WateringStatusGood, got[0].OldStatus)
+		assert.Equal(t, entities.WateringStatusModerate, got[0].NewStatus)
+	})
+
+	t.Run("should return error when context is canceled", func(t *testing.T) {
+		// given
+		r := NewWateringStatusRepository(suite.Store, defaultWateringStatusMappers())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		got, err := r.GetByTreeID(ctx, 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
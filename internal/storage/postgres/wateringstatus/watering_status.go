@@ -0,0 +1,29 @@
+package wateringstatus
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type WateringStatusRepository struct {
+	store *store.Store
+	WateringStatusRepositoryMappers
+}
+
+type WateringStatusRepositoryMappers struct {
+	mapper mapper.InternalWateringStatusRepoMapper
+}
+
+func NewWateringStatusRepositoryMappers(wsMapper mapper.InternalWateringStatusRepoMapper) WateringStatusRepositoryMappers {
+	return WateringStatusRepositoryMappers{
+		mapper: wsMapper,
+	}
+}
+
+func NewWateringStatusRepository(s *store.Store, mappers WateringStatusRepositoryMappers) storage.WateringStatusHistoryRepository {
+	return &WateringStatusRepository{
+		store:                           s,
+		WateringStatusRepositoryMappers: mappers,
+	}
+}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+type WaterBalanceDay struct {
+	Date           time.Time               `json:"date"`
+	Precipitation  float64                 `json:"precipitation"`
+	ET0            float64                 `json:"et0"`
+	Deficit        float64                 `json:"deficit"`
+	WateringStatus entities.WateringStatus `json:"watering_status"`
+	Forecast       bool                    `json:"forecast"`
+}
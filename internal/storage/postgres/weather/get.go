@@ -0,0 +1,77 @@
+package weather
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+// GetLocations returns the center of the tree clusters of every region with located tree clusters
+func (r *WeatherRepository) GetLocations(ctx context.Context) ([]*entities.WeatherLocation, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetWeatherLocations(ctx)
+	if err != nil {
+		log.Debug("failed to get weather locations in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.WeatherDay{})
+	}
+
+	return r.mapper.FromSqlLocationList(rows), nil
+}
+
+func (r *WeatherRepository) GetByRegionID(ctx context.Context, regionID int32, from, to time.Time) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	fromDate, err := utils.TimeToPgDate(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toDate, err := utils.TimeToPgDate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.store.GetWeatherDaysByRegionID(ctx, &sqlc.GetWeatherDaysByRegionIDParams{
+		RegionID: regionID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		log.Debug("failed to get weather days of region in db", "error", err, "region_id", regionID)
+		return nil, r.store.MapError(err, sqlc.WeatherDay{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *WeatherRepository) GetAllWaterBalances(ctx context.Context, flagged bool) ([]*entities.WaterBalance, error) {
+	log := logger.GetLogger(ctx)
+
+	var rows []*sqlc.TreeClusterWaterBalance
+	var err error
+	if flagged {
+		rows, err = r.store.GetFlaggedWaterBalances(ctx)
+	} else {
+		rows, err = r.store.GetAllWaterBalances(ctx)
+	}
+	if err != nil {
+		log.Debug("failed to get water balances in db", "error", err, "flagged", flagged)
+		return nil, r.store.MapError(err, sqlc.TreeClusterWaterBalance{})
+	}
+
+	return r.mapper.FromSqlWaterBalanceList(rows)
+}
+
+func (r *WeatherRepository) GetWaterBalanceByTreeClusterID(ctx context.Context, clusterID int32) (*entities.WaterBalance, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetWaterBalanceByTreeClusterID(ctx, clusterID)
+	if err != nil {
+		log.Debug("failed to get water balance of tree cluster in db", "error", err, "cluster_id", clusterID)
+		return nil, r.store.MapError(err, sqlc.TreeClusterWaterBalance{})
+	}
+
+	return r.mapper.FromSqlWaterBalance(row)
+}
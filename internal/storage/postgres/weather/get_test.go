@@ -0,0 +1,173 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeatherRepository_GetLocations(t *testing.T) {
+	t.Run("should return center of tree clusters per region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetLocations(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(1), got[0].RegionID)
+		assert.InDelta(t, 54.80, got[0].Latitude, 0.0001)
+		assert.InDelta(t, 9.46, got[0].Longitude, 0.0001)
+		assert.Equal(t, int32(4), got[1].RegionID)
+	})
+
+	t.Run("should return empty slice when db is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetLocations(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestWeatherRepository_GetByRegionID(t *testing.T) {
+	t.Run("should return weather days of region in date range ordered by date", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		from := time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)
+
+		// when
+		got, err := r.GetByRegionID(context.Background(), 1, from, to)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, from, got[0].Date)
+		assert.Equal(t, int32(1), got[0].RegionID)
+		assert.Equal(t, 4.2, got[0].Precipitation)
+		assert.Equal(t, 2.0, got[0].TemperatureMin)
+		assert.Equal(t, 7.5, got[0].TemperatureMax)
+		assert.Equal(t, 0.3, got[0].ET0)
+		assert.Equal(t, "open-meteo", got[0].Provider)
+		assert.Equal(t, to, got[1].Date)
+	})
+
+	t.Run("should return empty slice when region has no weather", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetByRegionID(context.Background(), 2, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when date is zero", func(t *testing.T) {
+		// given
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetByRegionID(context.Background(), 1, time.Time{}, time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherRepository_GetAllWaterBalances(t *testing.T) {
+	t.Run("should return all water balances", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetAllWaterBalances(context.Background(), false)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(1), got[0].TreeClusterID)
+		assert.Equal(t, int32(2), got[1].TreeClusterID)
+		assert.Nil(t, got[1].BadAt)
+		assert.Empty(t, got[1].Days)
+	})
+
+	t.Run("should return only flagged water balances", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetAllWaterBalances(context.Background(), true)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(1), got[0].TreeClusterID)
+		assert.True(t, got[0].Flagged)
+	})
+}
+
+func TestWeatherRepository_GetWaterBalanceByTreeClusterID(t *testing.T) {
+	t.Run("should return water balance with days", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetWaterBalanceByTreeClusterID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.RegionID)
+		assert.Equal(t, entities.WateringStatusGood, got.BaseStatus)
+		assert.Equal(t, time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC), got.BaseDate)
+		assert.Equal(t, entities.WateringStatusModerate, got.AdjustedStatus)
+		assert.Equal(t, entities.WateringStatusBad, got.ForecastStatus)
+		assert.Equal(t, 25.5, got.Deficit)
+		assert.Equal(t, time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), *got.BadAt)
+		assert.True(t, got.Flagged)
+		assert.Len(t, got.Days, 2)
+		assert.Equal(t, 30.2, got.Days[1].Deficit)
+		assert.Equal(t, entities.WateringStatusBad, got.Days[1].WateringStatus)
+		assert.True(t, got.Days[1].Forecast)
+	})
+
+	t.Run("should return error when water balance not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetWaterBalanceByTreeClusterID(context.Background(), 3)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,108 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Save inserts the weather days or overwrites the stored ones of the same region and date
+func (r *WeatherRepository) Save(ctx context.Context, days ...*entities.WeatherDay) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		for _, day := range days {
+			date, err := utils.TimeToPgDate(day.Date)
+			if err != nil {
+				return err
+			}
+
+			if err := s.UpsertWeatherDay(ctx, &sqlc.UpsertWeatherDayParams{
+				RegionID:       day.RegionID,
+				Date:           date,
+				Precipitation:  day.Precipitation,
+				TemperatureMin: day.TemperatureMin,
+				TemperatureMax: day.TemperatureMax,
+				Et0:            day.ET0,
+				Provider:       day.Provider,
+			}); err != nil {
+				return s.MapError(err, sqlc.WeatherDay{})
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error("failed to save weather days in db", "error", err)
+		return err
+	}
+
+	log.Debug("weather days saved successfully in db", "days", len(days))
+	return nil
+}
+
+// DeleteBefore deletes all weather days before the given date and returns the number of deleted days
+func (r *WeatherRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.GetLogger(ctx)
+	date, err := utils.TimeToPgDate(before)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := r.store.DeleteWeatherDaysBefore(ctx, date)
+	if err != nil {
+		log.Error("failed to delete weather days in db", "error", err, "before", before)
+		return 0, r.store.MapError(err, sqlc.WeatherDay{})
+	}
+
+	return deleted, nil
+}
+
+// SaveWaterBalance inserts the water balance of a tree cluster or replaces the stored one
+func (r *WeatherRepository) SaveWaterBalance(ctx context.Context, balance *entities.WaterBalance) error {
+	log := logger.GetLogger(ctx)
+	baseDate, err := utils.TimeToPgDate(balance.BaseDate)
+	if err != nil {
+		return err
+	}
+
+	var badAt pgtype.Date
+	if balance.BadAt != nil {
+		if badAt, err = utils.TimeToPgDate(*balance.BadAt); err != nil {
+			return err
+		}
+	}
+
+	days, err := json.Marshal(r.mapper.FromDomainWaterBalanceDays(balance.Days))
+	if err != nil {
+		return err
+	}
+
+	err = r.store.UpsertWaterBalance(ctx, &sqlc.UpsertWaterBalanceParams{
+		TreeClusterID:  balance.TreeClusterID,
+		RegionID:       balance.RegionID,
+		BaseStatus:     sqlc.WateringStatus(balance.BaseStatus),
+		BaseDate:       baseDate,
+		WateringStatus: sqlc.WateringStatus(balance.WateringStatus),
+		AdjustedStatus: sqlc.WateringStatus(balance.AdjustedStatus),
+		ForecastStatus: sqlc.WateringStatus(balance.ForecastStatus),
+		Deficit:        balance.Deficit,
+		BadAt:          badAt,
+		Flagged:        balance.Flagged,
+		Days:           days,
+	})
+	if err != nil {
+		log.Error("failed to save water balance in db", "error", err, "cluster_id", balance.TreeClusterID)
+		return r.store.MapError(err, sqlc.TreeClusterWaterBalance{})
+	}
+
+	log.Debug("water balance saved successfully in db", "cluster_id", balance.TreeClusterID, "adjusted_status", balance.AdjustedStatus, "flagged", balance.Flagged)
+	return nil
+}
@@ -0,0 +1,161 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeatherRepository_Save(t *testing.T) {
+	t.Run("should insert new and overwrite existing weather days", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		day := time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)
+
+		// when
+		err := r.Save(context.Background(),
+			&entities.WeatherDay{RegionID: 1, Date: day, Precipitation: 2.5, TemperatureMin: 1, TemperatureMax: 4, ET0: 0.6, Provider: "file"},
+			&entities.WeatherDay{RegionID: 1, Date: day.AddDate(0, 0, 1), Precipitation: 0, TemperatureMin: 0, TemperatureMax: 3, ET0: 0.7, Provider: "file"},
+		)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByRegionID(context.Background(), 1, day, day.AddDate(0, 0, 1))
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, 2.5, got[0].Precipitation)
+		assert.Equal(t, "file", got[0].Provider)
+		assert.Equal(t, 0.7, got[1].ET0)
+	})
+
+	t.Run("should return error and save nothing when region not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		day := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		// when
+		err := r.Save(context.Background(),
+			&entities.WeatherDay{RegionID: 1, Date: day},
+			&entities.WeatherDay{RegionID: 99, Date: day},
+		)
+
+		// then
+		assert.Error(t, err)
+		got, err := r.GetByRegionID(context.Background(), 1, day, day)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestWeatherRepository_DeleteBefore(t *testing.T) {
+	t.Run("should delete weather days before date", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		deleted, err := r.DeleteBefore(context.Background(), time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		got, err := r.GetByRegionID(context.Background(), 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+}
+
+func TestWeatherRepository_SaveWaterBalance(t *testing.T) {
+	t.Run("should insert water balance", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		baseDate := time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC)
+		balance := &entities.WaterBalance{
+			TreeClusterID:  3,
+			RegionID:       4,
+			BaseStatus:     entities.WateringStatusModerate,
+			BaseDate:       baseDate,
+			WateringStatus: entities.WateringStatusBad,
+			AdjustedStatus: entities.WateringStatusModerate,
+			ForecastStatus: entities.WateringStatusModerate,
+			Deficit:        40.1,
+			Days: []entities.WaterBalanceDay{
+				{Date: baseDate.AddDate(0, 0, 1), Precipitation: 3, ET0: 0.3, Deficit: 40.1, WateringStatus: entities.WateringStatusModerate},
+			},
+		}
+
+		// when
+		err := r.SaveWaterBalance(context.Background(), balance)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetWaterBalanceByTreeClusterID(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, baseDate, got.BaseDate)
+		assert.Equal(t, entities.WateringStatusBad, got.WateringStatus)
+		assert.Equal(t, 40.1, got.Deficit)
+		assert.Nil(t, got.BadAt)
+		assert.False(t, got.Flagged)
+		assert.Equal(t, balance.Days, got.Days)
+	})
+
+	t.Run("should replace existing water balance", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		badAt := time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)
+
+		// when
+		err := r.SaveWaterBalance(context.Background(), &entities.WaterBalance{
+			TreeClusterID:  2,
+			RegionID:       1,
+			BaseStatus:     entities.WateringStatusModerate,
+			BaseDate:       time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC),
+			WateringStatus: entities.WateringStatusModerate,
+			AdjustedStatus: entities.WateringStatusModerate,
+			ForecastStatus: entities.WateringStatusBad,
+			Deficit:        52,
+			BadAt:          utils.P(badAt),
+			Flagged:        true,
+			Days:           []entities.WaterBalanceDay{},
+		})
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetAllWaterBalances(context.Background(), true)
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[1].TreeClusterID)
+		assert.Equal(t, badAt, *got[1].BadAt)
+	})
+
+	t.Run("should return error when tree cluster not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/weather")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		err := r.SaveWaterBalance(context.Background(), &entities.WaterBalance{
+			TreeClusterID: 99,
+			RegionID:      1,
+			BaseDate:      time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC),
+			Days:          []entities.WaterBalanceDay{},
+		})
+
+		// then
+		assert.Error(t, err)
+	})
+}
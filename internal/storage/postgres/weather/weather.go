@@ -0,0 +1,29 @@
+package weather
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type WeatherRepository struct {
+	store *store.Store
+	WeatherRepositoryMappers
+}
+
+type WeatherRepositoryMappers struct {
+	mapper mapper.InternalWeatherRepoMapper
+}
+
+func NewWeatherRepositoryMappers(wMapper mapper.InternalWeatherRepoMapper) WeatherRepositoryMappers {
+	return WeatherRepositoryMappers{
+		mapper: wMapper,
+	}
+}
+
+func NewWeatherRepository(s *store.Store, mappers WeatherRepositoryMappers) storage.WeatherRepository {
+	return &WeatherRepository{
+		store:                    s,
+		WeatherRepositoryMappers: mappers,
+	}
+}
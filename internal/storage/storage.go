@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -51,6 +53,12 @@ var (
 	ErrBucketNotExists    = errors.New("bucket don't exists")
 
 	ErrPaginationValueInvalid = errors.New("pagination values are invalid")
+
+	ErrWateringStatusCauseMissing = errors.New("watering status changed without a cause")
+	ErrReplacementHasTree         = errors.New("replacement sensor is already linked to a tree")
+	ErrDeadLetterNotPending       = errors.New("dead letter is not pending")
+	ErrTreeHasSensor              = errors.New("tree is already linked to a sensor")
+	ErrSensorAssignmentClosed     = errors.New("sensor assignment review is already closed")
 )
 
 type BasicCrudRepository[T entities.Entities] interface {
@@ -126,8 +134,8 @@ type WateringPlanRepository interface {
 }
 
 type TreeClusterRepository interface {
-	// GetAll returns all tree clusters
-	GetAll(ctx context.Context) ([]*entities.TreeCluster, int64, error)
+	// GetAll returns all tree clusters in the sort order of the query
+	GetAll(ctx context.Context, query entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error)
 	// GetByID returns one tree cluster by id
 	GetByID(ctx context.Context, id int32) (*entities.TreeCluster, error)
 	// GetByIDs returns multiple tree cluster by ids
@@ -144,6 +152,14 @@ type TreeClusterRepository interface {
 	LinkTreesToCluster(ctx context.Context, treeClusterID int32, treeIDs []int32) error
 	GetCenterPoint(ctx context.Context, id int32) (float64, float64, error)
 	GetAllLatestSensorDataByClusterID(ctx context.Context, tcID int32) ([]*entities.SensorData, error)
+	// CalculateMoistureTrends fits the drying trend per probe depth to the sensor data of the tree cluster since the given time.
+	// Only the depth, the trend per day, the number of samples and the time of the latest sample are set.
+	CalculateMoistureTrends(ctx context.Context, tcID int32, since time.Time) ([]*entities.MoistureTrend, error)
+	// SaveMoistureTrends replaces the stored moisture trends of the tree cluster
+	SaveMoistureTrends(ctx context.Context, tcID int32, trends []*entities.MoistureTrend) error
+	// SaveMoistureMetrics replaces the stored moisture metrics and sets the moisture level of the tree cluster.
+	// Without metrics the stored moisture metrics are removed.
+	SaveMoistureMetrics(ctx context.Context, tcID int32, metrics *entities.MoistureMetrics, moistureLevel float64) error
 }
 
 type TreeRepository interface {
@@ -164,6 +180,8 @@ type TreeRepository interface {
 	UnlinkImage(ctx context.Context, flowerbedID, imageID int32) error
 	CreateAndLinkImages(ctx context.Context, tcFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error)
 	FindNearestTree(ctx context.Context, latitude, longitude float64) (*entities.Tree, error)
+	// FindNearestUnassignedTrees returns up to limit trees without a sensor within the given radius in meters, nearest first
+	FindNearestUnassignedTrees(ctx context.Context, latitude, longitude, radius float64, limit int32) ([]*entities.TreeCandidate, error)
 }
 
 type SensorRepository interface {
@@ -172,9 +190,127 @@ type SensorRepository interface {
 	Create(ctx context.Context, createFn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error)
 	Update(ctx context.Context, id string, updateFn func(*entities.Sensor) (bool, error)) (*entities.Sensor, error)
 	Delete(ctx context.Context, id string) error
+	// Import creates all given sensors and links them to their tree in a single transaction. If one sensor can't be created, none of them will be created. A tree that is already linked to a sensor is not relinked, ErrTreeHasSensor is returned instead.
+	Import(ctx context.Context, sensors []*entities.SensorImport) ([]*entities.Sensor, error)
 
 	GetLatestSensorDataBySensorID(ctx context.Context, id string) (*entities.SensorData, error)
 	InsertSensorData(ctx context.Context, data *entities.SensorData, id string) error
+	GetSensorDataBySensorID(ctx context.Context, id string, from, to time.Time) ([]*entities.SensorData, error)
+	GetAggregatedSensorDataBySensorID(ctx context.Context, id string, resolution entities.SensorDataResolution, from, to time.Time) ([]*entities.SensorDataAggregate, error)
+	// StreamSensorData returns the sensor data matching the export query ordered by creation time. The data is fetched in batches while iterating, so it is never loaded into memory at once.
+	StreamSensorData(ctx context.Context, query *entities.SensorDataExportQuery) iter.Seq2[*entities.SensorDataExportRow, error]
+	// GetLastSeen returns the status, the offline threshold and the time of the latest sensor data of every sensor. If the raw sensor data was already pruned, the start of the latest rolled up bucket is returned.
+	GetLastSeen(ctx context.Context) ([]*entities.SensorActivity, error)
+	// UpdateStatus only updates the status of a sensor
+	UpdateStatus(ctx context.Context, id string, status entities.SensorStatus) error
+	// GetBatteryTrends returns the latest battery level and the battery trend of every sensor that sent data since the given time
+	GetBatteryTrends(ctx context.Context, since time.Time) ([]*entities.SensorBattery, error)
+	// RollupSensorData aggregates all raw sensor data created before the given time into the hourly and daily aggregates and deletes the raw data afterwards. It returns the number of deleted rows.
+	RollupSensorData(ctx context.Context, before time.Time) (int64, error)
+	// PruneHourlySensorData deletes all hourly aggregates before the given time. The daily aggregates are kept.
+	PruneHourlySensorData(ctx context.Context, before time.Time) (int64, error)
+
+	// GetCalibration returns the calibration profile of a sensor
+	GetCalibration(ctx context.Context, id string) (*entities.SensorCalibration, error)
+	// SaveCalibration creates or replaces the calibration profile of a sensor. In the same transaction all stored sensor data of the sensor is passed in batches and ordered by creation time to recalibrateFn and written back afterwards. Rolled up sensor data is not recomputed.
+	SaveCalibration(ctx context.Context, id string, calibration *entities.SensorCalibration, recalibrateFn func(*entities.SensorData) error) error
+	// DeleteCalibration removes the calibration profile of a sensor and passes the stored sensor data to recalibrateFn like SaveCalibration
+	DeleteCalibration(ctx context.Context, id string, recalibrateFn func(*entities.SensorData) error) error
+
+	// Decommission retires the sensor and keeps its data. With a replacement id the trees and flowerbeds of the sensor are linked to the replacement, which is created at the same location if it does not exist. An existing replacement must not be linked to a tree, otherwise ErrReplacementHasTree is returned.
+	Decommission(ctx context.Context, id string, reason string, replacementID *string) (*entities.Sensor, error)
+}
+
+type DeadLetterRepository interface {
+	// GetAll returns all dead letters, newest first
+	GetAll(ctx context.Context) ([]*entities.DeadLetter, error)
+	// GetAllByStatus returns all dead letters with the given status, newest first
+	GetAllByStatus(ctx context.Context, status entities.DeadLetterStatus) ([]*entities.DeadLetter, error)
+	// GetByID returns one dead letter by id
+	GetByID(ctx context.Context, id int32) (*entities.DeadLetter, error)
+	// Create creates a new dead letter. It accepts a function that takes a dead letter that can be modified. If the function returns true, the dead letter will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(dl *entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error)
+	// Update updates a dead letter by id. Only the error, status and replay information can be changed. If the function returns true, the dead letter will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(dl *entities.DeadLetter) (bool, error)) (*entities.DeadLetter, error)
+	// Claim marks a pending dead letter as replaying, so it is replayed only once at a time. If the dead letter is not pending, ErrDeadLetterNotPending is returned.
+	Claim(ctx context.Context, id int32) (*entities.DeadLetter, error)
+	// Delete deletes a dead letter by id
+	Delete(ctx context.Context, id int32) error
+}
+
+type SensorAssignmentRepository interface {
+	// GetAll returns all sensor assignment reviews, newest first
+	GetAll(ctx context.Context) ([]*entities.SensorAssignmentReview, error)
+	// GetAllByStatus returns all sensor assignment reviews with the given status, newest first
+	GetAllByStatus(ctx context.Context, status entities.SensorAssignmentStatus) ([]*entities.SensorAssignmentReview, error)
+	// GetByID returns one sensor assignment review with its candidates by id
+	GetByID(ctx context.Context, id int32) (*entities.SensorAssignmentReview, error)
+	// Create creates a new sensor assignment review together with its candidates. If the function returns true, the review will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(r *entities.SensorAssignmentReview) (bool, error)) (*entities.SensorAssignmentReview, error)
+	// Update updates a sensor assignment review by id. Only the status and the chosen tree can be changed. If the function returns true, the review will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(r *entities.SensorAssignmentReview) (bool, error)) (*entities.SensorAssignmentReview, error)
+	// Resolve links the sensor of a pending review to the chosen tree and resolves the review in a single transaction. If the review is not pending anymore, ErrSensorAssignmentClosed is returned. If the tree is already linked to a sensor, ErrTreeHasSensor is returned.
+	Resolve(ctx context.Context, id int32, treeID int32) (*entities.SensorAssignmentReview, error)
+}
+
+type SensorCommandRepository interface {
+	// GetAllBySensorID returns all downlink commands of a sensor, newest first
+	GetAllBySensorID(ctx context.Context, sensorID string) ([]*entities.SensorCommand, error)
+	// GetAllByStatus returns all downlink commands with the given status, oldest first
+	GetAllByStatus(ctx context.Context, status entities.SensorCommandStatus) ([]*entities.SensorCommand, error)
+	// GetByID returns one downlink command by id
+	GetByID(ctx context.Context, id int32) (*entities.SensorCommand, error)
+	// Create creates a new downlink command. If the function returns true, the command will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(cmd *entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error)
+	// Update updates a downlink command by id. Only the status and the sent and acknowledged timestamps can be changed. If the function returns true, the command will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(cmd *entities.SensorCommand) (bool, error)) (*entities.SensorCommand, error)
+}
+
+type WateringRuleRepository interface {
+	// GetAll returns all versions of the watering rule sets with their thresholds, newest version first
+	GetAll(ctx context.Context) ([]*entities.WateringRuleSet, error)
+	// GetByID returns one watering rule set with its thresholds by id
+	GetByID(ctx context.Context, id int32) (*entities.WateringRuleSet, error)
+	// GetActive returns the active watering rule set with its thresholds
+	GetActive(ctx context.Context) (*entities.WateringRuleSet, error)
+	// Create creates a new version of the watering rule sets together with its thresholds. The version number is assigned by the storage. If the function returns true, the rule set will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(rs *entities.WateringRuleSet) (bool, error)) (*entities.WateringRuleSet, error)
+	// Activate activates the watering rule set by id and deactivates the previously active rule set
+	Activate(ctx context.Context, id int32) (*entities.WateringRuleSet, error)
+}
+
+type WeatherRepository interface {
+	// GetLocations returns the location of all regions with tree clusters, the weather is fetched for these locations
+	GetLocations(ctx context.Context) ([]*entities.WeatherLocation, error)
+	// GetByRegionID returns the weather days of a region between from and to inclusive, ordered by date
+	GetByRegionID(ctx context.Context, regionID int32, from, to time.Time) ([]*entities.WeatherDay, error)
+	// Save stores the weather days, existing days of a region are overwritten
+	Save(ctx context.Context, days ...*entities.WeatherDay) error
+	// DeleteBefore deletes the weather days before the given date and returns the number of deleted days
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	// GetAllWaterBalances returns the water balances of all tree clusters, only the flagged ones if flagged is true
+	GetAllWaterBalances(ctx context.Context, flagged bool) ([]*entities.WaterBalance, error)
+	// GetWaterBalanceByTreeClusterID returns the water balance of a tree cluster
+	GetWaterBalanceByTreeClusterID(ctx context.Context, clusterID int32) (*entities.WaterBalance, error)
+	// SaveWaterBalance stores the water balance of a tree cluster, an existing balance is overwritten
+	SaveWaterBalance(ctx context.Context, balance *entities.WaterBalance) error
+}
+
+// WeatherProvider fetches the daily weather of a location from a weather service
+type WeatherProvider interface {
+	// Name returns the name of the provider that is stored with the weather days
+	Name() string
+	// GetDaily returns the observed weather of the past days and the forecast of today and the following days, ordered by date
+	GetDaily(ctx context.Context, latitude, longitude float64, pastDays, forecastDays int) ([]*entities.WeatherDay, error)
+}
+
+// WateringStatusHistoryRepository reads the recorded watering status transitions. Transitions are recorded by
+// the tree and tree cluster repositories whenever the watering status changes, with the cause from WithWateringStatusCause.
+type WateringStatusHistoryRepository interface {
+	// GetByTreeID returns the transitions of a tree since the given time, ordered by time
+	GetByTreeID(ctx context.Context, treeID int32, since time.Time) ([]*entities.WateringStatusTransition, error)
+	// GetByTreeClusterID returns the transitions of a tree cluster since the given time, ordered by time
+	GetByTreeClusterID(ctx context.Context, clusterID int32, since time.Time) ([]*entities.WateringStatusTransition, error)
 }
 
 type RoutingRepository interface {
@@ -213,18 +349,25 @@ type AuthRepository interface {
 }
 
 type Repository struct {
-	Auth         AuthRepository
-	Info         InfoRepository
-	Sensor       SensorRepository
-	Tree         TreeRepository
-	User         UserRepository
-	Image        ImageRepository
-	Vehicle      VehicleRepository
-	TreeCluster  TreeClusterRepository
-	Flowerbed    FlowerbedRepository
-	Region       RegionRepository
-	WateringPlan WateringPlanRepository
-	Routing      RoutingRepository
-	GpxBucket    S3Repository
+	Auth             AuthRepository
+	Info             InfoRepository
+	Sensor           SensorRepository
+	Tree             TreeRepository
+	User             UserRepository
+	Image            ImageRepository
+	Vehicle          VehicleRepository
+	TreeCluster      TreeClusterRepository
+	Flowerbed        FlowerbedRepository
+	Region           RegionRepository
+	WateringPlan     WateringPlanRepository
+	DeadLetter       DeadLetterRepository
+	SensorAssignment SensorAssignmentRepository
+	SensorCommand    SensorCommandRepository
+	WateringRule     WateringRuleRepository
+	Weather          WeatherRepository
+	WeatherProvider  WeatherProvider
+	WateringStatus   WateringStatusHistoryRepository
+	Routing          RoutingRepository
+	GpxBucket        S3Repository
 	// ImageBucket  S3Repository
 }
@@ -0,0 +1,118 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const ProviderName = "file"
+
+// validate is FileProvider implements storage.WeatherProvider
+var _ storage.WeatherProvider = (*FileProvider)(nil)
+
+type WeatherFile struct {
+	Daily []WeatherFileDay `json:"daily"`
+}
+
+type WeatherFileDay struct {
+	Date           string  `json:"date"`
+	Precipitation  float64 `json:"precipitation"`
+	TemperatureMin float64 `json:"temperature_min"`
+	TemperatureMax float64 `json:"temperature_max"`
+	ET0            float64 `json:"et0"`
+}
+
+// FileProvider reads the weather from a json file on disk or behind an http url. It is a stand-in for a weather
+// service, e.g. for tests or installations without internet access. The same days are returned for all locations.
+type FileProvider struct {
+	source string
+	client *http.Client
+}
+
+func NewFileProvider(source string) *FileProvider {
+	return &FileProvider{
+		source: source,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *FileProvider) Name() string {
+	return ProviderName
+}
+
+// GetDaily returns the days of the file between today minus the past days and today plus the forecast days
+func (p *FileProvider) GetDaily(ctx context.Context, _, _ float64, pastDays, forecastDays int) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	r, err := p.open(ctx)
+	if err != nil {
+		log.Error("failed to open weather file", "error", err, "source", p.source)
+		return nil, err
+	}
+	defer r.Close()
+
+	var file WeatherFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		log.Error("failed to decode weather file", "error", err, "source", p.source)
+		return nil, err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -pastDays)
+	to := today.AddDate(0, 0, forecastDays)
+
+	days := make([]*entities.WeatherDay, 0, len(file.Daily))
+	for _, day := range file.Daily {
+		date, err := time.Parse(time.DateOnly, day.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		if date.Before(from) || !date.Before(to) {
+			continue
+		}
+
+		days = append(days, &entities.WeatherDay{
+			Date:           date,
+			Precipitation:  day.Precipitation,
+			TemperatureMin: day.TemperatureMin,
+			TemperatureMax: day.TemperatureMax,
+			ET0:            day.ET0,
+			Provider:       ProviderName,
+		})
+	}
+
+	return days, nil
+}
+
+func (p *FileProvider) open(ctx context.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(p.source, "http://") && !strings.HasPrefix(p.source, "https://") {
+		return os.Open(p.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.source, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("response not successful: %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,115 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testWeatherFile() (content string, today time.Time) {
+	today = time.Now().UTC().Truncate(24 * time.Hour)
+	day := func(offset int) string {
+		return today.AddDate(0, 0, offset).Format(time.DateOnly)
+	}
+
+	return fmt.Sprintf(`{"daily": [
+		{"date": "%s", "precipitation": 1.0, "temperature_min": 10, "temperature_max": 20, "et0": 3.0},
+		{"date": "%s", "precipitation": 0.4, "temperature_min": 11.2, "temperature_max": 24.8, "et0": 4.1},
+		{"date": "%s", "precipitation": 0.0, "temperature_min": 12, "temperature_max": 26, "et0": 4.5},
+		{"date": "%s", "precipitation": 8.0, "temperature_min": 12, "temperature_max": 18, "et0": 2.0}
+	]}`, day(-2), day(-1), day(0), day(1)), today
+}
+
+func TestFileProvider_GetDaily(t *testing.T) {
+	t.Run("should read days of requested range from local file", func(t *testing.T) {
+		// given
+		content, today := testWeatherFile()
+		path := filepath.Join(t.TempDir(), "weather.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		p := NewFileProvider(path)
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 1, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, today.AddDate(0, 0, -1), got[0].Date)
+		assert.Equal(t, 0.4, got[0].Precipitation)
+		assert.Equal(t, 11.2, got[0].TemperatureMin)
+		assert.Equal(t, 24.8, got[0].TemperatureMax)
+		assert.Equal(t, 4.1, got[0].ET0)
+		assert.Equal(t, ProviderName, got[0].Provider)
+		assert.Equal(t, today, got[1].Date)
+	})
+
+	t.Run("should read days from http url", func(t *testing.T) {
+		// given
+		content, _ := testWeatherFile()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(content))
+		}))
+		defer server.Close()
+		p := NewFileProvider(server.URL + "/weather.json")
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 7, 7)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 4)
+	})
+
+	t.Run("should return error when url responds with not successful code", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		p := NewFileProvider(server.URL + "/weather.json")
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 7, 7)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when file does not exist", func(t *testing.T) {
+		// given
+		p := NewFileProvider(filepath.Join(t.TempDir(), "missing.json"))
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 7, 7)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when date is malformed", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "weather.json")
+		if err := os.WriteFile(path, []byte(`{"daily": [{"date": "01.06.2025"}]}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		p := NewFileProvider(path)
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 7, 7)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,16 @@
+package openmeteo
+
+type DailyResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Daily     Daily   `json:"daily"`
+}
+
+// Daily contains one entry per day in every slice, values are null if the weather of the day is not available
+type Daily struct {
+	Time                     []string   `json:"time"`
+	PrecipitationSum         []*float64 `json:"precipitation_sum"`
+	Temperature2mMax         []*float64 `json:"temperature_2m_max"`
+	Temperature2mMin         []*float64 `json:"temperature_2m_min"`
+	Et0FaoEvapotranspiration []*float64 `json:"et0_fao_evapotranspiration"`
+}
@@ -0,0 +1,139 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const ProviderName = "open-meteo"
+
+var dailyVariables = "precipitation_sum,temperature_2m_max,temperature_2m_min,et0_fao_evapotranspiration"
+
+// validate is OpenMeteoProvider implements storage.WeatherProvider
+var _ storage.WeatherProvider = (*OpenMeteoProvider)(nil)
+
+type OpenMeteoProviderConfig struct {
+	url    *url.URL
+	client *http.Client
+}
+
+type OpenMeteoProviderOption func(*OpenMeteoProviderConfig)
+
+type OpenMeteoProvider struct {
+	cfg OpenMeteoProviderConfig
+}
+
+func WithClient(client *http.Client) OpenMeteoProviderOption {
+	return func(cfg *OpenMeteoProviderConfig) {
+		cfg.client = client
+	}
+}
+
+func WithHostURL(hostURL *url.URL) OpenMeteoProviderOption {
+	return func(cfg *OpenMeteoProviderConfig) {
+		cfg.url = hostURL
+	}
+}
+
+var defaultCfg = OpenMeteoProviderConfig{
+	client: &http.Client{Timeout: 30 * time.Second},
+}
+
+func NewOpenMeteoProvider(opts ...OpenMeteoProviderOption) *OpenMeteoProvider {
+	cfg := defaultCfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &OpenMeteoProvider{
+		cfg: cfg,
+	}
+}
+
+func (p *OpenMeteoProvider) Name() string {
+	return ProviderName
+}
+
+func (p *OpenMeteoProvider) GetDaily(ctx context.Context, latitude, longitude float64, pastDays, forecastDays int) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	path := fmt.Sprintf("%s/v1/forecast", p.cfg.url.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Add("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Add("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Add("daily", dailyVariables)
+	query.Add("past_days", strconv.Itoa(pastDays))
+	query.Add("forecast_days", strconv.Itoa(forecastDays))
+	query.Add("timezone", "UTC")
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := p.cfg.client.Do(req)
+	if err != nil {
+		log.Error("failed to send request to open-meteo service", "error", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err == nil {
+			log.Error("response from the open-meteo service with a not successful code", "status_code", resp.StatusCode, "body", body)
+		} else {
+			log.Error("response from the open-meteo service with a not successful code", "status_code", resp.StatusCode)
+		}
+		return nil, errors.New("response not successful")
+	}
+
+	var response DailyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		log.Error("failed to decode open-meteo response", "error", err)
+		return nil, err
+	}
+
+	return toWeatherDays(&response.Daily)
+}
+
+// toWeatherDays converts the daily columns to weather days, days with missing values are skipped
+func toWeatherDays(daily *Daily) ([]*entities.WeatherDay, error) {
+	n := len(daily.Time)
+	if len(daily.PrecipitationSum) != n || len(daily.Temperature2mMax) != n || len(daily.Temperature2mMin) != n || len(daily.Et0FaoEvapotranspiration) != n {
+		return nil, errors.New("daily variables of open-meteo response differ in length")
+	}
+
+	days := make([]*entities.WeatherDay, 0, n)
+	for i, rawDate := range daily.Time {
+		date, err := time.Parse(time.DateOnly, rawDate)
+		if err != nil {
+			return nil, err
+		}
+
+		if daily.PrecipitationSum[i] == nil || daily.Temperature2mMax[i] == nil || daily.Temperature2mMin[i] == nil || daily.Et0FaoEvapotranspiration[i] == nil {
+			continue
+		}
+
+		days = append(days, &entities.WeatherDay{
+			Date:           date,
+			Precipitation:  *daily.PrecipitationSum[i],
+			TemperatureMin: *daily.Temperature2mMin[i],
+			TemperatureMax: *daily.Temperature2mMax[i],
+			ET0:            *daily.Et0FaoEvapotranspiration[i],
+			Provider:       ProviderName,
+		})
+	}
+
+	return days, nil
+}
@@ -0,0 +1,96 @@
+package openmeteo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *OpenMeteoProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	hostURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewOpenMeteoProvider(WithHostURL(hostURL), WithClient(server.Client()))
+}
+
+func TestOpenMeteoProvider_GetDaily(t *testing.T) {
+	t.Run("should request daily weather and convert response", func(t *testing.T) {
+		// given
+		var query url.Values
+		p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/forecast", r.URL.Path)
+			query = r.URL.Query()
+			_, _ = w.Write([]byte(`{
+				"latitude": 54.8,
+				"longitude": 9.46,
+				"daily": {
+					"time": ["2025-01-29", "2025-01-30", "2025-01-31"],
+					"precipitation_sum": [4.2, 0.1, null],
+					"temperature_2m_max": [7.5, 5.0, 4.0],
+					"temperature_2m_min": [2.0, 0.5, -1.0],
+					"et0_fao_evapotranspiration": [0.3, 0.5, 0.4]
+				}
+			}`))
+		})
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 1, 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "54.8", query.Get("latitude"))
+		assert.Equal(t, "9.46", query.Get("longitude"))
+		assert.Equal(t, "1", query.Get("past_days"))
+		assert.Equal(t, "2", query.Get("forecast_days"))
+		assert.Equal(t, "UTC", query.Get("timezone"))
+		assert.Equal(t, dailyVariables, query.Get("daily"))
+
+		assert.Len(t, got, 2)
+		assert.Equal(t, time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC), got[0].Date)
+		assert.Equal(t, 4.2, got[0].Precipitation)
+		assert.Equal(t, 2.0, got[0].TemperatureMin)
+		assert.Equal(t, 7.5, got[0].TemperatureMax)
+		assert.Equal(t, 0.3, got[0].ET0)
+		assert.Equal(t, ProviderName, got[0].Provider)
+		assert.Equal(t, time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), got[1].Date)
+	})
+
+	t.Run("should return error when response is not successful", func(t *testing.T) {
+		// given
+		p := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°."}`))
+		})
+
+		// when
+		got, err := p.GetDaily(context.Background(), 100, 9.46, 1, 2)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when daily variables differ in length", func(t *testing.T) {
+		// given
+		p := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"daily": {"time": ["2025-01-29"], "precipitation_sum": [], "temperature_2m_max": [7.5], "temperature_2m_min": [2.0], "et0_fao_evapotranspiration": [0.3]}}`))
+		})
+
+		// when
+		got, err := p.GetDaily(context.Background(), 54.8, 9.46, 1, 2)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,44 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/weather/file"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/weather/openmeteo"
+)
+
+// NewRepository sets up the configured weather provider. The provider is left empty if the weather is disabled.
+func NewRepository(cfg *config.Config) (*storage.Repository, error) {
+	if !cfg.Weather.Enable {
+		slog.Info("weather is disabled, skipping weather provider")
+		return &storage.Repository{}, nil
+	}
+
+	var provider storage.WeatherProvider
+	switch cfg.Weather.Provider {
+	case openmeteo.ProviderName:
+		hostURL, err := url.Parse(cfg.Weather.OpenMeteo.Host)
+		if err != nil {
+			slog.Error("failed to parse open-meteo host", "error", err, "host", cfg.Weather.OpenMeteo.Host)
+			return nil, err
+		}
+		provider = openmeteo.NewOpenMeteoProvider(openmeteo.WithHostURL(hostURL))
+	case file.ProviderName:
+		if cfg.Weather.File.Source == "" {
+			return nil, errors.New("weather file source is empty")
+		}
+		provider = file.NewFileProvider(cfg.Weather.File.Source)
+	default:
+		return nil, fmt.Errorf("unknown weather provider: %q", cfg.Weather.Provider)
+	}
+
+	slog.Info("successfully initialized weather provider", "service", provider.Name())
+	return &storage.Repository{
+		WeatherProvider: provider,
+	}, nil
+}
@@ -79,3 +79,17 @@ func Float64ToDuration(source float64) time.Duration {
 func DurationToPtrFloat64(source time.Duration) *float64 {
 	return P(float64(source))
 }
+
+func SecondsToDurationPtr(source *int32) *time.Duration {
+	if source == nil {
+		return nil
+	}
+	return P(time.Duration(*source) * time.Second)
+}
+
+func DurationPtrToSeconds(source *time.Duration) *int32 {
+	if source == nil {
+		return nil
+	}
+	return P(int32(source.Seconds()))
+}
@@ -151,3 +151,28 @@ func TestTimeDurationToString(t *testing.T) {
 		assert.Equal(t, "5s", result)
 	})
 }
+
+func TestSecondsToDurationPtr(t *testing.T) {
+	t.Run("should convert seconds to duration", func(t *testing.T) {
+		result := SecondsToDurationPtr(P(int32(3600)))
+		assert.Equal(t, time.Hour, *result)
+	})
+
+	t.Run("should return nil for nil seconds", func(t *testing.T) {
+		result := SecondsToDurationPtr(nil)
+		assert.Nil(t, result)
+	})
+}
+
+func TestDurationPtrToSeconds(t *testing.T) {
+	t.Run("should convert duration to seconds", func(t *testing.T) {
+		duration := 90 * time.Minute
+		result := DurationPtrToSeconds(&duration)
+		assert.Equal(t, int32(5400), *result)
+	})
+
+	t.Run("should return nil for nil duration", func(t *testing.T) {
+		result := DurationPtrToSeconds(nil)
+		assert.Nil(t, result)
+	})
+}
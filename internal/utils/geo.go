@@ -0,0 +1,20 @@
+package utils
+
+import "math"
+
+const earthRadiusInMeters = 6371000
+
+// DistanceInMeters returns the great-circle distance between two coordinates using the haversine formula.
+func DistanceInMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := degreesToRadians(lat2 - lat1)
+	dLng := degreesToRadians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(degreesToRadians(lat1))*math.Cos(degreesToRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusInMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func degreesToRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceInMeters(t *testing.T) {
+	t.Run("should return zero for the same coordinates", func(t *testing.T) {
+		// when
+		result := DistanceInMeters(54.82124518093376, 9.485702120628517, 54.82124518093376, 9.485702120628517)
+
+		// then
+		assert.Zero(t, result)
+	})
+
+	t.Run("should return distance between two coordinates", func(t *testing.T) {
+		// given
+		// Flensburg to Kiel
+		lat1, lng1 := 54.7833, 9.4333
+		lat2, lng2 := 54.3233, 10.1228
+
+		// when
+		result := DistanceInMeters(lat1, lng1, lat2, lng2)
+
+		// then
+		assert.InDelta(t, 67800, result, 500)
+	})
+
+	t.Run("should be symmetric", func(t *testing.T) {
+		// when
+		a := DistanceInMeters(54.7833, 9.4333, 54.3233, 10.1228)
+		b := DistanceInMeters(54.3233, 10.1228, 54.7833, 9.4333)
+
+		// then
+		assert.InDelta(t, a, b, 1e-6)
+	})
+
+	t.Run("should return small distances in meters", func(t *testing.T) {
+		// when
+		// one thousandth of a degree latitude is roughly 111 meters
+		result := DistanceInMeters(54.0, 9.0, 54.001, 9.0)
+
+		// then
+		assert.InDelta(t, 111.2, result, 0.5)
+	})
+}
@@ -26,6 +26,15 @@ func GetValues(ctx context.Context) (page, limit int32, err error) {
 	return page, limit, nil
 }
 
+// WithoutLimit returns a context that requests all entities on one page. It is used by jobs that run without
+// a http request, as the pagination values are otherwise set by the pagination middleware.
+func WithoutLimit(ctx context.Context) context.Context {
+	//nolint:staticcheck // the pagination values are read with the same string keys fiber sets them with
+	ctx = context.WithValue(ctx, "page", int32(1))
+	//nolint:staticcheck // the pagination values are read with the same string keys fiber sets them with
+	return context.WithValue(ctx, "limit", int32(-1))
+}
+
 func Create(ctx context.Context, totalCount int64) *entities.Pagination {
 	page, pageOk := ctx.Value("page").(int32)
 	limit, limitOK := ctx.Value("limit").(int32)
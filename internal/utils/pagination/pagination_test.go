@@ -96,3 +96,15 @@ func TestPaginationUtil_Create(t *testing.T) {
 		assert.Nil(t, pagination)
 	})
 }
+
+func TestPaginationUtil_WithoutLimit(t *testing.T) {
+	t.Run("should return context with first page and no limit", func(t *testing.T) {
+		// when
+		page, limit, err := GetValues(WithoutLimit(context.Background()))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), page)
+		assert.Equal(t, int32(-1), limit)
+	})
+}
@@ -39,6 +39,14 @@ func PgDateToTime(pgDate pgtype.Date) time.Time {
 	return time.Time{}
 }
 
+func PgDateToTimePtr(pgDate pgtype.Date) *time.Time {
+	if !pgDate.Valid {
+		return nil
+	}
+
+	return &pgDate.Time
+}
+
 func TimeToPgDate(date time.Time) (pgtype.Date, error) {
 	if date.IsZero() {
 		return pgtype.Date{}, fmt.Errorf("invalid date: zero value provided")
@@ -81,6 +81,23 @@ func TestPgDateToTime(t *testing.T) {
 	})
 }
 
+func TestPgDateToTimePtr(t *testing.T) {
+	t.Run("should return time pointer from pgtype.Date", func(t *testing.T) {
+		date := pgtype.Date{Time: time.Now(), Valid: true}
+		result := PgDateToTimePtr(date)
+
+		assert.NotNil(t, result)
+		assert.Equal(t, date.Time, *result)
+	})
+
+	t.Run("should return nil for invalid pgtype.Date", func(t *testing.T) {
+		date := pgtype.Date{Valid: false}
+		result := PgDateToTimePtr(date)
+
+		assert.Nil(t, result)
+	})
+}
+
 func TestTimeToPgDate(t *testing.T) {
 	t.Run("Convert current time", func(t *testing.T) {
 		date := time.Now()
@@ -111,3 +111,22 @@ func (s *UpdateWateringPlanSubscriber) HandleEvent(ctx context.Context, e entiti
 
 	return s.tcSvc.HandleUpdateWateringPlan(ctx, &event)
 }
+
+type CreateSensorSubscriber struct {
+	saSvc service.SensorAssignmentService
+}
+
+func NewCreateSensorSubscriber(saSvc service.SensorAssignmentService) *CreateSensorSubscriber {
+	return &CreateSensorSubscriber{
+		saSvc: saSvc,
+	}
+}
+
+func (s *CreateSensorSubscriber) EventType() entities.EventType {
+	return entities.EventTypeCreateSensor
+}
+
+func (s *CreateSensorSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	event := e.(entities.EventCreateSensor)
+	return s.saSvc.HandleCreateSensor(ctx, &event)
+}
@@ -97,4 +97,21 @@ func TestUpdateTreeSubsciber(t *testing.T) {
 			assert.NoError(t, err)
 		})
 	})
+
+	t.Run("should handle create sensor event", func(t *testing.T) {
+		// given
+		saSvc := svcMock.NewMockSensorAssignmentService(t)
+		sub := NewCreateSensorSubscriber(saSvc)
+		event := entities.NewEventCreateSensor(nil)
+
+		saSvc.EXPECT().HandleCreateSensor(mock.Anything, &event).Return(nil)
+
+		assert.NotPanics(t, func() {
+			// when
+			err := sub.HandleEvent(context.Background(), event)
+
+			// then
+			assert.NoError(t, err)
+		})
+	})
 }
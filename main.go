@@ -24,6 +24,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -33,6 +34,7 @@ import (
 	_ "github.com/green-ecolution/green-ecolution-backend/internal/storage/routing/openrouteservice"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/routing/valhalla"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/s3"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/weather"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker/subscriber"
 	"github.com/jackc/pgx/v5"
@@ -81,6 +83,11 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	if len(os.Args) > 1 && os.Args[1] == simulateCommand {
+		runSimulator(ctx, cfg)
+		return
+	}
+
 	startAppServices(ctx, cfg)
 }
 
@@ -115,9 +122,11 @@ func startAppServices(ctx context.Context, cfg *config.Config) {
 
 	em := initializeEventManager()
 
-	services := domain.NewService(cfg, repositories, em)
+	decoders := decoder.NewDefaultRegistry()
+
+	services := domain.NewService(cfg, repositories, em, decoders)
 	httpServer := http.NewServer(cfg, services)
-	mqttServer := mqtt.NewMqtt(cfg, services)
+	mqttServer := mqtt.NewMqtt(cfg, services, decoders)
 
 	runServices(ctx, httpServer, mqttServer, em, services)
 }
@@ -143,21 +152,33 @@ func initializeRepositories(ctx context.Context, cfg *config.Config) (repos *sto
 		panic(err)
 	}
 
+	weatherRepo, err := weather.NewRepository(cfg)
+	if err != nil {
+		panic(err)
+	}
+
 	repositories := &storage.Repository{
 		Auth: keycloakRepo.Auth,
 		User: keycloakRepo.User,
 
-		Info:         localRepo.Info,
-		Sensor:       postgresRepo.Sensor,
-		Tree:         postgresRepo.Tree,
-		TreeCluster:  postgresRepo.TreeCluster,
-		Vehicle:      postgresRepo.Vehicle,
-		Flowerbed:    postgresRepo.Flowerbed,
-		Image:        postgresRepo.Image,
-		Region:       postgresRepo.Region,
-		WateringPlan: postgresRepo.WateringPlan,
-		Routing:      routingRepo.Routing,
-		GpxBucket:    s3Repos.GpxBucket,
+		Info:             localRepo.Info,
+		Sensor:           postgresRepo.Sensor,
+		Tree:             postgresRepo.Tree,
+		TreeCluster:      postgresRepo.TreeCluster,
+		Vehicle:          postgresRepo.Vehicle,
+		Flowerbed:        postgresRepo.Flowerbed,
+		Image:            postgresRepo.Image,
+		Region:           postgresRepo.Region,
+		WateringPlan:     postgresRepo.WateringPlan,
+		DeadLetter:       postgresRepo.DeadLetter,
+		SensorAssignment: postgresRepo.SensorAssignment,
+		SensorCommand:    postgresRepo.SensorCommand,
+		WateringRule:     postgresRepo.WateringRule,
+		Weather:          postgresRepo.Weather,
+		WateringStatus:   postgresRepo.WateringStatus,
+		WeatherProvider:  weatherRepo.WeatherProvider,
+		Routing:          routingRepo.Routing,
+		GpxBucket:        s3Repos.GpxBucket,
 	}
 
 	return repositories, closeFn
@@ -171,6 +192,10 @@ func initializeEventManager() *worker.EventManager {
 		entities.EventTypeDeleteTree,
 		entities.EventTypeNewSensorData,
 		entities.EventTypeUpdateWateringPlan,
+		entities.EventTypeSensorBatteryLow,
+		entities.EventTypeUpdateSensorStatus,
+		entities.EventTypeCreateSensor,
+		entities.EventTypeSensorLocationDrift,
 	)
 }
 
@@ -209,6 +234,7 @@ func runEventSubscriptions(ctx context.Context, wg *sync.WaitGroup, em *worker.E
 		subscriber.NewDeleteTreeSubscriber(services.TreeClusterService),
 		subscriber.NewSensorDataSubscriber(services.TreeClusterService, services.TreeService),
 		subscriber.NewUpdateWateringPlanSubscriber(services.TreeClusterService),
+		subscriber.NewCreateSensorSubscriber(services.SensorAssignmentService),
 	}
 
 	for _, sub := range subscribers {
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/mqtt/decoder"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain"
+	"github.com/green-ecolution/green-ecolution-backend/internal/simulator"
+)
+
+const simulateCommand = "simulate"
+
+// runSimulator publishes simulated sensor uplinks as configured in the simulator section of the config.
+// With the service target the uplinks are processed by the services of this process, including the event subscriptions.
+func runSimulator(ctx context.Context, cfg *config.Config) {
+	simCfg := &cfg.Simulator
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var pub simulator.Publisher
+	switch simCfg.Target {
+	case simulator.TargetService:
+		repositories, closeFn := initializeRepositories(ctx, cfg)
+		defer closeFn()
+
+		em := initializeEventManager()
+		decoders := decoder.NewDefaultRegistry()
+		services := domain.NewService(cfg, repositories, em, decoders)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			em.Run(ctx)
+		}()
+		runEventSubscriptions(ctx, &wg, em, services)
+
+		pub = simulator.NewServicePublisher(services.SensorService, decoders)
+	case simulator.TargetMQTT, "":
+		mqttPub, disconnect, err := simulator.ConnectMQTT(ctx, simCfg)
+		if err != nil {
+			slog.Error("error connecting simulator to mqtt broker", "error", err, "broker", simCfg.Broker)
+			return
+		}
+		defer disconnect()
+
+		pub = mqttPub
+	default:
+		slog.Error("unknown simulator target", "target", simCfg.Target)
+		return
+	}
+
+	sim, err := simulator.NewSimulator(simCfg, pub, time.Now())
+	if err != nil {
+		slog.Error("error creating sensor simulator", "error", err)
+		return
+	}
+
+	sim.Run(ctx)
+
+	// stop the event subscriptions once the configured duration has been simulated
+	cancel()
+	wg.Wait()
+}